@@ -58,6 +58,7 @@ func runACPCommand(args []string) error {
 	fs := flag.NewFlagSet("acp", flag.ContinueOnError)
 	workdir := fs.String("workdir", "", "Working directory (defaults to current directory)")
 	logFile := fs.String("log", "", "Log file for debugging (default: no logging)")
+	defaultModel := fs.String("model", "", "Default model for editor sessions (must be in the model catalog)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -95,6 +96,11 @@ func runACPCommand(args []string) error {
 	}
 	defer store.Close()
 
+	if err := applyACPDefaultModel(cfg, mgr, *defaultModel); err != nil {
+		logf("model error: %v", err)
+		return err
+	}
+
 	// Load project context
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -352,7 +358,7 @@ func getACPSessionState(
 		engine = e
 	}
 
-	conv.AddSystemMessage(buildACPSystemPrompt(projectContext, workDir, skills, engine, ""))
+	conv.AddSystemMessage(buildACPSystemPrompt(projectContext, workDir, skills, engine, "", cfg.SystemPrompt.Footer))
 
 	state := &acpSessionState{
 		conv:       conv,
@@ -365,7 +371,7 @@ func getACPSessionState(
 	return state
 }
 
-func buildACPSystemPrompt(projectContext *projectcontext.ProjectContext, workDir string, skills *skill.Registry, engine *rules.Engine, agentProfile string) string {
+func buildACPSystemPrompt(projectContext *projectcontext.ProjectContext, workDir string, skills *skill.Registry, engine *rules.Engine, agentProfile string, footer string) string {
 	var evaluator *rules.EngineAdapter
 	if engine != nil {
 		evaluator = rules.NewEngineAdapter(engine)
@@ -388,6 +394,7 @@ func buildACPSystemPrompt(projectContext *projectcontext.ProjectContext, workDir
 		WorkDir:           workDir,
 		RootDir:           workDir,
 		SkillsDescription: skillDescriptions,
+		Footer:            footer,
 		TaskType:          "coding",
 	})
 }
@@ -526,8 +533,8 @@ func buildACPModelModes(cfg *config.Config, mgr *model.Manager) *acp.SessionMode
 
 func curatedModelIDs(cfg *config.Config, mgr *model.Manager) []string {
 	var base []string
-	if cfg != nil && len(cfg.Models.Curated) > 0 {
-		base = append([]string{}, cfg.Models.Curated...)
+	if cfg != nil && len(cfg.Models.Curated.IDs()) > 0 {
+		base = cfg.Models.Curated.IDs()
 	} else if cfg != nil {
 		base = []string{
 			cfg.Models.Execution,
@@ -589,6 +596,24 @@ func acpCatalogHasModel(mgr *model.Manager, modelID string) bool {
 	return false
 }
 
+// applyACPDefaultModel makes modelID the execution model for ACP sessions, so
+// it is listed first among the session modes and used when an editor does not
+// pick another. An empty modelID keeps the configured default.
+func applyACPDefaultModel(cfg *config.Config, mgr *model.Manager, modelID string) error {
+	modelID = strings.TrimSpace(modelID)
+	if modelID == "" {
+		return nil
+	}
+	if cfg == nil {
+		return fmt.Errorf("config unavailable")
+	}
+	if !acpCatalogHasModel(mgr, modelID) {
+		return fmt.Errorf("unknown model %q: not in the model catalog", modelID)
+	}
+	cfg.Models.Execution = modelID
+	return nil
+}
+
 func resolveACPModelOverride(cfg *config.Config, mgr *model.Manager, modeID string) string {
 	modeID = strings.TrimSpace(modeID)
 	if modeID == "" || modeID == acpDefaultMode {
@@ -689,10 +714,7 @@ type acpToolTurn struct {
 }
 
 func resolveACPExecutionModel(cfg *config.Config, mgr *model.Manager, engine *rules.Engine, modelOverride string) string {
-	modelID := model.ResolvePhaseModel(cfg, acpReasoningChecker(mgr), engine, "execution", modelOverride)
-	if modelID == "" {
-		return "openai/gpt-4o"
-	}
+	modelID, _ := mgr.ExecutionModelOrDefault(model.ResolvePhaseModel(cfg, acpReasoningChecker(mgr), engine, "execution", modelOverride))
 	return modelID
 }
 
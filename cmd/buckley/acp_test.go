@@ -1,9 +1,12 @@
 package main
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/skill"
@@ -265,6 +268,67 @@ func TestHandleACPUserSkillCommandActivatesSkill(t *testing.T) {
 	}
 }
 
+func TestApplyACPDefaultModelSetsSessionDefault(t *testing.T) {
+	mgr, cfg := newACPStaticCatalogManager(t, "alpha", "beta")
+
+	if err := applyACPDefaultModel(cfg, mgr, "litellm/beta"); err != nil {
+		t.Fatalf("applyACPDefaultModel: %v", err)
+	}
+	modes := buildACPModelModes(cfg, mgr)
+	if modes == nil || modes.CurrentModeID != acpModePrefix+"litellm/beta" {
+		t.Fatalf("modes = %#v, want current mode for litellm/beta", modes)
+	}
+	override := resolveACPModelOverride(cfg, mgr, acpDefaultMode)
+	if got := resolveACPExecutionModel(cfg, mgr, nil, override); got != "litellm/beta" {
+		t.Fatalf("execution model = %q, want litellm/beta", got)
+	}
+	override = resolveACPModelOverride(cfg, mgr, acpModePrefix+"litellm/alpha")
+	if got := resolveACPExecutionModel(cfg, mgr, nil, override); got != "litellm/alpha" {
+		t.Fatalf("execution model with editor mode = %q, want litellm/alpha", got)
+	}
+}
+
+func TestApplyACPDefaultModelRejectsUnknownModel(t *testing.T) {
+	mgr, cfg := newACPStaticCatalogManager(t, "alpha")
+
+	err := applyACPDefaultModel(cfg, mgr, "litellm/missing")
+	if err == nil || !strings.Contains(err.Error(), "litellm/missing") {
+		t.Fatalf("err = %v, want unknown model error", err)
+	}
+	if cfg.Models.Execution != "litellm/alpha" {
+		t.Fatalf("Execution = %q, want unchanged litellm/alpha", cfg.Models.Execution)
+	}
+	if err := applyACPDefaultModel(cfg, mgr, " "); err != nil {
+		t.Fatalf("empty model should keep config default: %v", err)
+	}
+}
+
+func newACPStaticCatalogManager(t *testing.T, models ...string) (*model.Manager, *config.Config) {
+	t.Helper()
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	cfg := config.DefaultConfig()
+	cfg.Models.DefaultProvider = "litellm"
+	cfg.Models.Execution = "litellm/" + models[0]
+	cfg.Models.Planning = cfg.Models.Execution
+	cfg.Models.Review = cfg.Models.Execution
+	cfg.Models.Curated = nil
+	cfg.Models.FallbackChains = map[string][]string{}
+	cfg.Providers.OpenRouter.Enabled = false
+	cfg.Providers.LiteLLM.Enabled = true
+	cfg.Providers.LiteLLM.BaseURL = server.URL
+	cfg.Providers.LiteLLM.Models = models
+	mgr, err := model.NewManager(cfg)
+	if err != nil {
+		t.Fatalf("new model manager: %v", err)
+	}
+	if err := mgr.Initialize(); err != nil {
+		t.Fatalf("initialize model manager: %v", err)
+	}
+	return mgr, cfg
+}
+
 func mustRegisterSkill(t *testing.T, registry *skill.Registry, s *skill.Skill) {
 	t.Helper()
 	if err := registry.Register(s); err != nil {
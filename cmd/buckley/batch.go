@@ -11,7 +11,7 @@ import (
 )
 
 type batchCoordinator interface {
-	CleanupWorkspaces(ctx context.Context, olderThan time.Duration) (int, error)
+	CleanupWorkspacesConcurrent(ctx context.Context, olderThan time.Duration, concurrency int) (int, error)
 }
 
 var batchLoadConfigFn = config.Load
@@ -35,9 +35,13 @@ func runBatchPruneWorkspaces(args []string) error {
 	fs := flag.NewFlagSet("batch prune-workspaces", flag.ContinueOnError)
 	olderThan := fs.Duration("older-than", 4*time.Hour, "Delete task PVCs older than this duration")
 	force := fs.Bool("force", false, "Run even when batch.enabled is false (dangerous)")
+	concurrency := fs.Int("concurrency", 1, "Number of workspace PVCs to delete in parallel")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
+	if *concurrency < 1 {
+		return withExitCode(fmt.Errorf("--concurrency must be at least 1"), 2)
+	}
 
 	cfg, err := batchLoadConfigFn()
 	if err != nil {
@@ -59,10 +63,13 @@ func runBatchPruneWorkspaces(args []string) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
-	deleted, err := coordinator.CleanupWorkspaces(ctx, *olderThan)
-	if err != nil {
+	deleted, err := coordinator.CleanupWorkspacesConcurrent(ctx, *olderThan, *concurrency)
+	if err != nil && deleted == 0 {
 		return err
 	}
 	fmt.Printf("Removed %d task workspace PVCs older than %s\n", deleted, olderThan.String())
+	if err != nil {
+		return fmt.Errorf("some workspaces could not be pruned: %w", err)
+	}
 	return nil
 }
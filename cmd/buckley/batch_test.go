@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -14,7 +15,7 @@ type fakeBatchPruneCoordinator struct {
 	deleted int
 }
 
-func (f *fakeBatchPruneCoordinator) CleanupWorkspaces(_ context.Context, _ time.Duration) (int, error) {
+func (f *fakeBatchPruneCoordinator) CleanupWorkspacesConcurrent(_ context.Context, _ time.Duration, _ int) (int, error) {
 	f.called = true
 	return f.deleted, nil
 }
@@ -86,3 +87,47 @@ func TestRunBatchPruneWorkspacesForceOverridesDisabledConfig(t *testing.T) {
 		t.Fatalf("expected output to include removal count, got %q", out)
 	}
 }
+
+func TestRunBatchPruneWorkspacesReportsPartialFailures(t *testing.T) {
+	oldLoad := batchLoadConfigFn
+	oldNewCoordinator := batchNewCoordinatorFn
+	t.Cleanup(func() {
+		batchLoadConfigFn = oldLoad
+		batchNewCoordinatorFn = oldNewCoordinator
+	})
+
+	fake := &fakeBatchCoordinator{deleted: 2, err: errors.New("deleting workspace pvc-b: forbidden")}
+	batchLoadConfigFn = func() (*config.Config, error) {
+		cfg := config.DefaultConfig()
+		cfg.Batch.Enabled = true
+		return cfg, nil
+	}
+	batchNewCoordinatorFn = func(_ config.BatchConfig) (batchCoordinator, error) {
+		return fake, nil
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = runBatchPruneWorkspaces([]string{"--concurrency", "4"})
+	})
+
+	if fake.concurrency != 4 {
+		t.Fatalf("concurrency=%d want 4", fake.concurrency)
+	}
+	if !strings.Contains(out, "Removed 2 task workspace PVCs") {
+		t.Fatalf("expected partial removal count, got %q", out)
+	}
+	if runErr == nil || !strings.Contains(runErr.Error(), "pvc-b") {
+		t.Fatalf("expected aggregated failure mentioning pvc-b, got %v", runErr)
+	}
+}
+
+func TestRunBatchPruneWorkspacesRejectsInvalidConcurrency(t *testing.T) {
+	err := runBatchPruneWorkspaces([]string{"--concurrency", "0"})
+	if err == nil {
+		t.Fatal("expected error for --concurrency 0")
+	}
+	if got := exitCodeForError(err); got != 2 {
+		t.Fatalf("exitCode=%d want 2", got)
+	}
+}
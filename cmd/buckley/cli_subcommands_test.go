@@ -918,6 +918,55 @@ func TestRunSkillsCommandInitCreatesAgentSkill(t *testing.T) {
 	}
 }
 
+func TestRunSkillsValidateReportsIssuesPerSkill(t *testing.T) {
+	dir := t.TempDir()
+	skillsDir := filepath.Join(dir, "agent", "skills")
+	fixtures := map[string]string{
+		filepath.Join("triage", "SKILL.md"): "---\ndescription: Triage work.\nphase: planning\nallowed_tools: [read_file]\n---\n\nInspect first.\n",
+		"deploy.md":                         "---\nname: deploy\ndescription: Ship it.\nphase: deploy\nallowed_tools: [launch_rockets]\n---\n\nDeploy.\n",
+	}
+	for rel, content := range fixtures {
+		path := filepath.Join(skillsDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = runSkillsCommand([]string{"validate", skillsDir})
+	})
+	if runErr == nil || exitCodeForError(runErr) != 1 {
+		t.Fatalf("expected exit code 1 for invalid skills, got %v", runErr)
+	}
+	for _, want := range []string{"✓ triage", "✗ deploy", `phase: unknown phase "deploy"`, `allowed_tools: unknown tool "launch_rockets"`, "Checked 2 skills: 1 valid, 1 invalid"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("skills validate output missing %q:\n%s", want, out)
+		}
+	}
+
+	jsonOut := captureStdout(t, func() {
+		runErr = runSkillsCommand([]string{"validate", "--json", filepath.Join(skillsDir, "triage")})
+	})
+	if runErr != nil {
+		t.Fatalf("validate valid skill: %v", runErr)
+	}
+	var report skillsValidateReport
+	if err := json.Unmarshal([]byte(jsonOut), &report); err != nil {
+		t.Fatalf("unmarshal validate json: %v\n%s", err, jsonOut)
+	}
+	if report.Checked != 1 || report.Invalid != 0 || report.Skills[0].Name != "triage" {
+		t.Fatalf("unexpected validate report: %+v", report)
+	}
+
+	if err := runSkillsCommand([]string{"validate", filepath.Join(dir, "missing")}); err == nil || exitCodeForError(err) != 2 {
+		t.Fatalf("expected exit code 2 for missing path, got %v", err)
+	}
+}
+
 func TestRunAgentCommandInvalidSpec(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "agent.yaml")
@@ -11,8 +11,10 @@ import (
 	"strings"
 	"time"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/oneshot"
 	"m31labs.dev/buckley/pkg/oneshot/commands"
+	"m31labs.dev/buckley/pkg/prompts"
 	"m31labs.dev/buckley/pkg/terminal"
 	"m31labs.dev/buckley/pkg/transparency"
 )
@@ -290,16 +292,29 @@ func newCommitCommandRuntime(opts commitCommandOptions) (*commitCommandRuntime,
 		backend: opts.backend,
 		modelID: modelID,
 		ledger:  ledger,
-		runner:  &frameworkCommitRunner{framework: framework, def: commitDefinition(opts.paths)},
+		runner:  &frameworkCommitRunner{framework: framework, def: commitDefinition(opts.paths, commitConventions(cfg))},
 	}
 	return runtime, cleanup, nil
 }
 
-func commitDefinition(paths []string) oneshot.Definition {
+func commitDefinition(paths []string, conv prompts.CommitConventions) oneshot.Definition {
+	def := commands.CommitDefinition{Conventions: conv}
 	if len(paths) > 0 {
-		return scopedCommitDefinition{paths: paths}
+		return scopedCommitDefinition{CommitDefinition: def, paths: paths}
+	}
+	return def
+}
+
+// commitConventions maps the commit config section onto prompt conventions.
+func commitConventions(cfg *config.Config) prompts.CommitConventions {
+	if cfg == nil {
+		return prompts.CommitConventions{}
+	}
+	return prompts.CommitConventions{
+		Template:         cfg.Commit.Template,
+		Types:            cfg.Commit.Types,
+		MaxSubjectLength: cfg.Commit.MaxSubjectLength,
 	}
-	return commands.CommitDefinition{}
 }
 
 func runCommitGeneration(ctx context.Context, runner commitRunner) (*commitRunResult, error) {
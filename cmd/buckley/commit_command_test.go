@@ -4,8 +4,10 @@ import (
 	"testing"
 	"time"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/oneshot"
 	"m31labs.dev/buckley/pkg/oneshot/commands"
+	"m31labs.dev/buckley/pkg/prompts"
 )
 
 func TestParseCommitCommandOptions(t *testing.T) {
@@ -86,15 +88,30 @@ func TestParseCommitCommandOptionsHonorsEnvironment(t *testing.T) {
 }
 
 func TestCommitDefinition(t *testing.T) {
-	if _, ok := commitDefinition(nil).(commands.CommitDefinition); !ok {
-		t.Fatalf("commitDefinition(nil) = %T, want commands.CommitDefinition", commitDefinition(nil))
+	if _, ok := commitDefinition(nil, prompts.CommitConventions{}).(commands.CommitDefinition); !ok {
+		t.Fatalf("commitDefinition(nil) = %T, want commands.CommitDefinition", commitDefinition(nil, prompts.CommitConventions{}))
 	}
 
-	scoped, ok := commitDefinition([]string{"a"}).(scopedCommitDefinition)
+	scoped, ok := commitDefinition([]string{"a"}, prompts.CommitConventions{}).(scopedCommitDefinition)
 	if !ok {
-		t.Fatalf("commitDefinition(paths) = %T, want scopedCommitDefinition", commitDefinition([]string{"a"}))
+		t.Fatalf("commitDefinition(paths) = %T, want scopedCommitDefinition", commitDefinition([]string{"a"}, prompts.CommitConventions{}))
 	}
 	if len(scoped.paths) != 1 || scoped.paths[0] != "a" {
 		t.Fatalf("scoped paths = %#v, want [a]", scoped.paths)
 	}
 }
+
+func TestCommitDefinitionCarriesConventions(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Commit.Template = "[{scope}] {subject}"
+	cfg.Commit.Types = []string{"feat", "fix"}
+
+	scoped, ok := commitDefinition([]string{"a"}, commitConventions(cfg)).(scopedCommitDefinition)
+	if !ok {
+		t.Fatalf("commitDefinition(paths) = %T, want scopedCommitDefinition", scoped)
+	}
+	conv := scoped.Conventions
+	if conv.Template != "[{scope}] {subject}" || len(conv.Types) != 2 || conv.MaxSubjectLength != 72 {
+		t.Fatalf("conventions = %#v, want the configured commit section", conv)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+// configCheckReport is what buckley config check inspects, and its --json
+// shape.
+type configCheckReport struct {
+	OK           bool                    `json:"ok"`
+	Error        string                  `json:"error,omitempty"`
+	Files        []configCheckFile       `json:"files"`
+	APIKeys      []configCheckAPIKey     `json:"apiKeys"`
+	Providers    []configCheckProvider   `json:"providers"`
+	Dependencies []configCheckDependency `json:"dependencies"`
+	Warnings     []string                `json:"warnings"`
+
+	// loaded is false when the config failed to load.
+	loaded bool
+}
+
+type configCheckFile struct {
+	Scope string `json:"scope"`
+	Path  string `json:"path"`
+	Found bool   `json:"found"`
+}
+
+type configCheckAPIKey struct {
+	Provider string `json:"provider"`
+	EnvVar   string `json:"envVar"`
+	Set      bool   `json:"set"`
+	Source   string `json:"source,omitempty"`
+}
+
+type configCheckProvider struct {
+	ID    string `json:"id"`
+	Ready bool   `json:"ready"`
+}
+
+type configCheckDependency struct {
+	Name     string `json:"name"`
+	Found    bool   `json:"found"`
+	Required bool   `json:"required"`
+}
+
+// configCheckProviderIDs matches the order of ProviderConfig.ReadyProviders.
+var configCheckProviderIDs = []string{"openrouter", "openai", "anthropic", "google", "ollama", "litellm", "codex"}
+
+// collectConfigCheck gathers the report. The returned error carries exit
+// code 2 when the config fails to load or no provider is ready.
+func collectConfigCheck(load func() (*config.Config, error)) (configCheckReport, error) {
+	report := configCheckReport{
+		Providers: []configCheckProvider{},
+		Warnings:  []string{},
+	}
+
+	home, _ := os.UserHomeDir()
+	for _, file := range []configCheckFile{
+		{Scope: "user", Path: filepath.Join(home, ".buckley", "config.yaml")},
+		{Scope: "project", Path: ".buckley/config.yaml"},
+	} {
+		_, err := os.Stat(file.Path)
+		file.Found = err == nil
+		report.Files = append(report.Files, file)
+	}
+
+	hasKey := false
+	for _, key := range []configCheckAPIKey{
+		{Provider: "OpenRouter", EnvVar: "OPENROUTER_API_KEY"},
+		{Provider: "OpenAI", EnvVar: "OPENAI_API_KEY"},
+		{Provider: "Anthropic", EnvVar: "ANTHROPIC_API_KEY"},
+		{Provider: "Google", EnvVar: "GOOGLE_API_KEY"},
+	} {
+		if os.Getenv(key.EnvVar) != "" {
+			key.Set, key.Source = true, "env"
+			hasKey = true
+		}
+		report.APIKeys = append(report.APIKeys, key)
+	}
+	if !hasKey && checkConfigEnvFile() != "" {
+		report.APIKeys[0].Set, report.APIKeys[0].Source = true, "config.env"
+	}
+
+	_, err := exec.LookPath("git")
+	report.Dependencies = append(report.Dependencies, configCheckDependency{Name: "git", Found: err == nil, Required: true})
+
+	cfg, err := load()
+	if err != nil {
+		report.Error = err.Error()
+		return report, withExitCode(err, 2)
+	}
+	report.loaded = true
+	report.Warnings = append(report.Warnings, cfg.ValidationWarnings()...)
+	ready := make(map[string]bool)
+	for _, id := range cfg.Providers.ReadyProviders() {
+		ready[id] = true
+	}
+	for _, id := range configCheckProviderIDs {
+		report.Providers = append(report.Providers, configCheckProvider{ID: id, Ready: ready[id]})
+	}
+	if !cfg.Providers.HasReadyProvider() {
+		err := fmt.Errorf("no providers configured")
+		report.Error = err.Error()
+		return report, withExitCode(err, 2)
+	}
+	report.OK = true
+	return report, nil
+}
+
+func writeConfigCheckJSON(out io.Writer, report configCheckReport) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+func writeConfigCheckText(out io.Writer, report configCheckReport) {
+	fmt.Fprintln(out, "Checking Buckley configuration...")
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "Configuration files:")
+	for _, file := range report.Files {
+		label := "User config:   "
+		if file.Scope == "project" {
+			label = "Project config:"
+		}
+		if file.Found {
+			fmt.Fprintf(out, "  ✓ %s %s\n", label, file.Path)
+		} else {
+			fmt.Fprintf(out, "  - %s %s (not found)\n", label, file.Path)
+		}
+	}
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "API keys:")
+	for _, key := range report.APIKeys {
+		switch {
+		case key.Source == "config.env":
+			fmt.Fprintf(out, "  ✓ %s: found in ~/.buckley/config.env\n", key.Provider)
+		case key.Set:
+			fmt.Fprintf(out, "  ✓ %s: configured\n", key.Provider)
+		default:
+			fmt.Fprintf(out, "  - %s: not set\n", key.Provider)
+		}
+	}
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "Dependencies:")
+	for _, dep := range report.Dependencies {
+		if dep.Found {
+			fmt.Fprintf(out, "  ✓ %s: installed\n", dep.Name)
+		} else {
+			fmt.Fprintf(out, "  ✗ %s: not found (required)\n", dep.Name)
+		}
+	}
+	fmt.Fprintln(out)
+
+	if !report.loaded {
+		// The caller prints the load error.
+		return
+	}
+	if len(report.Warnings) > 0 {
+		fmt.Fprintln(out, "Warnings:")
+		for _, w := range report.Warnings {
+			fmt.Fprintf(out, "  ⚠ %s\n", w)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if report.OK {
+		fmt.Fprintln(out, "✓ Configuration is valid")
+		return
+	}
+	fmt.Fprintln(out, "✗ No provider configured")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, `To fix: export OPENROUTER_API_KEY="<YOUR_OPENROUTER_API_KEY>"`)
+	fmt.Fprintln(out, "Or enable a local provider (BUCKLEY_OLLAMA_ENABLED=1 or BUCKLEY_LITELLM_ENABLED=1).")
+	fmt.Fprintln(out, "Get a key at: https://openrouter.ai/keys")
+}
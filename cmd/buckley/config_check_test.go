@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func TestConfigCheckReport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"OPENROUTER_API_KEY", "OPENAI_API_KEY", "ANTHROPIC_API_KEY", "GOOGLE_API_KEY", "BUCKLEY_OLLAMA_ENABLED", "BUCKLEY_LITELLM_ENABLED", "BUCKLEY_CODEX_ENABLED"} {
+		t.Setenv(name, "")
+	}
+
+	report, err := collectConfigCheck(config.Load)
+	if exitCodeForError(err) != 2 || report.OK || report.Error != "no providers configured" {
+		t.Fatalf("collectConfigCheck without providers = %+v, %v; want exit code 2", report, err)
+	}
+
+	t.Setenv("OPENAI_API_KEY", "sk-test-openai-key")
+	report, err = collectConfigCheck(config.Load)
+	if err != nil || !report.OK {
+		t.Fatalf("collectConfigCheck with OpenAI key = %+v, %v", report, err)
+	}
+
+	var out bytes.Buffer
+	if err := writeConfigCheckJSON(&out, report); err != nil {
+		t.Fatalf("writeConfigCheckJSON: %v", err)
+	}
+	var decoded struct {
+		OK        bool `json:"ok"`
+		Files     []configCheckFile
+		Providers []configCheckProvider
+		APIKeys   []configCheckAPIKey
+		Warnings  []string
+	}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out.String())
+	}
+	if !decoded.OK || len(decoded.Files) != 2 || decoded.Warnings == nil {
+		t.Fatalf("decoded report = %+v", decoded)
+	}
+	ready := map[string]bool{}
+	for _, p := range decoded.Providers {
+		ready[p.ID] = p.Ready
+	}
+	if !ready["openai"] || ready["anthropic"] {
+		t.Fatalf("provider readiness = %v", ready)
+	}
+
+	out.Reset()
+	writeConfigCheckText(&out, report)
+	for _, want := range []string{"Checking Buckley configuration", "✓ OpenAI: configured", "✓ Configuration is valid"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("text output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestConfigCheckReportLoadError(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	report, err := collectConfigCheck(func() (*config.Config, error) {
+		return nil, errors.New("config validation: bad trust level")
+	})
+	if exitCodeForError(err) != 2 || report.OK || !strings.Contains(report.Error, "bad trust level") {
+		t.Fatalf("collectConfigCheck = %+v, %v", report, err)
+	}
+	var out bytes.Buffer
+	writeConfigCheckText(&out, report)
+	if strings.Contains(out.String(), "Configuration is valid") || strings.Contains(out.String(), "No provider configured") {
+		t.Fatalf("text output should stop after dependencies on load error:\n%s", out.String())
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func runConfigDiffCommand(args []string) error {
+	return runConfigDiff(args, os.Stdout)
+}
+
+// runConfigDiff prints the settings that differ from the built-in defaults,
+// grouped by the layer that set them.
+func runConfigDiff(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("config diff", flag.ContinueOnError)
+	fs.SetOutput(out)
+	jsonOutput := fs.Bool("json", false, "print differences as JSON")
+	if err := fs.Parse(args); err != nil {
+		return withExitCode(err, 2)
+	}
+	if fs.NArg() > 0 {
+		return withExitCode(fmt.Errorf("usage: buckley config diff [--json]"), 2)
+	}
+
+	diffs, err := config.Diff()
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+	}
+	if *jsonOutput {
+		if diffs == nil {
+			diffs = []config.Difference{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diffs)
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(out, "Configuration matches the defaults.")
+		return nil
+	}
+	groups := []struct {
+		source string
+		title  string
+	}{
+		{config.DiffSourceUser, "User config (~/.buckley/config.yaml)"},
+		{config.DiffSourceProject, "Project config (.buckley/config.yaml)"},
+		{config.DiffSourceEnv, "Environment"},
+	}
+	first := true
+	for _, group := range groups {
+		var printed bool
+		for _, d := range diffs {
+			if d.Source != group.source {
+				continue
+			}
+			if !printed {
+				if !first {
+					fmt.Fprintln(out)
+				}
+				fmt.Fprintf(out, "%s:\n", group.title)
+				printed, first = true, false
+			}
+			fmt.Fprintf(out, "  %s: %s (default: %s)\n", d.Key, formatConfigValue(d.Value), formatConfigValue(d.Default))
+		}
+	}
+	return nil
+}
+
+func formatConfigValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "unset"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
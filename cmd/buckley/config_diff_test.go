@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func TestRunConfigDiff(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(".buckley", 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(".buckley", "config.yaml"), []byte("orchestrator:\n  trust_level: conservative\n"), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runConfigDiff(nil, &out); err != nil {
+		t.Fatalf("runConfigDiff: %v", err)
+	}
+	for _, want := range []string{"Project config", "orchestrator.trust_level: conservative (default: balanced)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runConfigDiff([]string{"--json"}, &out); err != nil {
+		t.Fatalf("runConfigDiff(--json): %v", err)
+	}
+	var diffs []config.Difference
+	if err := json.Unmarshal(out.Bytes(), &diffs); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, out.String())
+	}
+	found := false
+	for _, d := range diffs {
+		if d.Key == "orchestrator.trust_level" && d.Source == config.DiffSourceProject {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("json diff missing project trust_level: %+v", diffs)
+	}
+
+	if err := runConfigDiff([]string{"extra"}, &out); exitCodeForError(err) != 2 {
+		t.Fatalf("runConfigDiff(extra) = %v, want usage error", err)
+	}
+}
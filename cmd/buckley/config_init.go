@@ -0,0 +1,347 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/model"
+)
+
+const maxWizardModelChoices = 10
+
+// wizardProvider is one provider offered by config init. EnvVar is where
+// its API key is read from; it is empty for providers that need no key.
+type wizardProvider struct {
+	ID     string
+	Label  string
+	EnvVar string
+}
+
+var wizardProviders = []wizardProvider{
+	{ID: "openrouter", Label: "OpenRouter (many models, one key)", EnvVar: "OPENROUTER_API_KEY"},
+	{ID: "openai", Label: "OpenAI", EnvVar: "OPENAI_API_KEY"},
+	{ID: "anthropic", Label: "Anthropic", EnvVar: "ANTHROPIC_API_KEY"},
+	{ID: "google", Label: "Google Gemini", EnvVar: "GOOGLE_API_KEY"},
+	{ID: "ollama", Label: "Ollama (local models, no key)"},
+}
+
+// configWizard holds the I/O for config init so tests can script it.
+type configWizard struct {
+	in           *bufio.Reader
+	out          io.Writer
+	readSecret   func() (string, error)
+	modelChoices func(cfg *config.Config, providerID string) []string
+}
+
+func runConfigInitCommand(args []string) error {
+	if !stdinIsTerminalFn() {
+		return withExitCode(fmt.Errorf("config init is interactive and stdin is not a terminal; use 'buckley config set <key> <value>' to write settings from scripts"), 2)
+	}
+	w := &configWizard{
+		in:  bufio.NewReader(os.Stdin),
+		out: os.Stdout,
+		readSecret: func() (string, error) {
+			secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println()
+			return string(secret), err
+		},
+		modelChoices: catalogModelChoices,
+	}
+	return w.run(args)
+}
+
+// run prompts for a provider and key, default models, trust level and
+// sandbox mode, writes them with config.SetFileValue, and then reloads the
+// config to confirm a provider is ready. API keys go to
+// ~/.buckley/config.env, never into config.yaml. The provider switch and
+// sandbox mode always go to ~/.buckley/config.yaml, since project config
+// cannot set the sandbox mode.
+func (w *configWizard) run(args []string) error {
+	fs := flag.NewFlagSet("config init", flag.ContinueOnError)
+	fs.SetOutput(w.out)
+	global := fs.Bool("global", false, "write to ~/.buckley/config.yaml instead of the project config")
+	if err := fs.Parse(args); err != nil {
+		return withExitCode(err, 2)
+	}
+	if fs.NArg() > 0 {
+		return withExitCode(fmt.Errorf("usage: buckley config init [--global]"), 2)
+	}
+	path, err := configFilePath(*global)
+	if err != nil {
+		return err
+	}
+	userPath, err := configFilePath(true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w.out, "Buckley configuration wizard")
+	if path == userPath {
+		fmt.Fprintf(w.out, "Settings are written to %s. Press Enter to accept the default in [brackets].\n\n", path)
+	} else {
+		fmt.Fprintf(w.out, "Settings are written to %s; the provider and sandbox mode go to %s. Press Enter to accept the default in [brackets].\n\n", path, userPath)
+	}
+
+	labels := make([]string, len(wizardProviders))
+	for i, p := range wizardProviders {
+		labels[i] = p.Label
+	}
+	choice, err := w.choose("Provider", labels, 0)
+	if err != nil {
+		return err
+	}
+	provider := wizardProviders[choice]
+	if err := w.configureAPIKey(provider); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+	}
+	models := w.modelChoices(cfg, provider.ID)
+	defaultModel := cfg.Models.Execution
+	if len(models) > 0 {
+		defaultModel = models[0]
+	}
+	execution, err := w.chooseModel("Execution model", models, defaultModel)
+	if err != nil {
+		return err
+	}
+	planning, err := w.chooseModel("Planning model", models, execution)
+	if err != nil {
+		return err
+	}
+	review, err := w.chooseModel("Review model", models, execution)
+	if err != nil {
+		return err
+	}
+
+	trustLevels := []string{"conservative", "balanced", "autonomous"}
+	trust, err := w.choose("Trust level", trustLevels, indexOf(trustLevels, cfg.Orchestrator.TrustLevel))
+	if err != nil {
+		return err
+	}
+	sandboxModes := []string{"readonly", "workspace", "strict"}
+	sandboxMode, err := w.choose("Sandbox mode", sandboxModes, indexOf(sandboxModes, "workspace"))
+	if err != nil {
+		return err
+	}
+
+	writes := []struct {
+		path     string
+		settings [][2]string
+	}{
+		{path: userPath, settings: [][2]string{
+			{"providers." + provider.ID + ".enabled", "true"},
+			{"sandbox.mode", sandboxModes[sandboxMode]},
+		}},
+		{path: path, settings: [][2]string{
+			{"models.default_provider", provider.ID},
+			{"models.execution", execution},
+			{"models.planning", planning},
+			{"models.review", review},
+			{"orchestrator.trust_level", trustLevels[trust]},
+		}},
+	}
+	fmt.Fprintln(w.out)
+	for i, write := range writes {
+		for _, setting := range write.settings {
+			if err := config.SetFileValue(write.path, setting[0], setting[1], write.path != userPath); err != nil {
+				return withExitCode(err, 2)
+			}
+		}
+		if i == 0 || write.path != writes[0].path {
+			fmt.Fprintf(w.out, "✓ Wrote %s\n", write.path)
+		}
+	}
+
+	loaded, err := config.Load()
+	if err != nil {
+		return withExitCode(fmt.Errorf("config validation: %w", err), 2)
+	}
+	if !loaded.Providers.HasReadyProvider() {
+		fmt.Fprintln(w.out, "✗ No provider is ready; run 'buckley config check' for details")
+		return withExitCode(fmt.Errorf("no providers configured"), 2)
+	}
+	fmt.Fprintf(w.out, "✓ Ready providers: %s\n", strings.Join(loaded.Providers.ReadyProviders(), ", "))
+	return nil
+}
+
+func (w *configWizard) configureAPIKey(provider wizardProvider) error {
+	if provider.EnvVar == "" {
+		return nil
+	}
+	if strings.TrimSpace(os.Getenv(provider.EnvVar)) != "" {
+		fmt.Fprintf(w.out, "Using %s from the environment.\n\n", provider.EnvVar)
+		return nil
+	}
+	fmt.Fprintf(w.out, "%s API key (input hidden): ", provider.Label)
+	key, err := w.readSecret()
+	if err != nil {
+		return fmt.Errorf("read API key: %w", err)
+	}
+	key = strings.TrimSpace(key)
+	if len(key) < 8 {
+		return withExitCode(errors.New("API key looks too short"), 2)
+	}
+	envPath, err := saveConfigEnvVar(provider.EnvVar, key)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w.out, "Saved %s to %s.\n\n", provider.EnvVar, envPath)
+	return nil
+}
+
+// choose prints a numbered list and returns the picked index. The answer may
+// be a number or one of the options.
+func (w *configWizard) choose(title string, options []string, def int) (int, error) {
+	if def < 0 {
+		def = 0
+	}
+	for {
+		fmt.Fprintf(w.out, "%s:\n", title)
+		for i, option := range options {
+			fmt.Fprintf(w.out, "  %d) %s\n", i+1, option)
+		}
+		answer, err := w.prompt(fmt.Sprintf("Choose [%d]: ", def+1))
+		if err != nil {
+			return 0, err
+		}
+		fmt.Fprintln(w.out)
+		if answer == "" {
+			return def, nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		if i := indexOf(options, answer); i >= 0 {
+			return i, nil
+		}
+		fmt.Fprintf(w.out, "Pick a number from 1 to %d.\n", len(options))
+	}
+}
+
+// chooseModel offers catalog models by number but accepts any model ID.
+func (w *configWizard) chooseModel(title string, models []string, def string) (string, error) {
+	fmt.Fprintf(w.out, "%s:\n", title)
+	for i, id := range models {
+		fmt.Fprintf(w.out, "  %d) %s\n", i+1, id)
+	}
+	if len(models) == 0 {
+		fmt.Fprintln(w.out, "  (catalog unavailable; enter a model ID)")
+	}
+	for {
+		answer, err := w.prompt(fmt.Sprintf("Choose or type a model ID [%s]: ", def))
+		if err != nil {
+			return "", err
+		}
+		switch n, convErr := strconv.Atoi(answer); {
+		case answer == "" && def != "":
+			answer = def
+		case answer == "":
+			fmt.Fprintln(w.out, "A model ID is required.")
+			continue
+		case convErr == nil && n >= 1 && n <= len(models):
+			answer = models[n-1]
+		}
+		fmt.Fprintln(w.out)
+		return answer, nil
+	}
+}
+
+func (w *configWizard) prompt(label string) (string, error) {
+	fmt.Fprint(w.out, label)
+	line, err := w.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", withExitCode(fmt.Errorf("config init aborted: %w", err), 2)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// catalogModelChoices lists tool-capable catalog models for providerID, with
+// the configured defaults first. It returns nil when the catalog cannot be
+// loaded, for example without network access.
+func catalogModelChoices(cfg *config.Config, providerID string) []string {
+	mgr, err := model.NewManager(cfg)
+	if err != nil || mgr.Initialize() != nil {
+		return nil
+	}
+	entries := listCatalogModels(mgr, providerID, "", true)
+	available := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		available[entry.ID] = true
+	}
+	var choices []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && available[id] && !seen[id] && len(choices) < maxWizardModelChoices {
+			seen[id] = true
+			choices = append(choices, id)
+		}
+	}
+	add(cfg.Models.Execution)
+	add(cfg.Models.Planning)
+	for _, entry := range entries {
+		add(entry.ID)
+	}
+	return choices
+}
+
+// saveConfigEnvVar sets name in ~/.buckley/config.env, replacing an existing
+// assignment, and returns the file path.
+func saveConfigEnvVar(name, value string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	dir := filepath.Join(home, ".buckley")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create %s: %w", dir, err)
+	}
+	envPath := filepath.Join(dir, "config.env")
+
+	var lines []string
+	if data, err := os.ReadFile(envPath); err == nil {
+		if trimmed := strings.TrimRight(string(data), "\n"); trimmed != "" {
+			lines = strings.Split(trimmed, "\n")
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	assignment := fmt.Sprintf("export %s=%q", name, value)
+	replaced := false
+	for i, line := range lines {
+		trimmed := strings.TrimPrefix(strings.TrimSpace(line), "export ")
+		if strings.HasPrefix(strings.TrimSpace(trimmed), name+"=") {
+			lines[i] = assignment
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = append(lines, assignment)
+	}
+	if err := os.WriteFile(envPath, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("write %s: %w", envPath, err)
+	}
+	return envPath, nil
+}
+
+func indexOf(values []string, target string) int {
+	for i, value := range values {
+		if value == target {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func TestConfigWizardWritesConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"OPENROUTER_API_KEY", "OPENAI_API_KEY", "ANTHROPIC_API_KEY", "GOOGLE_API_KEY", "BUCKLEY_OLLAMA_ENABLED", "BUCKLEY_LITELLM_ENABLED", "BUCKLEY_CODEX_ENABLED"} {
+		t.Setenv(name, "")
+	}
+
+	// Anthropic, default execution model, second model for planning, a typed
+	// review model, trust level by name, strict sandbox mode.
+	input := "3\n\n2\nanthropic/claude-opus-4-1\nconservative\nstrict\n"
+	var out bytes.Buffer
+	w := &configWizard{
+		in:         bufio.NewReader(strings.NewReader(input)),
+		out:        &out,
+		readSecret: func() (string, error) { return "sk-ant-test-key-123", nil },
+		modelChoices: func(_ *config.Config, providerID string) []string {
+			if providerID != "anthropic" {
+				t.Fatalf("modelChoices provider = %q, want anthropic", providerID)
+			}
+			return []string{"anthropic/claude-sonnet-4-5", "anthropic/claude-haiku-4-5"}
+		},
+	}
+	if err := w.run(nil); err != nil {
+		t.Fatalf("run: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Ready providers: anthropic") {
+		t.Fatalf("output missing ready provider:\n%s", out.String())
+	}
+	if got := os.Getenv("ANTHROPIC_API_KEY"); got != "" {
+		t.Fatalf("wizard exported ANTHROPIC_API_KEY=%q into the process environment", got)
+	}
+
+	env, err := os.ReadFile(filepath.Join(home, ".buckley", "config.env"))
+	if err != nil || !strings.Contains(string(env), `export ANTHROPIC_API_KEY="sk-ant-test-key-123"`) {
+		t.Fatalf("config.env = %q, %v", env, err)
+	}
+	data, err := os.ReadFile(filepath.Join(".buckley", "config.yaml"))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if strings.Contains(string(data), "sk-ant") {
+		t.Fatalf("API key written to config.yaml:\n%s", data)
+	}
+	if strings.Contains(string(data), "sandbox") || strings.Contains(string(data), "providers") {
+		t.Fatalf("user-only settings written to the project config:\n%s", data)
+	}
+	userData, err := os.ReadFile(filepath.Join(home, ".buckley", "config.yaml"))
+	if err != nil || !strings.Contains(string(userData), "mode: strict") || !strings.Contains(string(userData), "enabled: true") {
+		t.Fatalf("user config = %q, %v; want the sandbox mode and provider there", userData, err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	if cfg.Models.Execution != "anthropic/claude-sonnet-4-5" || cfg.Models.Planning != "anthropic/claude-haiku-4-5" || cfg.Models.Review != "anthropic/claude-opus-4-1" {
+		t.Fatalf("models = %s/%s/%s", cfg.Models.Execution, cfg.Models.Planning, cfg.Models.Review)
+	}
+	if cfg.Orchestrator.TrustLevel != "conservative" || cfg.Sandbox.Mode != "strict" {
+		t.Fatalf("trust = %q, sandbox = %q", cfg.Orchestrator.TrustLevel, cfg.Sandbox.Mode)
+	}
+}
+
+func TestSaveConfigEnvVarReplacesExisting(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	envPath := filepath.Join(home, ".buckley", "config.env")
+	if err := os.MkdirAll(filepath.Dir(envPath), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(envPath, []byte("# keys\nexport OPENAI_API_KEY=\"old\"\nOTHER=1\n"), 0o600); err != nil {
+		t.Fatalf("write config.env: %v", err)
+	}
+	if _, err := saveConfigEnvVar("OPENAI_API_KEY", "new-key-value"); err != nil {
+		t.Fatalf("saveConfigEnvVar: %v", err)
+	}
+	data, err := os.ReadFile(envPath)
+	if err != nil {
+		t.Fatalf("read config.env: %v", err)
+	}
+	want := "# keys\nexport OPENAI_API_KEY=\"new-key-value\"\nOTHER=1\n"
+	if string(data) != want {
+		t.Fatalf("config.env = %q, want %q", data, want)
+	}
+}
+
+func TestRunConfigInitRequiresTerminal(t *testing.T) {
+	orig := stdinIsTerminalFn
+	stdinIsTerminalFn = func() bool { return false }
+	t.Cleanup(func() { stdinIsTerminalFn = orig })
+
+	err := runConfigInitCommand(nil)
+	if exitCodeForError(err) != 2 || !strings.Contains(err.Error(), "buckley config set") {
+		t.Fatalf("runConfigInitCommand = %v, want non-interactive error suggesting config set", err)
+	}
+}
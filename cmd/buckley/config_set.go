@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func runConfigSetCommand(args []string) error {
+	return runConfigSet(args, os.Stdout)
+}
+
+// runConfigSet writes one dotted key into the project config, or the user
+// config with --global. Other keys already in the file are kept; keys only
+// the user config can set are refused for the project config.
+func runConfigSet(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("config set", flag.ContinueOnError)
+	fs.SetOutput(out)
+	global := fs.Bool("global", false, "write to ~/.buckley/config.yaml instead of the project config")
+	if err := fs.Parse(args); err != nil {
+		return withExitCode(err, 2)
+	}
+	rest := fs.Args()
+	// Allow the flag after the key and value too.
+	if len(rest) > 2 {
+		if err := fs.Parse(rest[2:]); err != nil {
+			return withExitCode(err, 2)
+		}
+		rest = append([]string{rest[0], rest[1]}, fs.Args()...)
+	}
+	if len(rest) != 2 {
+		return withExitCode(fmt.Errorf("usage: buckley config set [--global] <key> <value>"), 2)
+	}
+
+	path, err := configFilePath(*global)
+	if err != nil {
+		return err
+	}
+	if err := config.SetFileValue(path, rest[0], rest[1], !*global); err != nil {
+		return withExitCode(err, 2)
+	}
+	fmt.Fprintf(out, "✓ Set %s in %s\n", rest[0], path)
+	return nil
+}
+
+// configFilePath returns the project config path, or the user config path
+// when global is set.
+func configFilePath(global bool) (string, error) {
+	if !global {
+		return filepath.Join(".buckley", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".buckley", "config.yaml"), nil
+}
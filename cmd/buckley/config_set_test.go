@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigSet(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+
+	var out bytes.Buffer
+	if err := runConfigSet([]string{"models.execution", "z-ai/glm-5.2"}, &out); err != nil {
+		t.Fatalf("runConfigSet(project): %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(".buckley", "config.yaml"))
+	if err != nil || !strings.Contains(string(data), "execution: z-ai/glm-5.2") {
+		t.Fatalf("project config = %q, %v", data, err)
+	}
+
+	if err := runConfigSet([]string{"approval.mode", "safe", "--global"}, &out); err != nil {
+		t.Fatalf("runConfigSet(--global): %v", err)
+	}
+	data, err = os.ReadFile(filepath.Join(home, ".buckley", "config.yaml"))
+	if err != nil || !strings.Contains(string(data), "mode: safe") {
+		t.Fatalf("user config = %q, %v", data, err)
+	}
+
+	if err := runConfigSet([]string{"approval.mode", "yolo"}, &out); exitCodeForError(err) != 2 || !strings.Contains(err.Error(), "user config") {
+		t.Fatalf("runConfigSet(project approval.mode) = %v, want exit code 2 pointing at the user config", err)
+	}
+	if err := runConfigSet([]string{"models.exectuion", "x"}, &out); exitCodeForError(err) != 2 {
+		t.Fatalf("runConfigSet(unknown key) = %v, want exit code 2", err)
+	}
+	if err := runConfigSet([]string{"models.execution"}, &out); exitCodeForError(err) != 2 {
+		t.Fatalf("runConfigSet(missing value) = %v, want usage error", err)
+	}
+}
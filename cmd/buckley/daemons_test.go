@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -23,15 +24,17 @@ import (
 )
 
 type fakeBatchCoordinator struct {
-	called    bool
-	olderThan time.Duration
-	deleted   int
-	err       error
+	called      bool
+	olderThan   time.Duration
+	concurrency int
+	deleted     int
+	err         error
 }
 
-func (f *fakeBatchCoordinator) CleanupWorkspaces(ctx context.Context, olderThan time.Duration) (int, error) {
+func (f *fakeBatchCoordinator) CleanupWorkspacesConcurrent(ctx context.Context, olderThan time.Duration, concurrency int) (int, error) {
 	f.called = true
 	f.olderThan = olderThan
+	f.concurrency = concurrency
 	return f.deleted, f.err
 }
 
@@ -194,6 +197,54 @@ func TestRunGitWebhookCommandDefaultsToLoopback(t *testing.T) {
 	}
 }
 
+func TestRunGitWebhookCommandProvider(t *testing.T) {
+	origLoad := gitWebhookLoadConfigFn
+	origListen := gitWebhookListenFn
+	t.Cleanup(func() {
+		gitWebhookLoadConfigFn = origLoad
+		gitWebhookListenFn = origListen
+	})
+
+	cfg := config.DefaultConfig()
+	cfg.GitEvents.Enabled = true
+	cfg.GitEvents.Provider = "gitlab"
+	cfg.GitEvents.Secret = "token123"
+	gitWebhookLoadConfigFn = func() (*config.Config, error) {
+		return cfg, nil
+	}
+
+	var handler http.Handler
+	gitWebhookListenFn = func(addr string, h http.Handler) error {
+		handler = h
+		return nil
+	}
+
+	if err := runGitWebhookCommand(nil); err != nil {
+		t.Fatalf("runGitWebhookCommand: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"object_kind":"push"}`))
+	req.Header.Set("X-Gitlab-Token", "token123")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("gitlab token status=%d want %d", w.Code, http.StatusAccepted)
+	}
+
+	if err := runGitWebhookCommand([]string{"--provider", "github"}); err != nil {
+		t.Fatalf("runGitWebhookCommand: %v", err)
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("github with gitlab token status=%d want %d", w.Code, http.StatusUnauthorized)
+	}
+
+	err := runGitWebhookCommand([]string{"--provider", "bitbucket"})
+	if code := exitCodeForError(err); err == nil || code != 2 {
+		t.Fatalf("err=%v exitCode=%d want exit code 2", err, code)
+	}
+}
+
 type stubACPClient struct{}
 
 func (stubACPClient) StreamInlineCompletions(context.Context, *acppb.InlineCompletionRequest, ...grpc.CallOption) (acppb.AgentCommunication_StreamInlineCompletionsClient, error) {
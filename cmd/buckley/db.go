@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
 )
 
 func runDBCommand(args []string) error {
@@ -21,8 +23,10 @@ func runDBCommand(args []string) error {
 		return runDBBackup(args[1:])
 	case "restore":
 		return runDBRestore(args[1:])
+	case "prune-sessions":
+		return runDBPruneSessions(args[1:])
 	default:
-		return fmt.Errorf("usage: buckley db <backup|restore> [flags]")
+		return fmt.Errorf("usage: buckley db <backup|restore|prune-sessions> [flags]")
 	}
 }
 
@@ -162,6 +166,79 @@ func runDBRestore(args []string) error {
 	return nil
 }
 
+func runDBPruneSessions(args []string) error {
+	fs := flag.NewFlagSet("db prune-sessions", flag.ContinueOnError)
+	beforeFlag := fs.String("before", "", "Delete sessions last active before this date (YYYY-MM-DD or RFC 3339, required)")
+	dbPathFlag := fs.String("db", "", "DB path (defaults to BUCKLEY_DB_PATH/BUCKLEY_DATA_DIR)")
+	dryRun := fs.Bool("dry-run", false, "List the sessions that would be deleted without deleting them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(*beforeFlag) == "" {
+		return fmt.Errorf("usage: buckley db prune-sessions --before <date> [--dry-run]")
+	}
+	cutoff, err := parsePruneCutoff(*beforeFlag)
+	if err != nil {
+		return err
+	}
+
+	dbPath := strings.TrimSpace(*dbPathFlag)
+	if dbPath == "" {
+		resolved, err := resolveDBPath()
+		if err != nil {
+			return err
+		}
+		dbPath = resolved
+	}
+	store, err := storage.New(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer store.Close()
+
+	sessions, err := store.ListSessions(-1)
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+	pruned := 0
+	for _, sess := range sessions {
+		// A session's status stays active after its process exits, so only
+		// last activity decides; a live session keeps touching last_active.
+		if !sess.LastActive.Before(cutoff) {
+			continue
+		}
+		if *dryRun {
+			fmt.Printf("would delete %s (%s, last active %s)\n", sess.ID, sess.Status, sess.LastActive.Format(time.RFC3339))
+			pruned++
+			continue
+		}
+		if err := store.DeleteSession(sess.ID); err != nil {
+			return fmt.Errorf("delete session %s: %w", sess.ID, err)
+		}
+		pruned++
+	}
+
+	if *dryRun {
+		fmt.Printf("%d sessions would be deleted\n", pruned)
+		return nil
+	}
+	fmt.Printf("✅ Deleted %d sessions last active before %s\n", pruned, cutoff.Format(time.RFC3339))
+	return nil
+}
+
+func parsePruneCutoff(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+	ts, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --before %q: use YYYY-MM-DD or RFC 3339", value)
+	}
+	return ts, nil
+}
+
 func vacuumInto(dbPath string, outPath string) error {
 	dbPath = strings.TrimSpace(dbPath)
 	outPath = strings.TrimSpace(outPath)
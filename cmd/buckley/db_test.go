@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"m31labs.dev/buckley/pkg/storage"
 )
@@ -61,3 +62,51 @@ func TestDBBackupAndRestore(t *testing.T) {
 		t.Fatalf("expected .bak file in %s", filepath.Dir(restorePath))
 	}
 }
+
+func TestDBPruneSessions(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "buckley.db")
+	store, err := storage.New(dbPath)
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	old := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, sess := range []storage.Session{
+		{ID: "old-done", CreatedAt: old, LastActive: old, Status: storage.SessionStatusCompleted},
+		{ID: "old-active", CreatedAt: old, LastActive: old, Status: storage.SessionStatusActive},
+		{ID: "recent", CreatedAt: time.Now(), LastActive: time.Now(), Status: storage.SessionStatusCompleted},
+		{ID: "recent-active", CreatedAt: time.Now(), LastActive: time.Now(), Status: storage.SessionStatusActive},
+	} {
+		sess := sess
+		if err := store.CreateSession(&sess); err != nil {
+			t.Fatalf("CreateSession: %v", err)
+		}
+	}
+	_ = store.Close()
+
+	if err := runDBPruneSessions([]string{"--db", dbPath}); err == nil {
+		t.Fatalf("expected --before to be required")
+	}
+	if err := runDBPruneSessions([]string{"--db", dbPath, "--before", "2025-06-01", "--dry-run"}); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if err := runDBPruneSessions([]string{"--db", dbPath, "--before", "2025-06-01"}); err != nil {
+		t.Fatalf("runDBPruneSessions: %v", err)
+	}
+
+	store, err = storage.New(dbPath)
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	defer store.Close()
+	sessions, err := store.ListSessions(-1)
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	remaining := map[string]bool{}
+	for _, sess := range sessions {
+		remaining[sess.ID] = true
+	}
+	if remaining["old-done"] || remaining["old-active"] || !remaining["recent"] || !remaining["recent-active"] {
+		t.Fatalf("unexpected sessions after prune: %v", remaining)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"m31labs.dev/buckley/pkg/dream"
+	"m31labs.dev/buckley/pkg/worktree"
 )
 
 // runDreamCommand analyzes a codebase and suggests architectural improvements.
@@ -14,6 +17,7 @@ func runDreamCommand(args []string) error {
 	fs := flag.NewFlagSet("dream", flag.ContinueOnError)
 	rootDir := fs.String("dir", "", "Root directory to analyze (default: current directory)")
 	showIdeas := fs.Bool("ideas", false, "Generate improvement ideas based on analysis")
+	compareRef := fs.String("compare", "", "Compare gaps with the analysis of this git ref")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -38,6 +42,18 @@ func runDreamCommand(args []string) error {
 		return fmt.Errorf("analysis failed: %w", err)
 	}
 
+	var comparison *dream.GapComparison
+	ref := strings.TrimSpace(*compareRef)
+	if ref != "" {
+		fmt.Printf("Analyzing %s for comparison...\n\n", ref)
+		base, err := analyzeDreamRef(dir, ref)
+		if err != nil {
+			return fmt.Errorf("compare with %s: %w", ref, err)
+		}
+		result := dream.CompareGaps(base.Gaps, analysis.Gaps)
+		comparison = &result
+	}
+
 	// Display results
 	fmt.Println("📊 Codebase Analysis")
 	fmt.Println(strings.Repeat("─", 50))
@@ -69,20 +85,15 @@ func runDreamCommand(args []string) error {
 		}
 	}
 
-	if len(analysis.Gaps) > 0 {
+	if comparison != nil {
+		fmt.Printf("\n🔀 Gaps compared with %s:\n", ref)
+		printDreamGapGroup("New", comparison.New)
+		printDreamGapGroup("Resolved", comparison.Resolved)
+		printDreamGapGroup("Persistent", comparison.Persistent)
+	} else if len(analysis.Gaps) > 0 {
 		fmt.Printf("\n⚠️  Gaps Identified:\n")
 		for _, gap := range analysis.Gaps {
-			icon := "○"
-			switch gap.Severity {
-			case "critical":
-				icon = "🔴"
-			case "important":
-				icon = "🟡"
-			case "nice-to-have":
-				icon = "🟢"
-			}
-			fmt.Printf("   %s [%s] %s: %s\n", icon, gap.Category, gap.Severity, gap.Description)
-			fmt.Printf("      → %s\n", gap.Suggestion)
+			printDreamGap(gap)
 		}
 	} else {
 		fmt.Println("\n✅ No significant gaps detected!")
@@ -104,3 +115,80 @@ func runDreamCommand(args []string) error {
 
 	return nil
 }
+
+func printDreamGap(gap dream.Gap) {
+	icon := "○"
+	switch gap.Severity {
+	case "critical":
+		icon = "🔴"
+	case "important":
+		icon = "🟡"
+	case "nice-to-have":
+		icon = "🟢"
+	}
+	fmt.Printf("   %s [%s] %s: %s\n", icon, gap.Category, gap.Severity, gap.Description)
+	fmt.Printf("      → %s\n", gap.Suggestion)
+}
+
+func printDreamGapGroup(label string, gaps []dream.Gap) {
+	fmt.Printf("\n   %s (%d):\n", label, len(gaps))
+	if len(gaps) == 0 {
+		fmt.Println("   (none)")
+		return
+	}
+	for _, gap := range gaps {
+		printDreamGap(gap)
+	}
+}
+
+// analyzeDreamRef runs the dream analysis on ref, checked out in a temporary
+// detached worktree that is removed afterwards. When dir is below the repo
+// root, the same subdirectory of the checkout is analyzed.
+func analyzeDreamRef(dir, ref string) (*dream.CodebaseAnalysis, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s is not in a git repository", dir)
+	}
+	repoRoot := strings.TrimSpace(string(out))
+	// git reports the root with symlinks resolved, so resolve dir the same way
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(absDir); err == nil {
+		absDir = resolved
+	}
+	rel, err := filepath.Rel(repoRoot, absDir)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.MkdirTemp("", "buckley-dream-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(root)
+
+	manager, err := worktree.NewManager(repoRoot, root)
+	if err != nil {
+		return nil, err
+	}
+	const worktreeName = "dream-compare"
+	wt, err := manager.CreateDetached(worktreeName, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := manager.Remove(worktreeName, false); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to remove comparison worktree: %v\n", err)
+		}
+	}()
+
+	target := filepath.Join(wt.Path, rel)
+	if _, err := os.Stat(target); err != nil {
+		return nil, fmt.Errorf("%s does not exist at %s", rel, ref)
+	}
+	return dream.NewAnalyzer(target).Analyze()
+}
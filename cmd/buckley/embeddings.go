@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/embeddings"
+	"m31labs.dev/buckley/pkg/paths"
+)
+
+func runEmbeddingsCommand(args []string) error {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.TrimSpace(args[0])
+	}
+	switch sub {
+	case "cache":
+		return runEmbeddingsCacheCommand(args[1:], os.Stdout)
+	default:
+		return fmt.Errorf("usage: buckley embeddings cache <status|clear> [--dir <path>]")
+	}
+}
+
+func runEmbeddingsCacheCommand(args []string, out io.Writer) error {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.TrimSpace(args[0])
+	}
+	if sub != "status" && sub != "clear" {
+		return fmt.Errorf("usage: buckley embeddings cache <status|clear> [--dir <path>]")
+	}
+
+	fs := flag.NewFlagSet("embeddings cache "+sub, flag.ContinueOnError)
+	dirFlag := fs.String("dir", "", "Embedding cache directory (defaults to BUCKLEY_EMBEDDINGS_CACHE_DIR/BUCKLEY_DATA_DIR)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	dir := strings.TrimSpace(*dirFlag)
+	if dir == "" {
+		dir = paths.BuckleyEmbeddingsCacheDir()
+	} else {
+		expanded, err := expandHomePath(dir)
+		if err != nil {
+			return err
+		}
+		dir = expanded
+	}
+
+	cache := embeddings.NewCache(dir)
+	before, err := cache.Stats()
+	if err != nil {
+		return fmt.Errorf("read embedding cache: %w", err)
+	}
+
+	if sub == "status" {
+		fmt.Fprintf(out, "Embedding cache: %s\n", cache.Dir())
+		fmt.Fprintf(out, "  Entries: %d\n", before.Entries)
+		fmt.Fprintf(out, "  Size:    %s\n", formatCacheBytes(before.Bytes))
+		return nil
+	}
+
+	if err := cache.Clear(); err != nil {
+		return fmt.Errorf("clear embedding cache: %w", err)
+	}
+	fmt.Fprintf(out, "✅ Cleared %d cached embeddings (%s) from %s\n", before.Entries, formatCacheBytes(before.Bytes), cache.Dir())
+	return nil
+}
+
+func formatCacheBytes(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	if n < 1024*1024 {
+		return fmt.Sprintf("%.1f KiB", float64(n)/1024)
+	}
+	return fmt.Sprintf("%.1f MiB", float64(n)/(1024*1024))
+}
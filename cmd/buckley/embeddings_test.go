@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/embeddings"
+	"m31labs.dev/buckley/pkg/paths"
+)
+
+func TestEmbeddingsCacheStatusAndClear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "embeddings")
+	cache := embeddings.NewCache(dir)
+	if err := cache.Set("a", []float64{0.1, 0.2}); err != nil {
+		t.Fatalf("cache.Set: %v", err)
+	}
+	if err := cache.Set("b", []float64{0.3}); err != nil {
+		t.Fatalf("cache.Set: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runEmbeddingsCacheCommand([]string{"status", "--dir", dir}, &out); err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if !strings.Contains(out.String(), "Entries: 2") {
+		t.Fatalf("expected 2 entries in status output, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runEmbeddingsCacheCommand([]string{"clear", "--dir", dir}, &out); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if !strings.Contains(out.String(), "Cleared 2 cached embeddings") {
+		t.Fatalf("unexpected clear output:\n%s", out.String())
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected cache dir to be empty after clear, found %d entries", len(entries))
+	}
+}
+
+func TestEmbeddingsCacheUsesDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(paths.EnvBuckleyEmbeddingsCacheDir, "")
+	t.Setenv(envBuckleyDataDir, "~/data")
+
+	var out bytes.Buffer
+	if err := runEmbeddingsCacheCommand([]string{"status"}, &out); err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	want := filepath.Join(home, "data", "embeddings")
+	if !strings.Contains(out.String(), want) || !strings.Contains(out.String(), "Entries: 0") {
+		t.Fatalf("expected empty cache at %s, got:\n%s", want, out.String())
+	}
+}
+
+func TestEmbeddingsCacheRejectsUnknownSubcommand(t *testing.T) {
+	if err := runEmbeddingsCacheCommand([]string{"purge"}, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected usage error for unknown subcommand")
+	}
+}
@@ -16,8 +16,10 @@ import (
 	"m31labs.dev/buckley/pkg/config"
 	projectcontext "m31labs.dev/buckley/pkg/context"
 	"m31labs.dev/buckley/pkg/experiment"
+	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/notify"
 	"m31labs.dev/buckley/pkg/storage"
+	"m31labs.dev/buckley/pkg/telemetry"
 	"m31labs.dev/buckley/pkg/worktree"
 )
 
@@ -97,24 +99,6 @@ func runExperimentRun(args []string) error {
 		return withExitCode(fmt.Errorf("experiments are disabled (set experiment.enabled=true or BUCKLEY_EXPERIMENT_ENABLED=1)"), 2)
 	}
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-	projectCtx, err := projectcontext.NewLoader(cwd).Load()
-	if err != nil {
-		return err
-	}
-
-	root := strings.TrimSpace(cfg.Experiment.WorktreeRoot)
-	if root == "" {
-		root = cfg.Worktrees.RootPath
-	}
-	worktreeManager, err := worktree.NewManager(cwd, root)
-	if err != nil {
-		return err
-	}
-
 	exp := experiment.Experiment{
 		ID:   ulid.Make().String(),
 		Name: name,
@@ -146,24 +130,7 @@ func runExperimentRun(args []string) error {
 	}
 	exp.Criteria = criteria
 
-	runnerCfg := experiment.RunnerConfig{
-		MaxConcurrent:  cfg.Experiment.MaxConcurrent,
-		DefaultTimeout: cfg.Experiment.DefaultTimeout,
-		CleanupOnDone:  cfg.Experiment.CleanupOnDone,
-	}
-	if *maxConcurrent > 0 {
-		runnerCfg.MaxConcurrent = *maxConcurrent
-	}
-
-	notifyMgr := buildNotifyManager(cfg)
-	runner, err := experiment.NewRunner(runnerCfg, experiment.Dependencies{
-		Config:         cfg,
-		ModelManager:   mgr,
-		ProjectContext: projectCtx,
-		Notify:         notifyMgr,
-		Worktree:       worktreeManager,
-		Store:          experiment.NewStoreFromStorage(store),
-	})
+	runner, err := newExperimentRunner(cfg, mgr, store, nil, *maxConcurrent)
 	if err != nil {
 		return err
 	}
@@ -519,38 +486,7 @@ func runExperimentReplay(args []string) error {
 		return withExitCode(fmt.Errorf("experiments are disabled (set experiment.enabled=true or BUCKLEY_EXPERIMENT_ENABLED=1)"), 2)
 	}
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-	projectCtx, err := projectcontext.NewLoader(cwd).Load()
-	if err != nil {
-		return err
-	}
-
-	root := strings.TrimSpace(cfg.Experiment.WorktreeRoot)
-	if root == "" {
-		root = cfg.Worktrees.RootPath
-	}
-	worktreeManager, err := worktree.NewManager(cwd, root)
-	if err != nil {
-		return err
-	}
-
-	runnerCfg := experiment.RunnerConfig{
-		MaxConcurrent:  cfg.Experiment.MaxConcurrent,
-		DefaultTimeout: cfg.Experiment.DefaultTimeout,
-		CleanupOnDone:  cfg.Experiment.CleanupOnDone,
-	}
-	notifyMgr := buildNotifyManager(cfg)
-	runner, err := experiment.NewRunner(runnerCfg, experiment.Dependencies{
-		Config:         cfg,
-		ModelManager:   mgr,
-		ProjectContext: projectCtx,
-		Notify:         notifyMgr,
-		Worktree:       worktreeManager,
-		Store:          experiment.NewStoreFromStorage(store),
-	})
+	runner, err := newExperimentRunner(cfg, mgr, store, nil, 0)
 	if err != nil {
 		return err
 	}
@@ -588,6 +524,49 @@ func runExperimentReplay(args []string) error {
 	return err
 }
 
+// newExperimentRunner builds a runner for the current project. Worktrees go
+// under experiment.worktree_root (or worktrees.root_path), and progress is
+// published to hub when it is non-nil. maxConcurrent overrides
+// experiment.max_concurrent when positive.
+func newExperimentRunner(cfg *config.Config, mgr *model.Manager, store *storage.Store, hub *telemetry.Hub, maxConcurrent int) (*experiment.Runner, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	projectCtx, err := projectcontext.NewLoader(cwd).Load()
+	if err != nil {
+		return nil, err
+	}
+
+	root := strings.TrimSpace(cfg.Experiment.WorktreeRoot)
+	if root == "" {
+		root = cfg.Worktrees.RootPath
+	}
+	worktreeManager, err := worktree.NewManager(cwd, root)
+	if err != nil {
+		return nil, err
+	}
+
+	runnerCfg := experiment.RunnerConfig{
+		MaxConcurrent:  cfg.Experiment.MaxConcurrent,
+		DefaultTimeout: cfg.Experiment.DefaultTimeout,
+		CleanupOnDone:  cfg.Experiment.CleanupOnDone,
+	}
+	if maxConcurrent > 0 {
+		runnerCfg.MaxConcurrent = maxConcurrent
+	}
+
+	return experiment.NewRunner(runnerCfg, experiment.Dependencies{
+		Config:         cfg,
+		ModelManager:   mgr,
+		ProjectContext: projectCtx,
+		Notify:         buildNotifyManager(cfg),
+		Worktree:       worktreeManager,
+		Store:          experiment.NewStoreFromStorage(store),
+		Telemetry:      hub,
+	})
+}
+
 func extractExperimentName(args []string) (string, []string) {
 	if len(args) == 0 {
 		return "", args
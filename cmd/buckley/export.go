@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+const exportUsage = "usage: buckley export --all [--format markdown|json|html] [--include-tools] [--include-system] [--include-metadata] [--since <date>] [--dir <path> | --output <file>]"
+
+// exportRequest is a parsed buckley export invocation.
+type exportRequest struct {
+	Options conversation.ExportOptions
+	Since   time.Time
+	Dir     string
+	Output  string
+}
+
+func runExportCommand(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+	}
+	req, err := parseExportArgs(args, cfg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := resolveDBPath()
+	if err != nil {
+		return err
+	}
+	store, err := storage.New(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer store.Close()
+
+	count, err := runExport(store, req, os.Stdout, time.Now())
+	if err != nil {
+		return err
+	}
+	target := req.Output
+	if req.Dir != "" {
+		target = req.Dir
+	}
+	if target != "-" {
+		fmt.Fprintf(os.Stderr, "Exported %d sessions to %s\n", count, target)
+	}
+	return nil
+}
+
+func parseExportArgs(args []string, cfg *config.Config, now time.Time) (exportRequest, error) {
+	var defaults config.ExportConfig
+	if cfg != nil {
+		defaults = cfg.Export
+	}
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	all := fs.Bool("all", false, "Export every stored session")
+	formatFlag := fs.String("format", defaults.DefaultFormat, "Output format: markdown, json, or html")
+	includeTools := fs.Bool("include-tools", defaults.IncludeTools, "Include tool calls and tool results")
+	includeSystem := fs.Bool("include-system", defaults.IncludeSystem, "Include system messages")
+	includeMetadata := fs.Bool("include-metadata", false, "Include model, branch, timestamps, and usage for each session")
+	sinceFlag := fs.String("since", "", "Only export sessions active since a date (2006-01-02 or RFC3339) or window (e.g. 7d)")
+	dir := fs.String("dir", "", "Write one file per session into this directory")
+	output := fs.String("output", "-", "Write a single combined file (- for stdout)")
+	if err := fs.Parse(args); err != nil {
+		return exportRequest{}, withExitCode(err, 2)
+	}
+	if !*all || fs.NArg() > 0 {
+		return exportRequest{}, withExitCode(fmt.Errorf(exportUsage), 2)
+	}
+
+	format, err := conversation.ParseExportFormat(*formatFlag)
+	if err != nil {
+		return exportRequest{}, withExitCode(err, 2)
+	}
+	if format == "" {
+		format = conversation.ExportMarkdown
+	}
+	since, err := parseExportSince(*sinceFlag, now)
+	if err != nil {
+		return exportRequest{}, withExitCode(err, 2)
+	}
+	req := exportRequest{
+		Options: conversation.ExportOptions{
+			Format:          format,
+			IncludeSystem:   *includeSystem,
+			IncludeTools:    *includeTools,
+			IncludeMetadata: *includeMetadata,
+		},
+		Since:  since,
+		Dir:    strings.TrimSpace(*dir),
+		Output: strings.TrimSpace(*output),
+	}
+	outputSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "output" {
+			outputSet = true
+		}
+	})
+	if req.Dir != "" && outputSet {
+		return exportRequest{}, withExitCode(fmt.Errorf("--dir and --output cannot be combined"), 2)
+	}
+	if req.Dir == "" && format == conversation.ExportHTML {
+		return exportRequest{}, withExitCode(fmt.Errorf("--format html needs --dir; a combined export is markdown or json"), 2)
+	}
+	if req.Output == "" {
+		req.Output = "-"
+	}
+	return req, nil
+}
+
+// parseExportSince accepts a calendar date, an RFC3339 timestamp, or any
+// window parseStatsSince understands.
+func parseExportSince(raw string, now time.Time) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", raw, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	since, err := parseStatsSince(raw, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use a date such as 2026-01-31, an RFC3339 time, or a window such as 7d)", raw)
+	}
+	return since, nil
+}
+
+// runExport writes the selected sessions and returns how many were
+// exported. Sessions are loaded and written one at a time so an export of
+// the whole database never holds more than one conversation in memory.
+func runExport(store *storage.Store, req exportRequest, stdout io.Writer, now time.Time) (int, error) {
+	sessions, err := store.ListSessions(-1)
+	if err != nil {
+		return 0, fmt.Errorf("list sessions: %w", err)
+	}
+	exporter := conversation.NewExporter(req.Options)
+
+	if req.Dir != "" {
+		if err := os.MkdirAll(req.Dir, 0o755); err != nil {
+			return 0, fmt.Errorf("create export directory: %w", err)
+		}
+		ext := conversation.ExportExtension(req.Options.Format)
+		used := map[string]bool{}
+		return forEachExportSession(store, sessions, req.Since, exporter, func(session *storage.Session, messages []conversation.Message) error {
+			path := filepath.Join(req.Dir, uniqueExportFileName(exportFileName(session.ID), ext, used))
+			return writeExportFile(path, func(w io.Writer) error {
+				return exporter.WriteSession(w, session, messages, now)
+			})
+		})
+	}
+
+	var count int
+	write := func(w io.Writer) error {
+		archive, err := exporter.NewArchive(w, now)
+		if err != nil {
+			return err
+		}
+		count, err = forEachExportSession(store, sessions, req.Since, exporter, archive.Add)
+		if err != nil {
+			return err
+		}
+		return archive.Close()
+	}
+	if req.Output == "-" {
+		buffered := bufio.NewWriter(stdout)
+		if err := write(buffered); err != nil {
+			return count, err
+		}
+		return count, buffered.Flush()
+	}
+	if dir := filepath.Dir(req.Output); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return 0, fmt.Errorf("create export directory: %w", err)
+		}
+	}
+	return count, writeExportFile(req.Output, write)
+}
+
+// forEachExportSession loads each session active since the cutoff and hands
+// its filtered messages to fn. ListSessions returns the newest first, so the
+// walk stops at the first session older than the cutoff.
+func forEachExportSession(store *storage.Store, sessions []storage.Session, since time.Time, exporter *conversation.Exporter, fn func(*storage.Session, []conversation.Message) error) (int, error) {
+	count := 0
+	for i := range sessions {
+		session := &sessions[i]
+		if !since.IsZero() && session.LastActive.Before(since) {
+			break
+		}
+		conv := conversation.New(session.ID)
+		if err := conv.LoadFromStorage(store); err != nil {
+			return count, fmt.Errorf("load session %s: %w", session.ID, err)
+		}
+		if err := fn(session, exporter.Filter(conv.Messages)); err != nil {
+			return count, fmt.Errorf("export session %s: %w", session.ID, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func writeExportFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	buffered := bufio.NewWriter(f)
+	if err := write(buffered); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// exportFileName turns a session ID into a safe file name.
+func exportFileName(sessionID string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(sessionID))
+	name = strings.Trim(name, "_.-")
+	if name == "" {
+		return "session"
+	}
+	return name
+}
+
+// uniqueExportFileName returns name+ext, or name-2+ext and so on when an
+// earlier session in the same export already used that file name. Distinct
+// session IDs can sanitize to the same name.
+func uniqueExportFileName(name, ext string, used map[string]bool) string {
+	file := name + ext
+	for n := 2; used[strings.ToLower(file)]; n++ {
+		file = fmt.Sprintf("%s-%d%s", name, n, ext)
+	}
+	used[strings.ToLower(file)] = true
+	return file
+}
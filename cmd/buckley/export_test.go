@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func newExportTestStore(t *testing.T) *storage.Store {
+	t.Helper()
+	store, err := storage.New(filepath.Join(t.TempDir(), "buckley.db"))
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	for _, sess := range []storage.Session{
+		{ID: "recent", ProjectPath: "/repo/a", CreatedAt: now.Add(-time.Hour), LastActive: now.Add(-time.Hour)},
+		{ID: "stale/one", ProjectPath: "/repo/b", CreatedAt: now.AddDate(0, 0, -60), LastActive: now.AddDate(0, 0, -60)},
+	} {
+		sess := sess
+		if err := store.CreateSession(&sess); err != nil {
+			t.Fatalf("CreateSession: %v", err)
+		}
+	}
+	for _, msg := range []storage.Message{
+		{SessionID: "recent", Role: "system", Content: "be helpful", Timestamp: now},
+		{SessionID: "recent", Role: "user", Content: "list the files", Timestamp: now},
+		{SessionID: "recent", Role: "tool", Name: "list_files", ToolCallID: "call-1", Content: "main.go", Timestamp: now},
+		{SessionID: "recent", Role: "assistant", Content: "There is one file.", Timestamp: now},
+	} {
+		msg := msg
+		if err := store.SaveMessage(&msg); err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+	}
+	return store
+}
+
+func TestRunExportCombinedJSON(t *testing.T) {
+	store := newExportTestStore(t)
+
+	var out bytes.Buffer
+	req := exportRequest{Options: conversation.ExportOptions{Format: conversation.ExportJSON}, Output: "-"}
+	count, err := runExport(store, req, &out, time.Now())
+	if err != nil {
+		t.Fatalf("runExport: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("exported %d sessions, want 2", count)
+	}
+	var archive struct {
+		Sessions []struct {
+			SessionID string `json:"sessionId"`
+			Messages  []struct {
+				Role string `json:"role"`
+			} `json:"messages"`
+		} `json:"sessions"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &archive); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out.String())
+	}
+	if len(archive.Sessions) != 2 || archive.Sessions[0].SessionID != "recent" {
+		t.Fatalf("sessions = %+v", archive.Sessions)
+	}
+	var roles []string
+	for _, msg := range archive.Sessions[0].Messages {
+		roles = append(roles, msg.Role)
+	}
+	if got := strings.Join(roles, ","); got != "user,assistant" {
+		t.Fatalf("roles = %s, want system and tool messages filtered", got)
+	}
+}
+
+func TestRunExportDirectoryHonorsSince(t *testing.T) {
+	store := newExportTestStore(t)
+	dir := filepath.Join(t.TempDir(), "out")
+
+	req := exportRequest{
+		Options: conversation.ExportOptions{Format: conversation.ExportMarkdown, IncludeTools: true},
+		Since:   time.Now().AddDate(0, 0, -7),
+		Dir:     dir,
+	}
+	count, err := runExport(store, req, nil, time.Now())
+	if err != nil {
+		t.Fatalf("runExport: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("exported %d sessions, want 1", count)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "recent.md" {
+		t.Fatalf("entries = %v", entries)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "recent.md"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(data), "main.go") {
+		t.Fatalf("tool output missing from export:\n%s", data)
+	}
+}
+
+func TestParseExportArgs(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	req, err := parseExportArgs([]string{"--all", "--format", "json", "--since", "2026-03-01", "--output", "sessions.json"}, nil, now)
+	if err != nil {
+		t.Fatalf("parseExportArgs: %v", err)
+	}
+	if req.Options.Format != conversation.ExportJSON || req.Output != "sessions.json" {
+		t.Fatalf("req = %+v", req)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local); !req.Since.Equal(want) {
+		t.Fatalf("since = %v, want %v", req.Since, want)
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"--all", "--format", "pdf"},
+		{"--all", "--format", "html"},
+		{"--all", "--dir", "out", "--output", "x.md"},
+		{"--all", "--since", "someday"},
+	} {
+		if _, err := parseExportArgs(args, nil, now); err == nil {
+			t.Errorf("parseExportArgs(%v): expected error", args)
+		}
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	for in, want := range map[string]string{
+		"abc-123":   "abc-123",
+		"stale/one": "stale_one",
+		"../..":     "session",
+	} {
+		if got := exportFileName(in); got != want {
+			t.Errorf("exportFileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestUniqueExportFileName(t *testing.T) {
+	used := map[string]bool{}
+	var got []string
+	for _, id := range []string{"a/b", "a:b", "A_b", "c"} {
+		got = append(got, uniqueExportFileName(exportFileName(id), ".md", used))
+	}
+	if want := "a_b.md,a_b-2.md,A_b-3.md,c.md"; strings.Join(got, ",") != want {
+		t.Fatalf("file names = %v, want %s", got, want)
+	}
+}
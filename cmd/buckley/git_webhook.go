@@ -18,6 +18,7 @@ func runGitWebhookCommand(args []string) error {
 	fs := flag.NewFlagSet("git-webhook", flag.ContinueOnError)
 	bind := fs.String("bind", "", "address to bind the git webhook listener (default: git_events.webhook_bind or 127.0.0.1:8085)")
 	secret := fs.String("secret", "", "shared secret for webhook validation (overrides config)")
+	providerFlag := fs.String("provider", "", "webhook provider style, github or gitlab (default: git_events.provider or github)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -38,15 +39,24 @@ func runGitWebhookCommand(args []string) error {
 		addr = "127.0.0.1:8085"
 	}
 
+	providerName := strings.TrimSpace(*providerFlag)
+	if providerName == "" {
+		providerName = cfg.GitEvents.Provider
+	}
+	provider, err := gitwatcher.ParseProvider(providerName)
+	if err != nil {
+		return withExitCode(err, 2)
+	}
+
 	webhookSecret := strings.TrimSpace(chooseSecret(*secret, cfg.GitEvents.Secret))
 	if !isLoopbackAddress(addr) && webhookSecret == "" {
 		return withExitCode(fmt.Errorf("refusing to bind git webhook listener to %q without a shared secret (set --secret or git_events.secret)", addr), 2)
 	}
 
 	pipeline := regression.NewPipeline(cfg.GitEvents)
-	handler := gitwatcher.NewHandler(webhookSecret, pipeline.HandleMerge)
+	handler := gitwatcher.NewProviderHandler(provider, webhookSecret, pipeline.HandleMerge)
 
-	fmt.Printf("Listening for git webhooks on %s\n", addr)
+	fmt.Printf("Listening for git webhooks on %s (%s)\n", addr, provider)
 	return gitWebhookListenFn(addr, handler)
 }
 
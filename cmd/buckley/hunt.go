@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"os"
@@ -13,16 +14,34 @@ import (
 func runHuntCommand(args []string) error {
 	fs := flag.NewFlagSet("hunt", flag.ContinueOnError)
 	rootDir := fs.String("dir", "", "Root directory to scan (default: current directory)")
-	limit := fs.Int("limit", 10, "Maximum number of suggestions to show")
-	severity := fs.Int("min-severity", 1, "Minimum severity level (1-10)")
+	limit := fs.Int("limit", 10, "Maximum number of suggestions to show (0 for no limit)")
+	severity := fs.Int("min-severity", hunt.MinSeverity, "Minimum severity level (1-10)")
+	category := fs.String("category", "", "Comma-separated categories to show: "+strings.Join(hunt.Categories, ", "))
+	format := fs.String("format", "text", "Output format: text or sarif")
+	outputFile := fs.String("output", "", "Write output to this file instead of stdout")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
+	formatValue := strings.ToLower(strings.TrimSpace(*format))
+	switch formatValue {
+	case "", "text":
+		formatValue = "text"
+	case "sarif":
+	default:
+		return withExitCode(fmt.Errorf("unknown format %q (use text or sarif)", *format), 2)
+	}
+	categories, err := hunt.ParseCategories(*category)
+	if err != nil {
+		return withExitCode(fmt.Errorf("--category: %w", err), 2)
+	}
+	filter := hunt.Filter{MinSeverity: *severity, Categories: categories, Limit: *limit}
+	if err := filter.Validate(); err != nil {
+		return withExitCode(err, 2)
+	}
 
 	// Determine root directory
 	dir := *rootDir
 	if dir == "" {
-		var err error
 		dir, err = os.Getwd()
 		if err != nil {
 			return fmt.Errorf("get working directory: %w", err)
@@ -37,33 +56,32 @@ func runHuntCommand(args []string) error {
 	engine.AddAnalyzer(&hunt.LintAnalyzer{})
 	engine.AddAnalyzer(&hunt.DependencyAnalyzer{})
 
-	// Run scan
-	fmt.Printf("Scanning %s for improvements...\n\n", dir)
+	// Run scan. Progress goes to stderr when stdout carries SARIF.
+	progress := os.Stdout
+	if formatValue == "sarif" && *outputFile == "" {
+		progress = os.Stderr
+	}
+	fmt.Fprintf(progress, "Scanning %s for improvements...\n\n", dir)
 	suggestions, err := engine.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
 	}
 
-	// Filter by severity
-	var filtered []hunt.ImprovementSuggestion
-	for _, s := range suggestions {
-		if s.Severity >= *severity {
-			filtered = append(filtered, s)
-		}
-	}
+	// Filter by severity and category, then limit
+	filtered := filter.Apply(suggestions)
 
-	if len(filtered) == 0 {
-		fmt.Println("✓ No improvement suggestions found at this severity level")
-		return nil
+	if formatValue == "sarif" {
+		return writeHuntSARIF(*outputFile, filtered)
 	}
 
-	// Limit results
-	if len(filtered) > *limit {
-		filtered = filtered[:*limit]
+	var out strings.Builder
+	if len(filtered) == 0 {
+		out.WriteString("✓ No improvement suggestions match the filters\n")
+		return writeHuntOutput(*outputFile, out.String())
 	}
 
 	// Display results
-	fmt.Printf("Found %d improvement suggestions:\n\n", len(filtered))
+	fmt.Fprintf(&out, "Found %d improvement suggestions:\n\n", len(filtered))
 	for i, s := range filtered {
 		severityIcon := strings.Repeat("!", s.Severity/2)
 		if severityIcon == "" {
@@ -74,20 +92,42 @@ func runHuntCommand(args []string) error {
 			effortLabel = "unknown"
 		}
 
-		fmt.Printf("%d. [%s] %s\n", i+1, severityIcon, s.Rationale)
-		fmt.Printf("   Category: %s | Effort: %s | Severity: %d\n", s.Category, effortLabel, s.Severity)
+		fmt.Fprintf(&out, "%d. [%s] %s\n", i+1, severityIcon, s.Rationale)
+		fmt.Fprintf(&out, "   Category: %s | Effort: %s | Severity: %d\n", s.Category, effortLabel, s.Severity)
 		if s.File != "" {
 			location := s.File
 			if s.LineStart > 0 {
 				location = fmt.Sprintf("%s:%d", s.File, s.LineStart)
 			}
-			fmt.Printf("   Location: %s\n", location)
+			fmt.Fprintf(&out, "   Location: %s\n", location)
 		}
 		if s.Snippet != "" {
-			fmt.Printf("   Snippet: %s\n", s.Snippet)
+			fmt.Fprintf(&out, "   Snippet: %s\n", s.Snippet)
 		}
-		fmt.Println()
+		out.WriteString("\n")
 	}
 
+	return writeHuntOutput(*outputFile, out.String())
+}
+
+// writeHuntSARIF writes suggestions as SARIF to stdout or outputFile.
+func writeHuntSARIF(outputFile string, suggestions []hunt.ImprovementSuggestion) error {
+	var buf bytes.Buffer
+	if err := hunt.WriteSARIF(&buf, suggestions, version); err != nil {
+		return fmt.Errorf("encode sarif: %w", err)
+	}
+	return writeHuntOutput(outputFile, buf.String())
+}
+
+// writeHuntOutput prints output, or writes it to outputFile when set.
+func writeHuntOutput(outputFile, output string) error {
+	if outputFile == "" {
+		fmt.Print(output)
+		return nil
+	}
+	if err := os.WriteFile(outputFile, []byte(output), 0o644); err != nil {
+		return fmt.Errorf("write output file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote results to %s\n", outputFile)
 	return nil
 }
@@ -5,9 +5,11 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
@@ -63,6 +65,9 @@ var noColor bool
 var configPath string
 var modelOverrideFlag string
 var agentProfileFlag string
+var noPersistFlag bool
+var maxCostCentsFlag float64
+var dryRunFlag bool
 
 // initDependenciesFn allows tests to stub dependency initialization without hitting the network.
 var initDependenciesFn = initDependencies
@@ -73,6 +78,7 @@ type orchestratorRunner interface {
 	PlanFeature(featureName, description string) (*orchestrator.Plan, error)
 	LoadPlan(planID string) (*orchestrator.Plan, error)
 	ExecutePlan() error
+	ResumeExecution() error
 	ExecuteTask(taskID string) error
 }
 
@@ -99,6 +105,7 @@ var newOrchestratorFn = func(store *storage.Store, mgr *model.Manager, registry
 	}
 
 	if cfg != nil && cfg.ExecutionMode() == config.ExecutionModeRLM {
+		printRLMExperimentalWarning(cfg)
 		r := rlmrunner.New(store, mgr, registry, cfg, workflow, planStore)
 		workDir := config.ResolveProjectRoot(cfg)
 		graftClient := graft.NewClient(workDir, "buckley")
@@ -115,11 +122,15 @@ type startupOptions struct {
 	resumeSessionID  string
 	quiet            bool
 	noColor          bool
+	noPersist        bool
 	configPath       string
 	modelOverride    string
 	agentPath        string
+	maxCost          string
+	maxCostCents     float64
 	plainModeSet     bool
 	plainMode        bool
+	dryRun           bool
 }
 
 type startupPendingFlag int
@@ -131,12 +142,14 @@ const (
 	startupPendingConfig
 	startupPendingModel
 	startupPendingAgent
+	startupPendingMaxCost
 )
 
 type startupFlagState struct {
-	pending       startupPendingFlag
-	modelFlagSeen bool
-	agentFlagSeen bool
+	pending         startupPendingFlag
+	modelFlagSeen   bool
+	agentFlagSeen   bool
+	maxCostFlagSeen bool
 }
 
 func main() {
@@ -159,6 +172,9 @@ func main() {
 	configPath = opts.configPath
 	modelOverrideFlag = opts.modelOverride
 	agentProfileFlag = opts.agentPath
+	noPersistFlag = opts.noPersist
+	maxCostCentsFlag = opts.maxCostCents
+	dryRunFlag = opts.dryRun
 	os.Args = append([]string{os.Args[0]}, opts.args...)
 
 	if handled, exitCode := dispatchSubcommand(opts.args); handled {
@@ -176,8 +192,7 @@ func main() {
 	args := opts.args
 
 	// Check core dependencies
-	checker := setup.NewChecker()
-	if err := resolveDependencies(checker); err != nil {
+	if err := checkStartupDependencies(); err != nil {
 		fmt.Fprintf(os.Stderr, "Setup error: %v\n", err)
 		os.Exit(1)
 	}
@@ -194,6 +209,7 @@ func main() {
 		os.Exit(2)
 	}
 	applySandboxOverride(cfg)
+	applyNoPersistOverride(cfg)
 	agentProfile, err := loadStartupAgentProfile(agentProfileFlag)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading agent spec: %v\n", err)
@@ -249,6 +265,7 @@ func main() {
 		os.Exit(1)
 	}
 	defer store.Close()
+	configureStorePersistence(store, cfg)
 	modelManager.SetProviderThreadStore(store)
 
 	// Load project context (AGENTS.md)
@@ -304,6 +321,7 @@ func main() {
 		SessionID:     resumeSessionID,
 		AgentProfile:  agentPromptSection(agentProfile),
 		ModelOverride: modelOverrideFlag,
+		Notices:       startupNotices(cfg),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating TUI: %v\n", err)
@@ -326,7 +344,6 @@ func main() {
 
 // executeOneShot executes a single prompt and exits
 func executeOneShot(prompt string, cfg *config.Config, mgr *model.Manager, store *storage.Store, projectContext *projectcontext.ProjectContext, planStore orchestrator.PlanStore, agentProfile *agentspec.RuntimeProfile, modelOverride string, allowedTools []string) int {
-	_ = store
 	_ = planStore
 
 	cwd, err := os.Getwd()
@@ -341,18 +358,27 @@ func executeOneShot(prompt string, cfg *config.Config, mgr *model.Manager, store
 		engine = nil
 	}
 
-	resolvedModel := model.ResolvePhaseModel(cfg, mgr, engine, "execution", modelOverride)
-	if resolvedModel == "" {
-		resolvedModel = "openai/gpt-4o"
-	}
+	resolvedModel, autoSelected := mgr.ExecutionModelOrDefault(model.ResolvePhaseModel(cfg, mgr, engine, "execution", modelOverride))
 
 	if !quietMode {
+		if autoSelected {
+			fmt.Fprintf(os.Stderr, "note: %s\n", model.AutoSelectedModelNote(resolvedModel))
+		}
 		fmt.Fprintf(os.Stderr, "workdir: %s\n", cwd)
 		fmt.Fprintf(os.Stderr, "model: %s\n", resolvedModel)
+		if dryRunFlag {
+			fmt.Fprintln(os.Stderr, "dry run: file writes and commands are described, not performed")
+		}
 	}
 	if mgr != nil {
 		mgr.SetRequestTimeout(0)
 	}
+	spendCap, err := installSpendCap(mgr, store, maxCostCentsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer reportSpendCap(spendCap)
 
 	skills := skill.NewRegistry()
 	if err := skills.LoadAll(); err != nil {
@@ -366,7 +392,9 @@ func executeOneShot(prompt string, cfg *config.Config, mgr *model.Manager, store
 		fmt.Fprintf(os.Stderr, "Warning: failed to load some plugins: %v\n", err)
 	}
 	registry.ConfigureContainers(cfg, cwd)
+	tool.ApplyToolPolicyConfig(registry, cfg)
 	registry.SetWorkDir(cwd)
+	registry.SetDryRun(dryRunFlag)
 	registry.Register(&builtin.SkillActivationTool{
 		Registry:     skills,
 		Conversation: skillState,
@@ -378,7 +406,7 @@ func executeOneShot(prompt string, cfg *config.Config, mgr *model.Manager, store
 		skillState.SetToolFilter(toolFilter)
 	}
 
-	conv.AddSystemMessage(buildACPSystemPrompt(projectContext, cwd, skills, engine, agentPromptSection(agentProfile)))
+	conv.AddSystemMessage(buildACPSystemPrompt(projectContext, cwd, skills, engine, agentPromptSection(agentProfile), cfg.SystemPrompt.Footer))
 	conv.AddUserMessage(prompt)
 
 	responseText, err := runACPLoop(context.Background(), cfg, mgr, conv, registry, skillState, engine, resolvedModel, nil)
@@ -397,8 +425,27 @@ func executeOneShot(prompt string, cfg *config.Config, mgr *model.Manager, store
 }
 
 func runPlanCommand(args []string) error {
+	if len(args) > 0 && args[0] == "validate" {
+		return runPlanValidateCommand(args[1:])
+	}
+	fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	format := fs.String("format", "text", "output format: text or json")
+	outputPath := fs.String("output", "", "write the plan summary or JSON to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
 	if len(args) < 2 {
-		return fmt.Errorf("usage: buckley plan <feature-name> <description>")
+		return fmt.Errorf("usage: buckley plan [--format text|json] [--output <path>] <feature-name> <description>")
+	}
+	formatValue := strings.ToLower(strings.TrimSpace(*format))
+	switch formatValue {
+	case "", "text":
+		formatValue = "text"
+	case "json":
+	default:
+		return fmt.Errorf("unknown format %q (use text or json)", *format)
 	}
 
 	// Initialize dependencies
@@ -416,27 +463,67 @@ func runPlanCommand(args []string) error {
 	if cwd, err := os.Getwd(); err == nil {
 		registry.ConfigureContainers(cfg, cwd)
 	}
+	tool.ApplyToolPolicyConfig(registry, cfg)
 	planStore := orchestrator.NewFilePlanStore(cfg.Artifacts.PlanningDir)
 	orch := newOrchestratorFn(store, mgr, registry, cfg, nil, planStore)
 
+	// Keep stdout clean for JSON and file output; progress goes to stderr.
+	var progress io.Writer = os.Stdout
+	if formatValue == "json" || *outputPath != "" {
+		progress = os.Stderr
+		if quietMode {
+			progress = io.Discard
+		}
+	}
+
 	// Generate plan
-	fmt.Printf("Generating plan for: %s\n", featureName)
+	fmt.Fprintf(progress, "Generating plan for: %s\n", featureName)
 	plan, err := orch.PlanFeature(featureName, description)
 	if err != nil {
 		return fmt.Errorf("failed to create plan: %w", err)
 	}
 
-	fmt.Printf("\n✓ Plan created: %s\n\n", plan.ID)
-	fmt.Printf("Feature: %s\n", plan.FeatureName)
-	fmt.Printf("Tasks: %d\n", len(plan.Tasks))
-	fmt.Printf("\nTo execute: buckley execute %s\n", plan.ID)
-
+	if path := strings.TrimSpace(*outputPath); path != "" {
+		if err := writeExportFile(path, func(w io.Writer) error { return writePlanOutput(w, plan, formatValue) }); err != nil {
+			return fmt.Errorf("write plan: %w", err)
+		}
+		fmt.Fprintf(progress, "✓ Plan %s written to %s\n", plan.ID, path)
+		return nil
+	}
+	if err := writePlanOutput(os.Stdout, plan, formatValue); err != nil {
+		return fmt.Errorf("write plan: %w", err)
+	}
 	return nil
 }
 
+// writePlanOutput prints a generated plan as JSON or as the text summary.
+func writePlanOutput(out io.Writer, plan *orchestrator.Plan, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(plan)
+	}
+	fmt.Fprintf(out, "\n✓ Plan created: %s\n\n", plan.ID)
+	fmt.Fprintf(out, "Feature: %s\n", plan.FeatureName)
+	fmt.Fprintf(out, "Tasks: %d\n", len(plan.Tasks))
+	_, err := fmt.Fprintf(out, "\nTo execute: buckley execute %s\n", plan.ID)
+	return err
+}
+
 func runExecuteCommand(args []string) error {
+	fs := flag.NewFlagSet("execute", flag.ContinueOnError)
+	maxCost := fs.Float64("max-cost", maxCostCentsFlag, "abort once estimated spend would exceed this many cents (0 disables)")
+	resume := fs.Bool("resume", false, "continue from the first incomplete task, retrying failed or interrupted ones")
+	estimate := fs.Bool("estimate", false, "print an approximate cost range and confirm before executing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
 	if len(args) < 1 {
-		return fmt.Errorf("usage: buckley execute <plan-id>")
+		return fmt.Errorf("usage: buckley execute [--max-cost <cents>] [--resume] [--estimate] <plan-id>")
+	}
+	if *maxCost < 0 {
+		return fmt.Errorf("--max-cost must be a non-negative number of cents")
 	}
 
 	// Initialize dependencies
@@ -447,6 +534,15 @@ func runExecuteCommand(args []string) error {
 	defer store.Close()
 
 	planID := args[0]
+	spendCap, err := installSpendCap(mgr, store, *maxCost)
+	if err != nil {
+		return err
+	}
+	defer reportSpendCap(spendCap)
+	budget, err := installHardStopBudget(cfg, mgr, store, spendCap)
+	if err != nil {
+		return err
+	}
 
 	// Create orchestrator
 	registry := tool.NewRegistry()
@@ -456,9 +552,19 @@ func runExecuteCommand(args []string) error {
 	if cwd, err := os.Getwd(); err == nil {
 		registry.ConfigureContainers(cfg, cwd)
 	}
+	tool.ApplyToolPolicyConfig(registry, cfg)
 
 	planStore := orchestrator.NewFilePlanStore(cfg.Artifacts.PlanningDir)
 	orch := newOrchestratorFn(store, mgr, registry, cfg, nil, planStore)
+	if budget != nil {
+		if guarded, ok := orch.(interface {
+			SetBudgetChecker(orchestrator.BudgetChecker)
+		}); ok {
+			guarded.SetBudgetChecker(budget)
+		} else {
+			fmt.Fprintln(os.Stderr, "Warning: cost_management.hard_stop is not supported by this execution mode")
+		}
+	}
 
 	// Load plan
 	plan, err := orch.LoadPlan(planID)
@@ -466,11 +572,31 @@ func runExecuteCommand(args []string) error {
 		return fmt.Errorf("failed to load plan: %w", err)
 	}
 
+	if *estimate {
+		confirm := !quietMode && stdinIsTerminalFn()
+		if !confirmPlanCost(plan, executionModelFor(orch, cfg), mgr, confirm, os.Stdin, os.Stdout) {
+			return fmt.Errorf("execution aborted")
+		}
+		fmt.Println()
+	}
+
 	// Execute plan
 	fmt.Printf("Executing plan: %s\n", plan.FeatureName)
 	fmt.Printf("Tasks: %d\n\n", len(plan.Tasks))
 
-	if err := orch.ExecutePlan(); err != nil {
+	execute := orch.ExecutePlan
+	if *resume {
+		next, done := orchestrator.PrepareResume(plan)
+		if next == nil {
+			fmt.Println("✓ All tasks already complete")
+			return nil
+		}
+		if done > 0 {
+			fmt.Printf("Resuming at task %s: %s (%d/%d done)\n\n", next.ID, next.Title, done, len(plan.Tasks))
+		}
+		execute = orch.ResumeExecution
+	}
+	if err := execute(); err != nil {
 		return fmt.Errorf("failed to execute plan: %w", err)
 	}
 
@@ -495,9 +621,13 @@ func runExecuteTaskCommand(args []string) error {
 	remoteBranch := fs.String("remote-branch", defaultRemoteBranch, "remote branch to push after completion")
 	remoteName := fs.String("remote-name", defaultRemoteName, "remote to push the branch to")
 	pushChanges := fs.Bool("push", true, "push to the remote branch when set")
+	maxCost := fs.Float64("max-cost", maxCostCentsFlag, "abort once estimated spend would exceed this many cents (0 disables)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
+	if *maxCost < 0 {
+		return fmt.Errorf("--max-cost must be a non-negative number of cents")
+	}
 
 	remaining := fs.Args()
 	if *planID == "" && len(remaining) > 0 {
@@ -508,7 +638,7 @@ func runExecuteTaskCommand(args []string) error {
 	}
 
 	if strings.TrimSpace(*planID) == "" || strings.TrimSpace(*taskID) == "" {
-		return fmt.Errorf("usage: buckley execute-task --plan <plan-id> --task <task-id> [--remote-branch <branch>]")
+		return fmt.Errorf("usage: buckley execute-task --plan <plan-id> --task <task-id> [--remote-branch <branch>] [--max-cost <cents>]")
 	}
 
 	if _, err := prepareTaskWorkspace(*workdir, *repoURL, *repoRef, *repoDir); err != nil {
@@ -520,6 +650,11 @@ func runExecuteTaskCommand(args []string) error {
 		return err
 	}
 	defer store.Close()
+	spendCap, err := installSpendCap(mgr, store, *maxCost)
+	if err != nil {
+		return err
+	}
+	defer reportSpendCap(spendCap)
 
 	registry := tool.NewRegistry()
 	if err := registry.LoadDefaultPlugins(); err != nil {
@@ -528,6 +663,7 @@ func runExecuteTaskCommand(args []string) error {
 	if cwd, err := os.Getwd(); err == nil {
 		registry.ConfigureContainers(cfg, cwd)
 	}
+	tool.ApplyToolPolicyConfig(registry, cfg)
 
 	planStore := orchestrator.NewFilePlanStore(cfg.Artifacts.PlanningDir)
 	orch := newOrchestratorFn(store, mgr, registry, cfg, nil, planStore)
@@ -550,6 +686,49 @@ func runExecuteTaskCommand(args []string) error {
 	return nil
 }
 
+func runSkipTaskCommand(args []string) error {
+	fs := flag.NewFlagSet("skip-task", flag.ContinueOnError)
+	planID := fs.String("plan", "", "plan identifier")
+	taskID := fs.String("task", "", "task identifier")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	remaining := fs.Args()
+	if *planID == "" && len(remaining) > 0 {
+		*planID = remaining[0]
+	}
+	if *taskID == "" && len(remaining) > 1 {
+		*taskID = remaining[1]
+	}
+
+	if strings.TrimSpace(*planID) == "" || strings.TrimSpace(*taskID) == "" {
+		return fmt.Errorf("usage: buckley skip-task --plan <plan-id> --task <task-id>")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+	}
+
+	planStore := orchestrator.NewFilePlanStore(cfg.Artifacts.PlanningDir)
+	plan, err := planStore.LoadPlan(strings.TrimSpace(*planID))
+	if err != nil {
+		return fmt.Errorf("failed to load plan: %w", err)
+	}
+	task, err := orchestrator.SkipPlanTask(plan, *taskID)
+	if err != nil {
+		return err
+	}
+	if err := planStore.SavePlan(plan); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	fmt.Printf("✓ Skipped task %s (%s)\n", task.ID, task.Title)
+	fmt.Printf("\nTo continue: buckley execute %s\n", plan.ID)
+	return nil
+}
+
 func runMigrateCommand() error {
 	store, err := initIPCStore()
 	if err != nil {
@@ -590,6 +769,7 @@ func initDependencies() (*config.Config, *model.Manager, *storage.Store, error)
 	if encodingOverrideFlag != "" {
 		cfg.Encoding.UseToon = encodingOverrideFlag != "json"
 	}
+	applyNoPersistOverride(cfg)
 	agentProfile, err := loadStartupAgentProfile(agentProfileFlag)
 	if err != nil {
 		return nil, nil, nil, withExitCode(fmt.Errorf("loading agent spec: %w", err), 2)
@@ -622,6 +802,9 @@ func initDependencies() (*config.Config, *model.Manager, *storage.Store, error)
 	if err := modelManager.Initialize(); err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to initialize model manager: %w", err)
 	}
+	if err := projectconversation.SetTokenizer(projectconversation.ResolveTokenizer(cfg.Models.Tokenizer, cfg.Models.Execution)); err != nil {
+		return nil, nil, nil, withExitCode(err, 2)
+	}
 
 	// Initialize storage
 	dbPath, err := resolveDBPath()
@@ -632,6 +815,7 @@ func initDependencies() (*config.Config, *model.Manager, *storage.Store, error)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
+	configureStorePersistence(store, cfg)
 	modelManager.SetProviderThreadStore(store)
 
 	return cfg, modelManager, store, nil
@@ -650,13 +834,19 @@ func printHelp() {
 	fmt.Println("  buckley -p \"prompt\"              One-shot mode: run prompt and exit")
 	fmt.Println()
 	fmt.Println("COMMANDS:")
-	fmt.Println("  plan <name> <desc>               Generate feature plan")
-	fmt.Println("  execute <plan-id>                Execute a plan")
+	fmt.Println("  plan [--format json] [--output f] <name> <desc>")
+	fmt.Println("                                   Generate feature plan")
+	fmt.Println("  plan validate <plan-id>          Check a plan's task dependencies (cycles, missing tasks)")
+	fmt.Println("  execute [--max-cost c] [--resume] [--estimate] <plan-id>")
+	fmt.Println("                                   Execute a plan, optionally continuing a failed run")
 	fmt.Println("  execute-task --plan <id> --task <id>")
 	fmt.Println("                                   Execute single task (CI/batch friendly)")
+	fmt.Println("  skip-task --plan <id> --task <id>")
+	fmt.Println("                                   Mark a plan task skipped so execution moves past it")
 	fmt.Println("  commit [--dry-run]               Generate structured commit via tool-use (transparent)")
-	fmt.Println("  pr [--dry-run]                   Generate structured PR via tool-use (transparent)")
-	fmt.Println("  review [--scope worktree|branch|changes]")
+	fmt.Println("  pr [--dry-run] [--from <ref> --to <ref>]")
+	fmt.Println("                                   Generate structured PR via tool-use (transparent)")
+	fmt.Println("  review [--scope worktree|branch|changes] [--format json [--fail-on <severity>]]")
 	fmt.Println("                                   Review local changes with repository context")
 	fmt.Println("  review-pr <number|url>           Review a GitHub PR with CI and review-thread context")
 	fmt.Println("  experiment run <name> -m <model> -p <prompt>")
@@ -666,7 +856,8 @@ func printHelp() {
 	fmt.Println("  experiment diff <id|name>        Compare variant outputs side-by-side")
 	fmt.Println("  experiment replay <session-id>   Replay a session with a new model")
 	fmt.Println("  eval [list|run|init|runs|show]   Run project chat eval scenarios")
-	fmt.Println("  serve [--bind host:port]         Start local HTTP/WebSocket server")
+	fmt.Println("  serve [--bind host:port] [--open] [--max-body size] [--watch-config] [--experiment]")
+	fmt.Println("                                   Start local HTTP/WebSocket server")
 	fmt.Println("  remote <subcommand>              Remote session operations (attach, sessions, tokens, login, console)")
 	fmt.Println("  batch prune-workspaces           Garbage-collect stale batch workspaces (k8s/CI)")
 	fmt.Println("  git-webhook                      Listen for merge webhooks and run regression/release commands")
@@ -680,12 +871,18 @@ func printHelp() {
 	fmt.Println("  agent run [--project|--dry-run|--no-tools] Invoke or preview a named subagent")
 	fmt.Println("  agent-server                     HTTP proxy for ACP editor workflows (inline propose/apply)")
 	fmt.Println("  lsp [--coordinator addr]         Start LSP server on stdio (editor integration)")
-	fmt.Println("  acp [--workdir dir] [--log file] Start ACP agent on stdio (Zed/JetBrains/Neovim)")
-	fmt.Println("  hunt [--dir path]                Scan codebase for improvement suggestions")
-	fmt.Println("  dream [--dir path] [--plan]      Analyze architecture and identify gaps")
+	fmt.Println("  acp [--workdir dir] [--log file] [--model id]")
+	fmt.Println("                                   Start ACP agent on stdio (Zed/JetBrains/Neovim)")
+	fmt.Println("  hunt [--dir path] [--category list] [--min-severity n] [--format sarif] [--output file]")
+	fmt.Println("                                   Scan codebase for improvement suggestions")
+	fmt.Println("                                   (categories: tech-debt, readability, dependency)")
+	fmt.Println("  dream [--dir path] [--plan] [--compare ref]")
+	fmt.Println("                                   Analyze architecture and identify gaps")
 	fmt.Println("  info [--json|--format json]      Inspect resolved harness configuration and capabilities")
-	fmt.Println("  skills [init|list|show]          Create, list, or inspect workflow skills")
-	fmt.Println("  config [check|show|path]         Manage configuration")
+	fmt.Println("  skills [init|list|show|validate] Create, list, inspect, or validate workflow skills")
+	fmt.Println("  config [check|show|path|set|diff|init]")
+	fmt.Println("                                   Manage configuration")
+	fmt.Println("  validate-config <path>           Check a config file for errors and warnings")
 	fmt.Println("  trust [status|allow|deny|reset]  Inspect or change project trust")
 	fmt.Println("  doctor chat [init|runs|-project] Create, inspect, or run chat health checks")
 	fmt.Println("  completion [bash|zsh|fish]       Generate shell completions")
@@ -697,13 +894,29 @@ func printHelp() {
 	fmt.Println("  migrate                          Apply database migrations")
 	fmt.Println("  db backup --out <path>           Create a consistent SQLite backup (VACUUM INTO)")
 	fmt.Println("  db restore --in <path> --force   Restore SQLite backup (stop Buckley first)")
+	fmt.Println("  db prune-sessions --before <date> Permanently delete inactive sessions older than date")
+	fmt.Println("  embeddings cache [status|clear]  Inspect or clear the on-disk embedding cache")
 	fmt.Println("  resume <session-id>              Resume a previous session")
+	fmt.Println("  resume --list [--json]           List recent sessions (JSON for tooling)")
+	fmt.Println("  sessions merge <target> <source> Append source session messages onto target")
+	fmt.Println("  sessions stats [--since 30d] [--by project|model] [--json]")
+	fmt.Println("                                   Summarize sessions, tokens, and cost over a window")
+	fmt.Println("  export --all [--format markdown|json|html] [--since <date>] [--dir <path> | --output <file>]")
+	fmt.Println("                                   Export every saved session to one file or a directory")
+	fmt.Println("  tokens count [--model <id>] [--json] [file]")
+	fmt.Println("                                   Estimate tokens for a file or stdin")
+	fmt.Println("  models list [--provider <id>] [--contains <text>] [--supports-tools] [--json]")
+	fmt.Println("                                   List catalog models grouped by provider")
+	fmt.Println("  tool list [--json]               List builtin, plugin, and MCP tools available in this project")
 	fmt.Println()
 	fmt.Println("FLAGS:")
 	fmt.Println("  -p <prompt>                      Run prompt in one-shot mode")
+	fmt.Println("  --max-cost <cents>               Abort -p, execute, or execute-task before spend exceeds the cap")
+	fmt.Println("  --dry-run                        With -p, describe file writes and commands instead of running them")
 	fmt.Println("  -c, --config <path>              Use custom config file")
 	fmt.Println("  -q, --quiet                      Suppress non-essential output")
 	fmt.Println("  --no-color                       Disable colored output")
+	fmt.Println("  --no-persist                     Keep conversations in memory only (no transcripts or telemetry on disk)")
 	fmt.Println("  --tui                            Use rich TUI interface")
 	fmt.Println("  --plain                          Use plain scrollback mode")
 	fmt.Println("  --agent <path>                   Load a buckley.agent/v1 runtime profile for this session")
@@ -723,6 +936,7 @@ func printHelp() {
 	fmt.Println("  BUCKLEY_MODEL_REVIEW             Override review model")
 	fmt.Println("  BUCKLEY_MODEL_COMMIT             Override model for `buckley commit`")
 	fmt.Println("  BUCKLEY_MODEL_PR                 Override model for `buckley pr`")
+	fmt.Println("  BUCKLEY_MODEL_TOKENIZER          Override tokenizer for token counts (models.tokenizer)")
 	fmt.Println("  BUCKLEY_ONESHOT_BACKEND          Override one-shot backend: api, codex, or claude")
 	fmt.Println("  BUCKLEY_COMMIT_BACKEND           Override backend for `buckley commit`")
 	fmt.Println("  BUCKLEY_PR_BACKEND               Override backend for `buckley pr`")
@@ -738,12 +952,15 @@ func printHelp() {
 	fmt.Println("  BUCKLEY_GENERATE_IPC_TOKEN       Auto-generate an IPC token when missing (serve mode)")
 	fmt.Println("  BUCKLEY_IPC_TOKEN_FILE           Read/write the IPC token from this path (serve mode)")
 	fmt.Println("  BUCKLEY_PRINT_GENERATED_IPC_TOKEN Print generated IPC token to stderr (serve mode; use cautiously)")
+	fmt.Println("  BUCKLEY_IPC_ALLOWED_ORIGINS      Comma-separated CORS origins replacing ipc.allowed_origins (serve mode)")
 	fmt.Println("  BUCKLEY_BASIC_AUTH_USER          IPC basic auth username (optional)")
 	fmt.Println("  BUCKLEY_BASIC_AUTH_PASSWORD      IPC basic auth password (optional)")
 	fmt.Println("  BUCKLEY_DB_PATH                  Override primary SQLite DB path")
 	fmt.Println("  BUCKLEY_DATA_DIR                 Directory containing Buckley DB files (db, remote-auth, checkpoints, etc)")
+	fmt.Println("  BUCKLEY_EMBEDDINGS_CACHE_DIR     Override embedding cache directory")
 	fmt.Println("  BUCKLEY_LOG_DIR                  Override telemetry log directory")
 	fmt.Println("  BUCKLEY_QUIET                    Suppress non-essential output")
+	fmt.Println("  BUCKLEY_EPHEMERAL                Keep conversations in memory only (same as --no-persist)")
 	fmt.Println("  NO_COLOR                         Disable colored output")
 	fmt.Println()
 	fmt.Println("CONFIGURATION:")
@@ -781,106 +998,46 @@ func runConfigCommand(args []string) error {
 
 	switch subCmd {
 	case "check":
-		return runConfigCheck()
+		fs := flag.NewFlagSet("config check", flag.ContinueOnError)
+		strict := fs.Bool("strict", false, "fail on unrecognized config keys")
+		jsonOutput := fs.Bool("json", false, "print the report as JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return withExitCode(err, 2)
+		}
+		load := config.Load
+		if *strict {
+			load = config.LoadStrict
+		}
+		return runConfigCheckWith(load, *jsonOutput)
 	case "show":
 		return runConfigShow()
 	case "path":
 		return runConfigPath()
+	case "set":
+		return runConfigSetCommand(args[1:])
+	case "diff":
+		return runConfigDiffCommand(args[1:])
+	case "init":
+		return runConfigInitCommand(args[1:])
 	default:
-		return fmt.Errorf("unknown config command: %s (use check, show, or path)", subCmd)
+		return fmt.Errorf("unknown config command: %s (use check, show, path, set, diff, or init)", subCmd)
 	}
 }
 
 func runConfigCheck() error {
-	fmt.Println("Checking Buckley configuration...")
-	fmt.Println()
-
-	// Check config files
-	home, _ := os.UserHomeDir()
-	userConfig := filepath.Join(home, ".buckley", "config.yaml")
-	projectConfig := ".buckley/config.yaml"
-
-	fmt.Println("Configuration files:")
-	if _, err := os.Stat(userConfig); err == nil {
-		fmt.Printf("  ✓ User config:    %s\n", userConfig)
-	} else {
-		fmt.Printf("  - User config:    %s (not found)\n", userConfig)
-	}
-	if _, err := os.Stat(projectConfig); err == nil {
-		fmt.Printf("  ✓ Project config: %s\n", projectConfig)
-	} else {
-		fmt.Printf("  - Project config: %s (not found)\n", projectConfig)
-	}
-	fmt.Println()
-
-	// Check API keys
-	fmt.Println("API keys:")
-	providers := []struct {
-		name   string
-		envVar string
-	}{
-		{"OpenRouter", "OPENROUTER_API_KEY"},
-		{"OpenAI", "OPENAI_API_KEY"},
-		{"Anthropic", "ANTHROPIC_API_KEY"},
-		{"Google", "GOOGLE_API_KEY"},
-	}
-
-	hasProvider := false
-	for _, p := range providers {
-		if key := os.Getenv(p.envVar); key != "" {
-			fmt.Printf("  ✓ %s: configured\n", p.name)
-			hasProvider = true
-		} else {
-			fmt.Printf("  - %s: not set\n", p.name)
-		}
-	}
-
-	// Check config.env fallback
-	if !hasProvider {
-		if key := checkConfigEnvFile(); key != "" {
-			fmt.Printf("  ✓ OpenRouter: found in ~/.buckley/config.env\n")
-			hasProvider = true
-		}
-	}
-	fmt.Println()
-
-	// Check dependencies
-	fmt.Println("Dependencies:")
-	if _, err := exec.LookPath("git"); err == nil {
-		fmt.Println("  ✓ git: installed")
-	} else {
-		fmt.Println("  ✗ git: not found (required)")
-	}
-	fmt.Println()
-
-	// Load and validate config
-	cfg, err := config.Load()
-	if err != nil {
-		return withExitCode(err, 2)
-	}
+	return runConfigCheckWith(config.Load, false)
+}
 
-	// Show validation warnings
-	warnings := cfg.ValidationWarnings()
-	if len(warnings) > 0 {
-		fmt.Println("Warnings:")
-		for _, w := range warnings {
-			fmt.Printf("  ⚠ %s\n", w)
+func runConfigCheckWith(load func() (*config.Config, error), jsonOutput bool) error {
+	report, err := collectConfigCheck(load)
+	if jsonOutput {
+		if encErr := writeConfigCheckJSON(os.Stdout, report); encErr != nil {
+			return encErr
 		}
-		fmt.Println()
-	}
-
-	if cfg.Providers.HasReadyProvider() {
-		fmt.Println("✓ Configuration is valid")
 	} else {
-		fmt.Println("✗ No provider configured")
-		fmt.Println()
-		fmt.Println(`To fix: export OPENROUTER_API_KEY="<YOUR_OPENROUTER_API_KEY>"`)
-		fmt.Println("Or enable a local provider (BUCKLEY_OLLAMA_ENABLED=1 or BUCKLEY_LITELLM_ENABLED=1).")
-		fmt.Println("Get a key at: https://openrouter.ai/keys")
-		return withExitCode(fmt.Errorf("no providers configured"), 2)
+		writeConfigCheckText(os.Stdout, report)
 	}
-
-	return nil
+	return err
 }
 
 func runConfigShow() error {
@@ -990,11 +1147,11 @@ func printBashCompletion() {
     cur="${COMP_WORDS[COMP_CWORD]}"
     prev="${COMP_WORDS[COMP_CWORD-1]}"
 
-    commands="plan execute execute-task commit pr review review-pr experiment eval serve remote batch git-webhook agent skills skill agent-server lsp acp info config doctor completion worktree rules migrate db resume help version"
+    commands="plan execute execute-task skip-task commit pr review review-pr experiment eval serve remote batch git-webhook agent skills skill agent-server lsp acp info config validate-config doctor completion worktree rules migrate db embeddings resume sessions export tokens models tool help version"
 
     case "${prev}" in
         buckley)
-            COMPREPLY=( $(compgen -W "${commands} --help --version --tui --plain --quiet --no-color --config --agent" -- "${cur}") )
+            COMPREPLY=( $(compgen -W "${commands} --help --version --tui --plain --quiet --no-color --no-persist --config --agent --max-cost --dry-run" -- "${cur}") )
             return 0
             ;;
         batch)
@@ -1006,11 +1163,11 @@ func printBashCompletion() {
             return 0
             ;;
         skills|skill)
-            COMPREPLY=( $(compgen -W "init list show" -- "${cur}") )
+            COMPREPLY=( $(compgen -W "init list show validate" -- "${cur}") )
             return 0
             ;;
         config)
-            COMPREPLY=( $(compgen -W "check show path" -- "${cur}") )
+            COMPREPLY=( $(compgen -W "check show path set diff init" -- "${cur}") )
             return 0
             ;;
         doctor)
@@ -1038,7 +1195,27 @@ func printBashCompletion() {
             return 0
             ;;
         db)
-            COMPREPLY=( $(compgen -W "backup restore" -- "${cur}") )
+            COMPREPLY=( $(compgen -W "backup restore prune-sessions" -- "${cur}") )
+            return 0
+            ;;
+        sessions)
+            COMPREPLY=( $(compgen -W "merge stats" -- "${cur}") )
+            return 0
+            ;;
+        tokens)
+            COMPREPLY=( $(compgen -W "count" -- "${cur}") )
+            return 0
+            ;;
+        models)
+            COMPREPLY=( $(compgen -W "list" -- "${cur}") )
+            return 0
+            ;;
+        tool)
+            COMPREPLY=( $(compgen -W "list" -- "${cur}") )
+            return 0
+            ;;
+        plan)
+            COMPREPLY=( $(compgen -W "validate" -- "${cur}") )
             return 0
             ;;
         rules)
@@ -1066,6 +1243,7 @@ _buckley() {
         'plan:Generate feature plan'
         'execute:Execute a plan'
         'execute-task:Execute single task'
+        'skip-task:Skip a plan task'
         'commit:Create action-style commit'
         'pr:Create pull request'
         'review:Review local changes with repository context'
@@ -1084,12 +1262,19 @@ _buckley() {
         'acp:Start ACP agent on stdio for Zed/JetBrains/Neovim'
         'info:Inspect resolved harness configuration and capabilities'
         'config:Manage configuration'
+        'validate-config:Check a config file for errors and warnings'
         'completion:Generate shell completions'
         'worktree:Git worktree management'
         'rules:Inspect Arbiter rules and fact contracts'
         'migrate:Apply database migrations'
         'db:Backup/restore SQLite DB'
+        'embeddings:Inspect or clear the embedding cache'
         'resume:Resume a previous session'
+        'sessions:Manage saved sessions'
+        'export:Export saved sessions'
+        'tokens:Estimate tokens offline'
+        'models:Browse the model catalog'
+        'tool:List available tools'
         'doctor:Quick system and chat health checks'
         'help:Show help information'
         'version:Show version information'
@@ -1100,9 +1285,12 @@ _buckley() {
         '-c[Use custom config file]:config file:_files' \
         '--config[Use custom config file]:config file:_files' \
         '--agent[Load a buckley.agent/v1 runtime profile]:agent spec:_files' \
+        '--max-cost[Abort before spend exceeds this many cents]:cents:' \
+        '--dry-run[Describe file writes and commands instead of running them]' \
         '-q[Suppress non-essential output]' \
         '--quiet[Suppress non-essential output]' \
         '--no-color[Disable colored output]' \
+        '--no-persist[Keep conversations in memory only]' \
         '--tui[Use rich TUI interface]' \
         '--plain[Use plain scrollback mode]' \
         '-v[Show version]' \
@@ -1125,7 +1313,7 @@ _buckley() {
                     _values 'agent command' init list check show info subagents run invoke
                     ;;
                 skills|skill)
-                    _values 'skills command' init list show
+                    _values 'skills command' init list show validate
                     ;;
                 experiment)
                     _values 'experiment command' run
@@ -1134,7 +1322,7 @@ _buckley() {
                     _values 'eval command' init list run runs show artifacts
                     ;;
                 config)
-                    _values 'config command' check show path
+                    _values 'config command' check show path set diff init
                     ;;
                 doctor)
                     _values 'doctor command' check chat
@@ -1149,7 +1337,22 @@ _buckley() {
                     _values 'shell' bash zsh fish
                     ;;
                 db)
-                    _values 'db command' backup restore
+                    _values 'db command' backup restore prune-sessions
+                    ;;
+                sessions)
+                    _values 'sessions command' merge stats
+                    ;;
+                tokens)
+                    _values 'tokens command' count
+                    ;;
+                models)
+                    _values 'models command' list
+                    ;;
+                tool)
+                    _values 'tool command' list
+                    ;;
+                plan)
+                    _values 'plan command' validate
                     ;;
             esac
             ;;
@@ -1169,6 +1372,7 @@ complete -c buckley -f
 complete -c buckley -n __fish_use_subcommand -a plan -d 'Generate feature plan'
 complete -c buckley -n __fish_use_subcommand -a execute -d 'Execute a plan'
 complete -c buckley -n __fish_use_subcommand -a execute-task -d 'Execute single task'
+complete -c buckley -n __fish_use_subcommand -a skip-task -d 'Skip a plan task'
 complete -c buckley -n __fish_use_subcommand -a commit -d 'Create action-style commit'
 complete -c buckley -n __fish_use_subcommand -a pr -d 'Create pull request'
 complete -c buckley -n __fish_use_subcommand -a review -d 'Review local changes with repository context'
@@ -1187,12 +1391,19 @@ complete -c buckley -n __fish_use_subcommand -a lsp -d 'Start LSP server on stdi
 complete -c buckley -n __fish_use_subcommand -a acp -d 'Start ACP agent on stdio (Zed/JetBrains/Neovim)'
 complete -c buckley -n __fish_use_subcommand -a info -d 'Inspect resolved harness configuration and capabilities'
 complete -c buckley -n __fish_use_subcommand -a config -d 'Manage configuration'
+complete -c buckley -n __fish_use_subcommand -a validate-config -d 'Check a config file for errors and warnings'
 complete -c buckley -n __fish_use_subcommand -a completion -d 'Generate shell completions'
 complete -c buckley -n __fish_use_subcommand -a worktree -d 'Git worktree management'
 complete -c buckley -n __fish_use_subcommand -a rules -d 'Inspect Arbiter rules and fact contracts'
 complete -c buckley -n __fish_use_subcommand -a migrate -d 'Apply database migrations'
 complete -c buckley -n __fish_use_subcommand -a db -d 'Backup/restore SQLite DB'
+complete -c buckley -n __fish_use_subcommand -a embeddings -d 'Inspect or clear the embedding cache'
 complete -c buckley -n __fish_use_subcommand -a resume -d 'Resume a previous session'
+complete -c buckley -n __fish_use_subcommand -a sessions -d 'Manage saved sessions'
+complete -c buckley -n __fish_use_subcommand -a export -d 'Export saved sessions'
+complete -c buckley -n __fish_use_subcommand -a tokens -d 'Estimate tokens offline'
+complete -c buckley -n __fish_use_subcommand -a models -d 'Browse the model catalog'
+complete -c buckley -n __fish_use_subcommand -a tool -d 'List available tools'
 complete -c buckley -n __fish_use_subcommand -a doctor -d 'Quick system and chat health checks'
 complete -c buckley -n __fish_use_subcommand -a help -d 'Show help information'
 complete -c buckley -n __fish_use_subcommand -a version -d 'Show version information'
@@ -1201,8 +1412,11 @@ complete -c buckley -n __fish_use_subcommand -a version -d 'Show version informa
 complete -c buckley -s p -d 'Run prompt in one-shot mode'
 complete -c buckley -s c -l config -d 'Use custom config file' -r
 complete -c buckley -l agent -d 'Load a buckley.agent/v1 runtime profile' -r
+complete -c buckley -l max-cost -d 'Abort before spend exceeds this many cents' -r
+complete -c buckley -l dry-run -d 'Describe file writes and commands instead of running them'
 complete -c buckley -s q -l quiet -d 'Suppress non-essential output'
 complete -c buckley -l no-color -d 'Disable colored output'
+complete -c buckley -l no-persist -d 'Keep conversations in memory only'
 complete -c buckley -l tui -d 'Use rich TUI interface'
 complete -c buckley -l plain -d 'Use plain scrollback mode'
 complete -c buckley -s v -l version -d 'Show version'
@@ -1212,6 +1426,9 @@ complete -c buckley -s h -l help -d 'Show help'
 complete -c buckley -n '__fish_seen_subcommand_from config' -a check -d 'Validate configuration'
 complete -c buckley -n '__fish_seen_subcommand_from config' -a show -d 'Show current configuration'
 complete -c buckley -n '__fish_seen_subcommand_from config' -a path -d 'Show config file paths'
+complete -c buckley -n '__fish_seen_subcommand_from config' -a set -d 'Set a config key'
+complete -c buckley -n '__fish_seen_subcommand_from config' -a diff -d 'Show settings that differ from the defaults'
+complete -c buckley -n '__fish_seen_subcommand_from config' -a init -d 'Interactively create a config file'
 
 # Agent subcommands
 complete -c buckley -n '__fish_seen_subcommand_from agent' -a check -d 'Validate agent spec'
@@ -1227,6 +1444,7 @@ complete -c buckley -n '__fish_seen_subcommand_from agent' -a invoke -d 'Invoke
 complete -c buckley -n '__fish_seen_subcommand_from skills skill' -a init -d 'Create a project workflow skill'
 complete -c buckley -n '__fish_seen_subcommand_from skills skill' -a list -d 'List loaded workflow skills'
 complete -c buckley -n '__fish_seen_subcommand_from skills skill' -a show -d 'Inspect a loaded workflow skill'
+complete -c buckley -n '__fish_seen_subcommand_from skills skill' -a validate -d 'Check skill files for malformed definitions'
 
 # Doctor subcommands
 complete -c buckley -n '__fish_seen_subcommand_from doctor' -a check -d 'Validate configuration'
@@ -1255,6 +1473,13 @@ complete -c buckley -n '__fish_seen_subcommand_from completion' -a fish -d 'Gene
 # DB subcommands
 complete -c buckley -n '__fish_seen_subcommand_from db' -a backup -d 'Create a consistent SQLite backup'
 complete -c buckley -n '__fish_seen_subcommand_from db' -a restore -d 'Restore an SQLite backup'
+complete -c buckley -n '__fish_seen_subcommand_from db' -a prune-sessions -d 'Delete inactive sessions older than a date'
+complete -c buckley -n '__fish_seen_subcommand_from sessions' -a merge -d 'Append one session onto another'
+complete -c buckley -n '__fish_seen_subcommand_from sessions' -a stats -d 'Summarize usage across sessions'
+complete -c buckley -n '__fish_seen_subcommand_from tokens' -a count -d 'Count tokens in a file or stdin'
+complete -c buckley -n '__fish_seen_subcommand_from models' -a list -d 'List catalog models'
+complete -c buckley -n '__fish_seen_subcommand_from tool' -a list -d 'List available tools'
+complete -c buckley -n '__fish_seen_subcommand_from plan' -a validate -d 'Check plan task dependencies'
 
 # Batch subcommands
 complete -c buckley -n '__fish_seen_subcommand_from batch' -a prune-workspaces -d 'Garbage-collect stale batch workspaces'
@@ -1288,6 +1513,8 @@ func dispatchSubcommand(args []string) (bool, int) {
 		return true, runCommand(runAgentCommand, args[1:])
 	case "execute-task":
 		return true, runCommand(runExecuteTaskCommand, args[1:])
+	case "skip-task":
+		return true, runCommand(runSkipTaskCommand, args[1:])
 	case "commit":
 		return true, runCommand(runCommitCommand, args[1:])
 	case "pr":
@@ -1310,9 +1537,24 @@ func dispatchSubcommand(args []string) (bool, int) {
 		return true, 0
 	case "db":
 		return true, runCommand(runDBCommand, args[1:])
+	case "sessions":
+		return true, runCommand(runSessionsCommand, args[1:])
+	case "export":
+		return true, runCommand(runExportCommand, args[1:])
+	case "tokens":
+		return true, runCommand(runTokensCommand, args[1:])
+	case "models":
+		return true, runCommand(runModelsCommand, args[1:])
+	case "tool":
+		return true, runCommand(runToolCommand, args[1:])
+	case "embeddings":
+		return true, runCommand(runEmbeddingsCommand, args[1:])
 	case "worktree":
 		return true, runCommand(runWorktreeCommand, args[1:])
 	case "resume":
+		if isResumeListRequest(args[1:]) {
+			return true, runCommand(runResumeCommand, args[1:])
+		}
 		return false, 0
 	case "agent-server":
 		return true, runCommand(runAgentServerCommand, args[1:])
@@ -1330,6 +1572,8 @@ func dispatchSubcommand(args []string) (bool, int) {
 		return true, runCommand(runSkillsCommand, args[1:])
 	case "config":
 		return true, runCommand(runConfigCommand, args[1:])
+	case "validate-config":
+		return true, runCommand(runValidateConfigCommand, args[1:])
 	case "trust":
 		return true, runCommand(runTrustCommand, args[1:])
 	case "doctor":
@@ -1409,6 +1653,8 @@ func (s *startupFlagState) consumePending(opts *startupOptions, arg string) bool
 		opts.modelOverride = strings.TrimSpace(arg)
 	case startupPendingAgent:
 		opts.agentPath = strings.TrimSpace(arg)
+	case startupPendingMaxCost:
+		opts.maxCost = arg
 	default:
 		return false
 	}
@@ -1445,6 +1691,8 @@ func (s *startupFlagState) consumeStartupFlag(opts *startupOptions, arg string,
 		opts.quiet = true
 	case "--no-color":
 		opts.noColor = true
+	case "--no-persist":
+		opts.noPersist = true
 	case "--config", "-c":
 		s.pending = startupPendingConfig
 	case "--model", "-m":
@@ -1459,6 +1707,17 @@ func (s *startupFlagState) consumeStartupFlag(opts *startupOptions, arg string,
 		}
 		s.pending = startupPendingAgent
 		s.agentFlagSeen = true
+	case "--max-cost":
+		if !beforeCommand {
+			return false
+		}
+		s.pending = startupPendingMaxCost
+		s.maxCostFlagSeen = true
+	case "--dry-run":
+		if !beforeCommand {
+			return false
+		}
+		opts.dryRun = true
 	default:
 		return s.consumeStartupValueFlag(opts, arg, beforeCommand)
 	}
@@ -1480,6 +1739,11 @@ func (s *startupFlagState) consumeStartupValueFlag(opts *startupOptions, arg str
 		s.agentFlagSeen = true
 		return true
 	}
+	if strings.HasPrefix(arg, "--max-cost=") && beforeCommand {
+		opts.maxCost = strings.TrimPrefix(arg, "--max-cost=")
+		s.maxCostFlagSeen = true
+		return true
+	}
 	return false
 }
 
@@ -1495,6 +1759,8 @@ func (s startupFlagState) validate(opts *startupOptions) error {
 		return fmt.Errorf("--model requires a value")
 	case startupPendingAgent:
 		return fmt.Errorf("--agent requires a path")
+	case startupPendingMaxCost:
+		return fmt.Errorf("--max-cost requires a value in cents")
 	}
 	if s.modelFlagSeen && strings.TrimSpace(opts.modelOverride) == "" {
 		return fmt.Errorf("--model requires a value")
@@ -1502,11 +1768,18 @@ func (s startupFlagState) validate(opts *startupOptions) error {
 	if s.agentFlagSeen && strings.TrimSpace(opts.agentPath) == "" {
 		return fmt.Errorf("--agent requires a path")
 	}
+	if s.maxCostFlagSeen {
+		cents, err := parseMaxCostCents(opts.maxCost)
+		if err != nil {
+			return err
+		}
+		opts.maxCostCents = cents
+	}
 	return nil
 }
 
 func (o *startupOptions) consumeResumeCommand() error {
-	if len(o.args) == 0 || o.args[0] != "resume" {
+	if len(o.args) == 0 || o.args[0] != "resume" || isResumeListRequest(o.args[1:]) {
 		return nil
 	}
 	if len(o.args) < 2 {
@@ -1548,6 +1821,22 @@ func applySandboxOverride(cfg *config.Config) {
 	}
 }
 
+// applyNoPersistOverride turns on ephemeral mode when --no-persist was given.
+func applyNoPersistOverride(cfg *config.Config) {
+	if cfg != nil && noPersistFlag {
+		cfg.Persistence.Ephemeral = true
+	}
+}
+
+// configureStorePersistence applies the persistence config to an opened store.
+func configureStorePersistence(store *storage.Store, cfg *config.Config) {
+	if store == nil || cfg == nil {
+		return
+	}
+	store.SetEphemeral(cfg.Persistence.Ephemeral)
+	store.SetTelemetryPersistence(!cfg.Persistence.DisableTelemetry)
+}
+
 func applyStartupModelOverride(cfg *config.Config, modelID string) {
 	if cfg == nil {
 		return
@@ -1726,6 +2015,27 @@ func sanitizeTerminalInput(input string) string {
 	return ansiEscapePattern.ReplaceAllString(input, "")
 }
 
+// runDependencyChecks runs the interactive setup checker. Tests replace it to
+// observe whether startup would have checked dependencies.
+var runDependencyChecks = func() error {
+	return resolveDependencies(setup.NewChecker())
+}
+
+// needsDependencyChecks reports whether startup should run the setup checker.
+// Subcommands return from dispatchSubcommand before reaching it;
+// BUCKLEY_SKIP_SETUP_CHECKS=1 skips it for interactive and one-shot runs too.
+func needsDependencyChecks() bool {
+	skip, ok := parseBoolEnv("BUCKLEY_SKIP_SETUP_CHECKS")
+	return !ok || !skip
+}
+
+func checkStartupDependencies() error {
+	if !needsDependencyChecks() {
+		return nil
+	}
+	return runDependencyChecks()
+}
+
 func resolveDependencies(checker *setup.Checker) error {
 	missing, err := checker.CheckAll()
 	if err != nil {
@@ -1845,7 +2155,7 @@ func humanReadableURL(bind string) string {
 	if port == "" {
 		return fmt.Sprintf("http://%s", host)
 	}
-	return fmt.Sprintf("http://%s:%s", host, port)
+	return "http://" + net.JoinHostPort(host, port)
 }
 
 type acpEventStoreHandle struct {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"io"
@@ -38,6 +39,65 @@ func TestParseBoolEnv(t *testing.T) {
 	}
 }
 
+func stubDependencyChecks(t *testing.T) *int {
+	t.Helper()
+	calls := 0
+	prev := runDependencyChecks
+	runDependencyChecks = func() error {
+		calls++
+		return nil
+	}
+	t.Cleanup(func() { runDependencyChecks = prev })
+	return &calls
+}
+
+func TestCheckStartupDependenciesRunsForInteractiveStartup(t *testing.T) {
+	t.Setenv("BUCKLEY_SKIP_SETUP_CHECKS", "")
+	calls := stubDependencyChecks(t)
+
+	if err := checkStartupDependencies(); err != nil {
+		t.Fatalf("checkStartupDependencies error: %v", err)
+	}
+	if *calls != 1 {
+		t.Fatalf("dependency checks ran %d times, want 1", *calls)
+	}
+}
+
+func TestCheckStartupDependenciesHonorsSkipEnv(t *testing.T) {
+	t.Setenv("BUCKLEY_SKIP_SETUP_CHECKS", "1")
+	calls := stubDependencyChecks(t)
+
+	if err := checkStartupDependencies(); err != nil {
+		t.Fatalf("checkStartupDependencies error: %v", err)
+	}
+	if *calls != 0 {
+		t.Fatalf("dependency checks ran %d times with BUCKLEY_SKIP_SETUP_CHECKS=1", *calls)
+	}
+}
+
+func TestLightweightCommandsBypassStartupDependencyChecks(t *testing.T) {
+	t.Setenv("BUCKLEY_SKIP_SETUP_CHECKS", "")
+	t.Setenv("HOME", t.TempDir())
+	calls := stubDependencyChecks(t)
+
+	for _, args := range [][]string{
+		{"config", "path"},
+		{"validate-config", filepath.Join(t.TempDir(), "missing.yaml")},
+		{"completion", "bash"},
+		{"--version"},
+		{"help"},
+	} {
+		captureStdout(t, func() {
+			if handled, _ := dispatchSubcommand(args); !handled {
+				t.Fatalf("dispatchSubcommand(%v) fell through to startup", args)
+			}
+		})
+	}
+	if *calls != 0 {
+		t.Fatalf("dependency checks ran %d times for lightweight commands", *calls)
+	}
+}
+
 func TestParseStartupOptionsFlagsAndFiltering(t *testing.T) {
 	t.Setenv("BUCKLEY_QUIET", "1")
 	raw := []string{"--encoding=json", "--model", "codex/gpt-5.4-mini", "--agent", "agent.yaml", "-p", "hello", "--config=proj.yaml", "plan", "feat", "do", "thing"}
@@ -68,6 +128,61 @@ func TestParseStartupOptionsFlagsAndFiltering(t *testing.T) {
 	}
 }
 
+func TestParseStartupOptionsNoPersist(t *testing.T) {
+	opts, err := parseStartupOptions([]string{"--no-persist", "-p", "hello"})
+	if err != nil {
+		t.Fatalf("parseStartupOptions error: %v", err)
+	}
+	if !opts.noPersist {
+		t.Fatal("expected --no-persist to set noPersist")
+	}
+	if len(opts.args) != 0 {
+		t.Fatalf("args=%v want none", opts.args)
+	}
+}
+
+func TestParseStartupOptionsDryRun(t *testing.T) {
+	opts, err := parseStartupOptions([]string{"--dry-run", "-p", "hello"})
+	if err != nil {
+		t.Fatalf("parseStartupOptions error: %v", err)
+	}
+	if !opts.dryRun || opts.prompt != "hello" {
+		t.Fatalf("dryRun=%v prompt=%q", opts.dryRun, opts.prompt)
+	}
+
+	opts, err = parseStartupOptions([]string{"agent", "init", "--dry-run"})
+	if err != nil {
+		t.Fatalf("parseStartupOptions after command error: %v", err)
+	}
+	if opts.dryRun || len(opts.args) != 3 {
+		t.Fatalf("expected --dry-run after a command to reach the subcommand, got dryRun=%v args=%v", opts.dryRun, opts.args)
+	}
+}
+
+func TestParseStartupOptionsMaxCost(t *testing.T) {
+	opts, err := parseStartupOptions([]string{"--max-cost", "12.5", "-p", "hello"})
+	if err != nil {
+		t.Fatalf("parseStartupOptions error: %v", err)
+	}
+	if opts.maxCostCents != 12.5 {
+		t.Fatalf("maxCostCents=%v want 12.5", opts.maxCostCents)
+	}
+
+	opts, err = parseStartupOptions([]string{"execute", "--max-cost=50", "p1"})
+	if err != nil {
+		t.Fatalf("parseStartupOptions after command error: %v", err)
+	}
+	if opts.maxCostCents != 0 || len(opts.args) != 3 {
+		t.Fatalf("expected --max-cost after a command to reach the subcommand, got cents=%v args=%v", opts.maxCostCents, opts.args)
+	}
+
+	for _, raw := range [][]string{{"--max-cost"}, {"--max-cost", "-3"}, {"--max-cost=lots"}} {
+		if _, err := parseStartupOptions(raw); err == nil {
+			t.Fatalf("expected error for %v", raw)
+		}
+	}
+}
+
 func TestParseStartupOptionsMissingValues(t *testing.T) {
 	_, err := parseStartupOptions([]string{"-p"})
 	if err == nil {
@@ -485,6 +600,7 @@ type fakeOrchestrator struct {
 	description       string
 	loadedPlanID      string
 	executedPlan      bool
+	resumedPlan       bool
 	executedTaskID    string
 	plan              *orchestrator.Plan
 }
@@ -512,6 +628,11 @@ func (f *fakeOrchestrator) ExecutePlan() error {
 	return nil
 }
 
+func (f *fakeOrchestrator) ResumeExecution() error {
+	f.resumedPlan = true
+	return nil
+}
+
 func (f *fakeOrchestrator) ExecuteTask(taskID string) error {
 	f.executedTaskID = taskID
 	return nil
@@ -566,6 +687,122 @@ func TestRunPlanAndExecuteCommandsViaHarness(t *testing.T) {
 	}
 }
 
+func TestRunPlanCommandJSONOutput(t *testing.T) {
+	origInit := initDependenciesFn
+	origNewOrch := newOrchestratorFn
+	t.Cleanup(func() {
+		initDependenciesFn = origInit
+		newOrchestratorFn = origNewOrch
+	})
+
+	initDependenciesFn = func() (*config.Config, *model.Manager, *storage.Store, error) {
+		store, err := storage.New(filepath.Join(t.TempDir(), "cli.db"))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return config.DefaultConfig(), nil, store, nil
+	}
+	fake := &fakeOrchestrator{plan: &orchestrator.Plan{
+		ID:          "p1",
+		FeatureName: "auth",
+		Tasks: []orchestrator.Task{
+			{ID: "1", Title: "Add login"},
+			{ID: "2", Title: "Add logout", Dependencies: []string{"1"}},
+		},
+	}}
+	newOrchestratorFn = func(store *storage.Store, mgr *model.Manager, registry *tool.Registry, cfg *config.Config, workflow *orchestrator.WorkflowManager, planStore orchestrator.PlanStore) orchestratorRunner {
+		return fake
+	}
+
+	out := captureStdout(t, func() {
+		if err := runPlanCommand([]string{"--format", "json", "auth", "add", "login"}); err != nil {
+			t.Fatalf("runPlanCommand: %v", err)
+		}
+	})
+	var plan orchestrator.Plan
+	if err := json.Unmarshal([]byte(out), &plan); err != nil {
+		t.Fatalf("stdout is not a JSON plan: %v\n%s", err, out)
+	}
+	if plan.ID != "p1" || len(plan.Tasks) != 2 || plan.Tasks[1].Dependencies[0] != "1" {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	out = captureStdout(t, func() {
+		if err := runPlanCommand([]string{"--format", "json", "--output", path, "auth", "add", "login"}); err != nil {
+			t.Fatalf("runPlanCommand --output: %v", err)
+		}
+	})
+	if out != "" {
+		t.Fatalf("expected nothing on stdout with --output, got %q", out)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if err := json.Unmarshal(data, &plan); err != nil || plan.FeatureName != "auth" {
+		t.Fatalf("output file is not the plan: %v\n%s", err, data)
+	}
+
+	if err := runPlanCommand([]string{"--format", "yaml", "auth", "x"}); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Fatalf("expected unknown format error, got %v", err)
+	}
+}
+
+func TestRunExecuteCommandResume(t *testing.T) {
+	origInit := initDependenciesFn
+	origNewOrch := newOrchestratorFn
+	t.Cleanup(func() {
+		initDependenciesFn = origInit
+		newOrchestratorFn = origNewOrch
+	})
+
+	initDependenciesFn = func() (*config.Config, *model.Manager, *storage.Store, error) {
+		store, err := storage.New(filepath.Join(t.TempDir(), "cli.db"))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return config.DefaultConfig(), nil, store, nil
+	}
+	fake := &fakeOrchestrator{plan: &orchestrator.Plan{
+		ID:          "p1",
+		FeatureName: "auth",
+		Tasks: []orchestrator.Task{
+			{ID: "1", Title: "Add login", Status: orchestrator.TaskCompleted},
+			{ID: "2", Title: "Add logout", Status: orchestrator.TaskFailed, Dependencies: []string{"1"}},
+		},
+	}}
+	newOrchestratorFn = func(store *storage.Store, mgr *model.Manager, registry *tool.Registry, cfg *config.Config, workflow *orchestrator.WorkflowManager, planStore orchestrator.PlanStore) orchestratorRunner {
+		return fake
+	}
+
+	out := captureStdout(t, func() {
+		if err := runExecuteCommand([]string{"--resume", "p1"}); err != nil {
+			t.Fatalf("runExecuteCommand --resume: %v", err)
+		}
+	})
+	if !fake.resumedPlan || fake.executedPlan {
+		t.Fatalf("expected ResumeExecution only, got %+v", fake)
+	}
+	if !strings.Contains(out, "Resuming at task 2: Add logout (1/2 done)") {
+		t.Fatalf("unexpected resume output: %q", out)
+	}
+
+	fake.resumedPlan = false
+	fake.plan.Tasks[1].Status = orchestrator.TaskCompleted
+	out = captureStdout(t, func() {
+		if err := runExecuteCommand([]string{"--resume", "p1"}); err != nil {
+			t.Fatalf("runExecuteCommand --resume: %v", err)
+		}
+	})
+	if fake.resumedPlan || fake.executedPlan {
+		t.Fatalf("expected nothing to run for a finished plan, got %+v", fake)
+	}
+	if !strings.Contains(out, "All tasks already complete") {
+		t.Fatalf("unexpected resume output: %q", out)
+	}
+}
+
 func TestRunExecuteTaskCommandHarness(t *testing.T) {
 	origInit := initDependenciesFn
 	origNewOrch := newOrchestratorFn
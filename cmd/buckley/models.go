@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/model"
+)
+
+const modelsListUsage = "usage: buckley models list [--provider <id>] [--contains <text>] [--supports-tools] [--json]"
+
+// modelCatalogSource is the part of model.Manager that models list reads.
+type modelCatalogSource interface {
+	GetCatalog() *model.ModelCatalog
+	ProviderIDForModel(modelID string) string
+	SupportsReasoning(modelID string) bool
+	SupportsTools(modelID string) bool
+}
+
+// modelListEntry is one row of buckley models list, and its --json shape.
+type modelListEntry struct {
+	ID            string `json:"id"`
+	Provider      string `json:"provider"`
+	ContextLength int    `json:"contextLength"`
+	Reasoning     bool   `json:"reasoning"`
+	Tools         bool   `json:"tools"`
+}
+
+func runModelsCommand(args []string) error {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.TrimSpace(args[0])
+	}
+	if sub != "list" {
+		return withExitCode(fmt.Errorf(modelsListUsage), 2)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+	}
+	if !cfg.Providers.HasReadyProvider() {
+		return withExitCode(fmt.Errorf("no model provider configured; set OPENROUTER_API_KEY or enable another provider"), 2)
+	}
+	mgr, err := model.NewManager(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create model manager: %w", err)
+	}
+	if err := mgr.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize model manager: %w", err)
+	}
+	return runModelsList(args[1:], mgr, os.Stdout)
+}
+
+func runModelsList(args []string, source modelCatalogSource, out io.Writer) error {
+	fs := flag.NewFlagSet("models list", flag.ContinueOnError)
+	provider := fs.String("provider", "", "Only list models served by this provider")
+	contains := fs.String("contains", "", "Only list models whose ID contains this text (case-insensitive)")
+	supportsTools := fs.Bool("supports-tools", false, "Only list models that support tool calling")
+	jsonOut := fs.Bool("json", false, "Print the models as JSON")
+	if err := fs.Parse(args); err != nil {
+		return withExitCode(err, 2)
+	}
+	if fs.NArg() > 0 {
+		return withExitCode(fmt.Errorf(modelsListUsage), 2)
+	}
+
+	entries := listCatalogModels(source, strings.TrimSpace(*provider), strings.ToLower(strings.TrimSpace(*contains)), *supportsTools)
+	if *jsonOut {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No models match.")
+		return nil
+	}
+	return writeModelList(out, entries)
+}
+
+// listCatalogModels returns the catalog models matching the filters, sorted
+// by provider and then ID.
+func listCatalogModels(source modelCatalogSource, provider, contains string, supportsTools bool) []modelListEntry {
+	entries := []modelListEntry{}
+	catalog := source.GetCatalog()
+	if catalog == nil {
+		return entries
+	}
+	for _, info := range catalog.Data {
+		entry := modelListEntry{
+			ID:            info.ID,
+			Provider:      source.ProviderIDForModel(info.ID),
+			ContextLength: info.ContextLength,
+			Reasoning:     source.SupportsReasoning(info.ID),
+			Tools:         source.SupportsTools(info.ID),
+		}
+		if provider != "" && entry.Provider != provider {
+			continue
+		}
+		if contains != "" && !strings.Contains(strings.ToLower(entry.ID), contains) {
+			continue
+		}
+		if supportsTools && !entry.Tools {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Provider != entries[j].Provider {
+			return entries[i].Provider < entries[j].Provider
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+func writeModelList(out io.Writer, entries []modelListEntry) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for i, entry := range entries {
+		if i == 0 || entries[i-1].Provider != entry.Provider {
+			if i > 0 {
+				fmt.Fprintln(tw)
+			}
+			fmt.Fprintf(tw, "%s (%d models)\n", firstNonEmpty(entry.Provider, "(unknown)"), countProviderModels(entries[i:], entry.Provider))
+			fmt.Fprintln(tw, "  MODEL\tCONTEXT\tREASONING\tTOOLS")
+		}
+		window := "-"
+		if entry.ContextLength > 0 {
+			window = fmt.Sprintf("%d", entry.ContextLength)
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", entry.ID, window, yesNo(entry.Reasoning), yesNo(entry.Tools))
+	}
+	return tw.Flush()
+}
+
+func countProviderModels(entries []modelListEntry, provider string) int {
+	n := 0
+	for _, entry := range entries {
+		if entry.Provider != provider {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
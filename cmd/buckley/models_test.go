@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/model"
+)
+
+type fakeModelCatalog struct {
+	models    []model.ModelInfo
+	providers map[string]string
+	reasoning map[string]bool
+	tools     map[string]bool
+}
+
+func (f fakeModelCatalog) GetCatalog() *model.ModelCatalog {
+	return &model.ModelCatalog{Data: f.models}
+}
+
+func (f fakeModelCatalog) ProviderIDForModel(id string) string { return f.providers[id] }
+func (f fakeModelCatalog) SupportsReasoning(id string) bool    { return f.reasoning[id] }
+func (f fakeModelCatalog) SupportsTools(id string) bool        { return f.tools[id] }
+
+func testModelCatalog() fakeModelCatalog {
+	return fakeModelCatalog{
+		models: []model.ModelInfo{
+			{ID: "openai/gpt-5", ContextLength: 400000},
+			{ID: "anthropic/claude-sonnet-4-5", ContextLength: 200000},
+			{ID: "openai/gpt-5-nano"},
+		},
+		providers: map[string]string{
+			"openai/gpt-5":                "openrouter",
+			"anthropic/claude-sonnet-4-5": "anthropic",
+			"openai/gpt-5-nano":           "openrouter",
+		},
+		reasoning: map[string]bool{"openai/gpt-5": true},
+		tools:     map[string]bool{"openai/gpt-5": true, "anthropic/claude-sonnet-4-5": true},
+	}
+}
+
+func TestRunModelsListGroupsByProvider(t *testing.T) {
+	var out bytes.Buffer
+	if err := runModelsList(nil, testModelCatalog(), &out); err != nil {
+		t.Fatalf("runModelsList: %v", err)
+	}
+	text := out.String()
+	anthropic := strings.Index(text, "anthropic (1 models)")
+	openrouter := strings.Index(text, "openrouter (2 models)")
+	if anthropic < 0 || openrouter < anthropic {
+		t.Fatalf("providers not grouped in order:\n%s", text)
+	}
+	if !strings.Contains(text, "400000") || !strings.Contains(text, "openai/gpt-5-nano") {
+		t.Fatalf("missing model rows:\n%s", text)
+	}
+}
+
+func TestRunModelsListFiltersJSON(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"--provider", "openrouter", "--contains", "GPT", "--supports-tools", "--json"}
+	if err := runModelsList(args, testModelCatalog(), &out); err != nil {
+		t.Fatalf("runModelsList: %v", err)
+	}
+	var entries []modelListEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out.String())
+	}
+	if len(entries) != 1 || entries[0].ID != "openai/gpt-5" || !entries[0].Reasoning || !entries[0].Tools {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	out.Reset()
+	if err := runModelsList([]string{"--provider", "none", "--json"}, testModelCatalog(), &out); err != nil {
+		t.Fatalf("runModelsList: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Fatalf("empty JSON output = %q", out.String())
+	}
+}
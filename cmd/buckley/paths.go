@@ -34,6 +34,20 @@ func resolveDBPath() (string, error) {
 	return filepath.Join(home, ".buckley", "buckley.db"), nil
 }
 
+// resolveDataDir returns the directory Buckley keeps its databases and
+// per-user state in.
+func resolveDataDir() (string, error) {
+	if dir := strings.TrimSpace(os.Getenv(envBuckleyDataDir)); dir != "" {
+		return expandHomePath(dir)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".buckley"), nil
+}
+
 func resolveACPEventsDBPath() (string, error) {
 	if path := strings.TrimSpace(os.Getenv(envBuckleyACPEventsDBPath)); path != "" {
 		return expandHomePath(path)
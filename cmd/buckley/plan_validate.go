@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/orchestrator"
+)
+
+func runPlanValidateCommand(args []string) error {
+	if len(args) != 1 || strings.TrimSpace(args[0]) == "" {
+		return withExitCode(fmt.Errorf("usage: buckley plan validate <plan-id>"), 2)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+	}
+	return runPlanValidate(strings.TrimSpace(args[0]), orchestrator.NewFilePlanStore(cfg.Artifacts.PlanningDir), os.Stdout)
+}
+
+// runPlanValidate checks a saved plan's task dependency graph and prints each
+// problem. It exits non-zero when any are found so CI can gate execute.
+func runPlanValidate(planID string, planStore orchestrator.PlanStore, out io.Writer) error {
+	plan, err := planStore.LoadPlan(planID)
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load plan: %w", err), 2)
+	}
+
+	issues := orchestrator.ValidatePlanGraph(plan)
+	if len(issues) == 0 {
+		fmt.Fprintf(out, "✓ Plan %s is valid (%d tasks)\n", plan.ID, len(plan.Tasks))
+		return nil
+	}
+	fmt.Fprintf(out, "✗ Plan %s has %d dependency problem(s):\n", plan.ID, len(issues))
+	for _, issue := range issues {
+		fmt.Fprintf(out, "  %s [%s]: %s\n", issue.Kind, strings.Join(issue.TaskIDs, ", "), issue.Message)
+	}
+	return withExitCode(fmt.Errorf("plan %s failed validation", plan.ID), 1)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/orchestrator"
+)
+
+func TestRunPlanValidate(t *testing.T) {
+	planStore := orchestrator.NewFilePlanStore(t.TempDir())
+	if err := planStore.SavePlan(&orchestrator.Plan{
+		ID: "good",
+		Tasks: []orchestrator.Task{
+			{ID: "1", Title: "Schema"},
+			{ID: "2", Title: "API", Dependencies: []string{"1"}},
+		},
+	}); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+	if err := planStore.SavePlan(&orchestrator.Plan{
+		ID: "bad",
+		Tasks: []orchestrator.Task{
+			{ID: "1", Title: "Schema", Dependencies: []string{"2"}},
+			{ID: "2", Title: "API", Dependencies: []string{"1"}},
+			{ID: "3", Title: "UI", Dependencies: []string{"missing"}},
+		},
+	}); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runPlanValidate("good", planStore, &out); err != nil {
+		t.Fatalf("runPlanValidate(good): %v", err)
+	}
+	if !strings.Contains(out.String(), "Plan good is valid (2 tasks)") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	err := runPlanValidate("bad", planStore, &out)
+	if err == nil || exitCodeForError(err) != 1 {
+		t.Fatalf("runPlanValidate(bad) = %v, want exit code 1", err)
+	}
+	for _, want := range []string{
+		"2 dependency problem(s)",
+		"missing_dependency [3, missing]",
+		"cycle [1, 2]: dependency cycle: 1 -> 2 -> 1",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	if err := runPlanValidate("absent", planStore, &out); exitCodeForError(err) != 2 {
+		t.Fatalf("runPlanValidate(absent) = %v, want exit code 2", err)
+	}
+	if err := runPlanValidateCommand(nil); exitCodeForError(err) != 2 {
+		t.Fatalf("runPlanValidateCommand(no args) = %v, want usage error", err)
+	}
+}
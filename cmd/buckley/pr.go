@@ -28,6 +28,8 @@ type prCommandOptions struct {
 	verbose  bool
 	showCost bool
 	base     string
+	from     string
+	to       string
 	model    string
 	backend  string
 	timeout  time.Duration
@@ -53,6 +55,8 @@ func parsePRCommandOptions(args []string) (prCommandOptions, error) {
 	yes := fs.Bool("yes", false, "skip confirmation prompts and create the PR")
 	pushFlag := fs.Bool("push", true, "push current branch before creating PR")
 	baseFlag := fs.String("base", "", "base branch (default: auto-detect main/master)")
+	fromFlag := fs.String("from", "", "start of an explicit range; the PR describes --from..--to instead of the inferred base")
+	toFlag := fs.String("to", "", "end of an explicit range (the branch to open the PR from); requires --from")
 	verbose := fs.Bool("verbose", false, "show model reasoning and full trace")
 	showCost := fs.Bool("cost", true, "show token/cost breakdown")
 	modelFlag := fs.String("model", "", "model to use (default: BUCKLEY_MODEL_PR or models.utility.pr for API backend)")
@@ -62,6 +66,13 @@ func parsePRCommandOptions(args []string) (prCommandOptions, error) {
 	if err := fs.Parse(args); err != nil {
 		return prCommandOptions{}, err
 	}
+	from, to := strings.TrimSpace(*fromFlag), strings.TrimSpace(*toFlag)
+	if (from == "") != (to == "") {
+		return prCommandOptions{}, withExitCode(fmt.Errorf("--from and --to must be used together"), 2)
+	}
+	if from != "" && *baseFlag != "" {
+		return prCommandOptions{}, withExitCode(fmt.Errorf("--base cannot be combined with --from/--to; --from is the base"), 2)
+	}
 	backend, err := resolveOneshotBackend("pr", *backendFlag)
 	if err != nil {
 		return prCommandOptions{}, err
@@ -73,18 +84,34 @@ func parsePRCommandOptions(args []string) (prCommandOptions, error) {
 		verbose:  *verbose,
 		showCost: *showCost,
 		base:     *baseFlag,
+		from:     from,
+		to:       to,
 		model:    *modelFlag,
 		backend:  backend,
 		timeout:  *timeout,
 	}, nil
 }
 
+// explicitRange reports whether --from/--to replace base inference.
+func (o prCommandOptions) explicitRange() bool {
+	return o.from != ""
+}
+
 // runPRCommand generates a structured PR via tool-use.
 func runPRCommand(args []string) error {
 	opts, err := parsePRCommandOptions(args)
 	if err != nil {
 		return err
 	}
+	definition := commands.PRDefinition{}
+	if opts.explicitRange() {
+		if err := validatePRRange(opts.from, opts.to); err != nil {
+			return err
+		}
+		definition = commands.PRDefinition{BaseBranch: opts.from, HeadRef: opts.to}
+	} else {
+		definition.BaseBranch = resolveEvidenceBase(opts.base)
+	}
 
 	runtime, cleanup, err := newPRCommandRuntime(opts)
 	defer cleanup()
@@ -99,12 +126,12 @@ func runPRCommand(args []string) error {
 		termOut.Dim("Using %s", describeOneshotBackend(runtime.backend, runtime.modelID))
 	}
 
-	result, err := runPRGeneration(ctx, runtime.framework, resolveEvidenceBase(opts.base))
+	result, err := runPRGeneration(ctx, runtime.framework, definition)
 	if err != nil {
 		return err
 	}
 
-	pr, baseBranch, err := renderPRGenerationResult(opts, result, runtime.ledger)
+	pr, branch, baseBranch, err := renderPRGenerationResult(opts, result, runtime.ledger)
 	if err != nil {
 		return err
 	}
@@ -116,13 +143,18 @@ func runPRCommand(args []string) error {
 		return err
 	}
 
-	if opts.push {
+	// An explicit range names refs that may not be checked out, so there is
+	// no current branch to push; --to must already be on the remote.
+	headBranch := ""
+	if opts.explicitRange() {
+		headBranch = branch
+	} else if opts.push {
 		if err := pushCurrentBranch(); err != nil {
 			return err
 		}
 	}
 
-	if err := createPR(pr, baseBranch); err != nil {
+	if err := createPR(pr, baseBranch, headBranch); err != nil {
 		return err
 	}
 
@@ -173,11 +205,11 @@ func newPRCommandRuntime(opts prCommandOptions) (*prCommandRuntime, func(), erro
 	}, cleanup, nil
 }
 
-func runPRGeneration(ctx context.Context, framework *oneshot.Framework, baseBranch string) (*prRunResult, error) {
+func runPRGeneration(ctx context.Context, framework *oneshot.Framework, definition commands.PRDefinition) (*prRunResult, error) {
 	spinner := terminal.NewSpinner("Generating PR...")
 	spinner.Start()
 
-	fwResult, err := framework.Run(ctx, commands.PRDefinition{BaseBranch: baseBranch}, oneshot.RunOpts{})
+	fwResult, err := framework.Run(ctx, definition, oneshot.RunOpts{})
 	result := prRunResultFromFramework(fwResult)
 	if err != nil {
 		result.Error = err
@@ -211,7 +243,7 @@ func prRunResultFromFramework(fwResult *oneshot.RunResult) *prRunResult {
 	return result
 }
 
-func renderPRGenerationResult(opts prCommandOptions, result *prRunResult, ledger *transparency.CostLedger) (*commands.PRResult, string, error) {
+func renderPRGenerationResult(opts prCommandOptions, result *prRunResult, ledger *transparency.CostLedger) (pr *commands.PRResult, branch, baseBranch string, err error) {
 	if opts.verbose && result.ContextAudit != nil {
 		printContextAudit(result.ContextAudit)
 	}
@@ -220,18 +252,22 @@ func renderPRGenerationResult(opts prCommandOptions, result *prRunResult, ledger
 	}
 	if result.Error != nil {
 		printError(result.Error, result.Trace)
-		return nil, "", result.Error
+		return nil, "", "", result.Error
 	}
 	if result.PR == nil {
-		return nil, "", fmt.Errorf("no PR generated")
+		return nil, "", "", fmt.Errorf("no PR generated")
 	}
 
-	branch, baseBranch := detectPRBranches(opts.base)
+	if opts.explicitRange() {
+		branch, baseBranch = stripRemotePrefix(opts.to), stripRemotePrefix(opts.from)
+	} else {
+		branch, baseBranch = detectPRBranches(opts.base)
+	}
 	printPR(result.PR, branch, baseBranch)
 	if opts.showCost && result.Trace != nil {
 		printCost(result.Trace, ledger)
 	}
-	return result.PR, baseBranch, nil
+	return result.PR, branch, baseBranch, nil
 }
 
 func confirmPRCreation(opts prCommandOptions) error {
@@ -272,6 +308,37 @@ func resolveEvidenceBase(baseFlag string) string {
 	return short
 }
 
+// validatePRRange checks that both ends of an explicit range resolve to
+// commits and that the range is not empty.
+func validatePRRange(from, to string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitLocalTimeout)
+	defer cancel()
+
+	for _, ref := range []string{from, to} {
+		if err := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Run(); err != nil {
+			return withExitCode(fmt.Errorf("git ref %q not found (fetch it first in shallow or detached checkouts)", ref), 2)
+		}
+	}
+	out, err := exec.CommandContext(ctx, "git", "rev-list", "--count", from+".."+to).Output()
+	if err != nil {
+		return fmt.Errorf("git rev-list %s..%s: %w", from, to, err)
+	}
+	if strings.TrimSpace(string(out)) == "0" {
+		return withExitCode(fmt.Errorf("no commits in %s..%s; nothing to open a PR for", from, to), 2)
+	}
+	return nil
+}
+
+// stripRemotePrefix turns a remote-tracking ref like origin/main into the
+// branch name gh expects.
+func stripRemotePrefix(ref string) string {
+	remote := os.Getenv("BUCKLEY_REMOTE_NAME")
+	if remote == "" {
+		remote = "origin"
+	}
+	return strings.TrimPrefix(ref, remote+"/")
+}
+
 // prShortBase resolves the short base branch name (flag or auto-detected).
 func prShortBase(baseFlag string) (string, bool) {
 	if baseFlag != "" {
@@ -363,7 +430,9 @@ func pushCurrentBranch() error {
 	return nil
 }
 
-func createPR(pr *commands.PRResult, baseBranch string) error {
+// createPR opens the PR with gh. headBranch is passed as --head when set;
+// otherwise gh uses the current branch.
+func createPR(pr *commands.PRResult, baseBranch, headBranch string) error {
 	// Check for gh CLI
 	if _, err := exec.LookPath("gh"); err != nil {
 		return fmt.Errorf("gh CLI not found (install from https://cli.github.com)")
@@ -378,11 +447,15 @@ func createPR(pr *commands.PRResult, baseBranch string) error {
 	// Create PR using gh
 	body := pr.FormatBody()
 
-	cmd := exec.CommandContext(ctx, "gh", "pr", "create",
+	args := []string{"pr", "create",
 		"--title", pr.Header(),
 		"--body", body,
 		"--base", baseBranch,
-	)
+	}
+	if headBranch != "" {
+		args = append(args, "--head", headBranch)
+	}
+	cmd := exec.CommandContext(ctx, "gh", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("failed: %s", strings.TrimSpace(string(output))))
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -77,6 +79,50 @@ func TestParsePRCommandOptionsHonorsEnvironment(t *testing.T) {
 	}
 }
 
+func TestParsePRCommandOptionsExplicitRange(t *testing.T) {
+	opts, err := parsePRCommandOptions([]string{"--from", "origin/main", "--to", "feature"})
+	if err != nil {
+		t.Fatalf("parsePRCommandOptions: %v", err)
+	}
+	if !opts.explicitRange() || opts.from != "origin/main" || opts.to != "feature" {
+		t.Fatalf("from=%q to=%q, want origin/main..feature", opts.from, opts.to)
+	}
+
+	for _, args := range [][]string{
+		{"--from", "main"},
+		{"--to", "feature"},
+		{"--from", "main", "--to", "feature", "--base", "develop"},
+	} {
+		_, err := parsePRCommandOptions(args)
+		if err == nil || exitCodeForError(err) != 2 {
+			t.Fatalf("parsePRCommandOptions(%v) err=%v, want usage error", args, err)
+		}
+	}
+}
+
+func TestValidatePRRange(t *testing.T) {
+	repo := initTempGitRepo(t)
+	runGit(t, repo, "branch", "-M", "main")
+	runGit(t, repo, "checkout", "-q", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(repo, "feature.txt"), []byte("feature\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "feature.txt")
+	runGit(t, repo, "commit", "-m", "add feature")
+	runGit(t, repo, "checkout", "-q", "--detach", "main")
+	t.Chdir(repo)
+
+	if err := validatePRRange("main", "feature"); err != nil {
+		t.Fatalf("validatePRRange: %v", err)
+	}
+	if err := validatePRRange("feature", "main"); err == nil || !strings.Contains(err.Error(), "no commits in feature..main") {
+		t.Fatalf("empty range err=%v", err)
+	}
+	if err := validatePRRange("main", "missing"); err == nil || !strings.Contains(err.Error(), `"missing" not found`) {
+		t.Fatalf("missing ref err=%v", err)
+	}
+}
+
 func TestPRRunResultFromFramework(t *testing.T) {
 	pr := &commands.PRResult{
 		Title:   "tighten pr command",
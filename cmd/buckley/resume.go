@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+const (
+	defaultResumeListLimit = 20
+	resumeSnippetMaxChars  = 120
+	// resumeSnippetScanLimit bounds how many leading messages are scanned for
+	// the first user turn.
+	resumeSnippetScanLimit = 10
+)
+
+// resumeListEntry is the machine-readable shape of `buckley resume --list --json`.
+type resumeListEntry struct {
+	ID           string    `json:"id"`
+	Project      string    `json:"project"`
+	GitBranch    string    `json:"gitBranch,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastActive   time.Time `json:"lastActive"`
+	MessageCount int       `json:"messageCount"`
+	Snippet      string    `json:"snippet"`
+}
+
+// isResumeListRequest reports whether resume args ask for a session listing
+// instead of a session to resume.
+func isResumeListRequest(args []string) bool {
+	for _, arg := range args {
+		if arg == "--list" || arg == "-list" {
+			return true
+		}
+	}
+	return false
+}
+
+func runResumeListCommand(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("resume", flag.ContinueOnError)
+	fs.Bool("list", false, "List recent sessions instead of resuming one")
+	jsonOut := fs.Bool("json", false, "Output sessions as JSON")
+	limit := fs.Int("limit", defaultResumeListLimit, "Maximum number of sessions to list")
+	project := fs.String("project", "", "Only list sessions for this project path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 {
+		return withExitCode(fmt.Errorf("--limit must be at least 1"), 2)
+	}
+
+	dbPath, err := resolveDBPath()
+	if err != nil {
+		return err
+	}
+	store, err := storage.New(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer store.Close()
+
+	entries, err := listResumeSessions(store, strings.TrimSpace(*project), *limit)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No saved sessions.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tPROJECT\tLAST ACTIVE\tMESSAGES\tSNIPPET")
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", entry.ID, entry.Project, entry.LastActive.Local().Format("2006-01-02 15:04"), entry.MessageCount, entry.Snippet)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "\nResume with: buckley resume <session-id>")
+	return nil
+}
+
+func listResumeSessions(store *storage.Store, project string, limit int) ([]resumeListEntry, error) {
+	var (
+		sessions []storage.Session
+		err      error
+	)
+	if project != "" {
+		sessions, err = store.ListSessionsByRepo(project)
+		if len(sessions) > limit {
+			sessions = sessions[:limit]
+		}
+	} else {
+		sessions, err = store.ListSessions(limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	entries := make([]resumeListEntry, 0, len(sessions))
+	for _, sess := range sessions {
+		snippet, err := resumeSessionSnippet(store, sess.ID)
+		if err != nil {
+			return nil, fmt.Errorf("load session %s messages: %w", sess.ID, err)
+		}
+		entries = append(entries, resumeListEntry{
+			ID:           sess.ID,
+			Project:      sess.ProjectPath,
+			GitBranch:    sess.GitBranch,
+			Model:        sess.Model,
+			Status:       sess.Status,
+			CreatedAt:    sess.CreatedAt,
+			LastActive:   sess.LastActive,
+			MessageCount: sess.MessageCount,
+			Snippet:      snippet,
+		})
+	}
+	return entries, nil
+}
+
+// resumeSessionSnippet returns a one-line preview of the session's first user turn.
+func resumeSessionSnippet(store *storage.Store, sessionID string) (string, error) {
+	messages, err := store.GetMessages(sessionID, resumeSnippetScanLimit, 0)
+	if err != nil {
+		return "", err
+	}
+	for _, msg := range messages {
+		if msg.Role != "user" || msg.IsSummary {
+			continue
+		}
+		text := strings.Join(strings.Fields(msg.Content), " ")
+		if text == "" {
+			continue
+		}
+		runes := []rune(text)
+		if len(runes) > resumeSnippetMaxChars {
+			text = string(runes[:resumeSnippetMaxChars-1]) + "…"
+		}
+		return text, nil
+	}
+	return "", nil
+}
+
+func runResumeCommand(args []string) error {
+	return runResumeListCommand(args, os.Stdout)
+}
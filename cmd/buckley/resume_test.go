@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestRunResumeListCommandJSON(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "buckley.db")
+	t.Setenv(envBuckleyDBPath, dbPath)
+
+	store, err := storage.New(dbPath)
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, sess := range []storage.Session{
+		{ID: "older", ProjectPath: "/repo/a", GitBranch: "main", CreatedAt: now.Add(-2 * time.Hour), LastActive: now.Add(-time.Hour)},
+		{ID: "newer", ProjectPath: "/repo/b", CreatedAt: now.Add(-time.Hour), LastActive: now},
+	} {
+		sess := sess
+		if err := store.CreateSession(&sess); err != nil {
+			t.Fatalf("CreateSession %d: %v", i, err)
+		}
+	}
+	for _, msg := range []storage.Message{
+		{SessionID: "newer", Role: "system", Content: "system prompt", Timestamp: now.Add(-time.Hour)},
+		{SessionID: "newer", Role: "user", Content: "fix the\nflaky parser test", Timestamp: now.Add(-59 * time.Minute)},
+		{SessionID: "newer", Role: "assistant", Content: "on it", Timestamp: now.Add(-58 * time.Minute)},
+	} {
+		msg := msg
+		if err := store.SaveMessage(&msg); err != nil {
+			t.Fatalf("SaveMessage: %v", err)
+		}
+	}
+	_ = store.Close()
+
+	var out bytes.Buffer
+	if err := runResumeListCommand([]string{"--list", "--json"}, &out); err != nil {
+		t.Fatalf("runResumeListCommand: %v", err)
+	}
+
+	var raw []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &raw); err != nil {
+		t.Fatalf("unmarshal output: %v\n%s", err, out.String())
+	}
+	if len(raw) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(raw))
+	}
+	for _, key := range []string{"id", "project", "status", "createdAt", "lastActive", "messageCount", "snippet"} {
+		if _, ok := raw[0][key]; !ok {
+			t.Fatalf("entry missing %q: %v", key, raw[0])
+		}
+	}
+	if raw[0]["id"] != "newer" || raw[0]["project"] != "/repo/b" {
+		t.Fatalf("expected most recent session first, got %v", raw[0])
+	}
+	if raw[0]["snippet"] != "fix the flaky parser test" {
+		t.Fatalf("snippet = %q", raw[0]["snippet"])
+	}
+	if raw[1]["gitBranch"] != "main" || raw[1]["snippet"] != "" {
+		t.Fatalf("unexpected older entry: %v", raw[1])
+	}
+
+	out.Reset()
+	if err := runResumeListCommand([]string{"--list", "--json", "--project", "/repo/a"}, &out); err != nil {
+		t.Fatalf("runResumeListCommand --project: %v", err)
+	}
+	var filtered []resumeListEntry
+	if err := json.Unmarshal(out.Bytes(), &filtered); err != nil {
+		t.Fatalf("unmarshal filtered output: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != "older" {
+		t.Fatalf("filtered = %+v, want only older", filtered)
+	}
+}
+
+func TestRunResumeListCommandEmptyJSONIsArray(t *testing.T) {
+	t.Setenv(envBuckleyDBPath, filepath.Join(t.TempDir(), "buckley.db"))
+
+	var out bytes.Buffer
+	if err := runResumeListCommand([]string{"--list", "--json"}, &out); err != nil {
+		t.Fatalf("runResumeListCommand: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "[]" {
+		t.Fatalf("output = %q, want []", got)
+	}
+}
+
+func TestConsumeResumeCommandLeavesListForDispatch(t *testing.T) {
+	opts := &startupOptions{args: []string{"resume", "--list", "--json"}}
+	if err := opts.consumeResumeCommand(); err != nil {
+		t.Fatalf("consumeResumeCommand: %v", err)
+	}
+	if opts.resumeSessionID != "" || len(opts.args) != 3 {
+		t.Fatalf("opts = %+v, want list args preserved", opts)
+	}
+}
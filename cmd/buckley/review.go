@@ -36,6 +36,8 @@ type reviewCommandOptions struct {
 	maxTurns        int
 	maxDiff         int
 	maxRetries      int
+	format          string
+	failOn          commands.Severity
 }
 
 type reviewCommandRuntime struct {
@@ -80,6 +82,8 @@ func parseReviewCommandOptions(args []string) (reviewCommandOptions, error) {
 	maxTurns := fs.Int("max-turns", 0, "hard model turn limit per review pass (0 = adaptive)")
 	maxDiff := fs.Int("max-diff-bytes", 0, "maximum prioritized diff bytes (0 = Buckbot default)")
 	maxRetries := fs.Int("max-validation-attempts", 0, "maximum schema-validation attempts (0 = Buckbot default)")
+	format := fs.String("format", reviewFormatText, "output format: text or json")
+	failOn := fs.String("fail-on", "none", "with --format json, exit 1 when a finding is at or above this severity: none, minor, major, or critical")
 
 	if err := fs.Parse(args); err != nil {
 		return reviewCommandOptions{}, err
@@ -102,10 +106,27 @@ func parseReviewCommandOptions(args []string) (reviewCommandOptions, error) {
 		maxTurns:        *maxTurns,
 		maxDiff:         *maxDiff,
 		maxRetries:      *maxRetries,
+		format:          strings.ToLower(strings.TrimSpace(*format)),
 	}
 	if *noInteractive {
 		opts.interactive = false
 	}
+	switch opts.format {
+	case "", reviewFormatText:
+		opts.format = reviewFormatText
+	case reviewFormatJSON:
+		opts.interactive = false
+	default:
+		return reviewCommandOptions{}, fmt.Errorf("unknown format %q (use text or json)", *format)
+	}
+	severity, err := parseReviewFailOn(*failOn)
+	if err != nil {
+		return reviewCommandOptions{}, err
+	}
+	if severity != "" && opts.format != reviewFormatJSON {
+		return reviewCommandOptions{}, fmt.Errorf("--fail-on requires --format json")
+	}
+	opts.failOn = severity
 	if len(opts.untrackedPaths) > 0 && (opts.projectMode || normalizeReviewCommandScope(opts.scope) != commands.ReviewScopeWorktree || !opts.includeUnstaged) {
 		return reviewCommandOptions{}, fmt.Errorf("--include-untracked requires --scope worktree, --unstaged=true, and non-project review")
 	}
@@ -141,6 +162,14 @@ func runReviewCommand(args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
 	defer cancel()
 
+	jsonOutput := opts.format == reviewFormatJSON
+	if jsonOutput {
+		// Keep stdout for the report; progress and notices go to stderr.
+		previous := termOut
+		termOut = terminal.NewWithOutput(os.Stderr)
+		defer func() { termOut = previous }()
+	}
+
 	if !quietMode {
 		termOut.Dim("Using model: %s", runtime.modelID)
 		if runtime.reasoningEffort != "" {
@@ -154,11 +183,18 @@ func runReviewCommand(args []string) error {
 		maxDiffBytes:  opts.maxDiff,
 		maxCostUSD:    opts.budgetUSD,
 	})
+	if jsonOutput {
+		policy.findingCategories = true
+		policy.progress = os.Stderr
+	}
 	result, reviewErr := runReviewWithPolicy(ctx, opts, runtime.framework, policy)
 
 	if opts.verbose && result != nil && result.contextAudit != nil {
 		printReviewContextAudit(result.contextAudit)
 	}
+	if jsonOutput {
+		return finishReviewJSON(opts, runtime, result, reviewErr)
+	}
 	if reviewErr != nil {
 		if result == nil || !result.incomplete || strings.TrimSpace(result.reviewText) == "" {
 			return reviewErr
@@ -200,6 +236,17 @@ func runReviewCommand(args []string) error {
 	return nil
 }
 
+// newReviewToolRegistry builds the registry review agents run tools from,
+// with the configured tool policy and container settings applied.
+func newReviewToolRegistry(cfg *config.Config) *tool.Registry {
+	registry := tool.NewRegistry()
+	tool.ApplyToolMiddlewareConfig(registry, cfg)
+	if cwd, err := os.Getwd(); err == nil {
+		registry.ConfigureContainers(cfg, cwd)
+	}
+	return registry
+}
+
 func newReviewCommandRuntime(cfg *config.Config, mgr *model.Manager) (*reviewCommandRuntime, error) {
 	modelID := resolveReviewModel(cfg)
 	if modelID == "" {
@@ -212,13 +259,11 @@ func newReviewCommandRuntime(cfg *config.Config, mgr *model.Manager) (*reviewCom
 	}
 
 	ledger := transparency.NewCostLedger()
-	registry := tool.NewRegistry()
-	if cwd, err := os.Getwd(); err == nil {
-		registry.ConfigureContainers(cfg, cwd)
-	}
+	registry := newReviewToolRegistry(cfg)
 
 	rlmRunner := oneshot.NewRLMRunner(oneshot.RLMRunnerConfig{
 		Models:          mgr,
+		Config:          cfg,
 		Registry:        registry,
 		Ledger:          ledger,
 		ModelID:         modelID,
@@ -233,6 +278,7 @@ func newReviewCommandRuntime(cfg *config.Config, mgr *model.Manager) (*reviewCom
 	if criticModel != "" && criticModel != modelID {
 		criticRunner := oneshot.NewRLMRunner(oneshot.RLMRunnerConfig{
 			Models:          mgr,
+			Config:          cfg,
 			Registry:        registry,
 			Ledger:          ledger,
 			ModelID:         criticModel,
@@ -291,7 +337,7 @@ func runProjectReview(ctx context.Context, framework *oneshot.Framework) (*revie
 }
 
 func runProjectReviewWithPolicy(ctx context.Context, framework *oneshot.Framework, reviewPolicy automatedReviewOptions) (*reviewCommandResult, error) {
-	spinner := terminal.NewSpinner("Analyzing project...")
+	spinner := reviewPolicy.newSpinner("Analyzing project...")
 	spinner.Start()
 	policy := model.ReviewSnapshotPolicy{Mode: model.ReviewSnapshotTrackedWorktree}
 	snapshot, err := model.CaptureReviewSnapshot(ctx, "", policy)
@@ -317,6 +363,9 @@ func runProjectReviewWithPolicy(ctx context.Context, framework *oneshot.Framewor
 	}
 
 	userPrompt := commands.BuildProjectPrompt(projectCtx)
+	if reviewPolicy.findingCategories {
+		userPrompt += "\n\n" + commands.FindingCategoryInstructions
+	}
 	fwResult, runErr := framework.RunRLM(ctx, commands.ReviewProjectDef{}, oneshot.RLMRunOpts{
 		UserPrompt:               userPrompt,
 		Audit:                    audit,
@@ -346,7 +395,7 @@ func runBranchReview(ctx context.Context, opts reviewCommandOptions, framework *
 
 func runBranchReviewWithPolicy(ctx context.Context, opts reviewCommandOptions, framework *oneshot.Framework, reviewPolicy automatedReviewOptions) (*reviewCommandResult, error) {
 	reviewScope := normalizeReviewCommandScope(opts.scope)
-	spinner := terminal.NewSpinner(fmt.Sprintf("Analyzing %s changes...", reviewScope))
+	spinner := reviewPolicy.newSpinner(fmt.Sprintf("Analyzing %s changes...", reviewScope))
 	spinner.Start()
 	policy := branchReviewSnapshotPolicy(reviewScope, opts.includeUnstaged, opts.untrackedPaths)
 	snapshot, err := model.CaptureReviewSnapshot(ctx, "", policy)
@@ -387,6 +436,9 @@ func runBranchReviewWithPolicy(ctx context.Context, opts reviewCommandOptions, f
 	}
 
 	userPrompt := commands.BuildBranchPrompt(branchCtx)
+	if reviewPolicy.findingCategories {
+		userPrompt += "\n\n" + commands.FindingCategoryInstructions
+	}
 	reviewDef := commands.ReviewBranchDef{
 		ChangedFiles:      reviewChangedFilePaths(branchCtx.Files),
 		ContextIncomplete: branchCtx.DiffTruncated || branchCtx.UnstagedTruncated || branchCtx.ContextIncomplete,
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/oneshot/commands"
+	"m31labs.dev/buckley/pkg/transparency"
+)
+
+const (
+	reviewFormatText = "text"
+	reviewFormatJSON = "json"
+)
+
+// reviewSeverityRank orders severities for --fail-on.
+var reviewSeverityRank = map[commands.Severity]int{
+	commands.SeverityMinor:    1,
+	commands.SeverityMajor:    2,
+	commands.SeverityCritical: 3,
+}
+
+// reviewJSONReport is the envelope printed by review --format json.
+type reviewJSONReport struct {
+	Model            string              `json:"model"`
+	Scope            string              `json:"scope"`
+	Grade            string              `json:"grade,omitempty"`
+	Approved         bool                `json:"approved"`
+	Incomplete       bool                `json:"incomplete,omitempty"`
+	IncompleteReason string              `json:"incomplete_reason,omitempty"`
+	Findings         []reviewJSONFinding `json:"findings"`
+	Usage            reviewJSONUsage     `json:"usage"`
+	FailOn           string              `json:"fail_on,omitempty"`
+	Failed           bool                `json:"failed"`
+}
+
+type reviewJSONFinding struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity"`
+	Category string `json:"category"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+	Impact   string `json:"impact,omitempty"`
+	Fix      string `json:"fix,omitempty"`
+}
+
+type reviewJSONUsage struct {
+	InputTokens     int     `json:"input_tokens"`
+	OutputTokens    int     `json:"output_tokens"`
+	ReasoningTokens int     `json:"reasoning_tokens,omitempty"`
+	TotalTokens     int     `json:"total_tokens"`
+	CostUSD         float64 `json:"cost_usd"`
+}
+
+// parseReviewFailOn maps a --fail-on value to a severity; "none" and ""
+// disable the threshold.
+func parseReviewFailOn(value string) (commands.Severity, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" || value == "NONE" {
+		return "", nil
+	}
+	severity := commands.Severity(value)
+	if _, ok := reviewSeverityRank[severity]; !ok {
+		return "", fmt.Errorf("unknown --fail-on severity %q (use none, minor, major, or critical)", strings.ToLower(value))
+	}
+	return severity, nil
+}
+
+// buildReviewJSONReport converts a review result into the JSON envelope.
+// Findings missing a category are reported as "general".
+func buildReviewJSONReport(opts reviewCommandOptions, modelID string, result *reviewCommandResult, ledger *transparency.CostLedger) reviewJSONReport {
+	report := reviewJSONReport{
+		Model:    modelID,
+		Scope:    normalizeReviewCommandScope(opts.scope),
+		Findings: []reviewJSONFinding{},
+	}
+	if opts.projectMode {
+		report.Scope = "project"
+	}
+	if ledger != nil {
+		summary := ledger.Summary()
+		report.Usage = reviewJSONUsage{
+			InputTokens:     summary.SessionTokens.Input,
+			OutputTokens:    summary.SessionTokens.Output,
+			ReasoningTokens: summary.SessionTokens.Reasoning,
+			TotalTokens:     summary.SessionTokens.Total(),
+			CostUSD:         summary.SessionCost,
+		}
+	}
+	if opts.failOn != "" {
+		report.FailOn = strings.ToLower(string(opts.failOn))
+	}
+	if result == nil {
+		return report
+	}
+	report.Incomplete = result.incomplete
+	report.IncompleteReason = result.incompleteWhy
+
+	parsed := result.parsed
+	if parsed == nil {
+		parsed = commands.ParseReview(result.reviewText)
+	}
+	report.Grade = string(parsed.Grade)
+	report.Approved = parsed.Approved && !result.incomplete
+	for _, f := range parsed.Findings {
+		category := f.Category
+		if category == "" {
+			category = "general"
+		}
+		report.Findings = append(report.Findings, reviewJSONFinding{
+			ID:       f.ID,
+			Severity: strings.ToLower(string(f.Severity)),
+			Category: category,
+			File:     f.File,
+			Line:     f.Line,
+			Message:  f.Title,
+			Impact:   f.Impact,
+			Fix:      f.Fix,
+		})
+		if opts.failOn != "" && reviewSeverityRank[f.Severity] >= reviewSeverityRank[opts.failOn] {
+			report.Failed = true
+		}
+	}
+	return report
+}
+
+// finishReviewJSON writes the JSON report to stdout or --output. It fails
+// (exit code 1) when a finding meets --fail-on. A failed run is reported
+// only if it salvaged an incomplete review, and still returns its error.
+func finishReviewJSON(opts reviewCommandOptions, runtime *reviewCommandRuntime, result *reviewCommandResult, reviewErr error) error {
+	if reviewErr != nil && (result == nil || !result.incomplete || strings.TrimSpace(result.reviewText) == "") {
+		return reviewErr
+	}
+	if reviewErr == nil && (result == nil || result.reviewText == "") {
+		return fmt.Errorf("no review generated")
+	}
+
+	report := buildReviewJSONReport(opts, runtime.modelID, result, runtime.ledger)
+	if err := writeReviewJSON(opts.outputFile, report); err != nil {
+		return err
+	}
+	if reviewErr != nil {
+		return fmt.Errorf("%w; incomplete review salvaged%s", reviewErr, reviewSalvageDestination(opts.outputFile))
+	}
+	if report.Failed {
+		return fmt.Errorf("review has findings at or above %s", report.FailOn)
+	}
+	return nil
+}
+
+func writeReviewJSON(outputFile string, report reviewJSONReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if outputFile == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(outputFile, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	termOut.Success("Review written to %s", outputFile)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -209,6 +210,10 @@ type automatedReviewOptions struct {
 	maxCostUSD       float64
 	criticReserveUSD float64
 	approvalCritic   bool
+	// findingCategories asks the model to tag each finding with a category.
+	findingCategories bool
+	// progress receives spinner output; nil means stdout.
+	progress io.Writer
 }
 
 func defaultAutomatedReviewOptions(cfg *config.Config) automatedReviewOptions {
@@ -247,6 +252,13 @@ func (defaults automatedReviewOptions) withOverrides(overrides automatedReviewOp
 	return defaults
 }
 
+func (opts automatedReviewOptions) newSpinner(message string) *terminal.Spinner {
+	if opts.progress != nil {
+		return terminal.NewSpinnerWithOutput(opts.progress, message)
+	}
+	return terminal.NewSpinner(message)
+}
+
 func runPRReviewWithOptions(ctx context.Context, prRef string, framework *oneshot.Framework, opts automatedReviewOptions) (*reviewCommandResult, *commands.PRInfo, error) {
 	spinner := terminal.NewSpinner("Fetching PR details...")
 	spinner.Start()
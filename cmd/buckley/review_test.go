@@ -224,3 +224,127 @@ func TestReviewResultFromRLMPreservesIncompleteState(t *testing.T) {
 		t.Fatal("incomplete review must not retain a parsed merge verdict")
 	}
 }
+
+func TestParseReviewCommandOptionsFormatJSON(t *testing.T) {
+	opts, err := parseReviewCommandOptions([]string{"--format", "JSON", "--fail-on", "major"})
+	if err != nil {
+		t.Fatalf("parseReviewCommandOptions() error = %v", err)
+	}
+	if opts.format != reviewFormatJSON {
+		t.Fatalf("format = %q, want json", opts.format)
+	}
+	if opts.failOn != commands.SeverityMajor {
+		t.Fatalf("failOn = %q, want MAJOR", opts.failOn)
+	}
+	if opts.interactive {
+		t.Fatal("interactive = true, want false in JSON mode")
+	}
+
+	opts, err = parseReviewCommandOptions(nil)
+	if err != nil {
+		t.Fatalf("parseReviewCommandOptions() error = %v", err)
+	}
+	if opts.format != reviewFormatText || opts.failOn != "" || !opts.interactive {
+		t.Fatalf("defaults = format %q, failOn %q, interactive %v; want text, none, true", opts.format, opts.failOn, opts.interactive)
+	}
+
+	for _, args := range [][]string{
+		{"--format", "yaml"},
+		{"--format", "json", "--fail-on", "blocker"},
+		{"--fail-on", "critical"},
+	} {
+		if _, err := parseReviewCommandOptions(args); err == nil {
+			t.Fatalf("parseReviewCommandOptions(%v) succeeded, want error", args)
+		}
+	}
+}
+
+func TestBuildReviewJSONReport(t *testing.T) {
+	review := `## Grade: C
+
+## Findings
+
+### FINDING-001: [MAJOR] Token compared with ==
+- **File**: pkg/auth/token.go:31
+- **Category**: security
+- **Evidence**: a == b on secrets
+- **Impact**: timing leak
+- **Fix**: use subtle.ConstantTimeCompare
+
+### FINDING-002: [MINOR] Typo in log message
+- **File**: pkg/auth/log.go:9
+- **Evidence**: "recieved"
+- **Impact**: none
+- **Fix**: fix the spelling
+
+## Verdict
+- **Approved**: NO
+`
+	result := &reviewCommandResult{reviewText: review, parsed: commands.ParseReview(review)}
+
+	tests := []struct {
+		failOn commands.Severity
+		failed bool
+	}{
+		{failOn: "", failed: false},
+		{failOn: commands.SeverityMinor, failed: true},
+		{failOn: commands.SeverityMajor, failed: true},
+		{failOn: commands.SeverityCritical, failed: false},
+	}
+	for _, tt := range tests {
+		opts := reviewCommandOptions{scope: commands.ReviewScopeBranch, failOn: tt.failOn}
+		report := buildReviewJSONReport(opts, "test/reviewer", result, nil)
+		if report.Failed != tt.failed {
+			t.Fatalf("fail-on %q: failed = %v, want %v", tt.failOn, report.Failed, tt.failed)
+		}
+		if report.Model != "test/reviewer" || report.Scope != commands.ReviewScopeBranch || report.Grade != "C" || report.Approved {
+			t.Fatalf("report header = %+v", report)
+		}
+		if len(report.Findings) != 2 {
+			t.Fatalf("findings = %+v, want 2", report.Findings)
+		}
+	}
+
+	report := buildReviewJSONReport(reviewCommandOptions{}, "test/reviewer", result, nil)
+	got := report.Findings[0]
+	want := reviewJSONFinding{
+		ID:       "FINDING-001",
+		Severity: "major",
+		Category: "security",
+		File:     "pkg/auth/token.go",
+		Line:     31,
+		Message:  "Token compared with ==",
+		Impact:   "timing leak",
+		Fix:      "use subtle.ConstantTimeCompare",
+	}
+	if got != want {
+		t.Fatalf("finding = %+v, want %+v", got, want)
+	}
+	if report.Findings[1].Category != "general" {
+		t.Fatalf("uncategorized finding category = %q, want general", report.Findings[1].Category)
+	}
+}
+
+func TestNewReviewToolRegistryAppliesToolPolicy(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"read_file"}
+
+	registry := newReviewToolRegistry(cfg)
+	if !registry.RequiresApproval("read_file") {
+		t.Fatal("review registry ignored tools.dangerous")
+	}
+}
+
+func TestNewReviewToolRegistryAppliesAllowAndDenyLists(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ToolMiddleware.AllowList = []string{"read_file", "run_shell"}
+	cfg.ToolMiddleware.DenyList = []string{"run_shell"}
+
+	registry := newReviewToolRegistry(cfg)
+	if !registry.PolicyAllows("read_file") {
+		t.Fatal("review registry refused an allowed tool")
+	}
+	if registry.PolicyAllows("run_shell") || registry.PolicyAllows("write_file") {
+		t.Fatal("review registry ignored tool_middleware allow/deny lists")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/rlm"
+)
+
+// takeRLMExperimentalWarning returns the one-time experimental notice when cfg
+// runs in RLM mode, or "" when it is suppressed or was already shown.
+func takeRLMExperimentalWarning(cfg *config.Config) string {
+	if cfg == nil || cfg.ExecutionMode() != config.ExecutionModeRLM || cfg.Execution.SuppressRLMWarning {
+		return ""
+	}
+	dataDir, err := resolveDataDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	warning, err := rlm.TakeExperimentalWarning(dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return warning
+}
+
+// printRLMExperimentalWarning shows the RLM notice on stderr. Quiet runs skip
+// it without recording it, so the notice still appears on the next normal run.
+func printRLMExperimentalWarning(cfg *config.Config) {
+	if quietMode {
+		return
+	}
+	if warning := takeRLMExperimentalWarning(cfg); warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
+
+// startupNotices collects one-time notices for the TUI transcript.
+func startupNotices(cfg *config.Config) []string {
+	if warning := takeRLMExperimentalWarning(cfg); warning != "" {
+		return []string{warning}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/rlm"
+)
+
+func TestTakeRLMExperimentalWarningShowsOnceAndHonorsConfig(t *testing.T) {
+	t.Setenv(envBuckleyDataDir, t.TempDir())
+
+	classic := config.DefaultConfig()
+	classic.Execution.Mode = config.ExecutionModeClassic
+	if got := takeRLMExperimentalWarning(classic); got != "" {
+		t.Fatalf("classic mode warning = %q, want none", got)
+	}
+
+	suppressed := config.DefaultConfig()
+	suppressed.Execution.Mode = config.ExecutionModeRLM
+	suppressed.Execution.SuppressRLMWarning = true
+	if got := takeRLMExperimentalWarning(suppressed); got != "" {
+		t.Fatalf("suppressed warning = %q, want none", got)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Execution.Mode = config.ExecutionModeRLM
+	if got := startupNotices(cfg); len(got) != 1 || got[0] != rlm.ExperimentalWarning {
+		t.Fatalf("first startup notices = %v, want the RLM warning", got)
+	}
+	if got := startupNotices(cfg); len(got) != 0 {
+		t.Fatalf("second startup notices = %v, want none after acknowledgement", got)
+	}
+}
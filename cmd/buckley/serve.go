@@ -8,11 +8,16 @@ import (
 	"flag"
 	"fmt"
 	iofs "io/fs"
+	"math"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/headless"
@@ -33,6 +38,7 @@ const (
 	// #nosec G101 -- env var name (not a credential)
 	envBuckleyIPCTokenFile  = "BUCKLEY_IPC_TOKEN_FILE"
 	envBuckleyPrintIPCToken = "BUCKLEY_PRINT_GENERATED_IPC_TOKEN"
+	envBuckleyIPCOrigins    = "BUCKLEY_IPC_ALLOWED_ORIGINS"
 )
 
 var serveLoadConfigFn = config.Load
@@ -54,13 +60,17 @@ type serveCommandOptions struct {
 	basicAuthUser  string
 	basicAuthPass  string
 	allowedOrigins []string
+	openBrowser    bool
+	maxBodyBytes   int64
+	watchConfig    bool
+	experiment     bool
 }
 
 func parseServeCommandOptions(args []string, ipcDefaults config.IPCConfig) (serveCommandOptions, error) {
 	if strings.TrimSpace(ipcDefaults.Bind) == "" {
 		ipcDefaults.Bind = "127.0.0.1:4488"
 	}
-	allowedOrigins := append([]string{}, ipcDefaults.AllowedOrigins...)
+	var corsOrigins, extraOrigins []string
 	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
 	bind := fs.String("bind", ipcDefaults.Bind, "address to bind the IPC server")
 	assetPath := fs.String("assets", "", "path to built frontend assets (served when --browser)")
@@ -73,12 +83,29 @@ func parseServeCommandOptions(args []string, ipcDefaults config.IPCConfig) (serv
 	printToken := fs.Bool("print-token", false, "print generated IPC auth token to stderr (use cautiously; may leak via logs)")
 	basicAuthUser := fs.String("basic-auth-user", "", "Basic auth username (overrides config/env)")
 	basicAuthPass := fs.String("basic-auth-pass", "", "Basic auth password (overrides config/env)")
-	fs.Var(&stringListValue{target: &allowedOrigins}, "allow-origin", "additional allowed Origin (repeatable, accepts comma-separated list)")
+	fs.Var(&stringListValue{target: &corsOrigins}, "cors", "allowed CORS origins, comma-separated (replaces config and BUCKLEY_IPC_ALLOWED_ORIGINS)")
+	fs.Var(&stringListValue{target: &extraOrigins}, "allow-origin", "additional allowed Origin (repeatable, accepts comma-separated list)")
+	openUI := fs.Bool("open", false, "open the browser UI in the default browser once the server is listening")
+	watchConfig := fs.Bool("watch-config", false, "reload models, trust level and tool timeouts when a config file changes")
+	enableExperiments := fs.Bool("experiment", false, "enable model comparisons via POST /api/experiments (also experiment.enabled)")
+	var maxBody byteSizeValue
+	fs.Var(&maxBody, "max-body", "maximum request body size, e.g. 16MiB (default: per-endpoint limits up to 8MiB)")
 
 	if err := fs.Parse(args); err != nil {
 		return serveCommandOptions{}, err
 	}
 
+	if maxBody.set {
+		if err := ipc.ValidateMaxBodyBytes(maxBody.bytes); err != nil {
+			return serveCommandOptions{}, fmt.Errorf("--max-body: %w", err)
+		}
+	}
+
+	allowedOrigins, err := resolveServeAllowedOrigins(ipcDefaults.AllowedOrigins, corsOrigins, extraOrigins)
+	if err != nil {
+		return serveCommandOptions{}, err
+	}
+
 	token := strings.TrimSpace(*authTokenFlag)
 	if token == "" {
 		token = strings.TrimSpace(os.Getenv("BUCKLEY_IPC_TOKEN"))
@@ -106,9 +133,38 @@ func parseServeCommandOptions(args []string, ipcDefaults config.IPCConfig) (serv
 		basicAuthUser:  strings.TrimSpace(*basicAuthUser),
 		basicAuthPass:  strings.TrimSpace(*basicAuthPass),
 		allowedOrigins: allowedOrigins,
+		openBrowser:    *openUI,
+		maxBodyBytes:   maxBody.bytes,
+		watchConfig:    *watchConfig,
+		experiment:     *enableExperiments,
 	}, nil
 }
 
+// resolveServeAllowedOrigins picks the launch origin list: --cors, then
+// BUCKLEY_IPC_ALLOWED_ORIGINS, then config. --allow-origin entries are added on
+// top. Origins supplied at launch are validated; config entries are not.
+func resolveServeAllowedOrigins(configured, cors, extra []string) ([]string, error) {
+	base := append([]string{}, configured...)
+	var launch []string
+	switch {
+	case len(cors) > 0:
+		base = nil
+		launch = append(launch, cors...)
+	case strings.TrimSpace(os.Getenv(envBuckleyIPCOrigins)) != "":
+		base = nil
+		if err := (&stringListValue{target: &launch}).Set(os.Getenv(envBuckleyIPCOrigins)); err != nil {
+			return nil, err
+		}
+	}
+	launch = append(launch, extra...)
+	for _, origin := range launch {
+		if err := ipc.ValidateOrigin(origin); err != nil {
+			return nil, err
+		}
+	}
+	return append(base, launch...), nil
+}
+
 func finalizeServeCommandOptions(appCfg *config.Config, opts *serveCommandOptions) error {
 	tokenFilePath, err := resolveServeTokenFilePath(opts.tokenFile)
 	if err != nil {
@@ -257,6 +313,9 @@ func runServeCommand(args []string) error {
 	telemetryHub := telemetry.NewHub()
 	defer telemetryHub.Close()
 	commandGateway := command.NewGateway()
+	if err := registerServeCommands(commandGateway, appCfg.IPC.Commands); err != nil {
+		return err
+	}
 	planStore := orchestrator.NewFilePlanStore(appCfg.Artifacts.PlanningDir)
 	models := initServeModels(appCfg)
 	if models != nil {
@@ -271,6 +330,19 @@ func runServeCommand(args []string) error {
 
 	cfg := buildServeIPCConfig(appCfg, opts, agentPromptSection(agentProfile))
 	server := serveNewServerFn(cfg, store, telemetryHub, commandGateway, planStore, appCfg, nil, models)
+	var live *config.Live
+	if opts.watchConfig {
+		live = config.NewLive(appCfg)
+		if liveServer, ok := server.(interface{ SetLiveConfig(*config.Live) }); ok {
+			liveServer.SetLiveConfig(live)
+		}
+	}
+	currentConfig := func() *config.Config {
+		if cfg := live.Load(); cfg != nil {
+			return cfg
+		}
+		return appCfg
+	}
 	if models != nil {
 		if registryInit, ok := server.(interface {
 			InitHeadlessRegistry(context.Context) *headless.Registry
@@ -279,10 +351,137 @@ func runServeCommand(args []string) error {
 				commandGateway.Register(reg)
 			}
 		}
+		if planned, ok := server.(interface{ SetPlanCreator(ipc.PlanCreator) }); ok {
+			newPlanner := func(cfg *config.Config) ipc.PlanCreator {
+				return orchestrator.NewOrchestrator(store, models, nil, cfg, nil, planStore, nil, nil)
+			}
+			if live != nil {
+				planned.SetPlanCreator(livePlanCreator{live: live, build: newPlanner})
+			} else {
+				planned.SetPlanCreator(newPlanner(appCfg))
+			}
+		}
+		if opts.experiment || appCfg.Experiment.Enabled {
+			if experiments, ok := server.(interface {
+				SetExperimentRunner(ipc.ExperimentRunnerFactory)
+			}); ok {
+				experiments.SetExperimentRunner(func() (ipc.ExperimentRunner, error) {
+					return newExperimentRunner(currentConfig(), models, store, telemetryHub, 0)
+				})
+			}
+		}
+	}
+	if opts.watchConfig {
+		reloader := &serveConfigReloader{
+			live: live,
+			load: serveLoadConfigFn,
+			prepare: func(next *config.Config) {
+				if agentProfile != nil {
+					agentProfile.ApplyToConfig(next)
+				}
+				applyStartupModelOverride(next, modelOverrideFlag)
+			},
+			logf: func(format string, args ...any) {
+				fmt.Fprintf(os.Stderr, format+"\n", args...)
+			},
+		}
+		if err := reloader.watch(ctx, serveConfigPaths()); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: config watching disabled: %v\n", err)
+		}
+	}
+	if opts.openBrowser {
+		go openServeBrowserWhenReady(ctx, opts)
 	}
 	return server.Start(ctx)
 }
 
+var (
+	serveOpenBrowserFn = openBrowser
+	serveHasDisplayFn  = hasGraphicalDisplay
+	serveDialFn        = func(ctx context.Context, address string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", address)
+	}
+)
+
+// serveBrowserReadyTimeout bounds how long --open waits for the listener.
+const serveBrowserReadyTimeout = 10 * time.Second
+
+// serveBrowserURL returns the address of the browser UI for a bind address,
+// substituting loopback for wildcard hosts.
+func serveBrowserURL(bind string) string {
+	return humanReadableURL(bind) + "/"
+}
+
+// planServeBrowserOpen returns the URL --open should launch, or a note
+// explaining why it will not.
+func planServeBrowserOpen(opts serveCommandOptions) (url string, note string) {
+	url = serveBrowserURL(opts.bind)
+	if !opts.enableBrowser {
+		return "", "--open ignored: the browser UI is disabled (pass --browser or set ipc.enable_browser)"
+	}
+	if !serveHasDisplayFn() {
+		return "", fmt.Sprintf("--open skipped: no graphical display detected; open %s manually", url)
+	}
+	return url, ""
+}
+
+// openServeBrowserWhenReady waits for the IPC listener to accept connections
+// and then opens the browser UI. Notes are suppressed by --quiet.
+func openServeBrowserWhenReady(ctx context.Context, opts serveCommandOptions) {
+	url, note := planServeBrowserOpen(opts)
+	if url == "" {
+		if !quietMode {
+			fmt.Fprintln(os.Stderr, "note: "+note)
+		}
+		return
+	}
+	if err := waitForServeListener(ctx, opts.bind, serveBrowserReadyTimeout); err != nil {
+		if ctx.Err() == nil && !quietMode {
+			fmt.Fprintf(os.Stderr, "note: --open skipped: server not reachable at %s: %v\n", url, err)
+		}
+		return
+	}
+	if !quietMode {
+		fmt.Fprintf(os.Stderr, "Opening %s\n", url)
+	}
+	if err := serveOpenBrowserFn(url); err != nil && !quietMode {
+		fmt.Fprintf(os.Stderr, "note: could not open a browser (%v); open %s manually\n", err, url)
+	}
+}
+
+// waitForServeListener polls bind until a TCP connection succeeds, ctx is
+// cancelled, or timeout passes.
+func waitForServeListener(ctx context.Context, bind string, timeout time.Duration) error {
+	address := strings.TrimPrefix(humanReadableURL(bind), "http://")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		conn, err := serveDialFn(ctx, address)
+		if err == nil {
+			_ = conn.Close()
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// hasGraphicalDisplay reports whether a browser can be shown. macOS and
+// Windows always have one; elsewhere an X11 or Wayland display is required.
+func hasGraphicalDisplay() bool {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return true
+	}
+	return strings.TrimSpace(os.Getenv("DISPLAY")) != "" || strings.TrimSpace(os.Getenv("WAYLAND_DISPLAY")) != ""
+}
+
 func initServeModels(appCfg *config.Config) *model.Manager {
 	if appCfg == nil || !appCfg.Providers.HasReadyProvider() {
 		fmt.Fprintf(os.Stderr, "warning: no provider API keys configured; headless sessions disabled\n")
@@ -318,6 +517,18 @@ func maybeStartServeACP(appCfg *config.Config, models *model.Manager, store *sto
 	return stopACP, nil
 }
 
+// registerServeCommands adds the configured ipc.commands to the gateway so
+// IPC clients can send them as session commands.
+func registerServeCommands(gateway *command.Gateway, commands []config.IPCCommandConfig) error {
+	for _, cmd := range commands {
+		handler := command.ExecHandler(cmd.Command, cmd.Args, cmd.Timeout)
+		if err := gateway.RegisterCommand(cmd.Type, handler); err != nil {
+			return fmt.Errorf("ipc.commands: %w", err)
+		}
+	}
+	return nil
+}
+
 func buildServeIPCConfig(appCfg *config.Config, opts serveCommandOptions, agentProfile string) ipc.Config {
 	return ipc.Config{
 		BindAddress:       opts.bind,
@@ -333,6 +544,7 @@ func buildServeIPCConfig(appCfg *config.Config, opts serveCommandOptions, agentP
 		BasicAuthPassword: appCfg.IPC.BasicAuthPassword,
 		ProjectRoot:       config.ResolveProjectRoot(appCfg),
 		AgentProfile:      agentProfile,
+		MaxBodyBytes:      opts.maxBodyBytes,
 	}
 }
 
@@ -404,3 +616,62 @@ func (s *stringListValue) Set(value string) error {
 	}
 	return nil
 }
+
+// byteSizeValue parses sizes such as "512KiB", "16MB", or "1048576".
+// Suffixes are binary: K, KB, and KiB all mean 1024 bytes.
+type byteSizeValue struct {
+	bytes int64
+	set   bool
+}
+
+func (b *byteSizeValue) String() string {
+	if b == nil || !b.set {
+		return ""
+	}
+	return strconv.FormatInt(b.bytes, 10)
+}
+
+func (b *byteSizeValue) Set(value string) error {
+	n, err := parseByteSize(value)
+	if err != nil {
+		return err
+	}
+	b.bytes, b.set = n, true
+	return nil
+}
+
+func parseByteSize(value string) (int64, error) {
+	raw := strings.TrimSpace(value)
+	upper := strings.ToUpper(raw)
+	shift := 0
+	for _, unit := range []struct {
+		suffixes []string
+		shift    int
+	}{
+		{[]string{"GIB", "GB", "G"}, 30},
+		{[]string{"MIB", "MB", "M"}, 20},
+		{[]string{"KIB", "KB", "K"}, 10},
+		{[]string{"B"}, 0},
+	} {
+		matched := false
+		for _, suffix := range unit.suffixes {
+			if strings.HasSuffix(upper, suffix) {
+				upper = strings.TrimSpace(strings.TrimSuffix(upper, suffix))
+				shift = unit.shift
+				matched = true
+				break
+			}
+		}
+		if matched {
+			break
+		}
+	}
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q (use bytes or a K/M/G suffix, e.g. 16MiB)", raw)
+	}
+	if n > math.MaxInt64>>shift {
+		return 0, fmt.Errorf("size %q is too large", raw)
+	}
+	return n << shift, nil
+}
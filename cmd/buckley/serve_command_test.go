@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/ipc/command"
 )
 
 func TestParseServeCommandOptions(t *testing.T) {
@@ -111,3 +114,187 @@ func TestFinalizeServeCommandOptionsRequiresBasicAuthPair(t *testing.T) {
 		t.Fatalf("expected basic auth pair error, got %v", err)
 	}
 }
+
+func TestParseServeCommandOptionsCORSOverridesConfigAndEnv(t *testing.T) {
+	defaults := config.DefaultConfig().IPC
+	t.Setenv(envBuckleyIPCOrigins, "https://env.example.com")
+
+	opts, err := parseServeCommandOptions(nil, defaults)
+	if err != nil {
+		t.Fatalf("parseServeCommandOptions() error = %v", err)
+	}
+	if len(opts.allowedOrigins) != 1 || opts.allowedOrigins[0] != "https://env.example.com" {
+		t.Fatalf("allowedOrigins = %v, want env origins only", opts.allowedOrigins)
+	}
+
+	opts, err = parseServeCommandOptions([]string{
+		"--cors", "http://localhost:5173, https://staging.example.com",
+		"--allow-origin", "http://127.0.0.1:8080",
+	}, defaults)
+	if err != nil {
+		t.Fatalf("parseServeCommandOptions() error = %v", err)
+	}
+	want := []string{"http://localhost:5173", "https://staging.example.com", "http://127.0.0.1:8080"}
+	cfg := buildServeIPCConfig(config.DefaultConfig(), opts, "")
+	if strings.Join(cfg.AllowedOrigins, ",") != strings.Join(want, ",") {
+		t.Fatalf("server AllowedOrigins = %v, want %v", cfg.AllowedOrigins, want)
+	}
+}
+
+func TestParseServeCommandOptionsRejectsInvalidOrigins(t *testing.T) {
+	defaults := config.DefaultConfig().IPC
+
+	if _, err := parseServeCommandOptions([]string{"--cors", "localhost:5173"}, defaults); err == nil || !strings.Contains(err.Error(), "invalid origin") {
+		t.Fatalf("expected invalid --cors origin error, got %v", err)
+	}
+	if _, err := parseServeCommandOptions([]string{"--allow-origin", "http://example.com/app"}, defaults); err == nil || !strings.Contains(err.Error(), "invalid origin") {
+		t.Fatalf("expected invalid --allow-origin error, got %v", err)
+	}
+	t.Setenv(envBuckleyIPCOrigins, "ftp://files.example.com")
+	if _, err := parseServeCommandOptions(nil, defaults); err == nil || !strings.Contains(err.Error(), "invalid origin") {
+		t.Fatalf("expected invalid env origin error, got %v", err)
+	}
+}
+
+func TestParseServeCommandOptionsMaxBody(t *testing.T) {
+	defaults := config.DefaultConfig().IPC
+
+	opts, err := parseServeCommandOptions(nil, defaults)
+	if err != nil {
+		t.Fatalf("parseServeCommandOptions() error = %v", err)
+	}
+	if opts.maxBodyBytes != 0 {
+		t.Fatalf("maxBodyBytes = %d, want 0 without --max-body", opts.maxBodyBytes)
+	}
+
+	opts, err = parseServeCommandOptions([]string{"--max-body", "16MiB"}, defaults)
+	if err != nil {
+		t.Fatalf("parseServeCommandOptions() error = %v", err)
+	}
+	if opts.maxBodyBytes != 16<<20 {
+		t.Fatalf("maxBodyBytes = %d, want %d", opts.maxBodyBytes, 16<<20)
+	}
+	if cfg := buildServeIPCConfig(config.DefaultConfig(), opts, ""); cfg.MaxBodyBytes != 16<<20 {
+		t.Fatalf("ipc MaxBodyBytes = %d, want %d", cfg.MaxBodyBytes, 16<<20)
+	}
+
+	for _, bad := range []string{"lots", "0", "-1M", "1K", "2G"} {
+		if _, err := parseServeCommandOptions([]string{"--max-body", bad}, defaults); err == nil {
+			t.Errorf("--max-body %s: expected error", bad)
+		}
+	}
+}
+
+func TestRegisterServeCommands(t *testing.T) {
+	gateway := command.NewGateway()
+	err := registerServeCommands(gateway, []config.IPCCommandConfig{
+		{Type: "acme:deploy", Command: "./deploy.sh"},
+		{Type: "acme:rollback", Command: "./rollback.sh"},
+	})
+	if err != nil {
+		t.Fatalf("registerServeCommands: %v", err)
+	}
+	got := gateway.CustomCommands()
+	if len(got) != 2 || got[0] != "acme:deploy" || got[1] != "acme:rollback" {
+		t.Fatalf("CustomCommands() = %v", got)
+	}
+
+	err = registerServeCommands(command.NewGateway(), []config.IPCCommandConfig{{Type: "ACME:Deploy", Command: "./deploy.sh"}})
+	if err == nil || !strings.Contains(err.Error(), "ipc.commands") {
+		t.Fatalf("expected ipc.commands error for invalid type, got %v", err)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{
+		"1048576": 1 << 20,
+		"512KiB":  512 << 10,
+		"512k":    512 << 10,
+		"16MB":    16 << 20,
+		"16 M":    16 << 20,
+		"1GiB":    1 << 30,
+		"100B":    100,
+	}
+	for in, want := range tests {
+		got, err := parseByteSize(in)
+		if err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "MiB", "1.5M", "16TB", "9999999999G"} {
+		if _, err := parseByteSize(bad); err == nil {
+			t.Errorf("parseByteSize(%q): expected error", bad)
+		}
+	}
+}
+
+func TestServeBrowserURL(t *testing.T) {
+	tests := map[string]string{
+		"127.0.0.1:4488": "http://127.0.0.1:4488/",
+		"0.0.0.0:4488":   "http://127.0.0.1:4488/",
+		":4488":          "http://127.0.0.1:4488/",
+		"[::1]:4488":     "http://[::1]:4488/",
+		"localhost:9000": "http://localhost:9000/",
+	}
+	for bind, want := range tests {
+		if got := serveBrowserURL(bind); got != want {
+			t.Errorf("serveBrowserURL(%q) = %q, want %q", bind, got, want)
+		}
+	}
+}
+
+func TestPlanServeBrowserOpen(t *testing.T) {
+	origDisplay := serveHasDisplayFn
+	t.Cleanup(func() { serveHasDisplayFn = origDisplay })
+
+	opts, err := parseServeCommandOptions([]string{"--open", "--browser", "--bind", "0.0.0.0:5000"}, config.DefaultConfig().IPC)
+	if err != nil {
+		t.Fatalf("parseServeCommandOptions: %v", err)
+	}
+	if !opts.openBrowser {
+		t.Fatal("expected --open to be parsed")
+	}
+
+	serveHasDisplayFn = func() bool { return true }
+	if url, note := planServeBrowserOpen(opts); url != "http://127.0.0.1:5000/" || note != "" {
+		t.Fatalf("plan = (%q, %q), want the loopback URL", url, note)
+	}
+
+	serveHasDisplayFn = func() bool { return false }
+	if url, note := planServeBrowserOpen(opts); url != "" || !strings.Contains(note, "http://127.0.0.1:5000/") {
+		t.Fatalf("headless plan = (%q, %q), want a skip note naming the URL", url, note)
+	}
+
+	serveHasDisplayFn = func() bool { return true }
+	opts.enableBrowser = false
+	if url, note := planServeBrowserOpen(opts); url != "" || !strings.Contains(note, "--browser") {
+		t.Fatalf("disabled UI plan = (%q, %q), want a note about --browser", url, note)
+	}
+}
+
+func TestOpenServeBrowserWhenReadyLaunchesOnceListening(t *testing.T) {
+	origOpen, origDisplay := serveOpenBrowserFn, serveHasDisplayFn
+	t.Cleanup(func() {
+		serveOpenBrowserFn = origOpen
+		serveHasDisplayFn = origDisplay
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	var opened []string
+	serveOpenBrowserFn = func(target string) error {
+		opened = append(opened, target)
+		return nil
+	}
+	serveHasDisplayFn = func() bool { return true }
+
+	opts := serveCommandOptions{bind: listener.Addr().String(), enableBrowser: true, openBrowser: true}
+	openServeBrowserWhenReady(context.Background(), opts)
+	want := "http://" + listener.Addr().String() + "/"
+	if len(opened) != 1 || opened[0] != want {
+		t.Fatalf("opened = %v, want [%s]", opened, want)
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/ipc"
+	"m31labs.dev/buckley/pkg/orchestrator"
+)
+
+// serveReloadDebounce coalesces the burst of events editors emit on save.
+const serveReloadDebounce = 250 * time.Millisecond
+
+// serveConfigReloader re-reads the config files for a running serve and
+// publishes a new snapshot carrying the hot-reloadable settings to the live
+// config shared with the IPC server, the headless registry and the planner.
+// Those load the snapshot when a session, runner or plan starts, so changes
+// apply to new work; sessions already running keep what they started with.
+// Published snapshots are never modified.
+type serveConfigReloader struct {
+	mu   sync.Mutex
+	live *config.Live
+	// last is the previous successful load, used to warn about each
+	// restart-only change once rather than on every reload.
+	last    *config.Config
+	load    func() (*config.Config, error)
+	prepare func(*config.Config)
+	logf    func(format string, args ...any)
+}
+
+// livePlanCreator builds a planner from the current config snapshot for
+// each plan, so reloaded models and trust level apply to new plans.
+type livePlanCreator struct {
+	live  *config.Live
+	build func(*config.Config) ipc.PlanCreator
+}
+
+func (p livePlanCreator) PlanFeature(featureName, description string) (*orchestrator.Plan, error) {
+	return p.build(p.live.Load()).PlanFeature(featureName, description)
+}
+
+// serveHotField is a setting that can be swapped on a running server.
+type serveHotField struct {
+	key   string
+	value func(*config.Config) any
+	apply func(snapshot, next *config.Config)
+}
+
+var serveHotFields = []serveHotField{
+	{"models.planning", func(c *config.Config) any { return c.Models.Planning }, func(s, n *config.Config) { s.Models.Planning = n.Models.Planning }},
+	{"models.execution", func(c *config.Config) any { return c.Models.Execution }, func(s, n *config.Config) { s.Models.Execution = n.Models.Execution }},
+	{"models.review", func(c *config.Config) any { return c.Models.Review }, func(s, n *config.Config) { s.Models.Review = n.Models.Review }},
+	{"models.reasoning", func(c *config.Config) any { return c.Models.Reasoning }, func(s, n *config.Config) { s.Models.Reasoning = n.Models.Reasoning }},
+	{"orchestrator.trust_level", func(c *config.Config) any { return c.Orchestrator.TrustLevel }, func(s, n *config.Config) { s.Orchestrator.TrustLevel = n.Orchestrator.TrustLevel }},
+	{"tool_middleware.default_timeout", func(c *config.Config) any { return c.ToolMiddleware.DefaultTimeout }, func(s, n *config.Config) { s.ToolMiddleware.DefaultTimeout = n.ToolMiddleware.DefaultTimeout }},
+	// Snapshots are shallow copies; the map is replaced, never edited, so
+	// readers holding an older snapshot are unaffected.
+	{"tool_middleware.per_tool_timeouts", func(c *config.Config) any { return c.ToolMiddleware.PerToolTimeouts }, func(s, n *config.Config) { s.ToolMiddleware.PerToolTimeouts = n.ToolMiddleware.PerToolTimeouts }},
+}
+
+// serveRestartFields are read once at startup; changing them needs a restart.
+var serveRestartFields = []struct {
+	key   string
+	value func(*config.Config) any
+}{
+	{"ipc.bind", func(c *config.Config) any { return c.IPC.Bind }},
+	{"ipc.require_token", func(c *config.Config) any { return c.IPC.RequireToken }},
+	{"ipc.allowed_origins", func(c *config.Config) any { return c.IPC.AllowedOrigins }},
+	{"ipc.enable_browser", func(c *config.Config) any { return c.IPC.EnableBrowser }},
+	{"acp.listen", func(c *config.Config) any { return c.ACP.Listen }},
+	{"providers", func(c *config.Config) any { return c.Providers.ReadyProviders() }},
+}
+
+// reload loads and validates the config and applies it. A config that fails
+// to load or validate is rejected as a whole and the live snapshot is left
+// in place. It returns the keys it applied.
+func (r *serveConfigReloader) reload() []string {
+	next, err := r.load()
+	if err != nil {
+		r.logf("warning: config reload rejected, keeping current settings: %v", err)
+		return nil
+	}
+	if r.prepare != nil {
+		r.prepare(next)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.live.Load()
+	last := r.last
+	if last == nil {
+		last = current
+	}
+	for _, field := range serveRestartFields {
+		if !reflect.DeepEqual(field.value(last), field.value(next)) {
+			r.logf("warning: config %s changed; restart buckley serve to apply it", field.key)
+		}
+	}
+	r.last = next
+	snapshot := *current
+	var applied []string
+	for _, field := range serveHotFields {
+		before, after := field.value(current), field.value(next)
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+		field.apply(&snapshot, next)
+		applied = append(applied, field.key)
+		r.logf("config reloaded: %s %v -> %v", field.key, before, after)
+	}
+	if len(applied) == 0 {
+		r.logf("config reloaded: no hot-reloadable settings changed")
+		return nil
+	}
+	r.live.Store(&snapshot)
+	return applied
+}
+
+// watch reloads whenever one of paths is written, created, renamed or
+// removed, until ctx is done. It watches the parent directories so editors
+// that save by renaming a temp file are still seen; directories that do not
+// exist are skipped.
+func (r *serveConfigReloader) watch(ctx context.Context, paths []string) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	targets := make(map[string]bool, len(paths))
+	watched := make(map[string]bool)
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		targets[abs] = true
+		dir := filepath.Dir(abs)
+		if watched[dir] {
+			continue
+		}
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		if err := fw.Add(dir); err != nil {
+			fw.Close()
+			return fmt.Errorf("watch %s: %w", dir, err)
+		}
+		watched[dir] = true
+	}
+	if len(watched) == 0 {
+		fw.Close()
+		return fmt.Errorf("no config directory to watch (looked for %s)", strings.Join(paths, ", "))
+	}
+
+	// Serve adjusts some restart-only settings at startup (the ACP listen
+	// address, for one), so compare later loads against a fresh one.
+	if base, err := r.load(); err == nil {
+		if r.prepare != nil {
+			r.prepare(base)
+		}
+		r.mu.Lock()
+		r.last = base
+		r.mu.Unlock()
+	}
+
+	go func() {
+		defer fw.Close()
+		var debounce *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				if debounce != nil {
+					debounce.Stop()
+				}
+				return
+			case event, ok := <-fw.Events:
+				if !ok {
+					return
+				}
+				if !targets[filepath.Clean(event.Name)] {
+					continue
+				}
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(serveReloadDebounce, func() { r.reload() })
+			case err, ok := <-fw.Errors:
+				if !ok {
+					return
+				}
+				r.logf("warning: config watcher error: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// serveConfigPaths returns the user and project config files Load reads.
+func serveConfigPaths() []string {
+	paths := []string{filepath.Join(".", ".buckley", "config.yaml")}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		paths = append([]string{filepath.Join(home, ".buckley", "config.yaml")}, paths...)
+	}
+	return paths
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+type reloadLog struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *reloadLog) logf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func (l *reloadLog) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+func TestServeConfigReloaderAppliesHotFields(t *testing.T) {
+	base := config.DefaultConfig()
+	live := config.NewLive(base)
+	next := config.DefaultConfig()
+	next.Models.Execution = "openai/gpt-5"
+	next.Orchestrator.TrustLevel = "conservative"
+	next.ToolMiddleware.PerToolTimeouts = map[string]time.Duration{"run_shell": time.Minute}
+	next.IPC.Bind = "0.0.0.0:9999"
+
+	var log reloadLog
+	r := &serveConfigReloader{
+		live: live,
+		load: func() (*config.Config, error) { return next, nil },
+		logf: log.logf,
+	}
+	applied := r.reload()
+	if strings.Join(applied, ",") != "models.execution,orchestrator.trust_level,tool_middleware.per_tool_timeouts" {
+		t.Fatalf("applied = %v", applied)
+	}
+	got := live.Load()
+	if got.Models.Execution != "openai/gpt-5" || got.Orchestrator.TrustLevel != "conservative" || got.ToolMiddleware.PerToolTimeouts["run_shell"] != time.Minute {
+		t.Fatalf("live config not updated: %+v %+v", got.Models, got.Orchestrator)
+	}
+	if base.Models.Execution == "openai/gpt-5" || base.Orchestrator.TrustLevel == "conservative" {
+		t.Fatalf("published snapshot was modified in place: %+v", base.Models)
+	}
+	if got.IPC.Bind == "0.0.0.0:9999" {
+		t.Fatalf("restart-only ipc.bind was applied")
+	}
+	if !strings.Contains(log.String(), "ipc.bind changed; restart") {
+		t.Fatalf("missing restart warning:\n%s", log.String())
+	}
+
+	// The restart warning is reported once, not on every reload.
+	log.lines = nil
+	if applied := r.reload(); len(applied) != 0 {
+		t.Fatalf("second reload applied %v", applied)
+	}
+	if live.Load() != got {
+		t.Fatal("reload without changes published a new snapshot")
+	}
+	if strings.Contains(log.String(), "ipc.bind") {
+		t.Fatalf("restart warning repeated:\n%s", log.String())
+	}
+}
+
+func TestServeConfigReloaderRejectsInvalidConfig(t *testing.T) {
+	live := config.NewLive(config.DefaultConfig())
+	var log reloadLog
+	r := &serveConfigReloader{
+		live: live,
+		load: func() (*config.Config, error) {
+			return nil, errors.New("config validation: invalid trust level: reckless")
+		},
+		logf: log.logf,
+	}
+	if applied := r.reload(); applied != nil {
+		t.Fatalf("applied = %v, want nothing", applied)
+	}
+	if live.Load().Orchestrator.TrustLevel != config.DefaultConfig().Orchestrator.TrustLevel {
+		t.Fatalf("live config changed after rejected reload")
+	}
+	if !strings.Contains(log.String(), "reload rejected") {
+		t.Fatalf("missing rejection log:\n%s", log.String())
+	}
+}
+
+func TestServeConfigReloaderWatchesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("orchestrator:\n  trust_level: balanced\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	live := config.NewLive(config.DefaultConfig())
+	reloaded := make(chan struct{}, 4)
+	var log reloadLog
+	r := &serveConfigReloader{
+		live: live,
+		load: func() (*config.Config, error) {
+			cfg := config.DefaultConfig()
+			if data, err := os.ReadFile(path); err == nil && strings.Contains(string(data), "conservative") {
+				cfg.Orchestrator.TrustLevel = "conservative"
+				reloaded <- struct{}{}
+			}
+			return cfg, nil
+		},
+		logf: log.logf,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := r.watch(ctx, []string{path, filepath.Join(dir, "missing", "config.yaml")}); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("orchestrator:\n  trust_level: conservative\n"), 0o644); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("config change was not picked up:\n%s", log.String())
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if live.Load().Orchestrator.TrustLevel == "conservative" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("trust level not applied:\n%s", log.String())
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+const (
+	sessionsUsage            = "usage: buckley sessions merge <target> <source> | stats [--since 30d|all] [--by project|model] [--json]"
+	defaultSessionStatsSince = "30d"
+)
+
+func runSessionsCommand(args []string) error {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.TrimSpace(args[0])
+	}
+	switch sub {
+	case "merge":
+		return runSessionsMerge(args[1:])
+	case "stats":
+		return runSessionsStats(args[1:], os.Stdout)
+	default:
+		return withExitCode(fmt.Errorf(sessionsUsage), 2)
+	}
+}
+
+func runSessionsMerge(args []string) error {
+	if len(args) != 2 {
+		return withExitCode(fmt.Errorf("usage: buckley sessions merge <target> <source>"), 2)
+	}
+	targetID := strings.TrimSpace(args[0])
+	sourceID := strings.TrimSpace(args[1])
+
+	dbPath, err := resolveDBPath()
+	if err != nil {
+		return err
+	}
+	store, err := storage.New(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer store.Close()
+
+	appended, err := store.MergeSessions(targetID, sourceID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Merged %d messages from %s into %s; %s marked completed\n", appended, sourceID, targetID, sourceID)
+	return nil
+}
+
+func runSessionsStats(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("sessions stats", flag.ContinueOnError)
+	sinceFlag := fs.String("since", defaultSessionStatsSince, "Only count sessions active within this window (e.g. 24h, 7d, or all)")
+	groupBy := fs.String("by", storage.UsageGroupByProject, "Group usage by project or model")
+	jsonOut := fs.Bool("json", false, "Output stats as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return withExitCode(fmt.Errorf(sessionsUsage), 2)
+	}
+	since, err := parseStatsSince(*sinceFlag, time.Now())
+	if err != nil {
+		return withExitCode(err, 2)
+	}
+	switch strings.ToLower(strings.TrimSpace(*groupBy)) {
+	case storage.UsageGroupByProject, storage.UsageGroupByModel:
+	default:
+		return withExitCode(fmt.Errorf("--by must be %s or %s", storage.UsageGroupByProject, storage.UsageGroupByModel), 2)
+	}
+
+	dbPath, err := resolveDBPath()
+	if err != nil {
+		return err
+	}
+	store, err := storage.New(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer store.Close()
+
+	stats, err := store.SessionUsage(since, *groupBy)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+	return writeSessionStats(out, stats)
+}
+
+// parseStatsSince turns a --since window into a cutoff time. It accepts Go
+// durations, whole days such as "7d", and "all" for no cutoff.
+func parseStatsSince(raw string, now time.Time) (time.Time, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" || raw == "all" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return time.Time{}, fmt.Errorf("invalid --since %q (use e.g. 24h, 7d, or all)", raw)
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	window, err := time.ParseDuration(raw)
+	if err != nil || window <= 0 {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use e.g. 24h, 7d, or all)", raw)
+	}
+	return now.Add(-window), nil
+}
+
+func writeSessionStats(out io.Writer, stats *storage.SessionUsageStats) error {
+	window := "all time"
+	if !stats.Since.IsZero() {
+		window = "since " + stats.Since.Local().Format("2006-01-02 15:04")
+	}
+	fmt.Fprintf(out, "Sessions %s: %d sessions, %d messages, %d tokens, $%.4f\n", window, stats.Total.Sessions, stats.Total.Messages, stats.Total.Tokens, stats.Total.Cost)
+	if len(stats.Groups) == 0 {
+		return nil
+	}
+	fmt.Fprintln(out)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if stats.GroupBy == storage.UsageGroupByModel {
+		fmt.Fprintln(tw, "MODEL\tSESSIONS\tCALLS\tTOKENS\tCOST")
+		for _, group := range stats.Groups {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t$%.4f\n", group.Key, group.Sessions, group.Calls, group.Tokens, group.Cost)
+		}
+	} else {
+		fmt.Fprintln(tw, "PROJECT\tSESSIONS\tMESSAGES\tTOKENS\tCOST")
+		for _, group := range stats.Groups {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t$%.4f\n", firstNonEmpty(group.Key, "(none)"), group.Sessions, group.Messages, group.Tokens, group.Cost)
+		}
+	}
+	return tw.Flush()
+}
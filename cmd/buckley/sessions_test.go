@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestParseStatsSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"all": {},
+		"":    {},
+		"7d":  now.AddDate(0, 0, -7),
+		"24h": now.Add(-24 * time.Hour),
+		"90m": now.Add(-90 * time.Minute),
+	}
+	for raw, want := range tests {
+		got, err := parseStatsSince(raw, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseStatsSince(%q) = %v, %v; want %v", raw, got, err, want)
+		}
+	}
+	for _, bad := range []string{"0d", "-3d", "weekly", "-1h"} {
+		if _, err := parseStatsSince(bad, now); err == nil {
+			t.Errorf("parseStatsSince(%q): expected error", bad)
+		}
+	}
+}
+
+func TestRunSessionsStats(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "buckley.db")
+	t.Setenv(envBuckleyDBPath, dbPath)
+
+	store, err := storage.New(dbPath)
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	now := time.Now()
+	for _, sess := range []storage.Session{
+		{ID: "recent", ProjectPath: "/repo/a", CreatedAt: now.Add(-time.Hour), LastActive: now.Add(-time.Hour)},
+		{ID: "stale", ProjectPath: "/repo/b", CreatedAt: now.AddDate(0, 0, -60), LastActive: now.AddDate(0, 0, -60)},
+	} {
+		sess := sess
+		if err := store.CreateSession(&sess); err != nil {
+			t.Fatalf("CreateSession: %v", err)
+		}
+	}
+	if err := store.SaveAPICall(&storage.APICall{SessionID: "recent", Model: "openai/gpt-5", PromptTokens: 120, CompletionTokens: 30, Cost: 0.5, Timestamp: now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("SaveAPICall: %v", err)
+	}
+	_ = store.Close()
+
+	var out bytes.Buffer
+	if err := runSessionsStats([]string{"--by", "model"}, &out); err != nil {
+		t.Fatalf("runSessionsStats: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{"1 sessions", "$0.5000", "MODEL", "openai/gpt-5"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("output missing %q:\n%s", want, text)
+		}
+	}
+
+	out.Reset()
+	if err := runSessionsStats([]string{"--since", "all", "--json"}, &out); err != nil {
+		t.Fatalf("runSessionsStats --json: %v", err)
+	}
+	var stats storage.SessionUsageStats
+	if err := json.Unmarshal(out.Bytes(), &stats); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out.String())
+	}
+	if stats.Total.Sessions != 2 || len(stats.Groups) != 2 || stats.GroupBy != storage.UsageGroupByProject {
+		t.Fatalf("all-time stats = %+v", stats)
+	}
+
+	if err := runSessionsStats([]string{"--by", "branch"}, &out); err == nil {
+		t.Fatal("expected an error for --by branch")
+	}
+}
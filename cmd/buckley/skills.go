@@ -12,6 +12,8 @@ import (
 	"unicode"
 
 	"m31labs.dev/buckley/pkg/skill"
+	"m31labs.dev/buckley/pkg/tool"
+	"m31labs.dev/buckley/pkg/tool/builtin"
 )
 
 type skillsInitResult struct {
@@ -42,6 +44,13 @@ type skillsCommandEntry struct {
 	Content          string   `json:"content,omitempty"`
 }
 
+type skillsValidateReport struct {
+	Paths   []string               `json:"paths"`
+	Checked int                    `json:"checked"`
+	Invalid int                    `json:"invalid"`
+	Skills  []skill.FileValidation `json:"skills"`
+}
+
 func runSkillsCommand(args []string) error {
 	subCmd := "list"
 	if len(args) > 0 {
@@ -59,8 +68,10 @@ func runSkillsCommand(args []string) error {
 		return runSkillsListCommand(args)
 	case "show", "inspect":
 		return runSkillsShowCommand(args)
+	case "validate", "check":
+		return runSkillsValidateCommand(args)
 	default:
-		return fmt.Errorf("unknown skills subcommand: %s (use init, list, or show)", subCmd)
+		return fmt.Errorf("unknown skills subcommand: %s (use init, list, show, or validate)", subCmd)
 	}
 }
 
@@ -169,6 +180,109 @@ func runSkillsShowCommand(args []string) error {
 	return nil
 }
 
+func runSkillsValidateCommand(args []string) error {
+	fs := flag.NewFlagSet("skills validate", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	jsonOutput := fs.Bool("json", false, "print machine-readable JSON")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return withExitCode(fmt.Errorf("usage: buckley skills validate [--json|--format json] [path]"), 2)
+	}
+	if err := normalizeJSONFormatFlag(*format, jsonOutput); err != nil {
+		return withExitCode(err, 2)
+	}
+
+	var paths []string
+	if fs.NArg() == 1 {
+		path, err := expandHomePath(strings.TrimSpace(fs.Arg(0)))
+		if err != nil {
+			return withExitCode(err, 2)
+		}
+		paths = []string{path}
+	} else {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return withExitCode(fmt.Errorf("get working directory: %w", err), 2)
+		}
+		paths, err = skill.ProjectSkillDirs(cwd)
+		if err != nil {
+			return withExitCode(err, 2)
+		}
+		if len(paths) == 0 {
+			return withExitCode(fmt.Errorf("no project skill directories found; pass a skill file or directory to validate"), 2)
+		}
+	}
+
+	opts := skill.ValidateOptions{KnownTools: skillValidationToolNames()}
+	report := skillsValidateReport{Paths: paths, Skills: []skill.FileValidation{}}
+	for _, path := range paths {
+		results, err := skill.ValidatePath(path, opts)
+		if err != nil {
+			return withExitCode(err, 2)
+		}
+		report.Skills = append(report.Skills, results...)
+	}
+	report.Checked = len(report.Skills)
+	for _, result := range report.Skills {
+		if !result.Valid() {
+			report.Invalid++
+		}
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printSkillsValidateReport(os.Stdout, report)
+	}
+	if report.Invalid > 0 {
+		return withExitCode(fmt.Errorf("%d of %d skills failed validation", report.Invalid, report.Checked), 1)
+	}
+	return nil
+}
+
+// skillValidationToolNames lists the tools a skill's allowed_tools may name:
+// the built-in registry, default plugins, and the skill tools added at runtime.
+func skillValidationToolNames() []string {
+	registry := tool.NewRegistry()
+	_ = registry.LoadDefaultPlugins()
+	names := []string{(&builtin.SkillActivationTool{}).Name(), (&builtin.CreateSkillTool{}).Name()}
+	for _, t := range registry.List() {
+		if t != nil {
+			names = append(names, t.Name())
+		}
+	}
+	return names
+}
+
+func printSkillsValidateReport(w io.Writer, report skillsValidateReport) {
+	if len(report.Skills) == 0 {
+		fmt.Fprintf(w, "No skill files found in %s\n", strings.Join(report.Paths, ", "))
+		return
+	}
+	for _, result := range report.Skills {
+		name := result.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		if result.Valid() {
+			fmt.Fprintf(w, "✓ %s (%s)\n", name, result.Path)
+			continue
+		}
+		fmt.Fprintf(w, "✗ %s (%s)\n", name, result.Path)
+		for _, issue := range result.Issues {
+			fmt.Fprintf(w, "    %s: %s\n", issue.Field, issue.Message)
+		}
+	}
+	fmt.Fprintf(w, "\nChecked %d skills: %d valid, %d invalid\n", report.Checked, report.Checked-report.Invalid, report.Invalid)
+}
+
 func normalizeJSONFormatFlag(format string, jsonOutput *bool) error {
 	switch strings.ToLower(strings.TrimSpace(format)) {
 	case "", "text":
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/cost"
+	"m31labs.dev/buckley/pkg/model"
+	"m31labs.dev/buckley/pkg/orchestrator"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// parseMaxCostCents parses a --max-cost value. Zero disables the cap.
+func parseMaxCostCents(raw string) (float64, error) {
+	cents, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil || cents < 0 || math.IsInf(cents, 0) || math.IsNaN(cents) {
+		return 0, fmt.Errorf("--max-cost must be a non-negative number of cents, got %q", raw)
+	}
+	return cents, nil
+}
+
+// installSpendCap guards mgr with a hard limit of cents for the current run.
+// Spend is tracked for this run only, so earlier usage never counts toward
+// the cap. A zero cap installs nothing.
+func installSpendCap(mgr *model.Manager, store *storage.Store, cents float64) (*cost.SpendCap, error) {
+	if cents <= 0 || mgr == nil {
+		return nil, nil
+	}
+	if store == nil {
+		return nil, fmt.Errorf("--max-cost requires session storage")
+	}
+	tracker, err := newRunCostTracker(mgr, store)
+	if err != nil {
+		return nil, err
+	}
+	spendCap := cost.NewSpendCap(tracker, cents/100)
+	mgr.SetRequestGuard(spendCap)
+	return spendCap, nil
+}
+
+// installHardStopBudget returns the tracker plan execution checks between
+// tasks when cost_management.hard_stop is enabled. It shares the spend cap's
+// tracker when --max-cost is set; otherwise it installs an uncapped guard
+// that only records usage. It returns nil when hard stop is disabled.
+func installHardStopBudget(cfg *config.Config, mgr *model.Manager, store *storage.Store, spendCap *cost.SpendCap) (*cost.Tracker, error) {
+	if cfg == nil || !cfg.CostManagement.HardStop || mgr == nil {
+		return nil, nil
+	}
+	if store == nil {
+		return nil, fmt.Errorf("cost_management.hard_stop requires session storage")
+	}
+	tracker := spendCap.Tracker()
+	if tracker == nil {
+		var err error
+		if tracker, err = newRunCostTracker(mgr, store); err != nil {
+			return nil, err
+		}
+		mgr.SetRequestGuard(cost.NewSpendCap(tracker, 0))
+	}
+	budgets := cfg.CostManagement
+	tracker.SetBudgets(budgets.SessionBudget, budgets.DailyBudget, budgets.MonthlyBudget, budgets.AutoStopAt)
+	return tracker, nil
+}
+
+// newRunCostTracker tracks spend for one run in memory, against the stored
+// daily and monthly totals.
+func newRunCostTracker(mgr *model.Manager, store *storage.Store) (*cost.Tracker, error) {
+	tracker, err := cost.NewRunTracker(store, mgr)
+	if err != nil {
+		return nil, fmt.Errorf("create cost tracker: %w", err)
+	}
+	return tracker, nil
+}
+
+// reportSpendCap prints how much of the cap a run used.
+func reportSpendCap(spendCap *cost.SpendCap) {
+	if spendCap == nil || quietMode {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "cost: %.2f¢ of %.2f¢ cap\n", spendCap.Spent()*100, spendCap.Limit()*100)
+}
+
+// executionModelFor returns the model orch will execute tasks with, falling
+// back to the configured execution model when orch cannot report it.
+func executionModelFor(orch orchestratorRunner, cfg *config.Config) string {
+	if resolved, ok := orch.(interface{ ExecutionModel() string }); ok {
+		if modelID := strings.TrimSpace(resolved.ExecutionModel()); modelID != "" {
+			return modelID
+		}
+	}
+	if cfg == nil {
+		return ""
+	}
+	return cfg.Models.Execution
+}
+
+// confirmPlanCost prints an approximate cost range for the pending tasks of
+// plan and, when confirm is set, asks before running them. It reports
+// whether execution should go ahead.
+func confirmPlanCost(plan *orchestrator.Plan, modelID string, calc cost.CostCalculator, confirm bool, in io.Reader, out io.Writer) bool {
+	estimate, err := orchestrator.EstimatePlanCost(plan, modelID, calc)
+	switch {
+	case err != nil:
+		fmt.Fprintf(out, "Cost estimate unavailable: %v\n", err)
+	case estimate.Tasks == 0:
+		fmt.Fprintln(out, "Cost estimate: no pending tasks")
+		return true
+	default:
+		fmt.Fprintf(out, "Estimated cost (approximate): $%.2f-$%.2f for %d pending task(s) on %s\n",
+			estimate.Low, estimate.High, estimate.Tasks, estimate.Model)
+	}
+	if !confirm {
+		return true
+	}
+	fmt.Fprint(out, "Proceed with execution? [y/N]: ")
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/orchestrator"
+)
+
+type flatRateCalculator float64
+
+func (r flatRateCalculator) CalculateCostFromTokens(_ string, promptTokens, completionTokens int) (float64, error) {
+	return float64(promptTokens+completionTokens) / 1_000_000 * float64(r), nil
+}
+
+func TestConfirmPlanCost(t *testing.T) {
+	plan := &orchestrator.Plan{Tasks: []orchestrator.Task{{ID: "1", Type: orchestrator.TaskTypeImplementation}}}
+
+	var out bytes.Buffer
+	if !confirmPlanCost(plan, "p1/model-a", flatRateCalculator(10), false, strings.NewReader(""), &out) {
+		t.Fatal("expected execution to continue without confirmation")
+	}
+	if got := out.String(); !strings.Contains(got, "approximate") || !strings.Contains(got, "$0.34-$1.36") {
+		t.Fatalf("output = %q", got)
+	}
+
+	out.Reset()
+	if confirmPlanCost(plan, "p1/model-a", flatRateCalculator(10), true, strings.NewReader("n\n"), &out) {
+		t.Fatal("expected decline to stop execution")
+	}
+	if !confirmPlanCost(plan, "p1/model-a", flatRateCalculator(10), true, strings.NewReader("yes\n"), &out) {
+		t.Fatal("expected yes to continue")
+	}
+}
+
+type routedOrchestrator struct {
+	fakeOrchestrator
+	model string
+}
+
+func (o *routedOrchestrator) ExecutionModel() string { return o.model }
+
+func TestExecutionModelForUsesResolvedModel(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Models.Execution = "p1/configured"
+
+	if got := executionModelFor(&routedOrchestrator{model: "p1/routed"}, cfg); got != "p1/routed" {
+		t.Fatalf("executionModelFor = %q, want the orchestrator's resolved model", got)
+	}
+	if got := executionModelFor(&routedOrchestrator{}, cfg); got != "p1/configured" {
+		t.Fatalf("executionModelFor without a resolved model = %q, want the configured model", got)
+	}
+	if got := executionModelFor(&fakeOrchestrator{}, cfg); got != "p1/configured" {
+		t.Fatalf("executionModelFor = %q, want the configured model", got)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/model"
+)
+
+const tokensCountUsage = "usage: buckley tokens count [--model <id>] [--json] [file|-]"
+
+// modelInfoLookup resolves a model ID to its catalog entry.
+type modelInfoLookup func(modelID string) (*model.ModelInfo, error)
+
+// tokenCountReport is the --json output of buckley tokens count.
+type tokenCountReport struct {
+	Tokens         int     `json:"tokens"`
+	Bytes          int     `json:"bytes"`
+	Tokenizer      string  `json:"tokenizer"`
+	Model          string  `json:"model,omitempty"`
+	ContextLength  int     `json:"contextLength,omitempty"`
+	ContextPercent float64 `json:"contextPercent,omitempty"`
+	ContextUnknown bool    `json:"contextUnknown,omitempty"`
+}
+
+func runTokensCommand(args []string) error {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.TrimSpace(args[0])
+	}
+	switch sub {
+	case "count":
+		cfg, err := config.Load()
+		if err != nil {
+			return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+		}
+		return runTokensCount(args[1:], cfg, os.Stdin, os.Stdout, managerModelInfoLookup(cfg))
+	default:
+		return withExitCode(fmt.Errorf(tokensCountUsage), 2)
+	}
+}
+
+// managerModelInfoLookup returns a lookup that starts a model manager on
+// first use, so counting without --model never touches the network. When no
+// provider is configured or the model cannot be resolved it returns a nil
+// entry, which the count reports as an unknown context window.
+func managerModelInfoLookup(cfg *config.Config) modelInfoLookup {
+	return func(modelID string) (*model.ModelInfo, error) {
+		if cfg == nil || !cfg.Providers.HasReadyProvider() {
+			return nil, nil
+		}
+		mgr, err := model.NewManager(cfg)
+		if err != nil {
+			return nil, nil
+		}
+		if err := mgr.Initialize(); err != nil {
+			return nil, nil
+		}
+		info, err := mgr.GetModelInfo(modelID)
+		if err != nil {
+			return nil, nil
+		}
+		return info, nil
+	}
+}
+
+func runTokensCount(args []string, cfg *config.Config, stdin io.Reader, out io.Writer, lookup modelInfoLookup) error {
+	fs := flag.NewFlagSet("tokens count", flag.ContinueOnError)
+	modelID := fs.String("model", "", "Report the share of this model's context window")
+	jsonOut := fs.Bool("json", false, "Print the count as JSON")
+	if err := fs.Parse(args); err != nil {
+		return withExitCode(err, 2)
+	}
+	if fs.NArg() > 1 {
+		return withExitCode(fmt.Errorf(tokensCountUsage), 2)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if path := fs.Arg(0); path != "" && path != "-" {
+		data, err = os.ReadFile(path)
+	} else {
+		data, err = io.ReadAll(stdin)
+	}
+	if err != nil {
+		return fmt.Errorf("read input: %w", err)
+	}
+
+	tokenizerModel := strings.TrimSpace(*modelID)
+	setting := ""
+	if cfg != nil {
+		setting = cfg.Models.Tokenizer
+		if tokenizerModel == "" {
+			tokenizerModel = cfg.Models.Execution
+		}
+	}
+	if err := conversation.SetTokenizer(conversation.ResolveTokenizer(setting, tokenizerModel)); err != nil {
+		return withExitCode(err, 2)
+	}
+
+	report := tokenCountReport{
+		Tokens:    conversation.CountTokens(string(data)),
+		Bytes:     len(data),
+		Tokenizer: conversation.ActiveTokenizer(),
+		Model:     strings.TrimSpace(*modelID),
+	}
+	if report.Model != "" {
+		info, err := lookup(report.Model)
+		if err != nil {
+			return err
+		}
+		if info != nil && info.ContextLength > 0 {
+			report.ContextLength = info.ContextLength
+			report.ContextPercent = float64(report.Tokens) / float64(info.ContextLength) * 100
+		} else {
+			report.ContextUnknown = true
+		}
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	fmt.Fprintf(out, "%d tokens (%s, %d bytes)\n", report.Tokens, report.Tokenizer, report.Bytes)
+	switch {
+	case report.ContextLength > 0:
+		fmt.Fprintf(out, "%.1f%% of %s's %d-token context window\n", report.ContextPercent, report.Model, report.ContextLength)
+	case report.Model != "":
+		fmt.Fprintf(out, "Context window for %s is unknown\n", report.Model)
+	}
+	return nil
+}
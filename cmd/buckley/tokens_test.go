@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/model"
+)
+
+func estimateTokensConfig(t *testing.T) *config.Config {
+	t.Helper()
+	t.Cleanup(func() { _ = conversation.SetTokenizer(conversation.DefaultTokenizer) })
+	cfg := config.DefaultConfig()
+	cfg.Models.Tokenizer = conversation.TokenizerEstimate
+	return cfg
+}
+
+func TestRunTokensCountReadsStdin(t *testing.T) {
+	cfg := estimateTokensConfig(t)
+	noLookup := func(string) (*model.ModelInfo, error) {
+		t.Fatal("model lookup without --model")
+		return nil, nil
+	}
+
+	var out bytes.Buffer
+	if err := runTokensCount(nil, cfg, strings.NewReader(strings.Repeat("abcd", 10)), &out, noLookup); err != nil {
+		t.Fatalf("runTokensCount: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "10 tokens (estimate, 40 bytes)") {
+		t.Fatalf("output = %q", got)
+	}
+}
+
+func TestRunTokensCountJSONWithModel(t *testing.T) {
+	cfg := estimateTokensConfig(t)
+	path := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(path, []byte(strings.Repeat("abcd", 100)), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	lookup := func(id string) (*model.ModelInfo, error) {
+		return &model.ModelInfo{ID: id, ContextLength: 1000}, nil
+	}
+
+	var out bytes.Buffer
+	if err := runTokensCount([]string{"--model", "p1/model-a", "--json", path}, cfg, strings.NewReader(""), &out, lookup); err != nil {
+		t.Fatalf("runTokensCount: %v", err)
+	}
+	var report tokenCountReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out.String())
+	}
+	if report.Tokens != 100 || report.ContextLength != 1000 || report.ContextPercent != 10 || report.Model != "p1/model-a" {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestRunTokensCountErrors(t *testing.T) {
+	cfg := estimateTokensConfig(t)
+	failing := func(string) (*model.ModelInfo, error) { return nil, errors.New("no provider") }
+
+	if err := runTokensCount([]string{"a", "b"}, cfg, strings.NewReader(""), &bytes.Buffer{}, failing); err == nil {
+		t.Fatal("expected usage error for two files")
+	}
+	if err := runTokensCount([]string{"--model", "x"}, cfg, strings.NewReader("hi"), &bytes.Buffer{}, failing); err == nil {
+		t.Fatal("expected lookup error to be returned")
+	}
+}
+
+func TestRunTokensCountUnknownContextWithoutProvider(t *testing.T) {
+	cfg := estimateTokensConfig(t)
+	cfg.Providers = config.ProviderConfig{}
+
+	var out bytes.Buffer
+	if err := runTokensCount([]string{"--model", "p1/model-a", "--json"}, cfg, strings.NewReader("abcd"), &out, managerModelInfoLookup(cfg)); err != nil {
+		t.Fatalf("runTokensCount: %v", err)
+	}
+	var report tokenCountReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out.String())
+	}
+	if !report.ContextUnknown || report.ContextLength != 0 || report.Tokens != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+}
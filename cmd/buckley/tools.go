@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/mcp"
+	"m31labs.dev/buckley/pkg/tool"
+	"m31labs.dev/buckley/pkg/tool/external"
+)
+
+const toolListUsage = "usage: buckley tool list [--json]"
+
+func runToolCommand(args []string) error {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.TrimSpace(args[0])
+	}
+	if sub != "list" {
+		return withExitCode(fmt.Errorf(toolListUsage), 2)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return withExitCode(fmt.Errorf("failed to load config: %w", err), 2)
+	}
+	registry, closeTools := buildToolListRegistry(cfg, os.Stderr)
+	defer closeTools()
+	return runToolList(args[1:], registry, os.Stdout)
+}
+
+// buildToolListRegistry assembles the tools a project run would see:
+// builtins, plugins and any configured MCP servers, with the tool policy
+// applied. Load problems are reported to warn and do not stop the listing.
+func buildToolListRegistry(cfg *config.Config, warn io.Writer) (*tool.Registry, func()) {
+	registry := tool.NewRegistry()
+	if err := registry.LoadDefaultPlugins(); err != nil {
+		fmt.Fprintf(warn, "Warning: failed to load some plugins: %v\n", err)
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		registry.ConfigureContainers(cfg, cwd)
+	}
+	tool.ApplyToolPolicyConfig(registry, cfg)
+
+	manager, err := mcp.ManagerFromConfig(context.Background(), cfg.MCP)
+	if err != nil {
+		fmt.Fprintf(warn, "Warning: %v\n", err)
+	}
+	if manager == nil {
+		return registry, func() {}
+	}
+	mcp.RegisterMCPTools(manager, func(_ string, candidate any) {
+		if t, ok := candidate.(tool.Tool); ok {
+			registry.Register(t)
+		}
+	})
+	return registry, func() { _ = manager.Close() }
+}
+
+func runToolList(args []string, registry *tool.Registry, out io.Writer) error {
+	fs := flag.NewFlagSet("tool list", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "Print the OpenAI function schema sent to the model")
+	if err := fs.Parse(args); err != nil {
+		return withExitCode(err, 2)
+	}
+	if fs.NArg() > 0 {
+		return withExitCode(fmt.Errorf(toolListUsage), 2)
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(registry.ToOpenAIFunctionsFiltered(nil))
+	}
+
+	tools := registry.List()
+	if len(tools) == 0 {
+		fmt.Fprintln(out, "No tools registered.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSOURCE\tDESCRIPTION")
+	for _, t := range tools {
+		desc := firstLine(t.Description())
+		if !registry.PolicyAllows(t.Name()) {
+			desc = "(blocked by tool policy) " + desc
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", t.Name(), toolSource(t), desc)
+	}
+	return tw.Flush()
+}
+
+// toolSource reports where a registered tool came from.
+func toolSource(t tool.Tool) string {
+	switch t.(type) {
+	case *external.ExternalTool:
+		return "plugin"
+	case *mcp.ToolAdapter:
+		return "mcp"
+	default:
+		return "builtin"
+	}
+}
+
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if idx := strings.IndexByte(text, '\n'); idx >= 0 {
+		return strings.TrimSpace(text[:idx])
+	}
+	return text
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/tool"
+)
+
+func TestRunToolListText(t *testing.T) {
+	registry := tool.NewRegistry()
+	registry.SetToolPolicy(nil, []string{"run_shell"})
+
+	var out bytes.Buffer
+	if err := runToolList(nil, registry, &out); err != nil {
+		t.Fatalf("runToolList: %v", err)
+	}
+	text := out.String()
+	if !strings.HasPrefix(text, "NAME") {
+		t.Fatalf("missing header:\n%s", text)
+	}
+	var shellLine string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "run_shell ") {
+			shellLine = line
+		}
+	}
+	if !strings.Contains(shellLine, "builtin") || !strings.Contains(shellLine, "(blocked by tool policy)") {
+		t.Fatalf("run_shell line = %q", shellLine)
+	}
+}
+
+func TestRunToolListJSONOmitsBlockedTools(t *testing.T) {
+	registry := tool.NewRegistry()
+	registry.SetToolPolicy(nil, []string{"run_shell"})
+
+	var out bytes.Buffer
+	if err := runToolList([]string{"--json"}, registry, &out); err != nil {
+		t.Fatalf("runToolList --json: %v", err)
+	}
+	var functions []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &functions); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out.String())
+	}
+	if len(functions) == 0 {
+		t.Fatal("expected builtin tool schemas")
+	}
+	for _, fn := range functions {
+		def, _ := fn["function"].(map[string]any)
+		if def["name"] == "run_shell" {
+			t.Fatal("blocked tool included in --json output")
+		}
+		if _, ok := def["parameters"]; !ok {
+			t.Fatalf("schema missing parameters: %v", def)
+		}
+	}
+
+	if err := runToolList([]string{"extra"}, registry, &out); err == nil {
+		t.Fatal("expected usage error for extra arguments")
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func runValidateConfigCommand(args []string) error {
+	return runValidateConfig(args, os.Stdout)
+}
+
+// runValidateConfig checks one config file and prints its warnings. It only
+// reads the file; nothing is written and no session state is touched.
+func runValidateConfig(args []string, out io.Writer) error {
+	if len(args) != 1 || strings.TrimSpace(args[0]) == "" {
+		return withExitCode(fmt.Errorf("usage: buckley validate-config <path>"), 2)
+	}
+	path, err := expandHomePath(strings.TrimSpace(args[0]))
+	if err != nil {
+		return withExitCode(err, 2)
+	}
+
+	warnings, err := config.ValidateFile(path)
+	if err != nil {
+		fmt.Fprintf(out, "✗ %s is invalid\n", path)
+		return withExitCode(err, 2)
+	}
+	if len(warnings) > 0 {
+		fmt.Fprintln(out, "Warnings:")
+		for _, w := range warnings {
+			fmt.Fprintf(out, "  ⚠ %s\n", w)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintf(out, "✓ %s is valid\n", path)
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunValidateConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte("approval:\n  mode: yolo\norchestrator:\n  trust_levle: balanced\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("orchestrator:\n  trust_level: reckless\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runValidateConfig([]string{valid}, &out); err != nil {
+		t.Fatalf("runValidateConfig(valid): %v", err)
+	}
+	for _, want := range []string{"Warnings:", "yolo", "trust_levle", "is valid"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	err := runValidateConfig([]string{invalid}, &out)
+	if err == nil || exitCodeForError(err) != 2 {
+		t.Fatalf("runValidateConfig(invalid) = %v, want exit code 2", err)
+	}
+	if !strings.Contains(out.String(), "is invalid") {
+		t.Fatalf("output = %q, want invalid marker", out.String())
+	}
+
+	if err := runValidateConfig(nil, &out); exitCodeForError(err) != 2 {
+		t.Fatalf("runValidateConfig(no args) = %v, want usage error", err)
+	}
+}
@@ -879,6 +879,9 @@ func (s *Server) RequestToolExecution(req *acppb.ToolExecutionRequest, stream ac
 		_ = stream.Send(&acppb.ToolExecutionEvent{ExecutionId: req.Tool, Status: "failed", Output: err.Error(), Timestamp: timestamppb.Now()})
 		return statusError(codes.Internal, err.Error())
 	}
+	if res != nil && !res.Success {
+		return stream.Send(&acppb.ToolExecutionEvent{ExecutionId: req.Tool, Status: "failed", Output: res.Error, Timestamp: timestamppb.Now()})
+	}
 
 	out := ""
 	if res != nil {
@@ -11,6 +11,8 @@ import (
 	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/coordination/coordinator"
 	"m31labs.dev/buckley/pkg/coordination/events"
+	"m31labs.dev/buckley/pkg/coordination/security"
+	"m31labs.dev/buckley/pkg/mission"
 	"m31labs.dev/buckley/pkg/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -198,3 +200,63 @@ func TestUpdateEditorState_WithTodos(t *testing.T) {
 	require.NotNil(t, resp)
 	assert.Contains(t, resp.TodoState, "in_progress:")
 }
+
+type recordingToolStream struct {
+	mockServerStream
+	events []*acppb.ToolExecutionEvent
+}
+
+func (s *recordingToolStream) Send(ev *acppb.ToolExecutionEvent) error {
+	s.events = append(s.events, ev)
+	return nil
+}
+
+func TestRequestToolExecution_DangerousToolNeedsApprovalWhenAutonomous(t *testing.T) {
+	store, err := storage.New(filepath.Join(t.TempDir(), "acp.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	require.NoError(t, store.EnsureSession("agent-1"))
+
+	cfg := config.DefaultConfig()
+	cfg.Orchestrator.TrustLevel = "autonomous"
+	cfg.Tools.Dangerous = []string{"read_file"}
+	coord, err := coordinator.NewCoordinator(coordinator.DefaultConfig(), events.NewInMemoryStore())
+	require.NoError(t, err)
+	_, err = coord.RegisterAgent(context.Background(), &coordinator.AgentInfo{ID: "agent-1", Type: "builder", Capabilities: []string{"admin"}})
+	require.NoError(t, err)
+	srv, err := NewServer(coord, nil, cfg, store)
+	require.NoError(t, err)
+
+	ctx := security.ContextWithClaims(context.Background(), &security.Claims{AgentID: "agent-1", Capabilities: []string{"admin"}})
+	stream := &recordingToolStream{mockServerStream: mockServerStream{ctx: ctx}}
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.RequestToolExecution(&acppb.ToolExecutionRequest{
+			AgentId:    "agent-1",
+			Tool:       "read_file",
+			Parameters: map[string]string{"path": "go.mod"},
+		}, stream)
+	}()
+
+	missionStore := mission.NewStore(store.DB())
+	var changeID string
+	require.Eventually(t, func() bool {
+		pending, err := missionStore.ListPendingChanges("pending", 1)
+		if err != nil || len(pending) == 0 {
+			return false
+		}
+		changeID = pending[0].ID
+		return pending[0].FilePath == "tool://read_file"
+	}, 3*time.Second, 10*time.Millisecond, "dangerous call was not sent for approval")
+	require.NoError(t, missionStore.UpdatePendingChangeStatus(changeID, "rejected", "reviewer"))
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("tool execution did not finish after rejection")
+	}
+	last := stream.events[len(stream.events)-1]
+	assert.Equal(t, "failed", last.Status)
+	assert.Contains(t, last.Output, "rejected by reviewer")
+}
@@ -128,6 +128,7 @@ type Config struct {
 	Approval       ApprovalConfig       `yaml:"approval"`
 	Sandbox        SandboxConfig        `yaml:"sandbox"`
 	ToolMiddleware ToolMiddlewareConfig `yaml:"tool_middleware"`
+	Tools          ToolsConfig          `yaml:"tools"`
 	MCP            MCPConfig            `yaml:"mcp"`
 	ACP            ACPConfig            `yaml:"acp"`
 	Worktrees      WorktreeConfig       `yaml:"worktrees"`
@@ -143,11 +144,19 @@ type Config struct {
 	UI             UIConfig             `yaml:"ui"`
 	WebUI          WebUIConfig          `yaml:"web_ui"`
 	Commenting     CommentingConfig     `yaml:"commenting"`
+	Commit         CommitConfig         `yaml:"commit"`
 	GitEvents      GitEventsConfig      `yaml:"git_events"`
 	Buckbot        BuckbotConfig        `yaml:"buckbot"`
 	Input          InputConfig          `yaml:"input"`
 	Diagnostics    DiagnosticsConfig    `yaml:"diagnostics"`
 	Notify         NotifyConfig         `yaml:"notify"`
+	SystemPrompt   SystemPromptConfig   `yaml:"system_prompt"`
+	Persistence    PersistenceConfig    `yaml:"persistence"`
+	Export         ExportConfig         `yaml:"export"`
+
+	// loadWarnings holds problems found while loading config files, such as
+	// unrecognized keys. They are reported through ValidationWarnings.
+	loadWarnings []string
 }
 
 // NotifyConfig controls async notifications for human-in-the-loop workflows
@@ -176,16 +185,45 @@ type ModelConfig struct {
 	Planning        string              `yaml:"planning"`
 	Execution       string              `yaml:"execution"`
 	Review          string              `yaml:"review"`
-	Curated         []string            `yaml:"curated"`
+	Curated         CuratedModels       `yaml:"curated"`         // Model IDs curated for ACP/editor pickers, keyed by provider
 	VisionFallback  []string            `yaml:"vision_fallback"` // Ordered list of vision models to try
 	FallbackChains  map[string][]string `yaml:"fallback_chains"`
+	Fallbacks       []string            `yaml:"fallbacks"`        // Ordered models tried when a request fails because its provider is unavailable
 	DefaultProvider string              `yaml:"default_provider"` // Default provider (openrouter, openai, anthropic, google, codex)
 	Reasoning       string              `yaml:"reasoning"`        // Reasoning level: "off", "minimal", "low", "medium", "high", "xhigh", or "" for auto-detect
+	Tokenizer       string              `yaml:"tokenizer"`        // Token counting: "cl100k_base", "o200k_base", "p50k_base", "r50k_base", "estimate", or "auto"/"" to follow the execution model
+
+	// StreamIdleTimeout cancels a streaming response when the provider sends
+	// nothing for this long (0 disables the watchdog).
+	StreamIdleTimeout time.Duration `yaml:"stream_idle_timeout"`
+
+	// CuratedAutoSave persists /model curate changes to the "project" or
+	// "user" config as they are made; empty requires /model curate save.
+	CuratedAutoSave string `yaml:"curated_autosave"`
+
+	// MaxOutputTokens caps max_tokens on requests that do not set one. The
+	// cap is lowered to the model's output limit and the context left after
+	// the prompt; 0 leaves max_tokens unset.
+	MaxOutputTokens int `yaml:"max_output_tokens"`
+
+	// Retry controls retries of model requests that fail with a transient
+	// error (network failure, 429, 502, 503, 504).
+	Retry ModelRetryConfig `yaml:"retry"`
 
 	// Utility models for utility tasks.
 	Utility UtilityModelConfig `yaml:"utility"`
 }
 
+// ModelRetryConfig defines backoff for transient model request failures.
+// MaxAttempts counts the first try; 1 or less disables retries.
+type ModelRetryConfig struct {
+	MaxAttempts  int           `yaml:"max_attempts"`
+	InitialDelay time.Duration `yaml:"initial_delay"`
+	MaxDelay     time.Duration `yaml:"max_delay"`
+	Multiplier   float64       `yaml:"multiplier"`
+	Jitter       float64       `yaml:"jitter"`
+}
+
 // UtilityModelConfig defines models for utility tasks.
 type UtilityModelConfig struct {
 	Commit     string `yaml:"commit"`     // Model for generating commit messages
@@ -331,6 +369,8 @@ const (
 // ExecutionModeConfig controls the default execution strategy.
 type ExecutionModeConfig struct {
 	Mode string `yaml:"mode"`
+	// SuppressRLMWarning hides the one-time notice shown when RLM mode is active.
+	SuppressRLMWarning bool `yaml:"suppress_rlm_warning"`
 }
 
 // OneshotModeConfig controls the strategy for one-shot commands.
@@ -415,6 +455,36 @@ type DiagnosticsConfig struct {
 	NetworkLogsEnabled bool `yaml:"network_logs_enabled"`
 }
 
+// MaxSystemPromptFooterChars is the footer length above which config check warns.
+const MaxSystemPromptFooterChars = 1000
+
+// SystemPromptConfig controls additions to the assembled runtime system prompt.
+type SystemPromptConfig struct {
+	// Footer is appended after project context on every prompt, regardless of budget.
+	// Intended for short compliance notices or disclaimers.
+	Footer string `yaml:"footer"`
+}
+
+// PersistenceConfig controls what Buckley writes to its local database.
+type PersistenceConfig struct {
+	// Ephemeral keeps conversation transcripts in memory only. It also
+	// suppresses telemetry persistence.
+	Ephemeral bool `yaml:"ephemeral"`
+	// DisableTelemetry stops telemetry events from being stored for replay.
+	DisableTelemetry bool `yaml:"disable_telemetry"`
+}
+
+// ExportConfig sets the defaults for /export. Flags on the command override them.
+type ExportConfig struct {
+	// DefaultFormat is the export format when --format is not given:
+	// markdown (default, or its alias md), json, or html.
+	DefaultFormat string `yaml:"default_format"`
+	// IncludeSystem keeps system messages in exported transcripts.
+	IncludeSystem bool `yaml:"include_system"`
+	// IncludeTools keeps tool calls and tool results in exported transcripts.
+	IncludeTools bool `yaml:"include_tools"`
+}
+
 // TranscriptionConfig controls audio-to-text conversion
 type TranscriptionConfig struct {
 	Provider     string `yaml:"provider"`      // api, system, hybrid (default: api)
@@ -612,6 +682,32 @@ type IPCConfig struct {
 	BasicAuthUsername string   `yaml:"basic_auth_username"`
 	BasicAuthPassword string   `yaml:"basic_auth_password"`
 	PushSubject       string   `yaml:"push_subject"` // mailto: or https: URL for VAPID (e.g., mailto:admin@example.com)
+	// RateLimit throttles API requests per authenticated principal.
+	RateLimit IPCRateLimitConfig `yaml:"rate_limit"`
+	// LogFormat selects server log output: "text" (default) or "json". JSON
+	// mode also writes one access line per request.
+	LogFormat string `yaml:"log_format"`
+	// Commands registers namespaced session command types that run a local
+	// program when sent over IPC.
+	Commands []IPCCommandConfig `yaml:"commands"`
+}
+
+// IPCCommandConfig maps a namespaced command type ("namespace:name") to a
+// program. The command content is written to its stdin.
+type IPCCommandConfig struct {
+	Type    string        `yaml:"type"`
+	Command string        `yaml:"command"`
+	Args    []string      `yaml:"args"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// IPCRateLimitConfig is a per-principal token bucket: each principal gets
+// Requests requests per Interval, with up to Burst requests at once.
+// Requests of zero disables the limit.
+type IPCRateLimitConfig struct {
+	Requests int           `yaml:"requests"`
+	Interval time.Duration `yaml:"interval"`
+	Burst    int           `yaml:"burst"`
 }
 
 // CostConfig defines budget limits
@@ -620,6 +716,9 @@ type CostConfig struct {
 	DailyBudget   float64 `yaml:"daily_budget"`
 	MonthlyBudget float64 `yaml:"monthly_budget"`
 	AutoStopAt    float64 `yaml:"auto_stop_at"`
+	// HardStop halts plan execution and interactive turns once a budget is
+	// exceeded or AutoStopAt is reached, instead of only warning.
+	HardStop bool `yaml:"hard_stop"`
 }
 
 // RetryPolicy defines retry behavior for transient errors
@@ -639,18 +738,39 @@ type ToolRetryConfig struct {
 	Jitter       float64       `yaml:"jitter"`
 }
 
+// ToolsConfig controls per-tool gating.
+type ToolsConfig struct {
+	// Dangerous lists tools that always need confirmation, whatever the
+	// approval mode or trust level. Approving them over IPC needs operator
+	// scope.
+	Dangerous []string `yaml:"dangerous"`
+}
+
 // ToolMiddlewareConfig defines middleware defaults for tool execution.
 type ToolMiddlewareConfig struct {
 	DefaultTimeout  time.Duration            `yaml:"default_timeout"`
 	PerToolTimeouts map[string]time.Duration `yaml:"per_tool_timeouts"`
 	MaxResultBytes  int                      `yaml:"max_result_bytes"`
 	Retry           ToolRetryConfig          `yaml:"retry"`
+	// MaxParallel caps how many read-only tool calls from one model turn run
+	// at once. 1 or less runs every call sequentially.
+	MaxParallel int `yaml:"max_parallel"`
+	// AllowList, when non-empty, limits tools to these names. DenyList names
+	// are never advertised or run, and win over AllowList. Both apply on top
+	// of skill filtering.
+	AllowList []string `yaml:"allow_list"`
+	DenyList  []string `yaml:"deny_list"`
 }
 
 // MCPConfig defines MCP server settings for tool integration.
 type MCPConfig struct {
 	Enabled bool              `yaml:"enabled"`
 	Servers []MCPServerConfig `yaml:"servers"`
+	// HealthInterval is how often connected servers are pinged and dropped
+	// ones reconnected. 0 disables the health check loop.
+	HealthInterval time.Duration `yaml:"health_interval"`
+	// HealthTimeout bounds each ping.
+	HealthTimeout time.Duration `yaml:"health_timeout"`
 }
 
 // MCPServerConfig describes a single MCP server.
@@ -735,7 +855,10 @@ type UIConfig struct {
 	UseTextLabels   bool          `yaml:"use_text_labels"`  // Add text labels to color-only indicators
 	ReduceAnimation bool          `yaml:"reduce_animation"` // Reduce or disable animations
 	MessageMetadata string        `yaml:"message_metadata"` // "always", "hover", or "never"
+	RenderMarkdown  bool          `yaml:"render_markdown"`  // Render assistant markdown; false shows raw source
 	Audio           UIAudioConfig `yaml:"audio"`
+	// Model picker settings
+	ModelPickerGrouping string `yaml:"model_picker_grouping"` // "provider" or "capability"
 }
 
 // WebUIConfig defines web UI integration settings.
@@ -750,9 +873,22 @@ type CommentingConfig struct {
 	CommentNonObviousOnly         bool `yaml:"comment_non_obvious_only"`
 }
 
+// CommitConfig sets the commit message style for `buckley commit` and the TUI
+// /commit command. BUCKLEY_PROMPT_COMMIT still replaces the whole prompt.
+type CommitConfig struct {
+	// Template is the header layout, using {type}, {scope} and {subject}
+	// placeholders. Empty keeps the built-in action(scope): subject style.
+	Template string `yaml:"template"`
+	// Types lists the allowed header types. Empty keeps the built-in verbs.
+	Types []string `yaml:"types"`
+	// MaxSubjectLength caps the length of the full header line.
+	MaxSubjectLength int `yaml:"max_subject_length"`
+}
+
 type GitEventsConfig struct {
 	Enabled            bool   `yaml:"enabled"`
 	Secret             string `yaml:"secret"`
+	Provider           string `yaml:"provider"` // github (default) or gitlab
 	AutoRegressionPlan bool   `yaml:"auto_regression_plan"`
 	WebhookBind        string `yaml:"webhook_bind"`
 	RegressionCommand  string `yaml:"regression_command"`
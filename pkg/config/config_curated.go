@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CuratedAllBucket holds curated models that are not scoped to a provider.
+// A flat models.curated list from older configs loads into this bucket.
+const CuratedAllBucket = "all"
+
+// CuratedModels maps a provider ID to the model IDs curated for it.
+type CuratedModels map[string][]string
+
+// UnmarshalYAML accepts the per-provider map as well as the older flat
+// list, which is migrated into CuratedAllBucket.
+func (c *CuratedModels) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.SequenceNode:
+		var ids []string
+		if err := value.Decode(&ids); err != nil {
+			return err
+		}
+		*c = nil
+		if len(ids) > 0 {
+			*c = CuratedModels{CuratedAllBucket: ids}
+		}
+		return nil
+	case yaml.MappingNode:
+		var buckets map[string][]string
+		if err := value.Decode(&buckets); err != nil {
+			return err
+		}
+		*c = CuratedModels(buckets)
+		return nil
+	case yaml.ScalarNode:
+		if value.Tag == "!!null" {
+			*c = nil
+			return nil
+		}
+	}
+	return fmt.Errorf("models.curated must be a list of model IDs or a map of provider to model IDs")
+}
+
+// Providers returns the bucket names in display order: CuratedAllBucket
+// first, then providers alphabetically.
+func (c CuratedModels) Providers() []string {
+	providers := make([]string, 0, len(c))
+	for provider, ids := range c {
+		if len(ids) > 0 {
+			providers = append(providers, provider)
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		if providers[i] == CuratedAllBucket || providers[j] == CuratedAllBucket {
+			return providers[i] == CuratedAllBucket
+		}
+		return providers[i] < providers[j]
+	})
+	return providers
+}
+
+// IDs returns every curated model ID once, in Providers order.
+func (c CuratedModels) IDs() []string {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, provider := range c.Providers() {
+		for _, id := range c[provider] {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Contains reports whether modelID is curated under any provider.
+func (c CuratedModels) Contains(modelID string) bool {
+	for _, ids := range c {
+		if slices.Contains(ids, modelID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add curates modelID under provider.
+func (c *CuratedModels) Add(provider, modelID string) {
+	if *c == nil {
+		*c = make(CuratedModels)
+	}
+	(*c)[provider] = append((*c)[provider], modelID)
+}
+
+// Remove drops modelID from every provider it is curated under and reports
+// whether it was found. Buckets left empty are deleted.
+func (c CuratedModels) Remove(modelID string) bool {
+	found := false
+	for provider, ids := range c {
+		if !slices.Contains(ids, modelID) {
+			continue
+		}
+		found = true
+		kept := slices.DeleteFunc(slices.Clone(ids), func(id string) bool { return id == modelID })
+		if len(kept) == 0 {
+			delete(c, provider)
+		} else {
+			c[provider] = kept
+		}
+	}
+	return found
+}
+
+// Clone returns a deep copy, or nil when c is empty.
+func (c CuratedModels) Clone() CuratedModels {
+	if len(c) == 0 {
+		return nil
+	}
+	out := make(CuratedModels, len(c))
+	for provider, ids := range c {
+		out[provider] = append([]string{}, ids...)
+	}
+	return out
+}
@@ -111,10 +111,12 @@ func DefaultConfig() *Config {
 			Planning:  defaultOpenRouterModel,
 			Execution: defaultOpenRouterModel,
 			Review:    defaultOpenRouterModel,
-			Curated: []string{
-				defaultOpenRouterChatModel,
-				defaultOpenRouterKimiCode,
-				defaultOpenRouterQwenMax,
+			Curated: CuratedModels{
+				CuratedAllBucket: {
+					defaultOpenRouterChatModel,
+					defaultOpenRouterKimiCode,
+					defaultOpenRouterQwenMax,
+				},
 			},
 			VisionFallback: []string{
 				"openai/gpt-5.4-mini",
@@ -137,7 +139,16 @@ func DefaultConfig() *Config {
 					defaultOpenRouterUtilityModel,
 				},
 			},
-			DefaultProvider: "openrouter",
+			DefaultProvider:   "openrouter",
+			StreamIdleTimeout: 2 * time.Minute,
+			MaxOutputTokens:   16384,
+			Retry: ModelRetryConfig{
+				MaxAttempts:  3,
+				InitialDelay: time.Second,
+				MaxDelay:     30 * time.Second,
+				Multiplier:   2,
+				Jitter:       0.2,
+			},
 			Utility: UtilityModelConfig{
 				Commit:     DefaultCommitModel,
 				PR:         DefaultUtilityModel,
@@ -205,6 +216,14 @@ func DefaultConfig() *Config {
 		Diagnostics: DiagnosticsConfig{
 			NetworkLogsEnabled: false,
 		},
+		Commit: CommitConfig{
+			MaxSubjectLength: 72,
+		},
+		Export: ExportConfig{
+			DefaultFormat: "markdown",
+			IncludeSystem: false,
+			IncludeTools:  false,
+		},
 		Personality: PersonalityConfig{
 			Enabled:          true,
 			QuirkProbability: 0.15,
@@ -288,9 +307,13 @@ func DefaultConfig() *Config {
 			},
 		},
 		Sandbox: defaultSandboxConfig(),
+		Tools: ToolsConfig{
+			Dangerous: []string{},
+		},
 		ToolMiddleware: ToolMiddlewareConfig{
 			DefaultTimeout: 2 * time.Minute,
 			MaxResultBytes: 100_000,
+			MaxParallel:    4,
 			Retry: ToolRetryConfig{
 				MaxAttempts:  2,
 				InitialDelay: 200 * time.Millisecond,
@@ -300,8 +323,10 @@ func DefaultConfig() *Config {
 			},
 		},
 		MCP: MCPConfig{
-			Enabled: false,
-			Servers: []MCPServerConfig{},
+			Enabled:        false,
+			Servers:        []MCPServerConfig{},
+			HealthInterval: 30 * time.Second,
+			HealthTimeout:  2 * time.Second,
 		},
 		ACP: ACPConfig{
 			EventStore:         defaultACPStore(),
@@ -378,6 +403,12 @@ func DefaultConfig() *Config {
 			BasicAuthEnabled:  false,
 			BasicAuthUsername: "",
 			BasicAuthPassword: "",
+			RateLimit: IPCRateLimitConfig{
+				Requests: 0, // 0 = disabled
+				Interval: time.Minute,
+				Burst:    0,
+			},
+			LogFormat: "text",
 		},
 		CostManagement: CostConfig{
 			SessionBudget: 10.00,
@@ -454,6 +485,8 @@ func DefaultConfig() *Config {
 			SidebarMinWidth:           16,
 			SidebarMaxWidth:           60,
 			MessageMetadata:           "always",
+			RenderMarkdown:            true,
+			ModelPickerGrouping:       "provider",
 			Audio: UIAudioConfig{
 				Enabled:      false,
 				AssetsPath:   "",
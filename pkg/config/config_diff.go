@@ -0,0 +1,183 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sources reported in a Difference.
+const (
+	DiffSourceUser    = "user"
+	DiffSourceProject = "project"
+	DiffSourceEnv     = "env"
+)
+
+// Difference is one setting whose effective value is not the default.
+type Difference struct {
+	Key     string `json:"key"`
+	Default any    `json:"default"`
+	Value   any    `json:"value"`
+	// Source is the last layer that changed the value: user or project for
+	// the config files, env for environment overrides and the provider
+	// defaults Buckley derives from them.
+	Source string `json:"source"`
+}
+
+// secretConfigKeys are leaf keys whose string values are masked in a diff.
+var secretConfigKeys = map[string]bool{
+	"api_key":             true,
+	"token":               true,
+	"bot_token":           true,
+	"password":            true,
+	"basic_auth_password": true,
+	"secret":              true,
+	"webhook_url":         true,
+}
+
+// Diff loads the effective configuration the way Load does and returns every
+// dotted key whose value differs from DefaultConfig, sorted by key. Secret
+// values are masked.
+func Diff() ([]Difference, error) {
+	strict := strictFromEnv()
+	cfg := DefaultConfig()
+	defaults, err := flattenConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = os.Getenv("HOME")
+	}
+	layers := []struct {
+		source  string
+		path    string
+		project bool
+	}{
+		{DiffSourceUser, "", false},
+		{DiffSourceProject, filepath.Join(".", ".buckley", "config.yaml"), true},
+	}
+	if home != "" {
+		layers[0].path = filepath.Join(home, ".buckley", "config.yaml")
+	}
+	snapshots := make([]map[string]any, 0, len(layers))
+	for _, layer := range layers {
+		if layer.path != "" {
+			if err := loadAndMerge(cfg, layer.path, layer.project, strict); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("loading %s config: %w", layer.source, err)
+			}
+		}
+		snapshot, err := flattenConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	effectiveCfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	effective, err := flattenConfig(effectiveCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{}, len(effective))
+	for key := range effective {
+		keys[key] = struct{}{}
+	}
+	for key := range defaults {
+		keys[key] = struct{}{}
+	}
+
+	var diffs []Difference
+	for key := range keys {
+		value, def := effective[key], defaults[key]
+		if reflect.DeepEqual(value, def) {
+			continue
+		}
+		source := DiffSourceUser
+		switch {
+		case !reflect.DeepEqual(value, snapshots[1][key]):
+			source = DiffSourceEnv
+		case !reflect.DeepEqual(snapshots[1][key], snapshots[0][key]):
+			source = DiffSourceProject
+		}
+		diffs = append(diffs, Difference{
+			Key:     key,
+			Default: maskConfigValue(key, def),
+			Value:   maskConfigValue(key, value),
+			Source:  source,
+		})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Key < diffs[j].Key })
+	return diffs, nil
+}
+
+// flattenConfig renders cfg as YAML and flattens it into dotted keys, with
+// list elements indexed (mcp.servers[0].env.TOKEN).
+func flattenConfig(cfg *Config) (map[string]any, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	out := make(map[string]any)
+	flattenInto(out, "", raw)
+	return out, nil
+}
+
+func flattenInto(out map[string]any, path string, value any) {
+	switch node := value.(type) {
+	case map[string]any:
+		if len(node) > 0 {
+			for key, child := range node {
+				childPath := key
+				if path != "" {
+					childPath = path + "." + key
+				}
+				flattenInto(out, childPath, child)
+			}
+			return
+		}
+	case []any:
+		if len(node) > 0 {
+			for i, child := range node {
+				flattenInto(out, fmt.Sprintf("%s[%d]", path, i), child)
+			}
+			return
+		}
+	}
+	if path != "" {
+		out[path] = value
+	}
+}
+
+// maskConfigValue hides string values under a secret key at any depth and
+// every value inside an env map, since those commonly carry tokens.
+func maskConfigValue(key string, value any) any {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return value
+	}
+	segments := strings.Split(key, ".")
+	for i, segment := range segments {
+		if idx := strings.Index(segment, "["); idx >= 0 {
+			segment = segment[:idx]
+		}
+		if secretConfigKeys[segment] || (segment == "env" && i < len(segments)-1) {
+			return "********"
+		}
+	}
+	return value
+}
@@ -7,6 +7,18 @@ import (
 	"time"
 )
 
+// providerAPIKey returns the named key from the environment, or from
+// ~/.buckley/config.env when the config has no key of its own.
+func providerAPIKey(name string, configEnv map[string]string, current string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	if current == "" {
+		return configEnv[name]
+	}
+	return ""
+}
+
 // applyEnvOverrides applies environment variable overrides
 func applyEnvOverrides(cfg *Config, configEnv map[string]string) {
 	// Model selection
@@ -24,6 +36,9 @@ func applyEnvOverrides(cfg *Config, configEnv map[string]string) {
 	} else if v := os.Getenv("BUCKLEY_REASONING"); v != "" {
 		cfg.Models.Reasoning = v
 	}
+	if v := os.Getenv("BUCKLEY_MODEL_TOKENIZER"); v != "" {
+		cfg.Models.Tokenizer = v
+	}
 	if v := os.Getenv("BUCKLEY_TRUST_LEVEL"); v != "" {
 		cfg.Orchestrator.TrustLevel = v
 	}
@@ -78,24 +93,23 @@ func applyEnvOverrides(cfg *Config, configEnv map[string]string) {
 	} else if val, ok := envBool("BUCKLEY_DISABLE_NETWORK_LOGS"); ok && val {
 		cfg.Diagnostics.NetworkLogsEnabled = false
 	}
+	if val, ok := envBool("BUCKLEY_EPHEMERAL"); ok {
+		cfg.Persistence.Ephemeral = val
+	}
 
 	// Provider API keys
-	if v := os.Getenv("OPENROUTER_API_KEY"); v != "" {
+	if v := providerAPIKey("OPENROUTER_API_KEY", configEnv, cfg.Providers.OpenRouter.APIKey); v != "" {
 		cfg.Providers.OpenRouter.APIKey = v
-	} else if cfg.Providers.OpenRouter.APIKey == "" {
-		if v := configEnv["OPENROUTER_API_KEY"]; v != "" {
-			cfg.Providers.OpenRouter.APIKey = v
-		}
 	}
-	if v := os.Getenv("OPENAI_API_KEY"); v != "" {
+	if v := providerAPIKey("OPENAI_API_KEY", configEnv, cfg.Providers.OpenAI.APIKey); v != "" {
 		cfg.Providers.OpenAI.APIKey = v
 		cfg.Providers.OpenAI.Enabled = true
 	}
-	if v := os.Getenv("ANTHROPIC_API_KEY"); v != "" {
+	if v := providerAPIKey("ANTHROPIC_API_KEY", configEnv, cfg.Providers.Anthropic.APIKey); v != "" {
 		cfg.Providers.Anthropic.APIKey = v
 		cfg.Providers.Anthropic.Enabled = true
 	}
-	if v := os.Getenv("GOOGLE_API_KEY"); v != "" {
+	if v := providerAPIKey("GOOGLE_API_KEY", configEnv, cfg.Providers.Google.APIKey); v != "" {
 		cfg.Providers.Google.APIKey = v
 		cfg.Providers.Google.Enabled = true
 	}
@@ -120,7 +134,7 @@ func applyEnvOverrides(cfg *Config, configEnv map[string]string) {
 	if v := os.Getenv("BUCKLEY_LITELLM_API_KEY"); v != "" {
 		cfg.Providers.LiteLLM.APIKey = v
 		cfg.Providers.LiteLLM.Enabled = true
-	} else if v := os.Getenv("LITELLM_API_KEY"); v != "" && cfg.Providers.LiteLLM.APIKey == "" {
+	} else if v := providerAPIKey("LITELLM_API_KEY", configEnv, cfg.Providers.LiteLLM.APIKey); v != "" && cfg.Providers.LiteLLM.APIKey == "" {
 		cfg.Providers.LiteLLM.APIKey = v
 		cfg.Providers.LiteLLM.Enabled = true
 	}
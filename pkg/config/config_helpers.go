@@ -36,6 +36,23 @@ func isLoopbackBindAddress(addr string) bool {
 	}
 }
 
+// IsDangerousTool reports whether name is listed in tools.dangerous.
+func (c *Config) IsDangerousTool(name string) bool {
+	if c == nil {
+		return false
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return false
+	}
+	for _, dangerous := range c.Tools.Dangerous {
+		if strings.ToLower(strings.TrimSpace(dangerous)) == name {
+			return true
+		}
+	}
+	return false
+}
+
 // ExecutionMode returns the normalized execution mode.
 func (c *Config) ExecutionMode() string {
 	if c == nil {
@@ -7,8 +7,25 @@ import (
 	"strings"
 )
 
-// Load loads configuration from default locations with proper precedence
+// Load loads configuration from default locations with proper precedence.
+// Unrecognized keys are reported through ValidationWarnings; set
+// BUCKLEY_CONFIG_STRICT=1 to make them a load error instead.
 func Load() (*Config, error) {
+	return load(strictFromEnv())
+}
+
+// LoadStrict is Load with strict mode forced on: unrecognized keys in either
+// config file fail the load, naming the file and line.
+func LoadStrict() (*Config, error) {
+	return load(true)
+}
+
+func strictFromEnv() bool {
+	strict, ok := envBool("BUCKLEY_CONFIG_STRICT")
+	return ok && strict
+}
+
+func load(strict bool) (*Config, error) {
 	// Start with defaults
 	cfg := DefaultConfig()
 
@@ -22,14 +39,14 @@ func Load() (*Config, error) {
 	}
 	if home != "" {
 		userConfigPath := filepath.Join(home, ".buckley", "config.yaml")
-		if err := loadAndMerge(cfg, userConfigPath, false); err != nil && !os.IsNotExist(err) {
+		if err := loadAndMerge(cfg, userConfigPath, false, strict); err != nil && !os.IsNotExist(err) {
 			return nil, fmt.Errorf("loading user config: %w", err)
 		}
 	}
 
 	// Load project config (./.buckley/config.yaml)
 	projectConfigPath := filepath.Join(".", ".buckley", "config.yaml")
-	if err := loadAndMerge(cfg, projectConfigPath, true); err != nil && !os.IsNotExist(err) {
+	if err := loadAndMerge(cfg, projectConfigPath, true, strict); err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("loading project config: %w", err)
 	}
 
@@ -56,7 +73,7 @@ func LoadFromPath(path string) (*Config, error) {
 	configEnv := loadConfigEnvVars()
 
 	// Load from the specified path
-	if err := loadAndMerge(cfg, path, false); err != nil {
+	if err := loadAndMerge(cfg, path, false, strictFromEnv()); err != nil {
 		return nil, fmt.Errorf("loading config from %s: %w", path, err)
 	}
 
@@ -120,3 +137,22 @@ func loadConfigEnvVars() map[string]string {
 	}
 	return vars
 }
+
+// ValidateFile checks a single config file without loading it into any
+// running state. YAML syntax and type errors and Validate failures are
+// returned as errors. Keys Buckley would ignore are reported among the
+// ValidationWarnings, since they are usually typos.
+func ValidateFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+	if _, err := decodeConfigStrict(data); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.ValidationWarnings(), nil
+}
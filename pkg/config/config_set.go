@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetFileValue writes one dotted key (for example "models.execution") into
+// the YAML config at path, creating the file if needed and keeping its other
+// settings. The value is parsed as a YAML scalar or flow collection, so
+// "true", "30s" and "[a, b]" become a bool, duration and list.
+//
+// The key must name a Config field and the value must decode into it; a
+// value that decodes but fails Validate (an out-of-range threshold, say) is
+// rejected too. projectScope marks path as a project config: keys the loader
+// ignores there are refused and the value is validated the way the loader
+// would merge it. Comments and key order in the file are not preserved.
+func SetFileValue(path, key, value string, projectScope bool) error {
+	segments, err := splitConfigKey(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseConfigValue(value)
+	if err != nil {
+		return err
+	}
+	if err := checkConfigValue(segments, parsed, projectScope); err != nil {
+		return err
+	}
+
+	var raw map[string]any
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("parsing YAML from %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+
+	node := raw
+	for i, segment := range segments[:len(segments)-1] {
+		switch next := node[segment].(type) {
+		case map[string]any:
+			node = next
+		case nil:
+			child := make(map[string]any)
+			node[segment] = child
+			node = child
+		default:
+			return fmt.Errorf("%s in %s is not a mapping", strings.Join(segments[:i+1], "."), path)
+		}
+	}
+	node[segments[len(segments)-1]] = parsed
+
+	out, err := yaml.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0o644)
+}
+
+func splitConfigKey(key string) ([]string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("config key is required")
+	}
+	segments := strings.Split(key, ".")
+	for _, segment := range segments {
+		if strings.TrimSpace(segment) == "" {
+			return nil, fmt.Errorf("invalid config key %q", key)
+		}
+	}
+	return segments, nil
+}
+
+func parseConfigValue(value string) (any, error) {
+	if strings.TrimSpace(value) == "" {
+		return value, nil
+	}
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	return parsed, nil
+}
+
+// userOnlyConfigKeys are the settings mergeConfigs drops from a project
+// config (see mergeApprovalConfig, mergeSandboxConfig and mergeMCPConfig), so
+// a checked-out repo cannot loosen approval or the sandbox.
+var userOnlyConfigKeys = []string{
+	"approval.mode",
+	"sandbox.mode",
+	"sandbox.allow_unsafe",
+	"mcp.enabled",
+	"mcp.servers",
+}
+
+// checkConfigValue decodes {segments: value} strictly against Config, then
+// merges it over the defaults in the target scope and validates the result.
+func checkConfigValue(segments []string, value any, projectScope bool) error {
+	doc := map[string]any{segments[len(segments)-1]: value}
+	for i := len(segments) - 2; i >= 0; i-- {
+		doc = map[string]any{segments[i]: doc}
+	}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	key := strings.Join(segments, ".")
+	unknown, err := decodeConfigStrict(data)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	if projectScope {
+		for _, userKey := range userOnlyConfigKeys {
+			if boolFieldSet(doc, strings.Split(userKey, ".")...) {
+				return fmt.Errorf("%s is ignored in project config; set it in the user config instead", userKey)
+			}
+		}
+	}
+
+	var override Config
+	if err := yaml.Unmarshal(data, &override); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	cfg := DefaultConfig()
+	mergeConfigs(cfg, &override, doc, projectScope)
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return nil
+}
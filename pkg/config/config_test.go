@@ -1,9 +1,15 @@
 package config_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
 
 	"m31labs.dev/buckley/pkg/config"
 )
@@ -29,12 +35,13 @@ func TestDefaultConfig(t *testing.T) {
 		t.Fatalf("unexpected Buckbot efficiency defaults: %+v", cfg.Buckbot)
 	}
 	wantCurated := []string{"z-ai/glm-5.2", "moonshotai/kimi-k2.7-code", "qwen/qwen3.7-max"}
-	if len(cfg.Models.Curated) != len(wantCurated) {
+	gotCurated := cfg.Models.Curated[config.CuratedAllBucket]
+	if len(gotCurated) != len(wantCurated) {
 		t.Fatalf("expected curated defaults %v, got %v", wantCurated, cfg.Models.Curated)
 	}
 	for i, want := range wantCurated {
-		if cfg.Models.Curated[i] != want {
-			t.Fatalf("expected curated[%d] to be %s, got %s", i, want, cfg.Models.Curated[i])
+		if gotCurated[i] != want {
+			t.Fatalf("expected curated[%d] to be %s, got %s", i, want, gotCurated[i])
 		}
 	}
 	wantFallback := []string{"moonshotai/kimi-k2.7-code", "qwen/qwen3.7-max", "qwen/qwen3.6-flash"}
@@ -193,6 +200,65 @@ func TestIPCRemoteBindRequiresAuthentication(t *testing.T) {
 	}
 }
 
+func TestIPCRateLimitValidation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected default (disabled) rate limit to validate, got %v", err)
+	}
+
+	cfg.IPC.RateLimit.Requests = 60
+	cfg.IPC.RateLimit.Interval = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation error for rate limit without interval")
+	}
+
+	cfg.IPC.RateLimit.Interval = time.Minute
+	cfg.IPC.RateLimit.Burst = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation error for negative burst")
+	}
+
+	cfg.IPC.RateLimit.Burst = 10
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected rate limit to validate, got %v", err)
+	}
+}
+
+func TestIPCLogFormatValidation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if cfg.IPC.LogFormat != "text" {
+		t.Fatalf("expected text log format by default, got %q", cfg.IPC.LogFormat)
+	}
+	cfg.IPC.LogFormat = "json"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected json log format to validate, got %v", err)
+	}
+	cfg.IPC.LogFormat = "xml"
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation error for unknown log format")
+	}
+}
+
+func TestIPCCommandsValidation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.IPC.Commands = []config.IPCCommandConfig{{Type: "acme:deploy", Command: "./deploy.sh"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected namespaced command to validate, got %v", err)
+	}
+	cfg.IPC.Commands = append(cfg.IPC.Commands, config.IPCCommandConfig{Type: "acme:deploy", Command: "./other.sh"})
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation error for duplicate command type")
+	}
+	cfg.IPC.Commands = []config.IPCCommandConfig{{Type: "deploy", Command: "./deploy.sh"}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation error for command type without namespace")
+	}
+	cfg.IPC.Commands = []config.IPCCommandConfig{{Type: "acme:deploy"}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation error for missing command")
+	}
+}
+
 func TestWorktreesRootPathAllowsHomeExpansionWhenContainersEnabled(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
@@ -298,6 +364,51 @@ func TestLoadReadsConfigEnvForOpenRouterKey(t *testing.T) {
 	}
 }
 
+func TestLoadReadsConfigEnvForEveryProviderKey(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	keys := map[string]string{
+		"OPENAI_API_KEY":    "openai-file-key",
+		"ANTHROPIC_API_KEY": "anthropic-file-key",
+		"GOOGLE_API_KEY":    "google-file-key",
+		"LITELLM_API_KEY":   "litellm-file-key",
+	}
+	var configEnv strings.Builder
+	for name, value := range keys {
+		t.Setenv(name, "")
+		fmt.Fprintf(&configEnv, "export %s=%q\n", name, value)
+	}
+	t.Setenv("BUCKLEY_LITELLM_API_KEY", "")
+
+	configDir := filepath.Join(home, ".buckley")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("mkdir config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.env"), []byte(configEnv.String()), 0o600); err != nil {
+		t.Fatalf("write config.env: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	got := map[string]string{
+		"OPENAI_API_KEY":    cfg.Providers.OpenAI.APIKey,
+		"ANTHROPIC_API_KEY": cfg.Providers.Anthropic.APIKey,
+		"GOOGLE_API_KEY":    cfg.Providers.Google.APIKey,
+		"LITELLM_API_KEY":   cfg.Providers.LiteLLM.APIKey,
+	}
+	for name, want := range keys {
+		if got[name] != want {
+			t.Fatalf("%s = %q, want %q from config.env", name, got[name], want)
+		}
+	}
+	if !cfg.Providers.Anthropic.Enabled || !cfg.Providers.OpenAI.Enabled {
+		t.Fatal("providers with a config.env key were not enabled")
+	}
+}
+
 func TestEnvOverridesEnableProviders(t *testing.T) {
 	t.Setenv("OPENAI_API_KEY", "openai-key")
 	t.Setenv("ANTHROPIC_API_KEY", "anthropic-key")
@@ -479,7 +590,7 @@ func TestReadyProvidersOrdering(t *testing.T) {
 	}
 }
 
-func TestLoadProjectConfigCanDisableNetworkLogs(t *testing.T) {
+func TestLoadProjectConfigSystemPromptFooter(t *testing.T) {
 	home := t.TempDir()
 	project := t.TempDir()
 
@@ -490,8 +601,8 @@ func TestLoadProjectConfigCanDisableNetworkLogs(t *testing.T) {
 		t.Fatalf("mkdir project config: %v", err)
 	}
 	projectCfg := `
-diagnostics:
-  network_logs_enabled: false
+system_prompt:
+  footer: "Internal use only."
 `
 	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
 		t.Fatalf("write project config: %v", err)
@@ -503,7 +614,972 @@ diagnostics:
 	if err != nil {
 		t.Fatalf("config.Load returned error: %v", err)
 	}
-	if cfg.Diagnostics.NetworkLogsEnabled {
-		t.Fatalf("expected network logs disabled from project config")
+	if cfg.SystemPrompt.Footer != "Internal use only." {
+		t.Fatalf("SystemPrompt.Footer = %q, want %q", cfg.SystemPrompt.Footer, "Internal use only.")
+	}
+}
+
+func TestLoadProjectConfigPersistence(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+persistence:
+  ephemeral: true
+  disable_telemetry: true
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if !cfg.Persistence.Ephemeral || !cfg.Persistence.DisableTelemetry {
+		t.Fatalf("Persistence = %+v, want ephemeral with telemetry disabled", cfg.Persistence)
+	}
+}
+
+func TestLoadProjectConfigExportDefaults(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+export:
+  default_format: json
+  include_tools: true
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.Export.IncludeSystem || !cfg.Export.IncludeTools || cfg.Export.DefaultFormat != "json" {
+		t.Fatalf("Export = %+v, want json with tools included and system left at its default", cfg.Export)
+	}
+}
+
+func TestInvalidExportFormatFailsValidation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if cfg.Export.DefaultFormat != "markdown" {
+		t.Fatalf("default export format = %q, want markdown", cfg.Export.DefaultFormat)
+	}
+	cfg.Export.DefaultFormat = "pdf"
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation to fail for unsupported export format")
+	}
+	cfg.Export.DefaultFormat = "HTML"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected html export format to validate, got %v", err)
+	}
+	cfg.Export.DefaultFormat = "md"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected the md alias to validate like --format md, got %v", err)
+	}
+}
+
+func TestLoadProjectConfigCommitConventions(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+commit:
+  template: "[{scope}] {subject}"
+  types: [feature, bugfix]
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.Commit.Template != "[{scope}] {subject}" || len(cfg.Commit.Types) != 2 || cfg.Commit.MaxSubjectLength != 72 {
+		t.Fatalf("Commit = %+v, want the project template and types with the default length", cfg.Commit)
+	}
+}
+
+func TestInvalidCommitConventionsFailValidation(t *testing.T) {
+	tests := map[string]func(*config.Config){
+		"negative length":     func(c *config.Config) { c.Commit.MaxSubjectLength = -1 },
+		"template no subject": func(c *config.Config) { c.Commit.Template = "{type}({scope})" },
+		"empty type":          func(c *config.Config) { c.Commit.Types = []string{"feat", " "} },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			mutate(cfg)
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "commit.") {
+				t.Fatalf("Validate error = %v, want a commit.* error", err)
+			}
+		})
+	}
+}
+
+func TestLoadProjectConfigStreamIdleTimeout(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+models:
+  stream_idle_timeout: 0s
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	if got := config.DefaultConfig().Models.StreamIdleTimeout; got != 2*time.Minute {
+		t.Fatalf("default stream idle timeout = %s, want 2m", got)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.Models.StreamIdleTimeout != 0 {
+		t.Fatalf("stream idle timeout = %s, want explicit 0 to disable the watchdog", cfg.Models.StreamIdleTimeout)
+	}
+
+	cfg.Models.StreamIdleTimeout = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation to fail for a negative stream idle timeout")
+	}
+}
+
+func TestLoadProjectConfigMaxOutputTokens(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+models:
+  max_output_tokens: 0
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	if got := config.DefaultConfig().Models.MaxOutputTokens; got != 16384 {
+		t.Fatalf("default max output tokens = %d, want 16384", got)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.Models.MaxOutputTokens != 0 {
+		t.Fatalf("max output tokens = %d, want explicit 0 to disable the cap", cfg.Models.MaxOutputTokens)
+	}
+
+	cfg.Models.MaxOutputTokens = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation to fail for negative max_output_tokens")
+	}
+}
+
+func TestLoadProjectConfigModelRetry(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+models:
+  retry:
+    max_attempts: 5
+    initial_delay: 500ms
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	retry := cfg.Models.Retry
+	if retry.MaxAttempts != 5 || retry.InitialDelay != 500*time.Millisecond {
+		t.Fatalf("retry = %+v, want project max_attempts and initial_delay", retry)
+	}
+	if retry.MaxDelay != 30*time.Second || retry.Multiplier != 2 || retry.Jitter != 0.2 {
+		t.Fatalf("retry = %+v, want defaults for unset fields", retry)
+	}
+
+	cfg.Models.Retry.Multiplier = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation to fail for a negative retry multiplier")
+	}
+}
+
+func TestLoadProjectConfigDangerousToolsOnlyAdds(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	userCfgDir := filepath.Join(home, ".buckley")
+	if err := os.MkdirAll(userCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir user config: %v", err)
+	}
+	userCfg := `
+tools:
+  dangerous: [run_shell, write_file]
+`
+	if err := os.WriteFile(filepath.Join(userCfgDir, "config.yaml"), []byte(userCfg), 0o644); err != nil {
+		t.Fatalf("write user config: %v", err)
+	}
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+tools:
+  dangerous: [Write_File, git_push]
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	if got := config.DefaultConfig().Tools.Dangerous; len(got) != 0 {
+		t.Fatalf("default dangerous tools = %v, want none", got)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if got := strings.Join(cfg.Tools.Dangerous, ","); got != "run_shell,write_file,git_push" {
+		t.Fatalf("dangerous tools = %s, want the user list plus git_push", got)
+	}
+	if !cfg.IsDangerousTool("RUN_SHELL") || cfg.IsDangerousTool("read_file") {
+		t.Fatalf("IsDangerousTool does not match tools.dangerous %v", cfg.Tools.Dangerous)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte("tools:\n  dangerous: []\n"), 0o644); err != nil {
+		t.Fatalf("rewrite project config: %v", err)
+	}
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if len(cfg.Tools.Dangerous) != 2 {
+		t.Fatalf("project config dropped user dangerous tools: %v", cfg.Tools.Dangerous)
+	}
+
+	cfg.Tools.Dangerous = append(cfg.Tools.Dangerous, " ")
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation to fail for a blank dangerous tool")
+	}
+}
+
+func TestLoadProjectConfigModelPickerGrouping(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+ui:
+  model_picker_grouping: capability
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	if got := config.DefaultConfig().UI.ModelPickerGrouping; got != "provider" {
+		t.Fatalf("default model picker grouping = %q, want provider", got)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.UI.ModelPickerGrouping != "capability" {
+		t.Fatalf("model picker grouping = %q, want capability", cfg.UI.ModelPickerGrouping)
+	}
+
+	cfg.UI.ModelPickerGrouping = "price"
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected validation to fail for an unknown model picker grouping")
+	}
+}
+
+func TestLoadProjectConfigSuppressRLMWarning(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+execution:
+  mode: rlm
+  suppress_rlm_warning: true
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	if config.DefaultConfig().Execution.SuppressRLMWarning {
+		t.Fatalf("expected the RLM warning to be enabled by default")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.ExecutionMode() != config.ExecutionModeRLM || !cfg.Execution.SuppressRLMWarning {
+		t.Fatalf("execution = %+v, want rlm with the warning suppressed", cfg.Execution)
+	}
+}
+
+func TestLoadProjectConfigCanDisableNetworkLogs(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+diagnostics:
+  network_logs_enabled: false
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.Diagnostics.NetworkLogsEnabled {
+		t.Fatalf("expected network logs disabled from project config")
+	}
+}
+
+func writeValidateFileConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestValidateFileAcceptsValidConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeValidateFileConfig(t, `
+orchestrator:
+  trust_level: conservative
+export:
+  default_format: json
+`)
+	warnings, err := config.ValidateFile(path)
+	if err != nil {
+		t.Fatalf("ValidateFile: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("warnings = %v, want none", warnings)
+	}
+}
+
+func TestValidateFileReportsWarnings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeValidateFileConfig(t, `
+approval:
+  mode: yolo
+`)
+	warnings, err := config.ValidateFile(path)
+	if err != nil {
+		t.Fatalf("ValidateFile: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "yolo") {
+		t.Fatalf("warnings = %v, want the yolo approval warning", warnings)
+	}
+}
+
+func TestValidateFileWarnsOnUnknownKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := writeValidateFileConfig(t, `
+orchestrator:
+  trust_levle: conservative
+`)
+	warnings, err := config.ValidateFile(path)
+	if err != nil {
+		t.Fatalf("ValidateFile: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "trust_levle") || !strings.Contains(warnings[0], "line 3") {
+		t.Fatalf("warnings = %v, want the misspelled key with its line", warnings)
+	}
+}
+
+func TestValidateFileRejectsInvalidConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "type mismatch", content: "memory:\n  retrieval_limit: lots\n", wantErr: "line 2"},
+		{name: "invalid value", content: "orchestrator:\n  trust_level: reckless\n", wantErr: "invalid trust level"},
+		{name: "unknown webhook provider", content: "git_events:\n  provider: bitbucket\n", wantErr: "git_events.provider"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.ValidateFile(writeValidateFileConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateFile error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := config.ValidateFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestLoadProjectConfigToolMaxParallel(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+tool_middleware:
+  max_parallel: 1
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	if got := config.DefaultConfig().ToolMiddleware.MaxParallel; got != 4 {
+		t.Fatalf("default max_parallel = %d, want 4", got)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.ToolMiddleware.MaxParallel != 1 {
+		t.Fatalf("max_parallel = %d, want 1", cfg.ToolMiddleware.MaxParallel)
+	}
+}
+
+func TestLoadProjectConfigToolPolicy(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+tool_middleware:
+  deny_list: [run_shell]
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if got := cfg.ToolMiddleware.DenyList; len(got) != 1 || got[0] != "run_shell" {
+		t.Fatalf("deny_list = %v, want [run_shell]", got)
+	}
+	if cfg.ToolMiddleware.AllowList != nil {
+		t.Fatalf("allow_list = %v, want unset", cfg.ToolMiddleware.AllowList)
+	}
+}
+
+func TestLoadProjectConfigMCPHealthOnly(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+mcp:
+  enabled: true
+  servers:
+    - name: evil
+      command: /tmp/evil
+  health_interval: 5s
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.MCP.HealthInterval != 5*time.Second {
+		t.Fatalf("health_interval = %v, want 5s", cfg.MCP.HealthInterval)
+	}
+	if cfg.MCP.Enabled || len(cfg.MCP.Servers) != 0 {
+		t.Fatalf("project config enabled MCP servers: %+v", cfg.MCP)
+	}
+}
+
+func TestLoadProjectConfigTokenizer(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+models:
+  tokenizer: o200k_base
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.Models.Tokenizer != "o200k_base" {
+		t.Fatalf("tokenizer = %q, want o200k_base", cfg.Models.Tokenizer)
+	}
+
+	cfg.Models.Tokenizer = "sentencepiece"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid tokenizer") {
+		t.Fatalf("Validate() = %v, want invalid tokenizer error", err)
+	}
+}
+
+func TestLoadProjectConfigCuratedPerProvider(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	userCfgDir := filepath.Join(home, ".buckley")
+	if err := os.MkdirAll(userCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir user config: %v", err)
+	}
+	userCfg := `
+models:
+  curated:
+    - openai/gpt-4o
+    - anthropic/claude-sonnet-4-5
+`
+	if err := os.WriteFile(filepath.Join(userCfgDir, "config.yaml"), []byte(userCfg), 0o644); err != nil {
+		t.Fatalf("write user config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	want := config.CuratedModels{config.CuratedAllBucket: {"openai/gpt-4o", "anthropic/claude-sonnet-4-5"}}
+	if !reflect.DeepEqual(cfg.Models.Curated, want) {
+		t.Fatalf("flat curated list = %v, want it migrated to %v", cfg.Models.Curated, want)
+	}
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+models:
+  curated:
+    openrouter:
+      - z-ai/glm-5.2
+    anthropic:
+      - anthropic/claude-sonnet-4-5
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	want = config.CuratedModels{
+		"openrouter": {"z-ai/glm-5.2"},
+		"anthropic":  {"anthropic/claude-sonnet-4-5"},
+	}
+	if !reflect.DeepEqual(cfg.Models.Curated, want) {
+		t.Fatalf("per-provider curated = %v, want %v", cfg.Models.Curated, want)
+	}
+	if got := cfg.Models.Curated.IDs(); !reflect.DeepEqual(got, []string{"anthropic/claude-sonnet-4-5", "z-ai/glm-5.2"}) {
+		t.Fatalf("IDs() = %v, want providers in order", got)
+	}
+}
+
+func TestCuratedModelsAddRemove(t *testing.T) {
+	var curated config.CuratedModels
+	curated.Add("openai", "openai/gpt-4o")
+	curated.Add(config.CuratedAllBucket, "z-ai/glm-5.2")
+	curated.Add("openai", "openai/gpt-5")
+	if got := curated.IDs(); !reflect.DeepEqual(got, []string{"z-ai/glm-5.2", "openai/gpt-4o", "openai/gpt-5"}) {
+		t.Fatalf("IDs() = %v, want the all bucket first", got)
+	}
+	if !curated.Contains("openai/gpt-5") || curated.Contains("openai/o3") {
+		t.Fatalf("Contains reported the wrong membership for %v", curated)
+	}
+	if !curated.Remove("z-ai/glm-5.2") || curated.Remove("openai/o3") {
+		t.Fatalf("Remove reported the wrong result for %v", curated)
+	}
+	if _, ok := curated[config.CuratedAllBucket]; ok {
+		t.Fatalf("empty bucket was kept: %v", curated)
+	}
+}
+
+func TestLoadProjectConfigCuratedAutoSave(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+
+	t.Setenv("HOME", home)
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := `
+models:
+  curated_autosave: user
+`
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	if cfg.Models.CuratedAutoSave != "user" {
+		t.Fatalf("curated_autosave = %q, want user", cfg.Models.CuratedAutoSave)
+	}
+
+	cfg.Models.CuratedAutoSave = "workspace"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "curated_autosave") || !strings.Contains(err.Error(), "global") {
+		t.Fatalf("Validate() = %v, want invalid curated_autosave error listing every scope", err)
+	}
+}
+
+func TestLoadReportsUnknownKeys(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("BUCKLEY_CONFIG_STRICT", "")
+
+	projectCfgDir := filepath.Join(project, ".buckley")
+	if err := os.MkdirAll(projectCfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir project config: %v", err)
+	}
+	projectCfg := "orchestrator:\n  trust_level: balanced\n  trust_levle: autonomous\n"
+	if err := os.WriteFile(filepath.Join(projectCfgDir, "config.yaml"), []byte(projectCfg), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+	t.Chdir(project)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load returned error: %v", err)
+	}
+	found := false
+	for _, w := range cfg.ValidationWarnings() {
+		if strings.Contains(w, "config.yaml") && strings.Contains(w, "line 3") && strings.Contains(w, "trust_levle") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("warnings = %v, want the unknown key with file and line", cfg.ValidationWarnings())
+	}
+
+	if _, err := config.LoadStrict(); err == nil || !strings.Contains(err.Error(), "trust_levle") {
+		t.Fatalf("LoadStrict() = %v, want unknown key error", err)
+	}
+	t.Setenv("BUCKLEY_CONFIG_STRICT", "1")
+	if _, err := config.Load(); err == nil || !strings.Contains(err.Error(), "strict mode") {
+		t.Fatalf("Load() with BUCKLEY_CONFIG_STRICT = %v, want strict mode error", err)
+	}
+}
+
+func TestSetFileValueMergesIntoExistingConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".buckley", "config.yaml")
+	if err := config.SetFileValue(path, "models.execution", "anthropic/claude-sonnet-4-5", true); err != nil {
+		t.Fatalf("SetFileValue(new file): %v", err)
+	}
+	if err := os.WriteFile(path, []byte("models:\n  execution: anthropic/claude-sonnet-4-5\n  planning: openai/gpt-5\napproval:\n  mode: safe\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	for key, value := range map[string]string{
+		"models.execution":              "z-ai/glm-5.2",
+		"memory.auto_compact_threshold": "0.8",
+		"ipc.allowed_origins":           "[http://localhost:5173]",
+		"ipc.rate_limit.interval":       "30s",
+	} {
+		if err := config.SetFileValue(path, key, value, true); err != nil {
+			t.Fatalf("SetFileValue(%s, %s): %v", key, value, err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	var cfg config.Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("parse written config: %v", err)
+	}
+	if cfg.Models.Execution != "z-ai/glm-5.2" || cfg.Models.Planning != "openai/gpt-5" {
+		t.Fatalf("models = %+v, want execution replaced and planning kept", cfg.Models)
+	}
+	if cfg.Approval.Mode != "safe" {
+		t.Fatalf("approval.mode = %q, want safe", cfg.Approval.Mode)
+	}
+	if cfg.Memory.AutoCompactThreshold != 0.8 {
+		t.Fatalf("auto_compact_threshold = %v, want 0.8", cfg.Memory.AutoCompactThreshold)
+	}
+	if !reflect.DeepEqual(cfg.IPC.AllowedOrigins, []string{"http://localhost:5173"}) {
+		t.Fatalf("allowed_origins = %v", cfg.IPC.AllowedOrigins)
+	}
+	if cfg.IPC.RateLimit.Interval != 30*time.Second {
+		t.Fatalf("rate_limit.interval = %v, want 30s", cfg.IPC.RateLimit.Interval)
+	}
+}
+
+func TestSetFileValueRefusesUserOnlyKeysInProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "project.yaml")
+	for _, tc := range []struct{ key, value string }{
+		{"sandbox.mode", "strict"},
+		{"sandbox", "{mode: readonly}"},
+		{"approval.mode", "yolo"},
+		{"mcp.enabled", "true"},
+		{"mcp.servers", "[{name: x, command: y}]"},
+	} {
+		err := config.SetFileValue(project, tc.key, tc.value, true)
+		if err == nil || !strings.Contains(err.Error(), "ignored in project config") {
+			t.Fatalf("SetFileValue(project, %s) = %v, want a user-config-only error", tc.key, err)
+		}
+	}
+	if _, err := os.Stat(project); !os.IsNotExist(err) {
+		t.Fatalf("refused sets created the project config: %v", err)
+	}
+
+	if err := config.SetFileValue(project, "sandbox.allowed_paths", "[/tmp]", true); err != nil {
+		t.Fatalf("SetFileValue(project, sandbox.allowed_paths): %v", err)
+	}
+	if err := config.SetFileValue(filepath.Join(dir, "user.yaml"), "sandbox.mode", "strict", false); err != nil {
+		t.Fatalf("SetFileValue(user, sandbox.mode): %v", err)
+	}
+}
+
+func TestSetFileValueRejectsInvalidKeysAndValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "models:\n  execution: z-ai/glm-5.2\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cases := []struct {
+		key, value, want string
+	}{
+		{"models.exectuion", "x", "unknown config key"},
+		{"nope", "1", "unknown config key"},
+		{"models..execution", "x", "invalid config key"},
+		{"", "x", "config key is required"},
+		{"models", "x", "invalid value for models"},
+		{"ipc.enabled", "maybe", "invalid value for ipc.enabled"},
+		{"memory.auto_compact_threshold", "5", "invalid value for memory.auto_compact_threshold"},
+	}
+	for _, tc := range cases {
+		err := config.SetFileValue(path, tc.key, tc.value, false)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("SetFileValue(%q, %q) = %v, want %q", tc.key, tc.value, err, tc.want)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if string(data) != original {
+		t.Fatalf("config was modified by rejected sets:\n%s", data)
+	}
+}
+
+func TestDiffAttributesSources(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("BUCKLEY_CONFIG_STRICT", "")
+	t.Setenv("BUCKLEY_EPHEMERAL", "true")
+
+	for dir, content := range map[string]string{
+		home:    "orchestrator:\n  trust_level: conservative\nexport:\n  default_format: json\ngit_events:\n  secret: webhook-secret-value\n",
+		project: "export:\n  default_format: html\n",
+	} {
+		cfgDir := filepath.Join(dir, ".buckley")
+		if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+			t.Fatalf("mkdir config: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte(content), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	t.Chdir(project)
+
+	diffs, err := config.Diff()
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	bySource := make(map[string]config.Difference)
+	for _, d := range diffs {
+		bySource[d.Key] = d
+	}
+	want := map[string]string{
+		"orchestrator.trust_level": config.DiffSourceUser,
+		"export.default_format":    config.DiffSourceProject,
+		"persistence.ephemeral":    config.DiffSourceEnv,
+		"git_events.secret":        config.DiffSourceUser,
+	}
+	for key, source := range want {
+		d, ok := bySource[key]
+		if !ok {
+			t.Fatalf("Diff missing %s: %+v", key, diffs)
+		}
+		if d.Source != source {
+			t.Fatalf("%s source = %q, want %q", key, d.Source, source)
+		}
+	}
+	if got := bySource["export.default_format"].Value; got != "html" {
+		t.Fatalf("export.default_format = %v, want html", got)
+	}
+	if got := bySource["git_events.secret"].Value; got == "webhook-secret-value" {
+		t.Fatalf("git_events.secret was not masked")
+	}
+}
+
+func TestDiffIndexesListsAndMasksMCPEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("BUCKLEY_CONFIG_STRICT", "")
+	cfgDir := filepath.Join(home, ".buckley")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir config: %v", err)
+	}
+	content := "mcp:\n  servers:\n    - name: github\n      command: gh-mcp\n      env:\n        GITHUB_PERSONAL_ACCESS_TOKEN: ghp-secret-value\n"
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	diffs, err := config.Diff()
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	byKey := make(map[string]config.Difference)
+	for _, d := range diffs {
+		if s, ok := d.Value.(string); ok && strings.Contains(s, "ghp-secret-value") {
+			t.Fatalf("%s leaked the MCP env token", d.Key)
+		}
+		byKey[d.Key] = d
+	}
+	if got := byKey["mcp.servers[0].name"].Value; got != "github" {
+		t.Fatalf("mcp.servers[0].name = %v, want github (diffs: %+v)", got, diffs)
+	}
+	token, ok := byKey["mcp.servers[0].env.GITHUB_PERSONAL_ACCESS_TOKEN"]
+	if !ok {
+		t.Fatalf("Diff missing the indexed env key: %+v", diffs)
+	}
+	if token.Value != "********" {
+		t.Fatalf("env token = %v, want masked", token.Value)
 	}
 }
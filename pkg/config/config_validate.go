@@ -41,6 +41,41 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("invalid reasoning level: %s (valid: auto, off, minimal, low, medium, high, xhigh)", c.Models.Reasoning)
 		}
 	}
+	if tokenizer := strings.ToLower(strings.TrimSpace(c.Models.Tokenizer)); tokenizer != "" {
+		validTokenizers := map[string]bool{
+			"auto": true, "cl100k_base": true, "o200k_base": true,
+			"p50k_base": true, "r50k_base": true, "estimate": true,
+		}
+		if !validTokenizers[tokenizer] {
+			return fmt.Errorf("invalid tokenizer: %s (valid: auto, cl100k_base, o200k_base, p50k_base, r50k_base, estimate)", c.Models.Tokenizer)
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(c.Models.CuratedAutoSave)) {
+	case "", "project", "user", "global":
+	default:
+		return fmt.Errorf("invalid models.curated_autosave: %s (valid: project, user, global, or empty for manual save)", c.Models.CuratedAutoSave)
+	}
+	if c.Models.StreamIdleTimeout < 0 {
+		return fmt.Errorf("models.stream_idle_timeout must be >= 0")
+	}
+	if c.Models.MaxOutputTokens < 0 {
+		return fmt.Errorf("models.max_output_tokens must be >= 0, got %d", c.Models.MaxOutputTokens)
+	}
+	if c.Models.Retry.MaxAttempts < 0 {
+		return fmt.Errorf("models.retry.max_attempts must be >= 0")
+	}
+	if c.Models.Retry.InitialDelay < 0 {
+		return fmt.Errorf("models.retry.initial_delay must be >= 0")
+	}
+	if c.Models.Retry.MaxDelay < 0 {
+		return fmt.Errorf("models.retry.max_delay must be >= 0")
+	}
+	if c.Models.Retry.Multiplier < 0 {
+		return fmt.Errorf("models.retry.multiplier must be >= 0")
+	}
+	if c.Models.Retry.Jitter < 0 {
+		return fmt.Errorf("models.retry.jitter must be >= 0")
+	}
 
 	// Validate approval mode
 	validApprovalModes := map[string]bool{
@@ -65,6 +100,11 @@ func (c *Config) Validate() error {
 	if c.Sandbox.MaxOutputBytes < 0 {
 		return fmt.Errorf("sandbox.max_output_bytes must be >= 0")
 	}
+	for _, name := range c.Tools.Dangerous {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("tools.dangerous entries must be tool names")
+		}
+	}
 	if c.Sandbox.DockerSandbox.Enabled && strings.TrimSpace(c.Sandbox.DockerSandbox.Image) == "" {
 		return fmt.Errorf("sandbox.docker.image is required when docker sandbox is enabled")
 	}
@@ -95,6 +135,12 @@ func (c *Config) Validate() error {
 	if c.ToolMiddleware.Retry.Jitter < 0 {
 		return fmt.Errorf("tool_middleware.retry.jitter must be >= 0")
 	}
+	if c.MCP.HealthInterval < 0 {
+		return fmt.Errorf("mcp.health_interval must be >= 0")
+	}
+	if c.MCP.HealthTimeout < 0 {
+		return fmt.Errorf("mcp.health_timeout must be >= 0")
+	}
 	if c.PromptCache.SystemMessages < 0 {
 		return fmt.Errorf("prompt_cache.system_messages must be >= 0")
 	}
@@ -107,6 +153,12 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if grouping := strings.ToLower(strings.TrimSpace(c.UI.ModelPickerGrouping)); grouping != "" {
+		if grouping != "provider" && grouping != "capability" {
+			return fmt.Errorf("ui.model_picker_grouping must be provider or capability")
+		}
+	}
+
 	// Validate quirk probability
 	if c.Personality.QuirkProbability < 0 || c.Personality.QuirkProbability > 1 {
 		return fmt.Errorf("quirk probability must be between 0 and 1, got %f", c.Personality.QuirkProbability)
@@ -153,6 +205,39 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("ipc.basic_auth_password is required when basic auth is enabled")
 		}
 	}
+	if c.IPC.RateLimit.Requests < 0 || c.IPC.RateLimit.Burst < 0 {
+		return fmt.Errorf("ipc.rate_limit.requests and ipc.rate_limit.burst must be >= 0")
+	}
+	if c.IPC.RateLimit.Requests > 0 && c.IPC.RateLimit.Interval <= 0 {
+		return fmt.Errorf("ipc.rate_limit.interval must be > 0 when ipc.rate_limit.requests is set")
+	}
+	switch strings.ToLower(strings.TrimSpace(c.IPC.LogFormat)) {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("ipc.log_format must be text or json, got %q", c.IPC.LogFormat)
+	}
+	seenCommands := make(map[string]bool, len(c.IPC.Commands))
+	for i, cmd := range c.IPC.Commands {
+		commandType := strings.TrimSpace(cmd.Type)
+		if commandType == "" || !strings.Contains(commandType, ":") {
+			return fmt.Errorf("ipc.commands[%d].type must be namespace:name, got %q", i, cmd.Type)
+		}
+		if seenCommands[commandType] {
+			return fmt.Errorf("ipc.commands[%d].type %q is defined more than once", i, commandType)
+		}
+		seenCommands[commandType] = true
+		if strings.TrimSpace(cmd.Command) == "" {
+			return fmt.Errorf("ipc.commands[%d].command is required", i)
+		}
+		if cmd.Timeout < 0 {
+			return fmt.Errorf("ipc.commands[%d].timeout must be >= 0", i)
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(c.GitEvents.Provider)) {
+	case "", "github", "gitlab":
+	default:
+		return fmt.Errorf("git_events.provider must be github or gitlab, got %q", c.GitEvents.Provider)
+	}
 	if c.IPC.Enabled && strings.TrimSpace(c.IPC.Bind) != "" && !isLoopbackBindAddress(c.IPC.Bind) {
 		if !c.IPC.RequireToken && !c.IPC.BasicAuthEnabled {
 			return fmt.Errorf("ipc.bind %q is not loopback: enable ipc.require_token or ipc.basic_auth_enabled", c.IPC.Bind)
@@ -178,13 +263,35 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("retrieval_max_tokens must be >= 0, got %d", c.Memory.RetrievalMaxTokens)
 	}
 
+	validExportFormats := map[string]bool{
+		"markdown": true,
+		"md":       true,
+		"json":     true,
+		"html":     true,
+	}
+	if format := strings.ToLower(strings.TrimSpace(c.Export.DefaultFormat)); format != "" && !validExportFormats[format] {
+		return fmt.Errorf("invalid export.default_format: %s (valid: markdown or md, json, html)", c.Export.DefaultFormat)
+	}
+
+	if c.Commit.MaxSubjectLength < 0 {
+		return fmt.Errorf("commit.max_subject_length must be >= 0, got %d", c.Commit.MaxSubjectLength)
+	}
+	if template := strings.TrimSpace(c.Commit.Template); template != "" && !strings.Contains(template, "{subject}") {
+		return fmt.Errorf("commit.template must contain {subject}, got %q", c.Commit.Template)
+	}
+	for _, commitType := range c.Commit.Types {
+		if strings.TrimSpace(commitType) == "" {
+			return fmt.Errorf("commit.types must not contain empty entries")
+		}
+	}
+
 	return nil
 }
 
 // ValidationWarnings returns non-fatal warnings about the configuration.
 // These don't prevent operation but indicate potential security or usability issues.
 func (c *Config) ValidationWarnings() []string {
-	var warnings []string
+	warnings := append([]string(nil), c.loadWarnings...)
 
 	// Warn about API keys stored in config (prefer env vars)
 	if c.Providers.OpenRouter.APIKey != "" && os.Getenv("OPENROUTER_API_KEY") == "" {
@@ -246,5 +353,10 @@ func (c *Config) ValidationWarnings() []string {
 		warnings = append(warnings, "SECURITY: Network request/response logging is enabled. This may capture prompts and code in network.jsonl under BUCKLEY_LOG_DIR (default: .buckley/logs/network.jsonl); disable it when not actively debugging.")
 	}
 
+	// Warn when the always-included footer is large enough to crowd the prompt budget
+	if n := len(strings.TrimSpace(c.SystemPrompt.Footer)); n > MaxSystemPromptFooterChars {
+		warnings = append(warnings, fmt.Sprintf("system_prompt.footer is %d characters; keep it under %d since it is included in every prompt.", n, MaxSystemPromptFooterChars))
+	}
+
 	return warnings
 }
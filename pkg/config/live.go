@@ -0,0 +1,32 @@
+package config
+
+import "sync/atomic"
+
+// Live holds the current configuration of a long-running process such as
+// buckley serve. A reload publishes a new snapshot with Store instead of
+// editing the one readers hold, so readers never see a half-applied change.
+// Readers call Load when a session, runner or plan starts and keep that
+// snapshot for its duration. A published Config must not be modified.
+type Live struct {
+	current atomic.Pointer[Config]
+}
+
+// NewLive returns a Live whose first snapshot is cfg.
+func NewLive(cfg *Config) *Live {
+	l := &Live{}
+	l.current.Store(cfg)
+	return l
+}
+
+// Load returns the current snapshot. A nil Live returns nil.
+func (l *Live) Load() *Config {
+	if l == nil {
+		return nil
+	}
+	return l.current.Load()
+}
+
+// Store publishes cfg as the current snapshot.
+func (l *Live) Store(cfg *Config) {
+	l.current.Store(cfg)
+}
@@ -1,14 +1,20 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// loadAndMerge loads a YAML file and merges it into the config.
-func loadAndMerge(cfg *Config, path string, projectScope bool) error {
+// loadAndMerge loads a YAML file and merges it into the config. Keys that do
+// not map to a Config field are recorded as load warnings, or returned as an
+// error when strict is set.
+func loadAndMerge(cfg *Config, path string, projectScope, strict bool) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return err
@@ -19,6 +25,15 @@ func loadAndMerge(cfg *Config, path string, projectScope bool) error {
 		return fmt.Errorf("parsing YAML from %s: %w", path, err)
 	}
 
+	if unknown, _ := decodeConfigStrict(data); len(unknown) > 0 {
+		if strict {
+			return fmt.Errorf("unknown keys in %s (strict mode):\n  %s", path, strings.Join(unknown, "\n  "))
+		}
+		for _, msg := range unknown {
+			cfg.loadWarnings = append(cfg.loadWarnings, fmt.Sprintf("Unknown key ignored in %s: %s", path, msg))
+		}
+	}
+
 	var raw map[string]any
 	if err := yaml.Unmarshal(data, &raw); err != nil {
 		return fmt.Errorf("parsing YAML from %s: %w", path, err)
@@ -28,6 +43,35 @@ func loadAndMerge(cfg *Config, path string, projectScope bool) error {
 	return nil
 }
 
+// decodeConfigStrict decodes data with known-field checking. Keys that do
+// not map to a Config field, which the regular loader silently ignores, are
+// returned separately from real decode errors such as type mismatches.
+func decodeConfigStrict(data []byte) ([]string, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var cfg Config
+	err := dec.Decode(&cfg)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return nil, err
+	}
+	var unknown, problems []string
+	for _, msg := range typeErr.Errors {
+		if strings.Contains(msg, " not found in type ") {
+			unknown = append(unknown, msg)
+		} else {
+			problems = append(problems, msg)
+		}
+	}
+	if len(problems) > 0 {
+		return unknown, &yaml.TypeError{Errors: problems}
+	}
+	return unknown, nil
+}
+
 // mergeConfigs merges override into base.
 func mergeConfigs(base, override *Config, raw map[string]any, projectScope bool) {
 	if override == nil {
@@ -45,6 +89,8 @@ func mergeConfigs(base, override *Config, raw map[string]any, projectScope bool)
 	mergeApprovalConfig(base, override, raw, projectScope)
 	mergeSandboxConfig(base, override, raw, projectScope)
 	mergeToolMiddlewareConfig(base, override, raw)
+	mergeToolsConfig(base, override, raw, projectScope)
+	mergeMCPConfig(base, override, raw, projectScope)
 	mergeBatchConfig(base, override, raw)
 	mergeGitCloneConfig(base, override, raw)
 	mergeGitEventsConfig(base, override, raw)
@@ -53,14 +99,18 @@ func mergeConfigs(base, override *Config, raw map[string]any, projectScope bool)
 	mergeWorktreeConfig(base, override, raw)
 	mergeIPCConfig(base, override, raw)
 	mergeWorkflowPhaseConfig(base, override)
-	mergeCostConfig(base, override)
+	mergeCostConfig(base, override, raw)
 	mergeRetryPolicyConfig(base, override)
 	mergeArtifactsConfig(base, override, raw)
 	mergeWorkflowConfig(base, override, raw)
 	mergeCompactionConfig(base, override, raw)
 	mergeUIConfig(base, override, raw)
 	mergeCommentingConfig(base, override, raw)
+	mergeCommitConfig(base, override, raw)
 	mergeDiagnosticsConfig(base, override, raw)
+	mergeSystemPromptConfig(base, override, raw)
+	mergePersistenceConfig(base, override, raw)
+	mergeExportConfig(base, override, raw)
 }
 
 func mergeBuckbotConfig(base, override *Config, raw map[string]any) {
@@ -182,6 +182,7 @@ func TestMergeConfigsRespectsModelUtilityOverrides(t *testing.T) {
 			Reasoning:       "high",
 			VisionFallback:  []string{},
 			FallbackChains:  map[string][]string{},
+			Fallbacks:       []string{"openai/gpt-4o-mini"},
 			Utility: UtilityModelConfig{
 				Commit:     "openai/gpt-4.1-mini",
 				PR:         "openai/gpt-4.1-mini",
@@ -196,6 +197,7 @@ func TestMergeConfigsRespectsModelUtilityOverrides(t *testing.T) {
 			"reasoning":        "high",
 			"vision_fallback":  []any{},
 			"fallback_chains":  map[string]any{},
+			"fallbacks":        []any{"openai/gpt-4o-mini"},
 			"utility": map[string]any{
 				"commit":     "openai/gpt-4.1-mini",
 				"pr":         "openai/gpt-4.1-mini",
@@ -231,4 +233,7 @@ func TestMergeConfigsRespectsModelUtilityOverrides(t *testing.T) {
 	if len(base.Models.FallbackChains) != 0 {
 		t.Fatalf("expected models.fallback_chains to be overridable to an empty map")
 	}
+	if len(base.Models.Fallbacks) != 1 || base.Models.Fallbacks[0] != "openai/gpt-4o-mini" {
+		t.Fatalf("expected models.fallbacks to be overridden, got %v", base.Models.Fallbacks)
+	}
 }
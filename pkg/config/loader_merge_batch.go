@@ -148,6 +148,9 @@ func mergeGitEventsConfig(base, override *Config, raw map[string]any) {
 	if boolFieldSet(raw, "git_events", "secret") {
 		base.GitEvents.Secret = override.GitEvents.Secret
 	}
+	if boolFieldSet(raw, "git_events", "provider") {
+		base.GitEvents.Provider = override.GitEvents.Provider
+	}
 	if boolFieldSet(raw, "git_events", "auto_regression_plan") {
 		base.GitEvents.AutoRegressionPlan = override.GitEvents.AutoRegressionPlan
 	}
@@ -233,9 +236,24 @@ func mergeIPCConfig(base, override *Config, raw map[string]any) {
 	if override.IPC.PushSubject != "" {
 		base.IPC.PushSubject = override.IPC.PushSubject
 	}
+	if override.IPC.LogFormat != "" {
+		base.IPC.LogFormat = override.IPC.LogFormat
+	}
 	if len(override.IPC.AllowedOrigins) > 0 {
 		base.IPC.AllowedOrigins = append([]string{}, override.IPC.AllowedOrigins...)
 	}
+	if len(override.IPC.Commands) > 0 {
+		base.IPC.Commands = append([]IPCCommandConfig{}, override.IPC.Commands...)
+	}
+	if boolFieldSet(raw, "ipc", "rate_limit", "requests") {
+		base.IPC.RateLimit.Requests = override.IPC.RateLimit.Requests
+	}
+	if boolFieldSet(raw, "ipc", "rate_limit", "interval") {
+		base.IPC.RateLimit.Interval = override.IPC.RateLimit.Interval
+	}
+	if boolFieldSet(raw, "ipc", "rate_limit", "burst") {
+		base.IPC.RateLimit.Burst = override.IPC.RateLimit.Burst
+	}
 }
 
 func mergeWorkflowPhaseConfig(base, override *Config) {
@@ -1,6 +1,6 @@
 package config
 
-func mergeCostConfig(base, override *Config) {
+func mergeCostConfig(base, override *Config, raw map[string]any) {
 	if override.CostManagement.SessionBudget != 0 {
 		base.CostManagement.SessionBudget = override.CostManagement.SessionBudget
 	}
@@ -13,6 +13,9 @@ func mergeCostConfig(base, override *Config) {
 	if override.CostManagement.AutoStopAt != 0 {
 		base.CostManagement.AutoStopAt = override.CostManagement.AutoStopAt
 	}
+	if boolFieldSet(raw, "cost_management", "hard_stop") {
+		base.CostManagement.HardStop = override.CostManagement.HardStop
+	}
 }
 
 func mergeRetryPolicyConfig(base, override *Config) {
@@ -139,6 +142,12 @@ func mergeUIConfig(base, override *Config, raw map[string]any) {
 	if override.UI.MessageMetadata != "" {
 		base.UI.MessageMetadata = override.UI.MessageMetadata
 	}
+	if boolFieldSet(raw, "ui", "render_markdown") {
+		base.UI.RenderMarkdown = override.UI.RenderMarkdown
+	}
+	if override.UI.ModelPickerGrouping != "" {
+		base.UI.ModelPickerGrouping = override.UI.ModelPickerGrouping
+	}
 	if override.UI.SidebarWidth != 0 {
 		base.UI.SidebarWidth = override.UI.SidebarWidth
 	}
@@ -183,8 +192,47 @@ func mergeCommentingConfig(base, override *Config, raw map[string]any) {
 	}
 }
 
+func mergeCommitConfig(base, override *Config, raw map[string]any) {
+	if boolFieldSet(raw, "commit", "template") {
+		base.Commit.Template = override.Commit.Template
+	}
+	if boolFieldSet(raw, "commit", "types") {
+		base.Commit.Types = override.Commit.Types
+	}
+	if override.Commit.MaxSubjectLength != 0 {
+		base.Commit.MaxSubjectLength = override.Commit.MaxSubjectLength
+	}
+}
+
 func mergeDiagnosticsConfig(base, override *Config, raw map[string]any) {
 	if boolFieldSet(raw, "diagnostics", "network_logs_enabled") {
 		base.Diagnostics.NetworkLogsEnabled = override.Diagnostics.NetworkLogsEnabled
 	}
 }
+
+func mergeSystemPromptConfig(base, override *Config, raw map[string]any) {
+	if boolFieldSet(raw, "system_prompt", "footer") {
+		base.SystemPrompt.Footer = override.SystemPrompt.Footer
+	}
+}
+
+func mergePersistenceConfig(base, override *Config, raw map[string]any) {
+	if boolFieldSet(raw, "persistence", "ephemeral") {
+		base.Persistence.Ephemeral = override.Persistence.Ephemeral
+	}
+	if boolFieldSet(raw, "persistence", "disable_telemetry") {
+		base.Persistence.DisableTelemetry = override.Persistence.DisableTelemetry
+	}
+}
+
+func mergeExportConfig(base, override *Config, raw map[string]any) {
+	if override.Export.DefaultFormat != "" {
+		base.Export.DefaultFormat = override.Export.DefaultFormat
+	}
+	if boolFieldSet(raw, "export", "include_system") {
+		base.Export.IncludeSystem = override.Export.IncludeSystem
+	}
+	if boolFieldSet(raw, "export", "include_tools") {
+		base.Export.IncludeTools = override.Export.IncludeTools
+	}
+}
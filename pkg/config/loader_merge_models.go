@@ -61,17 +61,47 @@ func mergeModelConfig(base, override *Config, raw map[string]any) {
 		base.Models.Review = override.Models.Review
 	}
 	if boolFieldSet(raw, "models", "curated") {
-		base.Models.Curated = append([]string{}, override.Models.Curated...)
+		base.Models.Curated = override.Models.Curated.Clone()
+	}
+	if boolFieldSet(raw, "models", "curated_autosave") {
+		base.Models.CuratedAutoSave = override.Models.CuratedAutoSave
 	}
 	if boolFieldSet(raw, "models", "vision_fallback") {
 		base.Models.VisionFallback = append([]string{}, override.Models.VisionFallback...)
 	}
+	if boolFieldSet(raw, "models", "fallbacks") {
+		base.Models.Fallbacks = append([]string{}, override.Models.Fallbacks...)
+	}
 	if boolFieldSet(raw, "models", "default_provider") {
 		base.Models.DefaultProvider = override.Models.DefaultProvider
 	}
 	if boolFieldSet(raw, "models", "reasoning") {
 		base.Models.Reasoning = override.Models.Reasoning
 	}
+	if boolFieldSet(raw, "models", "tokenizer") {
+		base.Models.Tokenizer = override.Models.Tokenizer
+	}
+	if boolFieldSet(raw, "models", "stream_idle_timeout") {
+		base.Models.StreamIdleTimeout = override.Models.StreamIdleTimeout
+	}
+	if boolFieldSet(raw, "models", "max_output_tokens") {
+		base.Models.MaxOutputTokens = override.Models.MaxOutputTokens
+	}
+	if boolFieldSet(raw, "models", "retry", "max_attempts") {
+		base.Models.Retry.MaxAttempts = override.Models.Retry.MaxAttempts
+	}
+	if boolFieldSet(raw, "models", "retry", "initial_delay") {
+		base.Models.Retry.InitialDelay = override.Models.Retry.InitialDelay
+	}
+	if boolFieldSet(raw, "models", "retry", "max_delay") {
+		base.Models.Retry.MaxDelay = override.Models.Retry.MaxDelay
+	}
+	if boolFieldSet(raw, "models", "retry", "multiplier") {
+		base.Models.Retry.Multiplier = override.Models.Retry.Multiplier
+	}
+	if boolFieldSet(raw, "models", "retry", "jitter") {
+		base.Models.Retry.Jitter = override.Models.Retry.Jitter
+	}
 	if boolFieldSet(raw, "models", "utility", "commit") {
 		base.Models.Utility.Commit = override.Models.Utility.Commit
 	}
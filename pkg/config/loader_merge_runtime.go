@@ -63,6 +63,9 @@ func mergeExecutionAndRLMConfig(base, override *Config, raw map[string]any) {
 	if boolFieldSet(raw, "execution", "mode") {
 		base.Execution.Mode = override.Execution.Mode
 	}
+	if boolFieldSet(raw, "execution", "suppress_rlm_warning") {
+		base.Execution.SuppressRLMWarning = override.Execution.SuppressRLMWarning
+	}
 	if boolFieldSet(raw, "oneshot", "mode") {
 		base.Oneshot.Mode = override.Oneshot.Mode
 	}
@@ -174,6 +177,42 @@ func mergeSandboxConfig(base, override *Config, raw map[string]any, projectScope
 	}
 }
 
+// mergeToolsConfig lets project config add dangerous tools but not drop ones
+// the user config marked, so a checked-out repo cannot loosen the gate.
+func mergeToolsConfig(base, override *Config, raw map[string]any, projectScope bool) {
+	if !boolFieldSet(raw, "tools", "dangerous") {
+		return
+	}
+	if !projectScope {
+		base.Tools.Dangerous = append([]string{}, override.Tools.Dangerous...)
+		return
+	}
+	for _, name := range override.Tools.Dangerous {
+		if !base.IsDangerousTool(name) {
+			base.Tools.Dangerous = append(base.Tools.Dangerous, name)
+		}
+	}
+}
+
+// mergeMCPConfig keeps server definitions to user config: each server is a
+// command Buckley spawns, so a checked-out repo cannot add one.
+func mergeMCPConfig(base, override *Config, raw map[string]any, projectScope bool) {
+	if !projectScope {
+		if boolFieldSet(raw, "mcp", "enabled") {
+			base.MCP.Enabled = override.MCP.Enabled
+		}
+		if boolFieldSet(raw, "mcp", "servers") {
+			base.MCP.Servers = append([]MCPServerConfig{}, override.MCP.Servers...)
+		}
+	}
+	if boolFieldSet(raw, "mcp", "health_interval") {
+		base.MCP.HealthInterval = override.MCP.HealthInterval
+	}
+	if boolFieldSet(raw, "mcp", "health_timeout") {
+		base.MCP.HealthTimeout = override.MCP.HealthTimeout
+	}
+}
+
 func mergeToolMiddlewareConfig(base, override *Config, raw map[string]any) {
 	if boolFieldSet(raw, "tool_middleware", "default_timeout") {
 		base.ToolMiddleware.DefaultTimeout = override.ToolMiddleware.DefaultTimeout
@@ -191,6 +230,15 @@ func mergeToolMiddlewareConfig(base, override *Config, raw map[string]any) {
 	if boolFieldSet(raw, "tool_middleware", "max_result_bytes") {
 		base.ToolMiddleware.MaxResultBytes = override.ToolMiddleware.MaxResultBytes
 	}
+	if boolFieldSet(raw, "tool_middleware", "max_parallel") {
+		base.ToolMiddleware.MaxParallel = override.ToolMiddleware.MaxParallel
+	}
+	if boolFieldSet(raw, "tool_middleware", "allow_list") {
+		base.ToolMiddleware.AllowList = append([]string(nil), override.ToolMiddleware.AllowList...)
+	}
+	if boolFieldSet(raw, "tool_middleware", "deny_list") {
+		base.ToolMiddleware.DenyList = append([]string(nil), override.ToolMiddleware.DenyList...)
+	}
 	if boolFieldSet(raw, "tool_middleware", "retry", "max_attempts") {
 		base.ToolMiddleware.Retry.MaxAttempts = override.ToolMiddleware.Retry.MaxAttempts
 	}
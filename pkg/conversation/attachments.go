@@ -0,0 +1,126 @@
+package conversation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// Attachment identifies a file whose contents a message carries, so the file
+// can be re-shown or re-sent after the message is compacted away or the
+// session is resumed.
+type Attachment struct {
+	ID          int64 // message_attachments row; zero until saved
+	Path        string
+	ContentHash string
+	SizeBytes   int64
+}
+
+// AttachmentMessageContent formats a file's contents as sent to the model.
+func AttachmentMessageContent(path string, content []byte) string {
+	return fmt.Sprintf("Attached file: %s\n```\n%s\n```", path, string(content))
+}
+
+// AddAttachmentMessage adds a user message carrying a file's contents and
+// returns it. Pass an existing attachment's ID in id to re-attach it, or
+// zero for a new attachment; SaveMessage records it either way.
+func (c *Conversation) AddAttachmentMessage(id int64, path string, content []byte) Message {
+	text := AttachmentMessageContent(path, content)
+	msg := Message{
+		Role:      "user",
+		Content:   text,
+		Timestamp: time.Now(),
+		Tokens:    estimateTokens(text),
+		Attachment: &Attachment{
+			ID:          id,
+			Path:        path,
+			ContentHash: storage.AttachmentContentHash(content),
+			SizeBytes:   int64(len(content)),
+		},
+	}
+	c.Messages = append(c.Messages, msg)
+	c.TokenCount += msg.Tokens
+	return msg
+}
+
+// HasAttachment reports whether a loaded message carries the attachment.
+func (c *Conversation) HasAttachment(id int64) bool {
+	for _, msg := range c.Messages {
+		if msg.Attachment != nil && msg.Attachment.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// saveAttachment records att as carried by the stored message messageID,
+// inserting it first when it has not been saved before.
+func (c *Conversation) saveAttachment(store *storage.Store, att *Attachment, messageID int64) error {
+	if att == nil {
+		return nil
+	}
+	if att.ID != 0 {
+		return store.LinkMessageAttachment(att.ID, messageID)
+	}
+	row := &storage.MessageAttachment{
+		SessionID:   c.SessionID,
+		MessageID:   messageID,
+		Path:        att.Path,
+		ContentHash: att.ContentHash,
+		SizeBytes:   att.SizeBytes,
+	}
+	if err := store.SaveMessageAttachment(row); err != nil {
+		return err
+	}
+	att.ID = row.ID
+	return nil
+}
+
+// storedAttachments maps stored message IDs to the attachments they carry.
+func storedAttachments(store *storage.Store, sessionID string) (map[int64]*Attachment, error) {
+	rows, err := store.ListMessageAttachments(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	attached := make(map[int64]*Attachment, len(rows))
+	for _, row := range rows {
+		if row.MessageID == 0 {
+			continue
+		}
+		attached[row.MessageID] = &Attachment{
+			ID:          row.ID,
+			Path:        row.Path,
+			ContentHash: row.ContentHash,
+			SizeBytes:   row.SizeBytes,
+		}
+	}
+	return attached, nil
+}
+
+// splitAttachments separates attachment messages from the rest. Compaction
+// sets attachments aside instead of summarizing them, since the file can be
+// re-attached in full when it is needed again.
+func splitAttachments(messages []Message) (rest []Message, attachments []Message) {
+	for _, msg := range messages {
+		if msg.Attachment != nil {
+			attachments = append(attachments, msg)
+			continue
+		}
+		rest = append(rest, msg)
+	}
+	return rest, attachments
+}
+
+// setAsideNote tells the model which attached files compaction removed.
+func setAsideNote(attachments []Message) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+	paths := make([]string, 0, len(attachments))
+	for _, msg := range attachments {
+		paths = append(paths, msg.Attachment.Path)
+	}
+	return "\n\nAttached files set aside (ask the user to re-attach if needed): " + strings.Join(uniqueStrings(paths), ", ")
+}
@@ -0,0 +1,111 @@
+package conversation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttachmentPersistsAcrossResume(t *testing.T) {
+	sessionID := "session-attach"
+	store := newWindowTestStore(t, sessionID)
+
+	conv := New(sessionID)
+	conv.AddUserMessage("look at this")
+	msg := conv.AddAttachmentMessage(0, "main.go", []byte("package main\n"))
+	if err := conv.SaveMessage(store, conv.Messages[0]); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	if err := conv.SaveMessage(store, msg); err != nil {
+		t.Fatalf("SaveMessage attachment: %v", err)
+	}
+	if msg.Attachment.ID == 0 || conv.Messages[1].Attachment.ID != msg.Attachment.ID {
+		t.Fatalf("attachment ID not recorded on the conversation: %+v", conv.Messages[1].Attachment)
+	}
+
+	rows, err := store.ListMessageAttachments(sessionID)
+	if err != nil {
+		t.Fatalf("ListMessageAttachments: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Path != "main.go" || rows[0].MessageID == 0 || rows[0].SizeBytes != 13 {
+		t.Fatalf("stored attachments = %+v", rows)
+	}
+	if rows[0].ContentHash != msg.Attachment.ContentHash || rows[0].ContentHash == "" {
+		t.Fatalf("content hash = %q, want %q", rows[0].ContentHash, msg.Attachment.ContentHash)
+	}
+
+	loaded := New(sessionID)
+	if err := loaded.LoadRecentFromStorage(store, 10); err != nil {
+		t.Fatalf("LoadRecentFromStorage: %v", err)
+	}
+	if len(loaded.Messages) != 2 || loaded.Messages[0].Attachment != nil {
+		t.Fatalf("loaded messages = %+v", loaded.Messages)
+	}
+	att := loaded.Messages[1].Attachment
+	if att == nil || att.ID != rows[0].ID || att.Path != "main.go" {
+		t.Fatalf("loaded attachment = %+v, want row %d", att, rows[0].ID)
+	}
+	if !loaded.HasAttachment(att.ID) {
+		t.Fatal("HasAttachment = false for a loaded attachment")
+	}
+}
+
+func TestCompactionSetsAttachmentsAsideForReattach(t *testing.T) {
+	sessionID := "session-attach-compact"
+	store := newWindowTestStore(t, sessionID)
+
+	conv := New(sessionID)
+	conv.AddAttachmentMessage(0, "a.go", []byte("package a\n"))
+	conv.AddAttachmentMessage(0, "b.go", []byte("package b\n"))
+	conv.AddUserMessage("compare them")
+	conv.AddAssistantMessage("they differ")
+	conv.AddUserMessage("thanks")
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages: %v", err)
+	}
+
+	// Both messages chosen for summarizing are attachments, so compaction
+	// sets them aside without calling a summarizer model.
+	if err := NewCompactionManager(nil, nil).Compact(conv); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	for _, msg := range conv.Messages {
+		if msg.Attachment != nil {
+			t.Fatalf("attachment %s still in context after compaction", msg.Attachment.Path)
+		}
+	}
+	summary := GetContentAsString(conv.Messages[0].Content)
+	if !strings.Contains(summary, "a.go, b.go") || strings.Contains(summary, "package a") {
+		t.Fatalf("summary = %q, want set-aside paths without file contents", summary)
+	}
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages after compaction: %v", err)
+	}
+
+	rows, err := store.ListMessageAttachments(sessionID)
+	if err != nil {
+		t.Fatalf("ListMessageAttachments: %v", err)
+	}
+	if len(rows) != 2 || rows[0].MessageID != 0 || rows[1].MessageID != 0 {
+		t.Fatalf("attachments after compaction = %+v, want both set aside", rows)
+	}
+
+	msg := conv.AddAttachmentMessage(rows[0].ID, rows[0].Path, []byte("package a\n"))
+	if err := conv.SaveMessage(store, msg); err != nil {
+		t.Fatalf("SaveMessage re-attach: %v", err)
+	}
+	rows, err = store.ListMessageAttachments(sessionID)
+	if err != nil {
+		t.Fatalf("ListMessageAttachments: %v", err)
+	}
+	if len(rows) != 2 || rows[0].MessageID == 0 || rows[1].MessageID != 0 {
+		t.Fatalf("attachments after re-attach = %+v, want a.go linked again", rows)
+	}
+
+	loaded := New(sessionID)
+	if err := loaded.LoadFromStorage(store); err != nil {
+		t.Fatalf("LoadFromStorage: %v", err)
+	}
+	if !loaded.HasAttachment(rows[0].ID) || loaded.HasAttachment(rows[1].ID) {
+		t.Fatal("resumed conversation should carry only the re-attached file")
+	}
+}
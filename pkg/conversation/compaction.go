@@ -52,9 +52,10 @@ func (cm *CompactionManager) SetEvaluator(eval types.RuleEvaluator) {
 func (cm *CompactionManager) ShouldCompact(conv *Conversation, maxTokens int) bool {
 	// Arbiter-based compaction evaluation
 	if cm.engine != nil && maxTokens > 0 {
-		ratio := float64(conv.TokenCount) / float64(maxTokens)
+		tokens := conv.BudgetTokenCount()
+		ratio := float64(tokens) / float64(maxTokens)
 		matched, err := rules.Eval(cm.engine, "compaction", rules.ContextFacts{
-			TokenCount:   conv.TokenCount,
+			TokenCount:   tokens,
 			MaxTokens:    maxTokens,
 			UsageRatio:   ratio,
 			MessageCount: len(conv.Messages),
@@ -71,7 +72,7 @@ func (cm *CompactionManager) ShouldCompact(conv *Conversation, maxTokens int) bo
 		thresholdRatio = cm.cfg.Memory.AutoCompactThreshold
 	}
 	threshold := float64(maxTokens) * thresholdRatio
-	return float64(conv.TokenCount) >= threshold
+	return float64(conv.BudgetTokenCount()) >= threshold
 }
 
 // CompactionStrategy returns the compaction mode and how many recent messages
@@ -87,7 +88,7 @@ func (cm *CompactionManager) CompactionStrategy(conv *Conversation, maxTokens in
 
 	signals := ExtractSignals(conv.Messages)
 	if maxTokens > 0 {
-		signals.TokenUtilization = float64(conv.TokenCount) / float64(maxTokens)
+		signals.TokenUtilization = float64(conv.BudgetTokenCount()) / float64(maxTokens)
 	}
 
 	facts := rules.CompactionFacts{
@@ -125,6 +126,12 @@ func uniqueStrings(ss []string) []string {
 
 // Compact compacts a conversation by summarizing old messages
 func (cm *CompactionManager) Compact(conv *Conversation) error {
+	return cm.CompactContext(context.Background(), conv)
+}
+
+// CompactContext is Compact with cancellation. A cancelled ctx stops the
+// summary retries and leaves conv unchanged.
+func (cm *CompactionManager) CompactContext(ctx context.Context, conv *Conversation) error {
 	if len(conv.Messages) < 4 {
 		return fmt.Errorf("not enough messages to compact (need at least 4)")
 	}
@@ -133,24 +140,32 @@ func (cm *CompactionManager) Compact(conv *Conversation) error {
 	if err != nil {
 		return err
 	}
+	toSummarize, setAside := splitAttachments(toSummarize)
 
 	// 2. Generate summary with retry logic
 	var summary string
 	var lastErr error
 	maxRetries := 3
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
+	for attempt := 0; attempt < maxRetries && len(toSummarize) > 0; attempt++ {
 		var err error
-		summary, err = cm.generateSummary(toSummarize)
+		summary, err = cm.generateSummary(ctx, toSummarize)
 		if err == nil {
 			break
 		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		lastErr = err
 
 		// Exponential backoff: 1s, 2s, 4s
 		if attempt < maxRetries-1 {
 			backoffDuration := time.Duration(1<<uint(attempt)) * time.Second
-			time.Sleep(backoffDuration)
+			select {
+			case <-time.After(backoffDuration):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 		}
 	}
 
@@ -164,7 +179,7 @@ func (cm *CompactionManager) Compact(conv *Conversation) error {
 	// 3. Replace old messages with summary message
 	summaryMsg := Message{
 		Role:      "system",
-		Content:   fmt.Sprintf("[Summary of %d previous messages]\n\n%s", len(toSummarize), summary),
+		Content:   fmt.Sprintf("[Summary of %d previous messages]\n\n%s", len(toSummarize), summary) + setAsideNote(setAside),
 		Timestamp: toKeep[0].Timestamp, // Use timestamp of first kept message
 		Tokens:    estimateTokens(summary),
 		IsSummary: true,
@@ -174,13 +189,14 @@ func (cm *CompactionManager) Compact(conv *Conversation) error {
 
 	// 4. Recalculate token count
 	conv.UpdateTokenCount()
+	conv.resetRecordedUsage()
 	conv.CompactionCount++
 
 	return nil
 }
 
 // selectCompactionSegments splits messages into segments to summarize and to retain,
-// always retaining system messages/persona/steering content.
+// always retaining system messages/persona/steering content and pinned messages.
 func selectCompactionSegments(messages []Message) ([]Message, []Message, error) {
 	if len(messages) < 4 {
 		return nil, nil, fmt.Errorf("not enough messages to compact (need at least 4)")
@@ -189,7 +205,7 @@ func selectCompactionSegments(messages []Message) ([]Message, []Message, error)
 	var protected []Message
 	var candidate []Message
 	for _, msg := range messages {
-		if msg.Role == "system" {
+		if msg.Role == "system" || msg.Pinned {
 			protected = append(protected, msg)
 			continue
 		}
@@ -211,7 +227,7 @@ func selectCompactionSegments(messages []Message) ([]Message, []Message, error)
 }
 
 // generateSummary generates a summary of messages using the LLM
-func (cm *CompactionManager) generateSummary(messages []Message) (string, error) {
+func (cm *CompactionManager) generateSummary(ctx context.Context, messages []Message) (string, error) {
 	// Format messages for summarization
 	content := formatMessagesForSummary(messages)
 
@@ -232,7 +248,7 @@ func (cm *CompactionManager) generateSummary(messages []Message) (string, error)
 	}
 
 	// Get summary from model
-	ctx, cancel := context.WithTimeout(context.Background(), cm.summaryTimeout)
+	ctx, cancel := context.WithTimeout(ctx, cm.summaryTimeout)
 	defer cancel()
 	resp, err := cm.modelManager.ChatCompletion(ctx, req)
 	if err != nil {
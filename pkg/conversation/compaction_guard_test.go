@@ -32,3 +32,27 @@ func TestSelectCompactionSegmentsProtectsSystemMessages(t *testing.T) {
 		t.Fatalf("expected steering/system message to be retained in toKeep")
 	}
 }
+
+func TestSelectCompactionSegmentsKeepsPinnedMessages(t *testing.T) {
+	msgs := []Message{
+		{Role: "user", Content: "spec", Pinned: true},
+		{Role: "assistant", Content: "noted"},
+		{Role: "user", Content: "hello"},
+		{Role: "assistant", Content: "hi"},
+		{Role: "user", Content: "more"},
+		{Role: "assistant", Content: "reply"},
+	}
+
+	toSummarize, toKeep, err := selectCompactionSegments(msgs)
+	if err != nil {
+		t.Fatalf("selectCompactionSegments error: %v", err)
+	}
+	for _, msg := range toSummarize {
+		if msg.Pinned {
+			t.Fatalf("pinned message should not be summarized")
+		}
+	}
+	if len(toKeep) == 0 || !toKeep[0].Pinned || toKeep[0].Content != "spec" {
+		t.Fatalf("expected pinned message to be retained first, got %+v", toKeep)
+	}
+}
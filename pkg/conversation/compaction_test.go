@@ -1,6 +1,8 @@
 package conversation
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -427,3 +429,40 @@ func TestShouldCompact_ConfigurableThreshold(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldCompact_UsesRecordedPromptUsage(t *testing.T) {
+	cm := NewCompactionManager(nil, &config.Config{})
+	conv := New("test")
+	conv.AddUserMessage("Please refactor the loader.")
+
+	if cm.ShouldCompact(conv, 10000) {
+		t.Fatal("ShouldCompact() = true for a tiny estimated conversation")
+	}
+
+	conv.RecordPromptUsage(9500)
+	if !cm.ShouldCompact(conv, 10000) {
+		t.Errorf("ShouldCompact() = false, want true when recorded usage (%d) exceeds the threshold", conv.RecordedPromptTokens)
+	}
+	if !conv.NeedsCompaction(10000, 0.9) {
+		t.Error("NeedsCompaction() = false, want recorded usage to drive the check")
+	}
+}
+
+func TestCompactionManager_CompactContextCancelled(t *testing.T) {
+	conv := New("session-cancel")
+	conv.AddUserMessage("first")
+	conv.AddAssistantMessage("reply")
+	conv.AddUserMessage("second")
+	conv.AddAssistantMessage("reply again")
+	conv.AddUserMessage("third")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := NewCompactionManager(nil, nil).CompactContext(ctx, conv)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("CompactContext error = %v, want context.Canceled", err)
+	}
+	if len(conv.Messages) != 5 || conv.CompactionCount != 0 {
+		t.Fatalf("cancelled compaction changed the conversation: %d messages, %d compactions", len(conv.Messages), conv.CompactionCount)
+	}
+}
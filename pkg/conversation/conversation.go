@@ -23,6 +23,9 @@ type Message struct {
 	IsTruncated      bool                    // Indicates this message was interrupted/incomplete
 	Reasoning        string                  // Reasoning/thinking content for reasoning models
 	ReasoningDetails []model.ReasoningDetail // Structured reasoning blocks for reasoning continuity
+	Attachment       *Attachment             // File whose contents this message carries
+	Pinned           bool                    // Kept exact when history is compacted to fit the context window
+	StorageID        int64                   // Row ID in the messages table; zero until saved
 }
 
 // Conversation manages a conversation with the LLM
@@ -31,6 +34,15 @@ type Conversation struct {
 	Messages        []Message
 	TokenCount      int
 	CompactionCount int
+
+	// RecordedPromptTokens is the provider-reported prompt size of the most
+	// recent request, covering the first RecordedMessageCount messages.
+	RecordedPromptTokens int
+	RecordedMessageCount int
+
+	// olderCursor marks the oldest message loaded by LoadRecentFromStorage
+	// while older stored messages remain unloaded.
+	olderCursor *storage.Cursor
 }
 
 const (
@@ -210,6 +222,7 @@ func (c *Conversation) ToModelMessages() []model.Message {
 			Name:             msg.Name,
 			Reasoning:        msg.Reasoning, // Pass reasoning back to model for continuity
 			ReasoningDetails: cloneReasoningDetails(msg.ReasoningDetails),
+			Pinned:           msg.Pinned,
 		}
 	}
 	return msgs
@@ -223,11 +236,14 @@ func (c *Conversation) GetLastN(n int) []Message {
 	return c.Messages[len(c.Messages)-n:]
 }
 
-// Clear clears all messages
+// Clear clears all messages, including stored ones outside the loaded window
+// the next time the conversation is saved
 func (c *Conversation) Clear() {
 	c.Messages = []Message{}
 	c.TokenCount = 0
 	c.CompactionCount = 0
+	c.olderCursor = nil
+	c.resetRecordedUsage()
 }
 
 // estimateTokens provides a rough token estimate
@@ -252,7 +268,7 @@ func estimateToolCallTokens(toolCalls []model.ToolCall) int {
 // NeedsCompaction checks if compaction is needed
 // Placeholder for Phase 3
 func (c *Conversation) NeedsCompaction(maxTokens int, threshold float64) bool {
-	return float64(c.TokenCount) >= float64(maxTokens)*threshold
+	return float64(c.BudgetTokenCount()) >= float64(maxTokens)*threshold
 }
 
 // UpdateTokenCount recalculates token count
@@ -274,14 +290,92 @@ func (c *Conversation) LoadFromStorage(store *storage.Store) error {
 	if err != nil {
 		return err
 	}
+	attached, err := storedAttachments(store, c.SessionID)
+	if err != nil {
+		return err
+	}
 
-	// Convert storage messages to conversation messages
-	c.Messages = make([]Message, len(messages))
-	totalTokens := 0
-	compactions := 0
+	c.Messages = nil
+	c.TokenCount = 0
+	c.CompactionCount = 0
+	c.olderCursor = nil
+	c.prependStored(messages, attached)
+	return nil
+}
 
+// LoadRecentFromStorage loads only the newest limit messages of the session,
+// so resuming a very long session does not read its whole history. Older
+// messages can be pulled in later with LoadOlderFromStorage. The window is
+// widened as needed so it never starts with a tool result whose call is
+// unloaded. A limit of zero or less loads everything.
+func (c *Conversation) LoadRecentFromStorage(store *storage.Store, limit int) error {
+	if limit <= 0 {
+		return c.LoadFromStorage(store)
+	}
+	messages, cursor, err := store.GetMessagesBefore(c.SessionID, nil, limit)
+	if err != nil {
+		return err
+	}
+	attached, err := storedAttachments(store, c.SessionID)
+	if err != nil {
+		return err
+	}
+	c.Messages = nil
+	c.TokenCount = 0
+	c.CompactionCount = 0
+	c.olderCursor = cursor
+	c.prependStored(messages, attached)
+	return c.extendToToolCall(store, attached)
+}
+
+// HasOlderMessages reports whether stored messages older than the loaded
+// window remain to be fetched with LoadOlderFromStorage.
+func (c *Conversation) HasOlderMessages() bool {
+	return c.olderCursor != nil
+}
+
+// LoadOlderFromStorage prepends up to limit stored messages older than the
+// loaded window and returns how many were added.
+func (c *Conversation) LoadOlderFromStorage(store *storage.Store, limit int) (int, error) {
+	if c.olderCursor == nil {
+		return 0, nil
+	}
+	before := len(c.Messages)
+	messages, cursor, err := store.GetMessagesBefore(c.SessionID, c.olderCursor, limit)
+	if err != nil {
+		return 0, err
+	}
+	attached, err := storedAttachments(store, c.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	c.olderCursor = cursor
+	c.prependStored(messages, attached)
+	err = c.extendToToolCall(store, attached)
+	return len(c.Messages) - before, err
+}
+
+// extendToToolCall loads older messages while the window opens on a tool
+// result, since providers reject a tool result without its preceding call.
+func (c *Conversation) extendToToolCall(store *storage.Store, attached map[int64]*Attachment) error {
+	for c.olderCursor != nil && len(c.Messages) > 0 && c.Messages[0].Role == "tool" {
+		messages, cursor, err := store.GetMessagesBefore(c.SessionID, c.olderCursor, 1)
+		if err != nil {
+			return err
+		}
+		c.olderCursor = cursor
+		c.prependStored(messages, attached)
+	}
+	return nil
+}
+
+// prependStored converts storage messages and places them before the loaded
+// ones, updating token and compaction totals. attached maps stored message
+// IDs to the attachments they carry.
+func (c *Conversation) prependStored(messages []storage.Message, attached map[int64]*Attachment) {
+	converted := make([]Message, len(messages), len(messages)+len(c.Messages))
 	for i, msg := range messages {
-		c.Messages[i] = Message{
+		converted[i] = Message{
 			Role:             msg.Role,
 			Content:          materializeMessageContent(msg),
 			Timestamp:        msg.Timestamp,
@@ -293,20 +387,24 @@ func (c *Conversation) LoadFromStorage(store *storage.Store) error {
 			IsTruncated:      msg.IsTruncated,
 			Reasoning:        msg.Reasoning,
 			ReasoningDetails: decodeReasoningDetails(msg.ReasoningDetails),
+			Attachment:       attached[msg.ID],
+			Pinned:           msg.IsPinned,
+			StorageID:        msg.ID,
 		}
-		totalTokens += msg.Tokens
+		c.TokenCount += msg.Tokens
 		if msg.IsSummary {
-			compactions++
+			c.CompactionCount++
 		}
 	}
-
-	c.TokenCount = totalTokens
-	c.CompactionCount = compactions
-	return nil
+	c.Messages = append(converted, c.Messages...)
+	c.resetRecordedUsage()
 }
 
-// SaveMessage saves a message to storage
+// SaveMessage saves a message to storage. It is a no-op for ephemeral stores.
 func (c *Conversation) SaveMessage(store *storage.Store, msg Message) error {
+	if store.Ephemeral() {
+		return nil
+	}
 	contentText, contentJSON, contentType, err := serializeMessageContent(msg.Content)
 	if err != nil {
 		return fmt.Errorf("serialize message content: %w", err)
@@ -327,13 +425,51 @@ func (c *Conversation) SaveMessage(store *storage.Store, msg Message) error {
 		Tokens:           msg.Tokens,
 		IsSummary:        msg.IsSummary,
 		IsTruncated:      msg.IsTruncated,
+		IsPinned:         msg.Pinned,
+	}
+
+	if err := store.SaveMessage(storageMsg); err != nil {
+		return err
+	}
+	c.recordStorageID(msg, storageMsg.ID)
+	return c.saveAttachment(store, msg.Attachment, storageMsg.ID)
+}
+
+// recordStorageID stores id on the unsaved loaded message that msg was copied
+// from. Callers save the newest turns, so the search starts at the end.
+func (c *Conversation) recordStorageID(msg Message, id int64) {
+	for i := len(c.Messages) - 1; i >= 0; i-- {
+		loaded := &c.Messages[i]
+		if loaded.StorageID == 0 && loaded.Role == msg.Role && loaded.ToolCallID == msg.ToolCallID && loaded.Timestamp.Equal(msg.Timestamp) {
+			loaded.StorageID = id
+			return
+		}
 	}
+}
 
-	return store.SaveMessage(storageMsg)
+// SetPinned pins or unpins the message at index and records the change in
+// store. A message that has not been saved yet keeps the flag in memory and
+// stores it when it is saved.
+func (c *Conversation) SetPinned(store *storage.Store, index int, pinned bool) error {
+	if index < 0 || index >= len(c.Messages) {
+		return fmt.Errorf("no message at index %d", index)
+	}
+	if id := c.Messages[index].StorageID; id != 0 && store != nil && !store.Ephemeral() {
+		if err := store.SetMessagePinned(c.SessionID, id, pinned); err != nil {
+			return err
+		}
+	}
+	c.Messages[index].Pinned = pinned
+	return nil
 }
 
-// SaveAllMessages saves all messages to storage
+// SaveAllMessages rewrites the stored session to match the loaded messages.
+// When only a recent window was loaded, stored messages older than the window
+// are kept. It is a no-op for ephemeral stores.
 func (c *Conversation) SaveAllMessages(store *storage.Store) error {
+	if store.Ephemeral() {
+		return nil
+	}
 	messages := make([]storage.Message, len(c.Messages))
 	for i, msg := range c.Messages {
 		contentText, contentJSON, contentType, err := serializeMessageContent(msg.Content)
@@ -355,9 +491,21 @@ func (c *Conversation) SaveAllMessages(store *storage.Store) error {
 			Tokens:           msg.Tokens,
 			IsSummary:        msg.IsSummary,
 			IsTruncated:      msg.IsTruncated,
+			IsPinned:         msg.Pinned,
 		}
 	}
-	return store.ReplaceMessages(c.SessionID, messages)
+	if err := store.ReplaceMessagesFrom(c.SessionID, c.olderCursor, messages); err != nil {
+		return err
+	}
+	for i := range c.Messages {
+		c.Messages[i].StorageID = messages[i].ID
+	}
+	for i, msg := range c.Messages {
+		if err := c.saveAttachment(store, msg.Attachment, messages[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func cloneReasoningDetails(details []model.ReasoningDetail) []model.ReasoningDetail {
@@ -1,6 +1,7 @@
 package conversation
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
@@ -306,6 +307,208 @@ func TestConversationStoragePreservesToolTurns(t *testing.T) {
 	}
 }
 
+func newWindowTestStore(t *testing.T, sessionID string) *storage.Store {
+	t.Helper()
+	store, err := storage.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	if err := store.CreateSession(&storage.Session{
+		ID:         sessionID,
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     storage.SessionStatusActive,
+	}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	return store
+}
+
+func TestLoadRecentFromStorageLoadsOlderOnDemand(t *testing.T) {
+	sessionID := "session-window"
+	store := newWindowTestStore(t, sessionID)
+
+	conv := New(sessionID)
+	for i := 0; i < 10; i++ {
+		conv.AddUserMessage(fmt.Sprintf("message %d", i))
+	}
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages: %v", err)
+	}
+
+	loaded := New(sessionID)
+	if err := loaded.LoadRecentFromStorage(store, 4); err != nil {
+		t.Fatalf("LoadRecentFromStorage: %v", err)
+	}
+	if len(loaded.Messages) != 4 || GetContentAsString(loaded.Messages[0].Content) != "message 6" {
+		t.Fatalf("recent window = %+v, want messages 6-9", loaded.Messages)
+	}
+	if !loaded.HasOlderMessages() {
+		t.Fatal("expected older messages to remain")
+	}
+	windowTokens := loaded.TokenCount
+
+	added, err := loaded.LoadOlderFromStorage(store, 4)
+	if err != nil {
+		t.Fatalf("LoadOlderFromStorage: %v", err)
+	}
+	if added != 4 || len(loaded.Messages) != 8 {
+		t.Fatalf("added = %d, messages = %d, want 4 and 8", added, len(loaded.Messages))
+	}
+	if got := GetContentAsString(loaded.Messages[0].Content); got != "message 2" {
+		t.Fatalf("first message after older load = %q, want message 2", got)
+	}
+	if loaded.TokenCount <= windowTokens {
+		t.Fatalf("TokenCount = %d, want more than the window's %d", loaded.TokenCount, windowTokens)
+	}
+
+	if added, err = loaded.LoadOlderFromStorage(store, 4); err != nil || added != 2 {
+		t.Fatalf("final older load added %d (err %v), want 2", added, err)
+	}
+	if loaded.HasOlderMessages() {
+		t.Fatal("expected no older messages after loading the first one")
+	}
+	for i, msg := range loaded.Messages {
+		if got := GetContentAsString(msg.Content); got != fmt.Sprintf("message %d", i) {
+			t.Fatalf("message %d = %q, want chronological order", i, got)
+		}
+	}
+	if added, err = loaded.LoadOlderFromStorage(store, 4); err != nil || added != 0 {
+		t.Fatalf("load past the start added %d (err %v), want 0", added, err)
+	}
+}
+
+func TestSaveAllMessagesKeepsHistoryOutsideWindow(t *testing.T) {
+	sessionID := "session-window-save"
+	store := newWindowTestStore(t, sessionID)
+
+	conv := New(sessionID)
+	for i := 0; i < 10; i++ {
+		conv.AddUserMessage(fmt.Sprintf("message %d", i))
+	}
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages: %v", err)
+	}
+
+	loaded := New(sessionID)
+	if err := loaded.LoadRecentFromStorage(store, 4); err != nil {
+		t.Fatalf("LoadRecentFromStorage: %v", err)
+	}
+	loaded.Messages = loaded.Messages[:len(loaded.Messages)-1]
+	if err := loaded.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages on window: %v", err)
+	}
+	stored, err := store.GetAllMessages(sessionID)
+	if err != nil {
+		t.Fatalf("GetAllMessages: %v", err)
+	}
+	if len(stored) != 9 {
+		t.Fatalf("stored %d messages, want 9 (older history kept, last one dropped)", len(stored))
+	}
+	for i, msg := range stored {
+		if msg.Content != fmt.Sprintf("message %d", i) {
+			t.Fatalf("stored message %d = %q, want chronological order", i, msg.Content)
+		}
+	}
+	if sess, err := store.GetSession(sessionID); err != nil || sess.MessageCount != 9 {
+		t.Fatalf("session message count = %+v (err %v), want 9", sess, err)
+	}
+
+	if err := loaded.SaveAllMessages(store); err != nil {
+		t.Fatalf("second SaveAllMessages: %v", err)
+	}
+	if stored, _ = store.GetAllMessages(sessionID); len(stored) != 9 {
+		t.Fatalf("stored %d messages after resave, want 9", len(stored))
+	}
+
+	loaded.Clear()
+	if err := loaded.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages after Clear: %v", err)
+	}
+	if stored, _ = store.GetAllMessages(sessionID); len(stored) != 0 {
+		t.Fatalf("stored %d messages after Clear, want 0", len(stored))
+	}
+}
+
+func TestSetPinnedUpdatesTheStoredRow(t *testing.T) {
+	sessionID := "session-pin"
+	store := newWindowTestStore(t, sessionID)
+
+	conv := New(sessionID)
+	for i := 0; i < 3; i++ {
+		conv.AddUserMessage(fmt.Sprintf("message %d", i))
+		if err := conv.SaveMessage(store, conv.Messages[len(conv.Messages)-1]); err != nil {
+			t.Fatalf("SaveMessage %d: %v", i, err)
+		}
+	}
+	// An unsaved turn at the end must not shift which stored row is pinned.
+	conv.AddUserMessage("not saved yet")
+
+	if err := conv.SetPinned(store, 1, true); err != nil {
+		t.Fatalf("SetPinned: %v", err)
+	}
+	if err := conv.SetPinned(store, 3, true); err != nil {
+		t.Fatalf("SetPinned on unsaved message: %v", err)
+	}
+	if err := conv.SaveMessage(store, conv.Messages[3]); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	stored, err := store.GetAllMessages(sessionID)
+	if err != nil {
+		t.Fatalf("GetAllMessages: %v", err)
+	}
+	for i, msg := range stored {
+		if want := i == 1 || i == 3; msg.IsPinned != want {
+			t.Fatalf("stored message %d pinned = %v, want %v", i, msg.IsPinned, want)
+		}
+	}
+
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages: %v", err)
+	}
+	if err := conv.SetPinned(store, 1, false); err != nil {
+		t.Fatalf("SetPinned after rewrite: %v", err)
+	}
+	if stored, _ = store.GetAllMessages(sessionID); stored[1].IsPinned {
+		t.Fatal("message still pinned after unpinning a rewritten session")
+	}
+}
+
+func TestLoadRecentFromStorageKeepsToolCallWithResult(t *testing.T) {
+	sessionID := "session-window-tools"
+	store := newWindowTestStore(t, sessionID)
+
+	conv := New(sessionID)
+	conv.AddUserMessage("Read README.md")
+	conv.AddToolCallMessageWithReasoning([]model.ToolCall{{
+		ID:       "call_1",
+		Type:     "function",
+		Function: model.FunctionCall{Name: "read_file", Arguments: `{"path":"README.md"}`},
+	}}, "", nil)
+	conv.AddToolResponseMessage("call_1", "read_file", "contents")
+	conv.AddAssistantMessage("Done")
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages: %v", err)
+	}
+
+	loaded := New(sessionID)
+	if err := loaded.LoadRecentFromStorage(store, 2); err != nil {
+		t.Fatalf("LoadRecentFromStorage: %v", err)
+	}
+	if len(loaded.Messages) != 3 {
+		t.Fatalf("messages = %d, want the window widened to 3", len(loaded.Messages))
+	}
+	if loaded.Messages[0].Role != "assistant" || len(loaded.Messages[0].ToolCalls) != 1 {
+		t.Fatalf("window should open on the tool call, got %+v", loaded.Messages[0])
+	}
+	if !loaded.HasOlderMessages() {
+		t.Fatal("expected the user message to remain unloaded")
+	}
+}
+
 func TestGetContentAsString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -635,3 +838,45 @@ func TestMaterializeContentFallback(t *testing.T) {
 		t.Fatalf("expected fallback text, got %v", content)
 	}
 }
+
+func TestConversationSaveSkipsEphemeralStore(t *testing.T) {
+	store, err := storage.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	store.SetEphemeral(true)
+
+	sessionID := "session-ephemeral"
+	if err := store.CreateSession(&storage.Session{
+		ID:         sessionID,
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     storage.SessionStatusActive,
+	}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	conv := New(sessionID)
+	conv.AddUserMessage("keep this off disk")
+	conv.AddAssistantMessage("understood")
+	if err := conv.SaveMessage(store, conv.Messages[0]); err != nil {
+		t.Fatalf("save message: %v", err)
+	}
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("save all messages: %v", err)
+	}
+
+	var rows int
+	if err := store.DB().QueryRow(`SELECT COUNT(*) FROM messages`).Scan(&rows); err != nil {
+		t.Fatalf("count messages: %v", err)
+	}
+	if rows != 0 {
+		t.Fatalf("messages rows = %d, want 0 in ephemeral mode", rows)
+	}
+	if len(conv.Messages) != 2 {
+		t.Fatalf("in-memory messages = %d, want 2", len(conv.Messages))
+	}
+}
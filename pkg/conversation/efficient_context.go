@@ -49,31 +49,52 @@ func (c *Conversation) ToEfficientModelMessages() []model.Message {
 // a completion reserve. A zero contextWindow uses the stable default budget.
 func CompactModelMessagesForRequest(messages []model.Message, req model.ChatRequest, contextWindow int) []model.Message {
 	opts := DefaultEfficientContextOptions()
-	if contextWindow > 0 {
-		probe := req
-		probe.Messages = nil
-		overhead := model.EstimateRequestTokens(probe).Total
-		completionReserve := req.MaxCompletionTokens
-		if req.MaxTokens > completionReserve {
-			completionReserve = req.MaxTokens
-		}
-		if completionReserve < 2048 {
-			completionReserve = 2048
-		}
-		messageTokens := contextWindow*4/5 - overhead - completionReserve
-		if messageTokens < 1024 {
-			messageTokens = 1024
-		}
-		requestBytes := messageTokens * 4
-		if requestBytes < opts.MaxBytes {
-			opts.MaxBytes = requestBytes
+	opts.MaxBytes = RequestMessageBudget(req, contextWindow)
+	return CompactModelMessages(messages, opts)
+}
+
+// RequestMessageBudget returns the message byte budget that
+// CompactModelMessagesForRequest compacts to.
+func RequestMessageBudget(req model.ChatRequest, contextWindow int) int {
+	budget := DefaultEfficientContextOptions().MaxBytes
+	if contextWindow <= 0 {
+		return budget
+	}
+	probe := req
+	probe.Messages = nil
+	overhead := model.EstimateRequestTokens(probe).Total
+	completionReserve := req.MaxCompletionTokens
+	if req.MaxTokens > completionReserve {
+		completionReserve = req.MaxTokens
+	}
+	if completionReserve < 2048 {
+		completionReserve = 2048
+	}
+	messageTokens := contextWindow*4/5 - overhead - completionReserve
+	if messageTokens < 1024 {
+		messageTokens = 1024
+	}
+	if requestBytes := messageTokens * 4; requestBytes < budget {
+		budget = requestBytes
+	}
+	return budget
+}
+
+// PinnedMessageBytes returns the size of the pinned messages, which
+// compaction keeps exact even when they alone exceed the budget.
+func PinnedMessageBytes(messages []model.Message) int {
+	total := 0
+	for _, msg := range messages {
+		if msg.Pinned {
+			total += modelMessageBytes(msg)
 		}
 	}
-	return CompactModelMessages(messages, opts)
+	return total
 }
 
 // CompactModelMessages prunes stale high-volume fields without removing tool
-// call/result pairs required by chat-completion APIs.
+// call/result pairs required by chat-completion APIs. System and pinned
+// messages are never shortened or collapsed.
 func CompactModelMessages(messages []model.Message, opts EfficientContextOptions) []model.Message {
 	if opts.RecentMessages <= 0 {
 		opts = DefaultEfficientContextOptions()
@@ -108,7 +129,7 @@ func CompactModelMessages(messages []model.Message, opts EfficientContextOptions
 				hasToolDigest = true
 			}
 		}
-		if i >= recentStart {
+		if i >= recentStart || msg.Pinned {
 			if hasToolDigest {
 				seenToolResults[toolDigest] = i
 			}
@@ -153,6 +174,9 @@ func compactToBudget(messages []model.Message, opts EfficientContextOptions) []m
 	}
 	for i := 0; i < stop && totalBytes > opts.MaxBytes; i++ {
 		msg := &messages[i]
+		if msg.Pinned {
+			continue
+		}
 		before := modelMessageBytes(*msg)
 		switch msg.Role {
 		case "tool":
@@ -178,6 +202,9 @@ func compactToBudget(messages []model.Message, opts EfficientContextOptions) []m
 	}
 	for i := 0; i < stop && totalBytes > opts.MaxBytes; i++ {
 		msg := &messages[i]
+		if msg.Pinned {
+			continue
+		}
 		before := modelMessageBytes(*msg)
 		switch msg.Role {
 		case "tool":
@@ -217,7 +244,7 @@ func collapseHistoricalPrefix(messages []model.Message, maxBytes int) []model.Me
 	protected := make([]model.Message, 0, tailStart)
 	collapsed := make([]model.Message, 0, tailStart)
 	for _, msg := range messages[:tailStart] {
-		if msg.Role == "system" {
+		if msg.Role == "system" || msg.Pinned {
 			protected = append(protected, msg)
 		} else {
 			collapsed = append(collapsed, msg)
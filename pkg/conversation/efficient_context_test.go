@@ -175,3 +175,51 @@ func TestCompactModelMessages_EnforcesBurstBudget(t *testing.T) {
 		t.Fatal("immediate tail should remain exact")
 	}
 }
+
+func TestCompactModelMessagesForRequest_KeepsPinnedMessagesExact(t *testing.T) {
+	spec := "SPEC: " + strings.Repeat("the parser must accept trailing commas ", 60)
+	messages := []model.Message{
+		{Role: "system", Content: "protected instructions"},
+		{Role: "user", Content: spec, Pinned: true},
+	}
+	for i := 0; i < 100; i++ {
+		messages = append(messages, model.Message{Role: "user", Content: strings.Repeat("old request ", 100)})
+	}
+	messages = append(messages, model.Message{Role: "user", Content: "finish this"})
+
+	got := CompactModelMessagesForRequest(messages, model.ChatRequest{MaxTokens: 2048}, 8192)
+	if len(got) >= len(messages) {
+		t.Fatalf("historical prefix was not collapsed: %d messages", len(got))
+	}
+	var pinnedFound bool
+	for _, msg := range got {
+		if msg.Pinned && msg.Content == spec {
+			pinnedFound = true
+		}
+	}
+	if !pinnedFound {
+		t.Fatal("pinned message was compacted or dropped")
+	}
+	if got[0].Content != "protected instructions" || got[len(got)-1].Content != "finish this" {
+		t.Fatal("system instructions or latest message were not preserved")
+	}
+}
+
+func TestCompactModelMessages_PinnedMessagesOverBudgetAreKept(t *testing.T) {
+	pinned := strings.Repeat("p", 5000)
+	messages := []model.Message{
+		{Role: "user", Content: pinned, Pinned: true},
+		{Role: "assistant", Content: strings.Repeat("a", 5000), Pinned: true},
+		{Role: "user", Content: "next"},
+		{Role: "assistant", Content: "ok"},
+	}
+	got := CompactModelMessages(messages, EfficientContextOptions{
+		RecentMessages: 1, OldToolBytes: 100, OldAssistantBytes: 100, KeepReasoningRecent: 1, MaxBytes: 1000,
+	})
+	if got[0].Content != pinned || got[1].Content != messages[1].Content {
+		t.Fatal("pinned messages were compacted")
+	}
+	if PinnedMessageBytes(got) <= 1000 {
+		t.Fatalf("PinnedMessageBytes = %d, want the full pinned size", PinnedMessageBytes(got))
+	}
+}
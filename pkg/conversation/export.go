@@ -0,0 +1,404 @@
+package conversation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// Export formats understood by Exporter.
+const (
+	ExportMarkdown = "markdown"
+	ExportJSON     = "json"
+	ExportHTML     = "html"
+)
+
+// exportContentMaxBytes keeps a single huge tool output from dominating an
+// export; the head and tail are kept.
+const exportContentMaxBytes = 16 * 1024
+
+// ParseExportFormat normalizes a format name; an empty name returns "".
+func ParseExportFormat(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "":
+		return "", nil
+	case "markdown", "md":
+		return ExportMarkdown, nil
+	case "json":
+		return ExportJSON, nil
+	case "html":
+		return ExportHTML, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q (valid: markdown, json, html)", raw)
+	}
+}
+
+// ExportExtension returns the file extension for format.
+func ExportExtension(format string) string {
+	switch format {
+	case ExportJSON:
+		return ".json"
+	case ExportHTML:
+		return ".html"
+	default:
+		return ".md"
+	}
+}
+
+// ExportOptions selects the output format and what an export includes.
+type ExportOptions struct {
+	Format          string
+	IncludeSystem   bool
+	IncludeTools    bool
+	IncludeMetadata bool // model, branch, timestamps, and usage from the session record
+}
+
+// Exporter renders stored sessions as Markdown, JSON, or HTML.
+type Exporter struct {
+	opts ExportOptions
+}
+
+// NewExporter creates an exporter; an empty format means Markdown.
+func NewExporter(opts ExportOptions) *Exporter {
+	if opts.Format == "" {
+		opts.Format = ExportMarkdown
+	}
+	return &Exporter{opts: opts}
+}
+
+// Filter drops the system and tool messages the options exclude. Without
+// tools, assistant tool calls are stripped and messages left with nothing to
+// show are skipped. messages is not modified.
+func (e *Exporter) Filter(messages []Message) []Message {
+	out := make([]Message, 0, len(messages))
+	for _, msg := range messages {
+		switch {
+		case msg.Role == "system" && !e.opts.IncludeSystem:
+			continue
+		case msg.Role == "tool" && !e.opts.IncludeTools:
+			continue
+		}
+		if !e.opts.IncludeTools && len(msg.ToolCalls) > 0 {
+			msg.ToolCalls = nil
+			if strings.TrimSpace(GetContentAsString(msg.Content)) == "" && strings.TrimSpace(msg.Reasoning) == "" {
+				continue
+			}
+		}
+		out = append(out, msg)
+	}
+	return out
+}
+
+// WriteSession writes one session as a standalone document. messages should
+// already be filtered.
+func (e *Exporter) WriteSession(w io.Writer, session *storage.Session, messages []Message, exportedAt time.Time) error {
+	switch e.opts.Format {
+	case ExportJSON:
+		doc := e.jsonSession(session, messages)
+		doc.ExportedAt = exportedAt
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("encode export: %w", err)
+		}
+		return nil
+	case ExportHTML:
+		ew := &errWriter{w: w}
+		ew.printf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+		ew.printf("<title>Buckley Conversation Export</title>\n</head>\n<body>\n")
+		ew.printf("<h1>Buckley Conversation Export</h1>\n<ul>\n")
+		e.writeHTMLSummary(ew, session, len(messages))
+		ew.printf("<li>Exported: <code>%s</code></li>\n</ul>\n", exportedAt.Format(time.RFC3339))
+		writeHTMLMessages(ew, messages)
+		ew.printf("</body>\n</html>\n")
+		return ew.err
+	default:
+		ew := &errWriter{w: w}
+		ew.printf("# Buckley Conversation Export\n\n")
+		e.writeMarkdownSummary(ew, session, len(messages))
+		ew.printf("- Exported: `%s`\n\n## Messages\n\n", exportedAt.Format(time.RFC3339))
+		writeMarkdownMessages(ew, messages)
+		return ew.err
+	}
+}
+
+// ExportArchive writes many sessions into one Markdown or JSON document,
+// one session at a time, so only the session being written is held in memory.
+type ExportArchive struct {
+	exporter *Exporter
+	w        *errWriter
+	count    int
+}
+
+// NewArchive starts a combined export on w. HTML is only written one
+// session per file.
+func (e *Exporter) NewArchive(w io.Writer, exportedAt time.Time) (*ExportArchive, error) {
+	ew := &errWriter{w: w}
+	switch e.opts.Format {
+	case ExportJSON:
+		stamp, _ := json.Marshal(exportedAt)
+		ew.printf("{\n  \"exportedAt\": %s,\n  \"sessions\": [", stamp)
+	case ExportHTML:
+		return nil, errors.New("html exports are written one file per session")
+	default:
+		ew.printf("# Buckley Conversation Archive\n\n- Exported: `%s`\n\n", exportedAt.Format(time.RFC3339))
+	}
+	return &ExportArchive{exporter: e, w: ew}, ew.err
+}
+
+// Add appends one session to the archive. messages should already be
+// filtered.
+func (a *ExportArchive) Add(session *storage.Session, messages []Message) error {
+	switch a.exporter.opts.Format {
+	case ExportJSON:
+		data, err := json.MarshalIndent(a.exporter.jsonSession(session, messages), "    ", "  ")
+		if err != nil {
+			return fmt.Errorf("encode export: %w", err)
+		}
+		if a.count > 0 {
+			a.w.printf(",")
+		}
+		a.w.printf("\n    %s", data)
+	default:
+		a.w.printf("## Session `%s`\n\n", session.ID)
+		a.exporter.writeMarkdownSummary(a.w, session, len(messages))
+		a.w.printf("\n")
+		writeMarkdownMessages(a.w, messages)
+	}
+	a.count++
+	return a.w.err
+}
+
+// Close ends the archive document. It does not close the underlying writer.
+func (a *ExportArchive) Close() error {
+	if a.exporter.opts.Format == ExportJSON {
+		if a.count > 0 {
+			a.w.printf("\n  ")
+		}
+		a.w.printf("]\n}\n")
+	}
+	return a.w.err
+}
+
+type exportJSONSession struct {
+	SessionID  string                 `json:"sessionId"`
+	Project    string                 `json:"project"`
+	ExportedAt time.Time              `json:"exportedAt,omitzero"`
+	Metadata   *exportJSONMetadata    `json:"metadata,omitempty"`
+	Messages   []exportJSONMessageRow `json:"messages"`
+}
+
+type exportJSONMetadata struct {
+	Model        string    `json:"model,omitempty"`
+	GitRepo      string    `json:"gitRepo,omitempty"`
+	GitBranch    string    `json:"gitBranch,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	CreatedAt    time.Time `json:"createdAt,omitzero"`
+	LastActive   time.Time `json:"lastActive,omitzero"`
+	MessageCount int       `json:"messageCount"`
+	TotalTokens  int       `json:"totalTokens"`
+	TotalCost    float64   `json:"totalCost"`
+}
+
+type exportJSONMessageRow struct {
+	Role       string    `json:"role"`
+	Name       string    `json:"name,omitempty"`
+	Content    string    `json:"content,omitempty"`
+	Reasoning  string    `json:"reasoning,omitempty"`
+	ToolCalls  []string  `json:"toolCalls,omitempty"`
+	ToolCallID string    `json:"toolCallId,omitempty"`
+	IsSummary  bool      `json:"isSummary,omitempty"`
+	Timestamp  time.Time `json:"timestamp,omitzero"`
+}
+
+func (e *Exporter) jsonSession(session *storage.Session, messages []Message) exportJSONSession {
+	doc := exportJSONSession{
+		SessionID: session.ID,
+		Project:   session.ProjectPath,
+		Messages:  make([]exportJSONMessageRow, 0, len(messages)),
+	}
+	if e.opts.IncludeMetadata {
+		doc.Metadata = &exportJSONMetadata{
+			Model:        session.Model,
+			GitRepo:      session.GitRepo,
+			GitBranch:    session.GitBranch,
+			Status:       session.Status,
+			CreatedAt:    session.CreatedAt,
+			LastActive:   session.LastActive,
+			MessageCount: session.MessageCount,
+			TotalTokens:  session.TotalTokens,
+			TotalCost:    session.TotalCost,
+		}
+	}
+	for _, msg := range messages {
+		row := exportJSONMessageRow{
+			Role:       msg.Role,
+			Name:       msg.Name,
+			ToolCalls:  exportToolCalls(msg),
+			ToolCallID: msg.ToolCallID,
+			IsSummary:  msg.IsSummary,
+			Timestamp:  msg.Timestamp,
+		}
+		if content := GetContentAsString(msg.Content); strings.TrimSpace(content) != "" {
+			row.Content = truncateExportContent(content)
+		}
+		if strings.TrimSpace(msg.Reasoning) != "" {
+			row.Reasoning = truncateExportContent(msg.Reasoning)
+		}
+		doc.Messages = append(doc.Messages, row)
+	}
+	return doc
+}
+
+func (e *Exporter) writeMarkdownSummary(w *errWriter, session *storage.Session, messageCount int) {
+	w.printf("- Session: `%s`\n- Project: `%s`\n", session.ID, session.ProjectPath)
+	if e.opts.IncludeMetadata {
+		for _, field := range sessionMetadataFields(session) {
+			w.printf("- %s: `%s`\n", field[0], field[1])
+		}
+	}
+	w.printf("- Messages: `%d`\n", messageCount)
+}
+
+func (e *Exporter) writeHTMLSummary(w *errWriter, session *storage.Session, messageCount int) {
+	w.printf("<li>Session: <code>%s</code></li>\n", html.EscapeString(session.ID))
+	w.printf("<li>Project: <code>%s</code></li>\n", html.EscapeString(session.ProjectPath))
+	if e.opts.IncludeMetadata {
+		for _, field := range sessionMetadataFields(session) {
+			w.printf("<li>%s: <code>%s</code></li>\n", field[0], html.EscapeString(field[1]))
+		}
+	}
+	w.printf("<li>Messages: <code>%d</code></li>\n", messageCount)
+}
+
+// sessionMetadataFields lists the non-empty session record fields as
+// label/value pairs.
+func sessionMetadataFields(session *storage.Session) [][2]string {
+	var fields [][2]string
+	add := func(label, value string) {
+		if strings.TrimSpace(value) != "" {
+			fields = append(fields, [2]string{label, value})
+		}
+	}
+	add("Model", session.Model)
+	add("Repository", session.GitRepo)
+	add("Branch", session.GitBranch)
+	add("Status", session.Status)
+	if !session.CreatedAt.IsZero() {
+		add("Created", session.CreatedAt.Format(time.RFC3339))
+	}
+	if !session.LastActive.IsZero() {
+		add("Last active", session.LastActive.Format(time.RFC3339))
+	}
+	add("Tokens", fmt.Sprintf("%d", session.TotalTokens))
+	add("Cost", fmt.Sprintf("$%.4f", session.TotalCost))
+	return fields
+}
+
+func writeMarkdownMessages(w *errWriter, messages []Message) {
+	for i, msg := range messages {
+		w.printf("### %d. %s\n\n", i+1, exportTitle(msg))
+		if !msg.Timestamp.IsZero() {
+			w.printf("_%s_\n\n", msg.Timestamp.Format(time.RFC3339))
+		}
+		w.printf("%s\n\n", exportContent(msg))
+	}
+}
+
+func writeHTMLMessages(w *errWriter, messages []Message) {
+	for i, msg := range messages {
+		w.printf("<section>\n<h3>%d. %s</h3>\n", i+1, html.EscapeString(exportTitle(msg)))
+		if !msg.Timestamp.IsZero() {
+			w.printf("<p><time>%s</time></p>\n", msg.Timestamp.Format(time.RFC3339))
+		}
+		w.printf("<pre>%s</pre>\n</section>\n", html.EscapeString(exportContent(msg)))
+	}
+}
+
+func exportTitle(msg Message) string {
+	title := "Message"
+	if msg.Role != "" {
+		title = strings.ToUpper(msg.Role[:1]) + msg.Role[1:]
+	}
+	if msg.IsSummary {
+		title += " Summary"
+	}
+	if msg.Name != "" {
+		title += " " + msg.Name
+	}
+	return title
+}
+
+func exportContent(msg Message) string {
+	var blocks []string
+	if content := GetContentAsString(msg.Content); strings.TrimSpace(content) != "" {
+		blocks = append(blocks, truncateExportContent(content))
+	}
+	if calls := exportToolCalls(msg); len(calls) > 0 {
+		blocks = append(blocks, "Tool calls: "+strings.Join(calls, ", "))
+	}
+	if len(blocks) == 0 && strings.TrimSpace(msg.Reasoning) != "" {
+		blocks = append(blocks, truncateExportContent(msg.Reasoning))
+	}
+	if len(blocks) == 0 {
+		return "(empty)"
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func exportToolCalls(msg Message) []string {
+	if len(msg.ToolCalls) == 0 {
+		return nil
+	}
+	calls := make([]string, 0, len(msg.ToolCalls))
+	for _, call := range msg.ToolCalls {
+		name := strings.TrimSpace(call.Function.Name)
+		if name == "" {
+			name = strings.TrimSpace(call.ID)
+		}
+		if name == "" {
+			name = "tool_call"
+		}
+		calls = append(calls, name)
+	}
+	return calls
+}
+
+// truncateExportContent keeps the head and tail of content longer than
+// exportContentMaxBytes, cutting on rune boundaries.
+func truncateExportContent(content string) string {
+	if len(content) <= exportContentMaxBytes {
+		return content
+	}
+	head := exportContentMaxBytes * 2 / 3
+	tail := exportContentMaxBytes - head
+	for head > 0 && !utf8.RuneStart(content[head]) {
+		head--
+	}
+	start := len(content) - tail
+	for start < len(content) && !utf8.RuneStart(content[start]) {
+		start++
+	}
+	return fmt.Sprintf("%s\n\n... %d bytes omitted from export ...\n\n%s", content[:head], start-head, content[start:])
+}
+
+// errWriter keeps the first write error so rendering code can write
+// unconditionally and check once at the end.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *errWriter) printf(format string, args ...any) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.w, format, args...)
+}
@@ -0,0 +1,73 @@
+package conversation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/model"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestExporterFilterDropsToolCallsWithoutTools(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "rules"},
+		{Role: "user", Content: "hi"},
+		{Role: "assistant", ToolCalls: []model.ToolCall{{ID: "call-1"}}},
+		{Role: "tool", ToolCallID: "call-1", Content: "ok"},
+		{Role: "assistant", Content: "done", ToolCalls: []model.ToolCall{{ID: "call-2"}}},
+	}
+
+	got := NewExporter(ExportOptions{}).Filter(messages)
+	if len(got) != 2 || got[0].Role != "user" || got[1].Content != "done" || got[1].ToolCalls != nil {
+		t.Fatalf("Filter() = %+v", got)
+	}
+	if messages[4].ToolCalls == nil {
+		t.Fatal("Filter() modified its input")
+	}
+
+	all := NewExporter(ExportOptions{IncludeSystem: true, IncludeTools: true}).Filter(messages)
+	if len(all) != len(messages) {
+		t.Fatalf("Filter() with everything included kept %d of %d", len(all), len(messages))
+	}
+}
+
+func TestExportArchiveMarkdown(t *testing.T) {
+	var out bytes.Buffer
+	exporter := NewExporter(ExportOptions{IncludeMetadata: true})
+	archive, err := exporter.NewArchive(&out, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewArchive: %v", err)
+	}
+	for _, id := range []string{"s1", "s2"} {
+		session := &storage.Session{ID: id, ProjectPath: "/repo", Model: "openai/gpt-5"}
+		if err := archive.Add(session, []Message{{Role: "user", Content: "hello from " + id}}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{"# Buckley Conversation Archive", "## Session `s1`", "## Session `s2`", "hello from s2", "openai/gpt-5"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("archive missing %q:\n%s", want, text)
+		}
+	}
+
+	if _, err := NewExporter(ExportOptions{Format: ExportHTML}).NewArchive(&out, time.Now()); err == nil {
+		t.Fatal("expected html archive to be rejected")
+	}
+}
+
+func TestTruncateExportContent(t *testing.T) {
+	content := strings.Repeat("a", exportContentMaxBytes) + strings.Repeat("b", 100)
+	got := truncateExportContent(content)
+	if !strings.Contains(got, "bytes omitted from export") || !strings.HasSuffix(got, "b") {
+		t.Fatalf("truncateExportContent() = %q...", got[:40])
+	}
+	if short := "short"; truncateExportContent(short) != short {
+		t.Fatal("short content was truncated")
+	}
+}
@@ -0,0 +1,191 @@
+package conversation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"m31labs.dev/buckley/pkg/session"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// maxImportIDAttempts bounds the search for an unused generated session ID.
+const maxImportIDAttempts = 100
+
+// ImportOptions controls which session an import is written to.
+type ImportOptions struct {
+	// NewSessionID writes the import to a freshly generated session instead
+	// of the session ID recorded in the file.
+	NewSessionID bool
+	// Replace overwrites an existing session that has the file's session ID.
+	// Exports drop tool calls and truncate long content, so without it a
+	// taken ID gets a generated one instead.
+	Replace bool
+	// SessionIDPrefix is prepended to generated session IDs.
+	SessionIDPrefix string
+	// WorkDir is used to derive generated session IDs and as the project
+	// path when the file does not record one.
+	WorkDir string
+}
+
+// ImportResult describes a completed import.
+type ImportResult struct {
+	SessionID string
+	Imported  int
+	// Skipped counts tool calls and results dropped from the import; JSON
+	// exports keep only tool names, which cannot be replayed to a model.
+	Skipped int
+	// Replaced is true when an existing session's messages were overwritten.
+	Replaced bool
+}
+
+// Importer loads JSON conversation exports back into storage.
+type Importer struct {
+	store *storage.Store
+	now   func() time.Time
+}
+
+// NewImporter creates an importer that writes to store.
+func NewImporter(store *storage.Store) *Importer {
+	return &Importer{store: store, now: time.Now}
+}
+
+// Import reads a JSON export (as written by /export --format json) and
+// stores its messages. The session ID recorded in the file is reused when no
+// session has it; otherwise, or when the file has none, a generated ID is
+// used. An existing session is only overwritten with opts.Replace.
+func (i *Importer) Import(r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	if i == nil || i.store == nil {
+		return nil, errors.New("import: storage unavailable")
+	}
+	if i.store.Ephemeral() {
+		return nil, errors.New("import: persistence is disabled")
+	}
+	var doc exportJSONSession
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("import: decode: %w", err)
+	}
+	messages, skipped := importMessages(doc.Messages)
+	if len(messages) == 0 {
+		return nil, errors.New("import: file has no messages to import")
+	}
+
+	project := strings.TrimSpace(doc.Project)
+	if project == "" {
+		project = opts.WorkDir
+	}
+	base := opts.WorkDir
+	if base == "" {
+		base = project
+	}
+	sessionID := strings.TrimSpace(doc.SessionID)
+	var existing *storage.Session
+	if !opts.NewSessionID && sessionID != "" {
+		var err error
+		if existing, err = i.store.GetSession(sessionID); err != nil {
+			return nil, fmt.Errorf("import: look up session: %w", err)
+		}
+	}
+	if opts.NewSessionID || sessionID == "" || (existing != nil && !opts.Replace) {
+		id, err := i.generateSessionID(opts.SessionIDPrefix, base)
+		if err != nil {
+			return nil, err
+		}
+		sessionID = id
+		existing = nil
+	}
+
+	if existing == nil {
+		if err := i.store.CreateSession(i.importedSession(sessionID, project, doc.Metadata)); err != nil {
+			return nil, fmt.Errorf("import: create session: %w", err)
+		}
+	}
+
+	conv := New(sessionID)
+	conv.Messages = messages
+	conv.UpdateTokenCount()
+	if err := conv.SaveAllMessages(i.store); err != nil {
+		return nil, fmt.Errorf("import: save messages: %w", err)
+	}
+	return &ImportResult{
+		SessionID: sessionID,
+		Imported:  len(messages),
+		Skipped:   skipped,
+		Replaced:  existing != nil,
+	}, nil
+}
+
+// generateSessionID derives an ID from workDir the same way new sessions
+// are named, adding a counter if it is already taken.
+func (i *Importer) generateSessionID(prefix, workDir string) (string, error) {
+	base := session.DetermineSessionID(workDir) + "-" + i.now().Format("0102-150405")
+	if prefix = strings.TrimSpace(prefix); prefix != "" {
+		base = prefix + "-" + base
+	}
+	for n := 1; n <= maxImportIDAttempts; n++ {
+		id := base
+		if n > 1 {
+			id = fmt.Sprintf("%s-%d", base, n)
+		}
+		existing, err := i.store.GetSession(id)
+		if err != nil {
+			return "", fmt.Errorf("import: look up session: %w", err)
+		}
+		if existing == nil {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("import: no free session ID for %s", base)
+}
+
+func (i *Importer) importedSession(sessionID, project string, meta *exportJSONMetadata) *storage.Session {
+	now := i.now()
+	sess := &storage.Session{
+		ID:          sessionID,
+		ProjectPath: project,
+		CreatedAt:   now,
+		LastActive:  now,
+		Status:      storage.SessionStatusActive,
+	}
+	if meta != nil {
+		sess.Model = meta.Model
+		sess.GitRepo = meta.GitRepo
+		sess.GitBranch = meta.GitBranch
+		if !meta.CreatedAt.IsZero() {
+			sess.CreatedAt = meta.CreatedAt
+		}
+	}
+	return sess
+}
+
+// importMessages converts exported rows back into messages. Tool results
+// and assistant tool calls are dropped because the export does not keep the
+// call arguments needed to pair them again.
+func importMessages(rows []exportJSONMessageRow) ([]Message, int) {
+	messages := make([]Message, 0, len(rows))
+	skipped := 0
+	for _, row := range rows {
+		if row.Role == "tool" {
+			skipped++
+			continue
+		}
+		if len(row.ToolCalls) > 0 {
+			skipped += len(row.ToolCalls)
+		}
+		if strings.TrimSpace(row.Content) == "" && strings.TrimSpace(row.Reasoning) == "" {
+			continue
+		}
+		messages = append(messages, Message{
+			Role:      row.Role,
+			Name:      row.Name,
+			Content:   row.Content,
+			Reasoning: row.Reasoning,
+			IsSummary: row.IsSummary,
+			Timestamp: row.Timestamp,
+		})
+	}
+	return messages, skipped
+}
@@ -0,0 +1,169 @@
+package conversation
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+const importFixture = `{
+  "sessionId": "orig-session",
+  "project": "/repo/demo",
+  "messages": [
+    {"role": "user", "content": "list files"},
+    {"role": "assistant", "toolCalls": ["list_files"]},
+    {"role": "tool", "name": "list_files", "toolCallId": "call-1", "content": "main.go"},
+    {"role": "assistant", "content": "One file: main.go"}
+  ]
+}`
+
+func newImportTestStore(t *testing.T) *storage.Store {
+	t.Helper()
+	store, err := storage.New(filepath.Join(t.TempDir(), "buckley.db"))
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func loadImported(t *testing.T, store *storage.Store, sessionID string) []Message {
+	t.Helper()
+	conv := New(sessionID)
+	if err := conv.LoadFromStorage(store); err != nil {
+		t.Fatalf("LoadFromStorage: %v", err)
+	}
+	return conv.Messages
+}
+
+func TestImporterReusesFreeSessionIDAndKeepsExisting(t *testing.T) {
+	store := newImportTestStore(t)
+	importer := NewImporter(store)
+
+	first, err := importer.Import(strings.NewReader(importFixture), ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if first.SessionID != "orig-session" || first.Replaced || first.Imported != 2 || first.Skipped != 2 {
+		t.Fatalf("first import = %+v", first)
+	}
+	conv := New("orig-session")
+	if err := conv.SaveMessage(store, Message{Role: "user", Content: "only in the original", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+
+	second, err := importer.Import(strings.NewReader(importFixture), ImportOptions{})
+	if err != nil {
+		t.Fatalf("second Import: %v", err)
+	}
+	if second.SessionID == "orig-session" || second.Replaced {
+		t.Fatalf("second import = %+v, want a new session", second)
+	}
+	if msgs := loadImported(t, store, "orig-session"); len(msgs) != 3 {
+		t.Fatalf("original has %d messages after re-import, want 3 (untouched)", len(msgs))
+	}
+	if msgs := loadImported(t, store, second.SessionID); len(msgs) != 2 {
+		t.Fatalf("new session has %d messages, want 2", len(msgs))
+	}
+}
+
+func TestImporterReplace(t *testing.T) {
+	store := newImportTestStore(t)
+	importer := NewImporter(store)
+
+	if _, err := importer.Import(strings.NewReader(importFixture), ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	conv := New("orig-session")
+	if err := conv.SaveMessage(store, Message{Role: "user", Content: "only in the original", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	replaced, err := importer.Import(strings.NewReader(importFixture), ImportOptions{Replace: true})
+	if err != nil {
+		t.Fatalf("Import --replace: %v", err)
+	}
+	if replaced.SessionID != "orig-session" || !replaced.Replaced {
+		t.Fatalf("replace import = %+v", replaced)
+	}
+	if msgs := loadImported(t, store, "orig-session"); len(msgs) != 2 {
+		t.Fatalf("stored %d messages after replace, want 2", len(msgs))
+	}
+}
+
+func TestImporterNewSessionID(t *testing.T) {
+	store := newImportTestStore(t)
+	importer := NewImporter(store)
+	importer.now = func() time.Time { return time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC) }
+
+	if _, err := importer.Import(strings.NewReader(importFixture), ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	opts := ImportOptions{NewSessionID: true, SessionIDPrefix: "imported", WorkDir: t.TempDir()}
+	first, err := importer.Import(strings.NewReader(importFixture), opts)
+	if err != nil {
+		t.Fatalf("Import --new: %v", err)
+	}
+	second, err := importer.Import(strings.NewReader(importFixture), opts)
+	if err != nil {
+		t.Fatalf("second Import --new: %v", err)
+	}
+	if first.SessionID == "orig-session" || first.Replaced || !strings.HasPrefix(first.SessionID, "imported-") {
+		t.Fatalf("first --new import = %+v", first)
+	}
+	if second.SessionID == first.SessionID || !strings.HasSuffix(second.SessionID, "-2") {
+		t.Fatalf("second --new import reused %q", second.SessionID)
+	}
+	for _, id := range []string{"orig-session", first.SessionID, second.SessionID} {
+		msgs := loadImported(t, store, id)
+		if len(msgs) != 2 || GetContentAsString(msgs[1].Content) != "One file: main.go" {
+			t.Fatalf("session %s messages = %+v", id, msgs)
+		}
+	}
+}
+
+func TestImporterGeneratesIDWhenFileHasNone(t *testing.T) {
+	store := newImportTestStore(t)
+	doc := `{"project": "", "messages": [{"role": "user", "content": "hi"}]}`
+	workDir := t.TempDir()
+
+	result, err := NewImporter(store).Import(bytes.NewBufferString(doc), ImportOptions{WorkDir: workDir})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if strings.TrimSpace(result.SessionID) == "" {
+		t.Fatal("expected a generated session ID")
+	}
+	sess, err := store.GetSession(result.SessionID)
+	if err != nil || sess == nil {
+		t.Fatalf("GetSession = %v, %v", sess, err)
+	}
+	if sess.ProjectPath != workDir {
+		t.Fatalf("project = %q, want %q", sess.ProjectPath, workDir)
+	}
+}
+
+func TestImporterRejectsEmptyExport(t *testing.T) {
+	store := newImportTestStore(t)
+	if _, err := NewImporter(store).Import(strings.NewReader(`{"sessionId": "x", "messages": []}`), ImportOptions{}); err == nil {
+		t.Fatal("expected error for export without messages")
+	}
+	if _, err := NewImporter(store).Import(strings.NewReader(`not json`), ImportOptions{}); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
+
+func TestImporterRefusesEphemeralStore(t *testing.T) {
+	store := newImportTestStore(t)
+	store.SetEphemeral(true)
+	if _, err := NewImporter(store).Import(strings.NewReader(importFixture), ImportOptions{}); err == nil {
+		t.Fatal("expected import into an ephemeral store to be refused")
+	}
+	store.SetEphemeral(false)
+	if sess, err := store.GetSession("orig-session"); err != nil || sess != nil {
+		t.Fatalf("ephemeral import wrote session %+v (err=%v)", sess, err)
+	}
+}
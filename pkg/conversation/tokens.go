@@ -1,42 +1,179 @@
 package conversation
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/pkoukk/tiktoken-go"
 )
 
+// Tokenizer names accepted by SetTokenizer and CountTokensWith.
+const (
+	// TokenizerAuto picks a tokenizer from the model family; see ResolveTokenizer.
+	TokenizerAuto = "auto"
+	// TokenizerCL100K is used by GPT-4 and GPT-3.5 and is the default.
+	TokenizerCL100K = "cl100k_base"
+	// TokenizerO200K is used by GPT-4o, GPT-4.1, GPT-5 and the o-series.
+	TokenizerO200K = "o200k_base"
+	// TokenizerP50K is used by older Codex and davinci completion models.
+	TokenizerP50K = "p50k_base"
+	// TokenizerR50K is used by GPT-3 era models.
+	TokenizerR50K = "r50k_base"
+	// TokenizerEstimate skips BPE and counts roughly four characters per token.
+	TokenizerEstimate = "estimate"
+
+	// DefaultTokenizer is used until SetTokenizer picks another.
+	DefaultTokenizer = TokenizerCL100K
+)
+
+// errEstimateOnly marks the estimate tokenizer, which has no BPE encoder.
+var errEstimateOnly = errors.New("estimate tokenizer has no encoder")
+
 var (
-	// tokenEncoder is the global tiktoken encoder
-	tokenEncoder *tiktoken.Tiktoken
-	encoderOnce  sync.Once
-	encoderErr   error
+	// encoders caches tiktoken encoders, and the error from loading one, by name.
+	encoders    = make(map[string]*tiktoken.Tiktoken)
+	encoderErrs = make(map[string]error)
+	encodersMu  sync.Mutex
+
+	activeTokenizer   = DefaultTokenizer
+	activeTokenizerMu sync.RWMutex
 )
 
-// initTokenEncoder initializes the tiktoken encoder (lazy initialization)
+// SetTokenizer selects the tokenizer used by CountTokens and
+// CountTokensForMessages. Use ResolveTokenizer to turn a configured value,
+// including "auto", into a name.
+func SetTokenizer(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !knownTokenizer(name) {
+		return fmt.Errorf("unknown tokenizer %q (valid: %s, %s, %s, %s, %s)", name, TokenizerCL100K, TokenizerO200K, TokenizerP50K, TokenizerR50K, TokenizerEstimate)
+	}
+	activeTokenizerMu.Lock()
+	activeTokenizer = name
+	activeTokenizerMu.Unlock()
+	return nil
+}
+
+// ActiveTokenizer returns the tokenizer CountTokens currently uses.
+func ActiveTokenizer() string {
+	activeTokenizerMu.RLock()
+	defer activeTokenizerMu.RUnlock()
+	return activeTokenizer
+}
+
+// ResolveTokenizer returns the tokenizer for a configured setting. An empty
+// setting or "auto" is resolved from modelID with TokenizerForModel.
+func ResolveTokenizer(setting, modelID string) string {
+	setting = strings.ToLower(strings.TrimSpace(setting))
+	if setting == "" || setting == TokenizerAuto {
+		return TokenizerForModel(modelID)
+	}
+	return setting
+}
+
+// TokenizerForModel picks the tokenizer matching a model's family. OpenAI
+// models map to their published encodings; other families have no local
+// tokenizer and use cl100k_base, the closest general-purpose BPE.
+func TokenizerForModel(modelID string) string {
+	name := strings.ToLower(strings.TrimSpace(modelID))
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	switch {
+	case hasAnyPrefix(name, "gpt-4o", "chatgpt-4o", "gpt-4.1", "gpt-4.5", "gpt-5", "gpt-oss", "o1", "o3", "o4", "codex-"):
+		return TokenizerO200K
+	case hasAnyPrefix(name, "gpt-4", "gpt-3.5", "text-embedding-3", "text-embedding-ada"):
+		return TokenizerCL100K
+	case hasAnyPrefix(name, "text-davinci-002", "text-davinci-003", "code-davinci", "code-cushman"):
+		return TokenizerP50K
+	default:
+		return DefaultTokenizer
+	}
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func knownTokenizer(name string) bool {
+	switch name {
+	case TokenizerCL100K, TokenizerO200K, TokenizerP50K, TokenizerR50K, TokenizerEstimate:
+		return true
+	default:
+		return false
+	}
+}
+
+// encoderFor loads the named tiktoken encoder once and caches the result,
+// including a failure so an offline machine does not retry every call.
+func encoderFor(name string) (*tiktoken.Tiktoken, error) {
+	if name == TokenizerEstimate {
+		return nil, errEstimateOnly
+	}
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	if enc, ok := encoders[name]; ok {
+		return enc, nil
+	}
+	if err, ok := encoderErrs[name]; ok {
+		return nil, err
+	}
+	enc, err := tiktoken.GetEncoding(name)
+	if err != nil {
+		encoderErrs[name] = err
+		return nil, err
+	}
+	encoders[name] = enc
+	return enc, nil
+}
+
+// initTokenEncoder loads the active tokenizer's encoder and reports whether
+// it is usable.
 func initTokenEncoder() error {
-	encoderOnce.Do(func() {
-		// Use cl100k_base encoding (GPT-4, GPT-3.5-turbo, text-embedding-ada-002)
-		tokenEncoder, encoderErr = tiktoken.GetEncoding("cl100k_base")
-	})
-	return encoderErr
+	_, err := encoderFor(ActiveTokenizer())
+	return err
 }
 
-// CountTokens counts the number of tokens in a text using tiktoken
+// CountTokens counts the number of tokens in a text using the active tokenizer
 func CountTokens(text string) int {
-	if err := initTokenEncoder(); err != nil {
+	enc, err := encoderFor(ActiveTokenizer())
+	if err != nil {
 		// Fallback to estimation if tiktoken fails
 		return estimateTokens(text)
 	}
 
-	tokens := tokenEncoder.Encode(text, nil, nil)
+	tokens := enc.Encode(text, nil, nil)
 	return len(tokens)
 }
 
+// CountTokensWith counts tokens in text with the named tokenizer, reporting
+// an error instead of estimating when its encoder cannot be loaded.
+func CountTokensWith(tokenizer, text string) (int, error) {
+	tokenizer = strings.ToLower(strings.TrimSpace(tokenizer))
+	if !knownTokenizer(tokenizer) {
+		return 0, fmt.Errorf("unknown tokenizer %q", tokenizer)
+	}
+	enc, err := encoderFor(tokenizer)
+	if errors.Is(err, errEstimateOnly) {
+		return estimateTokens(text), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("load %s tokenizer: %w", tokenizer, err)
+	}
+	return len(enc.Encode(text, nil, nil)), nil
+}
+
 // CountTokensForMessages counts tokens for a list of messages
 // This accounts for message formatting overhead
 func CountTokensForMessages(messages []Message) int {
-	if err := initTokenEncoder(); err != nil {
+	enc, err := encoderFor(ActiveTokenizer())
+	if err != nil {
 		// Fallback to estimation
 		total := 0
 		for _, msg := range messages {
@@ -54,10 +191,10 @@ func CountTokensForMessages(messages []Message) int {
 		total += 4
 
 		// Role tokens
-		total += len(tokenEncoder.Encode(msg.Role, nil, nil))
+		total += len(enc.Encode(msg.Role, nil, nil))
 
 		// Content tokens
-		total += len(tokenEncoder.Encode(GetContentAsString(msg.Content), nil, nil))
+		total += len(enc.Encode(GetContentAsString(msg.Content), nil, nil))
 	}
 
 	// Add 2 tokens for the overall structure
@@ -86,3 +223,42 @@ func (c *Conversation) UpdateAllTokens() {
 func (c *Conversation) GetAccurateTokenCount() int {
 	return CountTokensForMessages(c.Messages)
 }
+
+// RecordPromptUsage stores the prompt token count the provider reported for a
+// request built from the current messages. Later budget checks start from
+// this figure instead of the local estimate.
+func (c *Conversation) RecordPromptUsage(promptTokens int) {
+	if c == nil || promptTokens <= 0 {
+		return
+	}
+	c.RecordedPromptTokens = promptTokens
+	c.RecordedMessageCount = len(c.Messages)
+}
+
+// BudgetTokenCount returns the best known prompt size for the conversation:
+// the last provider-reported prompt tokens plus estimates for messages added
+// since. Without recorded usage it falls back to TokenCount.
+func (c *Conversation) BudgetTokenCount() int {
+	if c == nil {
+		return 0
+	}
+	if c.RecordedPromptTokens <= 0 || c.RecordedMessageCount > len(c.Messages) {
+		return c.TokenCount
+	}
+	total := c.RecordedPromptTokens
+	for _, msg := range c.Messages[c.RecordedMessageCount:] {
+		if msg.Tokens == 0 {
+			total += estimateTokens(GetContentAsString(msg.Content))
+			continue
+		}
+		total += msg.Tokens
+	}
+	return total
+}
+
+// resetRecordedUsage drops recorded provider usage once the messages it
+// described have been replaced.
+func (c *Conversation) resetRecordedUsage() {
+	c.RecordedPromptTokens = 0
+	c.RecordedMessageCount = 0
+}
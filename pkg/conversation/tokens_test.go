@@ -253,3 +253,165 @@ func TestTokenCountingSpecialCharacters(t *testing.T) {
 		}
 	}
 }
+
+func TestBudgetTokenCountFallsBackToEstimate(t *testing.T) {
+	conv := New("test")
+	conv.AddUserMessage("Hello there")
+	conv.AddAssistantMessage("Hi, how can I help?")
+
+	if got := conv.BudgetTokenCount(); got != conv.TokenCount {
+		t.Errorf("BudgetTokenCount() = %d, want estimate %d without recorded usage", got, conv.TokenCount)
+	}
+}
+
+func TestBudgetTokenCountUsesRecordedUsage(t *testing.T) {
+	conv := New("test")
+	conv.AddSystemMessage("You are a helpful assistant.")
+	conv.AddUserMessage("Summarize the repository layout.")
+	estimated := conv.TokenCount
+
+	// Providers count the system prompt, tool schemas and message framing that
+	// the local estimate never sees.
+	recorded := estimated + 1200
+	conv.RecordPromptUsage(recorded)
+	if got := conv.BudgetTokenCount(); got != recorded {
+		t.Fatalf("BudgetTokenCount() = %d, want recorded %d", got, recorded)
+	}
+	if conv.TokenCount != estimated {
+		t.Fatalf("TokenCount = %d, recording usage should not rewrite the estimate %d", conv.TokenCount, estimated)
+	}
+
+	conv.AddAssistantMessage("The repository has cmd, pkg and docs directories.")
+	added := conv.Messages[len(conv.Messages)-1].Tokens
+	if got, want := conv.BudgetTokenCount(), recorded+added; got != want {
+		t.Errorf("BudgetTokenCount() = %d, want recorded plus new message estimate %d", got, want)
+	}
+	if got := conv.BudgetTokenCount() - conv.TokenCount; got != 1200 {
+		t.Errorf("budget drift from estimate = %d, want 1200", got)
+	}
+
+	// The next call reports the real size of everything sent so far.
+	conv.AddUserMessage("And the tests?")
+	conv.RecordPromptUsage(recorded + 40)
+	if got := conv.BudgetTokenCount(); got != recorded+40 {
+		t.Errorf("BudgetTokenCount() = %d, want latest recorded %d", got, recorded+40)
+	}
+}
+
+func TestRecordPromptUsageIgnoresMissingUsage(t *testing.T) {
+	conv := New("test")
+	conv.AddUserMessage("Hello")
+	conv.RecordPromptUsage(500)
+	conv.RecordPromptUsage(0)
+
+	if conv.RecordedPromptTokens != 500 {
+		t.Errorf("RecordedPromptTokens = %d, want 500 kept when provider omits usage", conv.RecordedPromptTokens)
+	}
+}
+
+func TestRecordedUsageResetWhenMessagesReplaced(t *testing.T) {
+	conv := New("test")
+	conv.AddUserMessage("Hello")
+	conv.AddAssistantMessage("Hi")
+	conv.RecordPromptUsage(900)
+
+	conv.Messages = conv.Messages[:1]
+	if got := conv.BudgetTokenCount(); got != conv.TokenCount {
+		t.Errorf("BudgetTokenCount() = %d, want estimate %d once recorded messages are gone", got, conv.TokenCount)
+	}
+
+	conv.RecordPromptUsage(900)
+	conv.Clear()
+	if conv.RecordedPromptTokens != 0 || conv.RecordedMessageCount != 0 {
+		t.Errorf("Clear() left recorded usage %d/%d", conv.RecordedPromptTokens, conv.RecordedMessageCount)
+	}
+}
+
+func TestTokenizerForModel(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{model: "openai/gpt-4o", want: TokenizerO200K},
+		{model: "openrouter/openai/gpt-4.1-mini", want: TokenizerO200K},
+		{model: "openai/gpt-5.4-mini", want: TokenizerO200K},
+		{model: "openai/o3-mini", want: TokenizerO200K},
+		{model: "openai/gpt-4-turbo", want: TokenizerCL100K},
+		{model: "gpt-3.5-turbo", want: TokenizerCL100K},
+		{model: "text-davinci-003", want: TokenizerP50K},
+		{model: "anthropic/claude-sonnet-4-5", want: DefaultTokenizer},
+		{model: "", want: DefaultTokenizer},
+	}
+	for _, tt := range tests {
+		if got := TokenizerForModel(tt.model); got != tt.want {
+			t.Errorf("TokenizerForModel(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestResolveTokenizer(t *testing.T) {
+	if got := ResolveTokenizer("", "openai/gpt-4o"); got != TokenizerO200K {
+		t.Errorf("empty setting = %q, want %q", got, TokenizerO200K)
+	}
+	if got := ResolveTokenizer(" Auto ", "openai/gpt-4"); got != TokenizerCL100K {
+		t.Errorf("auto setting = %q, want %q", got, TokenizerCL100K)
+	}
+	if got := ResolveTokenizer("estimate", "openai/gpt-4o"); got != TokenizerEstimate {
+		t.Errorf("pinned setting = %q, want %q", got, TokenizerEstimate)
+	}
+}
+
+func TestSetTokenizerSelectsCountingMethod(t *testing.T) {
+	t.Cleanup(func() {
+		if err := SetTokenizer(DefaultTokenizer); err != nil {
+			t.Fatalf("restore tokenizer: %v", err)
+		}
+	})
+
+	if err := SetTokenizer("sentencepiece"); err == nil {
+		t.Fatal("expected unknown tokenizer to be rejected")
+	}
+	if got := ActiveTokenizer(); got != DefaultTokenizer {
+		t.Fatalf("ActiveTokenizer after rejected set = %q, want %q", got, DefaultTokenizer)
+	}
+
+	if err := SetTokenizer(TokenizerEstimate); err != nil {
+		t.Fatalf("SetTokenizer(estimate): %v", err)
+	}
+	text := "The quick brown fox jumps over the lazy dog"
+	if got, want := CountTokens(text), estimateTokens(text); got != want {
+		t.Fatalf("CountTokens with estimate = %d, want %d", got, want)
+	}
+}
+
+func TestCountTokensWithComparesTokenizers(t *testing.T) {
+	sample := "Budget math: こんにちは世界 — résumé naïve café, func main() { fmt.Println(\"hi\") }"
+
+	estimate, err := CountTokensWith(TokenizerEstimate, sample)
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if estimate != len(sample)/4 {
+		t.Fatalf("estimate = %d, want %d", estimate, len(sample)/4)
+	}
+	if _, err := CountTokensWith("sentencepiece", sample); err == nil {
+		t.Fatal("expected unknown tokenizer to be rejected")
+	}
+
+	cl100k, err := CountTokensWith(TokenizerCL100K, sample)
+	if err != nil {
+		t.Skipf("cl100k_base unavailable: %v", err)
+	}
+	o200k, err := CountTokensWith(TokenizerO200K, sample)
+	if err != nil {
+		t.Skipf("o200k_base unavailable: %v", err)
+	}
+	if cl100k <= 0 || o200k <= 0 {
+		t.Fatalf("counts = cl100k %d, o200k %d, want positive", cl100k, o200k)
+	}
+	// o200k_base has a larger vocabulary and needs fewer tokens for
+	// non-English text.
+	if o200k > cl100k {
+		t.Fatalf("o200k_base = %d tokens, want no more than cl100k_base's %d", o200k, cl100k)
+	}
+}
@@ -0,0 +1,74 @@
+package cost
+
+import (
+	"errors"
+	"fmt"
+
+	"m31labs.dev/buckley/pkg/model"
+)
+
+// ErrSpendCapExceeded is returned when a request would push spend past a cap.
+var ErrSpendCapExceeded = errors.New("spend cap exceeded")
+
+// SpendCap is a model.RequestGuard that refuses a request once the tracked
+// session cost plus the estimated cost of that request would exceed a limit.
+// Actual usage is recorded on the tracker after every call, so the estimate
+// only has to cover the request about to be sent.
+type SpendCap struct {
+	tracker *Tracker
+	limit   float64
+}
+
+// NewSpendCap returns a guard that caps tracker's session cost at limit
+// dollars. A non-positive limit disables the cap.
+func NewSpendCap(tracker *Tracker, limit float64) *SpendCap {
+	return &SpendCap{tracker: tracker, limit: normalizeBudget(limit)}
+}
+
+// Limit returns the cap in dollars.
+func (sc *SpendCap) Limit() float64 {
+	if sc == nil {
+		return 0
+	}
+	return sc.limit
+}
+
+// Tracker returns the tracker spend is recorded on.
+func (sc *SpendCap) Tracker() *Tracker {
+	if sc == nil {
+		return nil
+	}
+	return sc.tracker
+}
+
+// Spent returns the actual cost recorded against the cap so far.
+func (sc *SpendCap) Spent() float64 {
+	if sc == nil || sc.tracker == nil {
+		return 0
+	}
+	return sc.tracker.GetSessionCost()
+}
+
+// BeforeRequest refuses req when the spend so far plus its estimated cost
+// would exceed the cap.
+func (sc *SpendCap) BeforeRequest(req model.ChatRequest) error {
+	if sc == nil || sc.tracker == nil || sc.limit <= 0 {
+		return nil
+	}
+	spent := sc.tracker.GetSessionCost()
+	estimate := sc.tracker.EstimateRequestCost(req)
+	if spent+estimate > sc.limit {
+		return fmt.Errorf("%w: spent $%.4f, next call to %s estimated at $%.4f, cap $%.4f",
+			ErrSpendCapExceeded, spent, req.Model, estimate, sc.limit)
+	}
+	return nil
+}
+
+// AfterResponse records the actual cost of a completed call.
+func (sc *SpendCap) AfterResponse(modelID string, usage model.Usage) {
+	if sc == nil || sc.tracker == nil {
+		return
+	}
+	// Persistence failures still update the in-memory total the cap reads.
+	_, _ = sc.tracker.RecordAPICall(modelID, usage.PromptTokens, usage.CompletionTokens)
+}
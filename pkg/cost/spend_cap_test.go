@@ -0,0 +1,64 @@
+package cost
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"m31labs.dev/buckley/pkg/model"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestSpendCapAbortsMultiCallRun(t *testing.T) {
+	tracker, store, calc := newTrackerWithMocks(t, &storage.Session{ID: "s"}, 0, 0)
+	calc.EXPECT().CalculateCostFromTokens("m", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, prompt, completion int) (float64, error) {
+			return float64(prompt)*0.00001 + float64(completion)*0.00002, nil
+		}).AnyTimes()
+	store.EXPECT().SaveAPICall(gomock.Any()).Return(nil).Times(2)
+
+	// Each call reports $0.02 of actual usage. The third call would start at
+	// $0.04 and may produce 500 more output tokens ($0.01), which breaks a
+	// 5¢ cap before the request is sent.
+	spendCap := NewSpendCap(tracker, 0.05)
+	req := model.ChatRequest{
+		Model:     "m",
+		Messages:  []model.Message{{Role: "user", Content: "fix the failing test"}},
+		MaxTokens: 500,
+	}
+	completed := 0
+	var runErr error
+	for range 5 {
+		if runErr = spendCap.BeforeRequest(req); runErr != nil {
+			break
+		}
+		spendCap.AfterResponse("m", model.Usage{PromptTokens: 1000, CompletionTokens: 500})
+		completed++
+	}
+
+	if !errors.Is(runErr, ErrSpendCapExceeded) {
+		t.Fatalf("run error = %v, want ErrSpendCapExceeded", runErr)
+	}
+	if completed != 2 {
+		t.Fatalf("completed %d calls before the cap, want 2", completed)
+	}
+	if math.Abs(spendCap.Spent()-0.04) > 1e-9 {
+		t.Fatalf("Spent() = %v, want 0.04", spendCap.Spent())
+	}
+}
+
+func TestSpendCapDisabledAndUnpricedRequests(t *testing.T) {
+	tracker, _, calc := newTrackerWithMocks(t, &storage.Session{ID: "s", TotalCost: 3}, 0, 0)
+	req := model.ChatRequest{Model: "unpriced"}
+
+	if err := NewSpendCap(tracker, 0).BeforeRequest(req); err != nil {
+		t.Fatalf("zero cap should not refuse requests: %v", err)
+	}
+
+	calc.EXPECT().CalculateCostFromTokens("unpriced", gomock.Any(), gomock.Any()).Return(0.0, errors.New("no pricing"))
+	if err := NewSpendCap(tracker, 2).BeforeRequest(req); !errors.Is(err, ErrSpendCapExceeded) {
+		t.Fatalf("expected actual session spend over the cap to refuse, got %v", err)
+	}
+}
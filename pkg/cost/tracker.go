@@ -6,9 +6,14 @@ import (
 	"sync"
 	"time"
 
+	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/storage"
 )
 
+// ErrBudgetExceeded is returned when a hard stop halts work because a budget
+// was exceeded or the auto-stop threshold was reached.
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
 // costStore defines the storage operations required by the tracker.
 type costStore interface {
 	GetSession(sessionID string) (*storage.Session, error)
@@ -71,6 +76,14 @@ func New(sessionID string, store costStore, calculator CostCalculator) (*Tracker
 	return ct, nil
 }
 
+// NewRunTracker creates a tracker for a single run that is not tied to a
+// stored session. Its session cost starts at zero and covers only the calls
+// recorded on it, which are kept in memory; daily and monthly totals are
+// still loaded from store for budget checks.
+func NewRunTracker(store costStore, calculator CostCalculator) (*Tracker, error) {
+	return New("", store, calculator)
+}
+
 // SetBudgets sets the budget limits
 func (ct *Tracker) SetBudgets(session, daily, monthly, autoStop float64) {
 	ct.mu.Lock()
@@ -88,12 +101,14 @@ func (ct *Tracker) loadCosts() error {
 	defer ct.mu.Unlock()
 
 	// Get session cost
-	session, err := ct.store.GetSession(ct.sessionID)
-	if err != nil {
-		return err
-	}
-	if session != nil {
-		ct.sessionCost = session.TotalCost
+	if ct.sessionID != "" {
+		session, err := ct.store.GetSession(ct.sessionID)
+		if err != nil {
+			return err
+		}
+		if session != nil {
+			ct.sessionCost = session.TotalCost
+		}
 	}
 
 	// Get daily cost
@@ -133,6 +148,11 @@ func (ct *Tracker) RecordAPICall(modelID string, promptTokens, completionTokens
 	ct.monthlyCost += cost
 	ct.mu.Unlock()
 
+	// Run trackers have no session row to attach the call to.
+	if ct.sessionID == "" {
+		return cost, nil
+	}
+
 	// Save to database
 	apiCall := &storage.APICall{
 		SessionID:        ct.sessionID,
@@ -173,6 +193,24 @@ func (ct *Tracker) EstimateStreamingCost(modelID string, streamingTokens int) fl
 	return cost
 }
 
+// EstimateRequestCost estimates the cost of sending req, pricing its input
+// footprint as prompt tokens and any requested output limit as completion
+// tokens.
+func (ct *Tracker) EstimateRequestCost(req model.ChatRequest) float64 {
+	if ct.costCalc == nil {
+		return 0
+	}
+	completionTokens := req.MaxCompletionTokens
+	if completionTokens <= 0 {
+		completionTokens = req.MaxTokens
+	}
+	cost, err := ct.costCalc.CalculateCostFromTokens(req.Model, model.EstimateRequestTokens(req).Total, completionTokens)
+	if err != nil {
+		return 0 // Unpriced models are capped on actual usage only
+	}
+	return cost
+}
+
 // GetDailyCost returns the current daily cost
 func (ct *Tracker) GetDailyCost() float64 {
 	ct.mu.RLock()
@@ -279,6 +317,25 @@ func (bs *BudgetStatus) GetWarningMessage() string {
 	return ""
 }
 
+// Err returns ErrBudgetExceeded, wrapped with the limit that was hit, when a
+// session, daily or monthly budget is exceeded or auto-stop was reached.
+func (bs *BudgetStatus) Err() error {
+	if bs == nil {
+		return nil
+	}
+	switch {
+	case bs.SessionExceeded:
+		return fmt.Errorf("%w: session $%.2f of $%.2f", ErrBudgetExceeded, bs.SessionCost, bs.SessionBudget)
+	case bs.ShouldStop:
+		return fmt.Errorf("%w: session $%.2f reached the auto-stop threshold", ErrBudgetExceeded, bs.SessionCost)
+	case bs.DailyExceeded:
+		return fmt.Errorf("%w: daily $%.2f of $%.2f", ErrBudgetExceeded, bs.DailyCost, bs.DailyBudget)
+	case bs.MonthlyExceeded:
+		return fmt.Errorf("%w: monthly $%.2f of $%.2f", ErrBudgetExceeded, bs.MonthlyCost, bs.MonthlyBudget)
+	}
+	return nil
+}
+
 func budgetPercent(current, limit float64) float64 {
 	if limit <= 0 {
 		return 0
@@ -2,6 +2,7 @@ package cost
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -73,6 +74,50 @@ func TestUnlimitedBudgetsNeverWarn(t *testing.T) {
 	}
 }
 
+func TestRunTrackerKeepsSpendInMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := NewMockcostStore(ctrl)
+	calc := NewMockCostCalculator(ctrl)
+	// No GetSession or SaveAPICall: a run has no stored session.
+	store.EXPECT().GetDailyCost().Return(3.0, nil)
+	store.EXPECT().GetMonthlyCost().Return(7.0, nil)
+	calc.EXPECT().CalculateCostFromTokens("m", 100, 50).Return(0.5, nil).Times(2)
+
+	tracker, err := NewRunTracker(store, calc)
+	if err != nil {
+		t.Fatalf("NewRunTracker: %v", err)
+	}
+	for range 2 {
+		if _, err := tracker.RecordAPICall("m", 100, 50); err != nil {
+			t.Fatalf("RecordAPICall: %v", err)
+		}
+	}
+	if tracker.GetSessionCost() != 1 || tracker.GetDailyCost() != 4 || tracker.GetMonthlyCost() != 8 {
+		t.Fatalf("costs = %v/%v/%v, want run 1, daily 4, monthly 8",
+			tracker.GetSessionCost(), tracker.GetDailyCost(), tracker.GetMonthlyCost())
+	}
+}
+
+func TestBudgetStatusErr(t *testing.T) {
+	tracker, _, _ := newTrackerWithMocks(t, &storage.Session{ID: "s", TotalCost: 1}, 25, 50)
+	tracker.SetBudgets(10, 20, 100, 0)
+
+	err := tracker.CheckBudget().Err()
+	if !errors.Is(err, ErrBudgetExceeded) || !strings.Contains(err.Error(), "daily") {
+		t.Fatalf("Err() = %v, want daily budget exceeded", err)
+	}
+
+	tracker.SetBudgets(10, 0, 0, 1)
+	if err := tracker.CheckBudget().Err(); !errors.Is(err, ErrBudgetExceeded) || !strings.Contains(err.Error(), "auto-stop") {
+		t.Fatalf("Err() = %v, want auto-stop", err)
+	}
+
+	tracker.SetBudgets(10, 0, 0, 0)
+	if err := tracker.CheckBudget().Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil under budget", err)
+	}
+}
+
 func TestEstimateStreamingCostNilCalculator(t *testing.T) {
 	tracker, _, _ := newTrackerWithMocks(t, &storage.Session{ID: "s"}, 0, 0)
 	// Simulate calculator becoming unavailable
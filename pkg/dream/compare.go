@@ -0,0 +1,43 @@
+package dream
+
+// GapComparison splits gaps into those introduced, resolved, and still open
+// between a base analysis and the current one
+type GapComparison struct {
+	New        []Gap
+	Resolved   []Gap
+	Persistent []Gap
+}
+
+// CompareGaps compares the gaps of a base analysis with the current ones.
+// Gaps are matched by category and suggestion, since descriptions can carry
+// figures (file counts, ratios) that change between runs. Persistent gaps
+// use the current description.
+func CompareGaps(base, current []Gap) GapComparison {
+	var comparison GapComparison
+
+	baseKeys := make(map[string]bool, len(base))
+	for _, gap := range base {
+		baseKeys[gapKey(gap)] = true
+	}
+	currentKeys := make(map[string]bool, len(current))
+	for _, gap := range current {
+		key := gapKey(gap)
+		currentKeys[key] = true
+		if baseKeys[key] {
+			comparison.Persistent = append(comparison.Persistent, gap)
+		} else {
+			comparison.New = append(comparison.New, gap)
+		}
+	}
+	for _, gap := range base {
+		if !currentKeys[gapKey(gap)] {
+			comparison.Resolved = append(comparison.Resolved, gap)
+		}
+	}
+
+	return comparison
+}
+
+func gapKey(gap Gap) string {
+	return gap.Category + "\x00" + gap.Suggestion
+}
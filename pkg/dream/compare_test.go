@@ -0,0 +1,22 @@
+package dream
+
+import "testing"
+
+func TestCompareGaps(t *testing.T) {
+	testing1 := Gap{Category: "testing", Description: "Low test coverage: 2 test files out of 40 total files (5%)", Suggestion: "Add tests"}
+	testing2 := Gap{Category: "testing", Description: "Low test coverage: 9 test files out of 45 total files (20%)", Suggestion: "Add tests"}
+	readme := Gap{Category: "docs", Description: "No README.md found", Suggestion: "Add README.md"}
+	ci := Gap{Category: "automation", Description: "No CI/CD configuration found", Suggestion: "Add CI"}
+
+	got := CompareGaps([]Gap{testing1, readme}, []Gap{testing2, ci})
+
+	if len(got.New) != 1 || got.New[0] != ci {
+		t.Errorf("New = %+v, want the CI gap", got.New)
+	}
+	if len(got.Resolved) != 1 || got.Resolved[0] != readme {
+		t.Errorf("Resolved = %+v, want the README gap", got.Resolved)
+	}
+	if len(got.Persistent) != 1 || got.Persistent[0] != testing2 {
+		t.Errorf("Persistent = %+v, want the testing gap with the current description", got.Persistent)
+	}
+}
@@ -19,6 +19,17 @@ func NewCache(dir string) *Cache {
 	return &Cache{dir: dir}
 }
 
+// CacheStats summarizes the on-disk footprint of the cache.
+type CacheStats struct {
+	Entries int
+	Bytes   int64
+}
+
+// Dir returns the directory backing the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
 // Get retrieves a cached embedding
 func (c *Cache) Get(key string) ([]float64, bool) {
 	c.mu.RLock()
@@ -70,3 +81,32 @@ func (c *Cache) Clear() error {
 
 	return nil
 }
+
+// Stats reports the number of cached embeddings and their total size on disk.
+func (c *Cache) Stats() (CacheStats, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var stats CacheStats
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stats, nil
+		}
+		return stats, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += info.Size()
+	}
+
+	return stats, nil
+}
@@ -129,6 +129,34 @@ func TestCache_Clear(t *testing.T) {
 	}
 }
 
+func TestCache_Stats(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewCache(tmpDir)
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+
+	cache.Set("key1", []float64{1.0})
+	cache.Set("key2", []float64{2.0, 3.0})
+	os.WriteFile(filepath.Join(tmpDir, "notes.txt"), []byte("ignored"), 0644)
+
+	stats, err = cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Entries != 2 {
+		t.Errorf("Expected 2 entries, got %d", stats.Entries)
+	}
+	if stats.Bytes <= 0 {
+		t.Errorf("Expected positive byte count, got %d", stats.Bytes)
+	}
+}
+
 func TestCache_ConcurrentAccess(t *testing.T) {
 	tmpDir := t.TempDir()
 	cache := NewCache(tmpDir)
@@ -9,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"m31labs.dev/buckley/pkg/paths"
 )
 
 // Service provides text embedding capabilities
@@ -24,7 +26,19 @@ type ServiceOptions struct {
 	Model    string
 	Provider ProviderKind
 	BaseURL  string
+	// CacheDir defaults to paths.BuckleyEmbeddingsCacheDir, the directory
+	// `buckley embeddings cache` inspects and clears.
 	CacheDir string
+	// Store, when set, persists embeddings alongside the cache directory so
+	// they survive cache wipes and are included in database backups.
+	Store EmbeddingStore
+}
+
+// EmbeddingStore persists embeddings keyed by content hash and model.
+// *storage.Store satisfies it.
+type EmbeddingStore interface {
+	GetCachedEmbedding(ctx context.Context, contentHash, model string) ([]byte, error)
+	SaveCachedEmbedding(ctx context.Context, contentHash, model string, embedding []byte) error
 }
 
 type Service struct {
@@ -34,10 +48,15 @@ type Service struct {
 	provider   ProviderKind
 	httpClient *http.Client
 	cache      *Cache
+	store      EmbeddingStore
 }
 
 // NewService creates a new embedding service backed by the configured provider.
 func NewService(opts ServiceOptions) *Service {
+	cacheDir := opts.CacheDir
+	if cacheDir == "" {
+		cacheDir = paths.BuckleyEmbeddingsCacheDir()
+	}
 	service := &Service{
 		apiKey:   opts.APIKey,
 		model:    opts.Model,
@@ -45,7 +64,8 @@ func NewService(opts ServiceOptions) *Service {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		cache: NewCache(opts.CacheDir),
+		cache: NewCache(cacheDir),
+		store: opts.Store,
 	}
 
 	switch opts.Provider {
@@ -84,8 +104,7 @@ func (s *Service) SetModel(model string) {
 // Embed generates an embedding vector for the given text
 func (s *Service) Embed(ctx context.Context, text string) ([]float64, error) {
 	// Check cache first
-	cacheKey := s.computeCacheKey(text)
-	if cached, ok := s.cache.Get(cacheKey); ok {
+	if cached, ok := s.lookupCached(ctx, text); ok {
 		return cached, nil
 	}
 
@@ -96,7 +115,7 @@ func (s *Service) Embed(ctx context.Context, text string) ([]float64, error) {
 	}
 
 	// Cache result
-	s.cache.Set(cacheKey, embedding)
+	s.remember(ctx, text, embedding)
 
 	return embedding, nil
 }
@@ -112,8 +131,7 @@ func (s *Service) EmbedBatch(ctx context.Context, texts []string) ([][]float64,
 	missingIndexes := make([]int, 0, len(texts))
 
 	for i, text := range texts {
-		cacheKey := s.computeCacheKey(text)
-		if cached, ok := s.cache.Get(cacheKey); ok {
+		if cached, ok := s.lookupCached(ctx, text); ok {
 			embeddings[i] = cached
 			continue
 		}
@@ -139,7 +157,7 @@ func (s *Service) EmbedBatch(ctx context.Context, texts []string) ([][]float64,
 			return nil, fmt.Errorf("embedding response missing vector at index %d", i)
 		}
 		embeddings[idx] = embedding
-		_ = s.cache.Set(s.computeCacheKey(missingTexts[i]), embedding)
+		s.remember(ctx, missingTexts[i], embedding)
 	}
 
 	return embeddings, nil
@@ -279,7 +297,48 @@ func (s *Service) computeCacheKey(text string) string {
 	return fmt.Sprintf("%x", hash)[:32]
 }
 
-// ClearCache clears the embedding cache
+// lookupCached returns a cached embedding for text, checking the cache
+// directory before the store. Store hits are copied back to the directory.
+func (s *Service) lookupCached(ctx context.Context, text string) ([]float64, bool) {
+	cacheKey := s.computeCacheKey(text)
+	if cached, ok := s.cache.Get(cacheKey); ok {
+		return cached, true
+	}
+	if s.store == nil {
+		return nil, false
+	}
+	data, err := s.store.GetCachedEmbedding(ctx, contentHash(text), s.model)
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	embedding, err := deserializeEmbedding(data)
+	if err != nil {
+		return nil, false
+	}
+	_ = s.cache.Set(cacheKey, embedding)
+	return embedding, true
+}
+
+// remember writes a computed embedding through to the cache directory and,
+// when configured, the store. Failures only cost a later recompute.
+func (s *Service) remember(ctx context.Context, text string, embedding []float64) {
+	_ = s.cache.Set(s.computeCacheKey(text), embedding)
+	if s.store == nil {
+		return
+	}
+	if data, err := serializeEmbedding(embedding); err == nil {
+		_ = s.store.SaveCachedEmbedding(ctx, contentHash(text), s.model, data)
+	}
+}
+
+// contentHash identifies text in the embedding store.
+func contentHash(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return fmt.Sprintf("%x", hash)
+}
+
+// ClearCache clears the embedding cache directory. Embeddings kept in the
+// store are left in place.
 func (s *Service) ClearCache() error {
 	return s.cache.Clear()
 }
@@ -3,7 +3,12 @@ package embeddings
 import (
 	"context"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+
+	"m31labs.dev/buckley/pkg/paths"
 )
 
 func TestNewService(t *testing.T) {
@@ -31,6 +36,15 @@ func TestNewService(t *testing.T) {
 	}
 }
 
+func TestNewServiceDefaultsToSharedCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(paths.EnvBuckleyEmbeddingsCacheDir, dir)
+	service := NewService(ServiceOptions{APIKey: "test-key", Provider: ProviderOpenRouter})
+	if service.cache.Dir() != dir {
+		t.Fatalf("cache dir = %q, want %q", service.cache.Dir(), dir)
+	}
+}
+
 func TestSetModel(t *testing.T) {
 	tmpDir := t.TempDir()
 	service := NewService(ServiceOptions{
@@ -212,6 +226,57 @@ func TestEmbed_Cache(t *testing.T) {
 	}
 }
 
+type memoryEmbeddingStore map[string][]byte
+
+func (m memoryEmbeddingStore) GetCachedEmbedding(_ context.Context, contentHash, model string) ([]byte, error) {
+	return m[model+"|"+contentHash], nil
+}
+
+func (m memoryEmbeddingStore) SaveCachedEmbedding(_ context.Context, contentHash, model string, embedding []byte) error {
+	m[model+"|"+contentHash] = embedding
+	return nil
+}
+
+func TestEmbedWritesThroughToStore(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"data":[{"embedding":[0.5,0.25]}]}`))
+	}))
+	defer server.Close()
+
+	store := memoryEmbeddingStore{}
+	newService := func() *Service {
+		return NewService(ServiceOptions{
+			APIKey:   "key",
+			Provider: ProviderOpenAI,
+			BaseURL:  server.URL,
+			CacheDir: t.TempDir(),
+			Store:    store,
+		})
+	}
+
+	ctx := context.Background()
+	if _, err := newService().Embed(ctx, "hello"); err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(store) != 1 {
+		t.Fatalf("store entries = %d, want 1", len(store))
+	}
+
+	// A fresh cache directory still finds the embedding in the store.
+	got, err := newService().Embed(ctx, "hello")
+	if err != nil {
+		t.Fatalf("second Embed: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("API calls = %d, want 1", calls.Load())
+	}
+	if len(got) != 2 || got[0] != 0.5 || got[1] != 0.25 {
+		t.Fatalf("embedding = %v", got)
+	}
+}
+
 func TestSerializeDeserializeEmbedding(t *testing.T) {
 	original := []float64{1.5, -2.3, 0.0, 42.7, -0.001}
 
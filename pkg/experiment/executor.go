@@ -119,6 +119,7 @@ func (e *experimentExecutor) buildRegistry(task *parallel.AgentTask, wtPath stri
 		workDir = filepath.Join(wtPath, workDir)
 	}
 	registry.SetWorkDir(workDir)
+	tool.ApplyToolMiddlewareConfig(registry, e.config)
 	if e.telemetry != nil {
 		registry.EnableTelemetry(e.telemetry, ulid.Make().String())
 	}
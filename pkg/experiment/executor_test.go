@@ -4,6 +4,9 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/parallel"
 )
 
 func TestParseTimeout(t *testing.T) {
@@ -466,3 +469,19 @@ func TestParseInt(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRegistryAppliesToolPolicy(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := config.DefaultConfig()
+	cfg.ToolMiddleware.DenyList = []string{"run_shell"}
+	cfg.Tools.Dangerous = []string{"write_file"}
+	e := &experimentExecutor{config: cfg}
+
+	registry := e.buildRegistry(&parallel.AgentTask{Context: map[string]string{}}, t.TempDir())
+	if registry.PolicyAllows("run_shell") {
+		t.Fatal("tool_middleware.deny_list was not applied to the experiment registry")
+	}
+	if !registry.RequiresApproval("write_file") {
+		t.Fatal("tools.dangerous was not applied to the experiment registry")
+	}
+}
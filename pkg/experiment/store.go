@@ -201,6 +201,42 @@ func (s *Store) UpdateExperimentStatus(id string, status ExperimentStatus, compl
 	return err
 }
 
+// FailInterruptedExperiments marks experiments and runs that are still
+// pending or running as failed and reports how many experiments changed. It
+// is meant for startup, when no runner in this process can own them.
+func (s *Store) FailInterruptedExperiments(reason string) (int, error) {
+	if s == nil || s.db == nil {
+		return 0, ErrStoreUnavailable
+	}
+	now := time.Now()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`
+		UPDATE experiment_runs
+		SET status = ?, error = COALESCE(error, ?), completed_at = ?
+		WHERE status IN (?, ?)
+	`, string(RunFailed), reason, now, string(RunPending), string(RunRunning)); err != nil {
+		return 0, err
+	}
+	res, err := tx.Exec(`
+		UPDATE experiments
+		SET status = ?, completed_at = ?
+		WHERE status IN (?, ?)
+	`, string(ExperimentFailed), now, string(ExperimentPending), string(ExperimentRunning))
+	if err != nil {
+		return 0, err
+	}
+	count, _ := res.RowsAffected()
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
 // ListExperiments returns recent experiments, optionally filtered by status.
 func (s *Store) ListExperiments(limit int, status ExperimentStatus) ([]Experiment, error) {
 	if s == nil || s.db == nil {
@@ -274,6 +274,43 @@ func TestStore_UpdateExperimentStatus(t *testing.T) {
 	}
 }
 
+func TestStore_FailInterruptedExperiments(t *testing.T) {
+	db := setupTestDB(t)
+	db.SetMaxOpenConns(1)
+	store := NewStore(db)
+
+	for _, id := range []string{"exp-pending", "exp-running", "exp-done"} {
+		exp := &Experiment{ID: id, Name: id, Task: Task{Prompt: "test"}, Variants: []Variant{{ID: id + "-v", Name: "v", ModelID: "m"}}}
+		if err := store.CreateExperiment(exp); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	if err := store.UpdateExperimentStatus("exp-running", ExperimentRunning, nil); err != nil {
+		t.Fatalf("mark running: %v", err)
+	}
+	if err := store.UpdateExperimentStatus("exp-done", ExperimentCompleted, nil); err != nil {
+		t.Fatalf("mark completed: %v", err)
+	}
+	if err := store.SaveRun(&Run{ID: "run-1", ExperimentID: "exp-running", VariantID: "exp-running-v", Status: RunRunning}); err != nil {
+		t.Fatalf("save run: %v", err)
+	}
+
+	count, err := store.FailInterruptedExperiments("interrupted")
+	if err != nil || count != 2 {
+		t.Fatalf("FailInterruptedExperiments = %d, %v; want 2", count, err)
+	}
+	for id, want := range map[string]ExperimentStatus{"exp-pending": ExperimentFailed, "exp-running": ExperimentFailed, "exp-done": ExperimentCompleted} {
+		exp, err := store.GetExperiment(id)
+		if err != nil || exp.Status != want {
+			t.Fatalf("%s = %+v, %v; want %s", id, exp, err, want)
+		}
+	}
+	run, err := store.GetRun("run-1")
+	if err != nil || run.Status != RunFailed || run.Error == nil || *run.Error != "interrupted" {
+		t.Fatalf("run = %+v, %v; want failed with the reason", run, err)
+	}
+}
+
 func TestStore_ListExperiments(t *testing.T) {
 	db := setupTestDB(t)
 	store := NewStore(db)
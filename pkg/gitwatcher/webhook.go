@@ -3,13 +3,38 @@ package gitwatcher
 import (
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 )
 
+// Provider selects how a Handler authenticates and parses webhooks.
+type Provider string
+
+const (
+	// ProviderGitHub verifies the X-Hub-Signature-256 HMAC of the body.
+	ProviderGitHub Provider = "github"
+	// ProviderGitLab compares the X-Gitlab-Token header with the secret.
+	// GitLab sends the configured secret as is; it does not sign the body.
+	ProviderGitLab Provider = "gitlab"
+)
+
+// ParseProvider accepts "github" or "gitlab"; empty means GitHub.
+func ParseProvider(value string) (Provider, error) {
+	switch Provider(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ProviderGitHub:
+		return ProviderGitHub, nil
+	case ProviderGitLab:
+		return ProviderGitLab, nil
+	default:
+		return "", fmt.Errorf("unknown webhook provider %q (use github or gitlab)", value)
+	}
+}
+
 type MergeCallback func(MergeEvent)
 type PullRequestCallback func(PullRequestEvent)
 
@@ -30,19 +55,31 @@ type PullRequestEvent struct {
 }
 
 type Handler struct {
+	provider            Provider
 	secret              string
 	callback            MergeCallback
 	pullRequestCallback PullRequestCallback
 }
 
+// NewHandler accepts GitHub merge events. When secret is set, requests
+// without a valid X-Hub-Signature-256 are rejected with 401.
 func NewHandler(secret string, callback MergeCallback) *Handler {
-	return &Handler{secret: strings.TrimSpace(secret), callback: callback}
+	return NewProviderHandler(ProviderGitHub, secret, callback)
+}
+
+// NewProviderHandler accepts merge events in the given provider's format and
+// authenticates them the way that provider does.
+func NewProviderHandler(provider Provider, secret string, callback MergeCallback) *Handler {
+	if provider == "" {
+		provider = ProviderGitHub
+	}
+	return &Handler{provider: provider, secret: strings.TrimSpace(secret), callback: callback}
 }
 
 // NewPullRequestHandler accepts signed pull_request events and invokes the
 // callback only for reviewable revisions.
 func NewPullRequestHandler(secret string, callback PullRequestCallback) *Handler {
-	return &Handler{secret: strings.TrimSpace(secret), pullRequestCallback: callback}
+	return &Handler{provider: ProviderGitHub, secret: strings.TrimSpace(secret), pullRequestCallback: callback}
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -55,7 +92,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "bad request", http.StatusBadRequest)
 		return
 	}
-	if h.secret != "" && !validateSignature(r.Header.Get("X-Hub-Signature-256"), payload, h.secret) {
+	if h.secret != "" && !h.authenticated(r, payload) {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
@@ -64,7 +101,11 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "unprocessable entity", http.StatusUnprocessableEntity)
 		return
 	}
-	go h.handleEvent(r.Header.Get("X-GitHub-Event"), event)
+	if h.provider == ProviderGitLab {
+		go h.handleGitLabEvent(event)
+	} else {
+		go h.handleEvent(r.Header.Get("X-GitHub-Event"), event)
+	}
 	w.WriteHeader(http.StatusAccepted)
 	_, _ = w.Write([]byte("ok"))
 }
@@ -95,6 +136,31 @@ func (h *Handler) handleEvent(eventName string, event map[string]any) {
 	}
 }
 
+// handleGitLabEvent reports merged merge requests from a "Merge Request
+// Hook" payload.
+func (h *Handler) handleGitLabEvent(event map[string]any) {
+	if h.callback == nil || readString(event, "object_kind") != "merge_request" {
+		return
+	}
+	if readNestedString(event, "object_attributes", "action") != "merge" {
+		return
+	}
+	repo := readNestedString(event, "project", "path_with_namespace")
+	branch := readNestedString(event, "object_attributes", "target_branch")
+	sha := readNestedString(event, "object_attributes", "merge_commit_sha")
+	if repo == "" || branch == "" {
+		return
+	}
+	h.callback(MergeEvent{Repository: repo, Branch: branch, SHA: sha})
+}
+
+func (h *Handler) authenticated(r *http.Request, payload []byte) bool {
+	if h.provider == ProviderGitLab {
+		return validateToken(r.Header.Get("X-Gitlab-Token"), h.secret)
+	}
+	return validateSignature(r.Header.Get("X-Hub-Signature-256"), payload, h.secret)
+}
+
 func isReviewablePullRequestAction(action string) bool {
 	switch action {
 	case "opened", "reopened", "ready_for_review", "synchronize":
@@ -122,6 +188,13 @@ func validateSignature(signature string, payload []byte, secret string) bool {
 	return hmac.Equal(expected, sigBytes)
 }
 
+func validateToken(token, secret string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
+
 func readString(payload map[string]any, key string) string {
 	if val, ok := payload[key].(string); ok {
 		return val
@@ -327,6 +327,86 @@ func TestReadNestedString_NonMapValue(t *testing.T) {
 	}
 }
 
+func TestHandler_Authentication(t *testing.T) {
+	payload := []byte(`{"action":"closed"}`)
+	tests := []struct {
+		name     string
+		provider Provider
+		headers  map[string]string
+		want     int
+	}{
+		{"github valid", ProviderGitHub, map[string]string{"X-Hub-Signature-256": generateSignature(payload, "secret123")}, http.StatusAccepted},
+		{"github mismatched", ProviderGitHub, map[string]string{"X-Hub-Signature-256": generateSignature(payload, "other")}, http.StatusUnauthorized},
+		{"github missing", ProviderGitHub, nil, http.StatusUnauthorized},
+		{"github ignores gitlab token", ProviderGitHub, map[string]string{"X-Gitlab-Token": "secret123"}, http.StatusUnauthorized},
+		{"gitlab valid", ProviderGitLab, map[string]string{"X-Gitlab-Token": "secret123"}, http.StatusAccepted},
+		{"gitlab mismatched", ProviderGitLab, map[string]string{"X-Gitlab-Token": "secret1234"}, http.StatusUnauthorized},
+		{"gitlab missing", ProviderGitLab, nil, http.StatusUnauthorized},
+		{"gitlab ignores github signature", ProviderGitLab, map[string]string{"X-Hub-Signature-256": generateSignature(payload, "secret123")}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewProviderHandler(tt.provider, "secret123", nil)
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(payload))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GitLabMergeCallback(t *testing.T) {
+	eventChan := make(chan MergeEvent, 1)
+	handler := NewProviderHandler(ProviderGitLab, "secret123", func(event MergeEvent) {
+		eventChan <- event
+	})
+
+	payload, _ := json.Marshal(map[string]any{
+		"object_kind": "merge_request",
+		"project": map[string]any{
+			"path_with_namespace": "group/repo",
+		},
+		"object_attributes": map[string]any{
+			"action":           "merge",
+			"target_branch":    "main",
+			"merge_commit_sha": "def456",
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(payload))
+	req.Header.Set("X-Gitlab-Token", "secret123")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	select {
+	case e := <-eventChan:
+		if e.Repository != "group/repo" || e.Branch != "main" || e.SHA != "def456" {
+			t.Errorf("unexpected event %+v", e)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("callback was not invoked")
+	}
+}
+
+func TestParseProvider(t *testing.T) {
+	for value, want := range map[string]Provider{"": ProviderGitHub, "GitHub": ProviderGitHub, " gitlab ": ProviderGitLab} {
+		got, err := ParseProvider(value)
+		if err != nil || got != want {
+			t.Errorf("ParseProvider(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseProvider("bitbucket"); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+}
+
 // Helper types and functions
 
 type brokenReader struct{}
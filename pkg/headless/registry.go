@@ -19,6 +19,7 @@ import (
 	"m31labs.dev/buckley/pkg/storage"
 	"m31labs.dev/buckley/pkg/telemetry"
 	"m31labs.dev/buckley/pkg/tool"
+	"m31labs.dev/buckley/pkg/tool/builtin"
 )
 
 // CreateSessionRequest contains parameters for creating a headless session.
@@ -54,6 +55,7 @@ type Registry struct {
 	store        *storage.Store
 	modelManager *model.Manager
 	config       *config.Config
+	liveConfig   *config.Live
 	projectRoot  string
 	telemetry    *telemetry.Hub
 	emitter      EventEmitter
@@ -81,9 +83,12 @@ func (r *Registry) HandleSessionCommand(cmd command.SessionCommand) error {
 
 // RegistryConfig configures the session registry.
 type RegistryConfig struct {
-	Store           *storage.Store
-	ModelManager    *model.Manager
-	Config          *config.Config
+	Store        *storage.Store
+	ModelManager *model.Manager
+	Config       *config.Config
+	// LiveConfig, when set, supersedes Config: each new runner starts with
+	// its current snapshot so reloaded settings apply to new sessions.
+	LiveConfig      *config.Live
 	ProjectRoot     string
 	Telemetry       *telemetry.Hub
 	Emitter         EventEmitter
@@ -109,6 +114,7 @@ func NewRegistry(cfg RegistryConfig) *Registry {
 		store:           cfg.Store,
 		modelManager:    cfg.ModelManager,
 		config:          cfg.Config,
+		liveConfig:      cfg.LiveConfig,
 		projectRoot:     strings.TrimSpace(cfg.ProjectRoot),
 		telemetry:       cfg.Telemetry,
 		emitter:         cfg.Emitter,
@@ -176,11 +182,12 @@ func (r *Registry) CreateSession(req CreateSessionRequest) (*SessionInfo, error)
 	}
 
 	// Determine model
+	cfg := r.currentConfig()
 	modelID := req.Model
-	if modelID == "" && r.config != nil {
-		modelID = r.config.Models.Execution
+	if modelID == "" && cfg != nil {
+		modelID = cfg.Models.Execution
 		if modelID == "" {
-			modelID = r.config.Models.Planning
+			modelID = cfg.Models.Planning
 		}
 	}
 
@@ -201,7 +208,7 @@ func (r *Registry) CreateSession(req CreateSessionRequest) (*SessionInfo, error)
 		return nil, fmt.Errorf("create session: %w", err)
 	}
 
-	tools := r.buildToolRegistry(sessionID, projectPath)
+	tools := r.buildToolRegistry(cfg, sessionID, projectPath)
 	if req.ToolPolicy != nil {
 		applyToolPolicy(tools, req.ToolPolicy)
 	}
@@ -221,7 +228,7 @@ func (r *Registry) CreateSession(req CreateSessionRequest) (*SessionInfo, error)
 		ModelManager:  r.modelManager,
 		Tools:         tools,
 		Store:         r.store,
-		Config:        r.config,
+		Config:        cfg,
 		Emitter:       r.emitter,
 		Telemetry:     r.telemetry,
 		IdleTimeout:   idleTimeout,
@@ -294,21 +301,22 @@ func (r *Registry) EnsureSession(sessionID string) (*Runner, error) {
 	}
 
 	idleTimeout := r.maxIdleTime
+	cfg := r.currentConfig()
 	modelID := strings.TrimSpace(sess.Model)
-	if modelID == "" && r.config != nil {
-		modelID = r.config.Models.Execution
+	if modelID == "" && cfg != nil {
+		modelID = cfg.Models.Execution
 		if modelID == "" {
-			modelID = r.config.Models.Planning
+			modelID = cfg.Models.Planning
 		}
 	}
 
-	tools := r.buildToolRegistry(sessionID, project)
+	tools := r.buildToolRegistry(cfg, sessionID, project)
 	runner, err := NewRunner(RunnerConfig{
 		Session:       sess,
 		ModelManager:  r.modelManager,
 		Tools:         tools,
 		Store:         r.store,
-		Config:        r.config,
+		Config:        cfg,
 		Emitter:       r.emitter,
 		Telemetry:     r.telemetry,
 		IdleTimeout:   idleTimeout,
@@ -326,23 +334,35 @@ func (r *Registry) EnsureSession(sessionID string) (*Runner, error) {
 	return runner, nil
 }
 
-func (r *Registry) buildToolRegistry(sessionID string, project string) *tool.Registry {
+// currentConfig returns the config a new runner starts with.
+func (r *Registry) currentConfig() *config.Config {
+	if cfg := r.liveConfig.Load(); cfg != nil {
+		return cfg
+	}
+	return r.config
+}
+
+func (r *Registry) buildToolRegistry(cfg *config.Config, sessionID string, project string) *tool.Registry {
 	tools := tool.NewRegistry()
-	tool.ApplyToolMiddlewareConfig(tools, r.config)
-	if r.config == nil || r.config.ToolMiddleware.MaxResultBytes <= 0 {
+	tool.ApplyToolMiddlewareConfig(tools, cfg)
+	if cfg == nil || cfg.ToolMiddleware.MaxResultBytes <= 0 {
 		tools.SetMaxOutputBytes(defaultHeadlessMaxOutputBytes)
 	}
-	if strings.TrimSpace(project) != "" && r.config != nil {
-		tools.ConfigureContainers(r.config, project)
+	if strings.TrimSpace(project) != "" && cfg != nil {
+		tools.ConfigureContainers(cfg, project)
 	}
 	if r.store != nil {
-		tools.SetTodoStore(&todoStoreAdapter{store: r.store})
+		if r.store.Ephemeral() {
+			tools.SetTodoStore(builtin.NewMemoryTodoStore())
+		} else {
+			tools.SetTodoStore(&todoStoreAdapter{store: r.store})
+		}
 		tools.EnableCodeIndex(r.store)
 	}
 	if r.telemetry != nil && strings.TrimSpace(sessionID) != "" {
 		tools.EnableTelemetry(r.telemetry, sessionID)
 	}
-	if r.store != nil && r.config != nil && r.config.Workflow.IncrementalApproval {
+	if r.store != nil && cfg != nil && cfg.Workflow.IncrementalApproval {
 		missionStore := mission.NewStore(r.store.DB())
 		tools.EnableMissionControl(missionStore, "buckley-headless", true, 15*time.Minute)
 		tools.UpdateMissionSession(sessionID)
@@ -588,7 +608,7 @@ func (r *Registry) cleanupWorkspace(sess *storage.Session) error {
 
 	root := strings.TrimSpace(r.projectRoot)
 	if root == "" {
-		root = config.ResolveProjectRoot(r.config)
+		root = config.ResolveProjectRoot(r.currentConfig())
 	}
 	rootAbs, err := filepath.Abs(root)
 	if err != nil {
@@ -641,7 +661,7 @@ func (r *Registry) cleanupWorkspace(sess *storage.Session) error {
 func (r *Registry) resolveProject(sessionID string, req CreateSessionRequest) (projectPath string, gitRepo string, gitBranch string, err error) {
 	root := strings.TrimSpace(r.projectRoot)
 	if root == "" {
-		root = config.ResolveProjectRoot(r.config)
+		root = config.ResolveProjectRoot(r.currentConfig())
 	}
 	project := strings.TrimSpace(req.Project)
 	if project == "" {
@@ -651,8 +671,8 @@ func (r *Registry) resolveProject(sessionID string, req CreateSessionRequest) (p
 
 	if IsGitURL(project) {
 		policy := giturl.ClonePolicy{}
-		if r.config != nil {
-			policy = r.config.GitClone
+		if cfg := r.currentConfig(); cfg != nil {
+			policy = cfg.GitClone
 		}
 		if err := giturl.ValidateCloneURL(policy, project); err != nil {
 			return "", "", "", fmt.Errorf("git clone blocked by policy: %w", err)
@@ -165,6 +165,38 @@ func TestRegistryCreateSessionAppliesToolPolicyAllowList(t *testing.T) {
 	}
 }
 
+func TestRegistryCreateSessionUsesLiveConfigSnapshot(t *testing.T) {
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "repo")
+	if err := os.MkdirAll(repoDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	createTestGitRepo(t, repoDir)
+
+	base := config.DefaultConfig()
+	live := config.NewLive(base)
+	registry := NewRegistry(RegistryConfig{
+		Store:        newTestStore(t),
+		ModelManager: newTestModelManager(t),
+		Config:       base,
+		LiveConfig:   live,
+		ProjectRoot:  root,
+	})
+	t.Cleanup(registry.Stop)
+
+	reloaded := *base
+	reloaded.Models.Execution = "reloaded/model"
+	live.Store(&reloaded)
+
+	info, err := registry.CreateSession(CreateSessionRequest{Project: repoDir})
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if info.Model != "reloaded/model" {
+		t.Fatalf("session model = %q, want the reloaded execution model", info.Model)
+	}
+}
+
 func newTestStore(t *testing.T) *storage.Store {
 	t.Helper()
 	dir := t.TempDir()
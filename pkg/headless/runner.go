@@ -207,15 +207,16 @@ func NewRunner(cfg RunnerConfig) (*Runner, error) {
 		conv = conversation.New(cfg.Session.ID)
 	}
 
-	tools := cfg.Tools
-	if tools == nil {
-		tools = tool.NewRegistry()
-	}
-
 	baseCfg := cfg.Config
 	if baseCfg == nil {
 		baseCfg = config.DefaultConfig()
 	}
+
+	tools := cfg.Tools
+	if tools == nil {
+		tools = tool.NewRegistry()
+		tool.ApplyToolMiddlewareConfig(tools, baseCfg)
+	}
 	sessionCfg := resolveSessionConfig(baseCfg, cfg.Session)
 
 	projectCtx := loadRunnerProjectContext(cfg.Session)
@@ -236,7 +237,7 @@ func NewRunner(cfg RunnerConfig) (*Runner, error) {
 
 	// Inject system prompt if this is a fresh conversation (no messages yet)
 	if len(conv.Messages) == 0 {
-		conv.AddSystemMessage(buildHeadlessSystemPrompt(cfg.SystemPrompt, cfg.AgentProfile, projectCtx, cfg.Session, evaluator))
+		conv.AddSystemMessage(buildHeadlessSystemPrompt(cfg.SystemPrompt, cfg.AgentProfile, sessionCfg.SystemPrompt.Footer, projectCtx, cfg.Session, evaluator))
 	}
 
 	// Initialize policy engine if not provided
@@ -609,6 +610,7 @@ func (r *Runner) runConversationLoop() error {
 			r.emitError("model call failed", err)
 			return err
 		}
+		r.conv.RecordPromptUsage(response.Usage.PromptTokens)
 
 		// Extract message from response
 		if len(response.Choices) == 0 {
@@ -852,9 +854,14 @@ func (r *Runner) handleToolCalls(ctx context.Context, msg model.Message) error {
 			decision = "approved"
 		}
 
-		// Execute tool with timing
+		// Execute tool with timing. A call approved above is not asked
+		// about again by the registry's dangerous-tool gate.
+		execCtx := ctx
+		if decision == "approved" {
+			execCtx = tool.WithApprovedCall(ctx)
+		}
 		startTime := time.Now()
-		result, err := r.tools.ExecuteWithContext(ctx, tc.Function.Name, args)
+		result, err := r.tools.ExecuteWithContext(execCtx, tc.Function.Name, args)
 		duration := time.Since(startTime)
 
 		// Log to audit trail
@@ -899,6 +906,7 @@ func (r *Runner) handleToolCalls(ctx context.Context, msg model.Message) error {
 		// Format result
 		resultContent := r.formatToolResult(result)
 		auditEntry.ToolOutput = truncateOutput(resultContent, 10000)
+		auditEntry.Success = result.Success
 
 		r.conv.AddToolResponseMessage(tc.ID, tc.Function.Name, resultContent)
 		r.persistLatestConversationMessage()
@@ -948,6 +956,10 @@ func (r *Runner) evaluatePolicy(toolName string, args map[string]any) policy.Eva
 
 func (r *Runner) requiresApproval(toolName string, args map[string]any) bool {
 	toolName = strings.TrimSpace(strings.ToLower(toolName))
+	// tools.dangerous overrides the approval mode and trust level.
+	if r.tools.RequiresApproval(toolName) {
+		return true
+	}
 	if toolName != "" && len(r.requiredApprovalTools) > 0 {
 		if _, ok := r.requiredApprovalTools[toolName]; ok {
 			return true
@@ -1803,10 +1815,8 @@ func (r *Runner) resolveExecutionModel() string {
 	if r == nil {
 		return ""
 	}
-	if resolved := model.ResolvePhaseModel(r.config, r.modelManager, r.rulesEngine, "execution", r.modelOverride); strings.TrimSpace(resolved) != "" {
-		return resolved
-	}
-	return "openai/gpt-4o"
+	modelID, _ := r.modelManager.ExecutionModelOrDefault(model.ResolvePhaseModel(r.config, r.modelManager, r.rulesEngine, "execution", r.modelOverride))
+	return modelID
 }
 
 type toolRiskAssessment struct {
@@ -1887,7 +1897,7 @@ func (r *Runner) approvalMode() string {
 	}
 }
 
-func buildHeadlessSystemPrompt(basePrompt string, agentProfile string, projectCtx *projectcontext.ProjectContext, sess *storage.Session, evaluator types.RuleEvaluator) string {
+func buildHeadlessSystemPrompt(basePrompt string, agentProfile string, footer string, projectCtx *projectcontext.ProjectContext, sess *storage.Session, evaluator types.RuleEvaluator) string {
 	projectRaw := ""
 	if projectCtx != nil {
 		projectRaw = projectCtx.RawContent
@@ -1907,6 +1917,7 @@ func buildHeadlessSystemPrompt(basePrompt string, agentProfile string, projectCt
 		ProjectContext: projectRaw,
 		WorkDir:        workDir,
 		RootDir:        rootDir,
+		Footer:         footer,
 		TaskType:       "coding",
 		ModelTier:      model.InferModelTier(""),
 		GTSAvailable:   binaryAvailable("gts"),
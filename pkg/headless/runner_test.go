@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/ipc/command"
 	"m31labs.dev/buckley/pkg/model"
@@ -285,8 +286,32 @@ func TestToolApprovalRequiredRespectsToolPolicyList(t *testing.T) {
 	}
 }
 
+func TestToolApprovalRequiredForDangerousToolsAtEveryTrustLevel(t *testing.T) {
+	for _, level := range []struct{ trust, mode string }{
+		{trust: "conservative"},
+		{trust: "balanced"},
+		{trust: "autonomous"},
+		{trust: "autonomous", mode: "yolo"},
+	} {
+		cfg := config.DefaultConfig()
+		cfg.Approval.Mode = level.mode
+		cfg.Orchestrator.TrustLevel = level.trust
+		cfg.Tools.Dangerous = []string{"Git_Status"}
+		tools := tool.NewEmptyRegistry()
+		tool.ApplyToolPolicyConfig(tools, cfg)
+		runner := &Runner{config: cfg, tools: tools}
+
+		if !runner.requiresApproval("git_status", nil) {
+			t.Errorf("%s/%s: dangerous git_status ran without approval", level.trust, runner.approvalMode())
+		}
+		if runner.requiresApproval("read_file", nil) {
+			t.Errorf("%s/%s: safe read_file asked for approval", level.trust, runner.approvalMode())
+		}
+	}
+}
+
 func TestBuildHeadlessSystemPromptIncludesAgentProfile(t *testing.T) {
-	prompt := buildHeadlessSystemPrompt("", "Agent: browser\nAgent Instructions:\nUse approval gates.", nil, &storage.Session{ProjectPath: "/tmp/project"}, nil)
+	prompt := buildHeadlessSystemPrompt("", "Agent: browser\nAgent Instructions:\nUse approval gates.", "", nil, &storage.Session{ProjectPath: "/tmp/project"}, nil)
 	for _, want := range []string{
 		"Agent Profile:\nAgent: browser",
 		"Agent Instructions:\nUse approval gates.",
@@ -694,3 +719,41 @@ func TestConfigValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRunnerAppliesToolPolicyToDefaultRegistry(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"read_file"}
+	runner, err := NewRunner(RunnerConfig{
+		Session:      &storage.Session{ID: "policy-session"},
+		ModelManager: newTestModelManager(t),
+		Store:        newTestStore(t),
+		Config:       cfg,
+	})
+	if err != nil {
+		t.Fatalf("NewRunner: %v", err)
+	}
+	defer runner.Stop()
+
+	if !runner.tools.RequiresApproval("read_file") {
+		t.Fatal("default registry ignored tools.dangerous")
+	}
+}
+
+func TestNewRunnerAppliesDenyListToDefaultRegistry(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ToolMiddleware.DenyList = []string{"run_shell"}
+	runner, err := NewRunner(RunnerConfig{
+		Session:      &storage.Session{ID: "deny-session"},
+		ModelManager: newTestModelManager(t),
+		Store:        newTestStore(t),
+		Config:       cfg,
+	})
+	if err != nil {
+		t.Fatalf("NewRunner: %v", err)
+	}
+	defer runner.Stop()
+
+	if runner.tools.PolicyAllows("run_shell") {
+		t.Fatal("default registry ignored tool_middleware.deny_list")
+	}
+}
@@ -0,0 +1,79 @@
+package hunt
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severity bounds for ImprovementSuggestion.Severity
+const (
+	MinSeverity = 1
+	MaxSeverity = 10
+)
+
+// Categories lists the suggestion categories analyzers report
+var Categories = []string{"tech-debt", "readability", "dependency"}
+
+// ParseCategories splits a comma-separated category list and validates each
+// entry. An empty list matches every category.
+func ParseCategories(value string) ([]string, error) {
+	var categories []string
+	for _, part := range strings.Split(value, ",") {
+		category := strings.ToLower(strings.TrimSpace(part))
+		if category == "" {
+			continue
+		}
+		if !isCategory(category) {
+			return nil, fmt.Errorf("unknown category %q (use %s)", category, strings.Join(Categories, ", "))
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+func isCategory(category string) bool {
+	return slices.Contains(Categories, category)
+}
+
+// Filter selects which suggestions to report
+type Filter struct {
+	MinSeverity int
+	Categories  []string
+	// Limit caps the number of suggestions; 0 means no limit
+	Limit int
+}
+
+// Validate checks the filter values
+func (f Filter) Validate() error {
+	if f.MinSeverity < MinSeverity || f.MinSeverity > MaxSeverity {
+		return fmt.Errorf("min severity must be between %d and %d, got %d", MinSeverity, MaxSeverity, f.MinSeverity)
+	}
+	if f.Limit < 0 {
+		return fmt.Errorf("limit must not be negative, got %d", f.Limit)
+	}
+	for _, category := range f.Categories {
+		if !isCategory(category) {
+			return fmt.Errorf("unknown category %q (use %s)", category, strings.Join(Categories, ", "))
+		}
+	}
+	return nil
+}
+
+// Apply returns the suggestions that pass the filter, keeping their order
+func (f Filter) Apply(suggestions []ImprovementSuggestion) []ImprovementSuggestion {
+	filtered := []ImprovementSuggestion{}
+	for _, s := range suggestions {
+		if s.Severity < f.MinSeverity {
+			continue
+		}
+		if len(f.Categories) > 0 && !slices.Contains(f.Categories, s.Category) {
+			continue
+		}
+		filtered = append(filtered, s)
+		if f.Limit > 0 && len(filtered) == f.Limit {
+			break
+		}
+	}
+	return filtered
+}
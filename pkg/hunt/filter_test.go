@@ -0,0 +1,78 @@
+package hunt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCategories(t *testing.T) {
+	got, err := ParseCategories(" Tech-Debt, dependency ,,")
+	if err != nil {
+		t.Fatalf("ParseCategories: %v", err)
+	}
+	if want := []string{"tech-debt", "dependency"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCategories = %v, want %v", got, want)
+	}
+
+	if got, err := ParseCategories(""); err != nil || len(got) != 0 {
+		t.Errorf("ParseCategories(\"\") = %v, %v; want none", got, err)
+	}
+	if _, err := ParseCategories("style"); err == nil {
+		t.Error("ParseCategories(style) should fail")
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		ok     bool
+	}{
+		{Filter{MinSeverity: 1, Limit: 10}, true},
+		{Filter{MinSeverity: 10}, true},
+		{Filter{MinSeverity: 0}, false},
+		{Filter{MinSeverity: 11}, false},
+		{Filter{MinSeverity: 1, Limit: -1}, false},
+		{Filter{MinSeverity: 1, Categories: []string{"dependency"}}, true},
+		{Filter{MinSeverity: 1, Categories: []string{"style"}}, false},
+		{Filter{MinSeverity: 1, Categories: []string{"docs"}}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.filter.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%+v.Validate() = %v, want ok=%v", tt.filter, err, tt.ok)
+		}
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	suggestions := []ImprovementSuggestion{
+		{ID: "a", Category: "tech-debt", Severity: 8},
+		{ID: "b", Category: "dependency", Severity: 6},
+		{ID: "c", Category: "tech-debt", Severity: 3},
+		{ID: "d", Category: "readability", Severity: 5},
+		{ID: "e", Category: "tech-debt", Severity: 7},
+	}
+	ids := func(list []ImprovementSuggestion) []string {
+		out := []string{}
+		for _, s := range list {
+			out = append(out, s.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		filter Filter
+		want   []string
+	}{
+		{Filter{MinSeverity: 1}, []string{"a", "b", "c", "d", "e"}},
+		{Filter{MinSeverity: 6}, []string{"a", "b", "e"}},
+		{Filter{MinSeverity: 1, Categories: []string{"tech-debt"}}, []string{"a", "c", "e"}},
+		{Filter{MinSeverity: 6, Categories: []string{"tech-debt", "readability"}}, []string{"a", "e"}},
+		{Filter{MinSeverity: 1, Categories: []string{"tech-debt"}, Limit: 2}, []string{"a", "c"}},
+		{Filter{MinSeverity: 7, Categories: []string{"dependency"}}, []string{}},
+	}
+	for _, tt := range tests {
+		if got := ids(tt.filter.Apply(suggestions)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v.Apply = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
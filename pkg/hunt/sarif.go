@@ -0,0 +1,186 @@
+package hunt
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// sarifRuleDescriptions describes the rule for each suggestion category
+var sarifRuleDescriptions = map[string]string{
+	"bug-risk":    "Code that is likely to cause bugs",
+	"readability": "Lint finding that hurts readability",
+	"dependency":  "Dependency with an available update",
+	"docs":        "Missing or outdated documentation",
+	"tech-debt":   "TODO, FIXME and similar markers left in code",
+}
+
+// SARIFLog is a SARIF 2.1.0 log with a single run
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is one analysis run
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool identifies the analyzer that produced the run
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes buckley hunt and the rules it reports
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule is a suggestion category
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+// SARIFResult is one suggestion
+type SARIFResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    SARIFMessage    `json:"message"`
+	Locations  []SARIFLocation `json:"locations,omitempty"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+// SARIFMessage holds plain-text message content
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation points at the file and lines a result applies to
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is a file location, with lines when known
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is a file path relative to the scanned root
+type SARIFArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SARIFRegion is a line range
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// SARIFLevel maps a 1-10 suggestion severity to a SARIF level
+func SARIFLevel(severity int) string {
+	switch {
+	case severity >= 8:
+		return "error"
+	case severity >= 5:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifRuleID derives the rule ID from a suggestion category
+func sarifRuleID(category string) string {
+	if category == "" {
+		category = "general"
+	}
+	return "hunt/" + category
+}
+
+// ToSARIF converts suggestions into a SARIF log. File paths are expected to
+// be relative to the scanned root, which is referenced as %SRCROOT%.
+func ToSARIF(suggestions []ImprovementSuggestion, toolVersion string) SARIFLog {
+	results := []SARIFResult{}
+	categories := map[string]bool{}
+	for _, s := range suggestions {
+		categories[s.Category] = true
+
+		result := SARIFResult{
+			RuleID:  sarifRuleID(s.Category),
+			Level:   SARIFLevel(s.Severity),
+			Message: SARIFMessage{Text: s.Rationale},
+			Properties: map[string]any{
+				"severity": s.Severity,
+			},
+		}
+		if s.Effort != "" {
+			result.Properties["effort"] = s.Effort
+		}
+		if s.AutoFixable {
+			result.Properties["autoFixable"] = true
+		}
+		if s.File != "" {
+			location := SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{
+					URI:       filepath.ToSlash(s.File),
+					URIBaseID: "%SRCROOT%",
+				},
+			}
+			if s.LineStart > 0 {
+				location.Region = &SARIFRegion{StartLine: s.LineStart}
+				if s.LineEnd > s.LineStart {
+					location.Region.EndLine = s.LineEnd
+				}
+			}
+			result.Locations = []SARIFLocation{{PhysicalLocation: location}}
+		}
+		results = append(results, result)
+	}
+
+	rules := []SARIFRule{}
+	for category := range categories {
+		description := sarifRuleDescriptions[category]
+		if description == "" {
+			description = "Improvement suggestion"
+		}
+		rules = append(rules, SARIFRule{
+			ID:               sarifRuleID(category),
+			ShortDescription: SARIFMessage{Text: description},
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	return SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           "buckley-hunt",
+				Version:        toolVersion,
+				InformationURI: "https://github.com/odvcencio/buckley",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// WriteSARIF writes suggestions to w as an indented SARIF log
+func WriteSARIF(w io.Writer, suggestions []ImprovementSuggestion, toolVersion string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ToSARIF(suggestions, toolVersion))
+}
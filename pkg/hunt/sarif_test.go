@@ -0,0 +1,88 @@
+package hunt
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestToSARIF(t *testing.T) {
+	suggestions := []ImprovementSuggestion{
+		{
+			Category:  "tech-debt",
+			Severity:  8,
+			File:      "pkg/foo/bar.go",
+			LineStart: 12,
+			LineEnd:   12,
+			Rationale: "FIXME comment: handle error",
+			Effort:    "small",
+		},
+		{
+			Category:    "dependency",
+			Severity:    4,
+			File:        "go.mod",
+			Rationale:   "Update available: v1.0.0 → v1.1.0",
+			AutoFixable: true,
+		},
+		{
+			Category:  "tech-debt",
+			Severity:  5,
+			Rationale: "TODO comment: no location",
+		},
+	}
+
+	log := ToSARIF(suggestions, "1.2.3")
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v, want one SARIF 2.1.0 run", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver version = %q, want 1.2.3", run.Tool.Driver.Version)
+	}
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != "hunt/dependency" || run.Tool.Driver.Rules[1].ID != "hunt/tech-debt" {
+		t.Errorf("rules = %+v, want hunt/dependency and hunt/tech-debt", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.RuleID != "hunt/tech-debt" || first.Level != "error" || first.Message.Text != "FIXME comment: handle error" {
+		t.Errorf("first result = %+v", first)
+	}
+	if len(first.Locations) != 1 {
+		t.Fatalf("first result locations = %+v, want one", first.Locations)
+	}
+	loc := first.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "pkg/foo/bar.go" || loc.Region == nil || loc.Region.StartLine != 12 || loc.Region.EndLine != 0 {
+		t.Errorf("first location = %+v", loc)
+	}
+
+	second := run.Results[1]
+	if second.Level != "note" || len(second.Locations) != 1 || second.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("second result = %+v, want a note on go.mod without a region", second)
+	}
+	if second.Properties["autoFixable"] != true {
+		t.Errorf("second result properties = %+v, want autoFixable", second.Properties)
+	}
+
+	if third := run.Results[2]; third.Level != "warning" || len(third.Locations) != 0 {
+		t.Errorf("third result = %+v, want a warning without locations", third)
+	}
+}
+
+func TestWriteSARIF_EmptyResults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, nil, ""); err != nil {
+		t.Fatalf("WriteSARIF: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	runs := decoded["runs"].([]any)
+	results := runs[0].(map[string]any)["results"]
+	if list, ok := results.([]any); !ok || len(list) != 0 {
+		t.Errorf("results = %#v, want an empty array", results)
+	}
+}
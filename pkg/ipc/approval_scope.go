@@ -0,0 +1,51 @@
+package ipc
+
+import (
+	"encoding/json"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/headless"
+)
+
+// approvalNeedsOperator reports whether approving a call to toolName is
+// reserved for operators because tools.dangerous lists it.
+func (s *Server) approvalNeedsOperator(toolName string) bool {
+	return s != nil && s.appConfig.IsDangerousTool(toolName)
+}
+
+// approvalCommandForbidden reports whether a raw "approval" session command
+// from principal would approve a pending dangerous tool call. ApproveToolCall
+// checks scope itself; this keeps members from sending the command directly.
+func (s *Server) approvalCommandForbidden(principal *requestPrincipal, sessionID, cmdType, content string) (bool, error) {
+	if strings.TrimSpace(cmdType) != "approval" || isOperatorPrincipal(principal) || !approvalCommandGrants(content) {
+		return false, nil
+	}
+	if s == nil || s.store == nil || s.appConfig == nil || len(s.appConfig.Tools.Dangerous) == 0 {
+		return false, nil
+	}
+	pending, err := s.store.ListPendingApprovals(sessionID)
+	if err != nil {
+		return false, err
+	}
+	for _, approval := range pending {
+		if s.approvalNeedsOperator(approval.ToolName) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// approvalCommandGrants mirrors how the headless runner reads an approval
+// command: a JSON ApprovalResponse, or a plain approve/yes/y.
+func approvalCommandGrants(content string) bool {
+	var resp headless.ApprovalResponse
+	if err := json.Unmarshal([]byte(content), &resp); err == nil {
+		return resp.Approved
+	}
+	switch strings.ToLower(strings.TrimSpace(content)) {
+	case "approve", "yes", "y":
+		return true
+	default:
+		return false
+	}
+}
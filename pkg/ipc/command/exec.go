@@ -0,0 +1,49 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultExecTimeout bounds an exec command when none is configured.
+const defaultExecTimeout = 30 * time.Second
+
+// ExecHandler runs a program for each command it receives. The command
+// content is written to stdin, and the session ID, command ID and type are
+// passed as BUCKLEY_SESSION_ID, BUCKLEY_COMMAND_ID and BUCKLEY_COMMAND_TYPE.
+// A non-zero exit fails the command with the program's stderr.
+func ExecHandler(name string, args []string, timeout time.Duration) Handler {
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	args = append([]string{}, args...)
+	return HandlerFunc(func(cmd SessionCommand) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		proc := exec.CommandContext(ctx, name, args...)
+		proc.Stdin = strings.NewReader(cmd.Content)
+		proc.Env = append(os.Environ(),
+			"BUCKLEY_SESSION_ID="+cmd.SessionID,
+			"BUCKLEY_COMMAND_ID="+cmd.ID,
+			"BUCKLEY_COMMAND_TYPE="+strings.TrimSpace(cmd.Type),
+		)
+		var stderr bytes.Buffer
+		proc.Stderr = &stderr
+		if err := proc.Run(); err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("command %s timed out after %s", strings.TrimSpace(cmd.Type), timeout)
+			}
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("command %s failed: %w: %s", strings.TrimSpace(cmd.Type), err, msg)
+			}
+			return fmt.Errorf("command %s failed: %w", strings.TrimSpace(cmd.Type), err)
+		}
+		return nil
+	})
+}
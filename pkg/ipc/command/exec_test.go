@@ -0,0 +1,58 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecHandlerPassesCommandToProgram(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	handler := ExecHandler("sh", []string{"-c", `{ echo "$BUCKLEY_SESSION_ID $BUCKLEY_COMMAND_TYPE"; cat; } > "$0"`, out}, 0)
+
+	gateway := NewGateway()
+	if err := gateway.RegisterCommand("acme:deploy", handler); err != nil {
+		t.Fatalf("RegisterCommand: %v", err)
+	}
+	if err := gateway.Dispatch(SessionCommand{SessionID: "s1", Type: "acme:deploy", Content: "staging"}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if got, want := string(data), "s1 acme:deploy\nstaging"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestExecHandlerReportsFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	handler := ExecHandler("sh", []string{"-c", "echo boom >&2; exit 3"}, 0)
+
+	err := handler.HandleSessionCommand(SessionCommand{Type: "acme:deploy"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected stderr in error, got %v", err)
+	}
+}
+
+func TestExecHandlerTimesOut(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	handler := ExecHandler("sh", []string{"-c", "exec sleep 5"}, 50*time.Millisecond)
+
+	err := handler.HandleSessionCommand(SessionCommand{Type: "acme:deploy"})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
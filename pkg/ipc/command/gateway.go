@@ -2,7 +2,10 @@ package command
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/oklog/ulid/v2"
 )
@@ -52,9 +55,21 @@ func (f HandlerFunc) HandleSessionCommand(cmd SessionCommand) error {
 	return f(cmd)
 }
 
+// customCommandPattern matches namespaced plugin command types such as
+// "acme:deploy". The namespace keeps plugins clear of built-in types.
+var customCommandPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*:[a-z0-9][a-z0-9_.-]*$`)
+
+// IsCustomCommand reports whether commandType is a namespaced plugin command.
+func IsCustomCommand(commandType string) bool {
+	return customCommandPattern.MatchString(strings.TrimSpace(commandType))
+}
+
 // Gateway routes commands from IPC clients to the active agent.
 type Gateway struct {
 	handler Handler
+
+	mu     sync.RWMutex
+	custom map[string]Handler
 }
 
 // NewGateway constructs a gateway without a handler.
@@ -67,8 +82,75 @@ func (g *Gateway) Register(handler Handler) {
 	g.handler = handler
 }
 
-// Dispatch forwards the command to the registered handler.
+// RegisterCommand adds a plugin handler for a namespaced command type
+// ("namespace:name"). Commands of that type are dispatched to it instead of
+// the session handler.
+func (g *Gateway) RegisterCommand(commandType string, handler Handler) error {
+	if g == nil {
+		return fmt.Errorf("gateway unavailable")
+	}
+	commandType = strings.TrimSpace(commandType)
+	if !IsCustomCommand(commandType) {
+		return fmt.Errorf("invalid custom command type %q: want namespace:name", commandType)
+	}
+	if handler == nil {
+		return fmt.Errorf("nil handler for command %q", commandType)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, exists := g.custom[commandType]; exists {
+		return fmt.Errorf("command %q already registered", commandType)
+	}
+	if g.custom == nil {
+		g.custom = make(map[string]Handler)
+	}
+	g.custom[commandType] = handler
+	return nil
+}
+
+// UnregisterCommand removes a plugin command handler.
+func (g *Gateway) UnregisterCommand(commandType string) {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.custom, strings.TrimSpace(commandType))
+}
+
+// CustomCommands lists registered plugin command types in sorted order.
+func (g *Gateway) CustomCommands() []string {
+	if g == nil {
+		return nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	types := make([]string, 0, len(g.custom))
+	for commandType := range g.custom {
+		types = append(types, commandType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (g *Gateway) customHandler(commandType string) Handler {
+	if g == nil {
+		return nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.custom[strings.TrimSpace(commandType)]
+}
+
+// Dispatch forwards the command to the plugin registered for its type, or to
+// the session handler otherwise.
 func (g *Gateway) Dispatch(cmd SessionCommand) error {
+	if handler := g.customHandler(cmd.Type); handler != nil {
+		return handler.HandleSessionCommand(cmd)
+	}
+	if IsCustomCommand(cmd.Type) {
+		return fmt.Errorf("unknown custom command: %s", strings.TrimSpace(cmd.Type))
+	}
 	if g == nil || g.handler == nil {
 		return fmt.Errorf("no command handler registered")
 	}
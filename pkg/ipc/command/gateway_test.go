@@ -262,3 +262,124 @@ func TestRequiresContent(t *testing.T) {
 		}
 	}
 }
+
+func TestIsCustomCommand(t *testing.T) {
+	for _, commandType := range []string{"acme:deploy", "org-tools:sync.repo", " acme:deploy "} {
+		if !IsCustomCommand(commandType) {
+			t.Errorf("IsCustomCommand(%q)=false want true", commandType)
+		}
+	}
+	for _, commandType := range []string{"input", "slash", "deploy", ":deploy", "acme:", "Acme:Deploy", "a:b:c"} {
+		if IsCustomCommand(commandType) {
+			t.Errorf("IsCustomCommand(%q)=true want false", commandType)
+		}
+	}
+}
+
+func TestGateway_RegisterCommand_DispatchesCustomCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := NewGateway()
+	sessionHandler := NewMockHandler(ctrl)
+	gateway.Register(sessionHandler)
+
+	var received SessionCommand
+	if err := gateway.RegisterCommand("acme:deploy", HandlerFunc(func(cmd SessionCommand) error {
+		received = cmd
+		return nil
+	})); err != nil {
+		t.Fatalf("RegisterCommand() error = %v", err)
+	}
+
+	cmd := SessionCommand{SessionID: "s1", Type: "acme:deploy", Content: "staging"}
+	if err := gateway.Dispatch(cmd); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if received != cmd {
+		t.Fatalf("custom handler received %+v, want %+v", received, cmd)
+	}
+
+	// Built-in types still reach the session handler.
+	input := SessionCommand{SessionID: "s1", Type: "input", Content: "hello"}
+	sessionHandler.EXPECT().HandleSessionCommand(input).Return(nil)
+	if err := gateway.Dispatch(input); err != nil {
+		t.Fatalf("Dispatch(input) error = %v", err)
+	}
+}
+
+func TestGateway_RegisterCommand_WorksWithoutSessionHandler(t *testing.T) {
+	gateway := NewGateway()
+	called := false
+	if err := gateway.RegisterCommand("acme:ping", HandlerFunc(func(SessionCommand) error {
+		called = true
+		return nil
+	})); err != nil {
+		t.Fatalf("RegisterCommand() error = %v", err)
+	}
+	if err := gateway.Dispatch(SessionCommand{Type: "acme:ping"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if !called {
+		t.Fatal("custom handler was not called")
+	}
+}
+
+func TestGateway_RegisterCommand_Rejects(t *testing.T) {
+	gateway := NewGateway()
+	noop := HandlerFunc(func(SessionCommand) error { return nil })
+
+	if err := gateway.RegisterCommand("deploy", noop); err == nil {
+		t.Error("RegisterCommand() should reject un-namespaced types")
+	}
+	if err := gateway.RegisterCommand("acme:deploy", nil); err == nil {
+		t.Error("RegisterCommand() should reject a nil handler")
+	}
+	if err := gateway.RegisterCommand("acme:deploy", noop); err != nil {
+		t.Fatalf("RegisterCommand() error = %v", err)
+	}
+	if err := gateway.RegisterCommand("acme:deploy", noop); err == nil {
+		t.Error("RegisterCommand() should reject duplicate registration")
+	}
+	var nilGateway *Gateway
+	if err := nilGateway.RegisterCommand("acme:deploy", noop); err == nil {
+		t.Error("RegisterCommand() should fail on a nil gateway")
+	}
+}
+
+func TestGateway_Dispatch_UnknownCustomCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gateway := NewGateway()
+	// The session handler must not see namespaced commands it cannot run.
+	gateway.Register(NewMockHandler(ctrl))
+
+	err := gateway.Dispatch(SessionCommand{SessionID: "s1", Type: "acme:missing"})
+	if err == nil || err.Error() != "unknown custom command: acme:missing" {
+		t.Fatalf("Dispatch() error = %v, want unknown custom command", err)
+	}
+}
+
+func TestGateway_UnregisterCommandAndList(t *testing.T) {
+	gateway := NewGateway()
+	noop := HandlerFunc(func(SessionCommand) error { return nil })
+	for _, commandType := range []string{"zeta:sync", "acme:deploy"} {
+		if err := gateway.RegisterCommand(commandType, noop); err != nil {
+			t.Fatalf("RegisterCommand(%q) error = %v", commandType, err)
+		}
+	}
+
+	got := gateway.CustomCommands()
+	if len(got) != 2 || got[0] != "acme:deploy" || got[1] != "zeta:sync" {
+		t.Fatalf("CustomCommands() = %v, want sorted registrations", got)
+	}
+
+	gateway.UnregisterCommand("acme:deploy")
+	if err := gateway.Dispatch(SessionCommand{Type: "acme:deploy"}); err == nil {
+		t.Fatal("Dispatch() should fail after UnregisterCommand")
+	}
+	if got := gateway.CustomCommands(); len(got) != 1 || got[0] != "zeta:sync" {
+		t.Fatalf("CustomCommands() = %v after unregister", got)
+	}
+}
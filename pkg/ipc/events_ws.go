@@ -0,0 +1,138 @@
+package ipc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"nhooyr.io/websocket"
+)
+
+// handleEventStream serves hub events over a WebSocket (GET /ws). The
+// sessionId and type query parameters narrow the stream; type takes a
+// comma-separated list of event types, with "session.*" style wildcards.
+// Non-operator principals must name a session they can access.
+func (s *Server) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	types := parseEventTypes(r.URL.Query()["type"])
+	s.serveEventStream(w, r, "event", func(sessionID string, operator bool) func(Event) bool {
+		return eventStreamFilter(sessionID, types, operator)
+	}, nil)
+}
+
+// serveEventStream authorizes the request, upgrades it to a WebSocket and
+// streams hub events until the client goes away. Non-operator principals must
+// pass a sessionId they can access. filter builds the hub filter from that
+// session ID; onConnect, when set, runs once the client is registered.
+func (s *Server) serveEventStream(w http.ResponseWriter, r *http.Request, name string, filter func(sessionID string, operator bool) func(Event) bool, onConnect func(c *client, sessionID string)) {
+	principal, ok := s.authorize(r)
+	if !ok || principal == nil {
+		respondError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+	if !s.isWebSocketOriginAllowed(r) {
+		respondError(w, http.StatusForbidden, errors.New("forbidden"))
+		return
+	}
+	if s.eventConnLimiter != nil && !s.eventConnLimiter.Acquire() {
+		respondError(w, http.StatusTooManyRequests, errors.New("too many connections"))
+		return
+	}
+	defer func() {
+		if s.eventConnLimiter != nil {
+			s.eventConnLimiter.Release()
+		}
+	}()
+
+	sessionID := strings.TrimSpace(r.URL.Query().Get("sessionId"))
+	operator := isOperatorPrincipal(principal)
+	if !operator {
+		if sessionID == "" {
+			respondError(w, http.StatusForbidden, errors.New("sessionId required"))
+			return
+		}
+		sess, err := s.store.GetSession(sessionID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if sess == nil || !principalCanAccessSession(principal, sess) {
+			respondError(w, http.StatusNotFound, errors.New("session not found"))
+			return
+		}
+	}
+
+	// InsecureSkipVerify disables the library's built-in Origin check.
+	// Origin validation is already performed above via isWebSocketOriginAllowed,
+	// so the library's check would be redundant.
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		s.logger.Printf("%s websocket accept failed: %v", name, err)
+		return
+	}
+	conn.SetReadLimit(maxWSReadBytesEventStream)
+
+	client := s.hub.register(conn, filter(sessionID, operator))
+	ctx, cancel := context.WithCancel(r.Context())
+	startWSPing(ctx, conn)
+
+	go func() {
+		defer cancel()
+		s.readClient(ctx, client)
+	}()
+
+	go func() {
+		if err := client.writeLoop(ctx); err != nil {
+			s.logger.Printf("%s websocket write error: %v", name, err)
+			cancel()
+		}
+	}()
+
+	if onConnect != nil {
+		onConnect(client, sessionID)
+	}
+
+	<-ctx.Done()
+	s.hub.removeClient(client)
+	client.close(websocket.StatusNormalClosure, "shutdown")
+}
+
+// eventStreamFilter builds the hub filter for an event WebSocket. Operators
+// that ask for no filter get every event (nil filter). Everyone else only
+// sees events for their session, never mission or agent events.
+func eventStreamFilter(sessionID string, types []string, operator bool) func(Event) bool {
+	if operator && sessionID == "" && len(types) == 0 {
+		return nil
+	}
+	return func(event Event) bool {
+		if sessionID != "" && event.SessionID != sessionID {
+			return false
+		}
+		if !operator && (strings.HasPrefix(event.Type, "mission.") || strings.HasPrefix(event.Type, "agent.")) {
+			return false
+		}
+		if len(types) == 0 {
+			return true
+		}
+		for _, pattern := range types {
+			if matchesPrefix(event.Type, pattern) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func parseEventTypes(values []string) []string {
+	var types []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				types = append(types, part)
+			}
+		}
+	}
+	return types
+}
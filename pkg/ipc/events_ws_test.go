@@ -0,0 +1,133 @@
+package ipc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestEventStreamFilter(t *testing.T) {
+	if eventStreamFilter("", nil, true) != nil {
+		t.Fatal("operator without filters should be unfiltered")
+	}
+
+	tests := []struct {
+		name      string
+		sessionID string
+		types     []string
+		operator  bool
+		event     Event
+		expected  bool
+	}{
+		{"session match", "s1", nil, false, Event{Type: "message.created", SessionID: "s1"}, true},
+		{"other session", "s1", nil, false, Event{Type: "message.created", SessionID: "s2"}, false},
+		{"sessionless event", "s1", nil, false, Event{Type: "message.created"}, false},
+		{"mission hidden from viewers", "s1", nil, false, Event{Type: "mission.change", SessionID: "s1"}, false},
+		{"mission shown to operators", "s1", nil, true, Event{Type: "mission.change", SessionID: "s1"}, true},
+		{"type match", "", []string{"message.created"}, true, Event{Type: "message.created"}, true},
+		{"type mismatch", "", []string{"message.created"}, true, Event{Type: "tool.started"}, false},
+		{"type wildcard", "s1", []string{"tool.*"}, false, Event{Type: "tool.started", SessionID: "s1"}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := eventStreamFilter(tc.sessionID, tc.types, tc.operator)
+			if got := filter(tc.event); got != tc.expected {
+				t.Fatalf("filter(%+v) = %v, want %v", tc.event, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseEventTypes(t *testing.T) {
+	got := parseEventTypes([]string{"message.created, tool.*", "", "session.updated"})
+	want := []string{"message.created", "tool.*", "session.updated"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("parseEventTypes = %v, want %v", got, want)
+	}
+}
+
+func TestEventStreamEnforcesSessionAccess(t *testing.T) {
+	store, err := storage.New(t.TempDir() + "/buckley.db")
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now().UTC()
+	for _, sess := range []storage.Session{
+		{ID: "s1", Principal: "viewer", CreatedAt: now, LastActive: now, Status: storage.SessionStatusActive},
+		{ID: "s2", Principal: "other", CreatedAt: now, LastActive: now, Status: storage.SessionStatusActive},
+	} {
+		sess := sess
+		if err := store.CreateSession(&sess); err != nil {
+			t.Fatalf("CreateSession: %v", err)
+		}
+	}
+
+	s := &Server{store: store, hub: NewHub(), logger: log.New(io.Discard, "", 0)}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), principalContextKey, &requestPrincipal{
+			Name:  "viewer",
+			Scope: storage.TokenScopeViewer,
+		})
+		s.handleEventStream(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(ts.Close)
+	wsBase := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for query, status := range map[string]int{"": http.StatusForbidden, "?sessionId=s2": http.StatusNotFound} {
+		_, resp, err := websocket.Dial(ctx, wsBase+query, nil)
+		if err == nil || resp == nil || resp.StatusCode != status {
+			t.Fatalf("dial %q: err=%v resp=%v, want status %d", query, err, resp, status)
+		}
+	}
+
+	conn, _, err := websocket.Dial(ctx, wsBase+"?sessionId=s1&type=message.created", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "done")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		s.hub.mu.RLock()
+		registered := len(s.hub.clients)
+		s.hub.mu.RUnlock()
+		if registered == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("client never registered with hub")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	s.hub.Broadcast(Event{Type: "message.created", SessionID: "s2"})
+	s.hub.Broadcast(Event{Type: "tool.started", SessionID: "s1"})
+	s.hub.Broadcast(Event{Type: "message.created", SessionID: "s1", Payload: "hello"})
+
+	_, data, err := conn.Read(ctx)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var got Event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Type != "message.created" || got.SessionID != "s1" || got.Payload != "hello" {
+		t.Fatalf("received %+v, want only the s1 message.created event", got)
+	}
+}
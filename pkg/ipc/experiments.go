@@ -0,0 +1,372 @@
+package ipc
+
+import (
+	"context"
+	stdliberrors "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/oklog/ulid/v2"
+
+	"m31labs.dev/buckley/pkg/experiment"
+	"m31labs.dev/buckley/pkg/parallel"
+	"m31labs.dev/buckley/pkg/storage"
+	"m31labs.dev/buckley/pkg/telemetry"
+	"m31labs.dev/buckley/pkg/tool"
+)
+
+// ExperimentRunner executes the variants of an experiment and records the
+// results in the experiment store. experiment.Runner satisfies it.
+type ExperimentRunner interface {
+	RunExperiment(ctx context.Context, exp *experiment.Experiment) ([]*parallel.AgentResult, error)
+}
+
+// ExperimentRunnerFactory builds the runner for one experiment. A runner
+// drives one experiment at a time, so each request gets its own.
+type ExperimentRunnerFactory func() (ExperimentRunner, error)
+
+type createExperimentRequest struct {
+	Name   string   `json:"name,omitempty"`
+	Prompt string   `json:"prompt"`
+	Models []string `json:"models"`
+	// Timeout is a per-variant Go duration such as "10m"; empty uses
+	// experiment.default_timeout.
+	Timeout string `json:"timeout,omitempty"`
+	// Tools limits every variant to the named tools. Empty allows only
+	// read-only tools, so HTTP experiments cannot run shell commands or edit
+	// files unless asked to.
+	Tools []string `json:"tools,omitempty"`
+}
+
+type experimentJSON struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Status      string                  `json:"status"`
+	Prompt      string                  `json:"prompt"`
+	TimeoutMs   int64                   `json:"timeoutMs,omitempty"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	CompletedAt *time.Time              `json:"completedAt,omitempty"`
+	Variants    []experimentVariantJSON `json:"variants,omitempty"`
+	Runs        []experimentRunJSON     `json:"runs,omitempty"`
+}
+
+type experimentVariantJSON struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	ModelID    string   `json:"modelId"`
+	ProviderID string   `json:"providerId,omitempty"`
+	Tools      []string `json:"tools,omitempty"`
+}
+
+type experimentRunJSON struct {
+	ID          string                `json:"id"`
+	VariantID   string                `json:"variantId"`
+	Status      string                `json:"status"`
+	Branch      string                `json:"branch,omitempty"`
+	Output      string                `json:"output,omitempty"`
+	Files       []string              `json:"files,omitempty"`
+	Metrics     experimentMetricsJSON `json:"metrics"`
+	Error       string                `json:"error,omitempty"`
+	StartedAt   time.Time             `json:"startedAt"`
+	CompletedAt *time.Time            `json:"completedAt,omitempty"`
+}
+
+type experimentMetricsJSON struct {
+	DurationMs       int64   `json:"durationMs"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	TotalCost        float64 `json:"totalCost"`
+	ToolCalls        int     `json:"toolCalls"`
+	FilesModified    int     `json:"filesModified"`
+	LinesChanged     int     `json:"linesChanged"`
+}
+
+// SetExperimentRunner enables the /api/experiments endpoints.
+func (s *Server) SetExperimentRunner(factory ExperimentRunnerFactory) {
+	s.experimentRunner = factory
+}
+
+// handleCreateExperiment starts a model comparison (POST /api/experiments).
+// The experiment is stored as pending and run in the background; progress is
+// published as telemetry.experiment.* events on the event stream.
+func (s *Server) handleCreateExperiment(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireScope(w, r, storage.TokenScopeOperator); !ok {
+		return
+	}
+	var req createExperimentRequest
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesSmall), false); err != nil {
+		respondError(w, status, err)
+		return
+	}
+	prompt := strings.TrimSpace(req.Prompt)
+	if prompt == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("prompt required"))
+		return
+	}
+	var models []string
+	for _, modelID := range req.Models {
+		if modelID = strings.TrimSpace(modelID); modelID != "" {
+			models = append(models, modelID)
+		}
+	}
+	if len(models) == 0 {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("models required"))
+		return
+	}
+	if len(models) > maxExperimentVariants {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("at most %d models per experiment", maxExperimentVariants))
+		return
+	}
+	var timeout time.Duration
+	if raw := strings.TrimSpace(req.Timeout); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("invalid timeout %q", raw))
+			return
+		}
+		timeout = parsed
+	}
+	var tools []string
+	for _, name := range req.Tools {
+		if name = strings.TrimSpace(name); name != "" {
+			tools = append(tools, name)
+		}
+	}
+	if s.experimentRunner == nil {
+		respondError(w, http.StatusServiceUnavailable, fmt.Errorf("experiments unavailable (start serve with --experiment)"))
+		return
+	}
+	if len(tools) == 0 {
+		tools = tool.ReadOnlyToolNames(tool.NewRegistry())
+	}
+
+	if !s.expLimiter.Acquire() {
+		respondError(w, http.StatusTooManyRequests, stdliberrors.New("too many experiments in progress"))
+		return
+	}
+	runner, err := s.experimentRunner()
+	if err != nil {
+		s.expLimiter.Release()
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	exp := &experiment.Experiment{
+		ID:   ulid.Make().String(),
+		Name: strings.TrimSpace(req.Name),
+		Task: experiment.Task{
+			Prompt:  prompt,
+			Timeout: timeout,
+		},
+	}
+	if exp.Name == "" {
+		exp.Name = exp.ID
+	}
+	for _, modelID := range models {
+		variant := experiment.Variant{
+			ID:           ulid.Make().String(),
+			Name:         modelID,
+			ModelID:      modelID,
+			ToolsAllowed: tools,
+		}
+		if s.models != nil {
+			variant.ProviderID = s.models.ProviderIDForModel(modelID)
+		}
+		exp.Variants = append(exp.Variants, variant)
+	}
+
+	// Store it before returning so the ID can be polled right away.
+	if err := experiment.NewStoreFromStorage(s.store).CreateExperiment(exp); err != nil {
+		s.expLimiter.Release()
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	view := newExperimentJSON(exp, nil)
+
+	go s.runExperiment(runner, exp)
+
+	respondJSONStatus(w, http.StatusAccepted, map[string]any{
+		"experiment": view,
+	})
+}
+
+// runExperiment runs exp to completion. Errors that stop the runner before
+// it records a final status mark the experiment failed.
+func (s *Server) runExperiment(runner ExperimentRunner, exp *experiment.Experiment) {
+	defer s.expLimiter.Release()
+	expID, name := exp.ID, exp.Name
+
+	var runErr error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				runErr = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		// Variants are bounded by their own timeout; shutting the server
+		// down cancels them too.
+		_, runErr = runner.RunExperiment(s.backgroundContext(), exp)
+	}()
+	if runErr == nil {
+		return
+	}
+
+	s.logger.Printf("experiment %s failed: %v", expID, runErr)
+	store := experiment.NewStoreFromStorage(s.store)
+	if stored, err := store.GetExperiment(expID); err == nil && stored != nil &&
+		(stored.Status == experiment.ExperimentPending || stored.Status == experiment.ExperimentRunning) {
+		if err := store.UpdateExperimentStatus(expID, experiment.ExperimentFailed, nil); err != nil {
+			s.logger.Printf("experiment %s: failed to record status: %v", expID, err)
+		}
+	}
+	if s.telemetry != nil {
+		s.telemetry.Publish(telemetry.Event{
+			Type: telemetry.EventExperimentFailed,
+			Data: map[string]any{
+				"experiment_id": expID,
+				"name":          name,
+				"status":        string(experiment.ExperimentFailed),
+				"error":         runErr.Error(),
+			},
+		})
+	}
+}
+
+// backgroundContext is the context for work that outlives a request. It ends
+// when the server's Start context does.
+func (s *Server) backgroundContext() context.Context {
+	if s.runCtx != nil {
+		return s.runCtx
+	}
+	return context.Background()
+}
+
+// failInterruptedExperiments marks experiments left pending or running by an
+// earlier serve process as failed. Only servers that run experiments do this.
+func (s *Server) failInterruptedExperiments() {
+	if s.experimentRunner == nil {
+		return
+	}
+	count, err := experiment.NewStoreFromStorage(s.store).FailInterruptedExperiments("interrupted by server restart")
+	if err != nil {
+		s.logger.Printf("warning: failed to mark interrupted experiments: %v", err)
+		return
+	}
+	if count > 0 {
+		s.logger.Printf("marked %d interrupted experiment(s) failed", count)
+	}
+}
+
+// handleListExperiments lists stored experiments, newest first
+// (GET /api/experiments?status=&limit=).
+func (s *Server) handleListExperiments(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireScope(w, r, storage.TokenScopeOperator); !ok {
+		return
+	}
+	status := experiment.ExperimentStatus(strings.TrimSpace(r.URL.Query().Get("status")))
+	switch status {
+	case "", experiment.ExperimentPending, experiment.ExperimentRunning, experiment.ExperimentCompleted,
+		experiment.ExperimentFailed, experiment.ExperimentCancelled:
+	default:
+		respondError(w, http.StatusBadRequest, stdliberrors.New("invalid status filter"))
+		return
+	}
+	limit := 20
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if l, err := strconv.Atoi(raw); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	experiments, err := experiment.NewStoreFromStorage(s.store).ListExperiments(limit, status)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	views := make([]experimentJSON, 0, len(experiments))
+	for i := range experiments {
+		views = append(views, newExperimentJSON(&experiments[i], nil))
+	}
+	respondJSON(w, map[string]any{
+		"experiments": views,
+		"count":       len(views),
+	})
+}
+
+// handleGetExperiment returns an experiment with its variants and runs
+// (GET /api/experiments/{experimentID}).
+func (s *Server) handleGetExperiment(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireScope(w, r, storage.TokenScopeOperator); !ok {
+		return
+	}
+	expID := strings.TrimSpace(chi.URLParam(r, "experimentID"))
+	store := experiment.NewStoreFromStorage(s.store)
+	exp, err := store.GetExperiment(expID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if exp == nil {
+		respondError(w, http.StatusNotFound, stdliberrors.New("experiment not found"))
+		return
+	}
+	runs, err := store.ListRuns(expID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, map[string]any{
+		"experiment": newExperimentJSON(exp, runs),
+	})
+}
+
+func newExperimentJSON(exp *experiment.Experiment, runs []experiment.Run) experimentJSON {
+	view := experimentJSON{
+		ID:          exp.ID,
+		Name:        exp.Name,
+		Status:      string(exp.Status),
+		Prompt:      exp.Task.Prompt,
+		TimeoutMs:   exp.Task.Timeout.Milliseconds(),
+		CreatedAt:   exp.CreatedAt,
+		CompletedAt: exp.CompletedAt,
+	}
+	for _, variant := range exp.Variants {
+		view.Variants = append(view.Variants, experimentVariantJSON{
+			ID:         variant.ID,
+			Name:       variant.Name,
+			ModelID:    variant.ModelID,
+			ProviderID: variant.ProviderID,
+			Tools:      variant.ToolsAllowed,
+		})
+	}
+	for _, run := range runs {
+		runView := experimentRunJSON{
+			ID:        run.ID,
+			VariantID: run.VariantID,
+			Status:    string(run.Status),
+			Branch:    run.Branch,
+			Output:    run.Output,
+			Files:     run.Files,
+			Metrics: experimentMetricsJSON{
+				DurationMs:       run.Metrics.DurationMs,
+				PromptTokens:     run.Metrics.PromptTokens,
+				CompletionTokens: run.Metrics.CompletionTokens,
+				TotalCost:        run.Metrics.TotalCost,
+				ToolCalls:        run.Metrics.ToolCalls,
+				FilesModified:    run.Metrics.FilesModified,
+				LinesChanged:     run.Metrics.LinesChanged,
+			},
+			StartedAt:   run.StartedAt,
+			CompletedAt: run.CompletedAt,
+		}
+		if run.Error != nil {
+			runView.Error = *run.Error
+		}
+		view.Runs = append(view.Runs, runView)
+	}
+	return view
+}
@@ -0,0 +1,278 @@
+package ipc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"m31labs.dev/buckley/pkg/experiment"
+	"m31labs.dev/buckley/pkg/parallel"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+type stubExperimentRunner struct {
+	err  error
+	done chan *experiment.Experiment
+}
+
+func (r *stubExperimentRunner) RunExperiment(ctx context.Context, exp *experiment.Experiment) ([]*parallel.AgentResult, error) {
+	defer func() { r.done <- exp }()
+	return nil, r.err
+}
+
+func experimentRequestAs(principal *requestPrincipal, method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	return req.WithContext(context.WithValue(req.Context(), principalContextKey, principal))
+}
+
+func waitForExperimentRun(t *testing.T, runner *stubExperimentRunner) *experiment.Experiment {
+	t.Helper()
+	select {
+	case exp := <-runner.done:
+		return exp
+	case <-time.After(5 * time.Second):
+		t.Fatal("experiment runner was not called")
+		return nil
+	}
+}
+
+func TestHandleCreateExperiment(t *testing.T) {
+	server, store := newPlanCreateTestServer(t)
+	runner := &stubExperimentRunner{done: make(chan *experiment.Experiment, 1)}
+	server.SetExperimentRunner(func() (ExperimentRunner, error) { return runner, nil })
+	operator := &requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator}
+
+	rr := httptest.NewRecorder()
+	server.handleCreateExperiment(rr, experimentRequestAs(operator, http.MethodPost, "/api/experiments",
+		`{"name":"compare","prompt":"Add a test","models":["model-a"," model-b ",""],"timeout":"5m"}`))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("unexpected status %d: %s", rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Experiment experimentJSON `json:"experiment"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.Experiment.ID == "" || body.Experiment.Status != string(experiment.ExperimentPending) {
+		t.Fatalf("experiment = %+v, want a pending experiment with an ID", body.Experiment)
+	}
+	if len(body.Experiment.Variants) != 2 || body.Experiment.Variants[1].ModelID != "model-b" {
+		t.Fatalf("variants = %+v, want model-a and model-b", body.Experiment.Variants)
+	}
+	tools := strings.Join(body.Experiment.Variants[0].Tools, ",")
+	if !strings.Contains(tools, "read_file") || strings.Contains(tools, "run_shell") || strings.Contains(tools, "write_file") {
+		t.Fatalf("default tools = %s, want read-only tools only", tools)
+	}
+	if body.Experiment.TimeoutMs != (5 * time.Minute).Milliseconds() {
+		t.Fatalf("timeoutMs = %d, want 5m", body.Experiment.TimeoutMs)
+	}
+	if ran := waitForExperimentRun(t, runner); ran.ID != body.Experiment.ID {
+		t.Fatalf("runner got experiment %q, want %q", ran.ID, body.Experiment.ID)
+	}
+
+	stored, err := experiment.NewStoreFromStorage(store).GetExperiment(body.Experiment.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("GetExperiment = %v, %v; want the experiment stored", stored, err)
+	}
+
+	rr = httptest.NewRecorder()
+	req := experimentRequestAs(operator, http.MethodGet, "/api/experiments/"+stored.ID, "")
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("experimentID", stored.ID)
+	server.handleGetExperiment(rr, req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("get status = %d: %s", rr.Code, rr.Body.String())
+	}
+	body.Experiment = experimentJSON{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode get: %v", err)
+	}
+	if body.Experiment.Name != "compare" || len(body.Experiment.Variants) != 2 {
+		t.Fatalf("get experiment = %+v", body.Experiment)
+	}
+
+	rr = httptest.NewRecorder()
+	server.handleListExperiments(rr, experimentRequestAs(operator, http.MethodGet, "/api/experiments?status=pending", ""))
+	var list struct {
+		Experiments []experimentJSON `json:"experiments"`
+		Count       int              `json:"count"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if list.Count != 1 || list.Experiments[0].ID != stored.ID {
+		t.Fatalf("list = %+v, want the pending experiment", list)
+	}
+}
+
+func TestHandleCreateExperimentRejects(t *testing.T) {
+	server, _ := newPlanCreateTestServer(t)
+	operator := &requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator}
+
+	rr := httptest.NewRecorder()
+	server.handleCreateExperiment(rr, experimentRequestAs(operator, http.MethodPost, "/api/experiments", `{"prompt":"X","models":["m"]}`))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("without runner status = %d, want 503: %s", rr.Code, rr.Body.String())
+	}
+
+	calls := 0
+	server.SetExperimentRunner(func() (ExperimentRunner, error) {
+		calls++
+		return nil, errors.New("unexpected run")
+	})
+	tests := []struct {
+		name      string
+		principal *requestPrincipal
+		body      string
+		want      int
+	}{
+		{name: "member forbidden", principal: &requestPrincipal{Name: "alice", Scope: storage.TokenScopeMember}, body: `{"prompt":"X","models":["m"]}`, want: http.StatusForbidden},
+		{name: "missing prompt", principal: operator, body: `{"models":["m"]}`, want: http.StatusBadRequest},
+		{name: "missing models", principal: operator, body: `{"prompt":"X","models":[" "]}`, want: http.StatusBadRequest},
+		{name: "too many models", principal: operator, body: `{"prompt":"X","models":["a","b","c","d","e","f","g","h","i"]}`, want: http.StatusBadRequest},
+		{name: "invalid timeout", principal: operator, body: `{"prompt":"X","models":["m"],"timeout":"soon"}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.handleCreateExperiment(rr, experimentRequestAs(tt.principal, http.MethodPost, "/api/experiments", tt.body))
+			if rr.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.want, rr.Body.String())
+			}
+		})
+	}
+	if calls != 0 {
+		t.Fatalf("runner factory calls = %d, want 0 (rejected requests must not run)", calls)
+	}
+}
+
+func TestRunExperimentMarksFailure(t *testing.T) {
+	server, store := newPlanCreateTestServer(t)
+	runner := &stubExperimentRunner{err: errors.New("worktree setup failed"), done: make(chan *experiment.Experiment, 1)}
+	server.SetExperimentRunner(func() (ExperimentRunner, error) { return runner, nil })
+
+	rr := httptest.NewRecorder()
+	server.handleCreateExperiment(rr, experimentRequestAs(&requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator},
+		http.MethodPost, "/api/experiments", `{"prompt":"X","models":["m"]}`))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("unexpected status %d: %s", rr.Code, rr.Body.String())
+	}
+	exp := waitForExperimentRun(t, runner)
+
+	expStore := experiment.NewStoreFromStorage(store)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stored, err := expStore.GetExperiment(exp.ID)
+		if err != nil {
+			t.Fatalf("GetExperiment: %v", err)
+		}
+		if stored != nil && stored.Status == experiment.ExperimentFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("status = %v, want failed", stored)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandleCreateExperimentUsesRequestedTools(t *testing.T) {
+	server, _ := newPlanCreateTestServer(t)
+	runner := &stubExperimentRunner{done: make(chan *experiment.Experiment, 1)}
+	server.SetExperimentRunner(func() (ExperimentRunner, error) { return runner, nil })
+
+	rr := httptest.NewRecorder()
+	server.handleCreateExperiment(rr, experimentRequestAs(&requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator},
+		http.MethodPost, "/api/experiments", `{"prompt":"X","models":["m"],"tools":["read_file"," run_shell ",""]}`))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("unexpected status %d: %s", rr.Code, rr.Body.String())
+	}
+	ran := waitForExperimentRun(t, runner)
+	if got := strings.Join(ran.Variants[0].ToolsAllowed, ","); got != "read_file,run_shell" {
+		t.Fatalf("variant tools = %q, want the requested tools", got)
+	}
+}
+
+type blockingExperimentRunner struct {
+	started chan struct{}
+}
+
+func (r *blockingExperimentRunner) RunExperiment(ctx context.Context, exp *experiment.Experiment) ([]*parallel.AgentResult, error) {
+	close(r.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestRunExperimentStopsWithServerContext(t *testing.T) {
+	server, store := newPlanCreateTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server.runCtx = ctx
+	runner := &blockingExperimentRunner{started: make(chan struct{})}
+	server.SetExperimentRunner(func() (ExperimentRunner, error) { return runner, nil })
+
+	rr := httptest.NewRecorder()
+	server.handleCreateExperiment(rr, experimentRequestAs(&requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator},
+		http.MethodPost, "/api/experiments", `{"prompt":"X","models":["m"]}`))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("unexpected status %d: %s", rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Experiment experimentJSON `json:"experiment"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	select {
+	case <-runner.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("experiment runner was not called")
+	}
+	cancel()
+
+	expStore := experiment.NewStoreFromStorage(store)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stored, err := expStore.GetExperiment(body.Experiment.ID)
+		if err != nil {
+			t.Fatalf("GetExperiment: %v", err)
+		}
+		if stored != nil && stored.Status == experiment.ExperimentFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("status = %v after shutdown, want failed", stored)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFailInterruptedExperimentsOnStart(t *testing.T) {
+	server, store := newPlanCreateTestServer(t)
+	expStore := experiment.NewStoreFromStorage(store)
+	exp := &experiment.Experiment{ID: "orphan", Name: "orphan", Task: experiment.Task{Prompt: "X"}, Variants: []experiment.Variant{{ID: "orphan-v", Name: "m", ModelID: "m"}}}
+	if err := expStore.CreateExperiment(exp); err != nil {
+		t.Fatalf("CreateExperiment: %v", err)
+	}
+	if err := expStore.UpdateExperimentStatus(exp.ID, experiment.ExperimentRunning, nil); err != nil {
+		t.Fatalf("UpdateExperimentStatus: %v", err)
+	}
+
+	server.failInterruptedExperiments()
+	if stored, _ := expStore.GetExperiment(exp.ID); stored.Status != experiment.ExperimentRunning {
+		t.Fatalf("status = %s without an experiment runner, want it left alone", stored.Status)
+	}
+	server.SetExperimentRunner(func() (ExperimentRunner, error) { return nil, errors.New("unused") })
+	server.failInterruptedExperiments()
+	if stored, _ := expStore.GetExperiment(exp.ID); stored.Status != experiment.ExperimentFailed {
+		t.Fatalf("status = %s after startup, want failed", stored.Status)
+	}
+}
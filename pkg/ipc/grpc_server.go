@@ -119,7 +119,9 @@ func (s *GRPCService) dispatchSessionCommand(cmd command.SessionCommand) error {
 	if s.server == nil {
 		return fmt.Errorf("server unavailable")
 	}
-	if s.server.headlessRegistry != nil {
+	// Plugin commands are owned by the gateway; headless runners would only
+	// reject them after queueing.
+	if s.server.headlessRegistry != nil && !command.IsCustomCommand(cmd.Type) {
 		if err := s.server.headlessRegistry.DispatchCommand(cmd); err == nil {
 			return nil
 		}
@@ -815,8 +817,8 @@ func (s *GRPCService) SendCommand(
 	if sessionToken == "" {
 		sessionToken = strings.TrimSpace(req.Header().Get("X-Buckley-Session-Token"))
 	}
-	if !s.server.validateSessionTokenValue(sessionID, sessionToken) {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session token"))
+	if err := s.server.checkSessionTokenValue(sessionID, sessionToken); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
 	cmdType := strings.TrimSpace(msg.Type)
@@ -826,6 +828,11 @@ func (s *GRPCService) SendCommand(
 	if command.RequiresContent(cmdType) && strings.TrimSpace(msg.Content) == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("content required"))
 	}
+	if forbidden, err := s.server.approvalCommandForbidden(principal, sessionID, cmdType, msg.Content); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	} else if forbidden {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("approving a dangerous tool requires operator scope"))
+	}
 
 	cmd := command.SessionCommand{
 		SessionID: sessionID,
@@ -834,8 +841,8 @@ func (s *GRPCService) SendCommand(
 	}
 	cmd.EnsureID()
 
-	// Try headless registry first
-	if s.server.headlessRegistry != nil {
+	// Try headless registry first; plugin commands go straight to the gateway
+	if s.server.headlessRegistry != nil && !command.IsCustomCommand(cmd.Type) {
 		if err := s.server.headlessRegistry.DispatchCommand(cmd); err == nil {
 			return connect.NewResponse(&ipcpb.CommandResponse{
 				Status:    "accepted",
@@ -1097,8 +1104,8 @@ func (s *GRPCService) DeleteHeadlessSession(
 	}
 
 	sessionToken := strings.TrimSpace(req.Header().Get("X-Buckley-Session-Token"))
-	if !s.server.validateSessionTokenValue(sessionID, sessionToken) {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session token"))
+	if err := s.server.checkSessionTokenValue(sessionID, sessionToken); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
 	if reg, ok := s.server.headlessRegistry.(interface {
@@ -1223,8 +1230,8 @@ func (s *GRPCService) WorkflowAction(
 	}
 
 	sessionToken := strings.TrimSpace(req.Header().Get("X-Buckley-Session-Token"))
-	if !s.server.validateSessionTokenValue(sessionID, sessionToken) {
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid session token"))
+	if err := s.server.checkSessionTokenValue(sessionID, sessionToken); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
 	if s.server.commandLimiter != nil && !s.server.commandLimiter.Allow(sessionID) {
@@ -1905,6 +1912,10 @@ func (s *GRPCService) ApproveToolCall(
 		}), nil
 	}
 
+	if s.server.approvalNeedsOperator(approval.ToolName) && !isOperatorPrincipal(principal) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("approving %s requires operator scope", approval.ToolName))
+	}
+
 	if approval.Status == "pending" && !approval.ExpiresAt.IsZero() && time.Now().After(approval.ExpiresAt) {
 		approval.Status = "expired"
 		approval.DecidedBy = ""
@@ -138,6 +138,70 @@ func TestGRPCSendCommandRequiresSessionToken(t *testing.T) {
 	}
 }
 
+func TestGRPCSendCommandRoutesCustomCommandToGateway(t *testing.T) {
+	dir, err := os.MkdirTemp("", "grpc-custom-command-*")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := storage.New(dir + "/buckley.db")
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	defer store.Close()
+
+	gateway := command.NewGateway()
+	var received command.SessionCommand
+	if err := gateway.RegisterCommand("acme:deploy", command.HandlerFunc(func(cmd command.SessionCommand) error {
+		received = cmd
+		return nil
+	})); err != nil {
+		t.Fatalf("RegisterCommand: %v", err)
+	}
+
+	server := NewServer(Config{}, store, nil, gateway, nil, config.DefaultConfig(), nil, nil)
+	registry := newFakeHeadlessRegistry()
+	server.SetHeadlessRegistry(registry)
+	svc := NewGRPCService(server)
+
+	if err := store.CreateSession(&storage.Session{
+		ID:         "s1",
+		Principal:  "member",
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     storage.SessionStatusActive,
+	}); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if err := store.SaveSessionToken("s1", "token"); err != nil {
+		t.Fatalf("SaveSessionToken: %v", err)
+	}
+
+	memberCtx := context.WithValue(context.Background(), principalContextKey, &requestPrincipal{
+		Name:  "member",
+		Scope: storage.TokenScopeMember,
+	})
+	resp, err := svc.SendCommand(memberCtx, connect.NewRequest(&ipcpb.CommandRequest{
+		SessionId:    "s1",
+		SessionToken: "token",
+		Type:         "acme:deploy",
+		Content:      "staging",
+	}))
+	if err != nil {
+		t.Fatalf("SendCommand: %v", err)
+	}
+	if resp.Msg.Status != "accepted" {
+		t.Fatalf("SendCommand status=%q want accepted (msg=%q)", resp.Msg.Status, resp.Msg.Message)
+	}
+	if received.Type != "acme:deploy" || received.Content != "staging" || received.SessionID != "s1" {
+		t.Fatalf("custom handler received %+v", received)
+	}
+	if registry.lastCommand.Type != "" {
+		t.Fatalf("headless registry should not see custom commands, got %+v", registry.lastCommand)
+	}
+}
+
 func TestGRPCWorkflowActionDispatchesSlashCommand(t *testing.T) {
 	store, err := storage.New(t.TempDir() + "/buckley.db")
 	if err != nil {
@@ -672,6 +736,79 @@ func TestGRPCApproveToolCallRejectsExpiredApproval(t *testing.T) {
 	}
 }
 
+func TestGRPCApproveToolCallRequiresOperatorForDangerousTool(t *testing.T) {
+	store, err := storage.New(t.TempDir() + "/buckley.db")
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	defer store.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"run_shell"}
+	server := NewServer(Config{}, store, nil, nil, nil, cfg, nil, nil)
+	server.SetHeadlessRegistry(newFakeHeadlessRegistry())
+	svc := NewGRPCService(server)
+
+	now := time.Now()
+	if err := store.CreateSession(&storage.Session{
+		ID:         "s1",
+		Principal:  "alice",
+		CreatedAt:  now,
+		LastActive: now,
+		Status:     storage.SessionStatusActive,
+	}); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if err := store.CreatePendingApproval(&storage.PendingApproval{
+		ID:        "approval-1",
+		SessionID: "s1",
+		ToolName:  "run_shell",
+		ToolInput: "{}",
+		Status:    "pending",
+		ExpiresAt: now.Add(5 * time.Minute),
+		CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("CreatePendingApproval: %v", err)
+	}
+
+	memberCtx := context.WithValue(context.Background(), principalContextKey, &requestPrincipal{
+		Name:  "alice",
+		Scope: storage.TokenScopeMember,
+	})
+	_, err = svc.ApproveToolCall(memberCtx, connect.NewRequest(&ipcpb.ApproveToolCallRequest{ApprovalId: "approval-1"}))
+	assertConnectCode(t, err, connect.CodePermissionDenied)
+	pending, err := store.GetPendingApproval("approval-1")
+	if err != nil {
+		t.Fatalf("GetPendingApproval: %v", err)
+	}
+	if pending.Status != "pending" {
+		t.Fatalf("status=%q want pending after member approval was denied", pending.Status)
+	}
+
+	if err := store.SaveSessionToken("s1", "session-token"); err != nil {
+		t.Fatalf("SaveSessionToken: %v", err)
+	}
+	_, err = svc.SendCommand(memberCtx, connect.NewRequest(&ipcpb.CommandRequest{
+		SessionId:    "s1",
+		SessionToken: "session-token",
+		Type:         "approval",
+		Content:      "yes",
+	}))
+	assertConnectCode(t, err, connect.CodePermissionDenied)
+
+	operatorCtx := context.WithValue(context.Background(), principalContextKey, &requestPrincipal{
+		Name:  "ops",
+		Scope: storage.TokenScopeOperator,
+	})
+	resp, err := svc.ApproveToolCall(operatorCtx, connect.NewRequest(&ipcpb.ApproveToolCallRequest{ApprovalId: "approval-1"}))
+	if err != nil {
+		t.Fatalf("ApproveToolCall: %v", err)
+	}
+	if !resp.Msg.Success {
+		t.Fatalf("expected success, got message=%q", resp.Msg.Message)
+	}
+}
+
 func TestGRPCListPendingApprovalsSkipsExpiredApprovals(t *testing.T) {
 	store, err := storage.New(t.TempDir() + "/buckley.db")
 	if err != nil {
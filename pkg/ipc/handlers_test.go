@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -780,6 +781,65 @@ func TestHandleListSessions_WithLimit(t *testing.T) {
 	}
 }
 
+func TestHandleListSessions_CursorPaging(t *testing.T) {
+	server, store := testServer(t)
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, principal := range []string{"test", "other", "test", "test"} {
+		sess := &storage.Session{
+			ID:         "session-" + string(rune('a'+i)),
+			Principal:  principal,
+			CreatedAt:  base,
+			LastActive: base.Add(time.Duration(i) * time.Minute),
+			Status:     storage.SessionStatusActive,
+		}
+		if err := store.CreateSession(sess); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+
+	list := func(query string) (int, []string, string) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions"+query, nil)
+		req = withPrincipal(req, "test", storage.TokenScopeViewer)
+		rr := httptest.NewRecorder()
+		server.handleListSessions(rr, req)
+
+		var resp struct {
+			Sessions   []storage.Session `json:"sessions"`
+			NextCursor string            `json:"nextCursor"`
+		}
+		if rr.Code == http.StatusOK {
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+		}
+		ids := make([]string, 0, len(resp.Sessions))
+		for _, sess := range resp.Sessions {
+			ids = append(ids, sess.ID)
+		}
+		return rr.Code, ids, resp.NextCursor
+	}
+
+	code, ids, next := list("?limit=2")
+	if code != http.StatusOK || strings.Join(ids, ",") != "session-d,session-c" || next == "" {
+		t.Fatalf("first page: code=%d ids=%v next=%q", code, ids, next)
+	}
+	// session-b belongs to another principal, so this page is short.
+	code, ids, next = list("?limit=2&cursor=" + url.QueryEscape(next))
+	if code != http.StatusOK || strings.Join(ids, ",") != "session-a" || next != "" {
+		t.Fatalf("second page: code=%d ids=%v next=%q", code, ids, next)
+	}
+
+	code, ids, _ = list("?before=" + url.QueryEscape(base.Add(2*time.Minute).Format(time.RFC3339)))
+	if code != http.StatusOK || strings.Join(ids, ",") != "session-a" {
+		t.Fatalf("before page: code=%d ids=%v", code, ids)
+	}
+
+	if code, _, _ := list("?cursor=not-a-cursor"); code != http.StatusBadRequest {
+		t.Fatalf("expected bad cursor to be rejected, got %d", code)
+	}
+}
+
 func TestHandleListSessions_Unauthorized(t *testing.T) {
 	server, _ := testServer(t)
 
@@ -883,6 +943,13 @@ func TestHandleCostMetrics_Success(t *testing.T) {
 	if rr.Code != http.StatusOK {
 		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
 	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if string(body["latency"]) != "[]" {
+		t.Errorf("latency = %s, want empty list without a model manager", body["latency"])
+	}
 }
 
 func TestHandleCostMetrics_ForbiddenForViewer(t *testing.T) {
@@ -1122,6 +1189,107 @@ func TestHandleSessionTodos_NotFound(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Session Tool Calls Handler Tests
+// =============================================================================
+
+func TestHandleSessionToolCalls_Success(t *testing.T) {
+	server, store := testServer(t)
+
+	sess := &storage.Session{
+		ID:         "test-session",
+		Principal:  "test",
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     storage.SessionStatusActive,
+	}
+	if err := store.CreateSession(sess); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	for i, name := range []string{"read_file", "run_shell"} {
+		if err := store.LogToolExecution(&storage.ToolAuditEntry{
+			SessionID:  "test-session",
+			ToolName:   name,
+			ToolInput:  `{"n":` + string(rune('0'+i)) + `}`,
+			ToolOutput: "done",
+			Decision:   "auto",
+			ExecutedAt: time.Now().Add(time.Duration(i) * time.Second),
+			DurationMs: int64(10 * (i + 1)),
+			Success:    i == 0,
+		}); err != nil {
+			t.Fatalf("failed to log tool call: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/test-session/tool-calls?limit=1", nil)
+	req = withPrincipal(req, "test", storage.TokenScopeViewer)
+	req = withURLParam(req, "sessionID", "test-session")
+	rr := httptest.NewRecorder()
+
+	server.handleSessionToolCalls(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var resp struct {
+		SessionID string                   `json:"sessionId"`
+		ToolCalls []storage.ToolAuditEntry `json:"toolCalls"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.SessionID != "test-session" {
+		t.Errorf("expected sessionId 'test-session', got %s", resp.SessionID)
+	}
+	if len(resp.ToolCalls) != 1 {
+		t.Fatalf("expected limit to return 1 tool call, got %d", len(resp.ToolCalls))
+	}
+	call := resp.ToolCalls[0]
+	if call.ToolName != "run_shell" || call.Success || call.DurationMs != 20 || call.ArgsHash == "" || call.ResultSummary != "done" {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+}
+
+func TestHandleSessionToolCalls_EmptyAndNotFound(t *testing.T) {
+	server, store := testServer(t)
+
+	sess := &storage.Session{
+		ID:         "quiet-session",
+		Principal:  "test",
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     storage.SessionStatusActive,
+	}
+	if err := store.CreateSession(sess); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/quiet-session/tool-calls", nil)
+	req = withPrincipal(req, "test", storage.TokenScopeViewer)
+	req = withURLParam(req, "sessionID", "quiet-session")
+	rr := httptest.NewRecorder()
+	server.handleSessionToolCalls(rr, req)
+	var resp struct {
+		ToolCalls []storage.ToolAuditEntry `json:"toolCalls"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusOK || resp.ToolCalls == nil || len(resp.ToolCalls) != 0 {
+		t.Errorf("expected empty toolCalls list, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/sessions/nonexistent/tool-calls", nil)
+	req = withPrincipal(req, "test", storage.TokenScopeViewer)
+	req = withURLParam(req, "sessionID", "nonexistent")
+	rr = httptest.NewRecorder()
+	server.handleSessionToolCalls(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
 // =============================================================================
 // Session Skills Handler Tests
 // =============================================================================
@@ -1824,6 +1992,49 @@ func TestHandleGetPlanTasks_NilPlanStore(t *testing.T) {
 	}
 }
 
+func TestHandleGetPlanStatus_MixedTaskStates(t *testing.T) {
+	server, _ := testServer(t)
+
+	plan := &orchestrator.Plan{
+		ID:          "plan-mixed",
+		FeatureName: "Mixed",
+		Tasks: []orchestrator.Task{
+			{ID: "1", Title: "Done task", Status: orchestrator.TaskCompleted},
+			{ID: "2", Title: "Skipped task", Status: orchestrator.TaskSkipped},
+			{ID: "3", Title: "Running task", Status: orchestrator.TaskInProgress},
+			{ID: "4", Title: "Failed task", Status: orchestrator.TaskFailed},
+			{ID: "5", Title: "Pending task", Status: orchestrator.TaskPending},
+		},
+	}
+	if err := server.planStore.SavePlan(plan); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/plans/plan-mixed/status", nil)
+	req = withPrincipal(req, "admin", storage.TokenScopeOperator)
+	req = withURLParam(req, "planID", "plan-mixed")
+	rr := httptest.NewRecorder()
+
+	server.handleGetPlanStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var body planStatusSummary
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.Total != 5 || body.Done != 1 || body.Skipped != 1 || body.Running != 1 || body.Failed != 1 || body.Pending != 1 {
+		t.Fatalf("unexpected counts: %+v", body)
+	}
+	if body.PercentComplete != 40 {
+		t.Errorf("percentComplete = %v, want 40", body.PercentComplete)
+	}
+	if body.CurrentTask == nil || body.CurrentTask.ID != "3" {
+		t.Errorf("currentTask = %+v, want task 3", body.CurrentTask)
+	}
+}
+
 // =============================================================================
 // Plan Log Handler Tests
 // =============================================================================
@@ -84,7 +84,7 @@ func (s *Server) handleCreateHeadlessSession(w http.ResponseWriter, r *http.Requ
 	}
 
 	var req headless.CreateSessionRequest
-	if status, err := decodeJSONBody(w, r, &req, maxBodyBytesCommand, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesCommand), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -264,8 +264,8 @@ func (s *Server) handleDeleteHeadlessSession(w http.ResponseWriter, r *http.Requ
 		respondError(w, http.StatusNotFound, fmt.Errorf("session not found"))
 		return
 	}
-	if !s.validateSessionToken(r, sessionID) {
-		respondError(w, http.StatusUnauthorized, fmt.Errorf("invalid session token"))
+	if err := s.checkSessionToken(r, sessionID); err != nil {
+		respondError(w, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -301,8 +301,8 @@ func (s *Server) handleHeadlessCommand(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, fmt.Errorf("session not found"))
 		return
 	}
-	if !s.validateSessionToken(r, sessionID) {
-		respondError(w, http.StatusUnauthorized, fmt.Errorf("invalid session token"))
+	if err := s.checkSessionToken(r, sessionID); err != nil {
+		respondError(w, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -310,7 +310,7 @@ func (s *Server) handleHeadlessCommand(w http.ResponseWriter, r *http.Request) {
 		Type    string `json:"type"`
 		Content string `json:"content"`
 	}
-	if status, err := decodeJSONBody(w, r, &payload, maxBodyBytesCommand, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &payload, s.bodyLimit(maxBodyBytesCommand), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -365,8 +365,8 @@ func (s *Server) handleAdoptHeadlessSession(w http.ResponseWriter, r *http.Reque
 		respondError(w, http.StatusNotFound, fmt.Errorf("session not found"))
 		return
 	}
-	if !s.validateSessionToken(r, sessionID) {
-		respondError(w, http.StatusUnauthorized, fmt.Errorf("invalid session token"))
+	if err := s.checkSessionToken(r, sessionID); err != nil {
+		respondError(w, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -392,6 +392,7 @@ func (s *Server) InitHeadlessRegistry(ctx context.Context) *headless.Registry {
 		Store:        s.store,
 		ModelManager: s.models,
 		Config:       s.appConfig,
+		LiveConfig:   s.liveConfig,
 		ProjectRoot:  s.projectRoot,
 		Telemetry:    s.telemetry,
 		Emitter:      s.NewHeadlessEmitter(),
@@ -12,8 +12,29 @@ const (
 	maxBodyBytesTiny    int64 = 64 << 10
 	maxBodyBytesSmall   int64 = 1 << 20
 	maxBodyBytesCommand int64 = 8 << 20
+
+	minConfigurableBodyBytes = maxBodyBytesTiny
+	maxConfigurableBodyBytes = 1 << 30
 )
 
+// bodyLimit returns the request body cap for an endpoint whose built-in
+// limit is defaultMax. A configured MaxBodyBytes replaces every limit above
+// maxBodyBytesTiny, so auth and flag endpoints stay small.
+func (s *Server) bodyLimit(defaultMax int64) int64 {
+	if s == nil || s.cfg.MaxBodyBytes <= 0 || defaultMax <= maxBodyBytesTiny {
+		return defaultMax
+	}
+	return s.cfg.MaxBodyBytes
+}
+
+// ValidateMaxBodyBytes reports whether n can be used as Config.MaxBodyBytes.
+func ValidateMaxBodyBytes(n int64) error {
+	if n < minConfigurableBodyBytes || n > maxConfigurableBodyBytes {
+		return fmt.Errorf("max body size must be between %d KiB and %d MiB, got %d bytes", minConfigurableBodyBytes>>10, maxConfigurableBodyBytes>>20, n)
+	}
+	return nil
+}
+
 func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst any, maxBytes int64, allowEOF bool) (int, error) {
 	if r == nil || r.Body == nil {
 		if allowEOF {
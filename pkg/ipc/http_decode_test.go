@@ -132,3 +132,34 @@ func TestDecodeJSONBodyNoLimit(t *testing.T) {
 		t.Fatalf("expected name=test, got %s", dst.Name)
 	}
 }
+
+func TestServerBodyLimit(t *testing.T) {
+	s := &Server{}
+	if got := s.bodyLimit(maxBodyBytesCommand); got != maxBodyBytesCommand {
+		t.Fatalf("unconfigured limit = %d, want %d", got, maxBodyBytesCommand)
+	}
+
+	s.cfg.MaxBodyBytes = 32 << 20
+	if got := s.bodyLimit(maxBodyBytesSmall); got != 32<<20 {
+		t.Fatalf("small limit = %d, want configured %d", got, 32<<20)
+	}
+	if got := s.bodyLimit(maxConnectRequestBytes); got != 32<<20 {
+		t.Fatalf("connect limit = %d, want configured %d", got, 32<<20)
+	}
+	if got := s.bodyLimit(maxBodyBytesTiny); got != maxBodyBytesTiny {
+		t.Fatalf("tiny limit = %d, want fixed %d", got, maxBodyBytesTiny)
+	}
+}
+
+func TestValidateMaxBodyBytes(t *testing.T) {
+	for _, n := range []int64{minConfigurableBodyBytes, 16 << 20, maxConfigurableBodyBytes} {
+		if err := ValidateMaxBodyBytes(n); err != nil {
+			t.Errorf("ValidateMaxBodyBytes(%d) = %v, want nil", n, err)
+		}
+	}
+	for _, n := range []int64{0, -1, minConfigurableBodyBytes - 1, maxConfigurableBodyBytes + 1} {
+		if err := ValidateMaxBodyBytes(n); err == nil {
+			t.Errorf("ValidateMaxBodyBytes(%d) = nil, want error", n)
+		}
+	}
+}
@@ -7,6 +7,15 @@ const (
 	maxEventStreamClients = 128
 	maxPTYClients         = 8
 
+	// Plan generation is a long planning-model call; the orchestrator also
+	// tracks a single current plan, so requests are serialized.
+	maxConcurrentPlanCreations = 1
+
+	// Each experiment runs its variants in parallel worktrees, so only a few
+	// run at once.
+	maxConcurrentExperiments = 2
+	maxExperimentVariants    = 8
+
 	maxGRPCSubscribersTotal        = 256
 	maxGRPCSubscribersPerPrincipal = 16
 
@@ -13,6 +13,9 @@ func TestLimitsConstants(t *testing.T) {
 		{"maxConnectRequestBytes", int64(maxConnectRequestBytes), 1 << 20},
 		{"maxEventStreamClients", int64(maxEventStreamClients), 1},
 		{"maxPTYClients", int64(maxPTYClients), 1},
+		{"maxConcurrentPlanCreations", int64(maxConcurrentPlanCreations), 1},
+		{"maxConcurrentExperiments", int64(maxConcurrentExperiments), 1},
+		{"maxExperimentVariants", int64(maxExperimentVariants), 2},
 		{"maxGRPCSubscribersTotal", int64(maxGRPCSubscribersTotal), 1},
 		{"maxGRPCSubscribersPerPrincipal", int64(maxGRPCSubscribersPerPrincipal), 1},
 		{"maxWSReadBytesEventStream", int64(maxWSReadBytesEventStream), 1 << 10},
@@ -4,11 +4,15 @@ import (
 	"context"
 	stdliberrors "errors"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
+	"connectrpc.com/connect"
+
 	"m31labs.dev/buckley/pkg/storage"
 )
 
@@ -24,8 +28,9 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 				}
 			}
 		}
-		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Buckley-Session-Token, Connect-Protocol-Version, Connect-Accept-Encoding")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Buckley-Session-Token, X-Request-ID, Connect-Protocol-Version, Connect-Accept-Encoding")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Retry-After")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
@@ -159,6 +164,36 @@ func (s *Server) basicAuthMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// ValidateOrigin reports whether origin can be used as an AllowedOrigins
+// entry: "*" or an http(s) scheme://host[:port] with no path, query, or
+// credentials.
+func ValidateOrigin(origin string) error {
+	origin = strings.TrimSpace(origin)
+	if origin == "*" {
+		return nil
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return fmt.Errorf("invalid origin %q: %w", origin, err)
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return fmt.Errorf("invalid origin %q: scheme must be http or https", origin)
+	}
+	if parsed.Host == "" || parsed.Hostname() == "" {
+		return fmt.Errorf("invalid origin %q: missing host", origin)
+	}
+	if parsed.User != nil || (parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("invalid origin %q: expected scheme://host[:port]", origin)
+	}
+	if port := parsed.Port(); port != "" {
+		if _, err := net.LookupPort("tcp", port); err != nil {
+			return fmt.Errorf("invalid origin %q: bad port %q", origin, port)
+		}
+	}
+	return nil
+}
+
 // isOriginAllowed checks if the provided origin is in the allowed origins list.
 func (s *Server) isOriginAllowed(origin string) (allowed bool, wildcard bool) {
 	origin = strings.TrimSpace(origin)
@@ -288,18 +323,54 @@ func (s *Server) authMiddleware(next http.Handler) http.Handler {
 			respondError(w, http.StatusUnauthorized, stdliberrors.New("unauthorized"))
 			return
 		}
+		if !s.allowPrincipalRequest(w, principal) {
+			return
+		}
 		ctx := context.WithValue(r.Context(), principalContextKey, principal)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// allowPrincipalRequest applies the per-principal rate limit and writes a 429
+// with Retry-After when it is exceeded. Anonymous requests are not limited.
+func (s *Server) allowPrincipalRequest(w http.ResponseWriter, principal *requestPrincipal) bool {
+	if s.takePrincipalToken(w, principal) {
+		return true
+	}
+	respondError(w, http.StatusTooManyRequests, stdliberrors.New("rate limit exceeded"))
+	return false
+}
+
+// takePrincipalToken charges one request to the principal's bucket. When the
+// bucket is empty it sets Retry-After and the caller writes the refusal.
+func (s *Server) takePrincipalToken(w http.ResponseWriter, principal *requestPrincipal) bool {
+	if principal == nil || principal.Name == "anonymous" {
+		return true
+	}
+	key := principal.Name
+	if principal.TokenID != "" {
+		key = "token:" + principal.TokenID
+	}
+	allowed, wait := s.principalLimiter.Allow(key)
+	if allowed {
+		return true
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	return false
+}
+
 // authContextMiddleware attaches the current request principal if authorized.
 // Unlike authMiddleware, it does not short-circuit unauthenticated requests. This is
 // used for Connect/gRPC endpoints so Connect can return protocol-native errors.
+// Authorized requests still count against the per-principal rate limit.
 func (s *Server) authContextMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		principal, ok := s.authorize(r)
 		if ok {
+			if !s.takePrincipalToken(w, principal) {
+				_ = connect.NewErrorWriter().Write(w, r, connect.NewError(connect.CodeResourceExhausted, stdliberrors.New("rate limit exceeded")))
+				return
+			}
 			ctx := context.WithValue(r.Context(), principalContextKey, principal)
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
@@ -310,6 +381,16 @@ func (s *Server) authContextMiddleware(next http.Handler) http.Handler {
 
 // authorize validates the request and returns the associated principal.
 func (s *Server) authorize(r *http.Request) (*requestPrincipal, bool) {
+	principal, ok := s.resolvePrincipal(r)
+	if ok {
+		if info := requestInfoFromContext(r.Context()); info != nil {
+			info.principal = principal
+		}
+	}
+	return principal, ok
+}
+
+func (s *Server) resolvePrincipal(r *http.Request) (*requestPrincipal, bool) {
 	if principal := principalFromContext(r.Context()); principal != nil {
 		return principal, true
 	}
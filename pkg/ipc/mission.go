@@ -1,7 +1,6 @@
 package ipc
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,7 +10,6 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oklog/ulid/v2"
-	"nhooyr.io/websocket"
 
 	"m31labs.dev/buckley/pkg/ipc/command"
 	"m31labs.dev/buckley/pkg/mission"
@@ -189,7 +187,7 @@ func (s *Server) handleSendAgentMessage(w http.ResponseWriter, r *http.Request)
 	agentID := chi.URLParam(r, "agentID")
 
 	var req mission.AgentMessageRequest
-	if status, err := decodeJSONBody(w, r, &req, maxBodyBytesCommand, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesCommand), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -214,8 +212,8 @@ func (s *Server) handleSendAgentMessage(w http.ResponseWriter, r *http.Request)
 			respondError(w, http.StatusNotFound, errors.New("session not found"))
 			return
 		}
-		if !s.validateSessionToken(r, sessionID) {
-			respondError(w, http.StatusUnauthorized, errors.New("invalid session token"))
+		if err := s.checkSessionToken(r, sessionID); err != nil {
+			respondError(w, http.StatusUnauthorized, err)
 			return
 		}
 	}
@@ -339,8 +337,8 @@ func (s *Server) handleApproveChange(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if change.SessionID != "" && !s.validateSessionToken(r, change.SessionID) {
-		respondError(w, http.StatusUnauthorized, errors.New("invalid session token"))
+	if err := s.checkSessionToken(r, change.SessionID); err != nil {
+		respondError(w, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -396,8 +394,8 @@ func (s *Server) handleRejectChange(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if change.SessionID != "" && !s.validateSessionToken(r, change.SessionID) {
-		respondError(w, http.StatusUnauthorized, errors.New("invalid session token"))
+	if err := s.checkSessionToken(r, change.SessionID); err != nil {
+		respondError(w, http.StatusUnauthorized, err)
 		return
 	}
 
@@ -434,85 +432,17 @@ func (s *Server) handleRejectChange(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleMissionEvents(w http.ResponseWriter, r *http.Request) {
-	principal, ok := s.authorize(r)
-	if !ok || principal == nil {
-		respondError(w, http.StatusUnauthorized, errors.New("unauthorized"))
-		return
-	}
-	if !s.isWebSocketOriginAllowed(r) {
-		respondError(w, http.StatusForbidden, errors.New("forbidden"))
-		return
-	}
-	if s.eventConnLimiter != nil && !s.eventConnLimiter.Acquire() {
-		respondError(w, http.StatusTooManyRequests, errors.New("too many connections"))
-		return
-	}
-	defer func() {
-		if s.eventConnLimiter != nil {
-			s.eventConnLimiter.Release()
-		}
-	}()
-
-	sessionID := strings.TrimSpace(r.URL.Query().Get("sessionId"))
-	if !isOperatorPrincipal(principal) {
-		if sessionID == "" {
-			respondError(w, http.StatusForbidden, errors.New("sessionId required"))
-			return
-		}
-		sess, err := s.store.GetSession(sessionID)
-		if err != nil {
-			respondError(w, http.StatusInternalServerError, err)
-			return
-		}
-		if sess == nil || !principalCanAccessSession(principal, sess) {
-			respondError(w, http.StatusNotFound, errors.New("session not found"))
-			return
-		}
-	}
-
-	// InsecureSkipVerify disables the library's built-in Origin check.
-	// Origin validation is already performed above via isWebSocketOriginAllowed,
-	// so the library's check would be redundant.
-	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
-		InsecureSkipVerify: true,
-	})
-	if err != nil {
-		s.logger.Printf("mission websocket accept failed: %v", err)
-		return
-	}
-	conn.SetReadLimit(maxWSReadBytesEventStream)
-
-	filter := func(event Event) bool {
-		if !strings.HasPrefix(event.Type, "mission.") {
-			return false
-		}
-		if sessionID != "" {
-			return event.SessionID == sessionID
-		}
-		return true
-	}
-
-	client := s.hub.register(conn, filter)
-	ctx, cancel := context.WithCancel(r.Context())
-	startWSPing(ctx, conn)
-
-	go func() {
-		defer cancel()
-		s.readClient(ctx, client)
-	}()
-
-	go func() {
-		if err := client.writeLoop(ctx); err != nil {
-			s.logger.Printf("mission websocket write error: %v", err)
-			cancel()
+	s.serveEventStream(w, r, "mission", func(sessionID string, _ bool) func(Event) bool {
+		return func(event Event) bool {
+			if !strings.HasPrefix(event.Type, "mission.") {
+				return false
+			}
+			if sessionID != "" {
+				return event.SessionID == sessionID
+			}
+			return true
 		}
-	}()
-
-	s.sendMissionSnapshot(client, sessionID)
-
-	<-ctx.Done()
-	s.hub.removeClient(client)
-	client.close(websocket.StatusNormalClosure, "shutdown")
+	}, s.sendMissionSnapshot)
 }
 
 func (s *Server) sendMissionSnapshot(c *client, sessionID string) {
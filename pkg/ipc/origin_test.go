@@ -98,3 +98,30 @@ func TestWebSocketOriginAllowed_DisallowedOriginRejected(t *testing.T) {
 		t.Fatalf("expected websocket origin to be rejected")
 	}
 }
+
+func TestValidateOrigin(t *testing.T) {
+	for _, origin := range []string{"*", "http://localhost:5173", "https://app.example.com", "http://[::1]:3000", "http://127.0.0.1/"} {
+		if err := ValidateOrigin(origin); err != nil {
+			t.Fatalf("ValidateOrigin(%q) = %v, want nil", origin, err)
+		}
+	}
+	for _, origin := range []string{"", "localhost:5173", "ftp://example.com", "http://", "http://example.com/app", "http://user@example.com", "http://example.com?x=1", "http://example.com:99999"} {
+		if err := ValidateOrigin(origin); err == nil {
+			t.Fatalf("ValidateOrigin(%q) = nil, want error", origin)
+		}
+	}
+}
+
+func TestIsOriginAllowed_OverriddenOriginsReplaceDefaults(t *testing.T) {
+	s := &Server{cfg: Config{AllowedOrigins: []string{"http://localhost:5173", "https://staging.example.com"}}}
+
+	if allowed, _ := s.isOriginAllowed("http://localhost:5173"); !allowed {
+		t.Fatalf("expected dev frontend origin allowed")
+	}
+	if allowed, _ := s.isOriginAllowed("https://staging.example.com"); !allowed {
+		t.Fatalf("expected staging origin allowed")
+	}
+	if allowed, _ := s.isOriginAllowed("http://localhost:3000"); allowed {
+		t.Fatalf("expected origin on another port rejected once defaults are replaced")
+	}
+}
@@ -0,0 +1,93 @@
+package ipc
+
+import (
+	stdliberrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/orchestrator"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// PlanCreator generates and persists a new feature plan.
+type PlanCreator interface {
+	PlanFeature(featureName, description string) (*orchestrator.Plan, error)
+}
+
+type createPlanRequest struct {
+	FeatureName string `json:"featureName"`
+	Description string `json:"description"`
+	SessionID   string `json:"sessionId,omitempty"`
+}
+
+// SetPlanCreator attaches the planner used by POST /api/plans.
+func (s *Server) SetPlanCreator(planner PlanCreator) {
+	s.planCreator = planner
+}
+
+func (s *Server) handleCreatePlan(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeMember)
+	if !ok {
+		return
+	}
+	var req createPlanRequest
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesSmall), false); err != nil {
+		respondError(w, status, err)
+		return
+	}
+	featureName := strings.TrimSpace(req.FeatureName)
+	description := strings.TrimSpace(req.Description)
+	if featureName == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("featureName required"))
+		return
+	}
+	if description == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("description required"))
+		return
+	}
+	if s.planCreator == nil {
+		respondError(w, http.StatusServiceUnavailable, fmt.Errorf("planner unavailable"))
+		return
+	}
+
+	sessionID := strings.TrimSpace(req.SessionID)
+	if sessionID != "" {
+		session, err := s.store.GetSession(sessionID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if session == nil || !principalCanAccessSession(principal, session) {
+			respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+			return
+		}
+	}
+
+	if !s.planLimiter.Acquire() {
+		respondError(w, http.StatusTooManyRequests, stdliberrors.New("too many plan requests in progress"))
+		return
+	}
+	plan, err := s.planCreator.PlanFeature(featureName, description)
+	s.planLimiter.Release()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if plan == nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("planner returned no plan"))
+		return
+	}
+
+	// Members only see plans linked to their sessions, so link the new plan
+	// when the caller names one.
+	if sessionID != "" {
+		if err := s.store.LinkSessionToPlan(sessionID, plan.ID); err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	respondJSONStatus(w, http.StatusCreated, map[string]any{
+		"plan": plan,
+	})
+}
@@ -0,0 +1,168 @@
+package ipc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/orchestrator"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+type stubPlanCreator struct {
+	calls   int
+	release chan struct{}
+	started chan struct{}
+}
+
+func (p *stubPlanCreator) PlanFeature(featureName, description string) (*orchestrator.Plan, error) {
+	p.calls++
+	if p.started != nil {
+		close(p.started)
+	}
+	if p.release != nil {
+		<-p.release
+	}
+	return &orchestrator.Plan{ID: "plan-" + strings.ToLower(featureName), FeatureName: featureName, Description: description}, nil
+}
+
+func newPlanCreateTestServer(t *testing.T) (*Server, *storage.Store) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	store, err := storage.New(filepath.Join(tmpDir, "buckley.db"))
+	if err != nil {
+		t.Fatalf("storage.New: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	planStore := orchestrator.NewFilePlanStore(filepath.Join(tmpDir, "plans"))
+	return NewServer(Config{ProjectRoot: tmpDir}, store, nil, nil, planStore, config.DefaultConfig(), nil, nil), store
+}
+
+func createPlanRequestAs(principal *requestPrincipal, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/plans", strings.NewReader(body))
+	return req.WithContext(context.WithValue(req.Context(), principalContextKey, principal))
+}
+
+func TestHandleCreatePlan(t *testing.T) {
+	server, store := newPlanCreateTestServer(t)
+	planner := &stubPlanCreator{}
+	server.SetPlanCreator(planner)
+
+	now := time.Now()
+	if err := store.CreateSession(&storage.Session{ID: "s-alice", Principal: "alice", CreatedAt: now, LastActive: now, Status: storage.SessionStatusActive}); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	alice := &requestPrincipal{Name: "alice", Scope: storage.TokenScopeMember}
+
+	rr := httptest.NewRecorder()
+	server.handleCreatePlan(rr, createPlanRequestAs(alice, `{"featureName":"Search","description":"Add search","sessionId":"s-alice"}`))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("unexpected status %d: %s", rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Plan orchestrator.Plan `json:"plan"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.Plan.ID != "plan-search" || body.Plan.Description != "Add search" {
+		t.Fatalf("plan = %+v", body.Plan)
+	}
+	if linked, err := store.PrincipalHasPlan("alice", "plan-search"); err != nil || !linked {
+		t.Fatalf("PrincipalHasPlan = %v, %v; want plan linked to the caller's session", linked, err)
+	}
+
+	tests := []struct {
+		name      string
+		principal *requestPrincipal
+		body      string
+		want      int
+	}{
+		{name: "viewer forbidden", principal: &requestPrincipal{Name: "alice", Scope: storage.TokenScopeViewer}, body: `{"featureName":"X","description":"Y"}`, want: http.StatusForbidden},
+		{name: "missing feature name", principal: alice, body: `{"description":"Y"}`, want: http.StatusBadRequest},
+		{name: "missing description", principal: alice, body: `{"featureName":"X"}`, want: http.StatusBadRequest},
+		{name: "other principal's session", principal: &requestPrincipal{Name: "bob", Scope: storage.TokenScopeMember}, body: `{"featureName":"X","description":"Y","sessionId":"s-alice"}`, want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.handleCreatePlan(rr, createPlanRequestAs(tt.principal, tt.body))
+			if rr.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.want, rr.Body.String())
+			}
+		})
+	}
+	if planner.calls != 1 {
+		t.Fatalf("planner calls = %d, want 1 (rejected requests must not plan)", planner.calls)
+	}
+}
+
+func TestHandleCreatePlanWithoutPlanner(t *testing.T) {
+	server, _ := newPlanCreateTestServer(t)
+
+	rr := httptest.NewRecorder()
+	server.handleCreatePlan(rr, createPlanRequestAs(&requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator}, `{"featureName":"X","description":"Y"}`))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestHandleCreatePlanHonorsConfiguredMaxBody(t *testing.T) {
+	server, _ := newPlanCreateTestServer(t)
+	server.SetPlanCreator(&stubPlanCreator{})
+	member := &requestPrincipal{Name: "alice", Scope: storage.TokenScopeMember}
+	planBody := func(size int) string {
+		return `{"featureName":"Big","description":"` + strings.Repeat("x", size) + `"}`
+	}
+
+	rr := httptest.NewRecorder()
+	server.handleCreatePlan(rr, createPlanRequestAs(member, planBody(int(maxBodyBytesSmall))))
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("default limit status = %d, want 413", rr.Code)
+	}
+
+	server.cfg.MaxBodyBytes = 2 << 20
+	rr = httptest.NewRecorder()
+	server.handleCreatePlan(rr, createPlanRequestAs(member, planBody(int(maxBodyBytesSmall))))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("under configured limit status = %d, want 201: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	server.handleCreatePlan(rr, createPlanRequestAs(member, planBody(2<<20)))
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("over configured limit status = %d, want 413", rr.Code)
+	}
+}
+
+func TestHandleCreatePlanRejectsConcurrentRequests(t *testing.T) {
+	server, _ := newPlanCreateTestServer(t)
+	planner := &stubPlanCreator{release: make(chan struct{}), started: make(chan struct{})}
+	server.SetPlanCreator(planner)
+	operator := &requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator}
+
+	done := make(chan int, 1)
+	go func() {
+		rr := httptest.NewRecorder()
+		server.handleCreatePlan(rr, createPlanRequestAs(operator, `{"featureName":"First","description":"one"}`))
+		done <- rr.Code
+	}()
+	<-planner.started
+
+	rr := httptest.NewRecorder()
+	server.handleCreatePlan(rr, createPlanRequestAs(operator, `{"featureName":"Second","description":"two"}`))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429 while a plan is in progress: %s", rr.Code, rr.Body.String())
+	}
+
+	close(planner.release)
+	if code := <-done; code != http.StatusCreated {
+		t.Fatalf("first request status = %d, want 201", code)
+	}
+}
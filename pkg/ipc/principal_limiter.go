@@ -0,0 +1,76 @@
+package ipc
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+// principalLimiter is a token bucket per principal, so one busy client
+// cannot use up another's request budget.
+type principalLimiter struct {
+	rate    float64 // tokens per second
+	burst   float64
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newPrincipalLimiter returns nil, which allows every request, when the
+// config sets no request budget. Burst defaults to the per-interval budget.
+func newPrincipalLimiter(cfg config.IPCRateLimitConfig) *principalLimiter {
+	if cfg.Requests <= 0 || cfg.Interval <= 0 {
+		return nil
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = cfg.Requests
+	}
+	return &principalLimiter{
+		rate:    float64(cfg.Requests) / cfg.Interval.Seconds(),
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Allow takes a token from key's bucket. When the bucket is empty it
+// reports how long until the next token is available.
+func (l *principalLimiter) Allow(key string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	} else {
+		bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+		bucket.last = now
+	}
+
+	// Full buckets carry no state, so drop them to bound memory.
+	if len(l.buckets) > 10000 {
+		for k, b := range l.buckets {
+			if k != key && b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+				delete(l.buckets, k)
+			}
+		}
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
@@ -0,0 +1,152 @@
+package ipc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/ipc/command"
+	"m31labs.dev/buckley/pkg/ipc/proto/ipcpbconnect"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestPrincipalLimiterBurstPerKey(t *testing.T) {
+	limiter := newPrincipalLimiter(config.IPCRateLimitConfig{Requests: 2, Interval: time.Hour})
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow("alice"); !ok {
+			t.Fatalf("expected request %d within burst to be allowed", i+1)
+		}
+	}
+	ok, wait := limiter.Allow("alice")
+	if ok {
+		t.Fatalf("expected request past burst to be refused")
+	}
+	if wait <= 0 || wait > 30*time.Minute+time.Second {
+		t.Fatalf("expected a retry delay of about 30m, got %v", wait)
+	}
+	if ok, _ := limiter.Allow("bob"); !ok {
+		t.Fatalf("expected a different principal to have its own bucket")
+	}
+}
+
+func TestPrincipalLimiterDisabledAlwaysAllows(t *testing.T) {
+	var nilLimiter *principalLimiter
+	if ok, _ := nilLimiter.Allow("alice"); !ok {
+		t.Fatalf("expected nil limiter to allow")
+	}
+	if limiter := newPrincipalLimiter(config.IPCRateLimitConfig{}); limiter != nil {
+		t.Fatalf("expected zero config to disable the limiter")
+	}
+}
+
+func TestAuthMiddleware_RateLimited(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := storage.New(filepath.Join(tmpDir, "buckley.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	appCfg := &config.Config{}
+	appCfg.IPC.RateLimit = config.IPCRateLimitConfig{Requests: 1, Interval: time.Minute}
+	server := NewServer(
+		Config{
+			ProjectRoot: tmpDir,
+			AuthToken:   "test-token",
+		},
+		store,
+		nil,
+		command.NewGateway(),
+		nil,
+		appCfg,
+		nil,
+		nil,
+	)
+
+	handler := server.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	send := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := send("test-token"); rr.Code != http.StatusOK {
+		t.Fatalf("expected first request to pass, got %d", rr.Code)
+	}
+	rr := send("test-token")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	if secs, err := strconv.Atoi(rr.Header().Get("Retry-After")); err != nil || secs < 1 || secs > 60 {
+		t.Fatalf("expected Retry-After in seconds, got %q", rr.Header().Get("Retry-After"))
+	}
+
+	for i := 0; i < 3; i++ {
+		if rr := send(""); rr.Code != http.StatusOK {
+			t.Fatalf("expected anonymous loopback request to stay unlimited, got %d", rr.Code)
+		}
+	}
+}
+
+func TestAuthContextMiddleware_RateLimited(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := storage.New(filepath.Join(tmpDir, "buckley.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	appCfg := &config.Config{}
+	appCfg.IPC.RateLimit = config.IPCRateLimitConfig{Requests: 1, Interval: time.Minute}
+	server := NewServer(
+		Config{
+			ProjectRoot: tmpDir,
+			AuthToken:   "test-token",
+		},
+		store,
+		nil,
+		command.NewGateway(),
+		nil,
+		appCfg,
+		nil,
+		nil,
+	)
+
+	handler := server.authContextMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, ipcpbconnect.BuckleyIPCListSessionsProcedure, nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := send(); rr.Code != http.StatusOK {
+		t.Fatalf("expected first request to pass, got %d", rr.Code)
+	}
+	rr := send()
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), "resource_exhausted") {
+		t.Fatalf("expected Connect resource_exhausted error, got %q", rr.Body.String())
+	}
+	if rr.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected Retry-After header")
+	}
+}
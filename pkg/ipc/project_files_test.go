@@ -0,0 +1,81 @@
+package ipc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/paths"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestListProjectFilesRejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	s := &Server{}
+
+	for _, query := range []string{"..", "../", "../../etc", "pkg/../../secret", root + "/../other"} {
+		_, err := s.listProjectFiles(context.Background(), root, query, 10)
+		var escape *paths.EscapeError
+		if !errors.As(err, &escape) || escape.Scope != "project root" {
+			t.Fatalf("listProjectFiles(%q) error = %v, want project root EscapeError", query, err)
+		}
+	}
+
+	files, err := s.listProjectFiles(context.Background(), root, "pkg/../main", 10)
+	if err != nil {
+		t.Fatalf("contained traversal should be allowed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("unexpected matches for literal query: %v", files)
+	}
+	files, err = s.listProjectFiles(context.Background(), root, "main", 10)
+	if err != nil || len(files) != 1 || files[0] != "main.go" {
+		t.Fatalf("listProjectFiles(main) = %v, %v; want [main.go]", files, err)
+	}
+}
+
+func TestListProjectFilesDropsIndexedPathsOutsideRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "project")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	store, err := storage.New(filepath.Join(tmpDir, "buckley.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	for _, p := range []string{"pkg/app.go", "../outside/app.go", filepath.Join(tmpDir, "elsewhere", "app.go")} {
+		if err := store.UpsertFileRecord(context.Background(), &storage.FileRecord{Path: p}); err != nil {
+			t.Fatalf("index %s: %v", p, err)
+		}
+	}
+
+	files, err := (&Server{store: store}).listProjectFiles(context.Background(), root, "app", 10)
+	if err != nil {
+		t.Fatalf("listProjectFiles: %v", err)
+	}
+	if len(files) != 1 || files[0] != "pkg/app.go" {
+		t.Fatalf("files = %v, want only pkg/app.go", files)
+	}
+}
+
+func TestHandleListFilesTraversalIsBadRequest(t *testing.T) {
+	s := &Server{projectRoot: t.TempDir()}
+	req := httptest.NewRequest(http.MethodGet, "/api/files?prefix=../../etc", nil)
+	req = req.WithContext(context.WithValue(req.Context(), principalContextKey, &requestPrincipal{Name: "op", Scope: storage.TokenScopeOperator}))
+	rr := httptest.NewRecorder()
+
+	s.handleListFiles(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", rr.Code, rr.Body.String())
+	}
+}
@@ -176,9 +176,11 @@ func (s *Server) resolvePTYProvidedToken(w http.ResponseWriter, r *http.Request,
 	if providedToken == "" && isLoopbackBindAddress(s.cfg.BindAddress) {
 		providedToken = strings.TrimSpace(r.URL.Query().Get("session_token"))
 	}
-	if providedToken != "" && !s.validateSessionTokenValue(sessionID, providedToken) {
-		httpError(w, "invalid session token", http.StatusUnauthorized)
-		return "", false
+	if providedToken != "" {
+		if err := s.checkSessionTokenValue(sessionID, providedToken); err != nil {
+			httpError(w, err.Error(), http.StatusUnauthorized)
+			return "", false
+		}
 	}
 	return providedToken, true
 }
@@ -192,8 +194,7 @@ func (s *Server) authenticatePTYConnection(ctx context.Context, conn *websocket.
 		s.writePTYErrorAndClose(ctx, conn, err, websocket.StatusPolicyViolation, "auth")
 		return false
 	}
-	if !s.validateSessionTokenValue(sessionID, token) {
-		err := errors.New("invalid session token")
+	if err := s.checkSessionTokenValue(sessionID, token); err != nil {
 		s.writePTYErrorAndClose(ctx, conn, err, websocket.StatusPolicyViolation, "token")
 		return false
 	}
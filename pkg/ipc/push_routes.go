@@ -192,7 +192,7 @@ func (s *Server) handlePushSubscribe(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req PushSubscribeRequest
-	if status, err := decodeJSONBody(w, r, &req, maxBodyBytesSmall, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesSmall), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
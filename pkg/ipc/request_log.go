@@ -0,0 +1,150 @@
+package ipc
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+const (
+	requestIDHeader              = "X-Request-ID"
+	requestInfoContextKey ctxKey = "buckley-ipc-request"
+	maxRequestIDLength           = 128
+)
+
+// requestInfo carries per-request log fields. The principal is filled in by
+// authorize, which runs deeper in the handler chain than the access logger.
+type requestInfo struct {
+	id        string
+	principal *requestPrincipal
+}
+
+func requestInfoFromContext(ctx context.Context) *requestInfo {
+	if ctx == nil {
+		return nil
+	}
+	info, _ := ctx.Value(requestInfoContextKey).(*requestInfo)
+	return info
+}
+
+// newServerLoggers builds the server's message logger and, for the json
+// format, an access logger. Text output keeps the classic "[ipc]" lines and
+// writes no access log.
+func newServerLoggers(format string, out io.Writer) (*log.Logger, *slog.Logger) {
+	if !strings.EqualFold(strings.TrimSpace(format), "json") {
+		return log.New(out, "[ipc] ", log.LstdFlags), nil
+	}
+	structured := slog.New(slog.NewJSONHandler(out, nil)).With("component", "ipc")
+	return log.New(levelWriter{logger: structured}, "", 0), structured
+}
+
+// levelWriter turns Printf-style server messages into structured records.
+// The "warning:" prefix the server already writes picks WARN; otherwise an
+// HTTP status in the message ("status 503") picks the level the access log
+// would use, and failure messages log at ERROR.
+type levelWriter struct {
+	logger *slog.Logger
+}
+
+// messageStatusPattern finds an HTTP status code such as "status 503" or
+// "HTTP 404" in a server message.
+var messageStatusPattern = regexp.MustCompile(`(?i)\b(?:status|http)[ :=]*([1-5][0-9]{2})\b`)
+
+func (w levelWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+	level := slog.LevelInfo
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.HasPrefix(lower, "warning:"):
+		level = slog.LevelWarn
+		msg = strings.TrimSpace(msg[len("warning:"):])
+	case strings.HasPrefix(lower, "panic"):
+		level = slog.LevelError
+	default:
+		if m := messageStatusPattern.FindStringSubmatch(msg); m != nil {
+			status, _ := strconv.Atoi(m[1])
+			level = statusLevel(status)
+		} else if strings.Contains(lower, "failed") || strings.Contains(lower, "error") {
+			level = slog.LevelError
+		}
+	}
+	w.logger.Log(context.Background(), level, msg)
+	return len(p), nil
+}
+
+// statusLevel maps an HTTP status to a log level: ERROR for 5xx, WARN for
+// 4xx and INFO otherwise.
+func statusLevel(status int) slog.Level {
+	switch {
+	case status >= 500:
+		return slog.LevelError
+	case status >= 400:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// requestLogMiddleware assigns every request an ID, echoes it in the
+// X-Request-ID response header and, when an access logger is configured,
+// writes one record per request with status and latency. A well-formed ID
+// from an upstream proxy is kept.
+func (s *Server) requestLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := &requestInfo{id: incomingRequestID(r)}
+		if info.id == "" {
+			info.id = uuid.New().String()
+		}
+		w.Header().Set(requestIDHeader, info.id)
+		r = r.WithContext(context.WithValue(r.Context(), requestInfoContextKey, info))
+
+		if s.accessLog == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := statusLevel(status)
+		attrs := []slog.Attr{
+			slog.String("request_id", info.id),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Int64("latency_ms", time.Since(start).Milliseconds()),
+			slog.Int("bytes", ww.BytesWritten()),
+		}
+		if info.principal != nil {
+			attrs = append(attrs, slog.String("principal", info.principal.Name), slog.String("scope", info.principal.Scope))
+		}
+		s.accessLog.LogAttrs(r.Context(), level, "request", attrs...)
+	})
+}
+
+func incomingRequestID(r *http.Request) string {
+	id := strings.TrimSpace(r.Header.Get(requestIDHeader))
+	if id == "" || len(id) > maxRequestIDLength {
+		return ""
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return ""
+		}
+	}
+	return id
+}
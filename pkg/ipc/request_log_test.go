@@ -0,0 +1,120 @@
+package ipc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func TestRequestLogMiddlewareSetsRequestID(t *testing.T) {
+	server, _ := testServer(t)
+	handler := server.requestLogMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/sessions", nil))
+	if rr.Header().Get(requestIDHeader) == "" {
+		t.Fatalf("expected a generated %s header", requestIDHeader)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions", nil)
+	req.Header.Set(requestIDHeader, "proxy-abc.123")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if got := rr.Header().Get(requestIDHeader); got != "proxy-abc.123" {
+		t.Fatalf("expected upstream request ID to be kept, got %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/sessions", nil)
+	req.Header.Set(requestIDHeader, "bad id\nInjected: 1")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if got := rr.Header().Get(requestIDHeader); got == "" || strings.Contains(got, " ") {
+		t.Fatalf("expected malformed request ID to be replaced, got %q", got)
+	}
+}
+
+func TestRequestLogMiddlewareJSONAccessLog(t *testing.T) {
+	server, _ := testServer(t)
+	var buf bytes.Buffer
+	server.logger, server.accessLog = newServerLoggers("json", &buf)
+	server.cfg.AuthToken = "test-token"
+
+	handler := server.requestLogMiddleware(server.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})))
+	req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log line, got %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "request" || entry["path"] != "/api/test" {
+		t.Fatalf("unexpected access log entry %v", entry)
+	}
+	if entry["status"] != float64(http.StatusTeapot) || entry["principal"] != "builtin" || entry["scope"] != storage.TokenScopeOperator {
+		t.Fatalf("expected status and principal in access log, got %v", entry)
+	}
+	if entry["request_id"] != rr.Header().Get(requestIDHeader) {
+		t.Fatalf("expected access log request_id to match header, got %v", entry["request_id"])
+	}
+	if _, ok := entry["latency_ms"]; !ok {
+		t.Fatalf("expected latency_ms in access log, got %v", entry)
+	}
+
+	buf.Reset()
+	server.logger.Printf("warning: failed to initialize push notifications: %v", "boom")
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected server messages as JSON, got %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "failed to initialize push notifications: boom" {
+		t.Fatalf("unexpected server log entry %v", entry)
+	}
+}
+
+func TestLevelWriterMapsStatusAndFailures(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := newServerLoggers("json", &buf)
+	cases := []struct {
+		msg  string
+		want string
+	}{
+		{"upstream returned status 503", "ERROR"},
+		{"push endpoint rejected subscription: HTTP 410", "WARN"},
+		{"webhook delivered with status 200", "INFO"},
+		{"compaction of session s1 failed: boom", "ERROR"},
+		{"serving IPC on 127.0.0.1:4488", "INFO"},
+	}
+	for _, tc := range cases {
+		buf.Reset()
+		logger.Print(tc.msg)
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("expected JSON for %q, got %q: %v", tc.msg, buf.String(), err)
+		}
+		if entry["level"] != tc.want {
+			t.Errorf("%q logged at %v, want %s", tc.msg, entry["level"], tc.want)
+		}
+	}
+}
+
+func TestNewServerLoggersTextHasNoAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger, accessLog := newServerLoggers("text", &buf)
+	if accessLog != nil {
+		t.Fatalf("expected text format to skip access logging")
+	}
+	logger.Printf("serving IPC on %s", "127.0.0.1:4488")
+	if !strings.Contains(buf.String(), "[ipc] ") {
+		t.Fatalf("expected classic text output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,105 @@
+package ipc
+
+import (
+	stdliberrors "errors"
+	"net/http"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// handleSearch runs a conversation search across the sessions the caller can
+// access, or within one session when sessionID is given. Operators search
+// every session; other principals only their own.
+//
+// Only full-text search is served. Conversation embeddings are not computed
+// anywhere yet, so mode=semantic reports 501 rather than silently falling back.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeViewer)
+	if !ok {
+		return
+	}
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		respondError(w, http.StatusBadRequest, stdliberrors.New("q is required"))
+		return
+	}
+	mode := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("mode")))
+	switch mode {
+	case "", "fulltext":
+		mode = "fulltext"
+	case "semantic":
+		respondError(w, http.StatusNotImplemented, stdliberrors.New("semantic search unavailable: no embedding provider configured"))
+		return
+	default:
+		respondError(w, http.StatusBadRequest, stdliberrors.New("mode must be fulltext or semantic"))
+		return
+	}
+	limit := parseIntDefault(r.URL.Query().Get("limit"), defaultSearchLimit)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	sessionID := strings.TrimSpace(r.URL.Query().Get("sessionID"))
+	if sessionID != "" {
+		session, err := s.store.GetSession(sessionID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if session == nil || !principalCanAccessSession(principal, session) {
+			respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+			return
+		}
+	}
+
+	var (
+		hits []storage.MessageSearchResult
+		err  error
+	)
+	match := ftsMatchQuery(query)
+	if sessionID != "" || isOperatorPrincipal(principal) {
+		hits, err = s.store.SearchMessagesFTS(r.Context(), match, sessionID, limit)
+	} else {
+		hits, err = s.store.SearchMessagesFTSForPrincipal(r.Context(), match, principal.Name, limit)
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	results := make([]map[string]any, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, map[string]any{
+			"sessionId": hit.Message.SessionID,
+			"messageId": hit.Message.ID,
+			"role":      hit.Message.Role,
+			"snippet":   hit.Snippet,
+			"score":     hit.Score,
+			"timestamp": hit.Message.Timestamp,
+		})
+	}
+	respondJSON(w, map[string]any{
+		"query":   query,
+		"mode":    mode,
+		"results": results,
+	})
+}
+
+// ftsMatchQuery quotes each term so user input is matched literally instead
+// of being parsed as FTS5 syntax; the terms are ANDed.
+func ftsMatchQuery(query string) string {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,90 @@
+package ipc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+type searchResponse struct {
+	Mode    string `json:"mode"`
+	Results []struct {
+		SessionID string  `json:"sessionId"`
+		Snippet   string  `json:"snippet"`
+		Score     float64 `json:"score"`
+	} `json:"results"`
+}
+
+func search(t *testing.T, server *Server, principal, scope string, params url.Values) (*httptest.ResponseRecorder, searchResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/search?"+params.Encode(), nil)
+	req = withPrincipal(req, principal, scope)
+	rr := httptest.NewRecorder()
+	server.handleSearch(rr, req)
+
+	var resp searchResponse
+	if rr.Code == http.StatusOK {
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return rr, resp
+}
+
+func TestHandleSearchFullText(t *testing.T) {
+	server, store := testServer(t)
+	now := time.Now()
+	for id, owner := range map[string]string{"alice-session": "alice", "bob-session": "bob"} {
+		if err := store.CreateSession(&storage.Session{ID: id, Principal: owner, CreatedAt: now, LastActive: now, Status: storage.SessionStatusActive}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		if err := store.SaveMessage(&storage.Message{SessionID: id, Role: "user", Content: "refactor the websocket reconnect logic", Timestamp: now, Tokens: 1}); err != nil {
+			t.Fatalf("failed to save message: %v", err)
+		}
+	}
+
+	rr, resp := search(t, server, "alice", storage.TokenScopeViewer, url.Values{"q": {"websocket reconnect"}})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if resp.Mode != "fulltext" || len(resp.Results) != 1 || resp.Results[0].SessionID != "alice-session" {
+		t.Fatalf("expected viewer to only see their own session, got %+v", resp)
+	}
+
+	_, resp = search(t, server, "ops", storage.TokenScopeOperator, url.Values{"q": {"websocket"}})
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected operator to search every session, got %+v", resp)
+	}
+
+	// FTS operators in user input are matched literally instead of failing.
+	if rr, _ := search(t, server, "alice", storage.TokenScopeViewer, url.Values{"q": {`reconnect" OR`}}); rr.Code != http.StatusOK {
+		t.Fatalf("expected quoted query to succeed, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if rr, _ := search(t, server, "alice", storage.TokenScopeViewer, url.Values{"q": {"websocket"}, "sessionID": {"bob-session"}}); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected another principal's session to be hidden, got %d", rr.Code)
+	}
+}
+
+func TestHandleSearchRejectsBadRequests(t *testing.T) {
+	server, _ := testServer(t)
+
+	cases := []struct {
+		params url.Values
+		status int
+	}{
+		{url.Values{}, http.StatusBadRequest},
+		{url.Values{"q": {"x"}, "mode": {"fuzzy"}}, http.StatusBadRequest},
+		{url.Values{"q": {"x"}, "mode": {"semantic"}}, http.StatusNotImplemented},
+	}
+	for _, tc := range cases {
+		if rr, _ := search(t, server, "alice", storage.TokenScopeViewer, tc.params); rr.Code != tc.status {
+			t.Errorf("%v: expected status %d, got %d", tc.params, tc.status, rr.Code)
+		}
+	}
+}
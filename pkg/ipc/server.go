@@ -9,6 +9,7 @@ import (
 	"fmt"
 	iofs "io/fs"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
@@ -106,12 +107,18 @@ type Config struct {
 	ProjectRoot       string
 	ExternalURL       string // External URL for generating links (magic links, QR codes)
 	AgentProfile      string // Optional rendered buckley.agent/v1 prompt section for headless sessions
+
+	// MaxBodyBytes caps request bodies on the JSON command, plan, and
+	// Connect endpoints (0 keeps the built-in per-endpoint limits). Auth and
+	// other tiny-payload endpoints keep their fixed limit.
+	MaxBodyBytes int64
 }
 
 // Server hosts a JSON/HTTP + WebSocket API for external UIs.
 type Server struct {
 	cfg              Config
 	appConfig        *config.Config
+	liveConfig       *config.Live
 	store            *storage.Store
 	missionStore     *mission.Store
 	models           *model.Manager
@@ -121,11 +128,19 @@ type Server struct {
 	ptyConnLimiter   *connLimiter
 	httpServer       *http.Server
 	logger           *log.Logger
+	accessLog        *slog.Logger
 	telemetry        *telemetry.Hub
 	commandGW        *command.Gateway
 	commandLimiter   *rateLimiter
 	cliTicketLimiter *rateLimiter
+	principalLimiter *principalLimiter
+	compactions      *compactionTracker
 	planStore        orchestrator.PlanStore
+	planCreator      PlanCreator
+	planLimiter      *connLimiter
+	experimentRunner ExperimentRunnerFactory
+	expLimiter       *connLimiter
+	runCtx           context.Context
 	projectRoot      string
 	workflow         *orchestrator.WorkflowManager
 	viewAssembler    *viewmodel.Assembler
@@ -155,6 +170,13 @@ func NewServer(cfg Config, store *storage.Store, telemetryHub *telemetry.Hub, co
 	if root == "" {
 		root = config.ResolveProjectRoot(appCfg)
 	}
+	var rateLimit config.IPCRateLimitConfig
+	logFormat := ""
+	if appCfg != nil {
+		rateLimit = appCfg.IPC.RateLimit
+		logFormat = appCfg.IPC.LogFormat
+	}
+	logger, accessLog := newServerLoggers(logFormat, os.Stdout)
 	// Create runtime state tracker for live telemetry-derived state
 	runtimeTracker := viewmodel.NewRuntimeStateTracker(telemetryHub)
 
@@ -167,12 +189,17 @@ func NewServer(cfg Config, store *storage.Store, telemetryHub *telemetry.Hub, co
 		hub:              NewHub(),
 		eventConnLimiter: newConnLimiter(maxEventStreamClients),
 		ptyConnLimiter:   newConnLimiter(maxPTYClients),
-		logger:           log.New(os.Stdout, "[ipc] ", log.LstdFlags),
+		logger:           logger,
+		accessLog:        accessLog,
 		telemetry:        telemetryHub,
 		commandGW:        commandGateway,
 		commandLimiter:   newRateLimiter(250 * time.Millisecond),
 		cliTicketLimiter: newRateLimiter(200 * time.Millisecond),
+		principalLimiter: newPrincipalLimiter(rateLimit),
+		compactions:      newCompactionTracker(),
 		planStore:        planStore,
+		planLimiter:      newConnLimiter(maxConcurrentPlanCreations),
+		expLimiter:       newConnLimiter(maxConcurrentExperiments),
 		projectRoot:      root,
 		workflow:         workflow,
 		runtimeTracker:   runtimeTracker,
@@ -199,6 +226,21 @@ func NewServer(cfg Config, store *storage.Store, telemetryHub *telemetry.Hub, co
 	return s
 }
 
+// SetLiveConfig makes sessions, runners and compactions started after this
+// call read settings from live instead of the config the server was built
+// with. It must be called before InitHeadlessRegistry.
+func (s *Server) SetLiveConfig(live *config.Live) {
+	s.liveConfig = live
+}
+
+// currentAppConfig returns the config new work should start with.
+func (s *Server) currentAppConfig() *config.Config {
+	if cfg := s.liveConfig.Load(); cfg != nil {
+		return cfg
+	}
+	return s.appConfig
+}
+
 func shouldPersistEvent(eventType string) bool {
 	eventType = strings.TrimSpace(eventType)
 	return eventType != "" && eventType != "view.patch" && eventType != "sessions.snapshot" && !strings.HasPrefix(eventType, "server.")
@@ -209,6 +251,8 @@ func (s *Server) Start(ctx context.Context) error {
 	if err := s.validateStartupConfig(); err != nil {
 		return err
 	}
+	s.runCtx = ctx
+	s.failInterruptedExperiments()
 
 	// Start runtime state tracker to process telemetry events
 	if s.runtimeTracker != nil {
@@ -224,6 +268,7 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 
 	router := chi.NewRouter()
+	router.Use(s.requestLogMiddleware)
 	router.Use(s.corsMiddleware)
 	router.Use(s.securityHeadersMiddleware)
 	router.Use(s.sessionMiddleware)
@@ -243,16 +288,28 @@ func (s *Server) Start(ctx context.Context) error {
 	api.Get("/sessions", s.handleListSessions)
 	api.Get("/models", s.handleListModels)
 	api.Get("/sessions/{sessionID}", s.handleSessionDetail)
+	api.Delete("/sessions/{sessionID}", s.handleDeleteSession)
 	api.Get("/sessions/{sessionID}/messages", s.handleSessionMessages)
 	api.Get("/sessions/{sessionID}/todos", s.handleSessionTodos)
 	api.Get("/sessions/{sessionID}/skills", s.handleSessionSkills)
+	api.Get("/sessions/{sessionID}/tool-calls", s.handleSessionToolCalls)
+	api.Get("/sessions/{sessionID}/cost", s.handleSessionCost)
+	api.Get("/sessions/{sessionID}/compaction", s.handleCompactionStatus)
+	api.Post("/sessions/{sessionID}/compaction", s.handleTriggerCompaction)
 	api.Post("/sessions/{sessionID}/tokens", s.handleSessionToken)
+	api.Delete("/sessions/{sessionID}/tokens", s.handleRevokeSessionTokens)
+	api.Get("/search", s.handleSearch)
 	api.Get("/files", s.handleListFiles)
 	api.Get("/metrics/cost", s.handleCostMetrics)
 	api.Get("/plans", s.handleListPlans)
+	api.Post("/plans", s.handleCreatePlan)
 	api.Get("/plans/{planID}", s.handleGetPlan)
 	api.Get("/plans/{planID}/tasks", s.handleGetPlanTasks)
+	api.Get("/plans/{planID}/status", s.handleGetPlanStatus)
 	api.Get("/plans/{planID}/logs/{kind}", s.handlePlanLog)
+	api.Get("/experiments", s.handleListExperiments)
+	api.Post("/experiments", s.handleCreateExperiment)
+	api.Get("/experiments/{experimentID}", s.handleGetExperiment)
 	api.Post("/workflow/{sessionID}", s.handleWorkflowAction)
 	api.Post("/generate", s.handleGenerateAsset)
 	api.Route("/config", func(r chi.Router) {
@@ -296,7 +353,8 @@ func (s *Server) Start(ctx context.Context) error {
 	})
 
 	router.Get("/healthz", s.handleHealthz)
-	router.Get("/ws/pty", s.handlePTY) // Interactive terminal (WebSocket for PTY I/O)
+	router.Get("/ws", s.handleEventStream) // Event stream (WebSocket, filterable by session and type)
+	router.Get("/ws/pty", s.handlePTY)     // Interactive terminal (WebSocket for PTY I/O)
 	router.Get("/cli/login/{ticket}", s.handleCliTicketPage)
 	router.Get("/auth/magic/{token}", s.handleRedeemMagicLink)
 
@@ -306,9 +364,9 @@ func (s *Server) Start(ctx context.Context) error {
 	grpcPath, grpcHandler := ipcpbconnect.NewBuckleyIPCHandler(
 		s.grpcService,
 		connect.WithCompressMinBytes(1024),
-		connect.WithReadMaxBytes(maxConnectReadBytes),
+		connect.WithReadMaxBytes(int(s.bodyLimit(maxConnectReadBytes))),
 	)
-	grpcHandler = http.MaxBytesHandler(grpcHandler, maxConnectRequestBytes)
+	grpcHandler = http.MaxBytesHandler(grpcHandler, s.bodyLimit(maxConnectRequestBytes))
 	router.With(s.authContextMiddleware).Mount(grpcPath, grpcHandler)
 	s.logger.Printf("gRPC/Connect service mounted at %s", grpcPath)
 
@@ -506,8 +564,19 @@ func (s *Server) handleListSessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	limit := parseIntDefault(r.URL.Query().Get("limit"), 50)
-	sessions, err := s.store.ListSessions(limit)
+	query := r.URL.Query()
+	limit := parseIntDefault(query.Get("limit"), 50)
+	before, err := parseSessionCursor(query.Get("cursor"), query.Get("before"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+	sessions, next, err := s.store.ListSessionsBefore(before, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	nextCursor, err := storage.EncodeSessionCursor(next)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, err)
 		return
@@ -528,12 +597,35 @@ func (s *Server) handleListSessions(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Principal filtering runs per page, so a page can hold fewer than limit
+	// sessions; nextCursor still points past every session scanned.
 	respondJSON(w, map[string]any{
-		"sessions":  filtered,
-		"summaries": filteredSummaries,
+		"sessions":   filtered,
+		"summaries":  filteredSummaries,
+		"nextCursor": nextCursor,
 	})
 }
 
+// parseSessionCursor reads a session list position from either an opaque
+// cursor or a plain RFC 3339 before timestamp. Neither means the first page.
+func parseSessionCursor(cursor, before string) (*storage.SessionCursor, error) {
+	if cursor = strings.TrimSpace(cursor); cursor != "" {
+		decoded, err := storage.DecodeSessionCursor(cursor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		return decoded, nil
+	}
+	if before = strings.TrimSpace(before); before != "" {
+		ts, err := time.Parse(time.RFC3339Nano, before)
+		if err != nil {
+			return nil, fmt.Errorf("invalid before timestamp: %w", err)
+		}
+		return &storage.SessionCursor{LastActive: ts}, nil
+	}
+	return nil, nil
+}
+
 func (s *Server) handleListModels(w http.ResponseWriter, r *http.Request) {
 	if _, ok := requireScope(w, r, storage.TokenScopeViewer); !ok {
 		return
@@ -707,6 +799,40 @@ func (s *Server) handleSessionSkills(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleSessionToolCalls returns the session's tool audit log, newest first.
+func (s *Server) handleSessionToolCalls(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeViewer)
+	if !ok {
+		return
+	}
+	sessionID := chi.URLParam(r, "sessionID")
+	limit := parseIntDefault(r.URL.Query().Get("limit"), 100)
+
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session == nil || !principalCanAccessSession(principal, session) {
+		respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+		return
+	}
+
+	calls, err := s.store.GetAuditLog(sessionID, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if calls == nil {
+		calls = []*storage.ToolAuditEntry{}
+	}
+
+	respondJSON(w, map[string]any{
+		"sessionId": sessionID,
+		"toolCalls": calls,
+	})
+}
+
 func (s *Server) handleListFiles(w http.ResponseWriter, r *http.Request) {
 	_, ok := requireScope(w, r, storage.TokenScopeViewer)
 	if !ok {
@@ -725,6 +851,10 @@ func (s *Server) handleListFiles(w http.ResponseWriter, r *http.Request) {
 
 	files, err := s.listProjectFiles(r.Context(), root, prefix, limit)
 	if err != nil {
+		if stdliberrors.Is(err, paths.ErrOutsideRoot) {
+			respondError(w, http.StatusBadRequest, err)
+			return
+		}
 		respondError(w, http.StatusInternalServerError, err)
 		return
 	}
@@ -753,7 +883,8 @@ func (s *Server) handleSessionToken(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
 		return
 	}
-	token, err := s.issueSessionToken(sessionID)
+	expiresAt := time.Now().Add(authSessionTTL)
+	token, err := s.issueSessionTokenUntil(sessionID, expiresAt)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, err)
 		return
@@ -761,18 +892,70 @@ func (s *Server) handleSessionToken(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, map[string]any{
 		"sessionId": sessionID,
 		"token":     token,
+		"expiresAt": expiresAt.UTC(),
+	})
+}
+
+// handleRevokeSessionTokens revokes every outstanding token for a session.
+// Attached clients get "session token revoked" until they request a new one.
+func (s *Server) handleRevokeSessionTokens(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeMember)
+	if !ok {
+		return
+	}
+	sessionID := strings.TrimSpace(chi.URLParam(r, "sessionID"))
+	if sessionID == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("missing session id"))
+		return
+	}
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session == nil || !principalCanAccessSession(principal, session) {
+		respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+		return
+	}
+	revoked, err := s.store.RevokeSessionTokens(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	_ = s.store.RecordAuditLog(principal.Name, principal.Scope, "session.tokens.revoke", map[string]any{
+		"sessionId": sessionID,
+		"revoked":   revoked,
+	})
+	respondJSON(w, map[string]any{
+		"sessionId": sessionID,
+		"revoked":   revoked,
 	})
 }
 
+// listProjectFiles returns up to limit files under root whose relative path
+// contains query. A query that climbs out of root with ".." is rejected with
+// a *paths.EscapeError, and indexed entries outside root are dropped.
 func (s *Server) listProjectFiles(ctx context.Context, root, query string, limit int) ([]string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project root: %w", err)
+	}
+	if hasParentSegment(query) && !paths.Within(absRoot, projectPath(absRoot, query)) {
+		return nil, &paths.EscapeError{Path: query, Root: absRoot, Scope: "project root"}
+	}
+
 	if s.store != nil {
 		records, err := s.store.SearchFiles(ctx, query, "", limit)
 		if err == nil && len(records) > 0 {
 			out := make([]string, 0, len(records))
 			for _, rec := range records {
-				out = append(out, rec.Path)
+				if paths.Within(absRoot, projectPath(absRoot, rec.Path)) {
+					out = append(out, rec.Path)
+				}
+			}
+			if len(out) > 0 {
+				return out, nil
 			}
-			return out, nil
 		}
 	}
 
@@ -790,7 +973,7 @@ func (s *Server) listProjectFiles(ctx context.Context, root, query string, limit
 	lowerQuery := strings.ToLower(query)
 	files := make([]string, 0, limit)
 	stopWalk := stdliberrors.New("stop walk")
-	err := filepath.WalkDir(root, func(path string, d iofs.DirEntry, err error) error {
+	err = filepath.WalkDir(root, func(path string, d iofs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -820,6 +1003,24 @@ func (s *Server) listProjectFiles(ctx context.Context, root, query string, limit
 	return files, nil
 }
 
+// hasParentSegment reports whether p contains a ".." path element.
+func hasParentSegment(p string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(p), "/") {
+		if part == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// projectPath resolves p against root unless it is already absolute.
+func projectPath(root, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(root, p)
+}
+
 func (s *Server) handleListPlans(w http.ResponseWriter, r *http.Request) {
 	principal, ok := requireScope(w, r, storage.TokenScopeViewer)
 	if !ok {
@@ -952,6 +1153,88 @@ func (s *Server) handleGetPlanTasks(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *Server) handleGetPlanStatus(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeViewer)
+	if !ok {
+		return
+	}
+	planID := strings.TrimSpace(chi.URLParam(r, "planID"))
+	if planID == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("missing plan id"))
+		return
+	}
+	if s.planStore == nil {
+		respondError(w, http.StatusServiceUnavailable, fmt.Errorf("plan store unavailable"))
+		return
+	}
+	if !isOperatorPrincipal(principal) && s.store != nil {
+		allowed, err := s.store.PrincipalHasPlan(principal.Name, planID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if !allowed {
+			respondError(w, http.StatusNotFound, stdliberrors.New("plan not found"))
+			return
+		}
+	}
+	plan, err := s.planStore.LoadPlan(planID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if plan == nil {
+		respondError(w, http.StatusNotFound, stdliberrors.New("plan not found"))
+		return
+	}
+	respondJSON(w, summarizePlanStatus(plan))
+}
+
+// planStatusSummary aggregates task states so dashboards can render plan
+// progress without fetching and folding the task list themselves.
+type planStatusSummary struct {
+	PlanID          string             `json:"planId"`
+	Total           int                `json:"total"`
+	Pending         int                `json:"pending"`
+	Running         int                `json:"running"`
+	Done            int                `json:"done"`
+	Failed          int                `json:"failed"`
+	Skipped         int                `json:"skipped"`
+	PercentComplete float64            `json:"percentComplete"`
+	CurrentTask     *planStatusCurrent `json:"currentTask,omitempty"`
+}
+
+type planStatusCurrent struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func summarizePlanStatus(plan *orchestrator.Plan) planStatusSummary {
+	summary := planStatusSummary{PlanID: plan.ID, Total: len(plan.Tasks)}
+	for _, task := range plan.Tasks {
+		switch task.Status {
+		case orchestrator.TaskInProgress:
+			summary.Running++
+			if summary.CurrentTask == nil {
+				summary.CurrentTask = &planStatusCurrent{ID: task.ID, Title: task.Title}
+			}
+		case orchestrator.TaskCompleted:
+			summary.Done++
+		case orchestrator.TaskFailed:
+			summary.Failed++
+		case orchestrator.TaskSkipped:
+			summary.Skipped++
+		default:
+			summary.Pending++
+		}
+	}
+	if summary.Total > 0 {
+		// Skipped tasks no longer block the plan, so they count toward progress.
+		summary.PercentComplete = float64(summary.Done+summary.Skipped) * 100 / float64(summary.Total)
+	}
+	return summary
+}
+
 func (s *Server) handlePlanLog(w http.ResponseWriter, r *http.Request) {
 	principal, ok := requireScope(w, r, storage.TokenScopeViewer)
 	if !ok {
@@ -1025,7 +1308,7 @@ func (s *Server) handleCreateAPIToken(w http.ResponseWriter, r *http.Request) {
 		Owner string `json:"owner"`
 		Scope string `json:"scope"`
 	}
-	if status, err := decodeJSONBody(w, r, &req, maxBodyBytesSmall, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesSmall), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -1171,7 +1454,7 @@ func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) {
 		Name        string `json:"name"`
 		Description string `json:"description"`
 	}
-	if status, err := decodeJSONBody(w, r, &req, maxBodyBytesSmall, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesSmall), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -1283,7 +1566,7 @@ func (s *Server) handleSessionCommand(w http.ResponseWriter, r *http.Request) {
 	}
 	sessionID := chi.URLParam(r, "sessionID")
 	var payload command.SessionCommand
-	if status, err := decodeJSONBody(w, r, &payload, maxBodyBytesCommand, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &payload, s.bodyLimit(maxBodyBytesCommand), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -1306,8 +1589,8 @@ func (s *Server) handleSessionCommand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !s.validateSessionToken(r, sessionID) {
-		respondError(w, http.StatusUnauthorized, fmt.Errorf("invalid session token"))
+	if err := s.checkSessionToken(r, sessionID); err != nil {
+		respondError(w, http.StatusUnauthorized, err)
 		return
 	}
 	if !s.commandLimiter.Allow(sessionID) {
@@ -1321,6 +1604,13 @@ func (s *Server) handleSessionCommand(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, fmt.Errorf("content required"))
 		return
 	}
+	if forbidden, err := s.approvalCommandForbidden(principal, sessionID, payload.Type, payload.Content); err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	} else if forbidden {
+		respondError(w, http.StatusForbidden, fmt.Errorf("approving a dangerous tool requires operator scope"))
+		return
+	}
 	payload.EnsureID()
 	if err := s.commandGW.Dispatch(payload); err != nil {
 		respondError(w, http.StatusServiceUnavailable, err)
@@ -1357,8 +1647,8 @@ func (s *Server) handleWorkflowAction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !s.validateSessionToken(r, sessionID) {
-		respondError(w, http.StatusUnauthorized, fmt.Errorf("invalid session token"))
+	if err := s.checkSessionToken(r, sessionID); err != nil {
+		respondError(w, http.StatusUnauthorized, err)
 		return
 	}
 	if !s.commandLimiter.Allow(sessionID) {
@@ -1367,7 +1657,7 @@ func (s *Server) handleWorkflowAction(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req workflowActionRequest
-	if status, err := decodeJSONBody(w, r, &req, maxBodyBytesSmall, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesSmall), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -1410,9 +1700,14 @@ func (s *Server) handleCostMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	latency := s.models.LatencyStats()
+	if latency == nil {
+		latency = []model.LatencyStats{}
+	}
 	respondJSON(w, map[string]any{
 		"daily":   daily,
 		"monthly": monthly,
+		"latency": latency,
 	})
 }
 
@@ -1472,7 +1767,7 @@ func (s *Server) handleGenerateAsset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var req generateAssetRequest
-	if status, err := decodeJSONBody(w, r, &req, maxBodyBytesSmall, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &req, s.bodyLimit(maxBodyBytesSmall), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -1686,7 +1981,7 @@ func (s *Server) handleUpdatePrompt(w http.ResponseWriter, r *http.Request) {
 	var payload struct {
 		Content string `json:"content"`
 	}
-	if status, err := decodeJSONBody(w, r, &payload, maxBodyBytesCommand, false); err != nil {
+	if status, err := decodeJSONBody(w, r, &payload, s.bodyLimit(maxBodyBytesCommand), false); err != nil {
 		respondError(w, status, err)
 		return
 	}
@@ -2190,6 +2485,42 @@ func (s *Server) broadcastTelemetry(event telemetry.Event) {
 		Payload:   event,
 		Timestamp: event.Timestamp,
 	})
+	if streamEvent, ok := streamStateEvent(event); ok {
+		s.hub.Broadcast(streamEvent)
+	}
+}
+
+const (
+	eventStreamStarted = "session.stream_started"
+	eventStreamEnded   = "session.stream_ended"
+)
+
+// streamStateEvent maps model stream telemetry to a session-scoped event web
+// clients can use as a typing indicator. It is transient so replay after a
+// reconnect never shows a stale indicator.
+func streamStateEvent(event telemetry.Event) (Event, bool) {
+	if strings.TrimSpace(event.SessionID) == "" {
+		return Event{}, false
+	}
+	var eventType string
+	switch event.Type {
+	case telemetry.EventModelStreamStarted:
+		eventType = eventStreamStarted
+	case telemetry.EventModelStreamEnded:
+		eventType = eventStreamEnded
+	default:
+		return Event{}, false
+	}
+	return Event{
+		Type:      eventType,
+		SessionID: event.SessionID,
+		Payload: map[string]any{
+			"sessionId": event.SessionID,
+			"streaming": eventType == eventStreamStarted,
+		},
+		Timestamp: event.Timestamp,
+		Transient: true,
+	}, true
 }
 
 func (s *Server) broadcastViewPatch(sessionID string) {
@@ -2211,45 +2542,73 @@ func (s *Server) broadcastViewPatch(sessionID string) {
 	})
 }
 
+// issueSessionToken mints a session token that expires after authSessionTTL,
+// replacing any token previously issued for the session.
 func (s *Server) issueSessionToken(sessionID string) (string, error) {
+	return s.issueSessionTokenUntil(sessionID, time.Now().Add(authSessionTTL))
+}
+
+func (s *Server) issueSessionTokenUntil(sessionID string, expiresAt time.Time) (string, error) {
 	buf := make([]byte, 24)
 	if _, err := rand.Read(buf); err != nil {
 		return "", fmt.Errorf("failed to generate session token: %w", err)
 	}
 	token := hex.EncodeToString(buf)
 	if s.store != nil {
-		if err := s.store.SaveSessionToken(sessionID, token); err != nil {
+		if err := s.store.SaveSessionTokenWithExpiry(sessionID, token, expiresAt); err != nil {
 			return "", fmt.Errorf("store session token: %w", err)
 		}
 	}
 	return token, nil
 }
 
+var (
+	errSessionTokenInvalid = stdliberrors.New("invalid session token")
+	errSessionTokenExpired = stdliberrors.New("session token expired")
+	errSessionTokenRevoked = stdliberrors.New("session token revoked")
+)
+
 func (s *Server) validateSessionToken(r *http.Request, sessionID string) bool {
+	return s.checkSessionToken(r, sessionID) == nil
+}
+
+// checkSessionToken validates the session token carried by r. The error says
+// whether the token expired, was revoked, or is simply wrong, so clients know
+// when to request a fresh one.
+func (s *Server) checkSessionToken(r *http.Request, sessionID string) error {
 	if sessionID == "" {
-		return true
+		return nil
 	}
 	provided := strings.TrimSpace(r.Header.Get("X-Buckley-Session-Token"))
 	if provided == "" && isLoopbackBindAddress(s.cfg.BindAddress) {
 		provided = strings.TrimSpace(r.URL.Query().Get("session_token"))
 	}
-	return s.validateSessionTokenValue(sessionID, provided)
+	return s.checkSessionTokenValue(sessionID, provided)
 }
 
-func (s *Server) validateSessionTokenValue(sessionID, provided string) bool {
+func (s *Server) checkSessionTokenValue(sessionID, provided string) error {
 	if sessionID == "" {
-		return true
+		return nil
 	}
 	provided = strings.TrimSpace(provided)
 	if provided == "" || s.store == nil {
-		return false
+		return errSessionTokenInvalid
 	}
-	ok, err := s.store.ValidateSessionToken(sessionID, provided)
+	status, err := s.store.CheckSessionToken(sessionID, provided)
 	if err != nil {
 		s.logger.Printf("session token validation error: %v", err)
-		return false
+		return errSessionTokenInvalid
+	}
+	switch status {
+	case storage.SessionTokenValid:
+		return nil
+	case storage.SessionTokenExpired:
+		return errSessionTokenExpired
+	case storage.SessionTokenRevoked:
+		return errSessionTokenRevoked
+	default:
+		return errSessionTokenInvalid
 	}
-	return ok
 }
 
 func (s *Server) validateBearerToken(token string) *requestPrincipal {
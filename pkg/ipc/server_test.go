@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"m31labs.dev/buckley/pkg/ipc/command"
 	"m31labs.dev/buckley/pkg/orchestrator"
 	"m31labs.dev/buckley/pkg/storage"
+	"m31labs.dev/buckley/pkg/telemetry"
 )
 
 func TestHandleSessionDetailReturnsPlanSnapshot(t *testing.T) {
@@ -147,6 +149,53 @@ func TestValidateSessionTokenQueryParamRejectedOnRemoteBind(t *testing.T) {
 	}
 }
 
+func TestCheckSessionTokenDistinguishesExpiredAndRevoked(t *testing.T) {
+	server, store := testServer(t)
+	if err := store.CreateSession(&storage.Session{ID: "abc123", Principal: "test", CreatedAt: time.Now(), LastActive: time.Now(), Status: storage.SessionStatusActive}); err != nil {
+		t.Fatalf("failed to seed session: %v", err)
+	}
+
+	expired, err := server.issueSessionTokenUntil("abc123", time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("issueSessionTokenUntil error: %v", err)
+	}
+	if err := server.checkSessionTokenValue("abc123", expired); err != errSessionTokenExpired {
+		t.Fatalf("expected expired token error, got %v", err)
+	}
+	if err := server.checkSessionTokenValue("abc123", "wrong"); err != errSessionTokenInvalid {
+		t.Fatalf("expected invalid token error, got %v", err)
+	}
+
+	token, err := server.issueSessionToken("abc123")
+	if err != nil {
+		t.Fatalf("issueSessionToken error: %v", err)
+	}
+	if err := server.checkSessionTokenValue("abc123", token); err != nil {
+		t.Fatalf("expected fresh token to validate, got %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/sessions/abc123/tokens", nil)
+	req = withPrincipal(req, "test", storage.TokenScopeMember)
+	req = withURLParam(req, "sessionID", "abc123")
+	rr := httptest.NewRecorder()
+	server.handleRevokeSessionTokens(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if err := server.checkSessionTokenValue("abc123", token); err != errSessionTokenRevoked {
+		t.Fatalf("expected revoked token error, got %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/sessions/abc123/tokens", nil)
+	req = withPrincipal(req, "intruder", storage.TokenScopeMember)
+	req = withURLParam(req, "sessionID", "abc123")
+	rr = httptest.NewRecorder()
+	server.handleRevokeSessionTokens(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected other principal to get %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
 func TestHandleWorkflowActionDispatchesCommand(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "buckley.db")
@@ -441,3 +490,46 @@ func TestHandleGenerateAgentsAsset(t *testing.T) {
 		t.Fatalf("expected AGENTS.md at %s: %v", expected, err)
 	}
 }
+
+func TestBroadcastTelemetryEmitsStreamStateEvents(t *testing.T) {
+	server, _, _ := newHeadlessTestServer(t)
+	fwd := &captureEventForwarder{}
+	server.hub.AddForwarder(fwd)
+	ws := server.hub.register(&fakeConn{writeCount: &atomic.Int32{}, closeCount: &atomic.Int32{}}, func(ev Event) bool {
+		return strings.HasPrefix(ev.Type, "session.stream_")
+	})
+
+	server.broadcastTelemetry(telemetry.Event{Type: telemetry.EventModelStreamStarted, SessionID: "s1"})
+	server.broadcastTelemetry(telemetry.Event{Type: telemetry.EventModelStreamEnded, SessionID: "s1"})
+	// Unscoped stream telemetry cannot drive a per-session indicator.
+	server.broadcastTelemetry(telemetry.Event{Type: telemetry.EventModelStreamStarted})
+
+	var stream []Event
+	for _, ev := range fwd.events {
+		if strings.HasPrefix(ev.Type, "session.stream_") {
+			stream = append(stream, ev)
+		}
+	}
+	if len(stream) != 2 {
+		t.Fatalf("forwarded stream events = %+v, want started and ended for s1", stream)
+	}
+	for i, want := range []string{eventStreamStarted, eventStreamEnded} {
+		if stream[i].Type != want || stream[i].SessionID != "s1" || !stream[i].Transient {
+			t.Fatalf("stream event %d = %+v, want transient %s for s1", i, stream[i], want)
+		}
+	}
+	if payload, _ := stream[0].Payload.(map[string]any); payload["streaming"] != true {
+		t.Fatalf("started payload = %v, want streaming=true", stream[0].Payload)
+	}
+
+	for _, want := range []string{eventStreamStarted, eventStreamEnded} {
+		select {
+		case ev := <-ws.send:
+			if ev.Type != want {
+				t.Fatalf("websocket event = %q, want %q", ev.Type, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("websocket client did not receive %s", want)
+		}
+	}
+}
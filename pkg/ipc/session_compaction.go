@@ -0,0 +1,247 @@
+package ipc
+
+import (
+	"context"
+	stdliberrors "errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// compactionResult describes the most recent compaction run for a session.
+type compactionResult struct {
+	StartedAt      time.Time `json:"startedAt"`
+	FinishedAt     time.Time `json:"finishedAt,omitempty"`
+	Success        bool      `json:"success"`
+	Error          string    `json:"error,omitempty"`
+	MessagesBefore int       `json:"messagesBefore"`
+	MessagesAfter  int       `json:"messagesAfter,omitempty"`
+	TokensBefore   int       `json:"tokensBefore"`
+	TokensAfter    int       `json:"tokensAfter,omitempty"`
+}
+
+// compactionTracker keeps one in-flight run per session and the last result.
+// Results live in memory and are lost when the server restarts.
+type compactionTracker struct {
+	mu      sync.Mutex
+	running map[string]bool
+	last    map[string]compactionResult
+}
+
+func newCompactionTracker() *compactionTracker {
+	return &compactionTracker{
+		running: make(map[string]bool),
+		last:    make(map[string]compactionResult),
+	}
+}
+
+// begin marks sessionID as compacting. It returns false if a run is already
+// in progress.
+func (t *compactionTracker) begin(sessionID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.running[sessionID] {
+		return false
+	}
+	t.running[sessionID] = true
+	return true
+}
+
+func (t *compactionTracker) finish(sessionID string, result compactionResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.running, sessionID)
+	t.last[sessionID] = result
+}
+
+func (t *compactionTracker) status(sessionID string) (running bool, last *compactionResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if result, ok := t.last[sessionID]; ok {
+		last = &result
+	}
+	return t.running[sessionID], last
+}
+
+// handleCompactionStatus reports whether a session is due for compaction,
+// using the same threshold check as the TUI, and the last IPC-triggered run.
+func (s *Server) handleCompactionStatus(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeViewer)
+	if !ok {
+		return
+	}
+	sessionID := chi.URLParam(r, "sessionID")
+
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session == nil || !principalCanAccessSession(principal, session) {
+		respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+		return
+	}
+
+	conv := conversation.New(session.ID)
+	if err := conv.LoadFromStorage(s.store); err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	appCfg := s.currentAppConfig()
+	contextWindow := s.sessionContextWindow(session)
+	due := false
+	if contextWindow > 0 {
+		due = conversation.NewCompactionManager(s.models, appCfg).ShouldCompact(conv, contextWindow)
+	}
+	threshold := 0.0
+	if appCfg != nil {
+		threshold = appCfg.Memory.AutoCompactThreshold
+	}
+	usage := 0.0
+	if contextWindow > 0 {
+		usage = float64(conv.BudgetTokenCount()) / float64(contextWindow)
+	}
+	running, last := s.compactions.status(session.ID)
+
+	respondJSON(w, map[string]any{
+		"sessionId":     session.ID,
+		"messages":      len(conv.Messages),
+		"tokens":        conv.BudgetTokenCount(),
+		"contextWindow": contextWindow,
+		"usageRatio":    usage,
+		"threshold":     threshold,
+		"due":           due,
+		"running":       running,
+		"last":          last,
+	})
+}
+
+// handleTriggerCompaction starts compacting a session's stored conversation in
+// the background and returns 202. Poll the status endpoint for the result.
+// Sessions with a live headless runner or an in-flight model stream may still
+// be appending messages that the rewrite would drop, so they are refused.
+func (s *Server) handleTriggerCompaction(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeMember)
+	if !ok {
+		return
+	}
+	sessionID := chi.URLParam(r, "sessionID")
+
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session == nil || !principalCanAccessSession(principal, session) {
+		respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+		return
+	}
+	if s.models == nil {
+		respondError(w, http.StatusServiceUnavailable, stdliberrors.New("model manager unavailable; cannot compact"))
+		return
+	}
+	if s.sessionIsLive(session) {
+		respondError(w, http.StatusConflict, stdliberrors.New("session is running; pause or complete it before compacting"))
+		return
+	}
+
+	conv := conversation.New(session.ID)
+	if err := conv.LoadFromStorage(s.store); err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(conv.Messages) < 4 {
+		respondError(w, http.StatusBadRequest, stdliberrors.New("not enough messages to compact (need at least 4)"))
+		return
+	}
+	if !s.compactions.begin(session.ID) {
+		respondError(w, http.StatusConflict, stdliberrors.New("compaction already running for this session"))
+		return
+	}
+
+	result := compactionResult{
+		StartedAt:      time.Now(),
+		MessagesBefore: len(conv.Messages),
+		TokensBefore:   conversation.CountTokensForMessages(conv.Messages),
+	}
+	_ = s.store.RecordAuditLog(principal.Name, principal.Scope, "session.compact", map[string]any{
+		"sessionId": session.ID,
+		"messages":  result.MessagesBefore,
+	})
+	go s.runCompaction(conv, result)
+
+	respondJSONStatus(w, http.StatusAccepted, map[string]any{
+		"sessionId": session.ID,
+		"status":    "running",
+	})
+}
+
+func (s *Server) runCompaction(conv *conversation.Conversation, result compactionResult) {
+	manager := conversation.NewCompactionManager(s.models, s.currentAppConfig())
+	err := manager.CompactContext(context.Background(), conv)
+	if err == nil {
+		err = s.checkSessionUnchanged(conv.SessionID, result.MessagesBefore)
+	}
+	if err == nil {
+		err = conv.SaveAllMessages(s.store)
+	}
+	result.FinishedAt = time.Now()
+	if err != nil {
+		result.Error = err.Error()
+		s.logger.Printf("compaction of session %s failed: %v", conv.SessionID, err)
+	} else {
+		result.Success = true
+		result.MessagesAfter = len(conv.Messages)
+		result.TokensAfter = conversation.CountTokensForMessages(conv.Messages)
+	}
+	s.compactions.finish(conv.SessionID, result)
+}
+
+// checkSessionUnchanged refuses to save a compaction when the session was
+// resumed or gained messages while the model was summarizing it.
+func (s *Server) checkSessionUnchanged(sessionID string, messages int) error {
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return stdliberrors.New("session was deleted during compaction")
+	}
+	if s.sessionIsLive(session) {
+		return stdliberrors.New("session was resumed during compaction; nothing was saved")
+	}
+	stats, err := s.store.GetSessionStats(sessionID)
+	if err != nil {
+		return err
+	}
+	if stats.MessageCount != messages {
+		return stdliberrors.New("session gained messages during compaction; nothing was saved")
+	}
+	return nil
+}
+
+// sessionContextWindow returns the context length of the session's model, or
+// of the configured execution model, or 0 when neither is known.
+func (s *Server) sessionContextWindow(session *storage.Session) int {
+	if s.models == nil {
+		return 0
+	}
+	modelID := strings.TrimSpace(session.Model)
+	if appCfg := s.currentAppConfig(); modelID == "" && appCfg != nil {
+		modelID = appCfg.Models.Execution
+	}
+	if modelID == "" {
+		return 0
+	}
+	window, err := s.models.GetContextLength(modelID)
+	if err != nil {
+		return 0
+	}
+	return window
+}
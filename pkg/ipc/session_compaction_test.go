@@ -0,0 +1,133 @@
+package ipc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/model"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func compactionRequest(server *Server, method, principal, scope, sessionID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/sessions/"+sessionID+"/compaction", nil)
+	req = withPrincipal(req, principal, scope)
+	req = withURLParam(req, "sessionID", sessionID)
+	rr := httptest.NewRecorder()
+	if method == http.MethodPost {
+		server.handleTriggerCompaction(rr, req)
+	} else {
+		server.handleCompactionStatus(rr, req)
+	}
+	return rr
+}
+
+func seedCompactionSession(t *testing.T, store *storage.Store, sessionID string, messages int) {
+	t.Helper()
+	now := time.Now()
+	if err := store.CreateSession(&storage.Session{ID: sessionID, Principal: "test", CreatedAt: now, LastActive: now, Status: storage.SessionStatusPaused}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	for i := 0; i < messages; i++ {
+		msg := &storage.Message{SessionID: sessionID, Role: "user", Content: fmt.Sprintf("message %d", i), Timestamp: now.Add(time.Duration(i) * time.Second), Tokens: 10}
+		if err := store.SaveMessage(msg); err != nil {
+			t.Fatalf("failed to save message: %v", err)
+		}
+	}
+}
+
+func TestHandleCompactionStatus(t *testing.T) {
+	server, store := testServer(t)
+	seedCompactionSession(t, store, "compact-session", 5)
+
+	rr := compactionRequest(server, http.MethodGet, "test", storage.TokenScopeViewer, "compact-session")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Messages int             `json:"messages"`
+		Due      bool            `json:"due"`
+		Running  bool            `json:"running"`
+		Last     json.RawMessage `json:"last"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Messages != 5 || resp.Due || resp.Running || string(resp.Last) != "null" {
+		t.Fatalf("unexpected status without a known context window: %+v", resp)
+	}
+
+	server.compactions.finish("compact-session", compactionResult{StartedAt: time.Now(), Success: true, MessagesBefore: 5, MessagesAfter: 2})
+	rr = compactionRequest(server, http.MethodGet, "test", storage.TokenScopeViewer, "compact-session")
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	var last compactionResult
+	if err := json.Unmarshal(resp.Last, &last); err != nil || !last.Success || last.MessagesAfter != 2 {
+		t.Fatalf("expected last compaction result, got %s (err=%v)", resp.Last, err)
+	}
+
+	if rr := compactionRequest(server, http.MethodGet, "intruder", storage.TokenScopeViewer, "compact-session"); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected other principal to get %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestHandleTriggerCompactionGuards(t *testing.T) {
+	server, store := testServer(t)
+	seedCompactionSession(t, store, "compact-session", 5)
+	seedCompactionSession(t, store, "short-session", 2)
+
+	if rr := compactionRequest(server, http.MethodPost, "test", storage.TokenScopeViewer, "compact-session"); rr.Code != http.StatusForbidden {
+		t.Fatalf("viewer trigger: expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+	if rr := compactionRequest(server, http.MethodPost, "test", storage.TokenScopeMember, "compact-session"); rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("no models: expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	server.models = &model.Manager{}
+	if rr := compactionRequest(server, http.MethodPost, "test", storage.TokenScopeMember, "short-session"); rr.Code != http.StatusBadRequest {
+		t.Fatalf("short session: expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	seedCompactionSession(t, store, "live-session", 5)
+	server.runtimeTracker.SetStreaming("live-session", true)
+	if rr := compactionRequest(server, http.MethodPost, "test", storage.TokenScopeMember, "live-session"); rr.Code != http.StatusConflict {
+		t.Fatalf("streaming session: expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+
+	if err := server.checkSessionUnchanged("compact-session", 5); err != nil {
+		t.Fatalf("unchanged session: %v", err)
+	}
+	if err := store.SaveMessage(&storage.Message{SessionID: "compact-session", Role: "user", Content: "late", Timestamp: time.Now(), Tokens: 1}); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	if err := server.checkSessionUnchanged("compact-session", 5); err == nil {
+		t.Fatal("expected a session that gained messages to be refused")
+	}
+
+	if !server.compactions.begin("compact-session") {
+		t.Fatalf("expected first begin to succeed")
+	}
+	if rr := compactionRequest(server, http.MethodPost, "test", storage.TokenScopeMember, "compact-session"); rr.Code != http.StatusConflict {
+		t.Fatalf("concurrent trigger: expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestCompactionLivenessIgnoresStaleActiveStatus(t *testing.T) {
+	server, store := testServer(t)
+	seedCompactionSession(t, store, "stale-session", 5)
+	if err := store.SetSessionStatus("stale-session", storage.SessionStatusActive); err != nil {
+		t.Fatalf("SetSessionStatus: %v", err)
+	}
+
+	if err := server.checkSessionUnchanged("stale-session", 5); err != nil {
+		t.Fatalf("active session without a runner or stream was refused: %v", err)
+	}
+	server.runtimeTracker.SetStreaming("stale-session", true)
+	if err := server.checkSessionUnchanged("stale-session", 5); err == nil {
+		t.Fatal("expected a session streaming during compaction to be refused")
+	}
+}
@@ -0,0 +1,88 @@
+package ipc
+
+import (
+	stdliberrors "errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"m31labs.dev/buckley/pkg/cost"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// handleSessionCost reports token usage, spend and budget status for one
+// session. Sessions with no recorded API calls report zeros. Daily and
+// monthly totals cover every session on the server, so like /api/metrics/cost
+// they are only included for operators.
+func (s *Server) handleSessionCost(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeViewer)
+	if !ok {
+		return
+	}
+	sessionID := chi.URLParam(r, "sessionID")
+
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session == nil || !principalCanAccessSession(principal, session) {
+		respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+		return
+	}
+
+	usage, err := s.store.GetSessionAPIUsage(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	// The tracker only reads stored totals here, so pricing is never needed.
+	tracker, err := cost.New(sessionID, s.store, noPricing{})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if s.appConfig != nil {
+		budgets := s.appConfig.CostManagement
+		tracker.SetBudgets(budgets.SessionBudget, budgets.DailyBudget, budgets.MonthlyBudget, budgets.AutoStopAt)
+	}
+	status := tracker.CheckBudget()
+
+	budget := map[string]any{
+		"sessionBudget":   status.SessionBudget,
+		"sessionPercent":  status.SessionPercent,
+		"sessionExceeded": status.SessionExceeded,
+		"shouldStop":      status.ShouldStop,
+	}
+	if scopeRank[strings.ToLower(principal.Scope)] >= scopeRank[storage.TokenScopeOperator] {
+		budget["dailyCost"] = status.DailyCost
+		budget["dailyBudget"] = status.DailyBudget
+		budget["dailyPercent"] = status.DailyPercent
+		budget["dailyExceeded"] = status.DailyExceeded
+		budget["monthlyCost"] = status.MonthlyCost
+		budget["monthlyBudget"] = status.MonthlyBudget
+		budget["monthlyPercent"] = status.MonthlyPercent
+		budget["monthlyExceeded"] = status.MonthlyExceeded
+		budget["shouldWarn"] = status.ShouldWarn
+	} else {
+		// The tracker's warning also trips on the global totals.
+		budget["shouldWarn"] = status.SessionPercent >= 80
+	}
+
+	respondJSON(w, map[string]any{
+		"sessionId":        sessionID,
+		"apiCalls":         usage.Calls,
+		"promptTokens":     usage.PromptTokens,
+		"completionTokens": usage.CompletionTokens,
+		"totalTokens":      usage.PromptTokens + usage.CompletionTokens,
+		"totalCost":        status.SessionCost,
+		"budget":           budget,
+	})
+}
+
+type noPricing struct{}
+
+func (noPricing) CalculateCostFromTokens(string, int, int) (float64, error) {
+	return 0, stdliberrors.New("pricing unavailable")
+}
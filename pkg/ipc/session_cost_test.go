@@ -0,0 +1,160 @@
+package ipc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+type sessionCostResponse struct {
+	SessionID        string  `json:"sessionId"`
+	APICalls         int     `json:"apiCalls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	TotalTokens      int     `json:"totalTokens"`
+	TotalCost        float64 `json:"totalCost"`
+	Budget           struct {
+		SessionBudget  float64 `json:"sessionBudget"`
+		SessionPercent float64 `json:"sessionPercent"`
+	} `json:"budget"`
+}
+
+func getSessionCost(t *testing.T, server *Server, principal, sessionID string) (*httptest.ResponseRecorder, sessionCostResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/cost", nil)
+	req = withPrincipal(req, principal, storage.TokenScopeViewer)
+	req = withURLParam(req, "sessionID", sessionID)
+	rr := httptest.NewRecorder()
+	server.handleSessionCost(rr, req)
+
+	var resp sessionCostResponse
+	if rr.Code == http.StatusOK {
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return rr, resp
+}
+
+func TestHandleSessionCost(t *testing.T) {
+	server, store := testServer(t)
+	server.appConfig.CostManagement.SessionBudget = 1.00
+
+	sess := &storage.Session{
+		ID:         "test-session",
+		Principal:  "test",
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     storage.SessionStatusActive,
+	}
+	if err := store.CreateSession(sess); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	rr, resp := getSessionCost(t, server, "test", "test-session")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if resp.SessionID != "test-session" || resp.APICalls != 0 || resp.TotalTokens != 0 || resp.TotalCost != 0 {
+		t.Fatalf("expected zero usage for a session without calls, got %+v", resp)
+	}
+	if resp.Budget.SessionBudget != 1.00 {
+		t.Fatalf("expected the configured session budget, got %+v", resp.Budget)
+	}
+
+	if err := store.SaveAPICall(&storage.APICall{
+		SessionID:        "test-session",
+		Model:            "test/model",
+		PromptTokens:     1200,
+		CompletionTokens: 300,
+		Cost:             0.25,
+		Timestamp:        time.Now(),
+	}); err != nil {
+		t.Fatalf("save api call: %v", err)
+	}
+
+	rr, resp = getSessionCost(t, server, "test", "test-session")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if resp.APICalls != 1 || resp.PromptTokens != 1200 || resp.CompletionTokens != 300 || resp.TotalTokens != 1500 {
+		t.Fatalf("unexpected token totals %+v", resp)
+	}
+	if resp.TotalCost != 0.25 || resp.Budget.SessionPercent != 25 {
+		t.Fatalf("expected total cost 0.25 at 25%% of budget, got %f (%f%%)", resp.TotalCost, resp.Budget.SessionPercent)
+	}
+}
+
+func TestHandleSessionCost_OtherPrincipal(t *testing.T) {
+	server, store := testServer(t)
+
+	sess := &storage.Session{
+		ID:         "owned-session",
+		Principal:  "owner",
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     storage.SessionStatusActive,
+	}
+	if err := store.CreateSession(sess); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	for _, id := range []string{"owned-session", "nonexistent"} {
+		if rr, _ := getSessionCost(t, server, "intruder", id); rr.Code != http.StatusNotFound {
+			t.Errorf("%s: expected status %d, got %d", id, http.StatusNotFound, rr.Code)
+		}
+	}
+}
+
+func TestHandleSessionCost_GlobalTotalsOperatorOnly(t *testing.T) {
+	server, store := testServer(t)
+	server.appConfig.CostManagement.DailyBudget = 1.00
+
+	for _, id := range []string{"test-session", "other-session"} {
+		if err := store.CreateSession(&storage.Session{ID: id, Principal: "test", CreatedAt: time.Now(), LastActive: time.Now(), Status: storage.SessionStatusActive}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+	if err := store.SaveAPICall(&storage.APICall{SessionID: "other-session", Model: "test/model", Cost: 0.90, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("save api call: %v", err)
+	}
+
+	budgetFor := func(scope string) map[string]any {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/test-session/cost", nil)
+		req = withPrincipal(req, "test", scope)
+		req = withURLParam(req, "sessionID", "test-session")
+		rr := httptest.NewRecorder()
+		server.handleSessionCost(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d: %s", scope, http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var resp struct {
+			Budget map[string]any `json:"budget"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp.Budget
+	}
+
+	for _, scope := range []string{storage.TokenScopeViewer, storage.TokenScopeMember} {
+		budget := budgetFor(scope)
+		for _, key := range []string{"dailyCost", "dailyPercent", "monthlyCost", "monthlyPercent"} {
+			if _, ok := budget[key]; ok {
+				t.Errorf("%s: budget includes server-wide %s", scope, key)
+			}
+		}
+		if budget["shouldWarn"] != false {
+			t.Errorf("%s: shouldWarn = %v, want false for an idle session", scope, budget["shouldWarn"])
+		}
+	}
+
+	budget := budgetFor(storage.TokenScopeOperator)
+	if budget["dailyCost"] != 0.90 || budget["shouldWarn"] != true {
+		t.Fatalf("operator budget = %+v, want the server-wide daily total", budget)
+	}
+}
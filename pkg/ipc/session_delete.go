@@ -0,0 +1,64 @@
+package ipc
+
+import (
+	stdliberrors "errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+// handleDeleteSession permanently removes a session and its history. Sessions
+// with a live headless runner or an in-flight model stream are refused with
+// 409; complete or pause them first.
+func (s *Server) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requireScope(w, r, storage.TokenScopeOperator)
+	if !ok {
+		return
+	}
+	sessionID := chi.URLParam(r, "sessionID")
+
+	session, err := s.store.GetSession(sessionID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session == nil {
+		respondError(w, http.StatusNotFound, stdliberrors.New("session not found"))
+		return
+	}
+	if s.sessionIsLive(session) {
+		respondError(w, http.StatusConflict, stdliberrors.New("session is active; complete it before deleting"))
+		return
+	}
+
+	if err := s.store.DeleteSession(sessionID); err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	_ = s.store.RecordAuditLog(principal.Name, principal.Scope, "session.delete", map[string]any{
+		"sessionId": sessionID,
+		"principal": session.Principal,
+		"status":    session.Status,
+	})
+	respondJSON(w, map[string]string{"status": "deleted", "sessionId": sessionID})
+}
+
+// sessionIsLive reports whether something is still driving the session: an
+// attached headless runner or a model stream this server has seen start and
+// not end. The stored status is not enough, since it stays active after the
+// owning process exits.
+func (s *Server) sessionIsLive(session *storage.Session) bool {
+	if s.runtimeTracker != nil {
+		if streaming, _, _, _ := s.runtimeTracker.GetRuntimeState(session.ID); streaming {
+			return true
+		}
+	}
+	if s.headlessRegistry != nil {
+		if _, running := s.headlessRegistry.GetSession(session.ID); running {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package ipc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+func deleteSession(server *Server, scope, sessionID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, "/api/sessions/"+sessionID, nil)
+	req = withPrincipal(req, "ops", scope)
+	req = withURLParam(req, "sessionID", sessionID)
+	rr := httptest.NewRecorder()
+	server.handleDeleteSession(rr, req)
+	return rr
+}
+
+func TestHandleDeleteSession(t *testing.T) {
+	server, store := testServer(t)
+
+	for id, status := range map[string]string{
+		"done-session":   storage.SessionStatusCompleted,
+		"active-session": storage.SessionStatusActive,
+		"stale-session":  storage.SessionStatusActive,
+	} {
+		if err := store.CreateSession(&storage.Session{
+			ID:         id,
+			Principal:  "test",
+			CreatedAt:  time.Now(),
+			LastActive: time.Now(),
+			Status:     status,
+		}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+
+	if rr := deleteSession(server, storage.TokenScopeMember, "done-session"); rr.Code != http.StatusForbidden {
+		t.Fatalf("member delete: expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+	server.runtimeTracker.SetStreaming("active-session", true)
+	if rr := deleteSession(server, storage.TokenScopeOperator, "active-session"); rr.Code != http.StatusConflict {
+		t.Fatalf("streaming delete: expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+	if rr := deleteSession(server, storage.TokenScopeOperator, "stale-session"); rr.Code != http.StatusOK {
+		t.Fatalf("stale active delete: expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if rr := deleteSession(server, storage.TokenScopeOperator, "missing"); rr.Code != http.StatusNotFound {
+		t.Fatalf("missing delete: expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+
+	if rr := deleteSession(server, storage.TokenScopeOperator, "done-session"); rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if sess, err := store.GetSession("done-session"); err != nil || sess != nil {
+		t.Fatalf("expected session to be deleted, got %+v err=%v", sess, err)
+	}
+
+	logs, err := store.ListAuditLogs(10)
+	if err != nil {
+		t.Fatalf("ListAuditLogs: %v", err)
+	}
+	if len(logs) == 0 || logs[0]["action"] != "session.delete" {
+		t.Fatalf("expected a session.delete audit entry, got %+v", logs)
+	}
+}
@@ -18,6 +18,7 @@ func ManagerFromConfig(ctx context.Context, cfg config.MCPConfig) (*Manager, err
 	}
 
 	manager := NewManager()
+	manager.SetHealthTimeout(cfg.HealthTimeout)
 	var errs []string
 
 	for _, srv := range cfg.Servers {
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// ServerEvent reports that an MCP server went down or came back.
+type ServerEvent struct {
+	Server    string
+	Connected bool
+	Err       error
+}
+
+// SetHealthTimeout bounds each health ping. Non-positive values keep the
+// current timeout.
+func (m *Manager) SetHealthTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	m.healthMu.Lock()
+	m.healthCheckTimeout = timeout
+	m.healthMu.Unlock()
+}
+
+// Watch checks every configured server each interval until ctx is done,
+// calling onChange whenever one goes down or reconnects. It returns
+// immediately when interval is not positive.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration, onChange func(ServerEvent)) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, event := range m.CheckServers(ctx) {
+				if onChange != nil {
+					onChange(event)
+				}
+			}
+		}
+	}
+}
+
+// CheckServers runs one health pass. A connected server that fails its ping
+// is disconnected so later calls fail fast; a disconnected one is
+// reconnected, which also refreshes its tool list. Only state changes are
+// returned.
+func (m *Manager) CheckServers(ctx context.Context) []ServerEvent {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	names := m.ListServers()
+	sort.Strings(names)
+
+	var events []ServerEvent
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		if _, connected := m.GetClient(name); connected {
+			err := m.checkServerHealth(ctx, name)
+			if err == nil {
+				m.recordHealth(name, nil)
+				continue
+			}
+			_ = m.DisconnectServer(name)
+			events = append(events, ServerEvent{Server: name, Connected: false, Err: err})
+			continue
+		}
+		if err := m.ConnectServer(ctx, name); err == nil {
+			m.clearHealth(name)
+			events = append(events, ServerEvent{Server: name, Connected: true})
+		}
+	}
+	return events
+}
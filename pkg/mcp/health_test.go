@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestHelperMCPServer is not a real test. It serves initialize and
+// tools/list over stdio when run as a child process by the tests below.
+func TestHelperMCPServer(t *testing.T) {
+	if os.Getenv("BUCKLEY_MCP_HELPER") != "1" {
+		return
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var msg struct {
+			ID     *int64 `json:"id"`
+			Method string `json:"method"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil || msg.ID == nil {
+			continue
+		}
+		result := `{}`
+		switch msg.Method {
+		case "initialize":
+			result = `{"protocolVersion":"2024-11-05","serverInfo":{"name":"helper","version":"1.0.0"}}`
+		case "tools/list":
+			result = `{"tools":[{"name":"echo","description":"Echo input"}]}`
+		}
+		fmt.Printf("{\"jsonrpc\":\"2.0\",\"id\":%d,\"result\":%s}\n", *msg.ID, result)
+	}
+	os.Exit(0)
+}
+
+func TestCheckServersReconnectsDroppedServer(t *testing.T) {
+	m := NewManager()
+	m.AddServer(Config{
+		Name:    "helper",
+		Command: os.Args[0],
+		Args:    []string{"-test.run=TestHelperMCPServer"},
+		Env:     map[string]string{"BUCKLEY_MCP_HELPER": "1"},
+		Timeout: 10 * time.Second,
+	})
+	defer m.Close()
+
+	events := m.CheckServers(context.Background())
+	if len(events) != 1 || events[0].Server != "helper" || !events[0].Connected {
+		t.Fatalf("events = %+v, want helper connected", events)
+	}
+	var names []string
+	RegisterServerTools(m, "helper", func(name string, _ any) { names = append(names, name) })
+	if len(names) != 1 || names[0] != "mcp__helper__echo" {
+		t.Fatalf("registered tools = %v", names)
+	}
+
+	if events := m.CheckServers(context.Background()); len(events) != 0 {
+		t.Fatalf("healthy pass reported %+v", events)
+	}
+}
+
+func TestCheckServersDisconnectsUnresponsiveServer(t *testing.T) {
+	m := NewManager()
+	m.SetHealthTimeout(20 * time.Millisecond)
+	m.mu.Lock()
+	m.configs["stuck"] = Config{Name: "stuck", Command: "nonexistent-command-xyz123"}
+	m.clients["stuck"] = &Client{
+		serverID: "stuck",
+		pending:  make(map[int64]chan *Message),
+		closed:   true,
+		stdin:    &mockCloser{},
+		stdout:   &mockCloser{},
+		stderr:   &mockCloser{},
+	}
+	m.mu.Unlock()
+
+	events := m.CheckServers(context.Background())
+	if len(events) != 1 || events[0].Connected || events[0].Err == nil {
+		t.Fatalf("events = %+v, want stuck down with an error", events)
+	}
+	if _, ok := m.GetClient("stuck"); ok {
+		t.Fatal("unresponsive server still connected")
+	}
+
+	// Still unreachable: no repeated down event.
+	if events := m.CheckServers(context.Background()); len(events) != 0 {
+		t.Fatalf("second pass reported %+v", events)
+	}
+}
+
+func TestWatchStopsWithContext(t *testing.T) {
+	m := NewManager()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Watch(ctx, time.Millisecond, nil)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return after cancel")
+	}
+	m.Watch(context.Background(), 0, nil)
+}
@@ -361,7 +361,9 @@ func (m *Manager) checkServerHealth(ctx context.Context, serverName string) erro
 	if !ok || client == nil {
 		return fmt.Errorf("server not connected: %s", serverName)
 	}
+	m.healthMu.Lock()
 	timeout := m.healthCheckTimeout
+	m.healthMu.Unlock()
 	if timeout <= 0 {
 		timeout = 2 * time.Second
 	}
@@ -33,7 +33,7 @@ func NewToolAdapter(manager *Manager, serverName string, tool ToolDefinition, ti
 
 // Name returns the tool name (prefixed with server name)
 func (t *ToolAdapter) Name() string {
-	return fmt.Sprintf("mcp__%s__%s", t.serverName, t.tool.Name)
+	return ToolPrefix(t.serverName) + t.tool.Name
 }
 
 // Description returns the tool description
@@ -155,15 +155,41 @@ func RegisterMCPTools(manager *Manager, register func(name string, tool any)) {
 		return
 	}
 	for _, twt := range manager.AllTools() {
-		timeout := 60 * time.Second
-		if cfg, ok := manager.configs[twt.Server]; ok && cfg.Timeout > 0 {
-			timeout = cfg.Timeout
+		adapter := manager.adapterFor(twt)
+		register(adapter.Name(), adapter)
+	}
+}
+
+// RegisterServerTools registers the tools of one connected server, for
+// example after it reconnects with a changed tool list.
+func RegisterServerTools(manager *Manager, server string, register func(name string, tool any)) {
+	if manager == nil {
+		return
+	}
+	for _, twt := range manager.AllTools() {
+		if twt.Server != server {
+			continue
 		}
-		adapter := NewToolAdapter(manager, twt.Server, twt.Tool, timeout)
+		adapter := manager.adapterFor(twt)
 		register(adapter.Name(), adapter)
 	}
 }
 
+// ToolPrefix is the name prefix shared by every tool of server.
+func ToolPrefix(server string) string {
+	return fmt.Sprintf("mcp__%s__", server)
+}
+
+func (m *Manager) adapterFor(twt ToolWithServer) *ToolAdapter {
+	timeout := 60 * time.Second
+	m.mu.RLock()
+	if cfg, ok := m.configs[twt.Server]; ok && cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+	m.mu.RUnlock()
+	return NewToolAdapter(m, twt.Server, twt.Tool, timeout)
+}
+
 // MCPToolInfo provides info about MCP tools for display
 type MCPToolInfo struct {
 	FullName    string
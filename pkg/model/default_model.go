@@ -0,0 +1,98 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+// FallbackExecutionModel is used when no execution model is configured and
+// no provider catalog is loaded to choose from.
+const FallbackExecutionModel = "openai/gpt-4o"
+
+// DefaultExecutionModel returns the model to use when no execution model
+// resolves from config or rules. FallbackExecutionModel wins while it is in
+// the catalog; otherwise the first ready provider (the default provider, then
+// the rest in order) supplies its first tool-capable model, or its first model
+// when none advertise tools. auto reports whether the result was picked from a
+// catalog rather than the fixed fallback.
+func (m *Manager) DefaultExecutionModel() (modelID string, auto bool) {
+	if m == nil || m.modelAvailable(FallbackExecutionModel) {
+		return FallbackExecutionModel, false
+	}
+	for _, providerID := range m.defaultProviderOrder() {
+		if candidate, ok := m.preferredModelForProvider(providerID); ok {
+			return candidate, true
+		}
+	}
+	return FallbackExecutionModel, false
+}
+
+// ExecutionModelOrDefault returns resolved, the execution model from config
+// and rules, unless it is empty or is the built-in default and missing from
+// every loaded catalog. In those cases it returns DefaultExecutionModel, and
+// auto reports that the result replaced the built-in default or came from a
+// catalog.
+func (m *Manager) ExecutionModelOrDefault(resolved string) (modelID string, auto bool) {
+	resolved = strings.TrimSpace(resolved)
+	if resolved == "" {
+		return m.DefaultExecutionModel()
+	}
+	if m == nil || resolved != config.DefaultExecutionModel || !m.catalogLoaded() || m.modelAvailable(resolved) {
+		return resolved, false
+	}
+	modelID, _ = m.DefaultExecutionModel()
+	return modelID, modelID != resolved
+}
+
+// catalogLoaded reports whether any provider catalog has been loaded.
+func (m *Manager) catalogLoaded() bool {
+	m.catalogMu.RLock()
+	defer m.catalogMu.RUnlock()
+	return len(m.catalog) > 0
+}
+
+// AutoSelectedModelNote describes an execution model chosen by
+// ExecutionModelOrDefault for display to the user.
+func AutoSelectedModelNote(modelID string) string {
+	return fmt.Sprintf("the default execution model is not available from any ready provider; using %s (set models.execution to choose)", modelID)
+}
+
+// defaultProviderOrder lists provider IDs with the configured default first.
+func (m *Manager) defaultProviderOrder() []string {
+	order := make([]string, 0, len(m.providerOrder)+1)
+	seen := make(map[string]bool)
+	candidates := m.providerOrder
+	if m.config != nil && m.config.Models.DefaultProvider != "" {
+		candidates = append([]string{m.config.Models.DefaultProvider}, candidates...)
+	}
+	for _, providerID := range candidates {
+		if providerID == "" || seen[providerID] {
+			continue
+		}
+		if _, ok := m.providers[providerID]; !ok {
+			continue
+		}
+		seen[providerID] = true
+		order = append(order, providerID)
+	}
+	return order
+}
+
+// preferredModelForProvider returns the first tool-capable model in a
+// provider's catalog, falling back to its first model.
+func (m *Manager) preferredModelForProvider(providerID string) (string, bool) {
+	m.catalogMu.RLock()
+	defer m.catalogMu.RUnlock()
+	models := m.providerModels[providerID]
+	if len(models) == 0 {
+		return "", false
+	}
+	for _, modelID := range models {
+		if info, ok := m.catalog[modelID]; ok && info.SupportsTools() {
+			return modelID, true
+		}
+	}
+	return models[0], true
+}
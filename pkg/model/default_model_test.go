@@ -0,0 +1,149 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func newDefaultModelTestManager(defaultProvider string, catalogs map[string][]ModelInfo) *Manager {
+	providers := make(map[string]Provider, len(catalogs))
+	for id := range catalogs {
+		providers[id] = &stubProvider{id: id}
+	}
+	mgr := newLatencyTestManager(providers)
+	mgr.config.Models.DefaultProvider = defaultProvider
+	for providerID, infos := range catalogs {
+		for _, info := range infos {
+			mgr.catalog[info.ID] = info
+			mgr.providerModels[providerID] = append(mgr.providerModels[providerID], info.ID)
+			mgr.modelProviders[info.ID] = providerID
+		}
+	}
+	return mgr
+}
+
+func TestDefaultExecutionModel(t *testing.T) {
+	tools := []string{"tools"}
+	tests := []struct {
+		name            string
+		defaultProvider string
+		catalogs        map[string][]ModelInfo
+		want            string
+		wantAuto        bool
+	}{
+		{
+			name:     "fallback available",
+			catalogs: map[string][]ModelInfo{"openai": {{ID: "openai/gpt-5"}, {ID: FallbackExecutionModel}}},
+			want:     FallbackExecutionModel,
+		},
+		{
+			name:     "fallback served through openrouter",
+			catalogs: map[string][]ModelInfo{"anthropic": {{ID: "anthropic/claude-sonnet-4"}}, "openrouter": {{ID: FallbackExecutionModel}}},
+			want:     FallbackExecutionModel,
+		},
+		{
+			name:     "anthropic only",
+			catalogs: map[string][]ModelInfo{"anthropic": {{ID: "anthropic/claude-sonnet-4", SupportedParameters: tools}}},
+			want:     "anthropic/claude-sonnet-4",
+			wantAuto: true,
+		},
+		{
+			name: "prefers tool-capable model",
+			catalogs: map[string][]ModelInfo{"ollama": {
+				{ID: "ollama/nomic-embed-text"},
+				{ID: "ollama/qwen3", SupportedParameters: tools},
+			}},
+			want:     "ollama/qwen3",
+			wantAuto: true,
+		},
+		{
+			name:     "first model when none advertise tools",
+			catalogs: map[string][]ModelInfo{"litellm": {{ID: "litellm/local-a"}, {ID: "litellm/local-b"}}},
+			want:     "litellm/local-a",
+			wantAuto: true,
+		},
+		{
+			name:            "default provider wins over provider order",
+			defaultProvider: "ollama",
+			catalogs: map[string][]ModelInfo{
+				"anthropic": {{ID: "anthropic/claude-sonnet-4", SupportedParameters: tools}},
+				"ollama":    {{ID: "ollama/qwen3", SupportedParameters: tools}},
+			},
+			want:     "ollama/qwen3",
+			wantAuto: true,
+		},
+		{
+			name:            "unready default provider is skipped",
+			defaultProvider: "openai",
+			catalogs: map[string][]ModelInfo{
+				"google":    {{ID: "google/gemini-2.5-pro", SupportedParameters: tools}},
+				"anthropic": {{ID: "anthropic/claude-sonnet-4", SupportedParameters: tools}},
+			},
+			want:     "anthropic/claude-sonnet-4",
+			wantAuto: true,
+		},
+		{
+			name:     "empty catalogs keep fallback",
+			catalogs: map[string][]ModelInfo{"anthropic": nil},
+			want:     FallbackExecutionModel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newDefaultModelTestManager(tt.defaultProvider, tt.catalogs)
+			got, auto := mgr.DefaultExecutionModel()
+			if got != tt.want || auto != tt.wantAuto {
+				t.Fatalf("DefaultExecutionModel() = %q, %v; want %q, %v", got, auto, tt.want, tt.wantAuto)
+			}
+		})
+	}
+}
+
+func TestDefaultExecutionModelNilManager(t *testing.T) {
+	var mgr *Manager
+	if got, auto := mgr.DefaultExecutionModel(); got != FallbackExecutionModel || auto {
+		t.Fatalf("nil manager = %q, %v; want fallback", got, auto)
+	}
+}
+
+func TestExecutionModelOrDefaultReplacesUnavailableBuiltInDefault(t *testing.T) {
+	builtIn := config.DefaultConfig().Models.Execution
+	tools := []string{"tools"}
+	anthropicOnly := map[string][]ModelInfo{"anthropic": {{ID: "anthropic/claude-sonnet-4", SupportedParameters: tools}}}
+	tests := []struct {
+		name     string
+		resolved string
+		catalogs map[string][]ModelInfo
+		want     string
+		wantAuto bool
+	}{
+		{name: "built-in default unavailable", resolved: builtIn, catalogs: anthropicOnly, want: "anthropic/claude-sonnet-4", wantAuto: true},
+		{name: "built-in default available", resolved: builtIn, catalogs: map[string][]ModelInfo{"openrouter": {{ID: builtIn}}}, want: builtIn},
+		{name: "no catalog loaded yet", resolved: builtIn, catalogs: map[string][]ModelInfo{"anthropic": nil}, want: builtIn},
+		{name: "explicit model kept", resolved: "openai/gpt-5", catalogs: anthropicOnly, want: "openai/gpt-5"},
+		{name: "nothing resolved", resolved: " ", catalogs: anthropicOnly, want: "anthropic/claude-sonnet-4", wantAuto: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newDefaultModelTestManager("", tt.catalogs)
+			got, auto := mgr.ExecutionModelOrDefault(tt.resolved)
+			if got != tt.want || auto != tt.wantAuto {
+				t.Fatalf("ExecutionModelOrDefault(%q) = %q, %v; want %q, %v", tt.resolved, got, auto, tt.want, tt.wantAuto)
+			}
+		})
+	}
+
+	var nilMgr *Manager
+	if got, auto := nilMgr.ExecutionModelOrDefault(builtIn); got != builtIn || auto {
+		t.Fatalf("nil manager = %q, %v; want the resolved model", got, auto)
+	}
+}
+
+func TestAutoSelectedModelNote(t *testing.T) {
+	note := AutoSelectedModelNote("anthropic/claude-sonnet-4")
+	if !strings.Contains(note, "anthropic/claude-sonnet-4") || !strings.Contains(note, "models.execution") {
+		t.Fatalf("note = %q, want model ID and config hint", note)
+	}
+}
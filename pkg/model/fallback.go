@@ -0,0 +1,118 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/telemetry"
+)
+
+// resolveFallbackModels keeps the models.fallbacks entries present in the
+// catalog and returns a warning for each one skipped.
+func (m *Manager) resolveFallbackModels() []string {
+	if m == nil || m.config == nil {
+		return nil
+	}
+	var (
+		available []string
+		warnings  []string
+	)
+	seen := make(map[string]bool)
+	for _, modelID := range m.config.Models.Fallbacks {
+		modelID = strings.TrimSpace(modelID)
+		if modelID == "" || seen[modelID] {
+			continue
+		}
+		seen[modelID] = true
+		if !m.modelAvailable(modelID) {
+			warnings = append(warnings, fmt.Sprintf("fallback model %q not found; skipping", modelID))
+			continue
+		}
+		available = append(available, modelID)
+	}
+	m.catalogMu.Lock()
+	m.fallbacks = available
+	m.catalogMu.Unlock()
+	return warnings
+}
+
+// fallbackCandidates returns the fallback models to try, in order, after a
+// request for modelID fails.
+func (m *Manager) fallbackCandidates(modelID string) []string {
+	if m == nil {
+		return nil
+	}
+	modelID = strings.TrimSpace(modelID)
+	m.catalogMu.RLock()
+	defer m.catalogMu.RUnlock()
+	candidates := make([]string, 0, len(m.fallbacks))
+	for _, candidate := range m.fallbacks {
+		if candidate != modelID {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// fallbackRequest retargets req at modelID. Any OpenRouter fallback list
+// built for the original model is dropped.
+func fallbackRequest(req ChatRequest, modelID string) ChatRequest {
+	req.Model = modelID
+	req.Models = nil
+	return req
+}
+
+// isProviderUnavailableError reports whether err means the provider could
+// not serve the request at all, as opposed to rejecting the request itself.
+func isProviderUnavailableError(err error) bool {
+	if isTransientModelError(err) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+func (m *Manager) publishFallback(from, to string, err error) {
+	if m == nil || m.telemetry == nil {
+		return
+	}
+	m.telemetry.Publish(telemetry.Event{
+		Type: telemetry.EventModelFallback,
+		Data: map[string]any{
+			"from":  from,
+			"to":    to,
+			"error": err.Error(),
+		},
+	})
+}
+
+// streamWithFallback opens a stream for req and moves down the fallback
+// chain while the provider is unavailable. Once a chunk has been forwarded
+// the stream is committed and later errors are passed through unchanged.
+func (m *Manager) streamWithFallback(ctx context.Context, req ChatRequest) (<-chan StreamChunk, <-chan error) {
+	out := make(chan StreamChunk)
+	outErrs := make(chan error, 1)
+	go func() {
+		defer close(outErrs)
+		defer close(out)
+		candidates := m.fallbackCandidates(req.Model)
+		current, from := req, req.Model
+		for i := 0; ; i++ {
+			chunks, errs := m.chatCompletionStreamModel(ctx, current)
+			started, err := forwardStream(ctx, chunks, errs, out)
+			if err == nil {
+				return
+			}
+			if started || i >= len(candidates) || !isProviderUnavailableError(err) {
+				outErrs <- err
+				return
+			}
+			m.publishFallback(from, candidates[i], err)
+			current, from = fallbackRequest(req, candidates[i]), candidates[i]
+		}
+	}()
+	return out, outErrs
+}
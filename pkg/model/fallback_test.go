@@ -0,0 +1,117 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/telemetry"
+)
+
+func newFallbackTestManager(t *testing.T, primary *flakyProvider, fallbacks ...string) (*Manager, *stubProvider) {
+	t.Helper()
+	secondary := &stubProvider{
+		id:      "p2",
+		catalog: ModelCatalog{Data: []ModelInfo{{ID: "p2/model-b", ContextLength: 8_000}}},
+	}
+	cfg := &config.Config{
+		Models: config.ModelConfig{
+			Execution:       "p1/model-a",
+			DefaultProvider: "p1",
+			FallbackChains:  map[string][]string{},
+			Fallbacks:       fallbacks,
+		},
+		Providers: config.ProviderConfig{
+			ModelRouting: map[string]string{"p1/": "p1", "p2/": "p2"},
+		},
+	}
+	mgr := &Manager{
+		config:         cfg,
+		providers:      map[string]Provider{"p1": primary, "p2": secondary},
+		providerOrder:  []string{"p1", "p2"},
+		catalog:        make(map[string]ModelInfo),
+		providerModels: make(map[string][]string),
+		modelProviders: make(map[string]string),
+	}
+	if err := mgr.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	return mgr, secondary
+}
+
+func TestInitializeSkipsFallbacksMissingFromCatalog(t *testing.T) {
+	mgr, _ := newFallbackTestManager(t, newFlakyProvider(), "p9/missing", "p2/model-b", "p2/model-b")
+
+	got := mgr.fallbackCandidates("p1/model-a")
+	if len(got) != 1 || got[0] != "p2/model-b" {
+		t.Fatalf("fallbackCandidates() = %v, want [p2/model-b]", got)
+	}
+	if got := mgr.fallbackCandidates("p2/model-b"); len(got) != 0 {
+		t.Fatalf("fallbackCandidates() for the fallback itself = %v, want none", got)
+	}
+}
+
+func TestChatCompletionFallsBackWhenProviderUnavailable(t *testing.T) {
+	primary := newFlakyProvider(&APIError{StatusCode: http.StatusServiceUnavailable, Message: "down"})
+	mgr, secondary := newFallbackTestManager(t, primary, "p2/model-b")
+	hub := telemetry.NewHub()
+	defer hub.Close()
+	events, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+	mgr.EnableTelemetry(hub)
+
+	resp, err := mgr.ChatCompletion(context.Background(), chatRequest())
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+	// The p2 prefix is stripped before the request reaches its provider.
+	if resp.Model != "model-b" || secondary.lastRequest.Model != "model-b" {
+		t.Fatalf("response model = %q, fallback request = %q", resp.Model, secondary.lastRequest.Model)
+	}
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type != telemetry.EventModelFallback {
+				continue
+			}
+			if event.Data["from"] != "p1/model-a" || event.Data["to"] != "p2/model-b" {
+				t.Fatalf("fallback event data = %+v", event.Data)
+			}
+			return
+		case <-timeout:
+			t.Fatal("no fallback telemetry event published")
+		}
+	}
+}
+
+func TestChatCompletionDoesNotFallBackOnRequestErrors(t *testing.T) {
+	primary := newFlakyProvider(&APIError{StatusCode: http.StatusBadRequest, Message: "bad prompt"})
+	mgr, secondary := newFallbackTestManager(t, primary, "p2/model-b")
+
+	if _, err := mgr.ChatCompletion(context.Background(), chatRequest()); err == nil {
+		t.Fatal("expected the request error to be returned")
+	}
+	if secondary.lastRequest.Model != "" {
+		t.Fatalf("fallback model was called with %q", secondary.lastRequest.Model)
+	}
+}
+
+func TestChatCompletionStreamFallsBackBeforeFirstChunk(t *testing.T) {
+	primary := newFlakyProvider(&APIError{StatusCode: http.StatusBadGateway, Message: "bad gateway"})
+	mgr, secondary := newFallbackTestManager(t, primary, "p2/model-b")
+
+	chunks, errs := mgr.ChatCompletionStream(context.Background(), chatRequest())
+	for range chunks {
+	}
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("stream error = %v", err)
+		}
+	}
+	if primary.calls != 1 || secondary.lastRequest.Model != "model-b" {
+		t.Fatalf("primary calls = %d, fallback request = %q", primary.calls, secondary.lastRequest.Model)
+	}
+}
@@ -0,0 +1,172 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"m31labs.dev/buckley/pkg/telemetry"
+)
+
+// LatencyStats aggregates request latency for one provider/model pair.
+// Streaming requests measure time to the first chunk; non-streaming requests
+// measure the full round trip.
+type LatencyStats struct {
+	Provider string
+	Model    string
+	Requests int
+	Errors   int
+	Total    time.Duration
+	Min      time.Duration
+	Max      time.Duration
+	Last     time.Duration
+}
+
+// Mean returns the average latency across recorded requests.
+func (s LatencyStats) Mean() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Requests)
+}
+
+// MarshalJSON reports latencies in milliseconds.
+func (s LatencyStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Provider string `json:"provider"`
+		Model    string `json:"model"`
+		Requests int    `json:"requests"`
+		Errors   int    `json:"errors"`
+		MeanMs   int64  `json:"meanMs"`
+		MinMs    int64  `json:"minMs"`
+		MaxMs    int64  `json:"maxMs"`
+		LastMs   int64  `json:"lastMs"`
+	}{
+		Provider: s.Provider,
+		Model:    s.Model,
+		Requests: s.Requests,
+		Errors:   s.Errors,
+		MeanMs:   s.Mean().Milliseconds(),
+		MinMs:    s.Min.Milliseconds(),
+		MaxMs:    s.Max.Milliseconds(),
+		LastMs:   s.Last.Milliseconds(),
+	})
+}
+
+type latencyKey struct {
+	provider string
+	model    string
+}
+
+type latencyTracker struct {
+	mu    sync.Mutex
+	stats map[latencyKey]*LatencyStats
+}
+
+func (t *latencyTracker) record(providerID, modelID string, elapsed time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stats == nil {
+		t.stats = make(map[latencyKey]*LatencyStats)
+	}
+	key := latencyKey{provider: providerID, model: modelID}
+	stat, ok := t.stats[key]
+	if !ok {
+		stat = &LatencyStats{Provider: providerID, Model: modelID, Min: elapsed}
+		t.stats[key] = stat
+	}
+	stat.Requests++
+	if failed {
+		stat.Errors++
+	}
+	stat.Total += elapsed
+	stat.Last = elapsed
+	if elapsed < stat.Min {
+		stat.Min = elapsed
+	}
+	if elapsed > stat.Max {
+		stat.Max = elapsed
+	}
+}
+
+func (t *latencyTracker) snapshot() []LatencyStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]LatencyStats, 0, len(t.stats))
+	for _, stat := range t.stats {
+		out = append(out, *stat)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Provider != out[j].Provider {
+			return out[i].Provider < out[j].Provider
+		}
+		return out[i].Model < out[j].Model
+	})
+	return out
+}
+
+// LatencyStats returns per-provider/model latency aggregates sorted by
+// provider then model.
+func (m *Manager) LatencyStats() []LatencyStats {
+	if m == nil {
+		return nil
+	}
+	return m.latency.snapshot()
+}
+
+func (m *Manager) recordLatency(providerID, modelID string, elapsed time.Duration, stream bool, err error) {
+	if m == nil {
+		return
+	}
+	m.latency.record(providerID, modelID, elapsed, err != nil)
+	if m.telemetry == nil {
+		return
+	}
+	data := map[string]any{
+		"provider":   providerID,
+		"model":      modelID,
+		"latency_ms": elapsed.Milliseconds(),
+		"stream":     stream,
+	}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+	m.telemetry.Publish(telemetry.Event{
+		Type: telemetry.EventModelLatency,
+		Data: data,
+	})
+}
+
+// timeFirstChunk forwards a provider stream and records the time until its
+// first chunk, or until it closes if no chunk arrives.
+func (m *Manager) timeFirstChunk(ctx context.Context, providerID, modelID string, start time.Time, in <-chan StreamChunk) <-chan StreamChunk {
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+		recorded := false
+		defer func() {
+			if !recorded {
+				m.recordLatency(providerID, modelID, time.Since(start), true, nil)
+			}
+		}()
+		for chunk := range in {
+			if !recorded {
+				recorded = true
+				m.recordLatency(providerID, modelID, time.Since(start), true, nil)
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// Let the provider finish without blocking on a reader that left.
+				go func() {
+					for range in {
+					}
+				}()
+				return
+			}
+		}
+	}()
+	return out
+}
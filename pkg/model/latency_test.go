@@ -0,0 +1,146 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/telemetry"
+)
+
+type failingStubProvider struct {
+	*stubProvider
+}
+
+func (s *failingStubProvider) ChatCompletion(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	return nil, errors.New("upstream unavailable")
+}
+
+type streamingStubProvider struct {
+	*stubProvider
+}
+
+func (s *streamingStubProvider) ChatCompletionStream(ctx context.Context, req ChatRequest) (<-chan StreamChunk, <-chan error) {
+	chunks := make(chan StreamChunk, 2)
+	errs := make(chan error)
+	chunks <- StreamChunk{ID: "chunk-1"}
+	chunks <- StreamChunk{ID: "chunk-2"}
+	close(chunks)
+	close(errs)
+	return chunks, errs
+}
+
+func newLatencyTestManager(providers map[string]Provider) *Manager {
+	order := make([]string, 0, len(providers))
+	for id := range providers {
+		order = append(order, id)
+	}
+	sort.Strings(order)
+	return &Manager{
+		config: &config.Config{
+			Providers: config.ProviderConfig{
+				ModelRouting: map[string]string{"p1/model-a": "p1", "p2/model-b": "p2"},
+			},
+		},
+		providers:      providers,
+		providerOrder:  order,
+		catalog:        make(map[string]ModelInfo),
+		providerModels: make(map[string][]string),
+		modelProviders: make(map[string]string),
+	}
+}
+
+func TestManagerRecordsLatencyPerRequest(t *testing.T) {
+	mgr := newLatencyTestManager(map[string]Provider{
+		"p1": &stubProvider{id: "p1"},
+		"p2": &failingStubProvider{stubProvider: &stubProvider{id: "p2"}},
+	})
+	hub := telemetry.NewHub()
+	defer hub.Close()
+	events, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+	mgr.EnableTelemetry(hub)
+
+	for range 2 {
+		if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "p1/model-a"}); err != nil {
+			t.Fatalf("ChatCompletion p1: %v", err)
+		}
+	}
+	if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "p2/model-b"}); err == nil {
+		t.Fatal("expected p2 failure")
+	}
+
+	stats := mgr.LatencyStats()
+	if len(stats) != 2 {
+		t.Fatalf("stats = %+v, want one entry per provider/model", stats)
+	}
+	if stats[0].Provider != "p1" || stats[0].Model != "p1/model-a" || stats[0].Requests != 2 || stats[0].Errors != 0 {
+		t.Fatalf("p1 stats = %+v", stats[0])
+	}
+	if stats[0].Min > stats[0].Max || stats[0].Total < stats[0].Max {
+		t.Fatalf("p1 aggregates inconsistent: %+v", stats[0])
+	}
+	if stats[1].Provider != "p2" || stats[1].Requests != 1 || stats[1].Errors != 1 {
+		t.Fatalf("p2 stats = %+v", stats[1])
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case event := <-events:
+			if event.Type != telemetry.EventModelLatency {
+				t.Fatalf("event type = %q, want %q", event.Type, telemetry.EventModelLatency)
+			}
+			if _, ok := event.Data["latency_ms"]; !ok {
+				t.Fatalf("event data missing latency_ms: %v", event.Data)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for latency event %d", i+1)
+		}
+	}
+}
+
+func TestManagerRecordsStreamTimeToFirstChunk(t *testing.T) {
+	mgr := newLatencyTestManager(map[string]Provider{
+		"p1": &streamingStubProvider{stubProvider: &stubProvider{id: "p1"}},
+	})
+
+	chunks, _ := mgr.ChatCompletionStream(context.Background(), ChatRequest{Model: "p1/model-a"})
+	received := 0
+	for range chunks {
+		received++
+	}
+	if received != 2 {
+		t.Fatalf("received %d chunks, want all chunks forwarded", received)
+	}
+
+	stats := mgr.LatencyStats()
+	if len(stats) != 1 || stats[0].Requests != 1 {
+		t.Fatalf("stats = %+v, want a single stream measurement", stats)
+	}
+}
+
+func TestLatencyStatsMarshalJSONUsesMilliseconds(t *testing.T) {
+	data, err := json.Marshal(LatencyStats{
+		Provider: "p1",
+		Model:    "p1/model-a",
+		Requests: 2,
+		Total:    300 * time.Millisecond,
+		Min:      100 * time.Millisecond,
+		Max:      200 * time.Millisecond,
+		Last:     200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got["meanMs"] != float64(150) || got["minMs"] != float64(100) || got["maxMs"] != float64(200) {
+		t.Fatalf("json = %s", data)
+	}
+}
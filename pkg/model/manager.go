@@ -25,7 +25,11 @@ type Manager struct {
 	catalog        map[string]ModelInfo
 	providerModels map[string][]string
 	modelProviders map[string]string
+	fallbacks      []string // models.fallbacks entries found in the catalog
 	routingHooks   *RoutingHooks
+	telemetry      *telemetry.Hub
+	latency        latencyTracker
+	requestGuard   RequestGuard
 }
 
 // ProviderThreadStore persists native provider conversation identifiers so a
@@ -41,6 +45,7 @@ func (m *Manager) EnableTelemetry(hub *telemetry.Hub) {
 	if m == nil {
 		return
 	}
+	m.telemetry = hub
 	for _, provider := range m.providers {
 		if setter, ok := provider.(interface{ SetTelemetry(*telemetry.Hub) }); ok {
 			setter.SetTelemetry(hub)
@@ -131,6 +136,9 @@ func (m *Manager) Initialize() error {
 	if err := m.ensureConfiguredModels(); err != nil {
 		return fmt.Errorf("ensuring configured models: %w", err)
 	}
+	for _, warning := range m.resolveFallbackModels() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 	return nil
 }
 
@@ -316,22 +324,53 @@ func (m *Manager) SetRequestTimeout(timeout time.Duration) {
 
 // ChatCompletion performs a chat completion routed to the proper provider
 func (m *Manager) ChatCompletion(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	resp, err := m.chatCompletionModel(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	from := req.Model
+	for _, next := range m.fallbackCandidates(req.Model) {
+		if !isProviderUnavailableError(err) {
+			break
+		}
+		m.publishFallback(from, next, err)
+		resp, err = m.chatCompletionModel(ctx, fallbackRequest(req, next))
+		if err == nil {
+			return resp, nil
+		}
+		from = next
+	}
+	return nil, err
+}
+
+func (m *Manager) chatCompletionModel(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
 	selectedModel, provider := m.resolveModel(req.Model)
 	if provider == nil {
 		return nil, fmt.Errorf("no provider configured for model %s", req.Model)
 	}
 	req.Model = selectedModel
 	req = m.applyFallbackChain(req, selectedModel, provider.ID())
+	req = m.applyOutputTokenCap(req, selectedModel)
 	req = applyProviderTransforms(req, provider.ID())
 	req = m.applyPromptCache(req, provider.ID())
+	if m.requestGuard != nil {
+		if err := m.requestGuard.BeforeRequest(req); err != nil {
+			return nil, err
+		}
+	}
 	req.Model = normalizeModelForProvider(req.Model, provider.ID())
-	resp, err := provider.ChatCompletion(ctx, req)
+	start := time.Now()
+	resp, err := m.chatCompletionWithRetry(ctx, provider, req)
+	m.recordLatency(provider.ID(), selectedModel, time.Since(start), false, err)
 	if err != nil {
 		return nil, err
 	}
 	if resp == nil {
 		return nil, NilChatResponseError(req)
 	}
+	if m.requestGuard != nil {
+		m.requestGuard.AfterResponse(selectedModel, resp.Usage)
+	}
 	if len(resp.Choices) == 0 {
 		return nil, NoResponseChoicesError(req, resp)
 	}
@@ -340,6 +379,13 @@ func (m *Manager) ChatCompletion(ctx context.Context, req ChatRequest) (*ChatRes
 
 // ChatCompletionStream performs a streaming chat completion
 func (m *Manager) ChatCompletionStream(ctx context.Context, req ChatRequest) (<-chan StreamChunk, <-chan error) {
+	if len(m.fallbackCandidates(req.Model)) == 0 {
+		return m.chatCompletionStreamModel(ctx, req)
+	}
+	return m.streamWithFallback(ctx, req)
+}
+
+func (m *Manager) chatCompletionStreamModel(ctx context.Context, req ChatRequest) (<-chan StreamChunk, <-chan error) {
 	selectedModel, provider := m.resolveModel(req.Model)
 	if provider == nil {
 		chunkChan := make(chan StreamChunk)
@@ -351,10 +397,30 @@ func (m *Manager) ChatCompletionStream(ctx context.Context, req ChatRequest) (<-
 	}
 	req.Model = selectedModel
 	req = m.applyFallbackChain(req, selectedModel, provider.ID())
+	req = m.applyOutputTokenCap(req, selectedModel)
 	req = applyProviderTransforms(req, provider.ID())
 	req = m.applyPromptCache(req, provider.ID())
+	if m.requestGuard != nil {
+		if err := m.requestGuard.BeforeRequest(req); err != nil {
+			chunkChan := make(chan StreamChunk)
+			close(chunkChan)
+			errChan := make(chan error, 1)
+			errChan <- err
+			close(errChan)
+			return chunkChan, errChan
+		}
+	}
 	req.Model = normalizeModelForProvider(req.Model, provider.ID())
-	return provider.ChatCompletionStream(ctx, req)
+	start := time.Now()
+	timeout := m.streamIdleTimeout()
+	if timeout <= 0 {
+		chunks, errs := m.streamWithRetry(ctx, provider, req)
+		return m.guardStream(ctx, selectedModel, m.timeFirstChunk(ctx, provider.ID(), selectedModel, start, chunks)), errs
+	}
+	streamCtx, cancel := context.WithCancel(ctx)
+	chunks, errs := m.streamWithRetry(streamCtx, provider, req)
+	chunks = m.guardStream(streamCtx, selectedModel, m.timeFirstChunk(streamCtx, provider.ID(), selectedModel, start, chunks))
+	return watchStreamIdle(streamCtx, cancel, timeout, chunks, errs)
 }
 
 func (m *Manager) applyFallbackChain(req ChatRequest, selectedModel, providerID string) ChatRequest {
@@ -404,6 +470,7 @@ func (m *Manager) applyFallbackChain(req ChatRequest, selectedModel, providerID
 
 func applyProviderTransforms(req ChatRequest, providerID string) ChatRequest {
 	req = normalizeProviderChatRequest(req, providerID)
+	req = translateProviderParameters(req, providerID)
 	if providerID == "openrouter" && len(req.Transforms) == 0 {
 		req.Transforms = []string{"middle-out"}
 	}
@@ -721,11 +788,7 @@ func (m *Manager) SupportsVision(modelID string) bool {
 	if err != nil {
 		return false
 	}
-
-	// Check if modality includes image support
-	modality := info.Architecture.Modality
-	return modality == "text+image" || modality == "multimodal" ||
-		modality == "text+image->text" || modality == "image+text->text"
+	return info.SupportsVision()
 }
 
 // SupportsReasoning checks if a model supports reasoning parameter
@@ -734,14 +797,7 @@ func (m *Manager) SupportsReasoning(modelID string) bool {
 	if err != nil {
 		return false
 	}
-
-	// Check supported_parameters from catalog
-	for _, param := range info.SupportedParameters {
-		if param == "reasoning" {
-			return true
-		}
-	}
-	return false
+	return info.SupportsReasoning()
 }
 
 // SupportsTools checks if a model supports function/tool calling
@@ -758,13 +814,7 @@ func (m *Manager) SupportsParameter(modelID, parameter string) bool {
 	if err != nil {
 		return false
 	}
-	parameter = strings.TrimSpace(parameter)
-	for _, candidate := range info.SupportedParameters {
-		if candidate == parameter {
-			return true
-		}
-	}
-	return false
+	return info.SupportsParameter(parameter)
 }
 
 // GetVisionFallbackModel returns a fallback model for vision tasks
@@ -0,0 +1,51 @@
+package model
+
+// minAutoOutputTokens keeps an automatic cap from shrinking to a few tokens
+// when the prompt estimate nearly fills the window; the estimate is rough
+// and a tiny cap would truncate every answer.
+const minAutoOutputTokens = 256
+
+// OutputTokenCap returns the max_tokens to send when a request sets none.
+// configured is the cap from models.max_output_tokens; it is lowered to
+// the model's own output limit (0 when unknown) and to the context left
+// after promptTokens (contextWindow 0 when unknown). It returns 0, leaving
+// max_tokens unset, when capping is disabled or the prompt alone already
+// fills the window, so the provider reports the overflow itself.
+func OutputTokenCap(contextWindow, outputLimit, promptTokens, configured int) int {
+	if configured <= 0 {
+		return 0
+	}
+	limit := configured
+	if outputLimit > 0 && outputLimit < limit {
+		limit = outputLimit
+	}
+	if contextWindow > 0 {
+		remaining := contextWindow - promptTokens
+		if remaining <= 0 {
+			return 0
+		}
+		if remaining < limit {
+			limit = max(remaining, min(minAutoOutputTokens, limit))
+		}
+	}
+	return limit
+}
+
+// applyOutputTokenCap fills max_tokens from OutputTokenCap for modelID. An
+// explicit MaxTokens or MaxCompletionTokens from the caller is kept as is.
+func (m *Manager) applyOutputTokenCap(req ChatRequest, modelID string) ChatRequest {
+	if m == nil || m.config == nil || req.MaxTokens > 0 || req.MaxCompletionTokens > 0 {
+		return req
+	}
+	configured := m.config.Models.MaxOutputTokens
+	if configured <= 0 {
+		return req
+	}
+	var contextWindow, outputLimit int
+	if info, err := m.GetModelInfo(modelID); err == nil && info != nil {
+		contextWindow = info.ContextLength
+		outputLimit = info.MaxOutputTokens()
+	}
+	req.MaxTokens = OutputTokenCap(contextWindow, outputLimit, EstimateRequestTokens(req).Total, configured)
+	return req
+}
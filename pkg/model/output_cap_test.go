@@ -0,0 +1,66 @@
+package model
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOutputTokenCap(t *testing.T) {
+	tests := []struct {
+		name          string
+		contextWindow int
+		outputLimit   int
+		promptTokens  int
+		configured    int
+		want          int
+	}{
+		{name: "disabled", contextWindow: 128000, outputLimit: 16384, promptTokens: 1000, configured: 0, want: 0},
+		{name: "configured cap below every limit", contextWindow: 200000, outputLimit: 64000, promptTokens: 1000, configured: 16384, want: 16384},
+		{name: "model output limit below configured", contextWindow: 200000, outputLimit: 8192, promptTokens: 1000, configured: 16384, want: 8192},
+		{name: "unknown model limits use configured cap", promptTokens: 50000, configured: 16384, want: 16384},
+		{name: "remaining context below cap", contextWindow: 32000, outputLimit: 16384, promptTokens: 28000, configured: 16384, want: 4000},
+		{name: "nearly full window keeps a floor", contextWindow: 32000, promptTokens: 31900, configured: 16384, want: minAutoOutputTokens},
+		{name: "floor never exceeds configured cap", contextWindow: 32000, promptTokens: 31950, configured: 100, want: 100},
+		{name: "prompt overflows window", contextWindow: 32000, promptTokens: 40000, configured: 16384, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OutputTokenCap(tt.contextWindow, tt.outputLimit, tt.promptTokens, tt.configured); got != tt.want {
+				t.Fatalf("OutputTokenCap(%d, %d, %d, %d) = %d, want %d",
+					tt.contextWindow, tt.outputLimit, tt.promptTokens, tt.configured, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatCompletionAppliesOutputTokenCap(t *testing.T) {
+	provider := &stubProvider{id: "p1", catalog: ModelCatalog{Data: []ModelInfo{{
+		ID:            "p1/model-a",
+		ContextLength: 100000,
+		TopProvider:   ModelTopProvider{MaxCompletionTokens: 4096},
+	}}}}
+	mgr := newLatencyTestManager(map[string]Provider{"p1": provider})
+	mgr.config.Models.MaxOutputTokens = 16384
+
+	if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "p1/model-a", Messages: []Message{{Role: "user", Content: "hi"}}}); err != nil {
+		t.Fatalf("ChatCompletion: %v", err)
+	}
+	if provider.lastRequest.MaxTokens != 4096 {
+		t.Fatalf("max_tokens = %d, want the model's 4096 output limit", provider.lastRequest.MaxTokens)
+	}
+
+	if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "p1/model-a", MaxTokens: 200}); err != nil {
+		t.Fatalf("ChatCompletion explicit: %v", err)
+	}
+	if provider.lastRequest.MaxTokens != 200 {
+		t.Fatalf("max_tokens = %d, want explicit 200 kept", provider.lastRequest.MaxTokens)
+	}
+
+	mgr.config.Models.MaxOutputTokens = 0
+	if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "p1/model-a"}); err != nil {
+		t.Fatalf("ChatCompletion disabled: %v", err)
+	}
+	if provider.lastRequest.MaxTokens != 0 {
+		t.Fatalf("max_tokens = %d, want unset when models.max_output_tokens is 0", provider.lastRequest.MaxTokens)
+	}
+}
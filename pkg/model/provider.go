@@ -28,7 +28,8 @@ type TimeoutConfigurer interface {
 // providerFactory builds the configured providers from config.
 func providerFactory(cfg *config.Config) (map[string]Provider, error) {
 	providers := make(map[string]Provider)
-	networkLogsEnabled := cfg.Diagnostics.NetworkLogsEnabled
+	// Ephemeral mode writes nothing to disk, network logs included.
+	networkLogsEnabled := cfg.Diagnostics.NetworkLogsEnabled && !cfg.Persistence.Ephemeral
 
 	if cfg.Providers.OpenRouter.Enabled && cfg.Providers.OpenRouter.APIKey != "" {
 		client := NewClientWithOptions(cfg.Providers.OpenRouter.APIKey, cfg.Providers.OpenRouter.BaseURL, ClientOptions{
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -33,6 +34,7 @@ var anthropicModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools", "functions"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 8192},
 	},
 	{
 		ID:            "anthropic/claude-3.5-haiku",
@@ -46,6 +48,7 @@ var anthropicModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools", "functions"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 8192},
 	},
 	{
 		ID:            "anthropic/claude-3-opus",
@@ -59,6 +62,7 @@ var anthropicModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools", "functions"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 4096},
 	},
 }
 
@@ -133,7 +137,8 @@ func (p *AnthropicProvider) ChatCompletion(ctx context.Context, req ChatRequest)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("anthropic request failed: %s", resp.Status)
+		body, _ := io.ReadAll(resp.Body)
+		return nil, providerStatusError("anthropic", "anthropic request failed", resp, body)
 	}
 
 	var anthropicResp anthropicResponse
@@ -195,6 +200,9 @@ func (p *AnthropicProvider) toAnthropicRequest(req ChatRequest, stream bool) (*a
 		Temperature: req.Temperature,
 		Stream:      stream,
 	}
+	if len(req.Stop) > 0 {
+		anthReq.StopSequences = append([]string(nil), req.Stop...)
+	}
 	if anthReq.MaxTokens == 0 {
 		anthReq.MaxTokens = 4096
 	}
@@ -252,15 +260,16 @@ func (p *AnthropicProvider) toAnthropicRequest(req ChatRequest, stream bool) (*a
 
 // anthropicRequest maps to Anthropics messages payload.
 type anthropicRequest struct {
-	Model       string               `json:"model"`
-	System      string               `json:"system,omitempty"`
-	Messages    []anthropicMessage   `json:"messages"`
-	MaxTokens   int                  `json:"max_tokens"`
-	Temperature float64              `json:"temperature,omitempty"`
-	Stream      bool                 `json:"stream"`
-	Tools       []anthropicTool      `json:"tools,omitempty"`
-	ToolChoice  *anthropicToolChoice `json:"tool_choice,omitempty"`
-	Metadata    map[string]string    `json:"metadata,omitempty"`
+	Model         string               `json:"model"`
+	System        string               `json:"system,omitempty"`
+	Messages      []anthropicMessage   `json:"messages"`
+	MaxTokens     int                  `json:"max_tokens"`
+	Temperature   float64              `json:"temperature,omitempty"`
+	StopSequences []string             `json:"stop_sequences,omitempty"`
+	Stream        bool                 `json:"stream"`
+	Tools         []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice    *anthropicToolChoice `json:"tool_choice,omitempty"`
+	Metadata      map[string]string    `json:"metadata,omitempty"`
 }
 
 type anthropicMessage struct {
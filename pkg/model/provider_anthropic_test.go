@@ -173,3 +173,20 @@ func TestAnthropicResponse_ToChatResponse_WithToolUse(t *testing.T) {
 		t.Fatalf("expected total tokens 19, got %d", resp.Usage.TotalTokens)
 	}
 }
+
+func TestAnthropicProvider_ToAnthropicRequest_StopSequences(t *testing.T) {
+	provider := &AnthropicProvider{}
+	req := ChatRequest{
+		Model:    "claude-3.5-sonnet",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+		Stop:     []string{"</answer>", "\n\nHuman:"},
+	}
+
+	anthReq, err := provider.toAnthropicRequest(req, false)
+	if err != nil {
+		t.Fatalf("toAnthropicRequest() error = %v", err)
+	}
+	if len(anthReq.StopSequences) != 2 || anthReq.StopSequences[0] != "</answer>" || anthReq.StopSequences[1] != "\n\nHuman:" {
+		t.Fatalf("stop_sequences = %#v", anthReq.StopSequences)
+	}
+}
@@ -33,6 +33,7 @@ var googleModels = []ModelInfo{
 		Architecture: Architecture{
 			Modality: "multimodal",
 		},
+		TopProvider: ModelTopProvider{MaxCompletionTokens: 8192},
 	},
 	{
 		ID:            "google/gemini-2.0-pro",
@@ -45,6 +46,7 @@ var googleModels = []ModelInfo{
 		Architecture: Architecture{
 			Modality: "multimodal",
 		},
+		TopProvider: ModelTopProvider{MaxCompletionTokens: 8192},
 	},
 	{
 		ID:            "google/gemini-1.5-flash",
@@ -57,6 +59,7 @@ var googleModels = []ModelInfo{
 		Architecture: Architecture{
 			Modality: "multimodal",
 		},
+		TopProvider: ModelTopProvider{MaxCompletionTokens: 8192},
 	},
 }
 
@@ -134,7 +137,7 @@ func (p *GoogleProvider) ChatCompletion(ctx context.Context, req ChatRequest) (*
 
 	if resp.StatusCode != http.StatusOK {
 		errBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("google request failed (%d): %s", resp.StatusCode, string(errBody))
+		return nil, providerStatusError("google", "google request failed", resp, errBody)
 	}
 
 	var genResp googleResponse
@@ -193,6 +196,16 @@ func (p *GoogleProvider) toGenerateContentRequest(req ChatRequest) (*googleReque
 	payload := &googleRequest{
 		Model: normalizeModelForProvider(req.Model, "google"),
 	}
+	if req.Temperature != 0 || req.MaxTokens > 0 || len(req.Stop) > 0 {
+		payload.GenerationConfig = &googleGenerationConfig{
+			MaxOutputTokens: req.MaxTokens,
+			StopSequences:   append([]string(nil), req.Stop...),
+		}
+		if req.Temperature != 0 {
+			temperature := req.Temperature
+			payload.GenerationConfig.Temperature = &temperature
+		}
+	}
 
 	for _, msg := range req.Messages {
 		text := messageContentToText(msg.Content)
@@ -215,9 +228,16 @@ func (p *GoogleProvider) toGenerateContentRequest(req ChatRequest) (*googleReque
 }
 
 type googleRequest struct {
-	Model             string          `json:"-"`
-	Contents          []googleContent `json:"contents"`
-	SystemInstruction []googlePart    `json:"system_instruction,omitempty"`
+	Model             string                  `json:"-"`
+	Contents          []googleContent         `json:"contents"`
+	SystemInstruction []googlePart            `json:"system_instruction,omitempty"`
+	GenerationConfig  *googleGenerationConfig `json:"generationConfig,omitempty"`
+}
+
+type googleGenerationConfig struct {
+	Temperature     *float64 `json:"temperature,omitempty"`
+	MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
+	StopSequences   []string `json:"stopSequences,omitempty"`
 }
 
 type googleContent struct {
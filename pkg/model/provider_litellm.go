@@ -146,6 +146,7 @@ func (p *LiteLLMProvider) fetchModelInfo() ([]ModelInfo, error) {
 				ID                      string  `json:"id"`
 				MaxTokens               int     `json:"max_tokens"`
 				MaxInputTokens          int     `json:"max_input_tokens"`
+				MaxOutputTokens         int     `json:"max_output_tokens"`
 				InputCostPerToken       float64 `json:"input_cost_per_token"`
 				OutputCostPerToken      float64 `json:"output_cost_per_token"`
 				Mode                    string  `json:"mode"`
@@ -181,6 +182,7 @@ func (p *LiteLLMProvider) fetchModelInfo() ([]ModelInfo, error) {
 				Prompt:     m.ModelInfo.InputCostPerToken * 1_000_000,
 				Completion: m.ModelInfo.OutputCostPerToken * 1_000_000,
 			},
+			TopProvider: ModelTopProvider{MaxCompletionTokens: m.ModelInfo.MaxOutputTokens},
 		}
 		if m.ModelInfo.SupportsFunctionCalling {
 			info.SupportedParameters = []string{"tools", "functions"}
@@ -287,7 +289,7 @@ func (p *LiteLLMProvider) invoke(ctx context.Context, req ChatRequest) (*ChatRes
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("litellm chat failed (%d): %s", resp.StatusCode, string(body))
+		return nil, providerStatusError("litellm", "litellm chat failed", resp, body)
 	}
 
 	var chatResp ChatResponse
@@ -321,7 +323,7 @@ func (p *LiteLLMProvider) invokeStream(ctx context.Context, req ChatRequest, chu
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("litellm streaming failed (%d): %s", resp.StatusCode, string(body))
+		return providerStatusError("litellm", "litellm streaming failed", resp, body)
 	}
 
 	scanner := bufio.NewScanner(resp.Body)
@@ -128,7 +128,7 @@ func (p *OllamaProvider) ChatCompletion(ctx context.Context, req ChatRequest) (*
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("ollama chat failed (%d): %s", resp.StatusCode, string(body))
+		return nil, providerStatusError("ollama", "ollama chat failed", resp, body)
 	}
 
 	var chatResp ollamaChatResponse
@@ -194,7 +194,7 @@ func (p *OllamaProvider) invokeStream(ctx context.Context, req ChatRequest, chun
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("ollama stream failed (%d): %s", resp.StatusCode, string(body))
+		return providerStatusError("ollama", "ollama stream failed", resp, body)
 	}
 
 	reader := bufio.NewReader(resp.Body)
@@ -325,6 +325,9 @@ func (p *OllamaProvider) buildRequest(req ChatRequest, stream bool) (*ollamaChat
 	if req.MaxTokens > 0 {
 		options["num_predict"] = req.MaxTokens
 	}
+	if len(req.Stop) > 0 {
+		options["stop"] = req.Stop
+	}
 	if len(options) == 0 {
 		options = nil
 	}
@@ -30,6 +30,7 @@ var openAIModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools", "reasoning"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 128000},
 	},
 	{
 		ID:            "openai/gpt-5.4",
@@ -39,6 +40,7 @@ var openAIModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools", "reasoning"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 128000},
 	},
 	{
 		ID:            "openai/gpt-5.4-mini",
@@ -48,6 +50,7 @@ var openAIModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools", "reasoning"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 128000},
 	},
 	{
 		ID:            "openai/gpt-4.1",
@@ -61,6 +64,7 @@ var openAIModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 32768},
 	},
 	{
 		ID:            "openai/gpt-4o",
@@ -74,6 +78,7 @@ var openAIModels = []ModelInfo{
 			Modality: "multimodal",
 		},
 		SupportedParameters: []string{"tools", "functions"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 16384},
 	},
 	{
 		ID:            "openai/gpt-4o-mini",
@@ -87,6 +92,7 @@ var openAIModels = []ModelInfo{
 			Modality: "text+image",
 		},
 		SupportedParameters: []string{"tools", "functions"},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 16384},
 	},
 	{
 		ID:            "openai/o1-mini",
@@ -100,6 +106,7 @@ var openAIModels = []ModelInfo{
 			Modality: "text",
 		},
 		SupportedParameters: []string{},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 65536},
 	},
 	{
 		ID:            "openai/o3-mini",
@@ -113,6 +120,7 @@ var openAIModels = []ModelInfo{
 			Modality: "text",
 		},
 		SupportedParameters: []string{},
+		TopProvider:         ModelTopProvider{MaxCompletionTokens: 100000},
 	},
 }
 
@@ -212,7 +220,7 @@ func (p *OpenAIProvider) invoke(ctx context.Context, req ChatRequest) (*ChatResp
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("openai request failed (%d): %s", resp.StatusCode, string(body))
+		return nil, providerStatusError("openai", "openai request failed", resp, body)
 	}
 
 	var chatResp ChatResponse
@@ -244,7 +252,7 @@ func (p *OpenAIProvider) invokeStream(ctx context.Context, req ChatRequest, chun
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("openai streaming request failed (%d): %s", resp.StatusCode, string(body))
+		return providerStatusError("openai", "openai streaming request failed", resp, body)
 	}
 
 	scanner := bufio.NewScanner(resp.Body)
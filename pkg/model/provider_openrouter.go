@@ -15,6 +15,12 @@ func (p *OpenRouterProvider) ID() string {
 	return "openrouter"
 }
 
+// retriesTransientErrors reports that the OpenRouter client handles its own
+// rate-limit and 5xx retries.
+func (p *OpenRouterProvider) retriesTransientErrors() bool {
+	return true
+}
+
 // FetchCatalog fetches catalog via OpenRouter.
 func (p *OpenRouterProvider) FetchCatalog() (*ModelCatalog, error) {
 	return p.client.FetchCatalog()
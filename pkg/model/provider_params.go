@@ -0,0 +1,47 @@
+package model
+
+import "strings"
+
+// Output token limit parameter names. ChatRequest carries both so callers can
+// set whichever they know; translation moves the limit to the one the target
+// provider actually reads.
+const (
+	paramMaxTokens           = "max_tokens"
+	paramMaxCompletionTokens = "max_completion_tokens"
+)
+
+// providerOutputTokenParams lists providers whose output limit field is not
+// max_tokens. OpenAI deprecated max_tokens and its reasoning models reject it.
+// Google's maxOutputTokens and Ollama's num_predict are filled from MaxTokens
+// by their request builders.
+var providerOutputTokenParams = map[string]string{
+	"openai": paramMaxCompletionTokens,
+}
+
+// outputTokenParam returns the unified ChatRequest field a provider reads for
+// the completion token limit.
+func outputTokenParam(providerID string) string {
+	if param, ok := providerOutputTokenParams[strings.ToLower(strings.TrimSpace(providerID))]; ok {
+		return param
+	}
+	return paramMaxTokens
+}
+
+// translateProviderParameters maps unified request parameters onto the names
+// providerID expects, so a limit set under the other name is not silently
+// dropped. An explicit value under the provider's own name wins.
+func translateProviderParameters(req ChatRequest, providerID string) ChatRequest {
+	switch outputTokenParam(providerID) {
+	case paramMaxCompletionTokens:
+		if req.MaxCompletionTokens <= 0 && req.MaxTokens > 0 {
+			req.MaxCompletionTokens = req.MaxTokens
+		}
+		req.MaxTokens = 0
+	default:
+		if req.MaxTokens <= 0 && req.MaxCompletionTokens > 0 {
+			req.MaxTokens = req.MaxCompletionTokens
+		}
+		req.MaxCompletionTokens = 0
+	}
+	return req
+}
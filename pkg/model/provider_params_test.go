@@ -0,0 +1,110 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTranslateProviderParametersOutputTokenLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		provider       string
+		req            ChatRequest
+		wantMax        int
+		wantCompletion int
+	}{
+		{name: "openai moves max_tokens", provider: "openai", req: ChatRequest{MaxTokens: 512}, wantCompletion: 512},
+		{name: "openai keeps explicit completion limit", provider: "openai", req: ChatRequest{MaxTokens: 512, MaxCompletionTokens: 256}, wantCompletion: 256},
+		{name: "openrouter moves completion limit", provider: "openrouter", req: ChatRequest{MaxCompletionTokens: 300}, wantMax: 300},
+		{name: "anthropic moves completion limit", provider: "anthropic", req: ChatRequest{MaxCompletionTokens: 300}, wantMax: 300},
+		{name: "google keeps max_tokens", provider: "google", req: ChatRequest{MaxTokens: 128, MaxCompletionTokens: 64}, wantMax: 128},
+		{name: "ollama moves completion limit", provider: "ollama", req: ChatRequest{MaxCompletionTokens: 42}, wantMax: 42},
+		{name: "litellm moves completion limit", provider: "litellm", req: ChatRequest{MaxCompletionTokens: 99}, wantMax: 99},
+		{name: "no limit stays unset", provider: "openai", req: ChatRequest{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translateProviderParameters(tt.req, tt.provider)
+			if got.MaxTokens != tt.wantMax || got.MaxCompletionTokens != tt.wantCompletion {
+				t.Fatalf("max_tokens=%d max_completion_tokens=%d, want %d/%d",
+					got.MaxTokens, got.MaxCompletionTokens, tt.wantMax, tt.wantCompletion)
+			}
+		})
+	}
+}
+
+func TestChatCompletionTranslatesParametersForProvider(t *testing.T) {
+	openai := &stubProvider{id: "openai"}
+	anthropic := &stubProvider{id: "anthropic"}
+	mgr := newLatencyTestManager(map[string]Provider{"openai": openai, "anthropic": anthropic})
+	mgr.config.Providers.ModelRouting = map[string]string{"openai/gpt-5": "openai", "anthropic/claude": "anthropic"}
+
+	if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "openai/gpt-5", MaxTokens: 400}); err != nil {
+		t.Fatalf("openai ChatCompletion: %v", err)
+	}
+	body, err := json.Marshal(openai.lastRequest)
+	if err != nil {
+		t.Fatalf("marshal openai request: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("unmarshal openai request: %v", err)
+	}
+	if _, ok := fields[paramMaxTokens]; ok {
+		t.Fatalf("openai request still sends max_tokens: %s", body)
+	}
+	if fields[paramMaxCompletionTokens] != float64(400) {
+		t.Fatalf("openai request max_completion_tokens = %v, want 400: %s", fields[paramMaxCompletionTokens], body)
+	}
+
+	if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "anthropic/claude", MaxCompletionTokens: 700}); err != nil {
+		t.Fatalf("anthropic ChatCompletion: %v", err)
+	}
+	anthReq, err := (&AnthropicProvider{}).toAnthropicRequest(anthropic.lastRequest, false)
+	if err != nil {
+		t.Fatalf("toAnthropicRequest: %v", err)
+	}
+	if anthReq.MaxTokens != 700 {
+		t.Fatalf("anthropic max_tokens = %d, want 700", anthReq.MaxTokens)
+	}
+}
+
+func TestGoogleRequestUsesGenerationConfig(t *testing.T) {
+	req := translateProviderParameters(ChatRequest{
+		Model:               "google/gemini-2.5-pro",
+		Messages:            []Message{{Role: "user", Content: "hi"}},
+		Temperature:         0.2,
+		MaxCompletionTokens: 256,
+		Stop:                []string{"END"},
+	}, "google")
+	payload, err := (&GoogleProvider{}).toGenerateContentRequest(req)
+	if err != nil {
+		t.Fatalf("toGenerateContentRequest: %v", err)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded struct {
+		GenerationConfig map[string]any `json:"generationConfig"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	cfg := decoded.GenerationConfig
+	if cfg["maxOutputTokens"] != float64(256) || cfg["temperature"] != 0.2 {
+		t.Fatalf("generationConfig = %v, want maxOutputTokens 256 and temperature 0.2", cfg)
+	}
+	if stops, ok := cfg["stopSequences"].([]any); !ok || len(stops) != 1 || stops[0] != "END" {
+		t.Fatalf("stopSequences = %v, want [END]", cfg["stopSequences"])
+	}
+
+	plain, err := (&GoogleProvider{}).toGenerateContentRequest(ChatRequest{Model: "google/gemini-2.5-pro"})
+	if err != nil {
+		t.Fatalf("toGenerateContentRequest plain: %v", err)
+	}
+	if plain.GenerationConfig != nil {
+		t.Fatalf("expected no generationConfig without parameters, got %+v", plain.GenerationConfig)
+	}
+}
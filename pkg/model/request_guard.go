@@ -0,0 +1,60 @@
+package model
+
+import "context"
+
+// RequestGuard vets chat requests before they reach a provider and observes
+// the usage reported for each completed call. Spend caps use it to stop a run
+// before it issues a request the budget cannot cover.
+type RequestGuard interface {
+	// BeforeRequest returns an error to refuse the request. The request model
+	// is the resolved catalog ID, so pricing lookups work unchanged.
+	BeforeRequest(req ChatRequest) error
+	// AfterResponse records the usage of a completed call.
+	AfterResponse(modelID string, usage Usage)
+}
+
+// SetRequestGuard installs a guard consulted on every chat completion. Passing
+// nil removes it.
+func (m *Manager) SetRequestGuard(guard RequestGuard) {
+	if m == nil {
+		return
+	}
+	m.requestGuard = guard
+}
+
+func (m *Manager) guardStream(ctx context.Context, modelID string, chunks <-chan StreamChunk) <-chan StreamChunk {
+	if m.requestGuard == nil {
+		return chunks
+	}
+	return guardStreamUsage(ctx, m.requestGuard, modelID, chunks)
+}
+
+// guardStreamUsage forwards chunks unchanged and reports the final usage chunk
+// to the guard once the stream ends.
+func guardStreamUsage(ctx context.Context, guard RequestGuard, modelID string, in <-chan StreamChunk) <-chan StreamChunk {
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+		var usage *Usage
+		defer func() {
+			if usage != nil {
+				guard.AfterResponse(modelID, *usage)
+			}
+		}()
+		for chunk := range in {
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				go func() {
+					for range in {
+					}
+				}()
+				return
+			}
+		}
+	}()
+	return out
+}
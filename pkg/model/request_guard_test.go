@@ -0,0 +1,63 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type countingGuard struct {
+	allow   int
+	checked []string
+	usage   []Usage
+}
+
+var errGuardRefused = errors.New("guard refused")
+
+func (g *countingGuard) BeforeRequest(req ChatRequest) error {
+	g.checked = append(g.checked, req.Model)
+	if len(g.checked) > g.allow {
+		return errGuardRefused
+	}
+	return nil
+}
+
+func (g *countingGuard) AfterResponse(modelID string, usage Usage) {
+	g.usage = append(g.usage, usage)
+}
+
+func TestRequestGuardRefusesBeforeProviderAndSeesUsage(t *testing.T) {
+	prov := &stubProvider{
+		id: "p1",
+		response: &ChatResponse{
+			Choices: []Choice{{Message: Message{Content: "ok"}, FinishReason: "stop"}},
+			Usage:   Usage{PromptTokens: 10, CompletionTokens: 5},
+		},
+	}
+	mgr := newLatencyTestManager(map[string]Provider{"p1": prov})
+	guard := &countingGuard{allow: 2}
+	mgr.SetRequestGuard(guard)
+
+	for i := range 2 {
+		if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "p1/model-a"}); err != nil {
+			t.Fatalf("ChatCompletion %d: %v", i+1, err)
+		}
+	}
+	prov.lastRequest = ChatRequest{}
+	if _, err := mgr.ChatCompletion(context.Background(), ChatRequest{Model: "p1/model-a"}); !errors.Is(err, errGuardRefused) {
+		t.Fatalf("third ChatCompletion err = %v, want guard refusal", err)
+	}
+	if prov.lastRequest.Model != "" {
+		t.Fatalf("refused request reached the provider: %+v", prov.lastRequest)
+	}
+	if len(guard.checked) != 3 || guard.checked[0] != "p1/model-a" {
+		t.Fatalf("guard checked %v, want three catalog model IDs", guard.checked)
+	}
+	if len(guard.usage) != 2 || guard.usage[1].PromptTokens != 10 {
+		t.Fatalf("guard usage = %+v, want usage from the two completed calls", guard.usage)
+	}
+
+	if _, errs := mgr.ChatCompletionStream(context.Background(), ChatRequest{Model: "p1/model-a"}); <-errs == nil {
+		t.Fatal("expected refused stream to report the guard error")
+	}
+}
@@ -0,0 +1,224 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// selfRetryingProvider is implemented by providers whose client already
+// retries transient failures, so the manager does not retry them again.
+type selfRetryingProvider interface {
+	retriesTransientErrors() bool
+}
+
+// requestRetryPolicy is the manager-level retry behaviour for one request,
+// built from models.retry. A zero policy makes a single attempt.
+type requestRetryPolicy struct {
+	maxAttempts  int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	multiplier   float64
+	jitter       float64
+}
+
+// requestRetryPolicy returns the retry policy for requests sent to provider.
+func (m *Manager) requestRetryPolicy(provider Provider) requestRetryPolicy {
+	if m == nil || m.config == nil {
+		return requestRetryPolicy{}
+	}
+	if self, ok := provider.(selfRetryingProvider); ok && self.retriesTransientErrors() {
+		return requestRetryPolicy{}
+	}
+	cfg := m.config.Models.Retry
+	return requestRetryPolicy{
+		maxAttempts:  cfg.MaxAttempts,
+		initialDelay: cfg.InitialDelay,
+		maxDelay:     cfg.MaxDelay,
+		multiplier:   cfg.Multiplier,
+		jitter:       cfg.Jitter,
+	}
+}
+
+// canRetry reports whether a request that failed with err on the given
+// zero-based attempt should be sent again.
+func (p requestRetryPolicy) canRetry(attempt int, err error) bool {
+	return attempt+1 < p.maxAttempts && isTransientModelError(err)
+}
+
+// delay returns how long to wait after the given zero-based attempt failed.
+// A Retry-After from the provider wins over the computed backoff; either is
+// capped at maxDelay.
+func (p requestRetryPolicy) delay(attempt int, err error) time.Duration {
+	var delay float64
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		delay = float64(apiErr.RetryAfter)
+	} else {
+		delay = float64(p.initialDelay)
+		for range attempt {
+			delay *= max(p.multiplier, 1)
+		}
+		if p.jitter > 0 {
+			delay += rand.Float64() * delay * p.jitter
+		}
+	}
+	if p.maxDelay > 0 {
+		delay = min(delay, float64(p.maxDelay))
+	}
+	return time.Duration(delay)
+}
+
+// wait sleeps before the next attempt, returning early if ctx ends.
+func (p requestRetryPolicy) wait(ctx context.Context, attempt int, lastErr error) error {
+	timer := time.NewTimer(p.delay(attempt, lastErr))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.Join(ctx.Err(), lastErr)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// finalError reports err from the last attempt, noting the attempt count
+// when the request was retried.
+func (p requestRetryPolicy) finalError(attempt int, err error) error {
+	if attempt == 0 {
+		return err
+	}
+	if isTransientModelError(err) {
+		return retryExhaustedError(attempt, err)
+	}
+	return fmt.Errorf("model request failed after %d attempts: %w", attempt+1, err)
+}
+
+// isTransientModelError reports whether err is a failure worth retrying:
+// rate limiting, a gateway or availability error, or a dropped connection.
+func isTransientModelError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// chatCompletionWithRetry sends req until it succeeds, fails with a
+// non-transient error, or runs out of attempts.
+func (m *Manager) chatCompletionWithRetry(ctx context.Context, provider Provider, req ChatRequest) (*ChatResponse, error) {
+	policy := m.requestRetryPolicy(provider)
+	for attempt := 0; ; attempt++ {
+		resp, err := provider.ChatCompletion(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if !policy.canRetry(attempt, err) {
+			return nil, policy.finalError(attempt, err)
+		}
+		if waitErr := policy.wait(ctx, attempt, err); waitErr != nil {
+			return nil, waitErr
+		}
+	}
+}
+
+// streamWithRetry opens a provider stream, reopening it when it fails with a
+// transient error before the first chunk. Once a chunk has been forwarded the
+// stream is committed and later errors are passed through unchanged.
+func (m *Manager) streamWithRetry(ctx context.Context, provider Provider, req ChatRequest) (<-chan StreamChunk, <-chan error) {
+	policy := m.requestRetryPolicy(provider)
+	if policy.maxAttempts <= 1 {
+		return provider.ChatCompletionStream(ctx, req)
+	}
+	out := make(chan StreamChunk)
+	outErrs := make(chan error, 1)
+	go func() {
+		defer close(outErrs)
+		defer close(out)
+		for attempt := 0; ; attempt++ {
+			chunks, errs := provider.ChatCompletionStream(ctx, req)
+			started, err := forwardStream(ctx, chunks, errs, out)
+			if err == nil {
+				return
+			}
+			if started {
+				outErrs <- err
+				return
+			}
+			if !policy.canRetry(attempt, err) {
+				outErrs <- policy.finalError(attempt, err)
+				return
+			}
+			if waitErr := policy.wait(ctx, attempt, err); waitErr != nil {
+				outErrs <- waitErr
+				return
+			}
+		}
+	}()
+	return out, outErrs
+}
+
+// forwardStream copies one provider stream to out and returns whether any
+// chunk was forwarded along with the stream's error.
+func forwardStream(ctx context.Context, chunks <-chan StreamChunk, errs <-chan error, out chan<- StreamChunk) (bool, error) {
+	started := false
+	var streamErr error
+	for chunks != nil || errs != nil {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				chunks = nil
+				continue
+			}
+			started = true
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				drainStream(chunks, errs)
+				return started, ctx.Err()
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil && streamErr == nil {
+				streamErr = err
+			}
+		}
+	}
+	return started, streamErr
+}
+
+// providerStatusError converts a non-OK provider response into an APIError
+// so callers can tell transient failures from permanent ones.
+func providerStatusError(providerID, message string, resp *http.Response, body []byte) *APIError {
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    message,
+		Provider:   providerID,
+		Details:    strings.TrimSpace(string(body)),
+		Retryable:  resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
@@ -0,0 +1,241 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+// flakyProvider fails the first len(failures) requests with the listed errors.
+type flakyProvider struct {
+	*stubProvider
+	failures []error
+	calls    int
+}
+
+func (p *flakyProvider) nextFailure() error {
+	p.calls++
+	if p.calls <= len(p.failures) {
+		return p.failures[p.calls-1]
+	}
+	return nil
+}
+
+func (p *flakyProvider) ChatCompletion(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	if err := p.nextFailure(); err != nil {
+		return nil, err
+	}
+	return p.stubProvider.ChatCompletion(ctx, req)
+}
+
+func (p *flakyProvider) ChatCompletionStream(ctx context.Context, req ChatRequest) (<-chan StreamChunk, <-chan error) {
+	chunks := make(chan StreamChunk, 1)
+	errs := make(chan error, 1)
+	if err := p.nextFailure(); err != nil {
+		errs <- err
+	} else {
+		chunks <- StreamChunk{ID: "chunk-1"}
+	}
+	close(chunks)
+	close(errs)
+	return chunks, errs
+}
+
+func newRetryTestManager(t *testing.T, prov Provider, retry config.ModelRetryConfig) *Manager {
+	t.Helper()
+	cfg := &config.Config{
+		Models: config.ModelConfig{
+			Execution:       "p1/model-a",
+			DefaultProvider: "p1",
+			FallbackChains:  map[string][]string{},
+			Retry:           retry,
+		},
+		Providers: config.ProviderConfig{
+			ModelRouting: map[string]string{},
+		},
+	}
+	mgr := &Manager{
+		config:         cfg,
+		providers:      map[string]Provider{"p1": prov},
+		providerOrder:  []string{"p1"},
+		catalog:        make(map[string]ModelInfo),
+		providerModels: make(map[string][]string),
+		modelProviders: make(map[string]string),
+	}
+	if err := mgr.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	return mgr
+}
+
+func newFlakyProvider(failures ...error) *flakyProvider {
+	return &flakyProvider{
+		stubProvider: &stubProvider{
+			id:      "p1",
+			catalog: ModelCatalog{Data: []ModelInfo{{ID: "p1/model-a", ContextLength: 8_000}}},
+		},
+		failures: failures,
+	}
+}
+
+var fastRetry = config.ModelRetryConfig{
+	MaxAttempts:  3,
+	InitialDelay: time.Millisecond,
+	MaxDelay:     5 * time.Millisecond,
+	Multiplier:   2,
+}
+
+func chatRequest() ChatRequest {
+	return ChatRequest{Model: "p1/model-a", Messages: []Message{{Role: "user", Content: "hello"}}}
+}
+
+func TestChatCompletionRetriesTransientErrors(t *testing.T) {
+	prov := newFlakyProvider(
+		&APIError{StatusCode: http.StatusServiceUnavailable, Message: "unavailable"},
+		fmt.Errorf("send: %w", syscall.ECONNRESET),
+	)
+	mgr := newRetryTestManager(t, prov, fastRetry)
+
+	resp, err := mgr.ChatCompletion(context.Background(), chatRequest())
+	if err != nil {
+		t.Fatalf("ChatCompletion() error = %v", err)
+	}
+	if resp == nil || len(resp.Choices) == 0 {
+		t.Fatalf("ChatCompletion() response = %+v", resp)
+	}
+	if prov.calls != 3 {
+		t.Fatalf("provider called %d times, want 3", prov.calls)
+	}
+}
+
+func TestChatCompletionReportsAttemptsWhenRetriesExhausted(t *testing.T) {
+	rateLimited := &APIError{StatusCode: http.StatusTooManyRequests, Message: "slow down"}
+	prov := newFlakyProvider(rateLimited, rateLimited, rateLimited, rateLimited)
+	mgr := newRetryTestManager(t, prov, fastRetry)
+
+	_, err := mgr.ChatCompletion(context.Background(), chatRequest())
+	if err == nil {
+		t.Fatal("expected error after retries")
+	}
+	if !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Fatalf("error does not report attempts: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("error does not wrap the provider error: %v", err)
+	}
+	if prov.calls != 3 {
+		t.Fatalf("provider called %d times, want 3", prov.calls)
+	}
+}
+
+func TestChatCompletionDoesNotRetryPermanentErrors(t *testing.T) {
+	prov := newFlakyProvider(&APIError{StatusCode: http.StatusBadRequest, Message: "bad request"})
+	mgr := newRetryTestManager(t, prov, fastRetry)
+
+	_, err := mgr.ChatCompletion(context.Background(), chatRequest())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if strings.Contains(err.Error(), "attempts") {
+		t.Fatalf("single attempt reported as retried: %v", err)
+	}
+	if prov.calls != 1 {
+		t.Fatalf("provider called %d times, want 1", prov.calls)
+	}
+}
+
+func TestChatCompletionStopsRetryingWhenContextEnds(t *testing.T) {
+	prov := newFlakyProvider(&APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute})
+	slowRetry := fastRetry
+	slowRetry.MaxDelay = time.Minute
+	mgr := newRetryTestManager(t, prov, slowRetry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := mgr.ChatCompletion(ctx, chatRequest())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("retry wait ignored cancellation, took %s", elapsed)
+	}
+	if prov.calls != 1 {
+		t.Fatalf("provider called %d times, want 1", prov.calls)
+	}
+}
+
+func TestChatCompletionStreamRetriesBeforeFirstChunk(t *testing.T) {
+	prov := newFlakyProvider(&APIError{StatusCode: http.StatusBadGateway, Message: "bad gateway"})
+	mgr := newRetryTestManager(t, prov, fastRetry)
+
+	chunks, errs := mgr.ChatCompletionStream(context.Background(), chatRequest())
+	var got []StreamChunk
+	for chunk := range chunks {
+		got = append(got, chunk)
+	}
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("stream error = %v", err)
+		}
+	}
+	if len(got) != 1 || got[0].ID != "chunk-1" {
+		t.Fatalf("chunks = %+v", got)
+	}
+	if prov.calls != 2 {
+		t.Fatalf("provider called %d times, want 2", prov.calls)
+	}
+}
+
+func TestRequestRetryPolicyDelay(t *testing.T) {
+	policy := requestRetryPolicy{
+		maxAttempts:  5,
+		initialDelay: 100 * time.Millisecond,
+		maxDelay:     time.Second,
+		multiplier:   2,
+	}
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		if got := policy.delay(attempt, io.ErrUnexpectedEOF); got != want {
+			t.Errorf("delay(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+	retryAfter := &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 700 * time.Millisecond}
+	if got := policy.delay(0, retryAfter); got != 700*time.Millisecond {
+		t.Errorf("delay with Retry-After = %s, want 700ms", got)
+	}
+	retryAfter.RetryAfter = time.Hour
+	if got := policy.delay(0, retryAfter); got != time.Second {
+		t.Errorf("delay with Retry-After past max_delay = %s, want 1s", got)
+	}
+}
+
+func TestIsTransientModelError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{&APIError{StatusCode: http.StatusBadGateway}, true},
+		{&APIError{StatusCode: http.StatusServiceUnavailable}, true},
+		{&APIError{StatusCode: http.StatusGatewayTimeout}, true},
+		{&APIError{StatusCode: http.StatusInternalServerError}, false},
+		{&APIError{StatusCode: http.StatusUnauthorized}, false},
+		{fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{context.Canceled, false},
+		{errors.New("decoding response: invalid character"), false},
+	}
+	for _, tt := range tests {
+		if got := isTransientModelError(tt.err); got != tt.want {
+			t.Errorf("isTransientModelError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StreamIdleError reports a provider stream that stopped sending data without
+// closing. The stream is cancelled when this error is returned.
+type StreamIdleError struct {
+	Timeout time.Duration
+}
+
+func (e *StreamIdleError) Error() string {
+	return fmt.Sprintf("provider stream stalled: no data received for %s", e.Timeout)
+}
+
+// streamIdleTimeout returns the configured inactivity window for streams.
+// Zero or negative disables the watchdog.
+func (m *Manager) streamIdleTimeout() time.Duration {
+	if m == nil || m.config == nil {
+		return 0
+	}
+	return m.config.Models.StreamIdleTimeout
+}
+
+// watchStreamIdle forwards a provider stream and cancels it when no chunk
+// arrives within timeout, reporting a StreamIdleError on the returned error
+// channel. Time spent waiting on a slow reader does not
+// count as provider inactivity.
+func watchStreamIdle(ctx context.Context, cancel context.CancelFunc, timeout time.Duration, chunks <-chan StreamChunk, errs <-chan error) (<-chan StreamChunk, <-chan error) {
+	out := make(chan StreamChunk)
+	outErrs := make(chan error, 1)
+	go func() {
+		defer cancel()
+		defer close(outErrs)
+		defer close(out)
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		// Provider errors are held until the chunk channel closes so chunks
+		// already buffered ahead of the error still reach the reader.
+		var streamErr error
+		for chunks != nil || errs != nil {
+			select {
+			case chunk, ok := <-chunks:
+				if !ok {
+					chunks = nil
+					continue
+				}
+				select {
+				case out <- chunk:
+				case <-ctx.Done():
+					outErrs <- ctx.Err()
+					drainStream(chunks, errs)
+					return
+				}
+				timer.Reset(timeout)
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				if err != nil && streamErr == nil {
+					streamErr = err
+				}
+			case <-timer.C:
+				cancel()
+				if streamErr == nil {
+					streamErr = &StreamIdleError{Timeout: timeout}
+				}
+				outErrs <- streamErr
+				drainStream(chunks, errs)
+				return
+			}
+		}
+		if streamErr != nil {
+			outErrs <- streamErr
+		}
+	}()
+	return out, outErrs
+}
+
+// drainStream lets a provider finish writing after its reader has gone.
+func drainStream(chunks <-chan StreamChunk, errs <-chan error) {
+	go func() {
+		for chunks != nil || errs != nil {
+			select {
+			case _, ok := <-chunks:
+				if !ok {
+					chunks = nil
+				}
+			case _, ok := <-errs:
+				if !ok {
+					errs = nil
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func TestWatchStreamIdle_FiresOnStalledStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := make(chan StreamChunk)
+	errs := make(chan error)
+	// A provider that sends one chunk and then goes silent without closing.
+	go func() {
+		chunks <- StreamChunk{ID: "c1"}
+		<-ctx.Done()
+		close(chunks)
+		close(errs)
+	}()
+
+	out, outErrs := watchStreamIdle(ctx, cancel, 30*time.Millisecond, chunks, errs)
+
+	got := 0
+	for range out {
+		got++
+	}
+	if got != 1 {
+		t.Fatalf("chunks = %d, want the chunk sent before the stall", got)
+	}
+	err := <-outErrs
+	var idleErr *StreamIdleError
+	if !errors.As(err, &idleErr) {
+		t.Fatalf("error = %v, want StreamIdleError", err)
+	}
+	if idleErr.Timeout != 30*time.Millisecond {
+		t.Fatalf("idle timeout = %s, want 30ms", idleErr.Timeout)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("watchdog should cancel the provider stream")
+	}
+}
+
+func TestWatchStreamIdle_ResetsOnEachChunk(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := make(chan StreamChunk)
+	errs := make(chan error)
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+		for i := 0; i < 5; i++ {
+			// Total runtime exceeds the window; each gap stays within it.
+			time.Sleep(20 * time.Millisecond)
+			chunks <- StreamChunk{}
+		}
+	}()
+
+	out, outErrs := watchStreamIdle(ctx, cancel, 80*time.Millisecond, chunks, errs)
+	got := 0
+	for range out {
+		got++
+	}
+	if got != 5 {
+		t.Fatalf("chunks = %d, want 5", got)
+	}
+	for err := range outErrs {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWatchStreamIdle_DeliversChunksBeforeProviderError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks := make(chan StreamChunk, 2)
+	errs := make(chan error, 1)
+	chunks <- StreamChunk{ID: "a"}
+	chunks <- StreamChunk{ID: "b"}
+	errs <- errors.New("upstream reset")
+	close(chunks)
+	close(errs)
+
+	out, outErrs := watchStreamIdle(ctx, cancel, time.Second, chunks, errs)
+	got := 0
+	for range out {
+		got++
+	}
+	if got != 2 {
+		t.Fatalf("chunks = %d, want buffered chunks delivered", got)
+	}
+	if err := <-outErrs; err == nil || err.Error() != "upstream reset" {
+		t.Fatalf("error = %v, want provider error", err)
+	}
+}
+
+func TestManagerChatCompletionStream_IdleWatchdog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chunkChan := make(chan StreamChunk)
+	errChan := make(chan error)
+	provider := NewMockProvider(ctrl)
+	provider.EXPECT().ID().Return("testprovider").AnyTimes()
+	provider.EXPECT().ChatCompletionStream(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req ChatRequest) (<-chan StreamChunk, <-chan error) {
+			go func() {
+				<-ctx.Done()
+				close(chunkChan)
+				close(errChan)
+			}()
+			return chunkChan, errChan
+		})
+
+	manager := &Manager{
+		config: &config.Config{
+			Models: config.ModelConfig{StreamIdleTimeout: 30 * time.Millisecond},
+			Providers: config.ProviderConfig{
+				ModelRouting: map[string]string{
+					"testprovider/": "testprovider",
+				},
+			},
+		},
+		providers: map[string]Provider{
+			"testprovider": provider,
+		},
+	}
+
+	chunks, errs := manager.ChatCompletionStream(context.Background(), ChatRequest{Model: "testprovider/model"})
+	done := make(chan error, 1)
+	go func() {
+		for range chunks {
+		}
+		done <- <-errs
+	}()
+
+	select {
+	case err := <-done:
+		var idleErr *StreamIdleError
+		if !errors.As(err, &idleErr) {
+			t.Fatalf("error = %v, want StreamIdleError", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stalled stream was not cancelled by the watchdog")
+	}
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// ModelNotFoundError reports a model ID missing from the catalog together
+// with the closest catalog IDs, so a typo can be corrected in one step.
+type ModelNotFoundError struct {
+	ModelID     string
+	Suggestions []string
+}
+
+func (e *ModelNotFoundError) Error() string {
+	msg := "model not found in catalog: " + e.ModelID
+	if len(e.Suggestions) > 0 {
+		msg += " (did you mean " + strings.Join(e.Suggestions, ", ") + "?)"
+	}
+	return msg
+}
+
+// NotFoundError builds a ModelNotFoundError for modelID with up to limit
+// suggestions from the loaded catalog.
+func (m *Manager) NotFoundError(modelID string, limit int) *ModelNotFoundError {
+	var ids []string
+	if m != nil {
+		for _, info := range m.GetCatalog().Data {
+			ids = append(ids, info.ID)
+		}
+	}
+	return &ModelNotFoundError{ModelID: modelID, Suggestions: SuggestModelIDs(modelID, ids, limit)}
+}
+
+// SuggestModelIDs returns up to limit candidates resembling query, best
+// first. Case-insensitive matches rank ahead of prefix matches, then
+// substring matches, then IDs within a small edit distance. The query is
+// compared both with each full ID and with the name after its provider
+// prefix, so "gpt4o" finds "openai/gpt-4o".
+func SuggestModelIDs(query string, candidates []string, limit int) []string {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" || limit <= 0 {
+		return nil
+	}
+	maxDistance := max(2, len(q)/3)
+
+	type scored struct {
+		id    string
+		score int
+	}
+	var matches []scored
+	seen := make(map[string]bool, len(candidates))
+	for _, id := range candidates {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		best := -1
+		for _, target := range suggestionTargets(id) {
+			score := -1
+			switch {
+			case target == q:
+				score = 0
+			case strings.HasPrefix(target, q):
+				score = 1
+			case strings.Contains(target, q):
+				score = 2
+			default:
+				if d := editDistance(q, target); d <= maxDistance {
+					score = 3 + d
+				}
+			}
+			if score >= 0 && (best < 0 || score < best) {
+				best = score
+			}
+		}
+		if best >= 0 {
+			matches = append(matches, scored{id: id, score: best})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].id < matches[j].id
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	out := make([]string, len(matches))
+	for i, match := range matches {
+		out[i] = match.id
+	}
+	return out
+}
+
+// suggestionTargets returns the lowercased forms of id compared against a
+// query: the full ID, its name after the last provider prefix, and both
+// with separators removed.
+func suggestionTargets(id string) []string {
+	full := strings.ToLower(id)
+	targets := []string{full}
+	if i := strings.LastIndex(full, "/"); i >= 0 && i < len(full)-1 {
+		targets = append(targets, full[i+1:])
+	}
+	for _, target := range targets {
+		if compact := stripModelSeparators(target); compact != target {
+			targets = append(targets, compact)
+		}
+	}
+	return targets
+}
+
+func stripModelSeparators(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', ' ':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// editDistance is the Levenshtein distance between a and b in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
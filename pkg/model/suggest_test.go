@@ -0,0 +1,59 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSuggestModelIDs(t *testing.T) {
+	catalog := []string{
+		"anthropic/claude-opus-4-5",
+		"anthropic/claude-sonnet-4-5",
+		"openai/gpt-4.1",
+		"openai/gpt-4o",
+		"openai/gpt-4o-mini",
+		"openrouter/moonshotai/kimi-k2",
+	}
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "typo in full id", query: "openai/gpt-4x", limit: 3, want: []string{"openai/gpt-4o", "openai/gpt-4.1"}},
+		{name: "missing provider prefix", query: "gpt-4o", limit: 3, want: []string{"openai/gpt-4o", "openai/gpt-4o-mini", "openai/gpt-4.1"}},
+		{name: "separators dropped", query: "gpt4o", limit: 1, want: []string{"openai/gpt-4o"}},
+		{name: "case insensitive", query: "Claude-Sonnet-4-5", limit: 1, want: []string{"anthropic/claude-sonnet-4-5"}},
+		{name: "transposed letters", query: "anthropic/claude-sonet-4-5", limit: 1, want: []string{"anthropic/claude-sonnet-4-5"}},
+		{name: "nested provider prefix", query: "kimi-k3", limit: 3, want: []string{"openrouter/moonshotai/kimi-k2"}},
+		{name: "prefix matches sort by id", query: "claude", limit: 5, want: []string{"anthropic/claude-opus-4-5", "anthropic/claude-sonnet-4-5"}},
+		{name: "limit caps results", query: "gpt", limit: 2, want: []string{"openai/gpt-4.1", "openai/gpt-4o"}},
+		{name: "unrelated query", query: "llama-3-70b", limit: 3, want: nil},
+		{name: "empty query", query: "  ", limit: 3, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestModelIDs(tt.query, catalog, tt.limit)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("SuggestModelIDs(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManagerNotFoundErrorSuggestsFromCatalog(t *testing.T) {
+	mgr := newDefaultModelTestManager("openai", map[string][]ModelInfo{
+		"openai": {{ID: "openai/gpt-4o"}, {ID: "openai/gpt-5"}},
+	})
+	err := mgr.NotFoundError("openai/gpt-4p", 5)
+	if !slices.Equal(err.Suggestions, []string{"openai/gpt-4o", "openai/gpt-5"}) {
+		t.Fatalf("Suggestions = %v", err.Suggestions)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "openai/gpt-4p") || !strings.Contains(msg, "did you mean openai/gpt-4o") {
+		t.Fatalf("Error() = %q", msg)
+	}
+	if msg := (&ModelNotFoundError{ModelID: "x"}).Error(); strings.Contains(msg, "did you mean") {
+		t.Fatalf("Error() without suggestions = %q", msg)
+	}
+}
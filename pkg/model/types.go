@@ -25,6 +25,7 @@ type Message struct {
 	Name             string            `json:"name,omitempty"`              // Tool name for tool messages
 	Reasoning        string            `json:"reasoning,omitempty"`         // Reasoning/thinking content for reasoning continuity
 	ReasoningDetails []ReasoningDetail `json:"reasoning_details,omitempty"` // OpenRouter reasoning_details blocks
+	Pinned           bool              `json:"-"`                           // Kept exact when history is compacted; never sent to providers
 }
 
 func (m Message) MarshalJSON() ([]byte, error) {
@@ -143,6 +144,7 @@ type ChatRequest struct {
 	Provider             map[string]any    `json:"provider,omitempty"`            // OpenRouter provider routing preferences
 	ResponseFormat       map[string]any    `json:"response_format,omitempty"`     // JSON mode or JSON schema
 	Seed                 *int              `json:"seed,omitempty"`
+	Stop                 []string          `json:"stop,omitempty"`
 	ServiceTier          string            `json:"service_tier,omitempty"`
 	SessionID            string            `json:"session_id,omitempty"`             // OpenRouter observability/session grouping
 	Metadata             map[string]string `json:"metadata,omitempty"`               // OpenRouter request metadata
@@ -157,6 +159,21 @@ type ChatRequest struct {
 	ReviewSnapshot *ReviewSnapshot `json:"-"`
 }
 
+// DefaultMaxStopSequences is the stop sequence limit for OpenAI-compatible
+// providers, which reject requests carrying more than four sequences.
+const DefaultMaxStopSequences = 4
+
+// MaxStopSequences returns how many stop sequences a provider accepts per
+// request.
+func MaxStopSequences(providerID string) int {
+	switch strings.ToLower(strings.TrimSpace(providerID)) {
+	case "anthropic", "ollama":
+		return 16
+	default:
+		return DefaultMaxStopSequences
+	}
+}
+
 // ChatResponse represents a non-streaming chat completion response.
 type ChatResponse struct {
 	ID                string                     `json:"id"`
@@ -373,6 +390,49 @@ type ModelInfo struct {
 	Created             int64        `json:"created"` // Unix timestamp
 	Architecture        Architecture `json:"architecture,omitempty"`
 	SupportedParameters []string     `json:"supported_parameters,omitempty"`
+
+	// TopProvider carries OpenRouter's limits for the primary provider,
+	// including the output cap, which is often far below ContextLength.
+	TopProvider ModelTopProvider `json:"top_provider,omitempty"`
+}
+
+// ModelTopProvider describes limits a catalog reports for serving a model.
+type ModelTopProvider struct {
+	MaxCompletionTokens int `json:"max_completion_tokens,omitempty"`
+}
+
+// MaxOutputTokens returns the most tokens the model may generate in one
+// response, or zero when the catalog does not say.
+func (info ModelInfo) MaxOutputTokens() int {
+	return info.TopProvider.MaxCompletionTokens
+}
+
+// SupportsVision reports whether the model accepts image inputs.
+func (info ModelInfo) SupportsVision() bool {
+	modality := info.Architecture.Modality
+	return modality == "text+image" || modality == "multimodal" ||
+		modality == "text+image->text" || modality == "image+text->text"
+}
+
+// SupportsReasoning reports whether the catalog advertises the reasoning parameter.
+func (info ModelInfo) SupportsReasoning() bool {
+	return info.SupportsParameter("reasoning")
+}
+
+// SupportsTools reports whether the catalog advertises function/tool calling.
+func (info ModelInfo) SupportsTools() bool {
+	return info.SupportsParameter("tools") || info.SupportsParameter("functions")
+}
+
+// SupportsParameter reports whether the catalog advertises a request field.
+func (info ModelInfo) SupportsParameter(parameter string) bool {
+	parameter = strings.TrimSpace(parameter)
+	for _, candidate := range info.SupportedParameters {
+		if candidate == parameter {
+			return true
+		}
+	}
+	return false
 }
 
 // Architecture contains model architecture details
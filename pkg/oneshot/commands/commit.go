@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"m31labs.dev/buckley/pkg/commitmsg"
 	"m31labs.dev/buckley/pkg/oneshot"
+	"m31labs.dev/buckley/pkg/prompts"
 	"m31labs.dev/buckley/pkg/tools"
 )
 
@@ -19,7 +21,10 @@ var commitActions = []string{
 }
 
 // CommitDefinition implements oneshot.Definition for commit message generation.
-type CommitDefinition struct{}
+type CommitDefinition struct {
+	// Conventions customizes the header template, allowed types and length.
+	Conventions prompts.CommitConventions
+}
 
 // CommitResult is the strongly-typed result of generate_commit.
 type CommitResult struct {
@@ -29,10 +34,16 @@ type CommitResult struct {
 	Body     []string `json:"body"`
 	Breaking bool     `json:"breaking,omitempty"`
 	Issues   []string `json:"issues,omitempty"`
+
+	// headerTemplate is the configured header layout, set by Unmarshal.
+	headerTemplate string
 }
 
 // Header formats the commit header line.
 func (cr CommitResult) Header() string {
+	if strings.TrimSpace(cr.headerTemplate) != "" {
+		return renderCommitHeader(cr.headerTemplate, cr.Action, cr.Scope, cr.Subject)
+	}
 	if cr.Scope != "" {
 		return cr.Action + "(" + cr.Scope + "): " + cr.Subject
 	}
@@ -61,9 +72,29 @@ func (cr CommitResult) Format() string {
 	return msg
 }
 
+// renderCommitHeader fills a {type}/{scope}/{subject} template. An empty
+// scope drops its placeholder along with any brackets around it.
+func renderCommitHeader(template, action, scope, subject string) string {
+	header := strings.TrimSpace(template)
+	if scope == "" {
+		for _, empty := range []string{"({scope})", "[{scope}]", "{scope}"} {
+			header = strings.ReplaceAll(header, empty, "")
+		}
+	}
+	header = strings.NewReplacer("{type}", action, "{scope}", scope, "{subject}", subject).Replace(header)
+	return strings.Join(strings.Fields(header), " ")
+}
+
 func (CommitDefinition) Name() string { return "commit" }
 
-func (CommitDefinition) Tool() tools.Definition {
+func (d CommitDefinition) actions() []string {
+	if len(d.Conventions.Types) > 0 {
+		return d.Conventions.Types
+	}
+	return commitActions
+}
+
+func (d CommitDefinition) Tool() tools.Definition {
 	return tools.Definition{
 		Name:        "generate_commit",
 		Description: "Generate a structured git commit message based on staged changes. Returns action-style commit with header and body bullets.",
@@ -71,7 +102,7 @@ func (CommitDefinition) Tool() tools.Definition {
 			map[string]tools.Property{
 				"action": tools.StringEnumProperty(
 					"The action verb describing what this commit does",
-					commitActions...,
+					d.actions()...,
 				),
 				"scope": tools.StringProperty(
 					"The component, package, or area affected (optional)",
@@ -79,7 +110,7 @@ func (CommitDefinition) Tool() tools.Definition {
 				"subject": {
 					Type:        "string",
 					Description: "Short summary of the change, imperative mood, no period, max 50 chars",
-					MaxLength:   72,
+					MaxLength:   d.Conventions.MaxLength(),
 				},
 				"body": tools.ArrayProperty(
 					"Bullet points explaining WHAT changed and WHY (not how)",
@@ -108,21 +139,8 @@ func (CommitDefinition) ContextSources() []oneshot.ContextSource {
 	}
 }
 
-func (CommitDefinition) SystemPrompt() string {
-	return `You are a git commit message generator. Analyze the staged changes and generate a clear, informative commit message.
-
-Use the generate_commit tool to produce your response. The tool expects:
-- action: The verb describing what this commit does (add, fix, update, refactor, etc.)
-- scope: Optional component/area (e.g., "api", "ui", "config")
-- subject: Short summary, imperative mood, no period, ~50 chars
-- body: Bullet points explaining WHAT changed and WHY
-
-Guidelines:
-- Focus on the "what" and "why", not the "how"
-- Be specific but concise
-- Match body detail to change size
-- Group related changes into single bullets
-- Use imperative mood ("Add feature" not "Added feature")`
+func (d CommitDefinition) SystemPrompt() string {
+	return prompts.CommitToolPrompt(time.Now(), d.Conventions)
 }
 
 func (CommitDefinition) BuildPrompt(ctx *oneshot.Context) string {
@@ -149,7 +167,7 @@ func (CommitDefinition) BuildPrompt(ctx *oneshot.Context) string {
 	return b.String()
 }
 
-func (CommitDefinition) Validate(result json.RawMessage) error {
+func (d CommitDefinition) Validate(result json.RawMessage) error {
 	var cr CommitResult
 	if err := json.Unmarshal(result, &cr); err != nil {
 		return fmt.Errorf("unmarshal: %w", err)
@@ -163,13 +181,26 @@ func (CommitDefinition) Validate(result json.RawMessage) error {
 	if len(cr.Body) == 0 {
 		return fmt.Errorf("body requires at least one bullet")
 	}
+	if len(d.Conventions.Types) > 0 && !containsString(d.Conventions.Types, cr.Action) {
+		return fmt.Errorf("action %q is not one of the configured commit types (%s)", cr.Action, strings.Join(d.Conventions.Types, ", "))
+	}
 	return nil
 }
 
-func (CommitDefinition) Unmarshal(result json.RawMessage) (any, error) {
+func (d CommitDefinition) Unmarshal(result json.RawMessage) (any, error) {
 	var cr CommitResult
 	if err := json.Unmarshal(result, &cr); err != nil {
 		return nil, fmt.Errorf("unmarshal: %w", err)
 	}
+	cr.headerTemplate = d.Conventions.Template
 	return &cr, nil
 }
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/prompts"
+)
+
+func TestCommitDefinitionUsesConfiguredTypes(t *testing.T) {
+	def := CommitDefinition{Conventions: prompts.CommitConventions{
+		Types:            []string{"feat", "fix"},
+		MaxSubjectLength: 50,
+	}}
+
+	subject := def.Tool().Parameters.Properties["subject"]
+	if subject.MaxLength != 50 {
+		t.Fatalf("subject max length = %d, want 50", subject.MaxLength)
+	}
+	action := def.Tool().Parameters.Properties["action"]
+	if len(action.Enum) != 2 || action.Enum[0] != "feat" {
+		t.Fatalf("action enum = %v, want [feat fix]", action.Enum)
+	}
+
+	valid, _ := json.Marshal(CommitResult{Action: "feat", Subject: "login", Body: []string{"Add login"}})
+	if err := def.Validate(valid); err != nil {
+		t.Fatalf("Validate(feat): %v", err)
+	}
+	invalid, _ := json.Marshal(CommitResult{Action: "add", Subject: "login", Body: []string{"Add login"}})
+	if err := def.Validate(invalid); err == nil {
+		t.Fatal("expected an action outside commit.types to be rejected")
+	}
+	if err := (CommitDefinition{}).Validate(invalid); err != nil {
+		t.Fatalf("default definition must keep accepting action verbs: %v", err)
+	}
+}
+
+func TestCommitResultHeaderTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		scope    string
+		want     string
+	}{
+		{"", "api", "add(api): login flow"},
+		{"", "", "add: login flow"},
+		{"[{scope}] {subject}", "api", "[api] login flow"},
+		{"[{scope}] {subject}", "", "login flow"},
+		{"{type}({scope}): {subject}", "", "add: login flow"},
+		{"{subject}", "api", "login flow"},
+	}
+	for _, tt := range tests {
+		def := CommitDefinition{Conventions: prompts.CommitConventions{Template: tt.template}}
+		raw, _ := json.Marshal(CommitResult{Action: "add", Scope: tt.scope, Subject: "login flow", Body: []string{"x"}})
+		value, err := def.Unmarshal(raw)
+		if err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		if got := value.(*CommitResult).Header(); got != tt.want {
+			t.Errorf("template %q scope %q: header = %q, want %q", tt.template, tt.scope, got, tt.want)
+		}
+	}
+}
@@ -14,6 +14,9 @@ import (
 type PRDefinition struct {
 	// BaseBranch overrides automatic base branch detection.
 	BaseBranch string
+	// HeadRef, when set, is the tip of the range instead of HEAD, so the PR
+	// describes BaseBranch..HeadRef regardless of what is checked out.
+	HeadRef string
 }
 
 // PRResult is the structured output from the generate_pull_request tool.
@@ -153,11 +156,17 @@ func (PRDefinition) Tool() tools.Definition {
 }
 
 func (d PRDefinition) ContextSources() []oneshot.ContextSource {
-	base := d.baseOrDefault()
+	params := func() map[string]string {
+		p := map[string]string{"base": d.baseOrDefault()}
+		if d.HeadRef != "" {
+			p["head"] = d.HeadRef
+		}
+		return p
+	}
 	return []oneshot.ContextSource{
-		{Type: "git_diff", Params: map[string]string{"base": base}},
-		{Type: "git_log", Params: map[string]string{"base": base}},
-		{Type: "git_files", Params: map[string]string{"base": base}},
+		{Type: "git_diff", Params: params()},
+		{Type: "git_log", Params: params()},
+		{Type: "git_files", Params: params()},
 		{Type: "agents_md"},
 	}
 }
@@ -169,6 +178,15 @@ func (d PRDefinition) baseOrDefault() string {
 	return "main"
 }
 
+// sourceKey is the suffix BuildContext labels this definition's git sources
+// with: the base, or "base..head" for an explicit range.
+func (d PRDefinition) sourceKey() string {
+	if d.HeadRef == "" {
+		return d.baseOrDefault()
+	}
+	return d.baseOrDefault() + ".." + d.HeadRef
+}
+
 // isCommitAction reports whether action is one of the allowed commit verbs.
 func isCommitAction(action string) bool {
 	for _, a := range commitActions {
@@ -217,6 +235,7 @@ Guidelines:
 func (d PRDefinition) BuildPrompt(ctx *oneshot.Context) string {
 	var b strings.Builder
 	base := d.baseOrDefault()
+	key := d.sourceKey()
 
 	if agents, ok := ctx.Sources["agents_md"]; ok && agents != "" {
 		b.WriteString("## Project Guidelines\n\n")
@@ -224,21 +243,25 @@ func (d PRDefinition) BuildPrompt(ctx *oneshot.Context) string {
 		b.WriteString("\n\n")
 	}
 
-	b.WriteString("Generate a pull request for the following branch changes (base: " + base + ").\n\n")
+	if d.HeadRef != "" {
+		b.WriteString("Generate a pull request for the changes from " + base + " to " + d.HeadRef + " (base: " + base + ").\n\n")
+	} else {
+		b.WriteString("Generate a pull request for the following branch changes (base: " + base + ").\n\n")
+	}
 
-	if log, ok := ctx.Sources["git_log:"+base]; ok && log != "" {
+	if log, ok := ctx.Sources["git_log:"+key]; ok && log != "" {
 		b.WriteString("## Commits\n\n```\n")
 		b.WriteString(log)
 		b.WriteString("\n```\n\n")
 	}
 
-	if files, ok := ctx.Sources["git_files:"+base]; ok && files != "" {
+	if files, ok := ctx.Sources["git_files:"+key]; ok && files != "" {
 		b.WriteString("## Changed Files\n\n")
 		b.WriteString(files)
 		b.WriteString("\n\n")
 	}
 
-	if diff, ok := ctx.Sources["git_diff:"+base]; ok && diff != "" {
+	if diff, ok := ctx.Sources["git_diff:"+key]; ok && diff != "" {
 		b.WriteString("## Full Diff\n\n```diff\n")
 		b.WriteString(diff)
 		b.WriteString("\n```\n\n")
@@ -108,6 +108,34 @@ func TestPRBuildPromptUsesConfiguredBase(t *testing.T) {
 	}
 }
 
+func TestPRExplicitRangeUsesRangeSources(t *testing.T) {
+	def := PRDefinition{BaseBranch: "v1.2.0", HeadRef: "release"}
+	for _, src := range def.ContextSources() {
+		if src.Type == "agents_md" {
+			continue
+		}
+		if src.Params["base"] != "v1.2.0" || src.Params["head"] != "release" {
+			t.Fatalf("%s params = %v, want base v1.2.0 and head release", src.Type, src.Params)
+		}
+	}
+
+	ctx := &oneshot.Context{Sources: map[string]string{
+		"git_log:v1.2.0..release":   "abc123 add: thing",
+		"git_files:v1.2.0..release": "pkg/x/y.go",
+		"git_diff:v1.2.0..release":  "+ range diff",
+		"git_diff:v1.2.0":           "+ checkout diff",
+	}}
+	prompt := def.BuildPrompt(ctx)
+	for _, want := range []string{"abc123 add: thing", "pkg/x/y.go", "+ range diff", "from v1.2.0 to release"} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("range prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "checkout diff") {
+		t.Fatalf("range prompt must not read the HEAD-based diff:\n%s", prompt)
+	}
+}
+
 func TestPRValidateRequiresActionButNotTesting(t *testing.T) {
 	valid := func(pr PRResult) error {
 		raw, err := json.Marshal(pr)
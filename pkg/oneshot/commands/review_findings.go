@@ -18,6 +18,16 @@ const (
 	SeverityMinor    Severity = "MINOR"
 )
 
+// FindingCategories are the categories a review is asked to tag findings
+// with when structured output is requested.
+var FindingCategories = []string{"security", "correctness", "reliability", "performance", "maintainability", "tests", "docs"}
+
+// FindingCategoryInstructions is appended to a review prompt to have each
+// finding carry a Category field. The default review format omits it.
+var FindingCategoryInstructions = "## Finding Categories\n" +
+	"Add a `- **Category**:` line to every finding, directly after **File**, with exactly one of: " +
+	strings.Join(FindingCategories, ", ") + "."
+
 // Grade represents the overall review grade.
 type Grade string
 
@@ -34,6 +44,7 @@ type Finding struct {
 	ID           string   // e.g., "FINDING-001"
 	Severity     Severity // CRITICAL, MAJOR, MINOR
 	Title        string   // Brief description
+	Category     string   // e.g. "security"; empty unless the review was asked for categories
 	File         string   // File path
 	Line         int      // Line number (0 if not specified)
 	Evidence     string   // Proof of the issue
@@ -701,6 +712,7 @@ func extractFindings(review string) []Finding {
 			finding.Impact = extractField(content, "Business Impact")
 		}
 		finding.Fix = extractField(content, "Fix")
+		finding.Category = strings.ToLower(strings.Trim(extractField(content, "Category"), "`*[] "))
 		finding.SuggestedFix = extractCodeBlock(content, "suggested")
 
 		findings = append(findings, finding)
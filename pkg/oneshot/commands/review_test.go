@@ -766,6 +766,28 @@ Code has issues.
 	assert.Equal(t, []string{"FINDING-002"}, parsed.Suggestions)
 }
 
+func TestParseReview_FindingCategory(t *testing.T) {
+	review := `## Findings
+
+### FINDING-001: [MAJOR] Unbounded retry loop
+- **File**: pkg/client/retry.go:12
+- **Category**: Reliability
+- **Evidence**: no attempt limit
+- **Fix**: cap retries
+
+### FINDING-002: [MINOR] Unused helper
+- **File**: pkg/client/util.go:3
+- **Evidence**: no callers
+`
+
+	parsed := ParseReview(review)
+
+	assert.Len(t, parsed.Findings, 2)
+	assert.Equal(t, "reliability", parsed.Findings[0].Category)
+	assert.Equal(t, "no attempt limit", parsed.Findings[0].Evidence)
+	assert.Equal(t, "", parsed.Findings[1].Category)
+}
+
 func TestParseVerdictApprovalRequiresOneExactNormalizedDecision(t *testing.T) {
 	tests := []struct {
 		name     string
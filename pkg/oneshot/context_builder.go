@@ -63,12 +63,12 @@ func sourceLabel(src ContextSource) string {
 			return "git_diff:staged"
 		}
 		if base := src.Params["base"]; base != "" {
-			return "git_diff:" + base
+			return "git_diff:" + rangeLabel(base, src.Params["head"])
 		}
 		return "git_diff"
 	case "git_log":
 		if base := src.Params["base"]; base != "" {
-			return "git_log:" + base
+			return "git_log:" + rangeLabel(base, src.Params["head"])
 		}
 		return "git_log"
 	case "git_files":
@@ -76,7 +76,7 @@ func sourceLabel(src ContextSource) string {
 			return "git_files:staged"
 		}
 		if base := src.Params["base"]; base != "" {
-			return "git_files:" + base
+			return "git_files:" + rangeLabel(base, src.Params["head"])
 		}
 		return "git_files"
 	case "env":
@@ -90,6 +90,23 @@ func sourceLabel(src ContextSource) string {
 	}
 }
 
+// rangeLabel names a base..head range in source labels. The head is omitted
+// when it is the implicit HEAD, so existing "git_diff:<base>" keys still work.
+func rangeLabel(base, head string) string {
+	if head == "" {
+		return base
+	}
+	return base + ".." + head
+}
+
+// rangeHead returns the tip of a base range: the "head" param, or HEAD.
+func rangeHead(params map[string]string) string {
+	if head := params["head"]; head != "" {
+		return head
+	}
+	return "HEAD"
+}
+
 // gatherSource fetches content for a single ContextSource.
 func gatherSource(src ContextSource, opts ContextOpts) (string, error) {
 	switch src.Type {
@@ -121,7 +138,7 @@ func gatherGitDiff(params map[string]string, opts ContextOpts) (string, error) {
 	if params["staged"] == "true" {
 		args = append(args, "--cached")
 	} else if base := params["base"]; base != "" {
-		args = append(args, base+"...HEAD")
+		args = append(args, base+"..."+rangeHead(params))
 	}
 
 	// Optional pathspec: "paths" param is a NUL-separated list of paths.
@@ -140,9 +157,12 @@ func gatherGitDiff(params map[string]string, opts ContextOpts) (string, error) {
 
 	output, rawTruncated, err := contextGitOutputLimited(diffsignal.MaxParseBytes, args...)
 	if err != nil {
-		// Retry without ...HEAD for base diff
+		// Retry without the merge base for base diff
 		if base := params["base"]; base != "" {
 			args = []string{"diff", base}
+			if head := params["head"]; head != "" {
+				args = append(args, head)
+			}
 			if len(pathsArgs) > 0 {
 				args = append(args, "--")
 				args = append(args, pathsArgs...)
@@ -174,7 +194,7 @@ func gatherGitLog(params map[string]string) (string, error) {
 	args := []string{"log", "--oneline"}
 
 	if base := params["base"]; base != "" {
-		args = append(args, base+"..HEAD")
+		args = append(args, base+".."+rangeHead(params))
 	} else {
 		args = append(args, "-20")
 	}
@@ -193,7 +213,7 @@ func gatherGitFiles(params map[string]string) (string, error) {
 	if params["staged"] == "true" {
 		args = append(args, "--cached")
 	} else if base := params["base"]; base != "" {
-		args = append(args, base+"...HEAD")
+		args = append(args, base+"..."+rangeHead(params))
 	}
 
 	// Optional pathspec: "paths" param is a NUL-separated list of paths.
@@ -212,9 +232,12 @@ func gatherGitFiles(params map[string]string) (string, error) {
 
 	output, err := contextGitOutput(args...)
 	if err != nil {
-		// Retry without ...HEAD for base
+		// Retry without the merge base for base
 		if base := params["base"]; base != "" {
 			args = []string{"diff", "--name-status", base}
+			if head := params["head"]; head != "" {
+				args = append(args, head)
+			}
 			if len(pathsArgs) > 0 {
 				args = append(args, "--")
 				args = append(args, pathsArgs...)
@@ -99,3 +99,46 @@ func TestBuildContextBudgetIncludesTruncationMarker(t *testing.T) {
 		t.Errorf("context length %d exceeds MaxDiffBytes %d (truncation marker not accounted for)", len(diff), budget)
 	}
 }
+
+// TestBuildContextExplicitHead checks that a "head" param gathers the
+// base..head range rather than what is checked out.
+func TestBuildContextExplicitHead(t *testing.T) {
+	dir := t.TempDir()
+	gitIn(t, dir, "init", "-q", "-b", "main")
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("base.txt", "base\n")
+	gitIn(t, dir, "add", ".")
+	gitIn(t, dir, "commit", "-q", "-m", "base")
+	gitIn(t, dir, "checkout", "-q", "-b", "feature")
+	write("feature.txt", "feature\n")
+	gitIn(t, dir, "add", ".")
+	gitIn(t, dir, "commit", "-q", "-m", "add feature")
+	// Detach at main, as a CI checkout might, so HEAD has nothing to compare.
+	gitIn(t, dir, "checkout", "-q", "--detach", "main")
+	t.Chdir(dir)
+
+	params := map[string]string{"base": "main", "head": "feature"}
+	ctx, err := BuildContext([]ContextSource{
+		{Type: "git_diff", Params: params},
+		{Type: "git_log", Params: params},
+		{Type: "git_files", Params: params},
+	}, DefaultContextOpts())
+	if err != nil {
+		t.Fatalf("BuildContext: %v", err)
+	}
+
+	if diff := ctx.Sources["git_diff:main..feature"]; !strings.Contains(diff, "+feature") {
+		t.Errorf("range diff missing feature change: %q (sources: %v)", diff, ctx.Sources)
+	}
+	if log := ctx.Sources["git_log:main..feature"]; !strings.Contains(log, "add feature") {
+		t.Errorf("range log missing commit: %q", log)
+	}
+	if files := ctx.Sources["git_files:main..feature"]; !strings.Contains(files, "feature.txt") {
+		t.Errorf("range files missing feature.txt: %q", files)
+	}
+}
@@ -17,6 +17,7 @@ type ContextSource struct {
 	//   git_diff:  "staged" => "true" for --cached; "base" => branch name for base...HEAD
 	//   git_log:   "base" => branch name for base..HEAD
 	//   git_files: "staged" => "true" for --cached --name-status; "base" => branch name
+	//   git_diff, git_log, git_files: "head" => ref to use instead of HEAD with "base"
 	//   agents_md: (no params)
 	//   env:       "name" => environment variable name
 	//   command:   "cmd" => shell command string
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/rlm"
 	"m31labs.dev/buckley/pkg/tool"
@@ -18,6 +19,7 @@ import (
 // instead of limited custom tool definitions.
 type RLMRunner struct {
 	models    *model.Manager
+	config    *config.Config
 	registry  *tool.Registry
 	ledger    *transparency.CostLedger
 	modelID   string
@@ -27,6 +29,7 @@ type RLMRunner struct {
 // RLMRunnerConfig configures the RLM runner.
 type RLMRunnerConfig struct {
 	Models          *model.Manager
+	Config          *config.Config // Tool policy for snapshot review registries
 	Registry        *tool.Registry
 	Ledger          *transparency.CostLedger
 	ModelID         string
@@ -37,6 +40,7 @@ type RLMRunnerConfig struct {
 func NewRLMRunner(cfg RLMRunnerConfig) *RLMRunner {
 	return &RLMRunner{
 		models:    cfg.Models,
+		config:    cfg.Config,
 		registry:  cfg.Registry,
 		ledger:    cfg.Ledger,
 		modelID:   cfg.ModelID,
@@ -113,7 +117,7 @@ func (r *RLMRunner) Run(ctx context.Context, systemPrompt, task string, allowedT
 				cleanupSnapshot()
 				return nil, fmt.Errorf("resolve API review snapshot root: %w", rootErr)
 			}
-			agentRegistry, err = newReviewSnapshotRegistry(snapshotRoot, allowedTools, r.models.ReviewSandboxCommand())
+			agentRegistry, err = newReviewSnapshotRegistry(r.config, snapshotRoot, allowedTools, r.models.ReviewSandboxCommand())
 			if err != nil {
 				cleanupSnapshot()
 				return nil, err
@@ -292,7 +296,7 @@ func salvageText(value string, limit int) string {
 	return value
 }
 
-func newReviewSnapshotRegistry(root string, allowedTools []string, codexCommand ...string) (*tool.Registry, error) {
+func newReviewSnapshotRegistry(cfg *config.Config, root string, allowedTools []string, codexCommand ...string) (*tool.Registry, error) {
 	allowed := make(map[string]struct{}, len(allowedTools))
 	for _, name := range allowedTools {
 		name = strings.TrimSpace(name)
@@ -312,6 +316,7 @@ func newReviewSnapshotRegistry(root string, allowedTools []string, codexCommand
 		_, ok := allowed[candidate.Name()]
 		return ok
 	}))
+	tool.ApplyToolMiddlewareConfig(registry, cfg)
 	if _, enabled := allowed["run_verification"]; enabled {
 		verification, err := builtin.NewRunVerificationTool(root, codexCommand...)
 		if err != nil {
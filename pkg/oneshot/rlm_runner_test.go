@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/rlm"
 )
@@ -71,7 +72,7 @@ func TestReviewSnapshotRegistryReadsOnlyMaterializedState(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ReviewWorkspaceRepositoryRoot: %v", err)
 	}
-	registry, err := newReviewSnapshotRegistry(root, []string{"read_file", "find_files", "search_text"})
+	registry, err := newReviewSnapshotRegistry(nil, root, []string{"read_file", "find_files", "search_text"})
 	if err != nil {
 		t.Fatalf("newReviewSnapshotRegistry: %v", err)
 	}
@@ -109,14 +110,14 @@ func TestReviewSnapshotRegistryReadsOnlyMaterializedState(t *testing.T) {
 }
 
 func TestReviewSnapshotRegistryRejectsNonReviewTools(t *testing.T) {
-	if _, err := newReviewSnapshotRegistry(t.TempDir(), []string{"read_file", "run_shell"}); err == nil {
+	if _, err := newReviewSnapshotRegistry(nil, t.TempDir(), []string{"read_file", "run_shell"}); err == nil {
 		t.Fatal("snapshot registry accepted an executable tool")
 	}
 }
 
 func TestReviewSnapshotRegistryExplicitlyRegistersSealedVerification(t *testing.T) {
 	root := t.TempDir()
-	registry, err := newReviewSnapshotRegistry(root, []string{"read_file", "run_verification"}, "/usr/bin/true")
+	registry, err := newReviewSnapshotRegistry(nil, root, []string{"read_file", "run_verification"}, "/usr/bin/true")
 	if err != nil {
 		t.Fatalf("newReviewSnapshotRegistry: %v", err)
 	}
@@ -140,3 +141,34 @@ func runReviewRegistryGit(t *testing.T, dir string, args ...string) {
 		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
 	}
 }
+
+func TestReviewSnapshotRegistryAppliesToolPolicy(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"read_file"}
+	registry, err := newReviewSnapshotRegistry(cfg, t.TempDir(), []string{"read_file", "find_files"})
+	if err != nil {
+		t.Fatalf("newReviewSnapshotRegistry: %v", err)
+	}
+	if !registry.RequiresApproval("read_file") {
+		t.Fatal("snapshot registry ignored tools.dangerous")
+	}
+}
+
+func TestReviewSnapshotRegistryAppliesDenyList(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ToolMiddleware.DenyList = []string{"search_text"}
+	registry, err := newReviewSnapshotRegistry(cfg, t.TempDir(), []string{"read_file", "search_text"})
+	if err != nil {
+		t.Fatalf("newReviewSnapshotRegistry: %v", err)
+	}
+	if !registry.PolicyAllows("read_file") || registry.PolicyAllows("search_text") {
+		t.Fatal("snapshot registry ignored tool_middleware.deny_list")
+	}
+	result, err := registry.Execute("search_text", map[string]any{"query": "x", "path": "."})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if result.Success {
+		t.Fatal("snapshot registry ran a tool on tool_middleware.deny_list")
+	}
+}
@@ -2,11 +2,13 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"m31labs.dev/buckley/pkg/config"
@@ -165,20 +167,31 @@ func (b *BatchCoordinator) collectJobLogs(ctx context.Context, jobName string) (
 	return string(data), nil
 }
 
+// CleanupWorkspaces deletes stale task workspace PVCs one at a time.
 func (b *BatchCoordinator) CleanupWorkspaces(ctx context.Context, olderThan time.Duration) (int, error) {
+	return b.CleanupWorkspacesConcurrent(ctx, olderThan, 1)
+}
+
+// CleanupWorkspacesConcurrent deletes stale task workspace PVCs using up to
+// concurrency parallel deletes. Per-workspace failures are aggregated so one
+// stuck PVC does not abort the rest of the run.
+func (b *BatchCoordinator) CleanupWorkspacesConcurrent(ctx context.Context, olderThan time.Duration, concurrency int) (int, error) {
 	if !b.Enabled() {
 		return 0, fmt.Errorf("batch coordinator is not enabled")
 	}
 	if olderThan <= 0 {
 		olderThan = 4 * time.Hour
 	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
 	selector := fmt.Sprintf("%s=%s", workspaceLabelKey, workspaceLabelValue)
 	pvcs, err := b.client.CoreV1().PersistentVolumeClaims(b.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
 	if err != nil {
 		return 0, err
 	}
 	cutoff := time.Now().Add(-olderThan)
-	deleted := 0
+	var stale []string
 	for _, pvc := range pvcs.Items {
 		if pvc.CreationTimestamp.IsZero() || pvc.CreationTimestamp.Time.After(cutoff) {
 			continue
@@ -186,13 +199,36 @@ func (b *BatchCoordinator) CleanupWorkspaces(ctx context.Context, olderThan time
 		if len(pvc.OwnerReferences) > 0 {
 			continue
 		}
-		if err := b.client.CoreV1().PersistentVolumeClaims(b.namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
-			return deleted, err
-		}
-		deleted++
+		stale = append(stale, pvc.Name)
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		deleted int
+		errs    []error
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, name := range stale {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := b.client.CoreV1().PersistentVolumeClaims(b.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("deleting workspace %s: %w", name, err))
+				return
+			}
+			deleted++
+		}(name)
 	}
+	wg.Wait()
+
 	recordWorkspacePrune(deleted)
-	return deleted, nil
+	return deleted, errors.Join(errs...)
 }
 
 func (b *BatchCoordinator) buildJob(jobName string, vars map[string]string) *batchv1.Job {
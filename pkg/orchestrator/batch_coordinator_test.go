@@ -1,12 +1,19 @@
 package orchestrator
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"m31labs.dev/buckley/pkg/config"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestBatchCoordinatorBuildJobRendersTemplates(t *testing.T) {
@@ -149,3 +156,57 @@ func TestBatchCoordinatorRemoteBranchDisabled(t *testing.T) {
 		t.Fatalf("expected remote branch to be empty, got %s", branch)
 	}
 }
+
+func TestBatchCoordinatorCleanupWorkspacesConcurrentAggregatesFailures(t *testing.T) {
+	old := metav1.NewTime(time.Now().Add(-24 * time.Hour))
+	fresh := metav1.NewTime(time.Now())
+	pvc := func(name string, created metav1.Time) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{workspaceLabelKey: workspaceLabelValue},
+			CreationTimestamp: created,
+		}}
+	}
+	client := fake.NewSimpleClientset(
+		pvc("ws-a", old),
+		pvc("ws-b", old),
+		pvc("ws-c", old),
+		pvc("ws-d", old),
+		pvc("ws-fresh", fresh),
+	)
+	client.PrependReactor("delete", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.DeleteAction).GetName()
+		if name == "ws-b" || name == "ws-d" {
+			return true, nil, fmt.Errorf("volume %s busy", name)
+		}
+		return false, nil, nil
+	})
+
+	bc := &BatchCoordinator{
+		cfg:       config.BatchConfig{Enabled: true},
+		namespace: "default",
+		client:    client,
+	}
+
+	deleted, err := bc.CleanupWorkspacesConcurrent(context.Background(), time.Hour, 3)
+	if deleted != 2 {
+		t.Fatalf("deleted=%d want 2", deleted)
+	}
+	if err == nil {
+		t.Fatal("expected aggregated error for failing workspaces")
+	}
+	for _, name := range []string{"ws-b", "ws-d"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected error to mention %s, got %v", name, err)
+		}
+	}
+
+	remaining, listErr := client.CoreV1().PersistentVolumeClaims("default").List(context.Background(), metav1.ListOptions{})
+	if listErr != nil {
+		t.Fatalf("List: %v", listErr)
+	}
+	if len(remaining.Items) != 3 {
+		t.Fatalf("remaining PVCs=%d want 3 (two failures plus fresh)", len(remaining.Items))
+	}
+}
@@ -0,0 +1,52 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"m31labs.dev/buckley/pkg/cost"
+)
+
+// ErrBudgetExceeded is returned by ExecutePlan when cost_management.hard_stop
+// is enabled and a budget ran out before the next task started.
+var ErrBudgetExceeded = cost.ErrBudgetExceeded
+
+// BudgetChecker reports spend against the configured budgets. *cost.Tracker
+// satisfies it.
+type BudgetChecker interface {
+	CheckBudget() *cost.BudgetStatus
+}
+
+// SetBudgetChecker installs the budget consulted before each task when
+// cost_management.hard_stop is enabled. Passing nil removes it.
+func (o *Orchestrator) SetBudgetChecker(budget BudgetChecker) {
+	if o == nil {
+		return
+	}
+	o.budget = budget
+	if o.executor != nil {
+		o.executor.SetBudgetChecker(budget)
+	}
+}
+
+// SetBudgetChecker installs the budget Execute consults before each task.
+func (e *Executor) SetBudgetChecker(budget BudgetChecker) {
+	if e == nil {
+		return
+	}
+	e.budget = budget
+}
+
+// checkBudget returns an ErrBudgetExceeded error when hard stop is enabled
+// and any budget is exhausted.
+func (e *Executor) checkBudget() error {
+	if e.budget == nil || e.config == nil || !e.config.CostManagement.HardStop {
+		return nil
+	}
+	if err := e.budget.CheckBudget().Err(); err != nil {
+		if e.workflow != nil {
+			e.workflow.SendProgress(fmt.Sprintf("⛔ Execution stopped: %v", err))
+		}
+		return err
+	}
+	return nil
+}
@@ -90,7 +90,7 @@ func NewBuilderAgent(plan *Plan, cfg *config.Config, client ModelClient, registr
 		toolRegistry: registry,
 		config:       cfg,
 		workflow:     workflow,
-		logger:       newBuilderLogger(plan),
+		logger:       newBuilderLogger(plan, cfg),
 		resultCodec:  toon.New(cfg.Encoding.UseToon),
 	}
 }
@@ -493,6 +493,11 @@ func (a *BuilderAgent) executeToolCall(tc model.ToolCall, task *Task) (string, e
 			return "", fmt.Errorf("tool authorization failed: %w", err)
 		}
 	}
+	// The tool runs outside the registry's middleware, so tools.dangerous
+	// has to be confirmed here.
+	if err := a.toolRegistry.ConfirmCall(context.Background(), tc.Function.Name, params); err != nil {
+		return "", fmt.Errorf("tool authorization failed: %w", err)
+	}
 
 	start := time.Now()
 	result, err := tool.Execute(params)
@@ -568,6 +573,10 @@ func (a *BuilderAgent) applyFiles(task *Task, files map[string]string, source st
 				return nil, err
 			}
 		}
+		if err := a.toolRegistry.ConfirmCall(context.Background(), writeTool.Name(), params); err != nil {
+			a.logFailure(task.ID, "tool_authorization", err)
+			return nil, err
+		}
 		result, err := writeTool.Execute(params)
 		end := time.Now()
 		if a.workflow != nil {
@@ -689,8 +698,8 @@ func (a *BuilderAgent) logFailure(taskID string, stage string, err error) {
 	})
 }
 
-func newBuilderLogger(plan *Plan) *builderLogger {
-	if plan == nil {
+func newBuilderLogger(plan *Plan, cfg *config.Config) *builderLogger {
+	if plan == nil || (cfg != nil && cfg.Persistence.Ephemeral) {
 		return nil
 	}
 
@@ -3,12 +3,14 @@ package orchestrator
 import (
 	"context"
 	"errors"
+	"os"
 	"strings"
 	"testing"
 
 	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/model"
 	orchmocks "m31labs.dev/buckley/pkg/orchestrator/mocks"
+	"m31labs.dev/buckley/pkg/paths"
 	"m31labs.dev/buckley/pkg/tool"
 	"m31labs.dev/buckley/pkg/tool/builtin"
 	"go.uber.org/mock/gomock"
@@ -501,3 +503,55 @@ func TestFileWithinPlannedScope(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilderExecuteToolCall_ConfirmsDangerousTools(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"run_tests"}
+	registry := tool.NewEmptyRegistry()
+	tool.ApplyToolPolicyConfig(registry, cfg)
+
+	testsTool := NewMockTool(ctrl)
+	testsTool.EXPECT().Name().Return("run_tests").AnyTimes()
+	testsTool.EXPECT().Description().Return("run tests").AnyTimes()
+	testsTool.EXPECT().Parameters().Return(builtin.ParameterSchema{}).AnyTimes()
+	testsTool.EXPECT().Execute(gomock.Any()).Return(&builtin.Result{Success: true}, nil).Times(1)
+	registry.Register(testsTool)
+
+	agent := NewBuilderAgent(&Plan{ID: "p1", FeatureName: "Feature"}, cfg, orchmocks.NewMockModelClient(ctrl), registry, nil)
+	call := model.ToolCall{ID: "call-1", Type: "function", Function: model.FunctionCall{Name: "run_tests", Arguments: `{}`}}
+	task := &Task{ID: "1", Title: "Task"}
+
+	if _, err := agent.executeToolCall(call, task); !errors.Is(err, tool.ErrToolNotApproved) {
+		t.Fatalf("unapproved call error = %v, want ErrToolNotApproved", err)
+	}
+
+	registry.SetApprover(func(context.Context, string, map[string]any) (bool, error) { return true, nil })
+	if _, err := agent.executeToolCall(call, task); err != nil {
+		t.Fatalf("approved call: %v", err)
+	}
+}
+
+func TestAgentLogsSkippedWhenEphemeral(t *testing.T) {
+	logDir := t.TempDir()
+	t.Setenv(paths.EnvBuckleyLogDir, logDir)
+	cfg := config.DefaultConfig()
+	cfg.Persistence.Ephemeral = true
+	plan := &Plan{ID: "ephemeral-plan", FeatureName: "Feature"}
+
+	client := orchmocks.NewMockModelClient(gomock.NewController(t))
+	builder := NewBuilderAgent(plan, cfg, client, tool.NewEmptyRegistry(), nil)
+	builder.logger.record(builderEvent{Type: builderEventStart, PlanID: plan.ID})
+	review := NewReviewAgent(plan, cfg, client, tool.NewEmptyRegistry(), nil)
+	review.logger.record(reviewEvent{PlanID: plan.ID})
+
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		t.Fatalf("read log dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("log dir has %d entries after an ephemeral run, want 0", len(entries))
+	}
+}
@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/cost"
+)
+
+// PlanCostEstimate is an approximate dollar range for running the tasks of a
+// plan that are not yet done.
+type PlanCostEstimate struct {
+	Model string
+	Tasks int
+	Low   float64
+	High  float64
+}
+
+// taskTokens is an assumed prompt/completion token count for one task.
+type taskTokens struct {
+	prompt     int
+	completion int
+}
+
+// taskTokenRanges holds the low and high token assumptions per task type.
+// They are rough averages of builder, review and retry traffic, not limits.
+var taskTokenRanges = map[TaskType][2]taskTokens{
+	TaskTypeImplementation: {{prompt: 30_000, completion: 4_000}, {prompt: 120_000, completion: 16_000}},
+	TaskTypeAnalysis:       {{prompt: 15_000, completion: 2_000}, {prompt: 60_000, completion: 8_000}},
+	TaskTypeValidation:     {{prompt: 10_000, completion: 1_000}, {prompt: 40_000, completion: 6_000}},
+}
+
+// EstimatePlanCost prices the pending tasks of plan on modelID using the
+// same calculator the cost tracker records spend with. Tasks that are
+// already completed or skipped are not counted.
+func EstimatePlanCost(plan *Plan, modelID string, calc cost.CostCalculator) (PlanCostEstimate, error) {
+	estimate := PlanCostEstimate{Model: strings.TrimSpace(modelID)}
+	if plan == nil {
+		return estimate, fmt.Errorf("plan is nil")
+	}
+	if calc == nil {
+		return estimate, fmt.Errorf("no cost calculator")
+	}
+	if estimate.Model == "" {
+		return estimate, fmt.Errorf("no execution model configured")
+	}
+
+	var low, high taskTokens
+	for _, task := range plan.Tasks {
+		if task.Status == TaskCompleted || task.Status == TaskSkipped {
+			continue
+		}
+		ranges, ok := taskTokenRanges[task.Type]
+		if !ok {
+			ranges = taskTokenRanges[TaskTypeImplementation]
+		}
+		low.prompt += ranges[0].prompt
+		low.completion += ranges[0].completion
+		high.prompt += ranges[1].prompt
+		high.completion += ranges[1].completion
+		estimate.Tasks++
+	}
+	if estimate.Tasks == 0 {
+		return estimate, nil
+	}
+
+	var err error
+	if estimate.Low, err = calc.CalculateCostFromTokens(estimate.Model, low.prompt, low.completion); err != nil {
+		return estimate, fmt.Errorf("price %s: %w", estimate.Model, err)
+	}
+	if estimate.High, err = calc.CalculateCostFromTokens(estimate.Model, high.prompt, high.completion); err != nil {
+		return estimate, fmt.Errorf("price %s: %w", estimate.Model, err)
+	}
+	return estimate, nil
+}
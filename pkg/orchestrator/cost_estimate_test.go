@@ -0,0 +1,59 @@
+package orchestrator
+
+import (
+	"errors"
+	"testing"
+)
+
+type perMillionCalculator struct {
+	prompt, completion float64
+	err                error
+}
+
+func (c perMillionCalculator) CalculateCostFromTokens(_ string, promptTokens, completionTokens int) (float64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	return float64(promptTokens)/1_000_000*c.prompt + float64(completionTokens)/1_000_000*c.completion, nil
+}
+
+func TestEstimatePlanCostSkipsFinishedTasks(t *testing.T) {
+	plan := &Plan{Tasks: []Task{
+		{ID: "1", Type: TaskTypeImplementation, Status: TaskCompleted},
+		{ID: "2", Type: TaskTypeImplementation},
+		{ID: "3", Type: TaskTypeValidation, Status: TaskFailed},
+		{ID: "4", Type: TaskTypeAnalysis, Status: TaskSkipped},
+	}}
+
+	got, err := EstimatePlanCost(plan, "p1/model-a", perMillionCalculator{prompt: 1, completion: 10})
+	if err != nil {
+		t.Fatalf("EstimatePlanCost: %v", err)
+	}
+	if got.Tasks != 2 || got.Model != "p1/model-a" {
+		t.Fatalf("estimate = %+v", got)
+	}
+	// low: 40k prompt + 5k completion, high: 160k prompt + 22k completion
+	if wantLow, wantHigh := 0.09, 0.38; !closeTo(got.Low, wantLow) || !closeTo(got.High, wantHigh) {
+		t.Fatalf("range = %.4f-%.4f, want %.2f-%.2f", got.Low, got.High, wantLow, wantHigh)
+	}
+}
+
+func TestEstimatePlanCostErrors(t *testing.T) {
+	plan := &Plan{Tasks: []Task{{ID: "1", Type: TaskTypeImplementation}}}
+	if _, err := EstimatePlanCost(plan, "", perMillionCalculator{}); err == nil {
+		t.Fatal("expected error without a model")
+	}
+	if _, err := EstimatePlanCost(plan, "m", perMillionCalculator{err: errors.New("no pricing")}); err == nil {
+		t.Fatal("expected pricing error to be returned")
+	}
+	done := &Plan{Tasks: []Task{{ID: "1", Status: TaskCompleted}}}
+	got, err := EstimatePlanCost(done, "m", perMillionCalculator{err: errors.New("unused")})
+	if err != nil || got.Tasks != 0 || got.High != 0 {
+		t.Fatalf("finished plan estimate = %+v, %v", got, err)
+	}
+}
+
+func closeTo(a, b float64) bool {
+	d := a - b
+	return d < 1e-9 && d > -1e-9
+}
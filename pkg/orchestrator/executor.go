@@ -55,12 +55,15 @@ type Executor struct {
 	issuesCodec      *toon.Codec
 	engine           *rules.Engine
 	resolver         *model.Resolver
+	budget           BudgetChecker
 
 	maxRetries      int
 	maxReviewCycles int
 	retryCount      int
 	retryContext    *RetryContext
 	taskPhases      []TaskPhase
+
+	onTaskEvent func(TaskEvent)
 }
 
 // resolveExecutionModel returns the model ID for the execution phase.
@@ -186,11 +189,15 @@ func (e *Executor) Execute() error {
 			return err
 		}
 
-		// Skip if already completed
-		if task.Status == TaskCompleted {
+		// Skip if already completed or explicitly skipped
+		if task.Status == TaskCompleted || task.Status == TaskSkipped {
 			continue
 		}
 
+		if err := e.checkBudget(); err != nil {
+			return err
+		}
+
 		// Check dependencies
 		if !e.dependenciesMet(task) {
 			return fmt.Errorf("task %s has unmet dependencies", task.ID)
@@ -198,11 +205,14 @@ func (e *Executor) Execute() error {
 
 		// Execute task
 		e.currentTask = task
+		e.notifyTask(task, TaskEventStart, nil)
 		if err := e.executeTask(task); err != nil {
 			task.Status = TaskFailed
 			e.planner.UpdatePlan(e.plan)
+			e.notifyTask(task, TaskEventFail, err)
 			return fmt.Errorf("task %s failed: %w", task.ID, err)
 		}
+		e.notifyTask(task, TaskEventSuccess, nil)
 
 		// Save progress
 		if err := e.saveProgress(); err != nil {
@@ -887,10 +897,10 @@ func (e *Executor) recordReviewArtifact(result *ReviewResult, iteration int) {
 
 func (e *Executor) dependenciesMet(task *Task) bool {
 	for _, depID := range task.Dependencies {
-		// Find dependency task
+		// Find dependency task; a skipped dependency no longer blocks its dependents
 		depMet := false
 		for _, t := range e.plan.Tasks {
-			if t.ID == depID && t.Status == TaskCompleted {
+			if t.ID == depID && (t.Status == TaskCompleted || t.Status == TaskSkipped) {
 				depMet = true
 				break
 			}
@@ -902,6 +912,56 @@ func (e *Executor) dependenciesMet(task *Task) bool {
 	return true
 }
 
+// SkipPlanTask marks a task as skipped so execution moves past it. Tasks that
+// depend on it are treated as unblocked the next time dependencies are checked.
+func SkipPlanTask(plan *Plan, taskID string) (*Task, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("no plan loaded")
+	}
+	taskID = strings.TrimSpace(taskID)
+	for i := range plan.Tasks {
+		task := &plan.Tasks[i]
+		if task.ID != taskID {
+			continue
+		}
+		switch task.Status {
+		case TaskCompleted:
+			return nil, fmt.Errorf("task %s already completed", taskID)
+		case TaskInProgress:
+			return nil, fmt.Errorf("task %s is in progress", taskID)
+		}
+		task.Status = TaskSkipped
+		return task, nil
+	}
+	return nil, fmt.Errorf("task %s not found", taskID)
+}
+
+// PrepareResume readies a partly executed plan to continue. Tasks left in
+// progress by an interrupted run are reset to pending so they are retried. It
+// returns the first task that still needs to run (nil when every task is done)
+// and how many tasks are already completed or skipped.
+func PrepareResume(plan *Plan) (*Task, int) {
+	if plan == nil {
+		return nil, 0
+	}
+	var next *Task
+	done := 0
+	for i := range plan.Tasks {
+		task := &plan.Tasks[i]
+		switch task.Status {
+		case TaskCompleted, TaskSkipped:
+			done++
+			continue
+		case TaskInProgress:
+			task.Status = TaskPending
+		}
+		if next == nil {
+			next = task
+		}
+	}
+	return next, done
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
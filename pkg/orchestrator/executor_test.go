@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	orchestratorMocks "m31labs.dev/buckley/pkg/orchestrator/mocks"
 
 	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/cost"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/storage"
 	"m31labs.dev/buckley/pkg/tool"
@@ -204,6 +206,111 @@ func TestExecutor_DependenciesMet(t *testing.T) {
 	}
 }
 
+func TestSkipPlanTask_UnblocksDependents(t *testing.T) {
+	plan := &Plan{
+		ID: "test-plan",
+		Tasks: []Task{
+			{ID: "1", Title: "Task 1", Status: TaskCompleted},
+			{ID: "2", Title: "Task 2", Dependencies: []string{"1"}},
+			{ID: "3", Title: "Task 3", Dependencies: []string{"2"}},
+		},
+	}
+	executor := &Executor{plan: plan}
+
+	if executor.dependenciesMet(&plan.Tasks[2]) {
+		t.Fatal("Expected task 3 to be blocked before task 2 is skipped")
+	}
+
+	task, err := SkipPlanTask(plan, "2")
+	if err != nil {
+		t.Fatalf("SkipPlanTask() error = %v", err)
+	}
+	if task.Status != TaskSkipped || plan.Tasks[1].Status != TaskSkipped {
+		t.Errorf("Expected task 2 to be skipped, got %v", plan.Tasks[1].Status)
+	}
+	if !executor.dependenciesMet(&plan.Tasks[2]) {
+		t.Error("Expected skipped dependency to unblock task 3")
+	}
+}
+
+func TestSkipPlanTask_Errors(t *testing.T) {
+	plan := &Plan{
+		ID: "test-plan",
+		Tasks: []Task{
+			{ID: "1", Title: "Task 1", Status: TaskCompleted},
+			{ID: "2", Title: "Task 2", Status: TaskInProgress},
+		},
+	}
+
+	if _, err := SkipPlanTask(nil, "1"); err == nil {
+		t.Error("Expected error for nil plan")
+	}
+	if _, err := SkipPlanTask(plan, "missing"); err == nil || !contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+	if _, err := SkipPlanTask(plan, "1"); err == nil || !contains(err.Error(), "already completed") {
+		t.Errorf("Expected already completed error, got: %v", err)
+	}
+	if _, err := SkipPlanTask(plan, "2"); err == nil || !contains(err.Error(), "in progress") {
+		t.Errorf("Expected in progress error, got: %v", err)
+	}
+}
+
+func TestPrepareResume(t *testing.T) {
+	plan := &Plan{
+		ID: "test-plan",
+		Tasks: []Task{
+			{ID: "1", Title: "Task 1", Status: TaskCompleted},
+			{ID: "2", Title: "Task 2", Status: TaskSkipped},
+			{ID: "3", Title: "Task 3", Status: TaskFailed, Dependencies: []string{"1"}},
+			{ID: "4", Title: "Task 4", Status: TaskInProgress},
+			{ID: "5", Title: "Task 5"},
+		},
+	}
+
+	next, done := PrepareResume(plan)
+	if next == nil || next.ID != "3" {
+		t.Fatalf("Expected to resume at failed task 3, got %+v", next)
+	}
+	if done != 2 {
+		t.Errorf("Expected 2 tasks done, got %d", done)
+	}
+	if plan.Tasks[2].Status != TaskFailed {
+		t.Errorf("Expected failed task to keep its status until retried, got %v", plan.Tasks[2].Status)
+	}
+	if plan.Tasks[3].Status != TaskPending {
+		t.Errorf("Expected interrupted task to be reset to pending, got %v", plan.Tasks[3].Status)
+	}
+
+	fresh := &Plan{Tasks: []Task{{ID: "1"}, {ID: "2"}}}
+	if next, done := PrepareResume(fresh); next == nil || next.ID != "1" || done != 0 {
+		t.Errorf("Expected fresh plan to start at task 1, got %+v done=%d", next, done)
+	}
+
+	finished := &Plan{Tasks: []Task{{ID: "1", Status: TaskCompleted}, {ID: "2", Status: TaskSkipped}}}
+	if next, done := PrepareResume(finished); next != nil || done != 2 {
+		t.Errorf("Expected nothing to resume, got %+v done=%d", next, done)
+	}
+	if next, done := PrepareResume(nil); next != nil || done != 0 {
+		t.Errorf("Expected nil plan to have nothing to resume, got %+v done=%d", next, done)
+	}
+}
+
+func TestOrchestratorResumeExecution_NothingLeft(t *testing.T) {
+	o := &Orchestrator{}
+	if err := o.ResumeExecution(); err == nil || !contains(err.Error(), "no plan loaded") {
+		t.Errorf("Expected no plan error, got: %v", err)
+	}
+
+	o.currentPlan = &Plan{Tasks: []Task{{ID: "1", Status: TaskCompleted}}}
+	if err := o.ResumeExecution(); err != nil {
+		t.Fatalf("ResumeExecution() error = %v", err)
+	}
+	if o.executor != nil {
+		t.Error("Expected a finished plan not to start an executor")
+	}
+}
+
 func TestExecutor_PersistExecutionContext(t *testing.T) {
 	ctrl, mockModel := setupMockModel(t)
 	defer ctrl.Finish()
@@ -530,6 +637,34 @@ func TestExecutor_Execute_SkipsCompletedTasks(t *testing.T) {
 	}
 }
 
+func TestExecutor_Execute_SkipsSkippedTasks(t *testing.T) {
+	ctrl, mockModel := setupMockModel(t)
+	defer ctrl.Finish()
+
+	plan := &Plan{
+		ID:          "test-plan",
+		FeatureName: "Test Feature",
+		Description: "Test Plan",
+		Tasks: []Task{
+			{ID: "1", Title: "Task 1", Status: TaskCompleted},
+			{ID: "2", Title: "Task 2", Status: TaskSkipped, Dependencies: []string{"1"}},
+		},
+	}
+
+	store := &storage.Store{}
+	registry := tool.NewRegistry()
+	cfg := &config.Config{}
+	planner := &Planner{}
+	executor := NewExecutor(plan, store, mockModel, registry, cfg, planner, nil, nil)
+
+	if err := executor.Execute(); err != nil {
+		t.Errorf("Execute() should move past skipped tasks, got: %v", err)
+	}
+	if plan.Tasks[1].Status != TaskSkipped {
+		t.Errorf("Expected skipped task to remain skipped, got %v", plan.Tasks[1].Status)
+	}
+}
+
 func TestExecutor_Execute_UnmetDependencies(t *testing.T) {
 	ctrl, mockModel := setupMockModel(t)
 	defer ctrl.Finish()
@@ -681,3 +816,89 @@ func TestExecutor_emitTaskEvent(t *testing.T) {
 	// With nil emitter is safe
 	executor.emitTaskEvent(task, "completed")
 }
+
+func TestExecutor_Execute_ReportsTaskEvents(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldWd)
+	os.WriteFile("go.mod", []byte("module test\n\ngo 1.21"), 0644)
+
+	ctrl, mockModel := setupMockModel(t)
+	defer ctrl.Finish()
+	mockModel.EXPECT().ChatCompletion(gomock.Any(), gomock.Any()).Times(0)
+
+	plan := &Plan{
+		ID:          "test-plan",
+		FeatureName: "Test Feature",
+		Description: "Test Plan",
+		Tasks: []Task{
+			{ID: "1", Title: "Task 1", Status: TaskCompleted},
+			{ID: "2", Title: "Task 2", Dependencies: []string{"1"}, Files: []string{"/nonexistent/deep/nested/path/test.txt"}},
+		},
+	}
+	cfg := &config.Config{
+		Orchestrator: config.OrchestratorConfig{
+			MaxSelfHealAttempts: 3,
+			MaxReviewCycles:     2,
+			TrustLevel:          "autonomous",
+		},
+	}
+	executor := NewExecutor(plan, &storage.Store{}, mockModel, tool.NewRegistry(), cfg, &Planner{}, nil, nil)
+
+	var events []TaskEvent
+	executor.SetTaskEventHandler(func(event TaskEvent) {
+		events = append(events, event)
+		if event.Phase == TaskEventStart {
+			panic("callback bug")
+		}
+	})
+
+	err := executor.Execute()
+	if err == nil || !contains(err.Error(), "task 2 failed") {
+		t.Fatalf("Expected task 2 to fail, got: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected start and fail events for task 2, got %+v", events)
+	}
+	if events[0].TaskID != "2" || events[0].Phase != TaskEventStart || events[0].Title != "Task 2" {
+		t.Errorf("Unexpected start event: %+v", events[0])
+	}
+	if events[1].TaskID != "2" || events[1].Phase != TaskEventFail || events[1].Err == nil {
+		t.Errorf("Unexpected fail event: %+v", events[1])
+	}
+}
+
+type staticBudget cost.BudgetStatus
+
+func (b staticBudget) CheckBudget() *cost.BudgetStatus {
+	status := cost.BudgetStatus(b)
+	return &status
+}
+
+func TestExecutor_Execute_HardStopOnBudget(t *testing.T) {
+	ctrl, mockModel := setupMockModel(t)
+	defer ctrl.Finish()
+
+	plan := &Plan{
+		ID:          "test-plan",
+		FeatureName: "Test Feature",
+		Tasks: []Task{
+			{ID: "1", Title: "Task 1", Status: TaskCompleted},
+			{ID: "2", Title: "Task 2", Dependencies: []string{"1"}},
+		},
+	}
+
+	cfg := &config.Config{}
+	cfg.CostManagement.HardStop = true
+	executor := NewExecutor(plan, &storage.Store{}, mockModel, tool.NewRegistry(), cfg, &Planner{}, nil, nil)
+	executor.SetBudgetChecker(staticBudget{DailyCost: 21, DailyBudget: 20, DailyExceeded: true, ShouldWarn: true})
+
+	err := executor.Execute()
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Execute() error = %v, want ErrBudgetExceeded", err)
+	}
+	if plan.Tasks[1].Status != TaskPending {
+		t.Fatalf("task 2 status = %v, want pending", plan.Tasks[1].Status)
+	}
+}
@@ -0,0 +1,23 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/paths"
+)
+
+// TestMain points plan, builder, review and research logs at a temporary
+// directory so tests do not write .buckley/logs into the package directory.
+func TestMain(m *testing.M) {
+	logDir, err := os.MkdirTemp("", "buckley-orchestrator-logs-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "create log dir:", err)
+		os.Exit(1)
+	}
+	os.Setenv(paths.EnvBuckleyLogDir, logDir)
+	code := m.Run()
+	os.RemoveAll(logDir)
+	os.Exit(code)
+}
@@ -30,6 +30,7 @@ type Orchestrator struct {
 	engine           *rules.Engine
 	gtsPipeline      *gts.Pipeline
 	resolver         *model.Resolver
+	budget           BudgetChecker
 
 	currentPlan *Plan
 	executor    *Executor
@@ -44,6 +45,21 @@ func (o *Orchestrator) GetWorkflow() *WorkflowManager {
 	return o.workflow
 }
 
+// ExecutionModel returns the model plan execution will use, after routing
+// rules and configured overrides are applied.
+func (o *Orchestrator) ExecutionModel() string {
+	if o == nil {
+		return ""
+	}
+	if o.resolver != nil {
+		return o.resolver.Resolve("execution")
+	}
+	if o.config != nil {
+		return o.config.Models.Execution
+	}
+	return ""
+}
+
 // GTSPipeline returns the GTS context pipeline, if configured.
 func (o *Orchestrator) GTSPipeline() *gts.Pipeline {
 	if o == nil {
@@ -242,12 +258,21 @@ func (o *Orchestrator) ListPlans() ([]Plan, error) {
 
 // ExecutePlan executes the current plan
 func (o *Orchestrator) ExecutePlan() error {
+	return o.ExecutePlanWithProgress(context.Background(), nil)
+}
+
+// ExecutePlanWithProgress executes the current plan under ctx and reports each
+// task's start and finish to onEvent. onEvent runs synchronously in task
+// order; a panic in it is recovered and does not abort execution.
+func (o *Orchestrator) ExecutePlanWithProgress(ctx context.Context, onEvent func(TaskEvent)) error {
 	if o.currentPlan == nil {
 		return fmt.Errorf("no plan loaded")
 	}
 
 	// Create executor with cancelable context
-	ctx := context.Background()
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if o.cancelPlan != nil {
 		o.cancelPlan() // cancel any previous run
 	}
@@ -256,6 +281,8 @@ func (o *Orchestrator) ExecutePlan() error {
 	o.executor = NewExecutor(o.currentPlan, o.store, o.modelClient, o.toolRegistry, o.config, o.planner, o.workflow, o.batchCoordinator, o.engine)
 	o.executor.SetContext(ctx)
 	o.executor.SetResolver(o.resolver)
+	o.executor.SetTaskEventHandler(onEvent)
+	o.executor.SetBudgetChecker(o.budget)
 	if o.gtsPipeline != nil && o.executor.builder != nil {
 		o.executor.builder.SetEnricher(o.enrichWithGTS)
 	}
@@ -268,6 +295,32 @@ func (o *Orchestrator) ExecutePlan() error {
 	return nil
 }
 
+// ResumeExecution continues the current plan from its first incomplete task.
+// Completed and skipped tasks are not run again; failed tasks and tasks an
+// interrupted run left in progress are retried. A plan with no progress runs
+// from the start, as with ExecutePlan.
+func (o *Orchestrator) ResumeExecution() error {
+	if o.currentPlan == nil {
+		return fmt.Errorf("no plan loaded")
+	}
+	next, done := PrepareResume(o.currentPlan)
+	if next == nil {
+		if o.workflow != nil {
+			o.workflow.SendProgress("✅ All plan tasks are already complete")
+		}
+		return nil
+	}
+	if done > 0 {
+		if err := o.planner.UpdatePlan(o.currentPlan); err != nil {
+			return fmt.Errorf("failed to save plan: %w", err)
+		}
+		if o.workflow != nil {
+			o.workflow.SendProgress(fmt.Sprintf("⏩ Resuming at task %s: %s (%d/%d done)", next.ID, next.Title, done, len(o.currentPlan.Tasks)))
+		}
+	}
+	return o.ExecutePlan()
+}
+
 // ExecuteTask executes a single task
 func (o *Orchestrator) ExecuteTask(taskID string) error {
 	if o.currentPlan == nil {
@@ -307,6 +360,25 @@ func (o *Orchestrator) ExecuteTask(taskID string) error {
 	return o.executor.executeTask(task)
 }
 
+// SkipTask marks a task in the current plan as skipped and persists the plan.
+func (o *Orchestrator) SkipTask(taskID string) error {
+	if o.currentPlan == nil {
+		return fmt.Errorf("no plan loaded")
+	}
+	task, err := SkipPlanTask(o.currentPlan, taskID)
+	if err != nil {
+		return err
+	}
+	if err := o.planner.UpdatePlan(o.currentPlan); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+	if o.workflow != nil {
+		o.workflow.SendProgress(fmt.Sprintf("⏭️ Skipped task %s: %s", task.ID, task.Title))
+		o.workflow.EmitPlanSnapshot(o.currentPlan, telemetry.EventPlanUpdated)
+	}
+	return nil
+}
+
 // GenerateCommit generates a commit for a task
 func (o *Orchestrator) GenerateCommit(taskID string) (*CommitInfo, error) {
 	if o.currentPlan == nil {
@@ -0,0 +1,209 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kinds of problems reported by ValidatePlanGraph.
+const (
+	PlanIssueDuplicateTask     = "duplicate_task"
+	PlanIssueMissingDependency = "missing_dependency"
+	PlanIssueCycle             = "cycle"
+	PlanIssueOutOfOrder        = "out_of_order"
+	PlanIssueBlocked           = "blocked"
+)
+
+// PlanIssue is one problem in a plan's task dependency graph.
+type PlanIssue struct {
+	Kind    string   `json:"kind"`
+	TaskIDs []string `json:"task_ids"`
+	Message string   `json:"message"`
+}
+
+// ValidatePlanGraph checks the task dependency graph of plan for problems
+// that would otherwise only surface during execution: duplicate task IDs,
+// dependencies on tasks that do not exist, dependency cycles, dependencies on
+// tasks listed later (the executor runs tasks in plan order), and tasks that
+// can never run because something they depend on is broken. Task status is
+// ignored; only the graph is checked.
+func ValidatePlanGraph(plan *Plan) []PlanIssue {
+	if plan == nil {
+		return nil
+	}
+	var issues []PlanIssue
+
+	position := make(map[string]int, len(plan.Tasks))
+	for i, task := range plan.Tasks {
+		if _, ok := position[task.ID]; ok {
+			issues = append(issues, PlanIssue{
+				Kind:    PlanIssueDuplicateTask,
+				TaskIDs: []string{task.ID},
+				Message: fmt.Sprintf("task ID %q is used by more than one task", task.ID),
+			})
+			continue
+		}
+		position[task.ID] = i
+	}
+
+	// broken holds tasks that cannot run on their own account; blocked
+	// tasks are reported separately once it is known what they wait on.
+	broken := make(map[string]bool)
+	inCycle := make(map[string]bool)
+	var cycleIssues []PlanIssue
+	for _, cycle := range planCycles(plan, position) {
+		for _, id := range cycle {
+			broken[id] = true
+			inCycle[id] = true
+		}
+		path := append(append([]string{}, cycle...), cycle[0])
+		cycleIssues = append(cycleIssues, PlanIssue{
+			Kind:    PlanIssueCycle,
+			TaskIDs: cycle,
+			Message: "dependency cycle: " + strings.Join(path, " -> "),
+		})
+	}
+
+	for i, task := range plan.Tasks {
+		for _, dep := range task.Dependencies {
+			depPos, ok := position[dep]
+			switch {
+			case !ok:
+				broken[task.ID] = true
+				issues = append(issues, PlanIssue{
+					Kind:    PlanIssueMissingDependency,
+					TaskIDs: []string{task.ID, dep},
+					Message: fmt.Sprintf("task %s depends on %s, which is not in the plan", task.ID, dep),
+				})
+			case depPos > i && !(inCycle[task.ID] && inCycle[dep]):
+				issues = append(issues, PlanIssue{
+					Kind:    PlanIssueOutOfOrder,
+					TaskIDs: []string{task.ID, dep},
+					Message: fmt.Sprintf("task %s depends on %s, which is listed after it", task.ID, dep),
+				})
+			}
+		}
+	}
+
+	issues = append(issues, cycleIssues...)
+
+	// A task can run once every dependency can; repeat until nothing changes
+	// so blocking propagates down chains of dependents.
+	runnable := make(map[string]bool, len(plan.Tasks))
+	for changed := true; changed; {
+		changed = false
+		for _, task := range plan.Tasks {
+			if runnable[task.ID] || broken[task.ID] {
+				continue
+			}
+			ready := true
+			for _, dep := range task.Dependencies {
+				if !runnable[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				runnable[task.ID] = true
+				changed = true
+			}
+		}
+	}
+	for _, task := range plan.Tasks {
+		if runnable[task.ID] || broken[task.ID] {
+			continue
+		}
+		var waiting []string
+		for _, dep := range task.Dependencies {
+			if !runnable[dep] {
+				waiting = append(waiting, dep)
+			}
+		}
+		issues = append(issues, PlanIssue{
+			Kind:    PlanIssueBlocked,
+			TaskIDs: append([]string{task.ID}, waiting...),
+			Message: fmt.Sprintf("task %s can never run: it waits on %s", task.ID, strings.Join(waiting, ", ")),
+		})
+		// Mark it so a task later in the list does not report it twice.
+		broken[task.ID] = true
+	}
+
+	return issues
+}
+
+// planCycles returns each dependency cycle once, starting from the task
+// listed first in the plan.
+func planCycles(plan *Plan, position map[string]int) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(plan.Tasks))
+	deps := make(map[string][]string, len(plan.Tasks))
+	for _, task := range plan.Tasks {
+		if _, ok := deps[task.ID]; !ok {
+			deps[task.ID] = task.Dependencies
+		}
+	}
+
+	var (
+		cycles [][]string
+		seen   = make(map[string]bool)
+		stack  []string
+		visit  func(id string)
+	)
+	visit = func(id string) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, dep := range deps[id] {
+			if _, ok := position[dep]; !ok {
+				continue
+			}
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				start := len(stack) - 1
+				for stack[start] != dep {
+					start--
+				}
+				cycle := rotateToFirst(stack[start:], position)
+				key := cycleKey(cycle)
+				if !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+	}
+	for _, task := range plan.Tasks {
+		if state[task.ID] == unvisited {
+			visit(task.ID)
+		}
+	}
+	return cycles
+}
+
+// rotateToFirst copies cycle so it starts at the task listed first in the
+// plan, keeping the dependency direction.
+func rotateToFirst(cycle []string, position map[string]int) []string {
+	first := 0
+	for i, id := range cycle {
+		if position[id] < position[cycle[first]] {
+			first = i
+		}
+	}
+	out := make([]string, 0, len(cycle))
+	out = append(out, cycle[first:]...)
+	return append(out, cycle[:first]...)
+}
+
+func cycleKey(cycle []string) string {
+	ids := append([]string{}, cycle...)
+	sort.Strings(ids)
+	return strings.Join(ids, "\x00")
+}
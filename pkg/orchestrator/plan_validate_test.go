@@ -0,0 +1,56 @@
+package orchestrator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidatePlanGraph_ValidPlan(t *testing.T) {
+	plan := &Plan{Tasks: []Task{
+		{ID: "1"},
+		{ID: "2", Dependencies: []string{"1"}},
+		{ID: "3", Dependencies: []string{"1", "2"}},
+	}}
+	if issues := ValidatePlanGraph(plan); len(issues) != 0 {
+		t.Fatalf("Expected no issues, got %+v", issues)
+	}
+	if issues := ValidatePlanGraph(nil); issues != nil {
+		t.Fatalf("Expected no issues for nil plan, got %+v", issues)
+	}
+}
+
+func TestValidatePlanGraph_ReportsProblems(t *testing.T) {
+	plan := &Plan{Tasks: []Task{
+		{ID: "1"},
+		{ID: "2", Dependencies: []string{"9"}},
+		{ID: "3", Dependencies: []string{"4"}},
+		{ID: "4", Dependencies: []string{"5"}},
+		{ID: "5", Dependencies: []string{"3"}},
+		{ID: "6", Dependencies: []string{"2"}},
+		{ID: "7", Dependencies: []string{"6"}},
+		{ID: "8", Dependencies: []string{"10"}},
+		{ID: "10"},
+		{ID: "1"},
+	}}
+
+	got := ValidatePlanGraph(plan)
+	want := []PlanIssue{
+		{Kind: PlanIssueDuplicateTask, TaskIDs: []string{"1"}, Message: `task ID "1" is used by more than one task`},
+		{Kind: PlanIssueMissingDependency, TaskIDs: []string{"2", "9"}, Message: "task 2 depends on 9, which is not in the plan"},
+		{Kind: PlanIssueOutOfOrder, TaskIDs: []string{"8", "10"}, Message: "task 8 depends on 10, which is listed after it"},
+		{Kind: PlanIssueCycle, TaskIDs: []string{"3", "4", "5"}, Message: "dependency cycle: 3 -> 4 -> 5 -> 3"},
+		{Kind: PlanIssueBlocked, TaskIDs: []string{"6", "2"}, Message: "task 6 can never run: it waits on 2"},
+		{Kind: PlanIssueBlocked, TaskIDs: []string{"7", "6"}, Message: "task 7 can never run: it waits on 6"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ValidatePlanGraph() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestValidatePlanGraph_SelfDependency(t *testing.T) {
+	plan := &Plan{Tasks: []Task{{ID: "1", Dependencies: []string{"1"}}}}
+	got := ValidatePlanGraph(plan)
+	if len(got) != 1 || got[0].Kind != PlanIssueCycle || got[0].Message != "dependency cycle: 1 -> 1" {
+		t.Fatalf("Expected a single self cycle, got %+v", got)
+	}
+}
@@ -315,7 +315,7 @@ func (r *ResearchAgent) logEvent(feature string, eventType researchEventType, de
 }
 
 func (r *ResearchAgent) loggerFor(feature string) *researchLogger {
-	if r == nil {
+	if r == nil || r.store.Ephemeral() {
 		return nil
 	}
 	if r.loggers == nil {
@@ -170,7 +170,7 @@ func NewReviewAgent(plan *Plan, cfg *config.Config, client ModelClient, registry
 		toolRegistry:    registry,
 		workflow:        workflow,
 		reviewGen:       artifact.NewReviewGenerator(outputDir),
-		logger:          newReviewLogger(plan),
+		logger:          newReviewLogger(plan, cfg),
 		schemaBlock:     reviewSchemaBlock(useToon),
 		personaProvider: personaProvider,
 		engine:          eng,
@@ -739,8 +739,8 @@ type reviewLogger struct {
 	mu   sync.Mutex
 }
 
-func newReviewLogger(plan *Plan) *reviewLogger {
-	if plan == nil {
+func newReviewLogger(plan *Plan, cfg *config.Config) *reviewLogger {
+	if plan == nil || (cfg != nil && cfg.Persistence.Ephemeral) {
 		return nil
 	}
 
@@ -0,0 +1,42 @@
+package orchestrator
+
+// TaskEventPhase marks where a task is in its execution.
+type TaskEventPhase string
+
+const (
+	TaskEventStart   TaskEventPhase = "start"
+	TaskEventSuccess TaskEventPhase = "success"
+	TaskEventFail    TaskEventPhase = "fail"
+)
+
+// TaskEvent reports a task starting or finishing during plan execution. Err
+// is set for TaskEventFail.
+type TaskEvent struct {
+	TaskID string
+	Title  string
+	Phase  TaskEventPhase
+	Err    error
+}
+
+// SetTaskEventHandler registers fn to receive task events from Execute. It is
+// called synchronously, in task order, on the executing goroutine.
+func (e *Executor) SetTaskEventHandler(fn func(TaskEvent)) {
+	if e == nil {
+		return
+	}
+	e.onTaskEvent = fn
+}
+
+// notifyTask delivers an event to the handler. A panicking handler is reported
+// through workflow progress and does not stop execution.
+func (e *Executor) notifyTask(task *Task, phase TaskEventPhase, err error) {
+	if e == nil || e.onTaskEvent == nil || task == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			e.sendProgress("⚠️ Task progress callback panicked on task %s: %v", task.ID, r)
+		}
+	}()
+	e.onTaskEvent(TaskEvent{TaskID: task.ID, Title: task.Title, Phase: phase, Err: err})
+}
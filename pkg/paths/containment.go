@@ -0,0 +1,56 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutsideRoot matches every EscapeError via errors.Is.
+var ErrOutsideRoot = errors.New("path outside root")
+
+// EscapeError reports a path that resolves outside the directory it must stay
+// under, such as a tool workdir or the project root.
+type EscapeError struct {
+	// Path is the path as supplied by the caller.
+	Path string
+	// Root is the absolute directory the path had to stay within.
+	Root string
+	// Scope names the root in messages, e.g. "workdir" or "project root".
+	Scope string
+	// Symlink is set when the lexical path was inside Root but a symlink
+	// pointed it elsewhere.
+	Symlink bool
+}
+
+func (e *EscapeError) Error() string {
+	scope := strings.TrimSpace(e.Scope)
+	if scope == "" {
+		scope = "root"
+	}
+	msg := fmt.Sprintf("path %q escapes %s %s", e.Path, scope, e.Root)
+	if e.Symlink {
+		msg += " via symlink"
+	}
+	return msg
+}
+
+func (e *EscapeError) Unwrap() error {
+	return ErrOutsideRoot
+}
+
+// Within reports whether target is root or lies beneath it, comparing cleaned
+// paths lexically. Callers that care about symlinks must resolve them first.
+func Within(root, target string) bool {
+	root = strings.TrimSpace(root)
+	target = strings.TrimSpace(target)
+	if root == "" || target == "" {
+		return false
+	}
+	rel, err := filepath.Rel(filepath.Clean(root), filepath.Clean(target))
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
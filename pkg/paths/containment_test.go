@@ -0,0 +1,49 @@
+package paths
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithin(t *testing.T) {
+	root := filepath.FromSlash("/work/project")
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"/work/project", true},
+		{"/work/project/pkg/main.go", true},
+		{"/work/project/pkg/../main.go", true},
+		{"/work/project/../other", false},
+		{"/work/project-other/file", false},
+		{"/etc/passwd", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := Within(root, filepath.FromSlash(tt.target)); got != tt.want {
+			t.Errorf("Within(%q, %q) = %v, want %v", root, tt.target, got, tt.want)
+		}
+	}
+	if Within("", root) {
+		t.Error("empty root should contain nothing")
+	}
+}
+
+func TestEscapeErrorMatchesErrOutsideRoot(t *testing.T) {
+	err := error(&EscapeError{Path: "../secret", Root: "/work/project", Scope: "workdir", Symlink: true})
+	if !errors.Is(err, ErrOutsideRoot) {
+		t.Fatalf("errors.Is(%v, ErrOutsideRoot) = false", err)
+	}
+	var escape *EscapeError
+	if !errors.As(err, &escape) || escape.Path != "../secret" {
+		t.Fatalf("errors.As did not recover the EscapeError: %v", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{`"../secret"`, "escapes workdir /work/project", "via symlink"} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("message %q missing %q", msg, want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	EnvBuckleyEmbeddingsCacheDir = "BUCKLEY_EMBEDDINGS_CACHE_DIR"
+	envBuckleyDataDir            = "BUCKLEY_DATA_DIR"
+)
+
+// BuckleyEmbeddingsCacheDir is where embedding services cache vectors on
+// disk: BUCKLEY_EMBEDDINGS_CACHE_DIR, then BUCKLEY_DATA_DIR/embeddings, then
+// ~/.buckley/embeddings.
+func BuckleyEmbeddingsCacheDir() string {
+	if dir := strings.TrimSpace(os.Getenv(EnvBuckleyEmbeddingsCacheDir)); dir != "" {
+		return filepath.Clean(expandHomePath(dir))
+	}
+	if dir := strings.TrimSpace(os.Getenv(envBuckleyDataDir)); dir != "" {
+		return filepath.Join(expandHomePath(dir), "embeddings")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		return filepath.Join(".buckley", "embeddings")
+	}
+	return filepath.Join(home, ".buckley", "embeddings")
+}
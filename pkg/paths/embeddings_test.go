@@ -0,0 +1,24 @@
+package paths
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBuckleyEmbeddingsCacheDirPrefersOverrideThenDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(EnvBuckleyEmbeddingsCacheDir, "")
+	t.Setenv(envBuckleyDataDir, "")
+	if got, want := BuckleyEmbeddingsCacheDir(), filepath.Join(home, ".buckley", "embeddings"); got != want {
+		t.Fatalf("default = %q, want %q", got, want)
+	}
+	t.Setenv(envBuckleyDataDir, "~/data")
+	if got, want := BuckleyEmbeddingsCacheDir(), filepath.Join(home, "data", "embeddings"); got != want {
+		t.Fatalf("data dir = %q, want %q", got, want)
+	}
+	t.Setenv(EnvBuckleyEmbeddingsCacheDir, "~/emb")
+	if got, want := BuckleyEmbeddingsCacheDir(), filepath.Join(home, "emb"); got != want {
+		t.Fatalf("override = %q, want %q", got, want)
+	}
+}
@@ -2,15 +2,134 @@ package prompts
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+// defaultCommitSubjectLength is the header limit when none is configured.
+const defaultCommitSubjectLength = 72
+
+// CommitConventions customizes the commit messages Buckley writes. Zero
+// fields keep each prompt's built-in style.
+type CommitConventions struct {
+	// Template is the header layout, with {type}, {scope} and {subject}
+	// placeholders, e.g. "{type}({scope}): {subject}" or "[{scope}] {subject}".
+	Template string
+	// Types lists the allowed header types (action verbs by default).
+	Types []string
+	// MaxSubjectLength caps the full header line.
+	MaxSubjectLength int
+}
+
+// MaxLength returns the configured header limit or the default of 72.
+func (c CommitConventions) MaxLength() int {
+	if c.MaxSubjectLength > 0 {
+		return c.MaxSubjectLength
+	}
+	return defaultCommitSubjectLength
+}
+
+// templateRule describes a configured header template for the model.
+func (c CommitConventions) templateRule() string {
+	return fmt.Sprintf("%q, where {type} is the commit type, {scope} the optional scope, and {subject} the summary", strings.TrimSpace(c.Template))
+}
+
 // CommitPrompt returns the effective prompt template for generating action-style commit messages.
 func CommitPrompt(now time.Time) string {
-	return resolvePrompt("commit", commitDefault(now), now)
+	return CommitPromptWith(now, CommitConventions{})
+}
+
+// CommitPromptWith is CommitPrompt with the team's commit conventions applied.
+// The commit override (BUCKLEY_PROMPT_COMMIT, its _FILE variant, or a saved
+// override) still replaces the result.
+func CommitPromptWith(now time.Time, conv CommitConventions) string {
+	return resolvePrompt("commit", commitDefaultWith(now, conv), now)
+}
+
+// CommitToolPrompt returns the system prompt for structured commit generation
+// with the generate_commit tool. The commit override takes precedence, with
+// {{DEFAULT_PROMPT}} expanding to this prompt.
+func CommitToolPrompt(now time.Time, conv CommitConventions) string {
+	return resolvePrompt("commit", commitToolDefault(conv), now)
+}
+
+// CommitRequirements returns the rules appended to the TUI /commit request.
+// The commit override takes precedence here too.
+func CommitRequirements(now time.Time, conv CommitConventions) string {
+	return resolvePrompt("commit", commitRequirementsDefault(conv), now)
+}
+
+func commitToolDefault(conv CommitConventions) string {
+	action := "- action: The verb describing what this commit does (add, fix, update, refactor, etc.)"
+	if len(conv.Types) > 0 {
+		action = "- action: The commit type, one of: " + strings.Join(conv.Types, ", ")
+	}
+	var format string
+	if strings.TrimSpace(conv.Template) != "" {
+		format = "\n- The header is rendered as " + conv.templateRule()
+	}
+	return fmt.Sprintf(`You are a git commit message generator. Analyze the staged changes and generate a clear, informative commit message.
+
+Use the generate_commit tool to produce your response. The tool expects:
+%s
+- scope: Optional component/area (e.g., "api", "ui", "config")
+- subject: Short summary, imperative mood, no period, ~50 chars
+- body: Bullet points explaining WHAT changed and WHY
+
+Guidelines:
+- Focus on the "what" and "why", not the "how"
+- Be specific but concise
+- Match body detail to change size
+- Group related changes into single bullets
+- Use imperative mood ("Add feature" not "Added feature")
+- Keep the full header line within %d characters%s`, action, conv.MaxLength(), format)
+}
+
+func commitRequirementsDefault(conv CommitConventions) string {
+	format := "- Use conventional commit format: type(scope): description"
+	if strings.TrimSpace(conv.Template) != "" {
+		format = "- Write the first line as " + conv.templateRule()
+	}
+	types := "feat, fix, refactor, docs, test, chore, perf, style"
+	if len(conv.Types) > 0 {
+		types = strings.Join(conv.Types, ", ")
+	}
+	return fmt.Sprintf(`Requirements:
+%s
+- Types: %s
+- First line under %d chars
+- Be specific about what changed and why
+- Add body if changes are complex
+
+Output ONLY the commit message, nothing else.`, format, types, conv.MaxLength())
 }
 
 func commitDefault(now time.Time) string {
+	return commitDefaultWith(now, CommitConventions{})
+}
+
+func commitDefaultWith(now time.Time, conv CommitConventions) string {
+	header := `- The first line MUST be an action header:
+  <action>(<scope>)?!: <summary>
+  - Use a clear action verb (e.g., add, fix, update, improve).
+  - <scope> is optional. Prefer a single scope from "Changed Areas" when it clearly fits.
+    If multiple subsystems change, omit scope and summarize the overarching change.
+  - Use "!" ONLY for breaking changes. If you use "!", include a footer:
+    BREAKING CHANGE: <explanation>`
+	if strings.TrimSpace(conv.Template) != "" {
+		header = `- The first line MUST follow the team's header template:
+  ` + conv.templateRule() + `.
+  - Leave out the scope when no single area from "Changed Areas" clearly fits.
+  - For breaking changes, include a footer:
+    BREAKING CHANGE: <explanation>`
+	}
+	if len(conv.Types) > 0 {
+		header += "\n  - The type MUST be one of: " + strings.Join(conv.Types, ", ") + "."
+	}
+	budget := ""
+	if max := conv.MaxLength(); max > 21 {
+		budget = fmt.Sprintf("\n  - Budget accordingly: \"refactor(execution): \" is 21 chars, leaving %d for summary.", max-21)
+	}
 	return fmt.Sprintf(`You are writing a Git commit message for the staged changes.
 
 SECURITY / SAFETY:
@@ -26,16 +145,9 @@ INPUT YOU WILL RECEIVE (as plain text):
 
 OUTPUT REQUIREMENTS (plain text only):
 - Output ONLY the commit message (no preamble, no commentary, no code fences, no surrounding quotes).
-- The first line MUST be an action header:
-  <action>(<scope>)?!: <summary>
-  - Use a clear action verb (e.g., add, fix, update, improve).
-  - <scope> is optional. Prefer a single scope from "Changed Areas" when it clearly fits.
-    If multiple subsystems change, omit scope and summarize the overarching change.
-  - Use "!" ONLY for breaking changes. If you use "!", include a footer:
-    BREAKING CHANGE: <explanation>
+%s
 - Summary rules:
-  - The FULL header line (action + scope + summary) MUST be <= 72 characters total.
-  - Budget accordingly: "refactor(execution): " is 21 chars, leaving 51 for summary.
+  - The FULL header line (action + scope + summary) MUST be <= %d characters total.%s
   - Match the breadth of the diff (avoid overfitting to a single file when many change).
   - Concise, no trailing period.
   - Prefer a noun phrase that focuses on the thing changed (e.g., "workflow summary").
@@ -60,5 +172,5 @@ STYLE EXAMPLES (format only):
 - update(deps): refresh generated artifacts
 
 Current date/time: %s
-`, ste100ProseBlock, now.Format(time.RFC3339))
+`, header, conv.MaxLength(), budget, ste100ProseBlock, now.Format(time.RFC3339))
 }
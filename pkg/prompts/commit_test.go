@@ -0,0 +1,58 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitPromptsApplyConventions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BUCKLEY_PROMPT_COMMIT", "")
+	t.Setenv("BUCKLEY_PROMPT_COMMIT_FILE", "")
+	now := time.Date(2025, 12, 13, 12, 0, 0, 0, time.UTC)
+	conv := CommitConventions{
+		Template:         "[{scope}] {subject}",
+		Types:            []string{"feature", "bugfix"},
+		MaxSubjectLength: 60,
+	}
+
+	for name, prompt := range map[string]string{
+		"plain":        CommitPromptWith(now, conv),
+		"tool":         CommitToolPrompt(now, conv),
+		"requirements": CommitRequirements(now, conv),
+	} {
+		for _, want := range []string{`"[{scope}] {subject}"`, "feature, bugfix", "60"} {
+			if !strings.Contains(prompt, want) {
+				t.Errorf("%s prompt missing %q:\n%s", name, want, prompt)
+			}
+		}
+		if strings.Contains(prompt, "72") {
+			t.Errorf("%s prompt still mentions the default 72-char limit:\n%s", name, prompt)
+		}
+	}
+
+	if got := CommitRequirements(now, CommitConventions{}); !strings.Contains(got, "conventional commit format") || !strings.Contains(got, "under 72 chars") {
+		t.Errorf("default requirements changed:\n%s", got)
+	}
+}
+
+func TestCommitPromptsPreferEnvOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BUCKLEY_PROMPT_COMMIT", "Team rules only.\n\n{{DEFAULT_PROMPT}}")
+	now := time.Date(2025, 12, 13, 12, 0, 0, 0, time.UTC)
+	conv := CommitConventions{Types: []string{"feature"}}
+
+	for name, prompt := range map[string]string{
+		"plain":        CommitPromptWith(now, conv),
+		"tool":         CommitToolPrompt(now, conv),
+		"requirements": CommitRequirements(now, conv),
+	} {
+		if !strings.HasPrefix(prompt, "Team rules only.") {
+			t.Errorf("%s prompt ignored BUCKLEY_PROMPT_COMMIT:\n%s", name, prompt)
+		}
+		if !strings.Contains(prompt, "feature") {
+			t.Errorf("%s prompt dropped the conventions from {{DEFAULT_PROMPT}}:\n%s", name, prompt)
+		}
+	}
+}
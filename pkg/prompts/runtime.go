@@ -29,6 +29,7 @@ type RuntimePromptInput struct {
 	WorkDir           string
 	RootDir           string
 	SkillsDescription string
+	Footer            string // Always appended last, outside the section budget
 	TaskType          string
 	ModelTier         string
 	GitDiffLines      int
@@ -90,6 +91,12 @@ func BuildRuntimeSystemPrompt(input RuntimePromptInput) string {
 		GTSAvailable:     input.GTSAvailable,
 	})
 
+	// The footer carries compliance text, so it bypasses section policy and
+	// budget truncation the same way the base prompt is never omitted.
+	if footer := strings.TrimSpace(input.Footer); footer != "" {
+		sections = append(sections, footer)
+	}
+
 	return strings.TrimSpace(strings.Join(sections, "\n\n"))
 }
 
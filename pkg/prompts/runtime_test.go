@@ -70,3 +70,40 @@ func TestBuildRuntimeSystemPrompt_DedupesProjectContextFromInstructionFile(t *te
 		t.Fatalf("expected duplicate project context to be omitted\nfull prompt:\n%s", prompt)
 	}
 }
+
+func TestBuildRuntimeSystemPrompt_FooterAlwaysPresent(t *testing.T) {
+	const footer = "Compliance: outputs are reviewed by a human before release."
+
+	tests := []struct {
+		name           string
+		projectContext string
+	}{
+		{name: "small context", projectContext: "Project context block"},
+		{name: "context over budget", projectContext: strings.Repeat("x", MaxTotalInstructionChars*2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt := BuildRuntimeSystemPrompt(RuntimePromptInput{
+				BasePrompt:     "Base prompt",
+				ProjectContext: tt.projectContext,
+				Footer:         footer,
+			})
+			if !strings.HasSuffix(prompt, footer) {
+				t.Fatalf("expected prompt to end with footer\nfull prompt tail:\n%s", prompt[max(0, len(prompt)-200):])
+			}
+			if !strings.HasPrefix(prompt, "Base prompt") {
+				t.Fatalf("expected base prompt to lead the prompt")
+			}
+		})
+	}
+}
+
+func TestBuildRuntimeSystemPrompt_EmptyFooterOmitted(t *testing.T) {
+	prompt := BuildRuntimeSystemPrompt(RuntimePromptInput{
+		BasePrompt: "Base prompt",
+		Footer:     "   ",
+	})
+	if prompt != "Base prompt" {
+		t.Fatalf("expected only base prompt, got %q", prompt)
+	}
+}
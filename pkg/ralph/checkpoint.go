@@ -0,0 +1,246 @@
+// pkg/ralph/checkpoint.go
+package ralph
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// checkpointTurns is how many recent backend responses a checkpoint keeps
+// for replay on resume.
+const checkpointTurns = 5
+
+// checkpointResponseChars caps each replayed response.
+const checkpointResponseChars = 2000
+
+// Checkpoint is the persisted state of a session after its last completed
+// iteration. It holds what NewSessionFromCheckpoint and WithResumeFrom need to
+// continue the session in a new process.
+type Checkpoint struct {
+	SessionID     string            `json:"session_id"`
+	Iteration     int               `json:"iteration"`
+	Timestamp     time.Time         `json:"timestamp"`
+	Prompt        string            `json:"prompt"`
+	PromptFile    string            `json:"prompt_file,omitempty"`
+	Sandbox       string            `json:"sandbox"`
+	MaxIterations int               `json:"max_iterations,omitempty"`
+	MaxCost       float64           `json:"max_cost,omitempty"`
+	NoRefine      bool              `json:"no_refine,omitempty"`
+	VerifyCommand string            `json:"verify_command,omitempty"`
+	GitWorkflow   GitWorkflowConfig `json:"git_workflow"`
+	TotalTokens   int               `json:"total_tokens"`
+	TotalCost     float64           `json:"total_cost"`
+	Elapsed       time.Duration     `json:"elapsed"`
+	FilesModified []string          `json:"files_modified,omitempty"`
+	LastBackend   string            `json:"last_backend,omitempty"`
+	LastModel     string            `json:"last_model,omitempty"`
+	LastError     string            `json:"last_error,omitempty"`
+	// Turns are the most recent backend responses, oldest first.
+	Turns []CheckpointTurn `json:"turns,omitempty"`
+	// StopReason is set once the session has ended; empty means the process
+	// stopped mid-run, for example because it crashed.
+	StopReason string `json:"stop_reason,omitempty"`
+}
+
+// CheckpointTurn is one backend response kept for replay.
+type CheckpointTurn struct {
+	Iteration int    `json:"iteration"`
+	Backend   string `json:"backend,omitempty"`
+	Response  string `json:"response"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SaveCheckpoint stores cp as the session's latest checkpoint, replacing the
+// previous one. The write is a single upsert, so a reader sees either the
+// old checkpoint or the new one, never a mix.
+func (s *MemoryStore) SaveCheckpoint(ctx context.Context, cp *Checkpoint) error {
+	if s == nil || s.db == nil || cp == nil {
+		return fmt.Errorf("memory store not initialized")
+	}
+	if strings.TrimSpace(cp.SessionID) == "" {
+		return fmt.Errorf("session id required")
+	}
+	if cp.Timestamp.IsZero() {
+		cp.Timestamp = time.Now()
+	}
+	state, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO ralph_checkpoints (session_id, iteration, ts, state)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(session_id) DO UPDATE SET
+			iteration = excluded.iteration,
+			ts = excluded.ts,
+			state = excluded.state
+	`, cp.SessionID, cp.Iteration, cp.Timestamp, string(state))
+	if err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LoadCheckpoint returns the latest checkpoint for sessionID, or nil if the
+// session has none.
+func (s *MemoryStore) LoadCheckpoint(ctx context.Context, sessionID string) (*Checkpoint, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("memory store not initialized")
+	}
+	var state string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT state FROM ralph_checkpoints WHERE session_id = ?
+	`, sessionID).Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal([]byte(state), &cp); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	return &cp, nil
+}
+
+// NewSessionFromCheckpoint recreates the session cp was taken from, with its
+// iteration count, totals and modified files restored. The timeout is not
+// stored; timeout applies to the resumed run only.
+func NewSessionFromCheckpoint(cp *Checkpoint, timeout time.Duration) *Session {
+	sess := NewSession(SessionConfig{
+		SessionID:     cp.SessionID,
+		Prompt:        cp.Prompt,
+		PromptFile:    cp.PromptFile,
+		Sandbox:       cp.Sandbox,
+		Timeout:       timeout,
+		MaxIterations: cp.MaxIterations,
+		MaxCost:       cp.MaxCost,
+		NoRefine:      cp.NoRefine,
+		VerifyCommand: cp.VerifyCommand,
+		GitWorkflow:   cp.GitWorkflow,
+	})
+	sess.mu.Lock()
+	sess.iteration = cp.Iteration
+	sess.totalTokens = cp.TotalTokens
+	sess.totalCost = cp.TotalCost
+	sess.priorElapsed = cp.Elapsed
+	sess.mu.Unlock()
+	for _, file := range cp.FilesModified {
+		sess.AddModifiedFile(file)
+	}
+	return sess
+}
+
+// checkpoint builds a checkpoint of the session's current state.
+func (e *Executor) checkpoint(stopReason string) *Checkpoint {
+	stats := e.session.Stats()
+	e.mu.Lock()
+	turns := append([]CheckpointTurn(nil), e.recentTurns...)
+	completed := e.completedIteration
+	e.mu.Unlock()
+	cp := &Checkpoint{
+		SessionID:     e.session.ID,
+		Iteration:     completed,
+		Timestamp:     time.Now(),
+		Prompt:        e.session.GetPrompt(),
+		PromptFile:    e.session.PromptFile,
+		Sandbox:       e.session.Sandbox,
+		MaxIterations: e.session.MaxIterations,
+		MaxCost:       e.session.MaxCost,
+		NoRefine:      e.session.NoRefine,
+		VerifyCommand: e.session.VerifyCommand,
+		GitWorkflow:   e.session.GitWorkflow,
+		TotalTokens:   stats.TotalTokens,
+		TotalCost:     stats.TotalCost,
+		Elapsed:       stats.Elapsed,
+		FilesModified: e.session.ModifiedFiles(),
+		LastBackend:   e.lastBackend,
+		LastModel:     e.lastModel,
+		Turns:         turns,
+		StopReason:    stopReason,
+	}
+	if e.lastError != nil {
+		cp.LastError = e.lastError.Error()
+	}
+	return cp
+}
+
+// saveCheckpoint writes a checkpoint if a checkpoint store is attached.
+// Failures are logged and do not stop the session.
+func (e *Executor) saveCheckpoint(stopReason string) {
+	if e.checkpoints == nil {
+		return
+	}
+	// Use a fresh context so the final checkpoint is written after a timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cp := e.checkpoint(stopReason)
+	if err := e.checkpoints.SaveCheckpoint(ctx, cp); err != nil {
+		e.logInternalError(cp.Iteration, "checkpoint", err)
+	}
+}
+
+// recordTurns keeps the latest backend responses for the next checkpoint.
+func (e *Executor) recordTurns(iteration int, results []*BackendResult) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		turn := CheckpointTurn{
+			Iteration: iteration,
+			Backend:   result.Backend,
+			Response:  truncateForDisplay(strings.TrimSpace(result.Output), checkpointResponseChars),
+		}
+		if result.Error != nil {
+			turn.Error = result.Error.Error()
+		}
+		e.recentTurns = append(e.recentTurns, turn)
+	}
+	if len(e.recentTurns) > checkpointTurns {
+		e.recentTurns = e.recentTurns[len(e.recentTurns)-checkpointTurns:]
+	}
+}
+
+// resumeContext describes the checkpointed progress for the first prompt of
+// a resumed session, so the model picks up where the last run stopped.
+func (e *Executor) resumeContext() string {
+	e.mu.Lock()
+	resumed := e.resumedFrom
+	turns := append([]CheckpointTurn(nil), e.recentTurns...)
+	e.mu.Unlock()
+	if resumed == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<ralph-resume>\nThis session was resumed from a checkpoint after iteration %d.\n", resumed.Iteration)
+	if files := resumed.FilesModified; len(files) > 0 {
+		fmt.Fprintf(&b, "Files modified so far: %s\n", strings.Join(files, ", "))
+	}
+	if resumed.LastError != "" {
+		fmt.Fprintf(&b, "Last error: %s\n", resumed.LastError)
+	}
+	for _, turn := range turns {
+		fmt.Fprintf(&b, "\n[Iteration %d", turn.Iteration)
+		if turn.Backend != "" {
+			fmt.Fprintf(&b, ", %s", turn.Backend)
+		}
+		b.WriteString("]\n")
+		if turn.Response != "" {
+			b.WriteString(turn.Response + "\n")
+		}
+		if turn.Error != "" {
+			fmt.Fprintf(&b, "Error: %s\n", turn.Error)
+		}
+	}
+	b.WriteString("</ralph-resume>\n\n")
+	return b.String()
+}
@@ -0,0 +1,172 @@
+// pkg/ralph/checkpoint_test.go
+package ralph
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type recordingRunner struct {
+	prompts []string
+}
+
+func (r *recordingRunner) ProcessInput(ctx context.Context, input string) error {
+	r.prompts = append(r.prompts, input)
+	return nil
+}
+
+func (r *recordingRunner) State() string {
+	return "idle"
+}
+
+func newTestMemoryStore(t *testing.T) *MemoryStore {
+	t.Helper()
+	store, err := NewMemoryStore(filepath.Join(t.TempDir(), "memory.db"))
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestMemoryStore_CheckpointRoundTrip(t *testing.T) {
+	store := newTestMemoryStore(t)
+	ctx := context.Background()
+
+	if cp, err := store.LoadCheckpoint(ctx, "missing"); err != nil || cp != nil {
+		t.Fatalf("LoadCheckpoint(missing) = %+v, %v; want nil, nil", cp, err)
+	}
+
+	first := &Checkpoint{SessionID: "s1", Iteration: 1, Prompt: "Build something"}
+	if err := store.SaveCheckpoint(ctx, first); err != nil {
+		t.Fatalf("SaveCheckpoint: %v", err)
+	}
+	second := &Checkpoint{
+		SessionID:     "s1",
+		Iteration:     2,
+		Prompt:        "Build something",
+		TotalCost:     0.25,
+		FilesModified: []string{"main.go"},
+		Turns:         []CheckpointTurn{{Iteration: 2, Backend: "claude", Response: "done"}},
+	}
+	if err := store.SaveCheckpoint(ctx, second); err != nil {
+		t.Fatalf("SaveCheckpoint: %v", err)
+	}
+
+	got, err := store.LoadCheckpoint(ctx, "s1")
+	if err != nil {
+		t.Fatalf("LoadCheckpoint: %v", err)
+	}
+	if got.Iteration != 2 || got.TotalCost != 0.25 || len(got.FilesModified) != 1 || len(got.Turns) != 1 {
+		t.Fatalf("LoadCheckpoint = %+v, want the second checkpoint", got)
+	}
+
+	if err := store.SaveCheckpoint(ctx, &Checkpoint{}); err == nil {
+		t.Fatal("SaveCheckpoint without a session id should fail")
+	}
+}
+
+func TestExecutor_CheckpointsEachIteration(t *testing.T) {
+	store := newTestMemoryStore(t)
+	sess := NewSession(SessionConfig{
+		SessionID:     "test-checkpoint",
+		Prompt:        "Build something",
+		Sandbox:       t.TempDir(),
+		MaxIterations: 2,
+	})
+	exec := NewExecutor(sess, &mockHeadlessRunner{}, nil, WithCheckpoints(store))
+	if err := exec.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	cp, err := store.LoadCheckpoint(context.Background(), "test-checkpoint")
+	if err != nil || cp == nil {
+		t.Fatalf("LoadCheckpoint = %+v, %v", cp, err)
+	}
+	if cp.Iteration != 2 || cp.StopReason != "max_iterations" || cp.Prompt != "Build something" {
+		t.Fatalf("checkpoint = %+v, want iteration 2 stopped at max_iterations", cp)
+	}
+}
+
+// cancellingRunner completes its first input and cancels the run during the
+// second, the way a timeout interrupts an iteration.
+type cancellingRunner struct {
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (r *cancellingRunner) ProcessInput(ctx context.Context, input string) error {
+	r.calls++
+	if r.calls < 2 {
+		return nil
+	}
+	r.cancel()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (r *cancellingRunner) State() string {
+	return "idle"
+}
+
+func TestExecutor_CheckpointSkipsInterruptedIteration(t *testing.T) {
+	store := newTestMemoryStore(t)
+	sess := NewSession(SessionConfig{
+		SessionID:     "test-interrupted",
+		Prompt:        "Build something",
+		Sandbox:       t.TempDir(),
+		MaxIterations: 5,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exec := NewExecutor(sess, &cancellingRunner{cancel: cancel}, nil, WithCheckpoints(store))
+	if err := exec.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	cp, err := store.LoadCheckpoint(context.Background(), "test-interrupted")
+	if err != nil || cp == nil {
+		t.Fatalf("LoadCheckpoint = %+v, %v", cp, err)
+	}
+	if cp.Iteration != 1 {
+		t.Fatalf("checkpoint iteration = %d, want 1 (iteration 2 was interrupted)", cp.Iteration)
+	}
+}
+
+func TestExecutor_ResumeFromCheckpoint(t *testing.T) {
+	cp := &Checkpoint{
+		SessionID:     "test-resume",
+		Iteration:     3,
+		Prompt:        "Build something",
+		Sandbox:       t.TempDir(),
+		MaxIterations: 5,
+		TotalCost:     0.40,
+		FilesModified: []string{"main.go"},
+		Turns:         []CheckpointTurn{{Iteration: 3, Backend: "claude", Response: "Added the parser."}},
+	}
+	sess := NewSessionFromCheckpoint(cp, 0)
+	if stats := sess.Stats(); stats.Iteration != 3 || stats.TotalCost != 0.40 || stats.FilesModified != 1 {
+		t.Fatalf("restored stats = %+v", stats)
+	}
+
+	runner := &recordingRunner{}
+	exec := NewExecutor(sess, runner, nil, WithResumeFrom(cp))
+	if err := exec.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(runner.prompts) != 2 {
+		t.Fatalf("ran %d iterations after resume, want 2", len(runner.prompts))
+	}
+	first := runner.prompts[0]
+	for _, want := range []string{"<ralph-resume>", "after iteration 3", "main.go", "Added the parser.", "[Iteration 4]"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("first resumed prompt is missing %q:\n%s", want, first)
+		}
+	}
+	if strings.Contains(runner.prompts[1], "<ralph-resume>") {
+		t.Errorf("resume context repeated in a later prompt:\n%s", runner.prompts[1])
+	}
+}
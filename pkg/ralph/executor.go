@@ -26,6 +26,12 @@ type HeadlessRunner interface {
 	State() string
 }
 
+// SpendTracker reports cumulative session spend in dollars. *cost.Tracker
+// satisfies it.
+type SpendTracker interface {
+	GetSessionCost() float64
+}
+
 // ProgressWriter receives progress updates during execution.
 type ProgressWriter interface {
 	io.Writer
@@ -44,6 +50,7 @@ type Executor struct {
 	projectCtx   string
 	lastBackend  string
 	lastModel    string
+	spend        SpendTracker
 
 	// Git workflow
 	commitBackend Backend
@@ -52,6 +59,15 @@ type Executor struct {
 	mu              sync.Mutex
 	promptFileMtime time.Time
 	lastError       error
+	stopReason      string
+
+	// Checkpointing
+	checkpoints *MemoryStore
+	resumedFrom *Checkpoint
+	recentTurns []CheckpointTurn
+	// completedIteration is the last iteration whose backend results were
+	// recorded. An interrupted iteration is not counted, so resuming reruns it.
+	completedIteration int
 }
 
 // ExecutorOption configures an Executor.
@@ -71,6 +87,14 @@ func WithProgressWriter(w ProgressWriter) ExecutorOption {
 	}
 }
 
+// WithSpendTracker counts spend recorded on tracker toward the session's
+// MaxCost, in addition to the costs backends report.
+func WithSpendTracker(tracker SpendTracker) ExecutorOption {
+	return func(e *Executor) {
+		e.spend = tracker
+	}
+}
+
 // WithMemoryStore attaches a session memory store.
 func WithMemoryStore(store *MemoryStore) ExecutorOption {
 	return func(e *Executor) {
@@ -78,6 +102,32 @@ func WithMemoryStore(store *MemoryStore) ExecutorOption {
 	}
 }
 
+// WithCheckpoints saves a checkpoint to store after every iteration and
+// when the session ends, so the session can be resumed with WithResumeFrom.
+func WithCheckpoints(store *MemoryStore) ExecutorOption {
+	return func(e *Executor) {
+		e.checkpoints = store
+	}
+}
+
+// WithResumeFrom continues the session saved in cp. The session itself should
+// come from NewSessionFromCheckpoint; this restores the executor state and
+// replays the checkpointed responses in the first resumed prompt.
+func WithResumeFrom(cp *Checkpoint) ExecutorOption {
+	return func(e *Executor) {
+		if cp == nil {
+			return
+		}
+		e.resumedFrom = cp
+		e.lastBackend = cp.LastBackend
+		e.lastModel = cp.LastModel
+		e.recentTurns = append([]CheckpointTurn(nil), cp.Turns...)
+		if cp.LastError != "" {
+			e.lastError = errors.New(cp.LastError)
+		}
+	}
+}
+
 // WithContextProcessor attaches a context processor for prompt injection.
 func WithContextProcessor(processor *ContextProcessor) ExecutorOption {
 	return func(e *Executor) {
@@ -133,6 +183,9 @@ func (e *Executor) Run(ctx context.Context) error {
 	}
 
 	e.session.Start()
+	e.mu.Lock()
+	e.completedIteration = e.session.Iteration()
+	e.mu.Unlock()
 
 	if err := e.session.TransitionTo(StateRunning); err != nil {
 		return fmt.Errorf("transition to running: %w", err)
@@ -166,6 +219,13 @@ func (e *Executor) Run(ctx context.Context) error {
 		if e.session.MaxIterations > 0 && e.session.Iteration() >= e.session.MaxIterations {
 			reason = "max_iterations"
 		}
+		if e.costCeilingReached() {
+			reason = "max_cost"
+		}
+		e.mu.Lock()
+		e.stopReason = reason
+		e.mu.Unlock()
+		e.saveCheckpoint(reason)
 
 		e.session.TransitionTo(StateCompleted)
 		stats := e.session.Stats()
@@ -201,6 +261,13 @@ func (e *Executor) Run(ctx context.Context) error {
 			return nil
 		}
 
+		// Check the cost ceiling. It is only checked between iterations, so
+		// the iteration that crosses it finishes and its changes are kept.
+		if e.costCeilingReached() {
+			e.writeProgress("\n  [STOP] Cost ceiling reached: $%.4f spent of $%.2f\n", e.spent(), e.session.MaxCost)
+			return nil
+		}
+
 		// Check for prompt file changes
 		e.checkPromptReload()
 
@@ -214,6 +281,31 @@ func (e *Executor) Run(ctx context.Context) error {
 	}
 }
 
+// StopReason reports why the last Run ended: completed, timeout,
+// max_iterations or max_cost. It is empty while Run is still going.
+func (e *Executor) StopReason() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.stopReason
+}
+
+// spent returns the session's cumulative spend: the larger of the backend
+// costs recorded on the session and the spend tracker, which may see the
+// same calls.
+func (e *Executor) spent() float64 {
+	spent := e.session.Stats().TotalCost
+	if e.spend != nil {
+		if tracked := e.spend.GetSessionCost(); tracked > spent {
+			spent = tracked
+		}
+	}
+	return spent
+}
+
+func (e *Executor) costCeilingReached() bool {
+	return e.session.MaxCost > 0 && e.spent() >= e.session.MaxCost
+}
+
 func (e *Executor) runIteration(ctx context.Context) error {
 	iteration := e.session.IncrementIteration()
 	maxIter := e.session.MaxIterations
@@ -309,6 +401,7 @@ func (e *Executor) runIteration(ctx context.Context) error {
 
 		// Log backend results and update session stats
 		e.handleBackendResults(ctx, iteration, prompt, promptTokens, results, cfg)
+		e.markIterationCompleted(ctx, iteration)
 
 		// Log comparison for parallel mode
 		if len(results) > 1 && e.logger != nil {
@@ -321,6 +414,7 @@ func (e *Executor) runIteration(ctx context.Context) error {
 		if err != nil {
 			e.lastError = err
 		}
+		e.markIterationCompleted(ctx, iteration)
 	}
 
 	// Run verification command if configured
@@ -366,9 +460,22 @@ func (e *Executor) runIteration(ctx context.Context) error {
 		e.runAutoCommit(ctx, iteration)
 	}
 
+	e.saveCheckpoint("")
+
 	return err
 }
 
+// markIterationCompleted records iteration as the last one to checkpoint,
+// unless ctx was cancelled while it ran.
+func (e *Executor) markIterationCompleted(ctx context.Context, iteration int) {
+	if ctx.Err() != nil {
+		return
+	}
+	e.mu.Lock()
+	e.completedIteration = iteration
+	e.mu.Unlock()
+}
+
 // runAutoCommit runs the commit backend to commit changes from the current iteration.
 func (e *Executor) runAutoCommit(ctx context.Context, iteration int) {
 	modifiedFiles := e.session.ModifiedFiles()
@@ -825,6 +932,7 @@ func (e *Executor) handleBackendResults(ctx context.Context, iteration int, prom
 		e.lastError = nil
 	}
 
+	e.recordTurns(iteration, results)
 	e.updateMemory(ctx, iteration, prompt, promptTokens, results, cfg)
 }
 
@@ -1189,6 +1297,9 @@ func (e *Executor) buildIterationPrompt(iteration int) string {
 	if iteration > 1 {
 		base = fmt.Sprintf("[Iteration %d] Continue working on the task.\n\nOriginal task:\n%s", iteration, base)
 	}
+	if e.resumedFrom != nil && iteration == e.resumedFrom.Iteration+1 {
+		base = e.resumeContext() + base
+	}
 
 	return base
 }
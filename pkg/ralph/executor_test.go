@@ -2,12 +2,16 @@
 package ralph
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
+
+	"m31labs.dev/buckley/pkg/cost"
 )
 
 type mockHeadlessRunner struct {
@@ -52,6 +56,105 @@ func TestExecutor_RunsIterations(t *testing.T) {
 	}
 }
 
+type fakeSpendTracker struct{ cost float64 }
+
+func (f *fakeSpendTracker) GetSessionCost() float64 { return f.cost }
+
+var _ SpendTracker = (*cost.Tracker)(nil)
+
+// spendingRunner records a fixed cost on its tracker for each prompt.
+type spendingRunner struct {
+	mockHeadlessRunner
+	tracker *fakeSpendTracker
+	perCall float64
+}
+
+func (r *spendingRunner) ProcessInput(ctx context.Context, input string) error {
+	r.tracker.cost += r.perCall
+	return r.mockHeadlessRunner.ProcessInput(ctx, input)
+}
+
+func TestExecutor_StopsAtCostCeiling(t *testing.T) {
+	sess := NewSession(SessionConfig{
+		SessionID:     "test-max-cost",
+		Prompt:        "Build something",
+		Sandbox:       t.TempDir(),
+		MaxIterations: 10,
+		MaxCost:       1.00,
+	})
+
+	tracker := &fakeSpendTracker{}
+	runner := &spendingRunner{tracker: tracker, perCall: 0.40}
+	var progress bytes.Buffer
+	endCalled := false
+	exec := NewExecutor(sess, runner, nil,
+		WithSpendTracker(tracker),
+		WithProgressWriter(&progress),
+		WithSessionEndHandler(func(context.Context) error {
+			endCalled = true
+			return nil
+		}),
+	)
+
+	if err := exec.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// $0.40 per iteration crosses $1.00 during the third, which still finishes.
+	if runner.processCount != 3 {
+		t.Errorf("expected 3 iterations, got %d", runner.processCount)
+	}
+	if got := exec.StopReason(); got != "max_cost" {
+		t.Errorf("StopReason() = %q, want max_cost", got)
+	}
+	if !endCalled {
+		t.Error("session end handler did not run after the cost ceiling")
+	}
+	if !strings.Contains(progress.String(), "Cost ceiling reached") {
+		t.Errorf("progress output does not report the cost ceiling:\n%s", progress.String())
+	}
+}
+
+func TestExecutor_CostCeilingUsesBackendCosts(t *testing.T) {
+	sess := NewSession(SessionConfig{
+		SessionID: "test-max-cost-backend",
+		Prompt:    "Build something",
+		Sandbox:   t.TempDir(),
+		MaxCost:   0.50,
+	})
+	exec := NewExecutor(sess, &mockHeadlessRunner{}, nil)
+
+	if exec.costCeilingReached() {
+		t.Fatal("ceiling reached before any spend")
+	}
+	sess.AddTokens(1000, 0.50)
+	if !exec.costCeilingReached() {
+		t.Fatal("ceiling not reached once backend costs hit MaxCost")
+	}
+
+	sess.MaxCost = 0
+	if exec.costCeilingReached() {
+		t.Fatal("zero MaxCost should disable the ceiling")
+	}
+}
+
+func TestExecutor_StopReasonMaxIterations(t *testing.T) {
+	sess := NewSession(SessionConfig{
+		SessionID:     "test-max-iterations",
+		Prompt:        "Build something",
+		Sandbox:       t.TempDir(),
+		MaxIterations: 2,
+		MaxCost:       100,
+	})
+	exec := NewExecutor(sess, &mockHeadlessRunner{}, nil)
+	if err := exec.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := exec.StopReason(); got != "max_iterations" {
+		t.Errorf("StopReason() = %q, want max_iterations", got)
+	}
+}
+
 func TestExecutor_RespectsTimeout(t *testing.T) {
 	sess := NewSession(SessionConfig{
 		SessionID:     "test-timeout",
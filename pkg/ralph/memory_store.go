@@ -110,6 +110,13 @@ CREATE TRIGGER IF NOT EXISTS ralph_summaries_au AFTER UPDATE ON ralph_summaries
     INSERT INTO ralph_summaries_fts(rowid, summary)
     VALUES (new.id, COALESCE(new.summary, ''));
 END;
+
+CREATE TABLE IF NOT EXISTS ralph_checkpoints (
+    session_id TEXT PRIMARY KEY,
+    iteration INTEGER NOT NULL,
+    ts TIMESTAMP NOT NULL,
+    state TEXT NOT NULL
+);
 `
 
 // TurnRecord captures a raw prompt/response pair for a session iteration.
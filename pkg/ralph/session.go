@@ -26,6 +26,7 @@ type SessionConfig struct {
 	Sandbox       string
 	Timeout       time.Duration
 	MaxIterations int
+	MaxCost       float64 // dollars of cumulative spend; 0 = no ceiling
 	NoRefine      bool
 	VerifyCommand string
 	GitWorkflow   GitWorkflowConfig
@@ -41,6 +42,7 @@ type Session struct {
 	Sandbox       string
 	Timeout       time.Duration
 	MaxIterations int
+	MaxCost       float64
 	NoRefine      bool
 	VerifyCommand string
 	GitWorkflow   GitWorkflowConfig
@@ -48,6 +50,7 @@ type Session struct {
 	state            State
 	iteration        int
 	startTime        time.Time
+	priorElapsed     time.Duration
 	totalTokens      int
 	totalCost        float64
 	filesModified    []string
@@ -63,6 +66,7 @@ func NewSession(cfg SessionConfig) *Session {
 		Sandbox:       cfg.Sandbox,
 		Timeout:       cfg.Timeout,
 		MaxIterations: cfg.MaxIterations,
+		MaxCost:       cfg.MaxCost,
 		NoRefine:      cfg.NoRefine,
 		VerifyCommand: cfg.VerifyCommand,
 		GitWorkflow:   cfg.GitWorkflow,
@@ -113,7 +117,7 @@ func (s *Session) Stats() SessionStats {
 		TotalTokens:   s.totalTokens,
 		TotalCost:     s.totalCost,
 		FilesModified: len(s.filesModified),
-		Elapsed:       time.Since(s.startTime),
+		Elapsed:       s.priorElapsed + time.Since(s.startTime),
 	}
 }
 
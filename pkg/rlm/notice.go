@@ -0,0 +1,43 @@
+package rlm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ExperimentalWarning tells users what to expect from RLM execution mode.
+const ExperimentalWarning = "RLM execution mode is experimental: a coordinator model delegates work to sub-agents, " +
+	"so behavior, cost, and output may change between releases. " +
+	"Set execution.mode: classic to switch back, or execution.suppress_rlm_warning: true to hide this notice."
+
+// experimentalAckFile records in the data directory that the warning was shown.
+const experimentalAckFile = "rlm-experimental.ack"
+
+// TakeExperimentalWarning returns ExperimentalWarning unless it was already
+// acknowledged in dataDir, and records the acknowledgement so the warning is
+// shown once. With an empty dataDir the warning is returned every time. A
+// failed write still returns the warning alongside the error.
+func TakeExperimentalWarning(dataDir string) (string, error) {
+	dataDir = strings.TrimSpace(dataDir)
+	if dataDir == "" {
+		return ExperimentalWarning, nil
+	}
+	path := filepath.Join(dataDir, experimentalAckFile)
+	if _, err := os.Stat(path); err == nil {
+		return "", nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return ExperimentalWarning, fmt.Errorf("check RLM warning acknowledgement: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return ExperimentalWarning, fmt.Errorf("create data dir: %w", err)
+	}
+	stamp := time.Now().UTC().Format(time.RFC3339) + "\n"
+	if err := os.WriteFile(path, []byte(stamp), 0o644); err != nil {
+		return ExperimentalWarning, fmt.Errorf("record RLM warning acknowledgement: %w", err)
+	}
+	return ExperimentalWarning, nil
+}
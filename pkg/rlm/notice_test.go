@@ -0,0 +1,41 @@
+package rlm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTakeExperimentalWarningShowsOnce(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), "data")
+
+	first, err := TakeExperimentalWarning(dataDir)
+	if err != nil {
+		t.Fatalf("first TakeExperimentalWarning: %v", err)
+	}
+	if first != ExperimentalWarning {
+		t.Fatalf("first warning = %q, want ExperimentalWarning", first)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, experimentalAckFile)); err != nil {
+		t.Fatalf("expected acknowledgement file: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		again, err := TakeExperimentalWarning(dataDir)
+		if err != nil {
+			t.Fatalf("repeat TakeExperimentalWarning: %v", err)
+		}
+		if again != "" {
+			t.Fatalf("warning shown again after acknowledgement: %q", again)
+		}
+	}
+}
+
+func TestTakeExperimentalWarningWithoutDataDir(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		got, err := TakeExperimentalWarning("")
+		if err != nil || got != ExperimentalWarning {
+			t.Fatalf("TakeExperimentalWarning(\"\") = %q, %v; want the warning every time", got, err)
+		}
+	}
+}
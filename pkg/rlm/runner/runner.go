@@ -116,6 +116,7 @@ func (r *Runner) initRuntime() error {
 		Telemetry:   r.telemetry,
 		UseToon:     r.cfg != nil && r.cfg.Encoding.UseToon,
 		GraftClient: r.graftClient,
+		ToolConfig:  r.cfg,
 	})
 	if err != nil {
 		return err
@@ -216,26 +217,56 @@ func (r *Runner) ExecutePlan() error {
 
 	// Execute independent tasks in parallel via the RLM coordinator
 	if len(independent) > 0 {
-		if err := r.executeTaskBatch(plan, independent); err != nil {
+		err := r.executeTaskBatch(plan, independent)
+		if saveErr := r.savePlan(plan); saveErr != nil {
+			return saveErr
+		}
+		if err != nil {
 			return err
 		}
 	}
 
-	// Execute dependent tasks sequentially
+	// Execute dependent tasks sequentially, saving after each so a resumed
+	// run knows which ones finished.
 	for _, task := range dependent {
 		if err := r.executeSingleTask(plan, &task); err != nil {
 			// Mark task as failed but continue with others
 			r.updateTaskStatus(plan, task.ID, orchestrator.TaskFailed)
 		}
+		if err := r.savePlan(plan); err != nil {
+			return err
+		}
 	}
 
-	// Save updated plan
-	if r.planStore != nil {
-		if err := r.planStore.SavePlan(plan); err != nil {
-			return fmt.Errorf("save plan: %w", err)
+	return nil
+}
+
+// ResumeExecution retries failed tasks and tasks an interrupted run left in
+// progress, then runs the rest of the plan. Completed and skipped tasks are
+// not run again.
+func (r *Runner) ResumeExecution() error {
+	r.mu.Lock()
+	plan := r.currentPlan
+	if plan != nil {
+		for i := range plan.Tasks {
+			switch plan.Tasks[i].Status {
+			case orchestrator.TaskFailed, orchestrator.TaskInProgress:
+				plan.Tasks[i].Status = orchestrator.TaskPending
+			}
 		}
 	}
+	r.mu.Unlock()
+
+	return r.ExecutePlan()
+}
 
+func (r *Runner) savePlan(plan *orchestrator.Plan) error {
+	if r.planStore == nil {
+		return nil
+	}
+	if err := r.planStore.SavePlan(plan); err != nil {
+		return fmt.Errorf("save plan: %w", err)
+	}
 	return nil
 }
 
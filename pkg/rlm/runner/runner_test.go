@@ -338,3 +338,31 @@ func TestRunner_ExecutePlan_AllTasksComplete(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "initialize runtime")
 }
+
+func TestRunner_ResumeExecution_RequeuesFailedTasks(t *testing.T) {
+	planStore := newMockPlanStore()
+	planStore.plans["plan-1"] = &orchestrator.Plan{
+		ID: "plan-1",
+		Tasks: []orchestrator.Task{
+			{ID: "task-1", Status: orchestrator.TaskCompleted},
+			{ID: "task-2", Status: orchestrator.TaskFailed},
+			{ID: "task-3", Status: orchestrator.TaskInProgress},
+			{ID: "task-4", Status: orchestrator.TaskSkipped},
+		},
+	}
+
+	runner := New(nil, nil, nil, nil, nil, planStore)
+	_, _ = runner.LoadPlan("plan-1")
+
+	// Without a model manager the runtime cannot start, but the plan is
+	// requeued before execution begins.
+	err := runner.ResumeExecution()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "initialize runtime")
+
+	plan := runner.GetCurrentPlan()
+	assert.Equal(t, orchestrator.TaskCompleted, plan.Tasks[0].Status)
+	assert.Equal(t, orchestrator.TaskPending, plan.Tasks[1].Status)
+	assert.Equal(t, orchestrator.TaskPending, plan.Tasks[2].Status)
+	assert.Equal(t, orchestrator.TaskSkipped, plan.Tasks[3].Status)
+}
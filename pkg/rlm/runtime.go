@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"m31labs.dev/buckley/pkg/bus"
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/coordination/security"
 	"m31labs.dev/buckley/pkg/encoding/toon"
@@ -117,7 +118,8 @@ type RuntimeDeps struct {
 	SessionID    string
 	UseToon      bool // Use TOON encoding for compact tool results
 	Engine       *rules.Engine
-	GraftClient  *graft.Client // Optional graft coordination client
+	GraftClient  *graft.Client  // Optional graft coordination client
+	ToolConfig   *config.Config // Tool policy for the default registry when Registry is nil
 }
 
 // Runtime is the RLM execution engine.
@@ -140,6 +142,17 @@ type Runtime struct {
 	hooks   []IterationHook
 }
 
+// runtimeRegistry returns deps.Registry, or a default registry with the
+// configured tool policy applied.
+func runtimeRegistry(deps RuntimeDeps) *tool.Registry {
+	if deps.Registry != nil {
+		return deps.Registry
+	}
+	registry := tool.NewRegistry()
+	tool.ApplyToolMiddlewareConfig(registry, deps.ToolConfig)
+	return registry
+}
+
 // NewRuntime wires the runtime dependencies together.
 func NewRuntime(cfg Config, deps RuntimeDeps) (*Runtime, error) {
 	cfg.Normalize()
@@ -147,10 +160,7 @@ func NewRuntime(cfg Config, deps RuntimeDeps) (*Runtime, error) {
 		return nil, fmt.Errorf("model manager required")
 	}
 
-	registry := deps.Registry
-	if registry == nil {
-		registry = tool.NewRegistry()
-	}
+	registry := runtimeRegistry(deps)
 
 	router, err := NewModelRouterFromManager(deps.Models, cfg)
 	if err != nil {
@@ -0,0 +1,31 @@
+package rlm
+
+import (
+	"testing"
+
+	"m31labs.dev/buckley/pkg/config"
+)
+
+func TestRuntimeRegistryAppliesToolPolicy(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"read_file"}
+
+	registry := runtimeRegistry(RuntimeDeps{ToolConfig: cfg})
+	if !registry.RequiresApproval("read_file") {
+		t.Fatal("default registry ignored tools.dangerous")
+	}
+}
+
+func TestRuntimeRegistryAppliesAllowAndDenyLists(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ToolMiddleware.AllowList = []string{"read_file", "run_shell"}
+	cfg.ToolMiddleware.DenyList = []string{"run_shell"}
+
+	registry := runtimeRegistry(RuntimeDeps{ToolConfig: cfg})
+	if !registry.PolicyAllows("read_file") {
+		t.Fatal("default registry refused an allowed tool")
+	}
+	if registry.PolicyAllows("run_shell") || registry.PolicyAllows("write_file") {
+		t.Fatal("default registry ignored tool_middleware allow/deny lists")
+	}
+}
@@ -52,7 +52,22 @@ func (a *Agent) ExecutePlan(ctx context.Context, planID string) error {
 			return err
 		}
 	}
-	return a.orchestrator.ExecutePlan()
+	return a.orchestrator.ExecutePlanWithProgress(ctx, nil)
+}
+
+// ExecutePlanWithProgress is ExecutePlan with a callback for each task's start
+// and finish, so callers can render their own progress. onEvent is called
+// synchronously in task order; a panic in it does not stop execution.
+func (a *Agent) ExecutePlanWithProgress(ctx context.Context, planID string, onEvent func(orchestrator.TaskEvent)) error {
+	if a.orchestrator == nil {
+		return fmt.Errorf("orchestrator not configured")
+	}
+	if planID != "" {
+		if _, err := a.orchestrator.LoadPlan(planID); err != nil {
+			return err
+		}
+	}
+	return a.orchestrator.ExecutePlanWithProgress(ctx, onEvent)
 }
 
 // ListPlans returns all persisted plans.
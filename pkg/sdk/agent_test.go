@@ -60,3 +60,27 @@ func TestAgent_GetPlan_NilOrchestrator(t *testing.T) {
 		t.Error("expected error for nil orchestrator")
 	}
 }
+
+func TestAgent_ExecutePlanWithProgress_NilOrchestrator(t *testing.T) {
+	agent := &Agent{}
+
+	err := agent.ExecutePlanWithProgress(context.Background(), "plan-id", func(orchestrator.TaskEvent) {})
+	if err == nil {
+		t.Error("expected error for nil orchestrator")
+	}
+}
+
+func TestAgent_ExecutePlanWithProgress_NoPlanLoaded(t *testing.T) {
+	agent := &Agent{orchestrator: &orchestrator.Orchestrator{}}
+
+	called := false
+	err := agent.ExecutePlanWithProgress(context.Background(), "", func(orchestrator.TaskEvent) { called = true })
+	if err == nil || err.Error() != "no plan loaded" {
+		t.Errorf("expected no plan loaded error, got %v", err)
+	}
+	if called {
+		t.Error("callback invoked without a plan")
+	}
+}
+
+var _ Service = (*Agent)(nil)
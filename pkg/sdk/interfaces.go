@@ -23,9 +23,15 @@ type Executor interface {
 	ExecutePlan(ctx context.Context, planID string) error
 }
 
+// ProgressExecutor executes a plan and reports each task's start and finish.
+type ProgressExecutor interface {
+	ExecutePlanWithProgress(ctx context.Context, planID string, onEvent func(orchestrator.TaskEvent)) error
+}
+
 // Service is the full SDK surface Buckley exposes today.
 type Service interface {
 	Planner
 	PlanLoader
 	Executor
+	ProgressExecutor
 }
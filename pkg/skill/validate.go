@@ -0,0 +1,226 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// skillPhases are the workflow phases that auto-activate phase skills.
+var skillPhases = []string{"planning", "execute", "review"}
+
+// skillFrontmatterFields lists every frontmatter key the loader reads. Other
+// keys are ignored at load time, so validation reports them as likely typos.
+var skillFrontmatterFields = map[string]bool{
+	"name":          true,
+	"description":   true,
+	"allowed-tools": true,
+	"allowed_tools": true,
+	"license":       true,
+	"compatibility": true,
+	"metadata":      true,
+	"phase":         true,
+	"requires_todo": true,
+	"priority":      true,
+	"model":         true,
+	"todo_template": true,
+}
+
+// ValidationIssue is one problem found in a skill file.
+type ValidationIssue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FileValidation holds the issues found in a single skill file.
+type FileValidation struct {
+	Path   string            `json:"path"`
+	Name   string            `json:"name,omitempty"`
+	Issues []ValidationIssue `json:"issues,omitempty"`
+}
+
+// Valid reports whether the skill file has no issues.
+func (v FileValidation) Valid() bool {
+	return len(v.Issues) == 0
+}
+
+func (v *FileValidation) add(field, format string, args ...any) {
+	v.Issues = append(v.Issues, ValidationIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateOptions configures skill validation.
+type ValidateOptions struct {
+	// KnownTools are the tool names allowed_tools may reference. When empty,
+	// tool references are only checked for syntax.
+	KnownTools []string
+}
+
+// ValidatePath validates a skill file, or every skill file under a directory
+// using the same layout rules as the loader: SKILL.md packages and loose
+// markdown files named after their relative path.
+func ValidatePath(path string, opts ValidateOptions) ([]FileValidation, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat skill path: %w", err)
+	}
+	if !info.IsDir() {
+		return []FileValidation{ValidateFile(path, skillNameFromFile(path), opts)}, nil
+	}
+	if hasAgentSkillPackage(path) {
+		skillFile := filepath.Join(path, "SKILL.md")
+		return []FileValidation{ValidateFile(skillFile, skillNameFromFile(skillFile), opts)}, nil
+	}
+
+	var results []FileValidation
+	walkErr := filepath.WalkDir(path, func(current string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			if current != path && hasAgentSkillPackage(current) {
+				name, err := agentSkillNameFromPath(path, current)
+				if err != nil {
+					return err
+				}
+				results = append(results, ValidateFile(filepath.Join(current, "SKILL.md"), name, opts))
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+			return nil
+		}
+		if strings.EqualFold(entry.Name(), "SKILL.md") {
+			return nil
+		}
+		name, err := agentSkillNameFromPath(path, strings.TrimSuffix(current, filepath.Ext(current)))
+		if err != nil {
+			return err
+		}
+		results = append(results, ValidateFile(current, name, opts))
+		return nil
+	})
+	if walkErr != nil {
+		return results, fmt.Errorf("read skills directory: %w", walkErr)
+	}
+	return results, nil
+}
+
+// ProjectSkillDirs returns the project skill directories the loader reads
+// from start: ./.buckley/skills and any ancestor agent/skills roots.
+func ProjectSkillDirs(start string) ([]string, error) {
+	var dirs []string
+	legacy := filepath.Join(start, ".buckley", "skills")
+	if info, err := os.Stat(legacy); err == nil && info.IsDir() {
+		dirs = append(dirs, legacy)
+	}
+	agentDirs, err := findProjectAgentSkillDirs(start)
+	if err != nil {
+		return dirs, err
+	}
+	return append(dirs, agentDirs...), nil
+}
+
+// ValidateFile checks one skill file for required fields, a known phase, and
+// resolvable tool references. fallbackName is used when the frontmatter does
+// not declare a name, matching how agent skill packages are loaded.
+func ValidateFile(path, fallbackName string, opts ValidateOptions) FileValidation {
+	result := FileValidation{Path: path, Name: strings.TrimSpace(fallbackName)}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		result.add("file", "read skill file: %v", err)
+		return result
+	}
+
+	frontmatter, body, ok, err := splitOptionalYAMLFrontmatter(string(content))
+	if err != nil {
+		result.add("frontmatter", "%v", err)
+		return result
+	}
+
+	var s Skill
+	if ok {
+		var fields map[string]any
+		if err := yaml.Unmarshal([]byte(frontmatter), &fields); err != nil {
+			result.add("frontmatter", "invalid YAML: %v", err)
+			return result
+		}
+		if err := yaml.Unmarshal([]byte(frontmatter), &s); err != nil {
+			result.add("frontmatter", "%v", err)
+			return result
+		}
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			if !skillFrontmatterFields[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result.add(key, "unknown frontmatter field %q is ignored by the loader", key)
+		}
+	}
+
+	if name := strings.TrimSpace(s.Name); name != "" {
+		result.Name = name
+	}
+	switch {
+	case result.Name == "":
+		result.add("name", "name is required")
+	case len(result.Name) > 64:
+		result.add("name", "name must be 64 characters or less")
+	}
+
+	description := strings.TrimSpace(s.Description)
+	switch {
+	case description == "":
+		result.add("description", "description is required so the model knows when to use the skill")
+	case len(description) > 1024:
+		result.add("description", "description must be 1024 characters or less")
+	}
+
+	if strings.TrimSpace(body) == "" {
+		result.add("body", "skill body is empty")
+	}
+
+	if phase := strings.TrimSpace(s.Phase); phase != "" && !slices.Contains(skillPhases, phase) {
+		result.add("phase", "unknown phase %q (use %s)", phase, strings.Join(skillPhases, ", "))
+	}
+
+	known := make(map[string]bool, len(opts.KnownTools))
+	for _, name := range opts.KnownTools {
+		known[name] = true
+	}
+	for _, name := range s.AllowedTools {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			result.add("allowed_tools", "tool name is empty")
+		case strings.ContainsAny(name, " \t,"):
+			result.add("allowed_tools", "tool name %q must be a single tool name", name)
+		case len(known) > 0 && !known[name]:
+			result.add("allowed_tools", "unknown tool %q", name)
+		}
+	}
+	for _, name := range s.PreapprovedTools {
+		if strings.TrimSpace(name) == "" {
+			result.add("allowed-tools", "tool name is empty")
+		}
+	}
+	return result
+}
+
+func skillNameFromFile(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if strings.EqualFold(filepath.Base(path), "SKILL.md") {
+		return filepath.Base(filepath.Dir(path))
+	}
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
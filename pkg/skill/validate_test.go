@@ -0,0 +1,220 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSkillFixture(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir fixture: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+}
+
+func issueFields(v FileValidation) []string {
+	fields := make([]string, 0, len(v.Issues))
+	for _, issue := range v.Issues {
+		fields = append(fields, issue.Field)
+	}
+	return fields
+}
+
+func TestValidateFile(t *testing.T) {
+	opts := ValidateOptions{KnownTools: []string{"read_file", "search_text"}}
+	tests := []struct {
+		name       string
+		file       string
+		content    string
+		wantName   string
+		wantFields []string
+		wantText   string
+	}{
+		{
+			name: "valid skill",
+			file: "triage/SKILL.md",
+			content: `---
+description: Triage ambiguous work before editing.
+phase: planning
+allowed_tools: [read_file, search_text]
+---
+
+# Triage
+
+Inspect the current state first.
+`,
+			wantName: "triage",
+		},
+		{
+			name: "missing description",
+			file: "no-description.md",
+			content: `---
+name: no-description
+---
+
+Body text.
+`,
+			wantName:   "no-description",
+			wantFields: []string{"description"},
+		},
+		{
+			name: "empty body",
+			file: "empty/SKILL.md",
+			content: `---
+name: empty
+description: Has no instructions.
+---
+`,
+			wantName:   "empty",
+			wantFields: []string{"body"},
+		},
+		{
+			name: "unknown phase",
+			file: "deploy.md",
+			content: `---
+name: deploy
+description: Ship it.
+phase: deploy
+---
+
+Deploy steps.
+`,
+			wantName:   "deploy",
+			wantFields: []string{"phase"},
+			wantText:   `unknown phase "deploy"`,
+		},
+		{
+			name: "unknown tool",
+			file: "tools.md",
+			content: `---
+name: tools
+description: References a missing tool.
+allowed_tools: [read_file, launch_rockets, ""]
+---
+
+Use the tools.
+`,
+			wantName:   "tools",
+			wantFields: []string{"allowed_tools", "allowed_tools"},
+			wantText:   `unknown tool "launch_rockets"`,
+		},
+		{
+			name: "unknown frontmatter field",
+			file: "typo.md",
+			content: `---
+name: typo
+description: Misspelled tool list.
+allowed_tool: [read_file]
+---
+
+Body.
+`,
+			wantName:   "typo",
+			wantFields: []string{"allowed_tool"},
+		},
+		{
+			name:       "invalid yaml",
+			file:       "broken.md",
+			content:    "---\nname: [broken\n---\n\nBody.\n",
+			wantName:   "broken",
+			wantFields: []string{"frontmatter"},
+		},
+		{
+			name:       "unterminated frontmatter",
+			file:       "open.md",
+			content:    "---\nname: open\n",
+			wantName:   "open",
+			wantFields: []string{"frontmatter"},
+		},
+		{
+			name:       "no frontmatter",
+			file:       "plain.md",
+			content:    "# Plain\n\nJust markdown.\n",
+			wantName:   "plain",
+			wantFields: []string{"description"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), filepath.FromSlash(tt.file))
+			writeSkillFixture(t, path, tt.content)
+
+			got := ValidateFile(path, skillNameFromFile(path), opts)
+			if got.Name != tt.wantName {
+				t.Fatalf("Name = %q, want %q", got.Name, tt.wantName)
+			}
+			fields := issueFields(got)
+			if strings.Join(fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Fatalf("issue fields = %v, want %v (issues: %+v)", fields, tt.wantFields, got.Issues)
+			}
+			if got.Valid() != (len(tt.wantFields) == 0) {
+				t.Fatalf("Valid() = %v with issues %+v", got.Valid(), got.Issues)
+			}
+			if tt.wantText != "" && !strings.Contains(got.Issues[0].Message, tt.wantText) {
+				t.Fatalf("first issue = %q, want it to mention %q", got.Issues[0].Message, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestValidateFileWithoutKnownToolsChecksSyntaxOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.md")
+	writeSkillFixture(t, path, `---
+name: tools
+description: Uses plugin tools.
+allowed_tools: ["plugin_tool", "read_file, search_text"]
+---
+
+Body.
+`)
+
+	got := ValidateFile(path, "tools", ValidateOptions{})
+	if len(got.Issues) != 1 || !strings.Contains(got.Issues[0].Message, "single tool name") {
+		t.Fatalf("issues = %+v, want only the comma-joined tool name flagged", got.Issues)
+	}
+}
+
+func TestValidatePathWalksSkillLayouts(t *testing.T) {
+	root := t.TempDir()
+	writeSkillFixture(t, filepath.Join(root, "triage", "SKILL.md"), "---\ndescription: Triage work.\n---\n\nSteps.\n")
+	writeSkillFixture(t, filepath.Join(root, "triage", "references", "notes.md"), "Not a skill.\n")
+	writeSkillFixture(t, filepath.Join(root, "nested", "release.md"), "---\ndescription: Release work.\n---\n\nSteps.\n")
+	writeSkillFixture(t, filepath.Join(root, "broken.md"), "---\nname: broken\n---\n")
+	writeSkillFixture(t, filepath.Join(root, "README.txt"), "ignored\n")
+
+	results, err := ValidatePath(root, ValidateOptions{})
+	if err != nil {
+		t.Fatalf("ValidatePath: %v", err)
+	}
+	byName := map[string]FileValidation{}
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+	if len(results) != 3 {
+		t.Fatalf("validated %d files, want 3: %+v", len(results), results)
+	}
+	if !byName["triage"].Valid() || !byName["nested/release"].Valid() {
+		t.Fatalf("expected triage and nested/release to be valid: %+v", results)
+	}
+	if fields := issueFields(byName["broken"]); strings.Join(fields, ",") != "description,body" {
+		t.Fatalf("broken issues = %v, want description and body", fields)
+	}
+
+	single, err := ValidatePath(filepath.Join(root, "triage"), ValidateOptions{})
+	if err != nil {
+		t.Fatalf("ValidatePath package: %v", err)
+	}
+	if len(single) != 1 || single[0].Name != "triage" || !single[0].Valid() {
+		t.Fatalf("unexpected package validation: %+v", single)
+	}
+
+	if _, err := ValidatePath(filepath.Join(root, "missing"), ValidateOptions{}); err == nil {
+		t.Fatal("expected error for missing path")
+	}
+}
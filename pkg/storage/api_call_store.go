@@ -17,7 +17,11 @@ type APICall struct {
 }
 
 // SaveAPICall records an API call and updates the owning session's total cost.
+// Ephemeral stores have no session rows to charge, so nothing is written.
 func (s *Store) SaveAPICall(call *APICall) error {
+	if s.Ephemeral() {
+		return nil
+	}
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -107,3 +111,24 @@ func (s *Store) GetMonthlyCostForPrincipal(principal string) (float64, error) {
 	err := s.db.QueryRow(query, principal).Scan(&cost)
 	return cost, err
 }
+
+// APIUsage totals the API calls recorded for one session.
+type APIUsage struct {
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	Cost             float64 `json:"cost"`
+}
+
+// GetSessionAPIUsage sums the API calls recorded for a session. Sessions
+// without calls yield a zero APIUsage.
+func (s *Store) GetSessionAPIUsage(sessionID string) (APIUsage, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(cost), 0)
+		FROM api_calls
+		WHERE session_id = ?
+	`
+	var usage APIUsage
+	err := s.db.QueryRow(query, sessionID).Scan(&usage.Calls, &usage.PromptTokens, &usage.CompletionTokens, &usage.Cost)
+	return usage, err
+}
@@ -71,4 +71,15 @@ func TestAPICallStoreTracking(t *testing.T) {
 	if principalMonthly != 0 {
 		t.Fatalf("expected no spend for empty principal, got %f", principalMonthly)
 	}
+
+	usage, err := store.GetSessionAPIUsage(session.ID)
+	if err != nil {
+		t.Fatalf("session api usage: %v", err)
+	}
+	if usage.Calls != 1 || usage.PromptTokens != 100 || usage.CompletionTokens != 50 || usage.Cost < 1.23 {
+		t.Fatalf("unexpected session usage %+v", usage)
+	}
+	if empty, err := store.GetSessionAPIUsage("missing"); err != nil || empty != (APIUsage{}) {
+		t.Fatalf("expected zero usage for session without calls, got %+v err=%v", empty, err)
+	}
 }
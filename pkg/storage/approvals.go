@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -58,8 +60,17 @@ type ToolAuditEntry struct {
 	DecidedBy  string    `json:"decided_by,omitempty"`
 	ExecutedAt time.Time `json:"executed_at"`
 	DurationMs int64     `json:"duration_ms"`
+
+	// ArgsHash identifies identical calls without exposing arguments; it is
+	// derived from ToolInput when empty.
+	ArgsHash      string `json:"args_hash,omitempty"`
+	Success       bool   `json:"success"`
+	ResultSummary string `json:"result_summary,omitempty"`
 }
 
+// maxToolResultSummaryChars bounds the stored one-line result summary.
+const maxToolResultSummaryChars = 200
+
 // GetActivePolicy returns the currently active approval policy
 func (s *Store) GetActivePolicy() (*ApprovalPolicy, error) {
 	if s.db == nil {
@@ -460,23 +471,36 @@ func (s *Store) ExpirePendingApprovals() (int, error) {
 	return int(count), nil
 }
 
-// LogToolExecution logs a tool execution to the audit log
+// LogToolExecution logs a tool execution to the audit log. It is a no-op for
+// ephemeral stores, which would otherwise keep tool inputs and outputs on disk.
 func (s *Store) LogToolExecution(entry *ToolAuditEntry) error {
 	if s.db == nil {
 		return ErrStoreClosed
 	}
+	if s.Ephemeral() {
+		return nil
+	}
 
 	var approvalID any
 	if entry.ApprovalID != "" {
 		approvalID = entry.ApprovalID
 	}
+	if entry.ArgsHash == "" {
+		sum := sha256.Sum256([]byte(entry.ToolInput))
+		entry.ArgsHash = hex.EncodeToString(sum[:])
+	}
+	if entry.ResultSummary == "" {
+		entry.ResultSummary = summarizeToolOutput(entry.ToolOutput)
+	}
 
 	result, err := s.db.Exec(`
 		INSERT INTO tool_audit_log (session_id, approval_id, tool_name, tool_input, tool_output,
-		                            risk_score, decision, decided_by, executed_at, duration_ms)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                            risk_score, decision, decided_by, executed_at, duration_ms,
+		                            args_hash, success, result_summary)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, entry.SessionID, approvalID, entry.ToolName, entry.ToolInput, entry.ToolOutput,
-		entry.RiskScore, entry.Decision, entry.DecidedBy, entry.ExecutedAt, entry.DurationMs)
+		entry.RiskScore, entry.Decision, entry.DecidedBy, entry.ExecutedAt, entry.DurationMs,
+		entry.ArgsHash, entry.Success, entry.ResultSummary)
 	if err != nil {
 		return fmt.Errorf("log tool execution: %w", err)
 	}
@@ -497,7 +521,8 @@ func (s *Store) GetAuditLog(sessionID string, limit int) ([]*ToolAuditEntry, err
 
 	rows, err := s.db.Query(`
 		SELECT id, session_id, approval_id, tool_name, tool_input, tool_output,
-		       risk_score, decision, decided_by, executed_at, duration_ms
+		       risk_score, decision, decided_by, executed_at, duration_ms,
+		       args_hash, success, result_summary
 		FROM tool_audit_log
 		WHERE session_id = ?
 		ORDER BY executed_at DESC
@@ -511,13 +536,18 @@ func (s *Store) GetAuditLog(sessionID string, limit int) ([]*ToolAuditEntry, err
 	var entries []*ToolAuditEntry
 	for rows.Next() {
 		var entry ToolAuditEntry
-		var approvalID, toolOutput, decidedBy sql.NullString
+		var approvalID, toolOutput, decidedBy, argsHash, resultSummary sql.NullString
+		var success sql.NullBool
 
 		if err := rows.Scan(&entry.ID, &entry.SessionID, &approvalID, &entry.ToolName,
 			&entry.ToolInput, &toolOutput, &entry.RiskScore, &entry.Decision,
-			&decidedBy, &entry.ExecutedAt, &entry.DurationMs); err != nil {
+			&decidedBy, &entry.ExecutedAt, &entry.DurationMs,
+			&argsHash, &success, &resultSummary); err != nil {
 			return nil, fmt.Errorf("scan audit entry: %w", err)
 		}
+		entry.ArgsHash = argsHash.String
+		entry.Success = success.Bool
+		entry.ResultSummary = resultSummary.String
 
 		if approvalID.Valid {
 			entry.ApprovalID = approvalID.String
@@ -586,3 +616,60 @@ func ensurePendingApprovalsSchema(db *sql.DB) error {
 
 	return nil
 }
+
+// summarizeToolOutput returns the first non-empty line of a tool result,
+// trimmed to maxToolResultSummaryChars.
+func summarizeToolOutput(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		runes := []rune(line)
+		if len(runes) > maxToolResultSummaryChars {
+			return string(runes[:maxToolResultSummaryChars]) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+func ensureToolAuditSchema(db *sql.DB) error {
+	rows, err := db.Query(`PRAGMA table_info(tool_audit_log)`)
+	if err != nil {
+		return fmt.Errorf("tool audit pragma: %w", err)
+	}
+	defer rows.Close()
+
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var cid int
+		var name, ctype string
+		var notNull int
+		var dflt any
+		var pk int
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &dflt, &pk); err != nil {
+			return fmt.Errorf("scan tool audit pragma: %w", err)
+		}
+		cols[strings.ToLower(name)] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, col := range []struct{ name, ddl string }{
+		{"args_hash", `ALTER TABLE tool_audit_log ADD COLUMN args_hash TEXT`},
+		{"success", `ALTER TABLE tool_audit_log ADD COLUMN success INTEGER`},
+		{"result_summary", `ALTER TABLE tool_audit_log ADD COLUMN result_summary TEXT`},
+	} {
+		if cols[col.name] {
+			continue
+		}
+		if _, err := db.Exec(col.ddl); err != nil {
+			return fmt.Errorf("add tool_audit_log.%s: %w", col.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func ensureEmbeddingCacheSchema(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS embedding_cache (
+		content_hash TEXT NOT NULL,
+		model TEXT NOT NULL,
+		embedding BLOB NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (content_hash, model)
+	)`); err != nil {
+		return fmt.Errorf("create embedding_cache: %w", err)
+	}
+	return nil
+}
+
+// GetCachedEmbedding returns the stored embedding for contentHash under
+// model. It returns nil without error when nothing is cached.
+func (s *Store) GetCachedEmbedding(ctx context.Context, contentHash, model string) ([]byte, error) {
+	if s == nil || s.db == nil {
+		return nil, ErrStoreClosed
+	}
+	var embedding []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT embedding FROM embedding_cache WHERE content_hash = ? AND model = ?`,
+		strings.TrimSpace(contentHash), strings.TrimSpace(model),
+	).Scan(&embedding)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load cached embedding: %w", err)
+	}
+	return embedding, nil
+}
+
+// SaveCachedEmbedding stores embedding for contentHash under model,
+// replacing any earlier value.
+func (s *Store) SaveCachedEmbedding(ctx context.Context, contentHash, model string, embedding []byte) error {
+	if s == nil || s.db == nil {
+		return ErrStoreClosed
+	}
+	contentHash = strings.TrimSpace(contentHash)
+	if contentHash == "" || len(embedding) == 0 {
+		return fmt.Errorf("content hash and embedding required")
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO embedding_cache (content_hash, model, embedding, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(content_hash, model) DO UPDATE SET
+			embedding = excluded.embedding,
+			created_at = excluded.created_at`,
+		contentHash, strings.TrimSpace(model), embedding, sqliteTimestamp(time.Now()),
+	)
+	if err != nil {
+		return fmt.Errorf("save cached embedding: %w", err)
+	}
+	return nil
+}
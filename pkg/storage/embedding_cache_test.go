@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddingCacheRoundTrip(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "embedding-cache.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	ctx := context.Background()
+
+	if got, err := store.GetCachedEmbedding(ctx, "abc", "model-a"); err != nil || got != nil {
+		t.Fatalf("empty GetCachedEmbedding = %v, %v", got, err)
+	}
+	if err := store.SaveCachedEmbedding(ctx, "abc", "model-a", []byte{1, 2}); err != nil {
+		t.Fatalf("SaveCachedEmbedding: %v", err)
+	}
+	if err := store.SaveCachedEmbedding(ctx, "abc", "model-a", []byte{3, 4}); err != nil {
+		t.Fatalf("overwrite SaveCachedEmbedding: %v", err)
+	}
+	if got, err := store.GetCachedEmbedding(ctx, "abc", "model-a"); err != nil || !bytes.Equal(got, []byte{3, 4}) {
+		t.Fatalf("GetCachedEmbedding = %v, %v", got, err)
+	}
+	if got, err := store.GetCachedEmbedding(ctx, "abc", "model-b"); err != nil || got != nil {
+		t.Fatalf("other model GetCachedEmbedding = %v, %v", got, err)
+	}
+}
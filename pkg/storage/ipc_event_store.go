@@ -19,7 +19,11 @@ type IPCEvent struct {
 }
 
 // SaveIPCEvent appends an event and bounds retained history per session.
+// It is a no-op when telemetry persistence is disabled.
 func (s *Store) SaveIPCEvent(event IPCEvent) error {
+	if !s.TelemetryPersistenceEnabled() {
+		return nil
+	}
 	event.ID = strings.TrimSpace(event.ID)
 	event.Type = strings.TrimSpace(event.Type)
 	if event.ID == "" || event.Type == "" {
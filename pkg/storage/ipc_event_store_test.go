@@ -36,3 +36,30 @@ func TestIPCEventStoreReplay(t *testing.T) {
 		t.Fatalf("payload=%s", events[0].Payload)
 	}
 }
+
+func TestIPCEventStoreSkipsWritesWhenTelemetryDisabled(t *testing.T) {
+	for name, configure := range map[string]func(*Store){
+		"telemetry disabled": func(s *Store) { s.SetTelemetryPersistence(false) },
+		"ephemeral":          func(s *Store) { s.SetEphemeral(true) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			store, err := New(filepath.Join(t.TempDir(), "events.db"))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			t.Cleanup(func() { _ = store.Close() })
+			configure(store)
+
+			if err := store.SaveIPCEvent(IPCEvent{ID: "01j00000000000000000000001", SessionID: "s1", Type: "command.started"}); err != nil {
+				t.Fatalf("SaveIPCEvent: %v", err)
+			}
+			var rows int
+			if err := store.DB().QueryRow(`SELECT COUNT(*) FROM ipc_events`).Scan(&rows); err != nil {
+				t.Fatalf("count ipc_events: %v", err)
+			}
+			if rows != 0 {
+				t.Fatalf("ipc_events rows = %d, want 0", rows)
+			}
+		})
+	}
+}
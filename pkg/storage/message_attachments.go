@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MessageAttachment records a file attached to a session message. MessageID
+// is zero once the message is no longer in the stored transcript, which
+// happens when compaction sets the attachment aside.
+type MessageAttachment struct {
+	ID          int64     `json:"id"`
+	SessionID   string    `json:"sessionId"`
+	MessageID   int64     `json:"messageId,omitempty"`
+	Path        string    `json:"path"`
+	ContentHash string    `json:"contentHash"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AttachmentContentHash returns the hex SHA-256 digest stored as an
+// attachment's ContentHash.
+func AttachmentContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// SaveMessageAttachment inserts an attachment and sets its ID.
+func (s *Store) SaveMessageAttachment(att *MessageAttachment) error {
+	if att == nil {
+		return fmt.Errorf("attachment required")
+	}
+	att.SessionID = strings.TrimSpace(att.SessionID)
+	att.Path = strings.TrimSpace(att.Path)
+	if att.SessionID == "" || att.Path == "" {
+		return fmt.Errorf("attachment session id and path required")
+	}
+	if att.CreatedAt.IsZero() {
+		att.CreatedAt = time.Now()
+	}
+	result, err := s.db.Exec(`
+		INSERT INTO message_attachments (session_id, message_id, path, content_hash, size_bytes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		att.SessionID, nullIfZero(att.MessageID), att.Path, att.ContentHash, att.SizeBytes, sqliteTimestamp(att.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("save message attachment: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("save message attachment: last insert id: %w", err)
+	}
+	att.ID = id
+	return nil
+}
+
+// LinkMessageAttachment points an attachment at the stored message that
+// carries its content. A messageID of zero marks it as set aside.
+func (s *Store) LinkMessageAttachment(id, messageID int64) error {
+	if _, err := s.db.Exec(`UPDATE message_attachments SET message_id = ? WHERE id = ?`, nullIfZero(messageID), id); err != nil {
+		return fmt.Errorf("link message attachment: %w", err)
+	}
+	return nil
+}
+
+// ListMessageAttachments returns a session's attachments oldest first.
+func (s *Store) ListMessageAttachments(sessionID string) ([]MessageAttachment, error) {
+	rows, err := s.db.Query(`
+		SELECT id, session_id, message_id, path, content_hash, size_bytes, created_at
+		FROM message_attachments
+		WHERE session_id = ?
+		ORDER BY id ASC`, strings.TrimSpace(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("list message attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	attachments := make([]MessageAttachment, 0)
+	for rows.Next() {
+		var att MessageAttachment
+		var messageID sql.NullInt64
+		var createdAt string
+		if err := rows.Scan(&att.ID, &att.SessionID, &messageID, &att.Path, &att.ContentHash, &att.SizeBytes, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan message attachment: %w", err)
+		}
+		att.MessageID = messageID.Int64
+		att.CreatedAt = parseSQLiteTimestamp(createdAt)
+		attachments = append(attachments, att)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate message attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func nullIfZero(value int64) any {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMessageAttachmentLifecycle(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "attachments.db"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	if err := store.EnsureSession("session-1"); err != nil {
+		t.Fatalf("EnsureSession: %v", err)
+	}
+	msg := &Message{SessionID: "session-1", Role: "user", Content: "Attached file: main.go"}
+	if err := store.SaveMessage(msg); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	att := &MessageAttachment{
+		SessionID:   "session-1",
+		MessageID:   msg.ID,
+		Path:        "main.go",
+		ContentHash: AttachmentContentHash([]byte("package main\n")),
+		SizeBytes:   13,
+	}
+	if err := store.SaveMessageAttachment(att); err != nil {
+		t.Fatalf("SaveMessageAttachment: %v", err)
+	}
+	if att.ID == 0 {
+		t.Fatal("SaveMessageAttachment did not set ID")
+	}
+
+	rows, err := store.ListMessageAttachments("session-1")
+	if err != nil {
+		t.Fatalf("ListMessageAttachments: %v", err)
+	}
+	if len(rows) != 1 || rows[0].MessageID != msg.ID || rows[0].ContentHash != att.ContentHash || rows[0].CreatedAt.IsZero() {
+		t.Fatalf("attachments = %+v", rows)
+	}
+
+	// Replacing the transcript drops the message, leaving the attachment set aside.
+	replacement := []Message{{SessionID: "session-1", Role: "user", Content: "summary"}}
+	if err := store.ReplaceMessages("session-1", replacement); err != nil {
+		t.Fatalf("ReplaceMessages: %v", err)
+	}
+	if replacement[0].ID == 0 {
+		t.Fatal("ReplaceMessages did not write back message IDs")
+	}
+	rows, err = store.ListMessageAttachments("session-1")
+	if err != nil {
+		t.Fatalf("ListMessageAttachments: %v", err)
+	}
+	if len(rows) != 1 || rows[0].MessageID != 0 {
+		t.Fatalf("attachments after replace = %+v, want message cleared", rows)
+	}
+
+	if err := store.LinkMessageAttachment(att.ID, replacement[0].ID); err != nil {
+		t.Fatalf("LinkMessageAttachment: %v", err)
+	}
+	rows, err = store.ListMessageAttachments("session-1")
+	if err != nil || len(rows) != 1 || rows[0].MessageID != replacement[0].ID {
+		t.Fatalf("attachments after link = %+v, %v", rows, err)
+	}
+
+	if err := store.DeleteSession("session-1"); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	rows, err = store.ListMessageAttachments("session-1")
+	if err != nil || len(rows) != 0 {
+		t.Fatalf("attachments after session delete = %+v, %v", rows, err)
+	}
+}
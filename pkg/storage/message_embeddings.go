@@ -29,7 +29,7 @@ func (s *Store) GetMessagesWithEmbeddings(ctx context.Context, sessionID string)
 		return nil, nil
 	}
 	query := `
-		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), embedding
+		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE), embedding
 		FROM messages
 		WHERE session_id = ? AND embedding IS NOT NULL
 		ORDER BY timestamp ASC
@@ -67,6 +67,7 @@ func (s *Store) GetMessagesWithEmbeddings(ctx context.Context, sessionID string)
 			&msg.Tokens,
 			&msg.IsSummary,
 			&msg.IsTruncated,
+			&msg.IsPinned,
 			&embedding,
 		); err != nil {
 			return nil, err
@@ -98,7 +99,7 @@ func (s *Store) GetMessagesMissingEmbeddings(ctx context.Context, sessionID stri
 		limit = 200
 	}
 	query := `
-		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE)
+		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
 		FROM messages
 		WHERE session_id = ? AND embedding IS NULL
 		ORDER BY timestamp ASC
@@ -136,6 +137,7 @@ func (s *Store) GetMessagesMissingEmbeddings(ctx context.Context, sessionID stri
 			&msg.Tokens,
 			&msg.IsSummary,
 			&msg.IsTruncated,
+			&msg.IsPinned,
 		); err != nil {
 			return nil, err
 		}
@@ -154,6 +156,20 @@ func (s *Store) GetMessagesMissingEmbeddings(ctx context.Context, sessionID stri
 
 // SearchMessagesFTS runs a full-text search query against messages.
 func (s *Store) SearchMessagesFTS(ctx context.Context, query, sessionID string, limit int) ([]MessageSearchResult, error) {
+	return s.searchMessagesFTS(ctx, query, sessionID, "", limit)
+}
+
+// SearchMessagesFTSForPrincipal runs a full-text search limited to sessions
+// owned by principal (compared case-insensitively).
+func (s *Store) SearchMessagesFTSForPrincipal(ctx context.Context, query, principal string, limit int) ([]MessageSearchResult, error) {
+	principal = strings.TrimSpace(principal)
+	if principal == "" {
+		return nil, nil
+	}
+	return s.searchMessagesFTS(ctx, query, "", principal, limit)
+}
+
+func (s *Store) searchMessagesFTS(ctx context.Context, query, sessionID, principal string, limit int) ([]MessageSearchResult, error) {
 	if s == nil || s.db == nil {
 		return nil, ErrStoreClosed
 	}
@@ -167,16 +183,17 @@ func (s *Store) SearchMessagesFTS(ctx context.Context, query, sessionID string,
 	sessionID = strings.TrimSpace(sessionID)
 
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT m.id, m.session_id, m.role, m.content, m.content_json, m.content_type, m.tool_calls, m.tool_call_id, m.name, m.reasoning, m.reasoning_details, m.timestamp, m.tokens, m.is_summary, COALESCE(m.is_truncated, FALSE),
+		SELECT m.id, m.session_id, m.role, m.content, m.content_json, m.content_type, m.tool_calls, m.tool_call_id, m.name, m.reasoning, m.reasoning_details, m.timestamp, m.tokens, m.is_summary, COALESCE(m.is_truncated, FALSE), COALESCE(m.is_pinned, FALSE),
 			snippet(messages_fts, 0, '', '', '...', 12) AS snippet,
 			bm25(messages_fts) AS rank
 		FROM messages_fts
 		JOIN messages m ON messages_fts.rowid = m.id
 		WHERE messages_fts MATCH ?
 			AND (? = '' OR m.session_id = ?)
+			AND (? = '' OR m.session_id IN (SELECT session_id FROM sessions WHERE LOWER(TRIM(principal)) = LOWER(?)))
 		ORDER BY rank
 		LIMIT ?
-	`, query, sessionID, sessionID, limit)
+	`, query, sessionID, sessionID, principal, principal, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -210,6 +227,7 @@ func (s *Store) SearchMessagesFTS(ctx context.Context, query, sessionID string,
 			&msg.Tokens,
 			&msg.IsSummary,
 			&msg.IsTruncated,
+			&msg.IsPinned,
 			&snippet,
 			&rank,
 		); err != nil {
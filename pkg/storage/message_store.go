@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -28,6 +29,7 @@ type Message struct {
 	Tokens           int       `json:"tokens"`
 	IsSummary        bool      `json:"isSummary"`
 	IsTruncated      bool      `json:"isTruncated"` // True if message was interrupted/incomplete
+	IsPinned         bool      `json:"isPinned"`    // Kept verbatim when history is trimmed to the context budget
 }
 
 // Cursor represents a pagination cursor for efficient message retrieval.
@@ -139,8 +141,8 @@ func (s *Store) SaveMessage(msg *Message) error {
 
 	now := time.Now()
 	insert := `
-		INSERT INTO messages (session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, is_truncated)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO messages (session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, is_truncated, is_pinned)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	result, err := tx.Exec(insert,
 		msg.SessionID,
@@ -157,6 +159,7 @@ func (s *Store) SaveMessage(msg *Message) error {
 		msg.Tokens,
 		msg.IsSummary,
 		msg.IsTruncated,
+		msg.IsPinned,
 	)
 	if err != nil {
 		return fmt.Errorf("saving message: insert: %w", err)
@@ -196,8 +199,17 @@ func (s *Store) SaveMessage(msg *Message) error {
 	return nil
 }
 
-// ReplaceMessages replaces all messages for a session with the provided set.
+// ReplaceMessages replaces all messages for a session with the provided set
+// and writes the new row IDs back into messages.
 func (s *Store) ReplaceMessages(sessionID string, messages []Message) error {
+	return s.ReplaceMessagesFrom(sessionID, nil, messages)
+}
+
+// ReplaceMessagesFrom replaces a session's messages at or after from (the
+// cursor GetMessagesBefore returned for a loaded window) and keeps the older
+// ones. A nil from replaces every message. New row IDs are written back into
+// messages.
+func (s *Store) ReplaceMessagesFrom(sessionID string, from *Cursor, messages []Message) error {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("replacing messages: begin tx: %w", err)
@@ -209,23 +221,31 @@ func (s *Store) ReplaceMessages(sessionID string, messages []Message) error {
 		}
 	}()
 
-	if _, err = tx.Exec(`DELETE FROM messages WHERE session_id = ?`, sessionID); err != nil {
+	if from == nil {
+		_, err = tx.Exec(`DELETE FROM messages WHERE session_id = ?`, sessionID)
+	} else {
+		fromTimestamp := sqliteTimestamp(from.Timestamp)
+		_, err = tx.Exec(`
+			DELETE FROM messages
+			WHERE session_id = ? AND (timestamp > ? OR (timestamp = ? AND id >= ?))
+		`, sessionID, fromTimestamp, fromTimestamp, from.ID)
+	}
+	if err != nil {
 		return fmt.Errorf("replacing messages: delete old: %w", err)
 	}
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO messages (session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, is_truncated)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO messages (session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, is_truncated, is_pinned)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("replacing messages: prepare insert: %w", err)
 	}
 	defer stmt.Close()
 
-	totalTokens := 0
 	latest := time.Time{}
 
-	for _, msg := range messages {
+	for i, msg := range messages {
 		ts := msg.Timestamp
 		if ts.IsZero() {
 			ts = time.Now()
@@ -233,9 +253,8 @@ func (s *Store) ReplaceMessages(sessionID string, messages []Message) error {
 		if ts.After(latest) {
 			latest = ts
 		}
-		totalTokens += msg.Tokens
 
-		if _, err := stmt.Exec(
+		result, err := stmt.Exec(
 			sessionID,
 			msg.Role,
 			msg.Content,
@@ -250,21 +269,34 @@ func (s *Store) ReplaceMessages(sessionID string, messages []Message) error {
 			msg.Tokens,
 			msg.IsSummary,
 			msg.IsTruncated,
-		); err != nil {
+			msg.IsPinned,
+		)
+		if err != nil {
 			return fmt.Errorf("replacing messages: insert: %w", err)
 		}
+		if messages[i].ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("replacing messages: last insert id: %w", err)
+		}
 	}
 
 	if latest.IsZero() {
 		latest = time.Now()
 	}
 
+	// Kept older rows still count, so recount instead of using len(messages).
+	var messageCount, totalTokens int
+	if err := tx.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(tokens), 0) FROM messages WHERE session_id = ?
+	`, sessionID).Scan(&messageCount, &totalTokens); err != nil {
+		return fmt.Errorf("replacing messages: count messages: %w", err)
+	}
+
 	// Update session stats within the same transaction for atomicity
 	if _, err := tx.Exec(`
 		UPDATE sessions
 		SET message_count = ?, total_tokens = ?, last_active = ?
 		WHERE session_id = ?
-	`, len(messages), totalTokens, sqliteTimestamp(latest), sessionID); err != nil {
+	`, messageCount, totalTokens, sqliteTimestamp(latest), sessionID); err != nil {
 		return fmt.Errorf("replacing messages: update session stats: %w", err)
 	}
 
@@ -274,7 +306,7 @@ func (s *Store) ReplaceMessages(sessionID string, messages []Message) error {
 	committed = true
 
 	s.notify(newEvent(EventSessionUpdated, sessionID, sessionID, map[string]any{
-		"messageCount": len(messages),
+		"messageCount": messageCount,
 		"totalTokens":  totalTokens,
 		"lastActive":   latest,
 	}))
@@ -282,6 +314,22 @@ func (s *Store) ReplaceMessages(sessionID string, messages []Message) error {
 	return nil
 }
 
+// SetMessagePinned marks the stored message with the given row ID as pinned
+// or unpinned. The ID must belong to sessionID.
+func (s *Store) SetMessagePinned(sessionID string, id int64, pinned bool) error {
+	result, err := s.db.Exec(`
+		UPDATE messages SET is_pinned = ?
+		WHERE id = ? AND session_id = ?
+	`, pinned, id, sessionID)
+	if err != nil {
+		return fmt.Errorf("pinning message: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("pinning message: no message %d in session %s", id, sessionID)
+	}
+	return nil
+}
+
 // GetMessages retrieves messages for a session using limit/offset pagination.
 //
 // Recommended indexes for optimal performance:
@@ -290,7 +338,7 @@ func (s *Store) ReplaceMessages(sessionID string, messages []Message) error {
 //	CREATE INDEX idx_messages_session_role ON messages(session_id, role);
 func (s *Store) GetMessages(sessionID string, limit int, offset int) ([]Message, error) {
 	query := `
-		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE)
+		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
 		FROM messages
 		WHERE session_id = ?
 		ORDER BY timestamp ASC
@@ -329,6 +377,7 @@ func (s *Store) GetMessages(sessionID string, limit int, offset int) ([]Message,
 			&msg.Tokens,
 			&msg.IsSummary,
 			&msg.IsTruncated,
+			&msg.IsPinned,
 		); err != nil {
 			return nil, fmt.Errorf("scanning message: %w", err)
 		}
@@ -367,7 +416,7 @@ func (s *Store) GetMessagesWithCursor(sessionID string, cursor *Cursor, limit in
 	if cursor == nil {
 		// First page: no cursor filter
 		query = `
-			SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE)
+			SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
 			FROM messages
 			WHERE session_id = ?
 			ORDER BY timestamp ASC, id ASC
@@ -377,7 +426,7 @@ func (s *Store) GetMessagesWithCursor(sessionID string, cursor *Cursor, limit in
 	} else {
 		// Subsequent pages: filter by cursor (timestamp, id)
 		query = `
-			SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE)
+			SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
 			FROM messages
 			WHERE session_id = ? AND (timestamp > ? OR (timestamp = ? AND id > ?))
 			ORDER BY timestamp ASC, id ASC
@@ -421,6 +470,7 @@ func (s *Store) GetMessagesWithCursor(sessionID string, cursor *Cursor, limit in
 			&msg.Tokens,
 			&msg.IsSummary,
 			&msg.IsTruncated,
+			&msg.IsPinned,
 		); err != nil {
 			return nil, nil, fmt.Errorf("scanning message: %w", err)
 		}
@@ -454,6 +504,107 @@ func (s *Store) GetMessagesWithCursor(sessionID string, cursor *Cursor, limit in
 	return messages, nextCursor, nil
 }
 
+// GetMessagesBefore returns up to limit of a session's messages older than
+// before, oldest first. A nil before starts from the newest message, so the
+// first call yields the latest window. The returned cursor marks the oldest
+// message returned and is nil once no older messages remain.
+func (s *Store) GetMessagesBefore(sessionID string, before *Cursor, limit int) ([]Message, *Cursor, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	limit = min(limit, 1000) // Cap to prevent excessive memory usage
+
+	var query string
+	var args []any
+	if before == nil {
+		query = `
+			SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
+			FROM messages
+			WHERE session_id = ?
+			ORDER BY timestamp DESC, id DESC
+			LIMIT ?
+		`
+		args = []any{sessionID, limit + 1} // Request one extra to detect older messages
+	} else {
+		query = `
+			SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
+			FROM messages
+			WHERE session_id = ? AND (timestamp < ? OR (timestamp = ? AND id < ?))
+			ORDER BY timestamp DESC, id DESC
+			LIMIT ?
+		`
+		beforeTimestamp := sqliteTimestamp(before.Timestamp)
+		args = []any{sessionID, beforeTimestamp, beforeTimestamp, before.ID, limit + 1}
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying messages before cursor: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]Message, 0, limit)
+	hasOlder := false
+	for rows.Next() {
+		if len(messages) == limit {
+			hasOlder = true
+			break
+		}
+		var msg Message
+		var contentJSON sql.NullString
+		var contentType sql.NullString
+		var toolCalls sql.NullString
+		var toolCallID sql.NullString
+		var name sql.NullString
+		var reasoning sql.NullString
+		var reasoningDetails sql.NullString
+		if err := rows.Scan(
+			&msg.ID,
+			&msg.SessionID,
+			&msg.Role,
+			&msg.Content,
+			&contentJSON,
+			&contentType,
+			&toolCalls,
+			&toolCallID,
+			&name,
+			&reasoning,
+			&reasoningDetails,
+			&msg.Timestamp,
+			&msg.Tokens,
+			&msg.IsSummary,
+			&msg.IsTruncated,
+			&msg.IsPinned,
+		); err != nil {
+			return nil, nil, fmt.Errorf("scanning message: %w", err)
+		}
+		msg.ContentJSON = contentJSON.String
+		msg.ContentType = defaultContentType(contentType.String)
+		msg.ToolCalls = toolCalls.String
+		msg.ToolCallID = toolCallID.String
+		msg.Name = name.String
+		msg.Reasoning = reasoning.String
+		msg.ReasoningDetails = reasoningDetails.String
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterating messages: %w", err)
+	}
+
+	slices.Reverse(messages)
+	var olderCursor *Cursor
+	if hasOlder {
+		oldest := messages[0]
+		olderCursor = &Cursor{
+			ID:        oldest.ID,
+			Timestamp: oldest.Timestamp,
+		}
+	}
+
+	return messages, olderCursor, nil
+}
+
 // GetAllMessages retrieves all messages for a session.
 func (s *Store) GetAllMessages(sessionID string) ([]Message, error) {
 	return s.GetMessages(sessionID, 999999, 0)
@@ -480,7 +631,7 @@ func (s *Store) GetMessagesWithSessions(sessionIDs []string) (map[string][]Messa
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE)
+		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
 		FROM messages
 		WHERE session_id IN (%s)
 		ORDER BY session_id, timestamp ASC
@@ -522,6 +673,7 @@ func (s *Store) GetMessagesWithSessions(sessionIDs []string) (map[string][]Messa
 			&msg.Tokens,
 			&msg.IsSummary,
 			&msg.IsTruncated,
+			&msg.IsPinned,
 		); err != nil {
 			return nil, fmt.Errorf("scanning message: %w", err)
 		}
@@ -614,7 +766,7 @@ func (s *Store) GetSessionStats(sessionID string) (*SessionStats, error) {
 // GetLatestMessageByRole returns the most recent message for a role in a session.
 func (s *Store) GetLatestMessageByRole(sessionID, role string) (*Message, error) {
 	query := `
-		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE)
+		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
 		FROM messages
 		WHERE session_id = ? AND role = ?
 		ORDER BY id DESC
@@ -646,6 +798,7 @@ func (s *Store) GetLatestMessageByRole(sessionID, role string) (*Message, error)
 		&msg.Tokens,
 		&msg.IsSummary,
 		&msg.IsTruncated,
+		&msg.IsPinned,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -666,7 +819,7 @@ func (s *Store) GetLatestMessageByRole(sessionID, role string) (*Message, error)
 // GetRecentMessagesByRole returns the most recent messages for a role across sessions.
 func (s *Store) GetRecentMessagesByRole(role string, limit int) ([]Message, error) {
 	query := `
-		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE)
+		SELECT id, session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, COALESCE(is_truncated, FALSE), COALESCE(is_pinned, FALSE)
 		FROM messages
 		WHERE role = ?
 		ORDER BY timestamp DESC
@@ -704,6 +857,7 @@ func (s *Store) GetRecentMessagesByRole(role string, limit int) ([]Message, erro
 			&msg.Tokens,
 			&msg.IsSummary,
 			&msg.IsTruncated,
+			&msg.IsPinned,
 		); err != nil {
 			return nil, fmt.Errorf("scanning recent message: %w", err)
 		}
@@ -800,23 +954,41 @@ func (s *Store) SaveMessagesBatch(messages []*Message) error {
 		}
 	}()
 
+	now := time.Now()
+	perSession, err := insertMessagesTx(tx, messages, now)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit batch transaction: %w", err)
+	}
+	committed = true
+
+	s.notifyMessagesBatch(messages, perSession, now)
+	return nil
+}
+
+// messageBatchStats sums the messages a batch added to one session.
+type messageBatchStats struct {
+	count  int
+	tokens int
+	latest time.Time
+}
+
+// insertMessagesTx inserts messages within tx, assigning their IDs, and bumps
+// the stats of every session they belong to.
+func insertMessagesTx(tx *sql.Tx, messages []*Message, now time.Time) (map[string]*messageBatchStats, error) {
 	stmt, err := tx.Prepare(`
-		INSERT INTO messages (session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, is_truncated)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO messages (session_id, role, content, content_json, content_type, tool_calls, tool_call_id, name, reasoning, reasoning_details, timestamp, tokens, is_summary, is_truncated, is_pinned)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
-		return fmt.Errorf("prepare batch insert: %w", err)
+		return nil, fmt.Errorf("prepare batch insert: %w", err)
 	}
 	defer stmt.Close()
 
-	now := time.Now()
-
-	type sessionStats struct {
-		count  int
-		tokens int
-		latest time.Time
-	}
-	perSession := make(map[string]*sessionStats)
+	perSession := make(map[string]*messageBatchStats)
 
 	for _, msg := range messages {
 		if msg == nil {
@@ -828,7 +1000,7 @@ func (s *Store) SaveMessagesBatch(messages []*Message) error {
 
 		ss := perSession[msg.SessionID]
 		if ss == nil {
-			ss = &sessionStats{}
+			ss = &messageBatchStats{}
 			perSession[msg.SessionID] = ss
 		}
 		ss.count++
@@ -852,14 +1024,15 @@ func (s *Store) SaveMessagesBatch(messages []*Message) error {
 			msg.Tokens,
 			msg.IsSummary,
 			msg.IsTruncated,
+			msg.IsPinned,
 		)
 		if err != nil {
-			return fmt.Errorf("batch insert message: %w", err)
+			return nil, fmt.Errorf("batch insert message: %w", err)
 		}
 
 		id, err := result.LastInsertId()
 		if err != nil {
-			return fmt.Errorf("get last insert id: %w", err)
+			return nil, fmt.Errorf("get last insert id: %w", err)
 		}
 		msg.ID = id
 	}
@@ -874,16 +1047,14 @@ func (s *Store) SaveMessagesBatch(messages []*Message) error {
 			WHERE session_id = ?
 		`
 		if _, err := tx.Exec(update, ss.count, ss.tokens, ss.latest, sid); err != nil {
-			return fmt.Errorf("update session stats: %w", err)
+			return nil, fmt.Errorf("update session stats: %w", err)
 		}
 	}
+	return perSession, nil
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("commit batch transaction: %w", err)
-	}
-	committed = true
-
-	// Notify after successful commit
+// notifyMessagesBatch publishes the events for a committed batch insert.
+func (s *Store) notifyMessagesBatch(messages []*Message, perSession map[string]*messageBatchStats, now time.Time) {
 	for _, msg := range messages {
 		if msg == nil {
 			continue
@@ -900,6 +1071,4 @@ func (s *Store) SaveMessagesBatch(messages []*Message) error {
 			"latestMessage": ss.latest,
 		}))
 	}
-
-	return nil
 }
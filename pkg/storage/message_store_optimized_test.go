@@ -2,6 +2,7 @@ package storage
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -105,6 +106,82 @@ func TestCursorPagination(t *testing.T) {
 	}
 }
 
+func TestGetMessagesBeforeWalksBackwardFromNewest(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("failed to init store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	session := &Session{
+		ID:         "before-test-session",
+		CreatedAt:  time.Now(),
+		LastActive: time.Now(),
+		Status:     SessionStatusActive,
+	}
+	if err := store.CreateSession(session); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	// Two messages share a timestamp so the id tiebreak is exercised.
+	base := time.Now()
+	for i := 0; i < 7; i++ {
+		msg := &Message{
+			SessionID: session.ID,
+			Role:      "user",
+			Content:   "message " + string(rune('0'+i)),
+			Timestamp: base.Add(time.Duration(min(i, 5)) * time.Second),
+		}
+		if err := store.SaveMessage(msg); err != nil {
+			t.Fatalf("save message %d: %v", i, err)
+		}
+	}
+
+	contents := func(msgs []Message) string {
+		parts := make([]string, len(msgs))
+		for i, msg := range msgs {
+			parts[i] = msg.Content[len("message "):]
+		}
+		return strings.Join(parts, ",")
+	}
+
+	latest, cursor, err := store.GetMessagesBefore(session.ID, nil, 3)
+	if err != nil {
+		t.Fatalf("get latest window: %v", err)
+	}
+	if got := contents(latest); got != "4,5,6" {
+		t.Fatalf("latest window = %s, want 4,5,6", got)
+	}
+	if cursor == nil {
+		t.Fatal("expected cursor for older messages")
+	}
+
+	older, cursor, err := store.GetMessagesBefore(session.ID, cursor, 3)
+	if err != nil {
+		t.Fatalf("get older page: %v", err)
+	}
+	if got := contents(older); got != "1,2,3" {
+		t.Fatalf("older page = %s, want 1,2,3", got)
+	}
+	if cursor == nil {
+		t.Fatal("expected cursor for the oldest message")
+	}
+
+	oldest, cursor, err := store.GetMessagesBefore(session.ID, cursor, 3)
+	if err != nil {
+		t.Fatalf("get oldest page: %v", err)
+	}
+	if got := contents(oldest); got != "0" {
+		t.Fatalf("oldest page = %s, want 0", got)
+	}
+	if cursor != nil {
+		t.Fatal("expected no cursor once the first message is returned")
+	}
+}
+
 func TestCursorEncodeDecode(t *testing.T) {
 	original := &Cursor{
 		ID:        12345,
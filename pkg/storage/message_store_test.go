@@ -87,3 +87,47 @@ func TestMessageStoreCRUD(t *testing.T) {
 		t.Fatalf("expected latest assistant message, got %+v", recent)
 	}
 }
+
+func TestSetMessagePinned(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to init store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	if err := store.CreateSession(&Session{ID: "s", CreatedAt: now, LastActive: now}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	var ids []int64
+	for i, content := range []string{"spec", "question", "answer"} {
+		msg := &Message{SessionID: "s", Role: "user", Content: content, Timestamp: now.Add(time.Duration(i) * time.Second)}
+		if err := store.SaveMessage(msg); err != nil {
+			t.Fatalf("save message: %v", err)
+		}
+		ids = append(ids, msg.ID)
+	}
+
+	if err := store.SetMessagePinned("s", ids[0], true); err != nil {
+		t.Fatalf("SetMessagePinned: %v", err)
+	}
+	records, err := store.GetAllMessages("s")
+	if err != nil {
+		t.Fatalf("get all messages: %v", err)
+	}
+	if !records[0].IsPinned || records[1].IsPinned || records[2].IsPinned {
+		t.Fatalf("expected only the oldest message pinned, got %+v", records)
+	}
+	if err := store.SetMessagePinned("s", ids[0], false); err != nil {
+		t.Fatalf("unpin: %v", err)
+	}
+	if records, _ = store.GetAllMessages("s"); records[0].IsPinned {
+		t.Fatal("message still pinned after unpin")
+	}
+	if err := store.CreateSession(&Session{ID: "other", CreatedAt: now, LastActive: now}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	if err := store.SetMessagePinned("other", ids[0], true); err == nil {
+		t.Fatal("expected error pinning a message from another session")
+	}
+}
@@ -116,3 +116,32 @@ func TestMigrationsApplyInOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestMessageAttachmentsMigrationUpgradesExistingDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	// Roll the database back to the schema before attachments existed.
+	if _, err := store.DB().Exec(`DROP TABLE message_attachments`); err != nil {
+		t.Fatalf("drop message_attachments: %v", err)
+	}
+	if _, err := store.DB().Exec(`DELETE FROM schema_migrations WHERE version >= 19`); err != nil {
+		t.Fatalf("reset schema version: %v", err)
+	}
+	store.Close()
+
+	store, err = New(dbPath)
+	if err != nil {
+		t.Fatalf("reopen New() error = %v", err)
+	}
+	defer store.Close()
+	if !tableExists(store.DB(), "message_attachments") {
+		t.Fatal("message_attachments table missing after upgrade")
+	}
+	version, err := store.GetSchemaVersion()
+	if err != nil || version != len(migrations) {
+		t.Fatalf("GetSchemaVersion() = %d, %v, want %d", version, err, len(migrations))
+	}
+}
@@ -23,7 +23,11 @@ func (s *Store) LoadProviderThread(sessionID, providerID string) (string, error)
 }
 
 // SaveProviderThread records the native provider thread associated with a Buckley session.
+// It is a no-op for ephemeral stores.
 func (s *Store) SaveProviderThread(sessionID, providerID, threadID string) error {
+	if s.Ephemeral() {
+		return nil
+	}
 	_, err := s.db.Exec(`
 		INSERT INTO provider_threads (session_id, provider_id, thread_id, updated_at)
 		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
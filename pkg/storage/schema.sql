@@ -25,6 +25,8 @@ CREATE TABLE IF NOT EXISTS sessions (
     git_repo TEXT,
     git_branch TEXT,
     model TEXT,
+    -- Session this one was forked from, if any
+    parent_session_id TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     last_active TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     message_count INT DEFAULT 0,
@@ -56,6 +58,7 @@ CREATE TABLE IF NOT EXISTS messages (
     tokens INT DEFAULT 0,
     is_summary BOOLEAN DEFAULT FALSE,
     is_truncated BOOLEAN DEFAULT FALSE,
+    is_pinned BOOLEAN DEFAULT FALSE,
     FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE
 );
 
@@ -226,6 +229,35 @@ CREATE TABLE IF NOT EXISTS provider_threads (
     FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE
 );
 
+-- Files attached to a session's messages via the file picker. message_id is
+-- cleared when the message leaves the stored transcript (for example after
+-- compaction) so the attachment can be re-attached later.
+CREATE TABLE IF NOT EXISTS message_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL,
+    message_id INTEGER,
+    path TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE,
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_attachments_session ON message_attachments(session_id, id);
+CREATE INDEX IF NOT EXISTS idx_message_attachments_message ON message_attachments(message_id);
+
+-- Text embeddings computed by the embeddings service, keyed by the SHA-256
+-- of the embedded text and the embedding model, so they survive cache-dir
+-- wipes and travel with database backups.
+CREATE TABLE IF NOT EXISTS embedding_cache (
+    content_hash TEXT NOT NULL,
+    model TEXT NOT NULL,
+    embedding BLOB NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (content_hash, model)
+);
+
 -- Executions table: tracks orchestrator execution metadata
 CREATE TABLE IF NOT EXISTS executions (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -362,10 +394,20 @@ CREATE INDEX IF NOT EXISTS idx_cli_tickets_expires ON cli_tickets(expires_at);
 CREATE TABLE IF NOT EXISTS session_tokens (
     session_id TEXT PRIMARY KEY,
     token_hash TEXT NOT NULL,
+    expires_at TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE
 );
 
+-- Revoked session tokens, kept until the token would have expired
+CREATE TABLE IF NOT EXISTS session_token_revocations (
+    token_hash TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE
+);
+
 -- Mission Control: Pending changes for approval (diff workflow)
 CREATE TABLE IF NOT EXISTS pending_changes (
     id TEXT PRIMARY KEY,
@@ -488,6 +530,9 @@ CREATE TABLE IF NOT EXISTS tool_audit_log (
     decided_by TEXT,
     executed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     duration_ms INTEGER,
+    args_hash TEXT,
+    success INTEGER,
+    result_summary TEXT,
     FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE,
     FOREIGN KEY (approval_id) REFERENCES pending_approvals(id) ON DELETE SET NULL
 );
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MergeSessions appends the source session's messages onto the target after a
+// separator marker and marks the source completed, in a single transaction.
+// It returns the number of messages appended, including the marker.
+func (s *Store) MergeSessions(targetID, sourceID string) (int, error) {
+	targetID = strings.TrimSpace(targetID)
+	sourceID = strings.TrimSpace(sourceID)
+	if targetID == "" || sourceID == "" {
+		return 0, fmt.Errorf("target and source session ids are required")
+	}
+	if targetID == sourceID {
+		return 0, fmt.Errorf("cannot merge session %s into itself", targetID)
+	}
+	for _, id := range []string{targetID, sourceID} {
+		sess, err := s.GetSession(id)
+		if err != nil {
+			return 0, fmt.Errorf("load session %s: %w", id, err)
+		}
+		if sess == nil {
+			return 0, fmt.Errorf("session %s not found", id)
+		}
+	}
+
+	target, err := s.GetAllMessages(targetID)
+	if err != nil {
+		return 0, fmt.Errorf("load target messages: %w", err)
+	}
+	source, err := s.GetAllMessages(sourceID)
+	if err != nil {
+		return 0, fmt.Errorf("load source messages: %w", err)
+	}
+
+	appended, err := mergedSessionMessages(target, source, targetID, sourceID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	batch := make([]*Message, len(appended))
+	for i := range appended {
+		batch[i] = &appended[i]
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("merging sessions: begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	perSession, err := insertMessagesTx(tx, batch, now)
+	if err != nil {
+		return 0, fmt.Errorf("append merged messages: %w", err)
+	}
+	res, err := tx.Exec(`UPDATE sessions SET status = ?, completed_at = ? WHERE session_id = ?`, SessionStatusCompleted, now, sourceID)
+	if err != nil {
+		return 0, fmt.Errorf("mark source session completed: %w", err)
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return 0, fmt.Errorf("mark source session completed: session %s not found", sourceID)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("merging sessions: commit: %w", err)
+	}
+	committed = true
+
+	s.notifyMessagesBatch(batch, perSession, now)
+	s.notify(newEvent(EventSessionUpdated, sourceID, sourceID, map[string]any{
+		"status":      SessionStatusCompleted,
+		"completedAt": now,
+	}))
+	return len(appended), nil
+}
+
+// mergedSessionMessages builds the messages to append to the target session.
+// Source tool call IDs that collide with target IDs are renamed together with
+// their tool responses so call/result pairing survives the merge. Timestamps
+// are rewritten to follow the target's last message because messages are read
+// back in timestamp order.
+func mergedSessionMessages(target, source []Message, targetID, sourceID string, now time.Time) ([]Message, error) {
+	used := make(map[string]struct{})
+	start := now
+	for _, msg := range target {
+		ids, err := toolCallIDs(msg.ToolCalls)
+		if err != nil {
+			return nil, fmt.Errorf("target message %d: %w", msg.ID, err)
+		}
+		for _, id := range ids {
+			used[id] = struct{}{}
+		}
+		if msg.ToolCallID != "" {
+			used[msg.ToolCallID] = struct{}{}
+		}
+		if !msg.Timestamp.Before(start) {
+			start = msg.Timestamp.Add(time.Microsecond)
+		}
+	}
+
+	renamed := make(map[string]string)
+	rename := func(id string) string {
+		if next, ok := renamed[id]; ok {
+			return next
+		}
+		next := id
+		for n := 1; ; n++ {
+			if _, taken := used[next]; !taken {
+				break
+			}
+			next = fmt.Sprintf("%s_merged%d", id, n)
+		}
+		used[next] = struct{}{}
+		renamed[id] = next
+		return next
+	}
+
+	out := make([]Message, 0, len(source)+1)
+	out = append(out, Message{
+		SessionID: targetID,
+		Role:      "system",
+		Content:   fmt.Sprintf("--- Merged from session %s ---", sourceID),
+		Timestamp: start,
+	})
+	for i, msg := range source {
+		sourceMsgID := msg.ID
+		msg.ID = 0
+		msg.SessionID = targetID
+		msg.Embedding = nil
+		msg.Timestamp = start.Add(time.Duration(i+1) * time.Microsecond)
+		if msg.ToolCalls != "" {
+			calls, err := renameToolCallIDs(msg.ToolCalls, rename)
+			if err != nil {
+				return nil, fmt.Errorf("source message %d: %w", sourceMsgID, err)
+			}
+			msg.ToolCalls = calls
+		}
+		if msg.ToolCallID != "" {
+			if next, ok := renamed[msg.ToolCallID]; ok {
+				msg.ToolCallID = next
+			}
+		}
+		out = append(out, msg)
+	}
+	return out, nil
+}
+
+func toolCallIDs(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var calls []map[string]any
+	if err := json.Unmarshal([]byte(raw), &calls); err != nil {
+		return nil, fmt.Errorf("decode tool calls: %w", err)
+	}
+	ids := make([]string, 0, len(calls))
+	for _, call := range calls {
+		if id, ok := call["id"].(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func renameToolCallIDs(raw string, rename func(string) string) (string, error) {
+	var calls []map[string]any
+	if err := json.Unmarshal([]byte(raw), &calls); err != nil {
+		return "", fmt.Errorf("decode tool calls: %w", err)
+	}
+	for _, call := range calls {
+		if id, ok := call["id"].(string); ok && id != "" {
+			call["id"] = rename(id)
+		}
+	}
+	data, err := json.Marshal(calls)
+	if err != nil {
+		return "", fmt.Errorf("encode tool calls: %w", err)
+	}
+	return string(data), nil
+}
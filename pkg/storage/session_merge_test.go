@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newMergeTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := New(filepath.Join(t.TempDir(), "buckley.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func seedMergeSession(t *testing.T, store *Store, id string, base time.Time, msgs []Message) {
+	t.Helper()
+	if err := store.CreateSession(&Session{ID: id, CreatedAt: base, LastActive: base, Status: SessionStatusActive}); err != nil {
+		t.Fatalf("CreateSession %s: %v", id, err)
+	}
+	for i := range msgs {
+		msgs[i].SessionID = id
+		msgs[i].Timestamp = base.Add(time.Duration(i) * time.Second)
+		if err := store.SaveMessage(&msgs[i]); err != nil {
+			t.Fatalf("SaveMessage %s/%d: %v", id, i, err)
+		}
+	}
+}
+
+func TestMergeSessionsAppendsInOrderAndKeepsToolPairing(t *testing.T) {
+	store := newMergeTestStore(t)
+	base := time.Now().Add(-time.Hour)
+
+	seedMergeSession(t, store, "target", base, []Message{
+		{Role: "user", Content: "target question"},
+		{Role: "assistant", ToolCalls: `[{"id":"call_1","type":"function","function":{"name":"read_file","arguments":"{}"}}]`},
+		{Role: "tool", ToolCallID: "call_1", Name: "read_file", Content: "target result"},
+	})
+	// The source session is older and reuses call_1, so timestamps and tool
+	// call IDs both need rewriting to keep the merged history coherent.
+	seedMergeSession(t, store, "source", base.Add(-time.Hour), []Message{
+		{Role: "user", Content: "source question"},
+		{Role: "assistant", ToolCalls: `[{"id":"call_1","type":"function","function":{"name":"grep","arguments":"{}"}},{"id":"call_2","type":"function","function":{"name":"ls","arguments":"{}"}}]`},
+		{Role: "tool", ToolCallID: "call_1", Name: "grep", Content: "source grep"},
+		{Role: "tool", ToolCallID: "call_2", Name: "ls", Content: "source ls"},
+	})
+
+	appended, err := store.MergeSessions("target", "source")
+	if err != nil {
+		t.Fatalf("MergeSessions: %v", err)
+	}
+	if appended != 5 {
+		t.Fatalf("appended = %d, want 5 (separator + 4 messages)", appended)
+	}
+
+	merged, err := store.GetAllMessages("target")
+	if err != nil {
+		t.Fatalf("GetAllMessages: %v", err)
+	}
+	wantContent := []string{"target question", "", "target result", "--- Merged from session source ---", "source question", "", "source grep", "source ls"}
+	if len(merged) != len(wantContent) {
+		t.Fatalf("merged has %d messages, want %d", len(merged), len(wantContent))
+	}
+	for i, want := range wantContent {
+		if merged[i].Content != want {
+			t.Fatalf("message %d content = %q, want %q", i, merged[i].Content, want)
+		}
+	}
+	if merged[3].Role != "system" {
+		t.Fatalf("separator role = %q, want system", merged[3].Role)
+	}
+
+	var calls []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(merged[5].ToolCalls), &calls); err != nil {
+		t.Fatalf("decode merged tool calls: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("merged tool calls = %+v", calls)
+	}
+	if calls[0].ID == "call_1" || !strings.HasPrefix(calls[0].ID, "call_1") {
+		t.Fatalf("colliding call id = %q, want a renamed call_1", calls[0].ID)
+	}
+	if calls[1].ID != "call_2" {
+		t.Fatalf("non-colliding call id = %q, want call_2 unchanged", calls[1].ID)
+	}
+	if merged[6].ToolCallID != calls[0].ID || merged[7].ToolCallID != calls[1].ID {
+		t.Fatalf("tool responses %q/%q do not pair with calls %+v", merged[6].ToolCallID, merged[7].ToolCallID, calls)
+	}
+	if merged[2].ToolCallID != "call_1" {
+		t.Fatalf("target tool response id changed to %q", merged[2].ToolCallID)
+	}
+
+	source, err := store.GetSession("source")
+	if err != nil {
+		t.Fatalf("GetSession source: %v", err)
+	}
+	if source.Status != SessionStatusCompleted {
+		t.Fatalf("source status = %q, want completed", source.Status)
+	}
+	target, err := store.GetSession("target")
+	if err != nil {
+		t.Fatalf("GetSession target: %v", err)
+	}
+	if target.MessageCount != len(wantContent) {
+		t.Fatalf("target message count = %d, want %d", target.MessageCount, len(wantContent))
+	}
+}
+
+func TestMergeSessionsRejectsInvalidSessions(t *testing.T) {
+	store := newMergeTestStore(t)
+	seedMergeSession(t, store, "only", time.Now(), nil)
+
+	tests := []struct {
+		name           string
+		target, source string
+	}{
+		{name: "empty id", target: "only", source: ""},
+		{name: "same session", target: "only", source: "only"},
+		{name: "missing source", target: "only", source: "missing"},
+		{name: "missing target", target: "missing", source: "only"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := store.MergeSessions(tt.target, tt.source); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestMergeSessionsRollsBackWhenSourceUpdateFails(t *testing.T) {
+	store := newMergeTestStore(t)
+	base := time.Now().Add(-time.Hour)
+	seedMergeSession(t, store, "target", base, []Message{{Role: "user", Content: "t1"}})
+	seedMergeSession(t, store, "source", base, []Message{{Role: "user", Content: "s1"}})
+	if _, err := store.DB().Exec(`
+		CREATE TRIGGER fail_source_complete BEFORE UPDATE OF status ON sessions
+		WHEN NEW.session_id = 'source'
+		BEGIN SELECT RAISE(ABORT, 'source locked'); END
+	`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	if _, err := store.MergeSessions("target", "source"); err == nil {
+		t.Fatal("expected merge to fail")
+	}
+	msgs, err := store.GetAllMessages("target")
+	if err != nil {
+		t.Fatalf("GetAllMessages: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("target has %d messages after a failed merge, want the original 1", len(msgs))
+	}
+	if sess, _ := store.GetSession("target"); sess == nil || sess.MessageCount != 1 {
+		t.Fatalf("target session = %+v, want message count 1", sess)
+	}
+}
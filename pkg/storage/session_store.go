@@ -2,6 +2,8 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -33,18 +35,24 @@ type Session struct {
 	PauseReason   string     `json:"pauseReason,omitempty"`
 	PauseQuestion string     `json:"pauseQuestion,omitempty"`
 	PausedAt      *time.Time `json:"pausedAt,omitempty"`
+	// ParentSessionID is the session this one was forked from, if any.
+	ParentSessionID string `json:"parentSessionId,omitempty"`
 }
 
 // CreateSession creates a new session with retry logic for database locks.
+// Ephemeral stores keep no session rows, so it is a no-op for them.
 func (s *Store) CreateSession(session *Session) error {
+	if s.Ephemeral() {
+		return nil
+	}
 	status := strings.TrimSpace(strings.ToLower(session.Status))
 	if status == "" {
 		status = SessionStatusActive
 	}
 
 	query := `
-		INSERT INTO sessions (session_id, principal, project_path, git_repo, git_branch, model, created_at, last_active, status, completed_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO sessions (session_id, principal, project_path, git_repo, git_branch, model, parent_session_id, created_at, last_active, status, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	principal := strings.TrimSpace(session.Principal)
@@ -71,6 +79,7 @@ func (s *Store) CreateSession(session *Session) error {
 			session.GitRepo,
 			session.GitBranch,
 			session.Model,
+			nullIfEmpty(session.ParentSessionID),
 			sqliteTimestamp(session.CreatedAt),
 			sqliteTimestamp(session.LastActive),
 			status,
@@ -102,14 +111,14 @@ func (s *Store) CreateSession(session *Session) error {
 // GetSession retrieves a session by ID.
 func (s *Store) GetSession(sessionID string) (*Session, error) {
 	query := `
-		SELECT session_id, principal, project_path, git_repo, git_branch, model, created_at, last_active,
+		SELECT session_id, principal, project_path, git_repo, git_branch, model, parent_session_id, created_at, last_active,
 		       message_count, total_tokens, total_cost, status, completed_at,
 		       pause_reason, pause_question, paused_at
 		FROM sessions WHERE session_id = ?
 	`
 	var session Session
 	var principal sql.NullString
-	var gitRepo, gitBranch, modelID sql.NullString
+	var gitRepo, gitBranch, modelID, parentID sql.NullString
 	var completed sql.NullTime
 	var pauseReason, pauseQuestion sql.NullString
 	var pausedAt sql.NullTime
@@ -120,6 +129,7 @@ func (s *Store) GetSession(sessionID string) (*Session, error) {
 		&gitRepo,
 		&gitBranch,
 		&modelID,
+		&parentID,
 		&session.CreatedAt,
 		&session.LastActive,
 		&session.MessageCount,
@@ -146,6 +156,7 @@ func (s *Store) GetSession(sessionID string) (*Session, error) {
 	session.GitRepo = gitRepo.String
 	session.GitBranch = gitBranch.String
 	session.Model = modelID.String
+	session.ParentSessionID = parentID.String
 	if pauseReason.Valid {
 		session.PauseReason = pauseReason.String
 	}
@@ -184,7 +195,7 @@ func (s *Store) EnsureSession(sessionID string) error {
 // ListSessions returns all sessions ordered by last active time.
 func (s *Store) ListSessions(limit int) ([]Session, error) {
 	query := `
-		SELECT session_id, principal, project_path, git_repo, git_branch, model, created_at, last_active,
+		SELECT session_id, principal, project_path, git_repo, git_branch, model, parent_session_id, created_at, last_active,
 		       message_count, total_tokens, total_cost, status, completed_at
 		FROM sessions
 		ORDER BY last_active DESC
@@ -195,12 +206,104 @@ func (s *Store) ListSessions(limit int) ([]Session, error) {
 		return nil, err
 	}
 	defer rows.Close()
+	return scanSessionRows(rows)
+}
+
+// SessionCursor marks a position in the last-active ordering of sessions.
+// The session ID breaks ties between sessions active at the same instant.
+type SessionCursor struct {
+	LastActive time.Time `json:"lastActive"`
+	ID         string    `json:"id,omitempty"`
+}
+
+// EncodeSessionCursor encodes a session cursor to a base64 string for API use.
+func EncodeSessionCursor(cursor *SessionCursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("encoding session cursor: %w", err)
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
 
+// DecodeSessionCursor decodes a base64 string to a session cursor.
+func DecodeSessionCursor(encoded string) (*SessionCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding session cursor: %w", err)
+	}
+	var cursor SessionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("parsing session cursor: %w", err)
+	}
+	return &cursor, nil
+}
+
+// ListSessionsBefore returns up to limit sessions that were last active
+// before the cursor, most recent first. A nil before starts from the newest
+// session. The returned cursor marks the last session returned and is nil
+// once no older sessions remain. Sessions created after the first page sort
+// ahead of every cursor, so they never shift later pages.
+func (s *Store) ListSessionsBefore(before *SessionCursor, limit int) ([]Session, *SessionCursor, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	limit = min(limit, 1000)
+
+	var query string
+	var args []any
+	if before == nil {
+		query = `
+			SELECT session_id, principal, project_path, git_repo, git_branch, model, parent_session_id, created_at, last_active,
+			       message_count, total_tokens, total_cost, status, completed_at
+			FROM sessions
+			ORDER BY last_active DESC, session_id DESC
+			LIMIT ?
+		`
+		args = []any{limit + 1}
+	} else {
+		query = `
+			SELECT session_id, principal, project_path, git_repo, git_branch, model, parent_session_id, created_at, last_active,
+			       message_count, total_tokens, total_cost, status, completed_at
+			FROM sessions
+			WHERE last_active < ? OR (last_active = ? AND session_id < ?)
+			ORDER BY last_active DESC, session_id DESC
+			LIMIT ?
+		`
+		beforeTimestamp := sqliteTimestamp(before.LastActive)
+		args = []any{beforeTimestamp, beforeTimestamp, before.ID, limit + 1}
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying sessions before cursor: %w", err)
+	}
+	defer rows.Close()
+	sessions, err := scanSessionRows(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *SessionCursor
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
+		last := sessions[len(sessions)-1]
+		next = &SessionCursor{LastActive: last.LastActive, ID: last.ID}
+	}
+	return sessions, next, nil
+}
+
+func scanSessionRows(rows *sql.Rows) ([]Session, error) {
 	sessions := []Session{}
 	for rows.Next() {
 		var session Session
 		var principal sql.NullString
-		var gitRepo, gitBranch, modelID sql.NullString
+		var gitRepo, gitBranch, modelID, parentID sql.NullString
 		var completed sql.NullTime
 		if err := rows.Scan(
 			&session.ID,
@@ -209,6 +312,7 @@ func (s *Store) ListSessions(limit int) ([]Session, error) {
 			&gitRepo,
 			&gitBranch,
 			&modelID,
+			&parentID,
 			&session.CreatedAt,
 			&session.LastActive,
 			&session.MessageCount,
@@ -228,6 +332,7 @@ func (s *Store) ListSessions(limit int) ([]Session, error) {
 		session.GitRepo = gitRepo.String
 		session.GitBranch = gitBranch.String
 		session.Model = modelID.String
+		session.ParentSessionID = parentID.String
 		sessions = append(sessions, session)
 	}
 
@@ -241,7 +346,7 @@ func (s *Store) ListSessionsByRepo(repoPath string) ([]Session, error) {
 		return []Session{}, nil
 	}
 	query := `
-		SELECT session_id, principal, project_path, git_repo, git_branch, model, created_at, last_active,
+		SELECT session_id, principal, project_path, git_repo, git_branch, model, parent_session_id, created_at, last_active,
 		       message_count, total_tokens, total_cost, status, completed_at
 		FROM sessions
 		WHERE git_repo = ? OR project_path = ?
@@ -257,7 +362,7 @@ func (s *Store) ListSessionsByRepo(repoPath string) ([]Session, error) {
 	for rows.Next() {
 		var session Session
 		var principal sql.NullString
-		var gitRepo, gitBranch, modelID sql.NullString
+		var gitRepo, gitBranch, modelID, parentID sql.NullString
 		var completed sql.NullTime
 		if err := rows.Scan(
 			&session.ID,
@@ -266,6 +371,7 @@ func (s *Store) ListSessionsByRepo(repoPath string) ([]Session, error) {
 			&gitRepo,
 			&gitBranch,
 			&modelID,
+			&parentID,
 			&session.CreatedAt,
 			&session.LastActive,
 			&session.MessageCount,
@@ -285,6 +391,7 @@ func (s *Store) ListSessionsByRepo(repoPath string) ([]Session, error) {
 		session.GitRepo = gitRepo.String
 		session.GitBranch = gitBranch.String
 		session.Model = modelID.String
+		session.ParentSessionID = parentID.String
 		sessions = append(sessions, session)
 	}
 	return sessions, rows.Err()
@@ -334,6 +441,9 @@ func (s *Store) UpdateSessionModel(sessionID, modelID string) error {
 	if modelID == "" {
 		return fmt.Errorf("model required")
 	}
+	if s.Ephemeral() {
+		return nil
+	}
 	res, err := s.db.Exec(`UPDATE sessions SET model = ?, last_active = ? WHERE session_id = ?`, modelID, sqliteTimestamp(time.Now()), sessionID)
 	if err != nil {
 		return err
@@ -509,13 +619,44 @@ func (s *Store) UpdateSessionPauseState(sessionID string, reason, question strin
 	return nil
 }
 
-// DeleteSession deletes a session and all related data (cascades to messages, api_calls).
+// sessionChildTables hold per-session rows removed by DeleteSession. Most
+// also cascade from sessions, but ipc_events has no foreign key and older
+// databases may predate some constraints, so they are cleared explicitly.
+var sessionChildTables = []string{
+	"messages",
+	"todo_checkpoints",
+	"todos",
+	"session_skills",
+	"api_calls",
+	"ipc_events",
+}
+
+// DeleteSession permanently deletes a session with its messages, todos,
+// skills, cost records and events in one transaction.
 func (s *Store) DeleteSession(sessionID string) error {
-	query := `DELETE FROM sessions WHERE session_id = ?`
-	_, err := s.db.Exec(query, sessionID)
+	tx, err := s.db.Begin()
 	if err != nil {
-		return err
+		return fmt.Errorf("delete session: begin tx: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, table := range sessionChildTables {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE session_id = ?", sessionID); err != nil {
+			return fmt.Errorf("delete session %s: %w", table, err)
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM sessions WHERE session_id = ?`, sessionID); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("delete session: commit: %w", err)
 	}
+	committed = true
 
 	s.notify(newEvent(EventSessionDeleted, sessionID, sessionID, nil))
 	return nil
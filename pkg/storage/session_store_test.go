@@ -87,6 +87,40 @@ func TestSessionStoreLifecycle(t *testing.T) {
 	}
 }
 
+func TestSessionParentRoundTrip(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "session.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	for _, sess := range []*Session{
+		{ID: "parent", ProjectPath: "/project", CreatedAt: now, LastActive: now},
+		{ID: "child", ProjectPath: "/project", ParentSessionID: "parent", CreatedAt: now, LastActive: now.Add(time.Second)},
+	} {
+		if err := store.CreateSession(sess); err != nil {
+			t.Fatalf("create session %s: %v", sess.ID, err)
+		}
+	}
+
+	fetched, err := store.GetSession("child")
+	if err != nil || fetched == nil || fetched.ParentSessionID != "parent" {
+		t.Fatalf("GetSession(child) = %+v, %v; want parent recorded", fetched, err)
+	}
+	list, err := store.ListSessionsByRepo("/project")
+	if err != nil {
+		t.Fatalf("list sessions by repo: %v", err)
+	}
+	if len(list) != 2 || list[0].ParentSessionID != "parent" || list[1].ParentSessionID != "" {
+		t.Fatalf("ListSessionsByRepo = %+v", list)
+	}
+	all, err := store.ListSessions(10)
+	if err != nil || len(all) != 2 || all[0].ParentSessionID != "parent" {
+		t.Fatalf("ListSessions = %+v, %v", all, err)
+	}
+}
+
 func TestListSessionsByRepo(t *testing.T) {
 	dir := t.TempDir()
 	store, err := New(filepath.Join(dir, "test.db"))
@@ -245,3 +279,185 @@ func TestUpdateSessionStats(t *testing.T) {
 		t.Errorf("expected TotalCost=0.15, got %f", fetched.TotalCost)
 	}
 }
+
+func TestListSessionsBeforePagesStably(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	create := func(id string, lastActive time.Time) {
+		t.Helper()
+		if err := store.CreateSession(&Session{
+			ID:         id,
+			CreatedAt:  lastActive,
+			LastActive: lastActive,
+			Status:     SessionStatusActive,
+		}); err != nil {
+			t.Fatalf("failed to create session %s: %v", id, err)
+		}
+	}
+	// sess-c and sess-d share a timestamp to exercise the ID tiebreak.
+	create("sess-a", base)
+	create("sess-b", base.Add(1*time.Minute))
+	create("sess-c", base.Add(2*time.Minute))
+	create("sess-d", base.Add(2*time.Minute))
+	create("sess-e", base.Add(3*time.Minute))
+
+	var got []string
+	var cursor *SessionCursor
+	for page := 0; ; page++ {
+		sessions, next, err := store.ListSessionsBefore(cursor, 2)
+		if err != nil {
+			t.Fatalf("page %d: %v", page, err)
+		}
+		for _, sess := range sessions {
+			got = append(got, sess.ID)
+		}
+		if page == 0 {
+			// A session created mid-pagination sorts ahead of the cursor.
+			create("sess-new", base.Add(time.Hour))
+		}
+		if next == nil {
+			break
+		}
+		encoded, err := EncodeSessionCursor(next)
+		if err != nil {
+			t.Fatalf("encode cursor: %v", err)
+		}
+		if cursor, err = DecodeSessionCursor(encoded); err != nil {
+			t.Fatalf("decode cursor: %v", err)
+		}
+	}
+
+	want := []string{"sess-e", "sess-d", "sess-c", "sess-b", "sess-a"}
+	if len(got) != len(want) {
+		t.Fatalf("paged sessions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("paged sessions = %v, want %v", got, want)
+		}
+	}
+
+	older, next, err := store.ListSessionsBefore(&SessionCursor{LastActive: base.Add(2 * time.Minute)}, 10)
+	if err != nil {
+		t.Fatalf("list before timestamp: %v", err)
+	}
+	if len(older) != 2 || older[0].ID != "sess-b" || older[1].ID != "sess-a" || next != nil {
+		t.Fatalf("sessions before timestamp = %+v (next %+v)", older, next)
+	}
+}
+
+func TestDeleteSessionRemovesRelatedRows(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	for _, id := range []string{"sess-doomed", "sess-kept"} {
+		if err := store.CreateSession(&Session{ID: id, CreatedAt: now, LastActive: now, Status: SessionStatusCompleted}); err != nil {
+			t.Fatalf("failed to create session %s: %v", id, err)
+		}
+		if err := store.SaveMessage(&Message{SessionID: id, Role: "user", Content: "hello", Timestamp: now}); err != nil {
+			t.Fatalf("save message: %v", err)
+		}
+		if err := store.CreateTodo(&Todo{SessionID: id, Content: "todo", ActiveForm: "doing", Status: "pending"}); err != nil {
+			t.Fatalf("create todo: %v", err)
+		}
+		if err := store.SaveSessionSkill(id, "skill", "user", "scope"); err != nil {
+			t.Fatalf("save skill: %v", err)
+		}
+		if err := store.SaveAPICall(&APICall{SessionID: id, Model: "m", PromptTokens: 1, CompletionTokens: 1, Cost: 0.01, Timestamp: now}); err != nil {
+			t.Fatalf("save api call: %v", err)
+		}
+		if err := store.SaveIPCEvent(IPCEvent{ID: id + "-event", SessionID: id, Type: "test", CreatedAt: now}); err != nil {
+			t.Fatalf("save ipc event: %v", err)
+		}
+	}
+
+	if err := store.DeleteSession("sess-doomed"); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+
+	if sess, err := store.GetSession("sess-doomed"); err != nil || sess != nil {
+		t.Fatalf("expected session to be gone, got %+v err=%v", sess, err)
+	}
+	for _, table := range []string{"messages", "todos", "session_skills", "api_calls", "ipc_events"} {
+		for id, want := range map[string]int{"sess-doomed": 0, "sess-kept": 1} {
+			var count int
+			if err := store.db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE session_id = ?", id).Scan(&count); err != nil {
+				t.Fatalf("count %s: %v", table, err)
+			}
+			if count != want {
+				t.Errorf("%s rows for %s = %d, want %d", table, id, count, want)
+			}
+		}
+	}
+}
+
+func TestEphemeralStoreLeavesNoSessionRows(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "ephemeral.db")
+	store, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	store.SetEphemeral(true)
+
+	now := time.Now()
+	if err := store.CreateSession(&Session{ID: "s1", CreatedAt: now, LastActive: now}); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if err := store.EnsureSession("s2"); err != nil {
+		t.Fatalf("EnsureSession: %v", err)
+	}
+	if err := store.UpdateSessionModel("s1", "model-a"); err != nil {
+		t.Fatalf("UpdateSessionModel: %v", err)
+	}
+	if err := store.SaveSessionSummary("s1", "summary"); err != nil {
+		t.Fatalf("SaveSessionSummary: %v", err)
+	}
+	if err := store.LogToolExecution(&ToolAuditEntry{SessionID: "s1", ToolName: "read_file", ToolInput: `{"path":"secret"}`, ExecutedAt: now}); err != nil {
+		t.Fatalf("LogToolExecution: %v", err)
+	}
+	if err := store.SaveAPICall(&APICall{SessionID: "s1", Model: "model-a", Cost: 0.01, Timestamp: now}); err != nil {
+		t.Fatalf("SaveAPICall: %v", err)
+	}
+	if err := store.SaveSessionSkill("s1", "skill", "model", "session"); err != nil {
+		t.Fatalf("SaveSessionSkill: %v", err)
+	}
+	if err := store.SaveProviderThread("s1", "codex", "thread-1"); err != nil {
+		t.Fatalf("SaveProviderThread: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	t.Cleanup(func() { _ = reopened.Close() })
+	for _, query := range []string{
+		`SELECT COUNT(*) FROM sessions`,
+		`SELECT COUNT(*) FROM settings WHERE key LIKE 'session.%'`,
+		`SELECT COUNT(*) FROM tool_audit_log`,
+		`SELECT COUNT(*) FROM api_calls`,
+		`SELECT COUNT(*) FROM session_skills`,
+		`SELECT COUNT(*) FROM provider_threads`,
+	} {
+		var rows int
+		if err := reopened.DB().QueryRow(query).Scan(&rows); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		if rows != 0 {
+			t.Fatalf("%s = %d after an ephemeral run, want 0", query, rows)
+		}
+	}
+}
@@ -2,37 +2,158 @@ package storage
 
 import (
 	"database/sql"
+	"fmt"
+	"time"
 )
 
+// SessionTokenStatus is the outcome of checking a session token.
+type SessionTokenStatus int
+
+const (
+	SessionTokenInvalid SessionTokenStatus = iota
+	SessionTokenValid
+	SessionTokenExpired
+	SessionTokenRevoked
+)
+
+func ensureSessionTokensSchema(db *sql.DB) error {
+	rows, err := db.Query(`PRAGMA table_info(session_tokens)`)
+	if err != nil {
+		return fmt.Errorf("session_tokens pragma: %w", err)
+	}
+	hasExpiry := false
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, ctype string
+		var dflt any
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &dflt, &pk); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scan session_tokens pragma: %w", err)
+		}
+		if name == "expires_at" {
+			hasExpiry = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("iterating session_tokens columns: %w", err)
+	}
+	_ = rows.Close()
+	if !hasExpiry {
+		if _, err := db.Exec(`ALTER TABLE session_tokens ADD COLUMN expires_at TIMESTAMP`); err != nil {
+			return fmt.Errorf("add session_tokens expires_at: %w", err)
+		}
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS session_token_revocations (
+		token_hash TEXT PRIMARY KEY,
+		session_id TEXT NOT NULL,
+		expires_at TIMESTAMP,
+		revoked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE
+	)`); err != nil {
+		return fmt.Errorf("create session_token_revocations: %w", err)
+	}
+	return nil
+}
+
 // SaveSessionToken stores or replaces the hashed session token for a session ID.
+// The token does not expire; use SaveSessionTokenWithExpiry for a bounded lifetime.
 func (s *Store) SaveSessionToken(sessionID, token string) error {
+	return s.SaveSessionTokenWithExpiry(sessionID, token, time.Time{})
+}
+
+// SaveSessionTokenWithExpiry stores or replaces the hashed session token for a
+// session ID. A zero expiresAt stores a token that never expires.
+func (s *Store) SaveSessionTokenWithExpiry(sessionID, token string, expiresAt time.Time) error {
 	if s == nil || s.db == nil {
 		return ErrStoreClosed
 	}
 	hash := hashSecret(token)
+	var expires sql.NullTime
+	if !expiresAt.IsZero() {
+		expires = sql.NullTime{Time: expiresAt.UTC(), Valid: true}
+	}
 	_, err := s.db.Exec(`
-        INSERT INTO session_tokens (session_id, token_hash, updated_at)
-        VALUES (?, ?, CURRENT_TIMESTAMP)
-        ON CONFLICT(session_id) DO UPDATE SET token_hash = excluded.token_hash, updated_at = CURRENT_TIMESTAMP
-    `, sessionID, hash)
+        INSERT INTO session_tokens (session_id, token_hash, expires_at, updated_at)
+        VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+        ON CONFLICT(session_id) DO UPDATE SET token_hash = excluded.token_hash, expires_at = excluded.expires_at, updated_at = CURRENT_TIMESTAMP
+    `, sessionID, hash, expires)
 	return err
 }
 
 // ValidateSessionToken compares the provided token against the stored hash.
+// Expired and revoked tokens are not valid.
 func (s *Store) ValidateSessionToken(sessionID, token string) (bool, error) {
+	status, err := s.CheckSessionToken(sessionID, token)
+	return status == SessionTokenValid, err
+}
+
+// CheckSessionToken reports whether the provided token is valid, expired,
+// revoked or unknown for the session.
+func (s *Store) CheckSessionToken(sessionID, token string) (SessionTokenStatus, error) {
 	if s == nil || s.db == nil {
-		return false, ErrStoreClosed
+		return SessionTokenInvalid, ErrStoreClosed
 	}
 	hash := hashSecret(token)
+
+	var revoked int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM session_token_revocations WHERE session_id = ? AND token_hash = ?`, sessionID, hash).Scan(&revoked)
+	if err != nil {
+		return SessionTokenInvalid, err
+	}
+	if revoked > 0 {
+		return SessionTokenRevoked, nil
+	}
+
 	var stored string
-	err := s.db.QueryRow(`SELECT token_hash FROM session_tokens WHERE session_id = ?`, sessionID).Scan(&stored)
+	var expires sql.NullTime
+	err = s.db.QueryRow(`SELECT token_hash, expires_at FROM session_tokens WHERE session_id = ?`, sessionID).Scan(&stored, &expires)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return false, nil
+			return SessionTokenInvalid, nil
 		}
-		return false, err
+		return SessionTokenInvalid, err
+	}
+	if stored != hash {
+		return SessionTokenInvalid, nil
+	}
+	if expires.Valid && !time.Now().Before(expires.Time) {
+		return SessionTokenExpired, nil
+	}
+	return SessionTokenValid, nil
+}
+
+// RevokeSessionTokens invalidates the outstanding token for a session and
+// records it in the revocation list. Revocations whose token has expired are
+// pruned. It reports how many tokens were revoked.
+func (s *Store) RevokeSessionTokens(sessionID string) (int64, error) {
+	if s == nil || s.db == nil {
+		return 0, ErrStoreClosed
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin revoke session tokens: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`
+        INSERT OR IGNORE INTO session_token_revocations (token_hash, session_id, expires_at, revoked_at)
+        SELECT token_hash, session_id, expires_at, CURRENT_TIMESTAMP FROM session_tokens WHERE session_id = ?
+    `, sessionID); err != nil {
+		return 0, fmt.Errorf("record session token revocation: %w", err)
+	}
+	res, err := tx.Exec(`DELETE FROM session_tokens WHERE session_id = ?`, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("delete session tokens: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM session_token_revocations WHERE expires_at IS NOT NULL AND expires_at <= ?`, time.Now().UTC()); err != nil {
+		return 0, fmt.Errorf("prune session token revocations: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit revoke session tokens: %w", err)
 	}
-	return stored == hash, nil
+	revoked, _ := res.RowsAffected()
+	return revoked, nil
 }
 
 // DeleteSessionToken removes any stored token for the session.
@@ -102,6 +102,50 @@ func TestSessionTokensLifecycle(t *testing.T) {
 	}
 }
 
+func TestSessionTokensExpiryAndRevocation(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	sessionID := "sess-123"
+	if err := store.CreateSession(&Session{ID: sessionID, CreatedAt: time.Now(), LastActive: time.Now(), Status: SessionStatusActive}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	if err := store.SaveSessionTokenWithExpiry(sessionID, "old-token", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("failed to save expired token: %v", err)
+	}
+	if status, err := store.CheckSessionToken(sessionID, "old-token"); err != nil || status != SessionTokenExpired {
+		t.Fatalf("expected expired status, got %v err=%v", status, err)
+	}
+
+	if err := store.SaveSessionTokenWithExpiry(sessionID, "live-token", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	if status, err := store.CheckSessionToken(sessionID, "live-token"); err != nil || status != SessionTokenValid {
+		t.Fatalf("expected valid status, got %v err=%v", status, err)
+	}
+
+	revoked, err := store.RevokeSessionTokens(sessionID)
+	if err != nil || revoked != 1 {
+		t.Fatalf("expected one revoked token, got %d err=%v", revoked, err)
+	}
+	if status, err := store.CheckSessionToken(sessionID, "live-token"); err != nil || status != SessionTokenRevoked {
+		t.Fatalf("expected revoked status, got %v err=%v", status, err)
+	}
+
+	// A token issued after revocation is valid again.
+	if err := store.SaveSessionTokenWithExpiry(sessionID, "new-token", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to save new token: %v", err)
+	}
+	if valid, err := store.ValidateSessionToken(sessionID, "new-token"); err != nil || !valid {
+		t.Fatalf("expected new token to be valid, got %v err=%v", valid, err)
+	}
+}
+
 func TestSessionTokensClosedStore(t *testing.T) {
 	dir := t.TempDir()
 	store, err := New(filepath.Join(dir, "test.db"))
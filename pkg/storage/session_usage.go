@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Usage grouping keys accepted by SessionUsage.
+const (
+	UsageGroupByProject = "project"
+	UsageGroupByModel   = "model"
+)
+
+// UsageGroup aggregates usage for one project or model. For project groups
+// Tokens are the stored conversation tokens; for model groups they are the
+// prompt and completion tokens billed by the API and Messages is unset.
+type UsageGroup struct {
+	Key      string  `json:"key"`
+	Sessions int     `json:"sessions"`
+	Messages int     `json:"messages,omitempty"`
+	Calls    int     `json:"calls,omitempty"`
+	Tokens   int     `json:"tokens"`
+	Cost     float64 `json:"cost"`
+}
+
+// SessionUsageStats summarizes sessions active since a point in time.
+type SessionUsageStats struct {
+	Since   time.Time    `json:"since,omitempty"`
+	GroupBy string       `json:"groupBy"`
+	Total   UsageGroup   `json:"total"`
+	Groups  []UsageGroup `json:"groups"`
+}
+
+// SessionUsage aggregates the per-session counters kept on the sessions table
+// for every session active at or after since (zero means all time), and
+// groups them by project or by the models recorded in api_calls. Groups are
+// ordered by cost, then tokens, descending.
+func (s *Store) SessionUsage(since time.Time, groupBy string) (*SessionUsageStats, error) {
+	groupBy = strings.ToLower(strings.TrimSpace(groupBy))
+	if groupBy == "" {
+		groupBy = UsageGroupByProject
+	}
+	if groupBy != UsageGroupByProject && groupBy != UsageGroupByModel {
+		return nil, fmt.Errorf("invalid usage grouping %q (valid: %s, %s)", groupBy, UsageGroupByProject, UsageGroupByModel)
+	}
+
+	rows, err := s.db.Query(`SELECT project_path, last_active, message_count, total_tokens, total_cost FROM sessions`)
+	if err != nil {
+		return nil, fmt.Errorf("query session usage: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &SessionUsageStats{Since: since, GroupBy: groupBy, Total: UsageGroup{Key: "total"}, Groups: []UsageGroup{}}
+	projects := make(map[string]*UsageGroup)
+	for rows.Next() {
+		var (
+			project    sql.NullString
+			lastActive time.Time
+			group      UsageGroup
+		)
+		if err := rows.Scan(&project, &lastActive, &group.Messages, &group.Tokens, &group.Cost); err != nil {
+			return nil, fmt.Errorf("scan session usage: %w", err)
+		}
+		if !since.IsZero() && lastActive.Before(since) {
+			continue
+		}
+		stats.Total.Sessions++
+		stats.Total.Messages += group.Messages
+		stats.Total.Tokens += group.Tokens
+		stats.Total.Cost += group.Cost
+
+		acc := projects[project.String]
+		if acc == nil {
+			acc = &UsageGroup{Key: project.String}
+			projects[project.String] = acc
+		}
+		acc.Sessions++
+		acc.Messages += group.Messages
+		acc.Tokens += group.Tokens
+		acc.Cost += group.Cost
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate session usage: %w", err)
+	}
+
+	if groupBy == UsageGroupByModel {
+		stats.Groups, err = s.modelUsage(since)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, group := range projects {
+			stats.Groups = append(stats.Groups, *group)
+		}
+	}
+	sort.Slice(stats.Groups, func(i, j int) bool {
+		a, b := stats.Groups[i], stats.Groups[j]
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		if a.Tokens != b.Tokens {
+			return a.Tokens > b.Tokens
+		}
+		return a.Key < b.Key
+	})
+	return stats, nil
+}
+
+// modelUsage groups API calls made at or after since by model.
+func (s *Store) modelUsage(since time.Time) ([]UsageGroup, error) {
+	query := `
+		SELECT model, COUNT(DISTINCT session_id), COUNT(*),
+		       COALESCE(SUM(prompt_tokens + completion_tokens), 0), COALESCE(SUM(cost), 0)
+		FROM api_calls
+		WHERE timestamp >= ?
+		GROUP BY model
+	`
+	// SaveAPICall stores UTC timestamps in this layout, so they compare as text.
+	cutoff := since.UTC().Format("2006-01-02 15:04:05")
+	if since.IsZero() {
+		cutoff = ""
+	}
+	rows, err := s.db.Query(query, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("query model usage: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []UsageGroup{}
+	for rows.Next() {
+		var group UsageGroup
+		if err := rows.Scan(&group.Key, &group.Sessions, &group.Calls, &group.Tokens, &group.Cost); err != nil {
+			return nil, fmt.Errorf("scan model usage: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate model usage: %w", err)
+	}
+	return groups, nil
+}
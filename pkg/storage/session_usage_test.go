@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionUsageGroupsByProjectAndModel(t *testing.T) {
+	store := setupTestStore(t)
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Now()
+	seed := []struct {
+		id         string
+		project    string
+		lastActive time.Time
+		messages   int
+		tokens     int
+	}{
+		{id: "a1", project: "/work/alpha", lastActive: now.Add(-time.Hour), messages: 10, tokens: 1000},
+		{id: "a2", project: "/work/alpha", lastActive: now.Add(-2 * time.Hour), messages: 4, tokens: 400},
+		{id: "b1", project: "/work/beta", lastActive: now.Add(-3 * time.Hour), messages: 6, tokens: 2000},
+		{id: "old", project: "/work/beta", lastActive: now.Add(-30 * 24 * time.Hour), messages: 50, tokens: 9000},
+	}
+	for _, sess := range seed {
+		if err := store.CreateSession(&Session{ID: sess.id, ProjectPath: sess.project, CreatedAt: sess.lastActive, LastActive: sess.lastActive, Status: SessionStatusActive}); err != nil {
+			t.Fatalf("CreateSession %s: %v", sess.id, err)
+		}
+		if err := store.UpdateSessionStats(sess.id, sess.messages, sess.tokens, 0); err != nil {
+			t.Fatalf("UpdateSessionStats %s: %v", sess.id, err)
+		}
+	}
+	calls := []APICall{
+		{SessionID: "a1", Model: "openai/gpt-5", PromptTokens: 300, CompletionTokens: 100, Cost: 0.50, Timestamp: now.Add(-time.Hour)},
+		{SessionID: "a2", Model: "openai/gpt-5", PromptTokens: 100, CompletionTokens: 50, Cost: 0.25, Timestamp: now.Add(-2 * time.Hour)},
+		{SessionID: "b1", Model: "anthropic/claude", PromptTokens: 800, CompletionTokens: 200, Cost: 2.00, Timestamp: now.Add(-3 * time.Hour)},
+		{SessionID: "old", Model: "anthropic/claude", PromptTokens: 5000, CompletionTokens: 1000, Cost: 9.00, Timestamp: now.Add(-30 * 24 * time.Hour)},
+	}
+	for i := range calls {
+		if err := store.SaveAPICall(&calls[i]); err != nil {
+			t.Fatalf("SaveAPICall: %v", err)
+		}
+	}
+
+	since := now.Add(-7 * 24 * time.Hour)
+	stats, err := store.SessionUsage(since, UsageGroupByProject)
+	if err != nil {
+		t.Fatalf("SessionUsage project: %v", err)
+	}
+	if stats.Total.Sessions != 3 || stats.Total.Messages != 20 || stats.Total.Tokens != 3400 {
+		t.Fatalf("total = %+v, want 3 sessions, 20 messages, 3400 tokens", stats.Total)
+	}
+	if diff := stats.Total.Cost - 2.75; diff > 1e-9 || diff < -1e-9 {
+		t.Fatalf("total cost = %f, want 2.75", stats.Total.Cost)
+	}
+	if len(stats.Groups) != 2 {
+		t.Fatalf("project groups = %+v", stats.Groups)
+	}
+	// Ordered by cost: beta's single session cost more than both alpha sessions.
+	if beta := stats.Groups[0]; beta.Key != "/work/beta" || beta.Sessions != 1 || beta.Messages != 6 {
+		t.Fatalf("first group = %+v, want /work/beta with 1 session", beta)
+	}
+	if alpha := stats.Groups[1]; alpha.Key != "/work/alpha" || alpha.Sessions != 2 || alpha.Messages != 14 || alpha.Tokens != 1400 {
+		t.Fatalf("second group = %+v, want /work/alpha with 2 sessions", alpha)
+	}
+
+	stats, err = store.SessionUsage(since, UsageGroupByModel)
+	if err != nil {
+		t.Fatalf("SessionUsage model: %v", err)
+	}
+	if len(stats.Groups) != 2 {
+		t.Fatalf("model groups = %+v", stats.Groups)
+	}
+	if claude := stats.Groups[0]; claude.Key != "anthropic/claude" || claude.Calls != 1 || claude.Tokens != 1000 || claude.Cost != 2.00 {
+		t.Fatalf("first model group = %+v, want only the in-window claude call", claude)
+	}
+	if gpt := stats.Groups[1]; gpt.Key != "openai/gpt-5" || gpt.Sessions != 2 || gpt.Calls != 2 || gpt.Tokens != 550 {
+		t.Fatalf("second model group = %+v", gpt)
+	}
+
+	all, err := store.SessionUsage(time.Time{}, "")
+	if err != nil {
+		t.Fatalf("SessionUsage all time: %v", err)
+	}
+	if all.GroupBy != UsageGroupByProject || all.Total.Sessions != 4 || all.Total.Tokens != 12400 {
+		t.Fatalf("all-time total = %+v (group by %q)", all.Total, all.GroupBy)
+	}
+
+	if _, err := store.SessionUsage(since, "branch"); err == nil {
+		t.Fatal("expected an error for an unknown grouping")
+	}
+}
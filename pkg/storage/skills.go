@@ -18,8 +18,12 @@ type SessionSkill struct {
 	DeactivatedAt *time.Time `json:"deactivatedAt,omitempty"`
 }
 
-// SaveSessionSkill saves or updates a skill activation in the database
+// SaveSessionSkill saves or updates a skill activation in the database.
+// It is a no-op for ephemeral stores.
 func (s *Store) SaveSessionSkill(sessionID, skillName, activatedBy, scope string) error {
+	if s.Ephemeral() {
+		return nil
+	}
 	query := `
 		INSERT INTO session_skills (session_id, skill_name, activated_by, scope, is_active)
 		VALUES (?, ?, ?, ?, TRUE)
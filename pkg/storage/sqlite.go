@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	sqlite "modernc.org/sqlite"
@@ -25,6 +26,9 @@ type Store struct {
 	observers  []Observer
 	observerMu sync.RWMutex
 	stmtCache  stmtCache
+
+	ephemeral         atomic.Bool
+	telemetryDisabled atomic.Bool
 }
 
 // ErrStoreClosed indicates the underlying database connection is unavailable.
@@ -132,6 +136,28 @@ func (s *Store) DB() *sql.DB {
 	return s.db
 }
 
+// SetEphemeral turns off transcript persistence. Conversations then live only
+// in memory and telemetry events are not stored either.
+func (s *Store) SetEphemeral(ephemeral bool) {
+	s.ephemeral.Store(ephemeral)
+}
+
+// Ephemeral reports whether transcript persistence is disabled. A nil store is
+// treated as ephemeral since nothing can be written to it.
+func (s *Store) Ephemeral() bool {
+	return s == nil || s.ephemeral.Load()
+}
+
+// SetTelemetryPersistence enables or disables storing telemetry events.
+func (s *Store) SetTelemetryPersistence(enabled bool) {
+	s.telemetryDisabled.Store(!enabled)
+}
+
+// TelemetryPersistenceEnabled reports whether telemetry events are stored.
+func (s *Store) TelemetryPersistenceEnabled() bool {
+	return !s.Ephemeral() && !s.telemetryDisabled.Load()
+}
+
 // AddObserver registers a new observer that will receive storage events.
 func (s *Store) AddObserver(observer Observer) {
 	s.observerMu.Lock()
@@ -183,6 +209,12 @@ var migrations = []Migration{
 	{15, "ipc_events", ensureIPCEventsSchema},
 	{16, "normalize_legacy_timestamps", normalizeLegacyTimestamps},
 	{17, "normalize_session_lifecycle_timestamps", normalizeLegacyTimestamps},
+	{18, "tool_audit_call_columns", ensureToolAuditSchema},
+	{19, "message_attachments", ensureMessageAttachmentsSchema},
+	{20, "session_parent", ensureSessionSchema},
+	{21, "message_pinned", ensureMessagesSchema},
+	{22, "embedding_cache", ensureEmbeddingCacheSchema},
+	{23, "session_token_expiry", ensureSessionTokensSchema},
 }
 
 func sqliteTimestamp(value time.Time) string {
@@ -253,6 +285,29 @@ func ensureProviderThreadsSchema(db *sql.DB) error {
 	return nil
 }
 
+func ensureMessageAttachmentsSchema(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS message_attachments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id TEXT NOT NULL,
+		message_id INTEGER,
+		path TEXT NOT NULL,
+		content_hash TEXT NOT NULL,
+		size_bytes INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (session_id) REFERENCES sessions(session_id) ON DELETE CASCADE,
+		FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE SET NULL
+	)`); err != nil {
+		return fmt.Errorf("create message_attachments: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_message_attachments_session ON message_attachments(session_id, id)`); err != nil {
+		return fmt.Errorf("index message_attachments session: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_message_attachments_message ON message_attachments(message_id)`); err != nil {
+		return fmt.Errorf("index message_attachments message: %w", err)
+	}
+	return nil
+}
+
 // runMigrations runs the schema migrations with version tracking
 func runMigrations(db *sql.DB) error {
 	// First, ensure the schema_migrations table exists so we can track versions
@@ -461,6 +516,12 @@ func ensureSessionSchema(db *sql.DB) error {
 		}
 	}
 
+	if !cols["parent_session_id"] {
+		if _, err := db.Exec(`ALTER TABLE sessions ADD COLUMN parent_session_id TEXT`); err != nil {
+			return fmt.Errorf("add session parent_session_id: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -572,6 +633,11 @@ func ensureMessagesSchema(db *sql.DB) error {
 			return fmt.Errorf("add messages.is_truncated: %w", err)
 		}
 	}
+	if !cols["is_pinned"] {
+		if _, err := db.Exec(`ALTER TABLE messages ADD COLUMN is_pinned BOOLEAN DEFAULT FALSE`); err != nil {
+			return fmt.Errorf("add messages.is_pinned: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -6,10 +6,14 @@ import (
 )
 
 // SaveSessionSummary stores a compacted session summary for cross-instance awareness.
+// It is a no-op for ephemeral stores.
 func (s *Store) SaveSessionSummary(sessionID, summary string) error {
 	if s == nil {
 		return ErrStoreClosed
 	}
+	if s.Ephemeral() {
+		return nil
+	}
 	key := fmt.Sprintf("session.%s.summary", sessionID)
 	return s.SetSetting(key, summary)
 }
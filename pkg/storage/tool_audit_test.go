@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogToolExecutionRecordsCallAuditFields(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "audit.db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer store.Close()
+
+	if err := store.CreateSession(&Session{ID: "s1", CreatedAt: time.Now(), LastActive: time.Now(), Status: SessionStatusActive}); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	started := time.Now().Add(-time.Minute)
+	if err := store.LogToolExecution(&ToolAuditEntry{
+		SessionID:  "s1",
+		ToolName:   "read_file",
+		ToolInput:  `{"path":"main.go"}`,
+		ToolOutput: "\n  package main\nfunc main() {}\n",
+		Decision:   "auto",
+		ExecutedAt: started,
+		DurationMs: 42,
+		Success:    true,
+	}); err != nil {
+		t.Fatalf("LogToolExecution success: %v", err)
+	}
+	if err := store.LogToolExecution(&ToolAuditEntry{
+		SessionID:  "s1",
+		ToolName:   "run_shell",
+		ToolInput:  `{"command":"false"}`,
+		ToolOutput: "Error: " + strings.Repeat("x", 500),
+		Decision:   "approved",
+		ExecutedAt: started.Add(time.Second),
+		DurationMs: 7,
+	}); err != nil {
+		t.Fatalf("LogToolExecution failure: %v", err)
+	}
+
+	entries, err := store.GetAuditLog("s1", 10)
+	if err != nil {
+		t.Fatalf("GetAuditLog: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	failed, ok := entries[0], entries[1]
+	if failed.ToolName != "run_shell" || failed.Success || failed.DurationMs != 7 {
+		t.Fatalf("unexpected failed entry: %+v", failed)
+	}
+	if len(failed.ResultSummary) != maxToolResultSummaryChars+len("...") {
+		t.Fatalf("failed summary length = %d, want it truncated", len(failed.ResultSummary))
+	}
+	if ok.ToolName != "read_file" || !ok.Success || ok.DurationMs != 42 || ok.ResultSummary != "package main" {
+		t.Fatalf("unexpected success entry: %+v", ok)
+	}
+	if len(ok.ArgsHash) != 64 || ok.ArgsHash == failed.ArgsHash {
+		t.Fatalf("args hashes = %q / %q, want distinct sha256 hex digests", ok.ArgsHash, failed.ArgsHash)
+	}
+}
+
+func TestEnsureToolAuditSchemaUpgradesLegacyTable(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "legacy.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE tool_audit_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id TEXT NOT NULL,
+		tool_name TEXT NOT NULL,
+		tool_input TEXT NOT NULL
+	)`); err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO tool_audit_log (session_id, tool_name, tool_input) VALUES ('s1', 'read_file', '{}')`); err != nil {
+		t.Fatalf("insert legacy row: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := ensureToolAuditSchema(db); err != nil {
+			t.Fatalf("ensureToolAuditSchema run %d: %v", i+1, err)
+		}
+	}
+
+	var argsHash, summary sql.NullString
+	var success sql.NullBool
+	if err := db.QueryRow(`SELECT args_hash, success, result_summary FROM tool_audit_log`).Scan(&argsHash, &success, &summary); err != nil {
+		t.Fatalf("query upgraded columns: %v", err)
+	}
+	if argsHash.Valid || success.Valid || summary.Valid {
+		t.Fatalf("legacy row should keep NULL audit columns, got %v %v %v", argsHash, success, summary)
+	}
+}
@@ -28,8 +28,11 @@ const (
 	EventToolStarted                EventType = "tool.started"
 	EventToolCompleted              EventType = "tool.completed"
 	EventToolFailed                 EventType = "tool.failed"
+	EventToolBlocked                EventType = "tool.blocked"
 	EventModelStreamStarted         EventType = "model.stream_start"
 	EventModelStreamEnded           EventType = "model.stream_end"
+	EventModelLatency               EventType = "model.latency"
+	EventModelFallback              EventType = "model.fallback"
 	EventIndexStarted               EventType = "index.started"
 	EventIndexCompleted             EventType = "index.completed"
 	EventIndexFailed                EventType = "index.failed"
@@ -46,6 +49,7 @@ const (
 	EventRLMIteration               EventType = "rlm.iteration"
 	EventCircuitFailure             EventType = "circuit.failure"
 	EventCircuitStateChange         EventType = "circuit.state_change"
+	EventMCPServerState             EventType = "mcp.server_state"
 	EventSubagentSpawned            EventType = "subagent.spawned"
 	EventSubagentState              EventType = "subagent.state"
 	EventSubagentCompleted          EventType = "subagent.completed"
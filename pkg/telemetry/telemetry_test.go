@@ -232,6 +232,8 @@ func TestHub_EventTypes(t *testing.T) {
 		EventToolFailed,
 		EventModelStreamStarted,
 		EventModelStreamEnded,
+		EventModelLatency,
+		EventModelFallback,
 		EventIndexStarted,
 		EventIndexCompleted,
 		EventIndexFailed,
@@ -0,0 +1,159 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/tool/builtin"
+)
+
+// ErrToolNotApproved is returned when a tool listed in tools.dangerous is
+// called and nobody approved it.
+var ErrToolNotApproved = errors.New("tool call not approved")
+
+// ApprovalFunc asks a person whether a tool call may run. It blocks until
+// they decide or ctx is done.
+type ApprovalFunc func(ctx context.Context, name string, params map[string]any) (bool, error)
+
+type approvedCallKey struct{}
+
+// WithApprovedCall marks calls made with ctx as already approved, for callers
+// that ask for confirmation themselves before executing.
+func WithApprovedCall(ctx context.Context) context.Context {
+	return context.WithValue(ctx, approvedCallKey{}, true)
+}
+
+func callApproved(ctx context.Context) bool {
+	approved, _ := ctx.Value(approvedCallKey{}).(bool)
+	return approved
+}
+
+// SetDangerousTools lists tools that need approval on every call, whatever
+// the approval mode or trust level. Names match case-insensitively; an empty
+// list clears it.
+func (r *Registry) SetDangerousTools(names []string) {
+	if r == nil {
+		return
+	}
+	var set map[string]struct{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if set == nil {
+			set = make(map[string]struct{}, len(names))
+		}
+		set[name] = struct{}{}
+	}
+	r.mu.Lock()
+	r.dangerous = set
+	r.mu.Unlock()
+}
+
+// SetApprover installs the function that confirms dangerous tool calls.
+// Without one the registry asks mission control when it is enabled, and
+// otherwise refuses the call.
+func (r *Registry) SetApprover(fn ApprovalFunc) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.approver = fn
+	r.mu.Unlock()
+}
+
+// RequiresApproval reports whether name is listed in tools.dangerous.
+func (r *Registry) RequiresApproval(name string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.dangerous[strings.ToLower(strings.TrimSpace(name))]
+	return ok
+}
+
+// ConfirmCall gets approval for a call to a dangerous tool. It returns nil
+// for other tools and for contexts marked with WithApprovedCall. Calls made
+// through ExecuteWithContext are confirmed automatically; callers that run a
+// Tool directly must call this first.
+func (r *Registry) ConfirmCall(ctx context.Context, name string, params map[string]any) error {
+	if !r.RequiresApproval(name) {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if callApproved(ctx) {
+		return nil
+	}
+	name = strings.TrimSpace(name)
+
+	r.mu.RLock()
+	approver := r.approver
+	r.mu.RUnlock()
+	switch {
+	case approver != nil:
+		approved, err := approver(ctx, name, params)
+		if err != nil {
+			return fmt.Errorf("approve %s: %w", name, err)
+		}
+		if !approved {
+			return fmt.Errorf("%s: %w", name, ErrToolNotApproved)
+		}
+		return nil
+	case r.missionStore != nil && r.missionSession != "":
+		return r.confirmWithMission(ctx, name, params)
+	default:
+		return fmt.Errorf("%s is listed in tools.dangerous and there is no one to approve it here: %w", name, ErrToolNotApproved)
+	}
+}
+
+// confirmWithMission records the call as a pending change and waits for a
+// reviewer. Unlike mission write gating it applies at every trust level.
+func (r *Registry) confirmWithMission(ctx context.Context, name string, params map[string]any) error {
+	args, err := json.MarshalIndent(params, "", "  ")
+	if err != nil {
+		args = []byte(fmt.Sprintf("%v", params))
+	}
+	diff := fmt.Sprintf("dangerous tool requested: %s\n%s", name, args)
+
+	changeID, err := r.recordPendingChange("tool://"+name, diff, name)
+	if err != nil {
+		return fmt.Errorf("failed to create pending change: %w", err)
+	}
+	change, err := r.awaitDecision(ctx, changeID)
+	if err != nil {
+		return fmt.Errorf("approval wait failed: %w", err)
+	}
+	if change.Status != "approved" {
+		return fmt.Errorf("change %s %s by %s: %w", change.ID, change.Status, change.ReviewedBy, ErrToolNotApproved)
+	}
+	return nil
+}
+
+// dangerousToolMiddleware holds calls to dangerous tools until ConfirmCall
+// approves them. Tools that mission approval already gates are left to it so
+// the reviewer sees the call once.
+func (r *Registry) dangerousToolMiddleware() Middleware {
+	return func(next Executor) Executor {
+		return func(ctx *ExecutionContext) (*builtin.Result, error) {
+			if ctx == nil || !r.RequiresApproval(ctx.ToolName) {
+				return next(ctx)
+			}
+			if r.shouldGateChanges() && missionGatedTool(ctx.ToolName) {
+				return next(ctx)
+			}
+			if err := r.ConfirmCall(ctx.Context, ctx.ToolName, ctx.Params); err != nil {
+				res := &builtin.Result{Success: false, Error: err.Error()}
+				r.publishToolBlock(ctx, strings.TrimSpace(ctx.ToolName), "approval", res.Error)
+				return res, nil
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/mission"
+	"m31labs.dev/buckley/pkg/storage"
+	"m31labs.dev/buckley/pkg/tool/builtin"
+)
+
+type countingTool struct {
+	governedTestTool
+	calls int
+}
+
+func (t *countingTool) Execute(params map[string]any) (*builtin.Result, error) {
+	t.calls++
+	return &builtin.Result{Success: true}, nil
+}
+
+func newDangerousTestRegistry(t *testing.T) (*Registry, *countingTool) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"Git_Status"}
+	registry := NewEmptyRegistry()
+	gitStatus := &countingTool{governedTestTool: governedTestTool{name: "git_status"}}
+	registry.Register(gitStatus)
+	registry.Register(&governedTestTool{name: "read_file"})
+	ApplyToolPolicyConfig(registry, cfg)
+	return registry, gitStatus
+}
+
+func TestDangerousToolRefusedWithoutApprover(t *testing.T) {
+	registry, gitStatus := newDangerousTestRegistry(t)
+	if !registry.RequiresApproval("GIT_STATUS") || registry.RequiresApproval("read_file") {
+		t.Fatal("RequiresApproval should match tools.dangerous case-insensitively")
+	}
+
+	res, err := registry.Execute("git_status", nil)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if res.Success || !strings.Contains(res.Error, "tools.dangerous") || gitStatus.calls != 0 {
+		t.Fatalf("result = %+v, calls = %d; want refusal without running", res, gitStatus.calls)
+	}
+	if res, _ := registry.Execute("read_file", nil); !res.Success {
+		t.Fatalf("safe tool refused: %+v", res)
+	}
+
+	res, _ = registry.ExecuteWithContext(WithApprovedCall(context.Background()), "git_status", nil)
+	if !res.Success || gitStatus.calls != 1 {
+		t.Fatalf("pre-approved call = %+v, calls = %d; want it to run", res, gitStatus.calls)
+	}
+}
+
+func TestDangerousToolAsksApprover(t *testing.T) {
+	registry, gitStatus := newDangerousTestRegistry(t)
+	var asked []string
+	approve := false
+	registry.SetApprover(func(_ context.Context, name string, _ map[string]any) (bool, error) {
+		asked = append(asked, name)
+		return approve, nil
+	})
+
+	res, _ := registry.Execute("git_status", nil)
+	if res.Success || gitStatus.calls != 0 {
+		t.Fatalf("denied call = %+v, calls = %d", res, gitStatus.calls)
+	}
+	if err := registry.ConfirmCall(context.Background(), "git_status", nil); !errors.Is(err, ErrToolNotApproved) {
+		t.Fatalf("ConfirmCall after denial = %v, want ErrToolNotApproved", err)
+	}
+
+	approve = true
+	if res, _ := registry.Execute("git_status", nil); !res.Success || gitStatus.calls != 1 {
+		t.Fatalf("approved call = %+v, calls = %d", res, gitStatus.calls)
+	}
+	registry.Execute("read_file", nil)
+	if len(asked) != 3 {
+		t.Fatalf("approver asked for %v, want only the three git_status calls", asked)
+	}
+}
+
+func TestDangerousToolUsesMissionControlAtEveryTrustLevel(t *testing.T) {
+	store, err := storage.New(filepath.Join(t.TempDir(), "mission.db"))
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.CreateSession(&storage.Session{ID: "session-1", CreatedAt: time.Now(), LastActive: time.Now(), Status: storage.SessionStatusActive}); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	missionStore := mission.NewStore(store.DB())
+
+	registry, gitStatus := newDangerousTestRegistry(t)
+	// Autonomous sessions turn write gating off; dangerous tools stay gated.
+	registry.EnableMissionControl(missionStore, "agent-1", false, 2*time.Second)
+	registry.UpdateMissionSession("session-1")
+
+	done := make(chan *builtin.Result, 1)
+	go func() {
+		res, _ := registry.Execute("git_status", map[string]any{"short": true})
+		done <- res
+	}()
+
+	changeID := waitForPendingChange(t, store.DB())
+	change, err := missionStore.GetPendingChange(changeID)
+	if err != nil || change.FilePath != "tool://git_status" {
+		t.Fatalf("pending change = %+v, %v", change, err)
+	}
+	if err := missionStore.UpdatePendingChangeStatus(changeID, "rejected", "tester"); err != nil {
+		t.Fatalf("reject change: %v", err)
+	}
+
+	select {
+	case res := <-done:
+		if res.Success || !strings.Contains(res.Error, "rejected by tester") || gitStatus.calls != 0 {
+			t.Fatalf("rejected call = %+v, calls = %d", res, gitStatus.calls)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("execution did not finish after rejection")
+	}
+}
@@ -0,0 +1,97 @@
+package builtin
+
+import (
+	"sync"
+)
+
+// MemoryTodoStore keeps TODO lists in memory for sessions that must not
+// write to disk. It satisfies TodoStore.
+type MemoryTodoStore struct {
+	mu          sync.Mutex
+	nextID      int64
+	todos       map[string][]TodoItem
+	checkpoints map[string]TodoCheckpointData
+}
+
+// NewMemoryTodoStore creates an empty in-memory TODO store.
+func NewMemoryTodoStore() *MemoryTodoStore {
+	return &MemoryTodoStore{
+		todos:       make(map[string][]TodoItem),
+		checkpoints: make(map[string]TodoCheckpointData),
+	}
+}
+
+func (m *MemoryTodoStore) CreateTodo(todo *TodoItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	todo.ID = m.nextID
+	m.todos[todo.SessionID] = append(m.todos[todo.SessionID], *todo)
+	return nil
+}
+
+func (m *MemoryTodoStore) UpdateTodoStatus(id int64, status string, errorMessage string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for sessionID, todos := range m.todos {
+		for i := range todos {
+			if todos[i].ID == id {
+				m.todos[sessionID][i].Status = status
+				m.todos[sessionID][i].ErrorMessage = errorMessage
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MemoryTodoStore) GetTodos(sessionID string) ([]TodoItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]TodoItem, len(m.todos[sessionID]))
+	copy(result, m.todos[sessionID])
+	return result, nil
+}
+
+func (m *MemoryTodoStore) GetActiveTodo(sessionID string) (*TodoItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, todo := range m.todos[sessionID] {
+		if todo.Status == "in_progress" {
+			active := todo
+			return &active, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MemoryTodoStore) DeleteTodos(sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.todos, sessionID)
+	return nil
+}
+
+func (m *MemoryTodoStore) CreateCheckpoint(checkpoint *TodoCheckpointData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	checkpoint.ID = m.nextID
+	m.checkpoints[checkpoint.SessionID] = *checkpoint
+	return nil
+}
+
+func (m *MemoryTodoStore) GetLatestCheckpoint(sessionID string) (*TodoCheckpointData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	checkpoint, ok := m.checkpoints[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+// EnsureSession is a no-op; in-memory lists need no session row.
+func (m *MemoryTodoStore) EnsureSession(sessionID string) error {
+	return nil
+}
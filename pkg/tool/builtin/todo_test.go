@@ -724,3 +724,25 @@ func (m *mockPlanningClient) ChatCompletion(ctx context.Context, req model.ChatR
 		},
 	}, nil
 }
+
+func TestTodoTool_MemoryStoreRoundTrip(t *testing.T) {
+	tool := &TodoTool{Store: NewMemoryTodoStore()}
+	res, err := tool.Execute(map[string]any{
+		"action":     "create",
+		"session_id": "s1",
+		"todos":      []any{map[string]any{"content": "Write tests", "activeForm": "Writing tests", "status": "pending"}},
+	})
+	if err != nil || !res.Success {
+		t.Fatalf("create = %+v, %v", res, err)
+	}
+	if res, err := tool.Execute(map[string]any{"action": "update", "session_id": "s1", "todo_id": float64(1), "status": "in_progress"}); err != nil || !res.Success {
+		t.Fatalf("update = %+v, %v", res, err)
+	}
+	active, err := tool.Store.GetActiveTodo("s1")
+	if err != nil || active == nil || active.Content != "Write tests" {
+		t.Fatalf("active todo = %+v, %v", active, err)
+	}
+	if todos, _ := tool.Store.GetTodos("s2"); len(todos) != 0 {
+		t.Fatalf("other session todos = %+v, want none", todos)
+	}
+}
@@ -0,0 +1,168 @@
+package builtin
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultToolOutputStoreBytes bounds the full outputs a ToolOutputStore keeps.
+const DefaultToolOutputStoreBytes = 8 << 20
+
+// readToolOutputMaxChunk keeps a read_tool_output result under the chat
+// context cap once its content is JSON-escaped again.
+const readToolOutputMaxChunk = 12 * 1024
+
+// ToolOutputStore keeps the full text of tool outputs that were truncated
+// before reaching the model, keyed by tool call ID. The oldest outputs are
+// evicted once the total exceeds the byte budget.
+type ToolOutputStore struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	outputs  map[string]string
+	order    []string
+}
+
+// NewToolOutputStore creates a store holding up to maxBytes of output;
+// maxBytes <= 0 uses DefaultToolOutputStoreBytes.
+func NewToolOutputStore(maxBytes int) *ToolOutputStore {
+	if maxBytes <= 0 {
+		maxBytes = DefaultToolOutputStoreBytes
+	}
+	return &ToolOutputStore{maxBytes: maxBytes, outputs: make(map[string]string)}
+}
+
+// Put stores output for callID, replacing any earlier output for it.
+func (s *ToolOutputStore) Put(callID, output string) {
+	callID = strings.TrimSpace(callID)
+	if s == nil || callID == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.outputs[callID]; ok {
+		s.size -= len(old)
+		s.removeLocked(callID)
+	}
+	for len(s.order) > 0 && s.size+len(output) > s.maxBytes {
+		oldest := s.order[0]
+		s.order = s.order[1:]
+		s.size -= len(s.outputs[oldest])
+		delete(s.outputs, oldest)
+	}
+	s.outputs[callID] = output
+	s.order = append(s.order, callID)
+	s.size += len(output)
+}
+
+// Get returns the stored output for callID.
+func (s *ToolOutputStore) Get(callID string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	output, ok := s.outputs[strings.TrimSpace(callID)]
+	return output, ok
+}
+
+func (s *ToolOutputStore) removeLocked(callID string) {
+	for i, id := range s.order {
+		if id == callID {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// ReadToolOutputTool pages through outputs kept in a ToolOutputStore.
+type ReadToolOutputTool struct {
+	Store *ToolOutputStore
+}
+
+func (t *ReadToolOutputTool) Name() string {
+	return "read_tool_output"
+}
+
+func (t *ReadToolOutputTool) Description() string {
+	return "Read more of a tool result that was truncated for chat context. Pass the tool_call_id from the truncation notice and the byte offset to continue from."
+}
+
+func (t *ReadToolOutputTool) Parameters() ParameterSchema {
+	return ParameterSchema{
+		Type: "object",
+		Properties: map[string]PropertySchema{
+			"tool_call_id": {
+				Type:        "string",
+				Description: "ID of the truncated tool call, as shown in the truncation notice",
+			},
+			"offset": {
+				Type:        "integer",
+				Description: "Byte offset to start reading from",
+				Default:     0,
+			},
+			"length": {
+				Type:        "integer",
+				Description: fmt.Sprintf("Maximum bytes to return (up to %d)", readToolOutputMaxChunk),
+				Default:     readToolOutputMaxChunk,
+			},
+		},
+		Required: []string{"tool_call_id"},
+	}
+}
+
+func (t *ReadToolOutputTool) Execute(params map[string]any) (*Result, error) {
+	callID := stringParam(params, "tool_call_id")
+	if callID == "" {
+		return &Result{Success: false, Error: "tool_call_id parameter is required"}, nil
+	}
+	output, ok := t.Store.Get(callID)
+	if !ok {
+		return &Result{
+			Success: false,
+			Error:   fmt.Sprintf("no stored output for tool call %q; only outputs truncated earlier in this session are kept", callID),
+		}, nil
+	}
+
+	offset := intParam(params, "offset", 0)
+	if offset < 0 || offset > len(output) {
+		return &Result{
+			Success: false,
+			Error:   fmt.Sprintf("offset %d is outside the output (0-%d bytes)", offset, len(output)),
+		}, nil
+	}
+	length := intParam(params, "length", readToolOutputMaxChunk)
+	if length <= 0 || length > readToolOutputMaxChunk {
+		length = readToolOutputMaxChunk
+	}
+
+	// Snap both ends to rune boundaries so a chunk never splits a character.
+	for offset < len(output) && !utf8.RuneStart(output[offset]) {
+		offset++
+	}
+	end := min(offset+length, len(output))
+	for end > offset && end < len(output) && !utf8.RuneStart(output[end]) {
+		end--
+	}
+	if end == offset && offset < len(output) {
+		_, size := utf8.DecodeRuneInString(output[offset:])
+		end = offset + size
+	}
+
+	return &Result{
+		Success: true,
+		Data: map[string]any{
+			"tool_call_id": callID,
+			"offset":       offset,
+			"next_offset":  end,
+			"total_bytes":  len(output),
+			"remaining":    len(output) - end,
+			"content":      output[offset:end],
+		},
+		DisplayData: map[string]any{
+			"summary": fmt.Sprintf("Read bytes %d-%d of %d from tool call %s", offset, end, len(output), callID),
+		},
+	}, nil
+}
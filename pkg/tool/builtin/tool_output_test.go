@@ -0,0 +1,75 @@
+package builtin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToolOutputStoreEvictsOldest(t *testing.T) {
+	store := NewToolOutputStore(10)
+	store.Put("a", "12345")
+	store.Put("b", "6789")
+	store.Put("a", "abcde") // replacing keeps a single entry for "a"
+	if got, _ := store.Get("a"); got != "abcde" {
+		t.Fatalf("Get(a) = %q", got)
+	}
+
+	store.Put("c", "xyz")
+	if _, ok := store.Get("b"); ok {
+		t.Fatal("oldest output was not evicted")
+	}
+	for _, id := range []string{"a", "c"} {
+		if _, ok := store.Get(id); !ok {
+			t.Fatalf("output %s was evicted too early", id)
+		}
+	}
+
+	store.Put(" ", "ignored")
+	if _, ok := store.Get(""); ok {
+		t.Fatal("blank call IDs should not be stored")
+	}
+}
+
+func TestReadToolOutputToolPagesStoredOutput(t *testing.T) {
+	store := NewToolOutputStore(0)
+	full := strings.Repeat("0123456789", 3000)
+	store.Put("call_1", full)
+	tool := &ReadToolOutputTool{Store: store}
+
+	res, err := tool.Execute(map[string]any{"tool_call_id": "call_1", "offset": float64(100), "length": 50})
+	if err != nil || !res.Success {
+		t.Fatalf("Execute = %+v, %v", res, err)
+	}
+	if got := res.Data["content"]; got != full[100:150] {
+		t.Fatalf("content = %q, want bytes 100-150", got)
+	}
+	if res.Data["next_offset"] != 150 || res.Data["remaining"] != len(full)-150 {
+		t.Fatalf("paging data = %+v", res.Data)
+	}
+
+	res, _ = tool.Execute(map[string]any{"tool_call_id": "call_1", "offset": 0})
+	if content := res.Data["content"].(string); len(content) != readToolOutputMaxChunk {
+		t.Fatalf("default chunk = %d bytes, want %d", len(content), readToolOutputMaxChunk)
+	}
+
+	res, _ = tool.Execute(map[string]any{"tool_call_id": "call_1", "offset": len(full) + 1})
+	if res.Success {
+		t.Fatal("offset past the end should fail")
+	}
+	res, _ = tool.Execute(map[string]any{"tool_call_id": "missing"})
+	if res.Success || !strings.Contains(res.Error, "missing") {
+		t.Fatalf("unknown call result = %+v", res)
+	}
+}
+
+func TestReadToolOutputToolKeepsRunesWhole(t *testing.T) {
+	store := NewToolOutputStore(0)
+	store.Put("call_1", "aé€b")
+	tool := &ReadToolOutputTool{Store: store}
+
+	// Offset 2 lands inside "é" and length 2 would end inside "€".
+	res, _ := tool.Execute(map[string]any{"tool_call_id": "call_1", "offset": 2, "length": 2})
+	if got := res.Data["content"]; got != "€" {
+		t.Fatalf("content = %q, want the whole rune", got)
+	}
+}
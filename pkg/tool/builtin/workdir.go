@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"m31labs.dev/buckley/pkg/paths"
 )
 
 type workDirAware struct {
@@ -107,14 +109,14 @@ func resolvePath(workDir, raw string) (string, error) {
 	}
 
 	if !isWithinDir(base, candidate) {
-		return "", fmt.Errorf("path %q escapes workdir", raw)
+		return "", &paths.EscapeError{Path: raw, Root: base, Scope: "workdir"}
 	}
 
 	// Harden against symlink escapes.
 	resolvedBase := evalSymlinksFallback(base)
 	resolvedCandidate := evalSymlinksFallbackForTarget(candidate)
 	if !isWithinDir(resolvedBase, resolvedCandidate) {
-		return "", fmt.Errorf("path %q escapes workdir via symlink", raw)
+		return "", &paths.EscapeError{Path: raw, Root: base, Scope: "workdir", Symlink: true}
 	}
 
 	return candidate, nil
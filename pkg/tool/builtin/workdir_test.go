@@ -1,10 +1,14 @@
 package builtin
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"m31labs.dev/buckley/pkg/paths"
 )
 
 func TestWorkDirAwareExecContextDefaultNoDeadline(t *testing.T) {
@@ -351,3 +355,67 @@ func TestEvalSymlinksFallbackForTarget(t *testing.T) {
 		}
 	})
 }
+
+func TestResolvePathReturnsEscapeError(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	tests := []struct {
+		raw     string
+		symlink bool
+	}{
+		{raw: "../escape.txt"},
+		{raw: "sub/../../escape.txt"},
+		{raw: "./../" + filepath.Base(root) + "-sibling/file"},
+		{raw: filepath.Join(outside, "file.txt")},
+		{raw: "link/secret.txt", symlink: true},
+	}
+	for _, tt := range tests {
+		_, err := resolvePath(root, tt.raw)
+		var escape *paths.EscapeError
+		if !errors.As(err, &escape) || !errors.Is(err, paths.ErrOutsideRoot) {
+			t.Fatalf("resolvePath(%q) error = %v, want *paths.EscapeError", tt.raw, err)
+		}
+		if escape.Path != tt.raw || escape.Symlink != tt.symlink {
+			t.Fatalf("resolvePath(%q) escape = %+v, want symlink=%v", tt.raw, escape, tt.symlink)
+		}
+	}
+}
+
+func TestFileToolsRejectTraversal(t *testing.T) {
+	root := t.TempDir()
+	secret := filepath.Join(filepath.Dir(root), filepath.Base(root)+"-secret.txt")
+	if err := os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Remove(secret) })
+	traversal := "../" + filepath.Base(secret)
+
+	read := &ReadFileTool{}
+	read.SetWorkDir(root)
+	write := &WriteFileTool{}
+	write.SetWorkDir(root)
+	list := &ListDirectoryTool{}
+	list.SetWorkDir(root)
+
+	calls := map[string]func() (*Result, error){
+		"read_file":      func() (*Result, error) { return read.Execute(map[string]any{"path": traversal}) },
+		"write_file":     func() (*Result, error) { return write.Execute(map[string]any{"path": traversal, "content": "pwned"}) },
+		"list_directory": func() (*Result, error) { return list.Execute(map[string]any{"path": ".."}) },
+	}
+	for name, call := range calls {
+		res, err := call()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if res.Success || !strings.Contains(res.Error, "escapes workdir") {
+			t.Fatalf("%s: result = %+v, want escape rejection", name, res)
+		}
+	}
+	if data, err := os.ReadFile(secret); err != nil || string(data) != "secret" {
+		t.Fatalf("secret file modified: %q, %v", data, err)
+	}
+}
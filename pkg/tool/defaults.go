@@ -31,6 +31,7 @@ func ApplyToolMiddlewareConfig(registry *Registry, cfg *config.Config) {
 		defaults.MaxOutputBytes = middleware.MaxResultBytes
 	}
 	ApplyRegistryConfig(registry, defaults)
+	ApplyToolPolicyConfig(registry, cfg)
 }
 
 const (
@@ -22,6 +22,7 @@ var DefaultDiscoveryTools = []string{
 	"run_tests",
 	"activate_skill",
 	"compact_context",
+	"read_tool_output",
 }
 
 // EnableDynamicDiscovery limits model-visible schemas to a working set. It is
@@ -0,0 +1,87 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/tool/builtin"
+	"m31labs.dev/buckley/pkg/types"
+)
+
+// SetDryRun toggles dry-run mode. While enabled, tools that write files, run
+// commands or otherwise need more than read-only access report what they
+// would do instead of doing it. Read-only tools run normally.
+func (r *Registry) SetDryRun(enabled bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.dryRun = enabled
+	r.mu.Unlock()
+}
+
+// DryRun reports whether dry-run mode is enabled.
+func (r *Registry) DryRun() bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.dryRun
+}
+
+// dryRunMiddleware short-circuits mutating calls while dry-run is enabled.
+// It sits ahead of hooks, approval and user middleware so nothing downstream
+// sees a call that will not happen.
+func (r *Registry) dryRunMiddleware() Middleware {
+	return func(next Executor) Executor {
+		return func(ctx *ExecutionContext) (*builtin.Result, error) {
+			if ctx == nil || !r.DryRun() {
+				return next(ctx)
+			}
+			t := ctx.Tool
+			if t == nil {
+				t, _ = r.Get(strings.TrimSpace(ctx.ToolName))
+			}
+			if !isMutatingTool(ctx.ToolName) && RequiredTierForTool(t) == types.TierReadOnly {
+				return next(ctx)
+			}
+			return dryRunResult(strings.TrimSpace(ctx.ToolName), ctx.Params), nil
+		}
+	}
+}
+
+// dryRunResult describes the change a mutating call would have made.
+func dryRunResult(toolName string, params map[string]any) *builtin.Result {
+	data := map[string]any{
+		"dry_run": true,
+		"tool":    toolName,
+	}
+	var action string
+	switch toolName {
+	case "write_file":
+		path := stringFromParams(params, "path")
+		content, _ := params["content"].(string)
+		data["path"] = path
+		data["bytes"] = len(content)
+		action = fmt.Sprintf("write %d bytes to %s", len(content), path)
+	case "apply_patch":
+		patch, _ := params["patch"].(string)
+		files := patchTargets(patch, patchStrip(params["strip"]))
+		data["files"] = files
+		action = fmt.Sprintf("apply a patch to %s", strings.Join(files, ", "))
+	case "run_shell":
+		command := stringFromParams(params, "command")
+		data["command"] = command
+		action = fmt.Sprintf("run `%s`", command)
+	default:
+		if path := stringFromParams(params, "path"); path != "" {
+			data["path"] = path
+			action = fmt.Sprintf("run %s on %s", toolName, path)
+		} else {
+			action = fmt.Sprintf("run %s", toolName)
+		}
+	}
+	data["message"] = fmt.Sprintf("Dry run: would %s. No changes were made.", action)
+	return &builtin.Result{Success: true, Data: data}
+}
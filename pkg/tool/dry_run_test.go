@@ -0,0 +1,68 @@
+package tool
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDryRunBlocksWritesButAllowsReads(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(existing, []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	registry := NewRegistry()
+	registry.SetWorkDir(dir)
+	registry.EnableUndo(0)
+	registry.SetDryRun(true)
+
+	res, err := registry.Execute("write_file", map[string]any{"path": "new.txt", "content": "hello\n"})
+	if err != nil || !res.Success {
+		t.Fatalf("dry-run write: %+v, %v", res, err)
+	}
+	if res.Data["dry_run"] != true || res.Data["bytes"] != 6 || res.Data["path"] != "new.txt" {
+		t.Fatalf("dry-run data = %+v", res.Data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.txt")); !os.IsNotExist(err) {
+		t.Fatalf("dry-run write created a file: %v", err)
+	}
+	if _, err := registry.UndoLastEdit(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("dry-run write recorded an undo entry: %v", err)
+	}
+
+	res, err = registry.Execute("run_shell", map[string]any{"command": "touch shell.txt"})
+	if err != nil || res.Data["dry_run"] != true || res.Data["command"] != "touch shell.txt" {
+		t.Fatalf("dry-run shell: %+v, %v", res, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "shell.txt")); !os.IsNotExist(err) {
+		t.Fatalf("dry-run shell ran the command: %v", err)
+	}
+
+	res, err = registry.Execute("read_file", map[string]any{"path": "main.go"})
+	if err != nil || !res.Success || res.Data["dry_run"] != nil {
+		t.Fatalf("read under dry-run: %+v, %v", res, err)
+	}
+
+	registry.SetDryRun(false)
+	if res, err := registry.Execute("write_file", map[string]any{"path": "new.txt", "content": "hello\n"}); err != nil || !res.Success {
+		t.Fatalf("write after dry-run: %+v, %v", res, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "new.txt")); string(data) != "hello\n" {
+		t.Fatalf("content after dry-run = %q", data)
+	}
+}
+
+func TestDryRunResultDescribesPatch(t *testing.T) {
+	patch := "--- a/one.go\n+++ b/one.go\n@@ -1 +1 @@\n-a\n+b\n"
+	res := dryRunResult("apply_patch", map[string]any{"patch": patch, "strip": 1})
+	files, _ := res.Data["files"].([]string)
+	if len(files) != 1 || files[0] != "one.go" {
+		t.Fatalf("files = %v", res.Data["files"])
+	}
+	if res.Data["message"] != "Dry run: would apply a patch to one.go. No changes were made." {
+		t.Fatalf("message = %q", res.Data["message"])
+	}
+}
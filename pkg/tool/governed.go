@@ -41,6 +41,22 @@ func (r *Registry) RequiredTier(name string) types.PermissionTier {
 	return RequiredTierForTool(t)
 }
 
+// ReadOnlyToolNames lists the registry's tools that need only the read-only
+// permission tier, sorted by name.
+func ReadOnlyToolNames(registry *Registry) []string {
+	if registry == nil {
+		return nil
+	}
+	var names []string
+	for _, candidate := range registry.List() {
+		if candidate != nil && RequiredTierForTool(candidate) == types.TierReadOnly {
+			names = append(names, strings.TrimSpace(candidate.Name()))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GovernedToolNames returns the tool list after skill and arbiter filtering.
 func GovernedToolNames(registry *Registry, evaluator types.RuleEvaluator, role, taskType string, baseAllowed []string, budgetUtil float64) []string {
 	if registry == nil {
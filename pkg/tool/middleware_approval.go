@@ -8,6 +8,19 @@ import (
 	"m31labs.dev/buckley/pkg/tool/builtin"
 )
 
+// missionGatedTool reports whether approvalMiddleware sends name to mission
+// control for review.
+func missionGatedTool(name string) bool {
+	switch strings.TrimSpace(name) {
+	case "write_file", "edit_file", "insert_text", "delete_lines",
+		"search_replace", "patch_file", "rename_symbol", "extract_function",
+		"apply_patch", "browser_clipboard_read", "run_shell":
+		return true
+	default:
+		return false
+	}
+}
+
 func (r *Registry) approvalMiddleware() Middleware {
 	return func(next Executor) Executor {
 		return func(ctx *ExecutionContext) (*builtin.Result, error) {
@@ -0,0 +1,132 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/telemetry"
+	"m31labs.dev/buckley/pkg/tool/builtin"
+)
+
+// toolPolicy is a static allow/deny list set by configuration. It applies on
+// top of skill filtering and cannot be widened by it.
+type toolPolicy struct {
+	allow map[string]struct{}
+	deny  map[string]struct{}
+}
+
+func newToolPolicy(allow, deny []string) *toolPolicy {
+	policy := &toolPolicy{allow: toolNameSet(allow), deny: toolNameSet(deny)}
+	if policy.allow == nil && policy.deny == nil {
+		return nil
+	}
+	return policy
+}
+
+func toolNameSet(names []string) map[string]struct{} {
+	var set map[string]struct{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if set == nil {
+			set = make(map[string]struct{}, len(names))
+		}
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+// permits reports whether name may run. Deny wins over allow; an empty
+// allow list permits every tool that is not denied.
+func (p *toolPolicy) permits(name string) bool {
+	if p == nil {
+		return true
+	}
+	name = strings.TrimSpace(name)
+	if _, denied := p.deny[name]; denied {
+		return false
+	}
+	if p.allow == nil {
+		return true
+	}
+	_, allowed := p.allow[name]
+	return allowed
+}
+
+// ApplyToolPolicyConfig installs tool_middleware.allow_list and deny_list,
+// and tools.dangerous, on the registry.
+func ApplyToolPolicyConfig(registry *Registry, cfg *config.Config) {
+	if registry == nil || cfg == nil {
+		return
+	}
+	registry.SetToolPolicy(cfg.ToolMiddleware.AllowList, cfg.ToolMiddleware.DenyList)
+	registry.SetDangerousTools(cfg.Tools.Dangerous)
+}
+
+// SetToolPolicy restricts which tools the registry will advertise and run.
+// A non-empty allow list limits tools to those names; names in deny are
+// always refused, even when also allowed. Empty lists clear the policy.
+func (r *Registry) SetToolPolicy(allow, deny []string) {
+	if r == nil {
+		return
+	}
+	policy := newToolPolicy(allow, deny)
+	r.mu.Lock()
+	r.policy = policy
+	r.mu.Unlock()
+}
+
+// PolicyAllows reports whether the configured tool policy permits name.
+func (r *Registry) PolicyAllows(name string) bool {
+	if r == nil {
+		return true
+	}
+	r.mu.RLock()
+	policy := r.policy
+	r.mu.RUnlock()
+	return policy.permits(name)
+}
+
+// policyMiddleware refuses calls the tool policy does not permit and
+// publishes a tool.blocked event for each one.
+func (r *Registry) policyMiddleware() Middleware {
+	return func(next Executor) Executor {
+		return func(ctx *ExecutionContext) (*builtin.Result, error) {
+			if ctx == nil || r.PolicyAllows(ctx.ToolName) {
+				return next(ctx)
+			}
+			name := strings.TrimSpace(ctx.ToolName)
+			res := &builtin.Result{
+				Success: false,
+				Error:   fmt.Sprintf("tool %s is blocked by tool policy", name),
+			}
+			r.publishToolBlock(ctx, name, "policy", res.Error)
+			return res, nil
+		}
+	}
+}
+
+func (r *Registry) publishToolBlock(ctx *ExecutionContext, name, source, msg string) {
+	if r.telemetryHub == nil {
+		return
+	}
+	callID := strings.TrimSpace(ctx.CallID)
+	if callID == "" {
+		callID = toolCallIDFromParams(ctx.Params)
+	}
+	r.telemetryHub.Publish(telemetry.Event{
+		Type:      telemetry.EventToolBlocked,
+		SessionID: r.telemetrySession,
+		TaskID:    callID,
+		Timestamp: time.Now(),
+		Data: map[string]any{
+			"toolName": name,
+			"source":   source,
+			"error":    msg,
+		},
+	})
+}
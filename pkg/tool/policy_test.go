@@ -0,0 +1,60 @@
+package tool
+
+import (
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/telemetry"
+)
+
+func TestToolPolicyDenyWinsOverAllow(t *testing.T) {
+	policy := newToolPolicy([]string{"read_file", "run_shell"}, []string{" run_shell "})
+	if !policy.permits("read_file") {
+		t.Fatal("allowed tool refused")
+	}
+	if policy.permits("run_shell") {
+		t.Fatal("tool in both lists should be denied")
+	}
+	if policy.permits("write_file") {
+		t.Fatal("tool outside a non-empty allow list should be refused")
+	}
+	if newToolPolicy(nil, []string{""}) != nil {
+		t.Fatal("blank lists should clear the policy")
+	}
+}
+
+func TestRegistryPolicyHidesAndBlocksDeniedTools(t *testing.T) {
+	registry := NewRegistry()
+	hub := telemetry.NewHub()
+	defer hub.Close()
+	events, unsub := hub.Subscribe()
+	defer unsub()
+	registry.EnableTelemetry(hub, "sess-1")
+	registry.SetToolPolicy(nil, []string{"run_shell"})
+
+	for _, name := range functionNames(registry.ToOpenAIFunctions()) {
+		if name == "run_shell" {
+			t.Fatal("denied tool advertised by ToOpenAIFunctions")
+		}
+	}
+	if got := functionNames(registry.ToOpenAIFunctionsFiltered([]string{"run_shell", "read_file"})); len(got) != 1 || got[0] != "read_file" {
+		t.Fatalf("filtered functions = %v, want [read_file]", got)
+	}
+
+	res, err := registry.Execute("run_shell", map[string]any{"command": "echo hi"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if res == nil || res.Success || res.Error != "tool run_shell is blocked by tool policy" {
+		t.Fatalf("blocked result = %+v", res)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != telemetry.EventToolBlocked || ev.Data["source"] != "policy" || ev.Data["toolName"] != "run_shell" {
+			t.Fatalf("event = %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no tool.blocked event published")
+	}
+}
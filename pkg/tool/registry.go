@@ -38,6 +38,14 @@ type Registry struct {
 	discoveryEnabled bool
 	discoveryCore    map[string]struct{}
 	discoveryExposed map[string]struct{}
+
+	workDir string
+	undo    *undoLog
+	dryRun  bool
+	policy  *toolPolicy
+
+	dangerous map[string]struct{}
+	approver  ApprovalFunc
 }
 
 type registryOptions struct {
@@ -96,6 +104,9 @@ func (r *Registry) SetWorkDir(workDir string) {
 		workDir = abs
 	}
 	workDir = filepath.Clean(workDir)
+	r.mu.Lock()
+	r.workDir = workDir
+	r.mu.Unlock()
 	tools := r.snapshotTools()
 	for _, t := range tools {
 		if setter, ok := t.(interface{ SetWorkDir(string) }); ok {
@@ -0,0 +1,79 @@
+package tool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"m31labs.dev/buckley/pkg/tool/builtin"
+	"m31labs.dev/buckley/pkg/types"
+)
+
+// BatchCall is one tool invocation in an ExecuteBatch run.
+type BatchCall struct {
+	Name   string
+	Params map[string]any
+}
+
+// BatchResult is the outcome of the BatchCall at the same index.
+type BatchResult struct {
+	Result   *builtin.Result
+	Err      error
+	Started  time.Time
+	Duration time.Duration
+}
+
+// CanRunConcurrently reports whether a registered tool only reads workspace
+// state, so several calls to it and other such tools can run at once without
+// one observing another's effects.
+func (r *Registry) CanRunConcurrently(name string) bool {
+	if r == nil {
+		return false
+	}
+	t, ok := r.Get(name)
+	if !ok || RequiredTierForTool(t) != types.TierReadOnly {
+		return false
+	}
+	switch GetMetadata(t).Category {
+	case CategoryFilesystem, CategoryCodebase, CategoryAnalysis, CategoryDocumentation:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExecuteBatch runs calls with at most limit in flight and returns their
+// results in call order. A limit of 1 or less runs them one at a time. Calls
+// not yet started when ctx is cancelled report ctx.Err().
+func (r *Registry) ExecuteBatch(ctx context.Context, calls []BatchCall, limit int) []BatchResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	results := make([]BatchResult, len(calls))
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(calls); j++ {
+				results[j].Err = ctx.Err()
+			}
+			wg.Wait()
+			return results
+		}
+		wg.Add(1)
+		go func(i int, call BatchCall) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			started := time.Now()
+			result, err := r.ExecuteWithContext(ctx, call.Name, call.Params)
+			results[i] = BatchResult{Result: result, Err: err, Started: started, Duration: time.Since(started)}
+		}(i, call)
+	}
+	wg.Wait()
+	return results
+}
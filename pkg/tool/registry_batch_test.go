@@ -0,0 +1,109 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/tool/builtin"
+)
+
+type batchTestTool struct {
+	governedTestTool
+	delay    time.Duration
+	inFlight *atomic.Int32
+	peak     *atomic.Int32
+}
+
+func (t *batchTestTool) Execute(params map[string]any) (*builtin.Result, error) {
+	n := t.inFlight.Add(1)
+	defer t.inFlight.Add(-1)
+	for {
+		peak := t.peak.Load()
+		if n <= peak || t.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	// Later calls finish first so ordering cannot come from completion order.
+	id, _ := params["id"].(int)
+	time.Sleep(t.delay / time.Duration(id+1))
+	return &builtin.Result{Success: true, Data: map[string]any{"id": id}}, nil
+}
+
+func newBatchTestRegistry(delay time.Duration) (*Registry, *atomic.Int32) {
+	var inFlight, peak atomic.Int32
+	registry := NewEmptyRegistry()
+	registry.Register(&batchTestTool{
+		governedTestTool: governedTestTool{name: "read_file", metadata: ToolMetadata{Category: CategoryFilesystem, Impact: ImpactReadOnly}},
+		delay:            delay,
+		inFlight:         &inFlight,
+		peak:             &peak,
+	})
+	return registry, &peak
+}
+
+func TestExecuteBatchCapsConcurrencyAndKeepsOrder(t *testing.T) {
+	for _, limit := range []int{1, 2, 3, 8} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			registry, peak := newBatchTestRegistry(40 * time.Millisecond)
+			calls := make([]BatchCall, 6)
+			for i := range calls {
+				calls[i] = BatchCall{Name: "read_file", Params: map[string]any{"id": i}}
+			}
+
+			results := registry.ExecuteBatch(context.Background(), calls, limit)
+
+			if len(results) != len(calls) {
+				t.Fatalf("got %d results, want %d", len(results), len(calls))
+			}
+			for i, res := range results {
+				if res.Err != nil || res.Result == nil {
+					t.Fatalf("result %d: %+v", i, res)
+				}
+				if got := res.Result.Data["id"]; got != i {
+					t.Fatalf("result %d carries id %v; results out of call order", i, got)
+				}
+			}
+			wantPeak := int32(min(limit, len(calls)))
+			if got := peak.Load(); got > wantPeak || (limit > 1 && got < 2) {
+				t.Fatalf("peak concurrency = %d, want between 2 and %d", got, wantPeak)
+			}
+		})
+	}
+}
+
+func TestExecuteBatchCancelledBeforeStart(t *testing.T) {
+	registry, _ := newBatchTestRegistry(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := registry.ExecuteBatch(ctx, []BatchCall{{Name: "read_file", Params: map[string]any{"id": 0}}}, 2)
+	if !errors.Is(results[0].Err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %+v", results[0])
+	}
+}
+
+func TestCanRunConcurrently(t *testing.T) {
+	registry := NewEmptyRegistry()
+	registry.Register(&governedTestTool{name: "read_file", metadata: ToolMetadata{Category: CategoryFilesystem, Impact: ImpactReadOnly}})
+	registry.Register(&governedTestTool{name: "search_text", metadata: ToolMetadata{Category: CategoryCodebase, Impact: ImpactReadOnly}})
+	registry.Register(&governedTestTool{name: "write_file", metadata: ToolMetadata{Category: CategoryFilesystem, Impact: ImpactModifying}})
+	registry.Register(&governedTestTool{name: "run_shell", metadata: ToolMetadata{Category: CategoryShell, Impact: ImpactReadOnly}})
+	registry.Register(&governedTestTool{name: "todo", metadata: ToolMetadata{Category: CategoryPlanning, Impact: ImpactReadOnly}})
+
+	for name, want := range map[string]bool{
+		"read_file":   true,
+		"search_text": true,
+		"write_file":  false,
+		"run_shell":   false,
+		"todo":        false,
+		"missing":     false,
+	} {
+		if got := registry.CanRunConcurrently(name); got != want {
+			t.Errorf("CanRunConcurrently(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
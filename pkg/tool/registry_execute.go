@@ -73,8 +73,8 @@ func (r *Registry) rebuildExecutor() {
 
 func (r *Registry) rebuildExecutorLocked() {
 	base := r.baseExecutor()
-	middlewares := make([]Middleware, 0, len(r.middlewares)+4)
-	middlewares = append(middlewares, PanicRecovery(), r.telemetryMiddleware(), Hooks(r.hooks), r.approvalMiddleware())
+	middlewares := make([]Middleware, 0, len(r.middlewares)+7)
+	middlewares = append(middlewares, PanicRecovery(), r.policyMiddleware(), r.telemetryMiddleware(), r.dryRunMiddleware(), Hooks(r.hooks), r.dangerousToolMiddleware(), r.approvalMiddleware())
 	middlewares = append(middlewares, r.middlewares...)
 	r.executor = Chain(middlewares...)(base)
 }
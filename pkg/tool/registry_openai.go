@@ -5,7 +5,9 @@ func (r *Registry) ToOpenAIFunctions() []map[string]any {
 	tools := r.snapshotTools()
 	functions := make([]map[string]any, 0, len(tools))
 	for _, t := range tools {
-		functions = append(functions, ToOpenAIFunction(t))
+		if r.PolicyAllows(t.Name()) {
+			functions = append(functions, ToOpenAIFunction(t))
+		}
 	}
 	return functions
 }
@@ -19,7 +21,7 @@ func (r *Registry) ToOpenAIFunctionsFiltered(allowed []string) []map[string]any
 	tools := r.snapshotTools()
 	functions := make([]map[string]any, 0, len(allowed))
 	for _, t := range tools {
-		if IsToolAllowed(t.Name(), allowed) {
+		if IsToolAllowed(t.Name(), allowed) && r.PolicyAllows(t.Name()) {
 			functions = append(functions, ToOpenAIFunction(t))
 		}
 	}
@@ -0,0 +1,315 @@
+package tool
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"m31labs.dev/buckley/pkg/tool/builtin"
+)
+
+const (
+	// defaultUndoLimit is how many edits EnableUndo keeps when no limit is given.
+	defaultUndoLimit = 20
+	// maxUndoFileBytes skips snapshots of files too large to hold in memory.
+	maxUndoFileBytes = 2 * 1024 * 1024
+)
+
+// ErrNothingToUndo is returned by UndoLastEdit when no reversible edit is recorded.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// ErrUndoConflict is returned by UndoLastEdit when a file was changed again
+// after the recorded edit, so restoring it would discard that later change.
+var ErrUndoConflict = errors.New("file changed since the edit")
+
+// undoPathParams maps single-file editing tools to the parameter naming the
+// file they change. apply_patch is handled separately; rename_symbol is only
+// snapshotted when its path names a single file.
+var undoPathParams = map[string]string{
+	"write_file":             "path",
+	"edit_file":              "path",
+	"insert_text":            "path",
+	"delete_lines":           "path",
+	"search_replace":         "path",
+	"edit_file_terminal":     "path",
+	"mark_conflict_resolved": "path",
+	"rename_symbol":          "path",
+	"extract_function":       "file",
+}
+
+// FileSnapshot is a file's state from just before a tool changed it.
+type FileSnapshot struct {
+	Path    string
+	Existed bool
+	Content []byte
+	Mode    os.FileMode
+
+	// after is the file's state once the tool finished, used to detect
+	// changes made outside the undo log before the edit is reverted.
+	after fileState
+}
+
+// fileState identifies a file's content without holding it.
+type fileState struct {
+	exists bool
+	sum    [sha256.Size]byte
+}
+
+func currentFileState(path string) (fileState, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fileState{}, nil
+	}
+	if err != nil {
+		return fileState{}, err
+	}
+	return fileState{exists: true, sum: sha256.Sum256(content)}, nil
+}
+
+// FileEdit is one successful, reversible call to a file-modifying tool.
+type FileEdit struct {
+	ToolName string
+	CallID   string
+	At       time.Time
+	Files    []FileSnapshot
+}
+
+// undoLog keeps the most recent file edits, oldest first.
+type undoLog struct {
+	mu    sync.Mutex
+	limit int
+	edits []FileEdit
+}
+
+func (l *undoLog) push(edit FileEdit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.edits = append(l.edits, edit)
+	if over := len(l.edits) - l.limit; over > 0 {
+		l.edits = append([]FileEdit(nil), l.edits[over:]...)
+	}
+}
+
+func (l *undoLog) pop() (FileEdit, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.edits) == 0 {
+		return FileEdit{}, false
+	}
+	edit := l.edits[len(l.edits)-1]
+	l.edits = l.edits[:len(l.edits)-1]
+	return edit, true
+}
+
+// EnableUndo records the prior contents of files changed by the built-in
+// editing tools (see undoPathParams) and apply_patch so UndoLastEdit can
+// restore them. At most limit edits are kept; a non-positive limit uses the
+// default. Calling it again only changes the limit.
+func (r *Registry) EnableUndo(limit int) {
+	if r == nil {
+		return
+	}
+	if limit <= 0 {
+		limit = defaultUndoLimit
+	}
+	r.mu.Lock()
+	if r.undo != nil {
+		r.undo.mu.Lock()
+		r.undo.limit = limit
+		r.undo.mu.Unlock()
+		r.mu.Unlock()
+		return
+	}
+	r.undo = &undoLog{limit: limit}
+	r.mu.Unlock()
+	r.Use(r.snapshotFileEdits(r.undo))
+}
+
+// UndoLastEdit restores the files from the most recent recorded edit and
+// returns it. Files the edit created are removed. It returns
+// ErrNothingToUndo when undo is disabled or no edit is recorded. When any of
+// the edit's files changed afterwards by other means (a shell command, a
+// multi-file refactor), nothing is restored, the edit is dropped and
+// ErrUndoConflict is returned.
+func (r *Registry) UndoLastEdit() (*FileEdit, error) {
+	if r == nil {
+		return nil, ErrNothingToUndo
+	}
+	r.mu.RLock()
+	log := r.undo
+	r.mu.RUnlock()
+	if log == nil {
+		return nil, ErrNothingToUndo
+	}
+	edit, ok := log.pop()
+	if !ok {
+		return nil, ErrNothingToUndo
+	}
+	for _, snap := range edit.Files {
+		state, err := currentFileState(snap.Path)
+		if err != nil {
+			return &edit, fmt.Errorf("undo %s: %w", edit.ToolName, err)
+		}
+		if state != snap.after {
+			return &edit, fmt.Errorf("undo %s: %s: %w", edit.ToolName, snap.Path, ErrUndoConflict)
+		}
+	}
+	for i := len(edit.Files) - 1; i >= 0; i-- {
+		if err := restoreSnapshot(edit.Files[i]); err != nil {
+			return &edit, fmt.Errorf("undo %s: %w", edit.ToolName, err)
+		}
+	}
+	return &edit, nil
+}
+
+func (r *Registry) snapshotFileEdits(log *undoLog) Middleware {
+	return func(next Executor) Executor {
+		return func(ctx *ExecutionContext) (*builtin.Result, error) {
+			if ctx == nil {
+				return next(ctx)
+			}
+			files, ok := r.snapshotEditTargets(ctx.ToolName, ctx.Params)
+			res, err := next(ctx)
+			if !ok || err != nil || res == nil || !res.Success || res.NeedsApproval {
+				return res, err
+			}
+			if recordAfterStates(files) {
+				log.push(FileEdit{ToolName: ctx.ToolName, CallID: ctx.CallID, At: time.Now(), Files: files})
+			}
+			return res, err
+		}
+	}
+}
+
+// snapshotEditTargets captures the files toolName is about to change. It
+// returns false when the call is not a reversible file edit.
+func (r *Registry) snapshotEditTargets(toolName string, params map[string]any) ([]FileSnapshot, bool) {
+	var paths []string
+	name := strings.TrimSpace(toolName)
+	if name == "apply_patch" {
+		patch, _ := params["patch"].(string)
+		paths = patchTargets(patch, patchStrip(params["strip"]))
+	} else if key, ok := undoPathParams[name]; ok {
+		if path := stringFromParams(params, key); path != "" {
+			paths = []string{path}
+		}
+	}
+	if len(paths) == 0 {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	workDir := r.workDir
+	r.mu.RUnlock()
+
+	files := make([]FileSnapshot, 0, len(paths))
+	for _, raw := range paths {
+		path := raw
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workDir, path)
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		snap := FileSnapshot{Path: path}
+		info, err := os.Stat(path)
+		switch {
+		case err == nil:
+			if info.IsDir() || info.Size() > maxUndoFileBytes {
+				return nil, false
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, false
+			}
+			snap.Existed, snap.Content, snap.Mode = true, content, info.Mode().Perm()
+		case !os.IsNotExist(err):
+			return nil, false
+		}
+		files = append(files, snap)
+	}
+	return files, true
+}
+
+// recordAfterStates stores each file's post-edit state and reports whether
+// the call changed any of them; calls that changed nothing are not recorded.
+func recordAfterStates(files []FileSnapshot) bool {
+	changed := false
+	for i := range files {
+		state, err := currentFileState(files[i].Path)
+		if err != nil {
+			return false
+		}
+		files[i].after = state
+		before := fileState{exists: files[i].Existed}
+		if before.exists {
+			before.sum = sha256.Sum256(files[i].Content)
+		}
+		if state != before {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func restoreSnapshot(snap FileSnapshot) error {
+	if !snap.Existed {
+		if err := os.Remove(snap.Path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	mode := snap.Mode
+	if mode == 0 {
+		mode = 0o644
+	}
+	return os.WriteFile(snap.Path, snap.Content, mode)
+}
+
+// patchTargets lists the files a unified diff changes, after stripping strip
+// leading path components as patch -pN would.
+func patchTargets(patch string, strip int) []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, line := range strings.Split(patch, "\n") {
+		if !strings.HasPrefix(line, "--- ") && !strings.HasPrefix(line, "+++ ") {
+			continue
+		}
+		path := strings.TrimSpace(line[4:])
+		if tab := strings.IndexByte(path, '\t'); tab >= 0 {
+			path = path[:tab]
+		}
+		if path == "" || path == "/dev/null" {
+			continue
+		}
+		parts := strings.Split(filepath.ToSlash(path), "/")
+		if strip >= len(parts) {
+			continue
+		}
+		path = filepath.FromSlash(strings.Join(parts[strip:], "/"))
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func patchStrip(value any) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package tool
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUndoLastEditRestoresWrites(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(existing, []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	registry := NewRegistry()
+	registry.SetWorkDir(dir)
+	registry.EnableUndo(0)
+
+	if res, err := registry.Execute("write_file", map[string]any{"path": "main.go", "content": "changed\n"}); err != nil || !res.Success {
+		t.Fatalf("write existing: %+v, %v", res, err)
+	}
+	if res, err := registry.Execute("write_file", map[string]any{"path": "new.txt", "content": "hello\n"}); err != nil || !res.Success {
+		t.Fatalf("write new: %+v, %v", res, err)
+	}
+
+	edit, err := registry.UndoLastEdit()
+	if err != nil || edit.Files[0].Path != filepath.Join(dir, "new.txt") {
+		t.Fatalf("first undo = %+v, %v", edit, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.txt")); !os.IsNotExist(err) {
+		t.Fatalf("created file still present: %v", err)
+	}
+
+	if _, err := registry.UndoLastEdit(); err != nil {
+		t.Fatalf("second undo: %v", err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "package main\n" {
+		t.Fatalf("restored content = %q", data)
+	}
+	if _, err := registry.UndoLastEdit(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("third undo error = %v, want ErrNothingToUndo", err)
+	}
+}
+
+func TestUndoLogIsBounded(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewRegistry()
+	registry.SetWorkDir(dir)
+	registry.EnableUndo(2)
+
+	for _, content := range []string{"1", "2", "3"} {
+		if res, err := registry.Execute("write_file", map[string]any{"path": "f.txt", "content": content}); err != nil || !res.Success {
+			t.Fatalf("write %s: %+v, %v", content, res, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := registry.UndoLastEdit(); err != nil {
+			t.Fatalf("undo %d: %v", i, err)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "f.txt")); string(data) != "1" {
+		t.Fatalf("content after bounded undo = %q, want 1", data)
+	}
+	if _, err := registry.UndoLastEdit(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("undo past limit error = %v", err)
+	}
+}
+
+func TestPatchTargets(t *testing.T) {
+	patch := "--- a/pkg/x.go\t2024-01-01\n+++ b/pkg/x.go\n@@ -1 +1 @@\n-old\n+new\n--- /dev/null\n+++ b/pkg/new.go\n"
+	got := patchTargets(patch, 1)
+	if len(got) != 2 || got[0] != filepath.FromSlash("pkg/x.go") || got[1] != filepath.FromSlash("pkg/new.go") {
+		t.Fatalf("patchTargets = %v", got)
+	}
+}
+
+func TestUndoCoversEditingTools(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	registry := NewRegistry()
+	registry.SetWorkDir(dir)
+	registry.EnableUndo(0)
+
+	if res, err := registry.Execute("search_replace", map[string]any{"path": "notes.txt", "search": "two", "replace": "TWO"}); err != nil || !res.Success {
+		t.Fatalf("search_replace: %+v, %v", res, err)
+	}
+	if res, err := registry.Execute("delete_lines", map[string]any{"path": "notes.txt", "start_line": 1, "end_line": 1}); err != nil || !res.Success {
+		t.Fatalf("delete_lines: %+v, %v", res, err)
+	}
+
+	for _, want := range []string{"delete_lines", "search_replace"} {
+		edit, err := registry.UndoLastEdit()
+		if err != nil || edit.ToolName != want {
+			t.Fatalf("undo = %+v, %v; want %s", edit, err, want)
+		}
+	}
+	if data, _ := os.ReadFile(path); string(data) != "one\ntwo\nthree\n" {
+		t.Fatalf("restored content = %q", data)
+	}
+}
+
+func TestUndoRefusesFileChangedSinceEdit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "f.txt")
+	registry := NewRegistry()
+	registry.SetWorkDir(dir)
+	registry.EnableUndo(0)
+
+	if res, err := registry.Execute("write_file", map[string]any{"path": "f.txt", "content": "tool\n"}); err != nil || !res.Success {
+		t.Fatalf("write_file: %+v, %v", res, err)
+	}
+	// A change the undo log did not see, e.g. from a shell command.
+	if err := os.WriteFile(path, []byte("shell\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := registry.UndoLastEdit(); !errors.Is(err, ErrUndoConflict) {
+		t.Fatalf("undo error = %v, want ErrUndoConflict", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "shell\n" {
+		t.Fatalf("content after refused undo = %q, want the later change kept", data)
+	}
+	if _, err := registry.UndoLastEdit(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("second undo error = %v, want the conflicting edit dropped", err)
+	}
+}
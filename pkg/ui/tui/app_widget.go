@@ -8,6 +8,7 @@ import (
 	stdruntime "runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"m31labs.dev/buckley/pkg/ui/filepicker"
@@ -78,6 +79,11 @@ type WidgetApp struct {
 	lastRender  time.Time
 	dirty       bool
 
+	// Suspend (Ctrl+Z / SIGTSTP) handling
+	suspended       atomic.Bool
+	suspendSignals  chan os.Signal
+	forceFullRedraw bool
+
 	// Render metrics
 	metrics RenderMetrics
 
@@ -118,6 +124,7 @@ type WidgetApp struct {
 	onPrevSession func()
 	onApproval    func(requestID string, approved, alwaysAllow bool)
 	onInterrupt   func()
+	onScrollTop   func()
 
 	// Configuration
 	theme       *theme.Theme
@@ -310,6 +317,9 @@ func NewWidgetApp(cfg WidgetAppConfig) (*WidgetApp, error) {
 		if app.applyScrollStatus(top, total, viewHeight) {
 			app.dirty = true
 		}
+		if top <= 0 && total > viewHeight && app.onScrollTop != nil {
+			app.Post(ScrollTopMsg{})
+		}
 	})
 
 	// Set up input callbacks
@@ -445,11 +455,14 @@ func (a *WidgetApp) showCommandPalette() {
 	items := []widgets.PaletteItem{
 		// Session commands
 		{ID: "new", Category: "Session", Label: "New Conversation", Shortcut: "/new"},
+		{ID: "fork", Category: "Session", Label: "Fork Conversation", Shortcut: "/fork"},
 		{ID: "clear", Category: "Session", Label: "Clear Messages", Shortcut: "/clear"},
 		{ID: "history", Category: "Session", Label: "View History", Shortcut: "/history"},
 		{ID: "export", Category: "Session", Label: "Export Conversation", Shortcut: "/export"},
 		{ID: "compact", Category: "Session", Label: "Compact Context", Shortcut: "/compact"},
 		{ID: "cancel", Category: "Session", Label: "Cancel Response", Shortcut: "/cancel"},
+		{ID: "continue", Category: "Session", Label: "Continue Truncated Response", Shortcut: "/continue"},
+		{ID: "retry", Category: "Session", Label: "Retry Last Prompt", Shortcut: "/retry"},
 		{ID: "steer", Category: "Session", Label: "Steer Active Response", Shortcut: "/steer"},
 		{ID: "queue", Category: "Session", Label: "Queue Follow-up", Shortcut: "/queue"},
 
@@ -499,15 +512,28 @@ func (a *WidgetApp) showSlashCommandPalette() {
 
 	// Slash command items
 	items := []widgets.PaletteItem{
-		{ID: "/review", Label: "/review", Description: "Review current git diff"},
+		{ID: "/review", Label: "/review", Description: "Review current git diff (--staged, --unstaged, or <ref>)"},
 		{ID: "/commit", Label: "/commit", Description: "Generate commit message"},
+		{ID: "/commit apply", Label: "/commit apply", Description: "Commit staged changes with the generated message"},
 		{ID: "/new", Label: "/new", Description: "Start a new session"},
+		{ID: "/fork", Label: "/fork", Description: "Branch a new session off this one"},
 		{ID: "/clear", Label: "/clear", Description: "Clear current session"},
 		{ID: "/tokens", Label: "/tokens", Description: "Show context and token budget"},
 		{ID: "/compact", Label: "/compact", Description: "Summarize older context"},
 		{ID: "/history", Label: "/history", Description: "Show recent turns"},
+		{ID: "/trace", Label: "/trace", Description: "Explain tool calls in the last turn"},
+		{ID: "/attachments", Label: "/attachments", Description: "List, view, or re-send attached files"},
 		{ID: "/export", Label: "/export", Description: "Export conversation to Markdown"},
+		{ID: "/import ", Label: "/import", Description: "Import a JSON conversation export"},
+		{ID: "/render ", Label: "/render", Description: "Show rendered or raw markdown"},
 		{ID: "/cancel", Label: "/cancel", Description: "Cancel current response"},
+		{ID: "/tasks", Label: "/tasks", Description: "List or cancel background tasks"},
+		{ID: "/continue", Label: "/continue", Description: "Resume a truncated response"},
+		{ID: "/retry", Label: "/retry", Description: "Resend the last prompt"},
+		{ID: "/undo", Label: "/undo", Description: "Revert the last file change made by a tool"},
+		{ID: "/dryrun", Label: "/dryrun", Description: "Describe tool changes without making them"},
+		{ID: "/pin", Label: "/pin", Description: "Keep your latest message when trimming context"},
+		{ID: "/unpin ", Label: "/unpin", Description: "Remove a pinned message"},
 		{ID: "/steer ", Label: "/steer", Description: "Interrupt and redirect the active response"},
 		{ID: "/queue ", Label: "/queue", Description: "Queue a follow-up without interrupting"},
 		{ID: "/sessions", Label: "/sessions", Description: "List saved sessions"},
@@ -516,6 +542,8 @@ func (a *WidgetApp) showSlashCommandPalette() {
 		{ID: "/prev", Label: "/prev", Description: "Switch to previous session"},
 		{ID: "/model", Label: "/model", Description: "Select execution model"},
 		{ID: "/model curate", Label: "/model curate", Description: "Curate models for ACP/editor pickers"},
+		{ID: "/model compare ", Label: "/model compare", Description: "Compare two models on the current context"},
+		{ID: "/model group ", Label: "/model group", Description: "Group the model picker by provider or capability"},
 		{ID: "/plans", Label: "/plans", Description: "List saved plans"},
 		{ID: "/config", Label: "/config", Description: "Show config summary"},
 		{ID: "/help", Label: "/help", Description: "Show available commands"},
@@ -631,6 +659,11 @@ func (a *WidgetApp) Run() error {
 	a.frameTicker = time.NewTicker(16 * time.Millisecond)
 	defer a.frameTicker.Stop()
 
+	// Suspend cleanly when SIGTSTP arrives from outside the raw-mode terminal
+	a.suspendSignals = make(chan os.Signal, 1)
+	notifySuspendSignals(a.suspendSignals)
+	defer stopSuspendSignals(a.suspendSignals)
+
 	// Start terminal event poller in background
 	go a.pollEvents()
 
@@ -642,6 +675,11 @@ func (a *WidgetApp) Run() error {
 				a.dirty = true
 			}
 
+		case <-a.suspendSignals:
+			if a.suspend() {
+				a.dirty = true
+			}
+
 		case now := <-a.frameTicker.C:
 			a.coalescer.Tick()
 			if a.updateAnimations(now) {
@@ -662,6 +700,9 @@ func (a *WidgetApp) pollEvents() {
 	for a.running {
 		ev := a.backend.PollEvent()
 		if ev == nil {
+			if a.suspended.Load() {
+				time.Sleep(suspendPollInterval)
+			}
 			continue
 		}
 
@@ -950,6 +991,10 @@ func (a *WidgetApp) handlePaletteCommand(id string) {
 		if a.onSubmit != nil {
 			a.onSubmit("/new")
 		}
+	case "fork":
+		if a.onSubmit != nil {
+			a.onSubmit("/fork")
+		}
 	case "clear":
 		if a.onSubmit != nil {
 			a.onSubmit("/clear")
@@ -971,6 +1016,14 @@ func (a *WidgetApp) handlePaletteCommand(id string) {
 		if a.onSubmit != nil {
 			a.onSubmit("/cancel")
 		}
+	case "continue":
+		if a.onSubmit != nil {
+			a.onSubmit("/continue")
+		}
+	case "retry":
+		if a.onSubmit != nil {
+			a.onSubmit("/retry")
+		}
 	case "steer":
 		a.prefillInput("/steer ")
 	case "queue":
@@ -1053,14 +1106,14 @@ func (a *WidgetApp) render() {
 	var cellsUpdated int64
 
 	// Use partial redraw if only some cells changed
-	if buf.IsDirty() {
+	if buf.IsDirty() || a.forceFullRedraw {
 		dirtyCount := buf.DirtyCount()
 		w, h := buf.Size()
 		totalCells := w * h
 
 		// If more than half the cells are dirty, do a full redraw
 		// (more efficient than many individual SetContent calls)
-		if dirtyCount > totalCells/2 {
+		if a.forceFullRedraw || dirtyCount > totalCells/2 {
 			for y := 0; y < h; y++ {
 				for x := 0; x < w; x++ {
 					cell := buf.Get(x, y)
@@ -1078,6 +1131,7 @@ func (a *WidgetApp) render() {
 			a.metrics.PartialRedraws++
 		}
 		buf.ClearDirty()
+		a.forceFullRedraw = false
 	}
 
 	// Show the screen
@@ -1195,6 +1249,12 @@ func (a *WidgetApp) SetInterruptCallback(onInterrupt func()) {
 	a.onInterrupt = onInterrupt
 }
 
+// SetScrollTopCallback sets the callback run when the chat view is scrolled
+// to its first line, used to load older history on demand.
+func (a *WidgetApp) SetScrollTopCallback(onScrollTop func()) {
+	a.onScrollTop = onScrollTop
+}
+
 // SetApprovalCallback sets the callback for tool approval decisions.
 func (a *WidgetApp) SetApprovalCallback(onApproval func(requestID string, approved, alwaysAllow bool)) {
 	a.onApproval = onApproval
@@ -1296,6 +1356,13 @@ func (a *WidgetApp) SetRLMStatus(status *widgets.RLMStatus, scratchpad []widgets
 	a.dirty = true
 }
 
+// SetRawMarkdown switches the transcript between rendered and raw markdown.
+// Existing messages keep their current display until they are re-added.
+func (a *WidgetApp) SetRawMarkdown(raw bool) {
+	a.chatView.SetRawMarkdown(raw)
+	a.dirty = true
+}
+
 // ClearScrollback clears all messages.
 func (a *WidgetApp) ClearScrollback() {
 	a.chatView.Clear()
@@ -1303,6 +1370,28 @@ func (a *WidgetApp) ClearScrollback() {
 	a.updateScrollStatus()
 }
 
+// rebuildScrollback clears the chat view and refills it with render. When
+// render only adds content above what was visible, the same lines stay on
+// screen; a view that was following the latest output keeps following it.
+func (a *WidgetApp) rebuildScrollback(render func()) {
+	top, total, _ := a.chatView.ScrollPosition()
+	following := a.isFollowing()
+	fromEnd := total - top
+
+	a.chatView.Clear()
+	a.unreadCount = 0
+	render()
+
+	if following {
+		a.chatView.ScrollToBottom()
+	} else {
+		_, total, _ = a.chatView.ScrollPosition()
+		a.chatView.ScrollToTop()
+		a.chatView.ScrollDown(max(0, total-fromEnd))
+	}
+	a.updateScrollStatus()
+}
+
 // HasInput returns true if there's text in the input.
 func (a *WidgetApp) HasInput() bool {
 	return a.inputArea.HasText()
@@ -88,6 +88,8 @@ func (a *WidgetApp) handleControlShortcut(m KeyMsg, key terminal.Key) bool {
 		a.showCommandPalette()
 	case key == terminal.KeyCtrlF || (m.Ctrl && m.Rune == 'f'):
 		a.showSearchOverlay()
+	case key == terminal.KeyCtrlZ || (m.Ctrl && m.Rune == 'z'):
+		a.suspend()
 	default:
 		return false
 	}
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// suspendPollInterval throttles the event poller while the terminal is
+// released, since some backends return nil events immediately until re-init.
+const suspendPollInterval = 50 * time.Millisecond
+
+// suspendProcess stops the process until it is continued. Tests replace it so
+// suspend can be exercised without signalling the test binary.
+var suspendProcess = stopProcessUntilContinued
+
+// suspendableBackend is implemented by backends that can hand the terminal
+// back without tearing down their screen state.
+type suspendableBackend interface {
+	Suspend() error
+	Resume() error
+}
+
+// suspendState is the UI state that must survive handing the terminal to the
+// shell and taking it back, which may reflow or reset widgets.
+type suspendState struct {
+	input     string
+	following bool
+	scrollTop int
+}
+
+// suspend releases the terminal, stops the process (Ctrl+Z / SIGTSTP) and
+// restores the UI after SIGCONT. It runs on the event loop, so nothing renders
+// while the process is stopped.
+func (a *WidgetApp) suspend() bool {
+	if !suspendSupported {
+		a.setStatusOverride("Suspend is not supported on this platform", 3*time.Second)
+		return true
+	}
+
+	state := a.saveSuspendState()
+	a.suspended.Store(true)
+	if err := a.releaseTerminal(); err != nil {
+		a.suspended.Store(false)
+		a.setStatusOverride("Could not suspend: "+err.Error(), 3*time.Second)
+		return true
+	}
+
+	// Our own SIGTSTP handler would swallow the stop, so drop it while the
+	// process suspends itself.
+	if a.suspendSignals != nil {
+		stopSuspendSignals(a.suspendSignals)
+	}
+	stopErr := suspendProcess()
+	if a.suspendSignals != nil {
+		notifySuspendSignals(a.suspendSignals)
+	}
+
+	if err := a.reacquireTerminal(); err != nil {
+		// Without a terminal there is nothing left to draw on.
+		a.Quit()
+		return false
+	}
+	a.suspended.Store(false)
+	a.restoreSuspendState(state)
+	if stopErr != nil {
+		a.setStatusOverride("Could not suspend: "+stopErr.Error(), 3*time.Second)
+	} else {
+		a.setStatusOverride("Resumed", 2*time.Second)
+	}
+	return true
+}
+
+func (a *WidgetApp) saveSuspendState() suspendState {
+	top, _, _ := a.chatView.ScrollPosition()
+	return suspendState{
+		input:     a.inputArea.Text(),
+		following: a.isFollowing(),
+		scrollTop: top,
+	}
+}
+
+func (a *WidgetApp) restoreSuspendState(state suspendState) {
+	// The terminal may have been resized while we were stopped.
+	w, h := a.backend.Size()
+	a.handleResizeMsg(ResizeMsg{Width: w, Height: h})
+
+	if a.inputArea.Text() != state.input {
+		a.inputArea.SetText(state.input)
+	}
+	if state.following {
+		a.chatView.ScrollToBottom()
+	} else {
+		a.chatView.ScrollToTop()
+		a.chatView.ScrollDown(state.scrollTop)
+	}
+	a.updateScrollStatus()
+
+	// The shell drew over our screen; repaint every cell on the next frame.
+	a.forceFullRedraw = true
+	a.backend.Sync()
+}
+
+func (a *WidgetApp) releaseTerminal() error {
+	if be, ok := a.backend.(suspendableBackend); ok {
+		return be.Suspend()
+	}
+	a.backend.Fini()
+	return nil
+}
+
+func (a *WidgetApp) reacquireTerminal() error {
+	if be, ok := a.backend.(suspendableBackend); ok {
+		if err := be.Resume(); err != nil {
+			return fmt.Errorf("resume backend: %w", err)
+		}
+		return nil
+	}
+	if err := a.backend.Init(); err != nil {
+		return fmt.Errorf("init backend: %w", err)
+	}
+	a.backend.HideCursor()
+	return nil
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"m31labs.dev/fluffyui/backend/sim"
+	"m31labs.dev/fluffyui/terminal"
+)
+
+type suspendRecordingBackend struct {
+	*sim.Backend
+	suspends int
+	resumes  int
+}
+
+func (b *suspendRecordingBackend) Suspend() error {
+	b.suspends++
+	return nil
+}
+
+func (b *suspendRecordingBackend) Resume() error {
+	b.resumes++
+	return nil
+}
+
+func stubSuspendProcess(t *testing.T, fn func() error) {
+	t.Helper()
+	prev := suspendProcess
+	suspendProcess = fn
+	t.Cleanup(func() { suspendProcess = prev })
+}
+
+func newSuspendTestApp(t *testing.T) (*WidgetApp, *suspendRecordingBackend) {
+	t.Helper()
+	if !suspendSupported {
+		t.Skip("suspend is not supported on this platform")
+	}
+	be := &suspendRecordingBackend{Backend: sim.New(80, 24)}
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: be})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	t.Cleanup(app.backend.Fini)
+	app.running = true
+	return app, be
+}
+
+func TestWidgetAppSuspendRestoresStateAfterContinue(t *testing.T) {
+	app, be := newSuspendTestApp(t)
+	for i := 0; i < 60; i++ {
+		app.addMessageImmediately("line of history", "assistant")
+	}
+	app.chatView.ScrollToTop()
+	app.chatView.ScrollDown(5)
+	app.inputArea.SetText("half-written prompt")
+	wantTop, _, _ := app.chatView.ScrollPosition()
+
+	stops := 0
+	stubSuspendProcess(t, func() error {
+		stops++
+		if !app.suspended.Load() {
+			t.Error("app should be marked suspended while the process is stopped")
+		}
+		if be.suspends != 1 || be.resumes != 0 {
+			t.Errorf("terminal not released before stopping: suspends=%d resumes=%d", be.suspends, be.resumes)
+		}
+		// Simulate the shell clobbering UI state while we were stopped.
+		app.inputArea.Clear()
+		app.chatView.ScrollToBottom()
+		return nil
+	})
+
+	if !app.suspend() {
+		t.Fatal("suspend should dirty the app for a redraw")
+	}
+	if stops != 1 {
+		t.Fatalf("suspendProcess calls = %d, want 1", stops)
+	}
+	if be.resumes != 1 {
+		t.Fatalf("backend resumes = %d, want 1", be.resumes)
+	}
+	if app.suspended.Load() {
+		t.Fatal("app should not be marked suspended after resume")
+	}
+	if !app.running {
+		t.Fatal("app should keep running after resume")
+	}
+	if got := app.inputArea.Text(); got != "half-written prompt" {
+		t.Fatalf("input = %q, want draft restored", got)
+	}
+	if top, _, _ := app.chatView.ScrollPosition(); top != wantTop {
+		t.Fatalf("scroll top = %d, want %d", top, wantTop)
+	}
+	if !app.forceFullRedraw {
+		t.Fatal("resume should force a full redraw")
+	}
+
+	app.render()
+	if app.forceFullRedraw {
+		t.Fatal("full redraw flag should clear after rendering")
+	}
+	if app.metrics.FullRedraws == 0 {
+		t.Fatal("expected a full redraw after resume")
+	}
+}
+
+func TestWidgetAppSuspendReportsStopFailure(t *testing.T) {
+	app, be := newSuspendTestApp(t)
+	stubSuspendProcess(t, func() error { return errors.New("no job control") })
+
+	app.suspend()
+	if be.suspends != 1 || be.resumes != 1 {
+		t.Fatalf("terminal should be reacquired after a failed stop: suspends=%d resumes=%d", be.suspends, be.resumes)
+	}
+	if got := app.statusBar.Status(); !strings.Contains(got, "Could not suspend") {
+		t.Fatalf("status = %q, want suspend failure", got)
+	}
+}
+
+func TestWidgetAppCtrlZSuspends(t *testing.T) {
+	app, _ := newSuspendTestApp(t)
+	stops := 0
+	stubSuspendProcess(t, func() error {
+		stops++
+		return nil
+	})
+
+	app.handleKeyMsg(KeyMsg{Key: int(terminal.KeyCtrlZ)})
+	if stops != 1 {
+		t.Fatalf("suspendProcess calls = %d, want Ctrl+Z to suspend", stops)
+	}
+}
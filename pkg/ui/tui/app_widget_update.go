@@ -44,6 +44,8 @@ func (a *WidgetApp) update(msg Message) bool {
 		return true
 	case RefreshMsg:
 		return true
+	case ScrollTopMsg:
+		return a.handleScrollTopMsg()
 	case QuitMsg:
 		a.Quit()
 		return false
@@ -60,6 +62,17 @@ func (a *WidgetApp) update(msg Message) bool {
 	}
 }
 
+// handleScrollTopMsg runs the scroll-top callback if the view is still at its
+// first line; the message may be stale after a programmatic scroll.
+func (a *WidgetApp) handleScrollTopMsg() bool {
+	top, total, viewHeight := a.chatView.ScrollPosition()
+	if a.onScrollTop == nil || top > 0 || total <= viewHeight {
+		return false
+	}
+	a.onScrollTop()
+	return true
+}
+
 func (a *WidgetApp) handleResizeMsg(m ResizeMsg) bool {
 	a.applyInputHeightLimit(m.Height)
 	a.inputMeasuredHeight = a.inputArea.Measure(runtime.Constraints{
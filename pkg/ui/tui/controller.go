@@ -11,13 +11,16 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gopkg.in/yaml.v3"
 	"m31labs.dev/buckley/pkg/config"
 	projectcontext "m31labs.dev/buckley/pkg/context"
 	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/cost"
 	"m31labs.dev/buckley/pkg/diffsignal"
+	"m31labs.dev/buckley/pkg/mcp"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/prompts"
 	"m31labs.dev/buckley/pkg/rules"
@@ -53,10 +56,35 @@ type Controller struct {
 	// Event bridge for sidebar updates
 	telemetryBridge *TelemetryUIBridge
 
+	// mcpManager is the MCP connection shared by every session's registry.
+	// It holds nil until the configured servers finish connecting.
+	mcpManager atomic.Pointer[mcp.Manager]
+	mcpCancel  context.CancelFunc
+
 	// State
-	workDir       string
-	agentProfile  string
-	modelOverride string
+	workDir             string
+	agentProfile        string
+	modelOverride       string
+	modelPickerGrouping string // overrides ui.model_picker_grouping for this run
+	notices             []string
+	autoModelNoteOnce   sync.Once
+
+	// curatedSaveTimer holds a pending models.curated_autosave write;
+	// curatedSaveDelay overrides curatedAutoSaveDelay when positive.
+	curatedSaveTimer *time.Timer
+	curatedSaveDelay time.Duration
+	// curatedWriteMu serializes curated config writes so a debounced
+	// auto-save and an explicit save cannot interleave or land out of order.
+	curatedWriteMu sync.Mutex
+
+	// pendingCommit is the /commit apply awaiting confirmation.
+	pendingCommit *pendingCommit
+	// toolApprovals holds tools.dangerous calls waiting on the dialog.
+	toolApprovals *toolApprovals
+
+	// tasks tracks running background operations for /tasks.
+	tasks      map[int]*backgroundTask
+	nextTaskID int
 
 	// Multi-session support - each session runs independently
 	sessions       []*SessionState // Active sessions for this project
@@ -83,8 +111,21 @@ type SessionState struct {
 	Compacting    bool
 	Cancel        context.CancelFunc
 	MessageQueue  []QueuedMessage // Messages queued while streaming
+	StopSequences []string        // Applied to every model request in this session
+	RawMarkdown   bool            // Display markdown source instead of rendered output
+
+	// ToolOutputs keeps truncated tool results whole for read_tool_output.
+	ToolOutputs *builtin.ToolOutputStore
 
 	DisableToolsNextTurn bool
+
+	// CostTracker records spend for cost_management.hard_stop. It is
+	// created on first use and nil while hard stop is disabled.
+	CostTracker *cost.Tracker
+
+	// PinnedOverBudget records that the user was warned their pinned
+	// messages alone exceed the model's context budget.
+	PinnedOverBudget bool
 }
 
 // ControllerConfig configures the controller.
@@ -97,16 +138,19 @@ type ControllerConfig struct {
 	SessionID     string // Resume session, empty for new
 	AgentProfile  string
 	ModelOverride string // CLI --model override, takes precedence over routing rules
+	// Notices are one-time startup messages shown in the transcript.
+	Notices []string
 }
 
 func newSessionState(cfg *config.Config, store *storage.Store, workDir string, hub *telemetry.Hub, sessionID string, loadMessages bool) (*SessionState, error) {
 	sess := &SessionState{
 		ID:           sessionID,
 		Conversation: conversation.New(sessionID),
+		RawMarkdown:  cfg != nil && !cfg.UI.RenderMarkdown,
 	}
 
 	if loadMessages && store != nil {
-		if err := sess.Conversation.LoadFromStorage(store); err != nil {
+		if err := sess.Conversation.LoadRecentFromStorage(store, sessionHistoryWindow); err != nil {
 			return nil, fmt.Errorf("load session %s messages: %w", sessionID, err)
 		}
 	}
@@ -127,6 +171,8 @@ func newSessionState(cfg *config.Config, store *storage.Store, workDir string, h
 		createTool.SetWorkDir(workDir)
 	}
 	registry.Register(createTool)
+	sess.ToolOutputs = builtin.NewToolOutputStore(0)
+	registry.Register(&builtin.ReadToolOutputTool{Store: sess.ToolOutputs})
 
 	sess.ToolRegistry = registry
 	sess.SkillRegistry = skills
@@ -195,6 +241,7 @@ func NewController(cfg ControllerConfig) (*Controller, error) {
 		workDir:        workDir,
 		agentProfile:   strings.TrimSpace(cfg.AgentProfile),
 		modelOverride:  strings.TrimSpace(cfg.ModelOverride),
+		notices:        cfg.Notices,
 		sessions:       projectSessions,
 		currentSession: currentIdx,
 	}
@@ -202,6 +249,7 @@ func NewController(cfg ControllerConfig) (*Controller, error) {
 	// Create telemetry bridge for sidebar updates
 	if cfg.Telemetry != nil {
 		ctrl.telemetryBridge = NewTelemetryUIBridge(cfg.Telemetry, app)
+		ctrl.telemetryBridge.SetSessionBudget(cfg.Config.CostManagement.SessionBudget)
 	}
 
 	// Set up callbacks
@@ -215,6 +263,12 @@ func NewController(cfg ControllerConfig) (*Controller, error) {
 		ctrl.prevSession,
 	)
 	app.SetInterruptCallback(ctrl.cancelCurrentStream)
+	app.SetScrollTopCallback(ctrl.loadOlderHistory)
+	app.SetApprovalCallback(ctrl.handleApproval)
+	ctrl.toolApprovals = newToolApprovals(app.RequestApproval)
+	for _, sess := range projectSessions {
+		ctrl.attachToolApprover(sess)
+	}
 
 	return ctrl, nil
 }
@@ -308,6 +362,7 @@ func (c *Controller) Run() error {
 	if c.telemetryBridge != nil {
 		c.telemetryBridge.Start(context.Background())
 	}
+	c.startMCP()
 
 	// Show welcome
 	c.app.WelcomeScreen()
@@ -316,16 +371,22 @@ func (c *Controller) Run() error {
 	if c.projectCtx != nil && c.projectCtx.Loaded {
 		c.app.addMessageImmediately("Project context loaded from AGENTS.md", "system")
 	}
+	for _, notice := range c.notices {
+		c.app.addMessageImmediately(notice, "system")
+	}
 
 	// Load existing conversation history for current session
 	sess := c.sessions[c.currentSession]
 	if len(sess.Conversation.Messages) > 0 {
 		c.app.addMessageImmediately(fmt.Sprintf("Resuming session: %s (%d messages)", sess.ID, len(sess.Conversation.Messages)), "system")
-		renderConversationHistoryImmediately(c.app, sess.Conversation.Messages)
+		renderSessionTranscriptImmediately(c.app, sess)
 	}
 
-	// Run the app
-	return c.app.Run()
+	// Run the app, then write any curate change still inside the auto-save
+	// debounce window.
+	err := c.app.Run()
+	c.flushCuratedAutoSave()
+	return err
 }
 
 // handleSubmit processes user input submission.
@@ -412,6 +473,9 @@ func (c *Controller) handleCommand(text string) {
 	case "/new":
 		c.newSession()
 
+	case "/fork":
+		c.forkCurrentSession()
+
 	case "/clear", "/reset":
 		c.clearCurrentSession()
 
@@ -438,6 +502,14 @@ func (c *Controller) handleCommand(text string) {
 				c.handleModelCurate(parts[2:])
 				return
 			}
+			if sub == "compare" {
+				c.handleModelCompare(parts[2:])
+				return
+			}
+			if sub == "group" || sub == "grouping" {
+				c.handleModelGrouping(parts[2:])
+				return
+			}
 			modelID := strings.TrimSpace(strings.Join(parts[1:], " "))
 			c.setExecutionModel(modelID)
 		} else {
@@ -450,15 +522,45 @@ func (c *Controller) handleCommand(text string) {
 	case "/history":
 		c.showHistory(parts[1:])
 
+	case "/trace":
+		c.showTurnTrace()
+
+	case "/attachments", "/attach":
+		c.handleAttachmentsCommand(parts[1:])
+
 	case "/export":
 		c.exportCurrentSession(parts[1:])
 
+	case "/import":
+		c.importConversation(parts[1:])
+
 	case "/compact", "/summarize":
 		c.compactCurrentSession()
 
 	case "/cancel", "/stop":
 		c.cancelCurrentStream()
 
+	case "/tasks":
+		c.handleTasksCommand(parts[1:])
+
+	case "/continue":
+		c.continueTruncatedResponse()
+
+	case "/retry":
+		c.retryLastTurn()
+
+	case "/undo":
+		c.handleUndoCommand()
+
+	case "/dryrun":
+		c.handleDryRunCommand(parts[1:])
+
+	case "/pin":
+		c.handlePinCommand(parts[1:])
+
+	case "/unpin":
+		c.handleUnpinCommand(parts[1:])
+
 	case "/queue":
 		prompt := strings.TrimSpace(strings.TrimPrefix(text, parts[0]))
 		if prompt == "" {
@@ -475,6 +577,12 @@ func (c *Controller) handleCommand(text string) {
 		}
 		c.submitPrompt(prompt, true)
 
+	case "/stop-seq":
+		c.handleStopSequenceCommand(strings.TrimSpace(strings.TrimPrefix(text, parts[0])))
+
+	case "/render":
+		c.handleRenderCommand(parts[1:])
+
 	case "/plans":
 		c.showPlans()
 
@@ -484,24 +592,45 @@ func (c *Controller) handleCommand(text string) {
 	case "/help":
 		c.app.AddMessage(`Commands:
   /new                 - Start a new session
+  /fork                - Branch a new session off the current conversation
   /clear, /reset       - Clear the current session
   /tokens, /context    - Show context, token, and tool-output budget
   /compact             - Summarize older context in the current session
   /history             - Show recent conversation turns
-  /export [file]       - Export the current conversation to Markdown
+  /trace               - Show reasoning, tool calls, and results for the last turn
+  /attachments         - List attached files (show|reattach <n> to view or re-send)
+  /export [file]       - Export the current conversation (Markdown by default)
+  /export --format X   - Export as markdown, json, or html
+  /export --tools      - Include tool messages (also --system, --no-tools)
+  /import <file.json>  - Import a JSON export (new session if its ID is taken)
+  /import --replace   - Overwrite the session that has the file's ID
   /cancel, /stop       - Cancel the current response and clear queued input
+  /tasks [cancel <id>] - List running responses, compactions, and comparisons
+  /continue            - Resume a response cut off by the output token limit
+  /retry               - Discard the last response and resend its prompt
+  /undo                - Revert the last file change made by a tool
+  /dryrun on|off       - Describe file writes and commands instead of running them
+  /pin [list]          - Keep your latest message when trimming context (list pins)
+  /unpin <n|all>       - Remove a pin shown by /pin list, or all pins
   /steer <message>     - Interrupt and redirect the active response
   /queue <message>     - Run a follow-up after the active response
+  /stop-seq add|clear  - List, add, or clear session stop sequences
+  /render on|off       - Show rendered or raw markdown for this session
   /sessions, /tabs     - List active sessions
   /next, /n            - Switch to next session
   /prev, /p            - Switch to previous session
   /model [id]          - Pick or set the execution model
   /model curate        - Curate models for ACP/editor pickers
+  /model compare a b   - Answer the current context with two models
+  /model group [mode]  - Group the model picker by provider or capability
   /skill [name|list]   - List or activate a skill
   /plans               - List saved plans
   /config              - Show active Buckley config summary
-  /review              - Review current git diff
+  /review              - Review current git diff (staged and unstaged)
+  /review --staged     - Review only staged changes (--unstaged for the rest)
+  /review <ref>        - Review changes against a branch, tag, or commit
   /commit              - Generate commit message for staged changes
+  /commit apply        - Commit staged changes with the generated message
   /help                - Show this help
   /quit, /exit         - Exit Buckley
 
@@ -511,10 +640,10 @@ Shortcuts: Ctrl+C (interrupt active work), Alt+Right (next), Alt+Left (prev), Ct
 		c.app.Quit()
 
 	case "/review":
-		c.handleReview()
+		c.handleReviewCommand(parts[1:])
 
 	case "/commit":
-		c.handleCommit()
+		c.handleCommitCommand(parts[1:])
 
 	case "/skill", "/skills":
 		c.handleSkillCommand(parts[1:])
@@ -572,7 +701,7 @@ func (c *Controller) collectModelPickerItemsLocked(curated map[string]struct{})
 	execID := strings.TrimSpace(c.cfg.Models.Execution)
 	planID := strings.TrimSpace(c.cfg.Models.Planning)
 	reviewID := strings.TrimSpace(c.cfg.Models.Review)
-	return buildModelPickerItems(catalog.Data, c.modelMgr, execID, planID, reviewID, curated)
+	return buildModelPickerItems(catalog.Data, c.modelMgr, c.modelPickerGroupingLocked(), execID, planID, reviewID, curated)
 }
 
 func (c *Controller) handleModelCurate(args []string) {
@@ -590,8 +719,9 @@ func (c *Controller) handleModelCurate(args []string) {
 	case "clear":
 		c.mu.Lock()
 		c.cfg.Models.Curated = nil
+		notice := c.curatedChangedLocked("Cleared curated models.")
 		c.mu.Unlock()
-		c.app.AddMessage("Cleared curated models. Use /model curate save to persist.", "system")
+		c.app.AddMessage(notice, "system")
 	case "save":
 		target := "project"
 		if len(args) > 1 {
@@ -618,7 +748,7 @@ func (c *Controller) handleModelCurate(args []string) {
 }
 
 func (c *Controller) showModelCuratePickerLocked() {
-	curatedSet := curatedModelSet(c.cfg.Models.Curated)
+	curatedSet := curatedModelSet(c.cfg.Models.Curated.IDs())
 	items, _ := c.collectModelPickerItemsLocked(curatedSet)
 	if len(items) == 0 {
 		return
@@ -629,36 +759,37 @@ func (c *Controller) showModelCuratePickerLocked() {
 		if id, ok := item.Data.(string); ok && strings.TrimSpace(id) != "" {
 			modelID = id
 		}
-		changed := c.toggleCuratedModel(modelID)
-		if changed {
-			c.app.AddMessage("Curated models updated. Use /model curate save to persist.", "system")
-		}
+		c.handleCuratedToggle(modelID)
 	})
 }
 
+// handleCuratedToggle flips a model's curated state from the picker.
+func (c *Controller) handleCuratedToggle(modelID string) {
+	if !c.toggleCuratedModel(modelID) {
+		return
+	}
+	c.mu.Lock()
+	notice := c.curatedChangedLocked("Curated models updated.")
+	c.mu.Unlock()
+	c.app.AddMessage(notice, "system")
+}
+
 func (c *Controller) toggleCuratedModel(modelID string) bool {
 	modelID = strings.TrimSpace(modelID)
 	if modelID == "" {
 		return false
 	}
 	if c.modelMgr != nil && !catalogHasModel(c.modelMgr, modelID) {
-		c.app.AddMessage("Model not found in catalog: "+modelID, "system")
+		c.app.AddMessage(formatModelNotFound(c.modelMgr.NotFoundError(modelID, modelSuggestionLimit), "/model curate add"), "system")
 		return false
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	curated := append([]string{}, c.cfg.Models.Curated...)
-	for i, id := range curated {
-		if id == modelID {
-			curated = append(curated[:i], curated[i+1:]...)
-			c.cfg.Models.Curated = curated
-			return true
-		}
+	if !c.cfg.Models.Curated.Remove(modelID) {
+		c.cfg.Models.Curated.Add(modelGroupKey(modelID, c.modelMgr), modelID)
 	}
-	curated = append(curated, modelID)
-	c.cfg.Models.Curated = curated
 	return true
 }
 
@@ -668,54 +799,62 @@ func (c *Controller) addCuratedModel(modelID string) {
 		return
 	}
 	if c.modelMgr != nil && !catalogHasModel(c.modelMgr, modelID) {
-		c.app.AddMessage("Model not found in catalog: "+modelID, "system")
+		c.app.AddMessage(formatModelNotFound(c.modelMgr.NotFoundError(modelID, modelSuggestionLimit), "/model curate add"), "system")
 		return
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for _, id := range c.cfg.Models.Curated {
-		if id == modelID {
-			c.app.AddMessage("Model already in curated list: "+modelID, "system")
-			return
-		}
+	if c.cfg.Models.Curated.Contains(modelID) {
+		c.app.AddMessage("Model already in curated list: "+modelID, "system")
+		return
 	}
-	c.cfg.Models.Curated = append(c.cfg.Models.Curated, modelID)
-	c.app.AddMessage("Added model to curated list. Use /model curate save to persist.", "system")
+	c.cfg.Models.Curated.Add(modelGroupKey(modelID, c.modelMgr), modelID)
+	c.app.AddMessage(c.curatedChangedLocked("Added model to curated list."), "system")
 }
 
 func (c *Controller) removeCuratedModel(modelID string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	curated := append([]string{}, c.cfg.Models.Curated...)
-	for i, id := range curated {
-		if id == modelID {
-			curated = append(curated[:i], curated[i+1:]...)
-			c.cfg.Models.Curated = curated
-			c.app.AddMessage("Removed model from curated list. Use /model curate save to persist.", "system")
-			return
-		}
+	if c.cfg.Models.Curated.Remove(modelID) {
+		c.app.AddMessage(c.curatedChangedLocked("Removed model from curated list."), "system")
+		return
 	}
 	c.app.AddMessage("Model not in curated list: "+modelID, "system")
 }
 
 func (c *Controller) showCuratedModelsLocked() {
 	c.mu.Lock()
-	curated := append([]string{}, c.cfg.Models.Curated...)
+	curated := c.cfg.Models.Curated.Clone()
 	c.mu.Unlock()
 
-	if len(curated) == 0 {
+	if len(curated.IDs()) == 0 {
 		c.app.AddMessage("Curated list is empty. ACP will use execution/planning/review defaults.", "system")
 		return
 	}
-	c.app.AddMessage("Curated models:\n- "+strings.Join(curated, "\n- "), "system")
+	c.app.AddMessage(formatCuratedModels(curated), "system")
+}
+
+// formatCuratedModels lists curated models under their provider.
+func formatCuratedModels(curated config.CuratedModels) string {
+	var b strings.Builder
+	b.WriteString("Curated models:")
+	for _, provider := range curated.Providers() {
+		b.WriteString("\n" + provider + ":")
+		for _, id := range curated[provider] {
+			b.WriteString("\n- " + id)
+		}
+	}
+	return b.String()
 }
 
 func (c *Controller) saveCuratedModels(target string) {
+	c.curatedWriteMu.Lock()
+	defer c.curatedWriteMu.Unlock()
 	c.mu.Lock()
-	curated := append([]string{}, c.cfg.Models.Curated...)
+	curated := c.cfg.Models.Curated.Clone()
 	c.mu.Unlock()
 
 	path, err := curatedConfigPath(c.workDir, target)
@@ -730,6 +869,54 @@ func (c *Controller) saveCuratedModels(target string) {
 	c.app.AddMessage("Saved curated models to "+path, "system")
 }
 
+// curatedAutoSaveDelay batches a burst of curate changes into one config
+// write.
+const curatedAutoSaveDelay = 500 * time.Millisecond
+
+// curatedChangedLocked schedules a models.curated_autosave write, restarting
+// the debounce window, and returns notice completed with how the change will
+// be persisted. Callers must hold c.mu.
+func (c *Controller) curatedChangedLocked(notice string) string {
+	target := strings.TrimSpace(c.cfg.Models.CuratedAutoSave)
+	if target == "" {
+		return notice + " Use /model curate save to persist."
+	}
+	if c.curatedSaveTimer != nil {
+		c.curatedSaveTimer.Stop()
+	}
+	delay := c.curatedSaveDelay
+	if delay <= 0 {
+		delay = curatedAutoSaveDelay
+	}
+	c.curatedSaveTimer = time.AfterFunc(delay, c.flushCuratedAutoSave)
+	return notice + " Saving to " + strings.ToLower(target) + " config automatically."
+}
+
+// flushCuratedAutoSave writes a pending curated auto-save now. It is called
+// when the debounce window ends and on shutdown.
+func (c *Controller) flushCuratedAutoSave() {
+	c.curatedWriteMu.Lock()
+	defer c.curatedWriteMu.Unlock()
+	c.mu.Lock()
+	if c.curatedSaveTimer == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.curatedSaveTimer.Stop()
+	c.curatedSaveTimer = nil
+	target := c.cfg.Models.CuratedAutoSave
+	curated := c.cfg.Models.Curated.Clone()
+	c.mu.Unlock()
+
+	path, err := curatedConfigPath(c.workDir, target)
+	if err == nil {
+		err = writeCuratedModels(path, curated)
+	}
+	if err != nil {
+		c.app.AddMessage("Failed to auto-save curated models: "+err.Error(), "system")
+	}
+}
+
 func curatedConfigPath(workDir, target string) (string, error) {
 	target = strings.TrimSpace(strings.ToLower(target))
 	switch target {
@@ -742,11 +929,13 @@ func curatedConfigPath(workDir, target string) (string, error) {
 		}
 		return filepath.Join(home, ".buckley", "config.yaml"), nil
 	default:
-		return "", fmt.Errorf("unknown target %q (use project or user)", target)
+		return "", fmt.Errorf("unknown target %q (use project, user or global)", target)
 	}
 }
 
-func writeCuratedModels(path string, curated []string) error {
+// writeCuratedModels stores curated as a per-provider map under
+// models.curated, replacing a flat list written by older versions.
+func writeCuratedModels(path string, curated config.CuratedModels) error {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
@@ -769,7 +958,11 @@ func writeCuratedModels(path string, curated []string) error {
 	if !ok {
 		modelsRaw = make(map[string]any)
 	}
-	modelsRaw["curated"] = curated
+	buckets := make(map[string][]string, len(curated))
+	for _, provider := range curated.Providers() {
+		buckets[provider] = curated[provider]
+	}
+	modelsRaw["curated"] = buckets
 	raw["models"] = modelsRaw
 
 	out, err := yaml.Marshal(raw)
@@ -816,12 +1009,15 @@ func (c *Controller) setExecutionModelLocked(modelID string) {
 	}
 
 	if c.modelMgr != nil && !catalogHasModel(c.modelMgr, modelID) {
-		c.app.AddMessage("Model not found in catalog: "+modelID, "system")
+		c.app.AddMessage(formatModelNotFound(c.modelMgr.NotFoundError(modelID, modelSuggestionLimit), "/model"), "system")
 		return
 	}
 
 	c.cfg.Models.Execution = modelID
 	c.modelOverride = modelID
+	// An explicit models.tokenizer resolves to itself; config validation has
+	// already rejected unknown names.
+	_ = conversation.SetTokenizer(conversation.ResolveTokenizer(c.cfg.Models.Tokenizer, modelID))
 	c.app.SetModelName(modelID)
 	notice := "Execution model set to " + modelID
 	if len(c.sessions) > 0 && c.sessions[c.currentSession].Streaming {
@@ -830,6 +1026,23 @@ func (c *Controller) setExecutionModelLocked(modelID string) {
 	c.app.AddMessage(notice, "system")
 }
 
+// modelSuggestionLimit caps the IDs suggested for an unknown /model argument.
+const modelSuggestionLimit = 5
+
+// formatModelNotFound lists the closest catalog IDs for an unknown model,
+// each as the command that was typed (/model or /model curate add).
+func formatModelNotFound(err *model.ModelNotFoundError, command string) string {
+	msg := "Model not found in catalog: " + err.ModelID
+	if len(err.Suggestions) == 0 {
+		return msg + "\nRun /model to open the picker."
+	}
+	msg += "\nDid you mean:"
+	for _, id := range err.Suggestions {
+		msg += "\n  " + command + " " + id
+	}
+	return msg
+}
+
 func catalogHasModel(mgr *model.Manager, modelID string) bool {
 	if mgr == nil {
 		return true
@@ -958,6 +1171,8 @@ func (c *Controller) newSession() {
 		c.app.AddMessage("Error creating session: "+err.Error(), "system")
 		return
 	}
+	c.registerMCPTools(newSess.ToolRegistry)
+	c.attachToolApprover(newSess)
 	c.sessions = append([]*SessionState{newSess}, c.sessions...)
 	c.currentSession = 0
 	c.conversation = newSess.Conversation
@@ -970,6 +1185,118 @@ func (c *Controller) newSession() {
 	c.app.SetStatus("Ready")
 }
 
+// forkCurrentSession copies the current conversation into a new session and
+// switches to it, leaving the original untouched.
+func (c *Controller) forkCurrentSession() {
+	if c.store == nil {
+		c.app.AddMessage("Session storage unavailable", "system")
+		return
+	}
+	if c.store.Ephemeral() {
+		// A fork is copied through storage and resumed from it; neither
+		// exists with persistence off.
+		c.app.AddMessage("Forking needs session storage, which is off for this run (--no-persist).", "system")
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.sessions) == 0 {
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	parent := c.sessions[c.currentSession]
+	if parent.Compacting {
+		c.app.AddMessage("Context compaction is running. Wait for it to finish before forking.", "system")
+		return
+	}
+	if parent.Streaming {
+		c.app.AddMessage("A response is still running. Wait for it to finish or use /cancel before forking.", "system")
+		return
+	}
+	messages := cloneMessages(parent.Conversation.Messages)
+	if parent.Conversation.HasOlderMessages() {
+		// Only the recent window is in memory; fork the full history.
+		full := conversation.New(parent.ID)
+		if err := full.LoadFromStorage(c.store); err != nil {
+			c.app.AddMessage("Could not load session history: "+err.Error(), "system")
+			return
+		}
+		messages = full.Messages
+	}
+	if len(messages) == 0 {
+		c.app.AddMessage("Nothing to fork yet. Send a message first.", "system")
+		return
+	}
+
+	forkID, err := c.forkSessionID()
+	if err != nil {
+		c.app.AddMessage("Could not fork session: "+err.Error(), "system")
+		return
+	}
+	now := time.Now()
+	record := &storage.Session{
+		ID:              forkID,
+		ProjectPath:     c.workDir,
+		ParentSessionID: parent.ID,
+		CreatedAt:       now,
+		LastActive:      now,
+		Status:          storage.SessionStatusActive,
+	}
+	if stored, err := c.store.GetSession(parent.ID); err == nil && stored != nil {
+		record.GitRepo = stored.GitRepo
+		record.GitBranch = stored.GitBranch
+		record.Model = stored.Model
+	}
+	if err := c.store.CreateSession(record); err != nil {
+		c.app.AddMessage("Could not fork session: "+err.Error(), "system")
+		return
+	}
+	fork, err := newSessionState(c.cfg, c.store, c.workDir, c.telemetry, forkID, false)
+	if err != nil {
+		_ = c.store.DeleteSession(forkID)
+		c.app.AddMessage("Could not fork session: "+err.Error(), "system")
+		return
+	}
+	c.registerMCPTools(fork.ToolRegistry)
+	c.attachToolApprover(fork)
+	for _, msg := range messages {
+		if err := fork.Conversation.SaveMessage(c.store, msg); err != nil {
+			_ = c.store.DeleteSession(forkID)
+			c.app.AddMessage("Could not copy messages into fork: "+err.Error(), "system")
+			return
+		}
+	}
+	if err := fork.Conversation.LoadRecentFromStorage(c.store, sessionHistoryWindow); err != nil {
+		c.app.AddMessage("Could not load forked session: "+err.Error(), "system")
+		return
+	}
+
+	c.sessions = append([]*SessionState{fork}, c.sessions...)
+	c.currentSession = 0
+	c.switchToSessionLocked(0)
+	c.app.AddMessage(fmt.Sprintf("Forked %d messages from %s. The original session is unchanged; /resume %s returns to it.", len(messages), parent.ID, parent.ID), "system")
+}
+
+// forkSessionID generates an unused session ID for a fork.
+func (c *Controller) forkSessionID() (string, error) {
+	base := generatedControllerSessionID(c.workDir) + "-fork"
+	for n := 1; n <= 100; n++ {
+		id := base
+		if n > 1 {
+			id = fmt.Sprintf("%s-%d", base, n)
+		}
+		existing, err := c.store.GetSession(id)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("no free session ID for %s", base)
+}
+
 // buildMessagesForSession constructs the message list for the API using a specific session.
 func (c *Controller) buildMessagesForSession(sess *SessionState) []model.Message {
 	messages := []model.Message{}
@@ -1054,6 +1381,10 @@ func (c *Controller) buildSystemPrompt(sess *SessionState) string {
 	if sess != nil && sess.SkillRegistry != nil {
 		skillDescriptions = sess.SkillRegistry.GetDescriptions()
 	}
+	footer := ""
+	if c.cfg != nil {
+		footer = c.cfg.SystemPrompt.Footer
+	}
 	return prompts.BuildRuntimeSystemPrompt(prompts.RuntimePromptInput{
 		Evaluator:         c.evaluator,
 		BasePrompt:        basePrompt,
@@ -1062,28 +1393,25 @@ func (c *Controller) buildSystemPrompt(sess *SessionState) string {
 		WorkDir:           c.workDir,
 		RootDir:           c.workDir,
 		SkillsDescription: skillDescriptions,
+		Footer:            footer,
 		TaskType:          "coding",
 		ModelTier:         model.InferModelTier(model.ResolvePhaseModel(c.cfg, c.modelMgr, c.rulesEngine, "execution", c.modelOverride)),
 		GTSAvailable:      commandAvailable("gts"),
 	})
 }
 
-// handleFileSelect processes file selection from the picker.
+// handleFileSelect attaches a file chosen in the picker to the current
+// session's conversation.
 func (c *Controller) handleFileSelect(path string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Read file and add to context
-	fullPath := filepath.Join(c.workDir, path)
-	content, err := os.ReadFile(fullPath)
+	content, err := c.readAttachment(path)
 	if err != nil {
 		c.app.AddMessage(fmt.Sprintf("Error reading file: %v", err), "system")
 		return
 	}
-
-	// Add file content as context
-	msg := fmt.Sprintf("File: %s\n```\n%s\n```", path, string(content))
-	c.app.AddMessage(msg, "system")
+	c.attachFileLocked(0, path, content)
 }
 
 // handleShellCmd executes a shell command.
@@ -1119,7 +1447,11 @@ func buildRegistry(cfg *config.Config, store *storage.Store, workDir string, hub
 
 	// Enable todo tracking
 	if store != nil {
-		registry.SetTodoStore(&todoStoreAdapter{store: store})
+		if store.Ephemeral() {
+			registry.SetTodoStore(builtin.NewMemoryTodoStore())
+		} else {
+			registry.SetTodoStore(&todoStoreAdapter{store: store})
+		}
 		registry.EnableCodeIndex(store)
 	}
 
@@ -1137,6 +1469,7 @@ func buildRegistry(cfg *config.Config, store *storage.Store, workDir string, hub
 	if workDir != "" {
 		registry.SetWorkDir(workDir)
 	}
+	registry.EnableUndo(0)
 	registry.EnableDynamicDiscovery(nil)
 
 	return registry
@@ -1167,7 +1500,11 @@ func (c *Controller) listSessions() {
 		if current := c.currentSessionState(); current != nil && current.ID == sess.ID {
 			marker = "→ "
 		}
-		fmt.Fprintf(&sb, "%s[%d] %s · %s · %d messages\n", marker, visible, sess.ID, sess.Status, sess.MessageCount)
+		fmt.Fprintf(&sb, "%s[%d] %s · %s · %d messages", marker, visible, sess.ID, sess.Status, sess.MessageCount)
+		if sess.ParentSessionID != "" {
+			fmt.Fprintf(&sb, " · forked from %s", sess.ParentSessionID)
+		}
+		sb.WriteString("\n")
 	}
 	if visible == 0 {
 		sb.WriteString("No saved sessions for this project.\n")
@@ -1226,6 +1563,8 @@ func (c *Controller) resumeSession(reference string) {
 		c.app.AddMessage("Could not load session: "+err.Error(), "system")
 		return
 	}
+	c.registerMCPTools(sess.ToolRegistry)
+	c.attachToolApprover(sess)
 	c.sessions = append([]*SessionState{sess}, c.sessions...)
 	c.currentSession = 0
 	c.switchToSessionLocked(0)
@@ -1271,6 +1610,7 @@ func (c *Controller) switchToSessionLocked(idx int) {
 	c.registry = sess.ToolRegistry
 
 	// Clear and rebuild display
+	c.app.SetRawMarkdown(sess.RawMarkdown)
 	c.app.ClearScrollback()
 	c.app.WelcomeScreen()
 
@@ -1281,7 +1621,7 @@ func (c *Controller) switchToSessionLocked(idx int) {
 	c.app.addMessageImmediately(statusMsg, "system")
 
 	// Replay conversation to display
-	renderConversationHistoryImmediately(c.app, sess.Conversation.Messages)
+	renderSessionTranscriptImmediately(c.app, sess)
 
 	if sess.Streaming {
 		c.app.SetStatus("Streaming...")
@@ -1296,6 +1636,9 @@ func (c *Controller) Stop() {
 	if c.telemetryBridge != nil {
 		c.telemetryBridge.Stop()
 	}
+	c.stopMCP()
+
+	c.flushCuratedAutoSave()
 
 	c.mu.Lock()
 	// Cancel all streaming sessions
@@ -1325,17 +1668,16 @@ func (c *Controller) saveLatestConversationMessage(sess *SessionState) {
 	}
 }
 
-// handleReview reviews the current git diff in conversation.
-func (c *Controller) handleReview() {
-	// Get git diff (staged + unstaged)
-	diff, err := c.getGitDiff()
+// handleReview reviews the git diff selected by target in conversation.
+func (c *Controller) handleReview(target reviewTarget) {
+	diff, err := c.reviewDiff(target)
 	if err != nil {
 		c.app.AddMessage(fmt.Sprintf("Error getting diff: %v", err), "system")
 		return
 	}
 
 	if strings.TrimSpace(diff) == "" {
-		c.app.AddMessage("No changes to review. Stage some changes or make modifications first.", "system")
+		c.app.AddMessage(target.emptyMessage(), "system")
 		return
 	}
 
@@ -1343,7 +1685,7 @@ func (c *Controller) handleReview() {
 	diff = shapeDiff(diff, diffsignal.ReviewDiffBudget)
 
 	// Build review prompt
-	prompt := fmt.Sprintf(`Please review the following code changes and provide feedback:
+	prompt := fmt.Sprintf(`Please review the following %s and provide feedback:
 
 %s
 
@@ -1354,9 +1696,9 @@ Focus on:
 4. **Style** - Naming, conventions, readability
 5. **Architecture** - Design concerns, coupling, abstractions
 
-Be specific with file:line references. Flag critical issues first.`, "```diff\n"+diff+"\n```")
+Be specific with file:line references. Flag critical issues first.`, target.subject(), "```diff\n"+diff+"\n```")
 
-	c.startSessionPrompt("/review", prompt)
+	c.startSessionPrompt(target.command(), prompt)
 }
 
 // handleCommit generates a commit message for staged changes.
@@ -1379,22 +1721,24 @@ func (c *Controller) handleCommit() {
 	// Get recent commit messages for style reference
 	recentCommits := c.getRecentCommits(5)
 
+	var conventions prompts.CommitConventions
+	if c.cfg != nil {
+		conventions = prompts.CommitConventions{
+			Template:         c.cfg.Commit.Template,
+			Types:            c.cfg.Commit.Types,
+			MaxSubjectLength: c.cfg.Commit.MaxSubjectLength,
+		}
+	}
+
 	// Build commit message generation prompt
-	prompt := fmt.Sprintf(`Generate a commit message for these staged changes:
+	prompt := fmt.Sprintf(`%s
 
 %s
 
 Recent commit style for reference:
 %s
 
-Requirements:
-- Use conventional commit format: type(scope): description
-- Types: feat, fix, refactor, docs, test, chore, perf, style
-- First line under 72 chars
-- Be specific about what changed and why
-- Add body if changes are complex
-
-Output ONLY the commit message, nothing else.`, "```diff\n"+diff+"\n```", recentCommits)
+%s`, commitPromptHeader, "```diff\n"+diff+"\n```", recentCommits, prompts.CommitRequirements(time.Now(), conventions))
 
 	c.startSessionPrompt("/commit", prompt)
 }
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/storage"
+)
+
+const attachmentsUsage = "Usage: /attachments [show|reattach <number>]"
+
+// attachFileLocked adds a file's contents to the current session as an
+// attachment and persists it. id is the stored attachment being re-sent, or
+// zero for a new one. Callers must hold c.mu.
+func (c *Controller) attachFileLocked(id int64, path string, content []byte) {
+	if c.currentSession < 0 || c.currentSession >= len(c.sessions) {
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if sess.Streaming {
+		c.app.AddMessage("A response is still running. Attach the file after it finishes.", "system")
+		return
+	}
+	msg := sess.Conversation.AddAttachmentMessage(id, path, content)
+	c.saveLatestConversationMessage(sess)
+	c.app.AddMessage(conversation.GetContentAsString(msg.Content), "system")
+}
+
+// handleAttachmentsCommand lists the current session's stored attachments,
+// re-shows one from disk, or re-sends one to the model after it was set
+// aside by compaction or left out of the loaded history.
+func (c *Controller) handleAttachmentsCommand(args []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.currentSession < 0 || c.currentSession >= len(c.sessions) {
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	if c.store == nil || c.store.Ephemeral() {
+		c.app.AddMessage("Attachments are not stored for this session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	rows, err := c.store.ListMessageAttachments(sess.ID)
+	if err != nil {
+		c.app.AddMessage("Could not load attachments: "+err.Error(), "system")
+		return
+	}
+	if len(args) == 0 {
+		c.app.AddMessage(c.formatAttachmentList(sess, rows), "system")
+		return
+	}
+	if len(args) != 2 {
+		c.app.AddMessage(attachmentsUsage, "system")
+		return
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n < 1 || n > len(rows) {
+		c.app.AddMessage(fmt.Sprintf("No attachment %s. Run /attachments to list them.", args[1]), "system")
+		return
+	}
+	row := rows[n-1]
+
+	switch strings.ToLower(args[0]) {
+	case "show":
+		content, err := c.readAttachment(row.Path)
+		if err != nil {
+			c.app.AddMessage(fmt.Sprintf("Error reading file: %v", err), "system")
+			return
+		}
+		text := conversation.AttachmentMessageContent(row.Path, content)
+		if storage.AttachmentContentHash(content) != row.ContentHash {
+			text = row.Path + " has changed since it was attached; showing the current version.\n\n" + text
+		}
+		c.app.AddMessage(text, "system")
+	case "reattach":
+		if sess.Conversation.HasAttachment(row.ID) {
+			c.app.AddMessage(row.Path+" is already in the conversation context.", "system")
+			return
+		}
+		content, err := c.readAttachment(row.Path)
+		if err != nil {
+			c.app.AddMessage(fmt.Sprintf("Error reading file: %v", err), "system")
+			return
+		}
+		id := row.ID
+		if storage.AttachmentContentHash(content) != row.ContentHash {
+			c.app.AddMessage(row.Path+" has changed since it was attached; sending the current version.", "system")
+			id = 0
+		}
+		c.attachFileLocked(id, row.Path, content)
+	default:
+		c.app.AddMessage(attachmentsUsage, "system")
+	}
+}
+
+func (c *Controller) readAttachment(path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(c.workDir, path))
+}
+
+// formatAttachmentList numbers attachments for /attachments show and
+// reattach, noting whether each is in context and whether the file changed.
+func (c *Controller) formatAttachmentList(sess *SessionState, rows []storage.MessageAttachment) string {
+	if len(rows) == 0 {
+		return "No attachments in this session. Pick a file with the file picker to attach it."
+	}
+	var b strings.Builder
+	b.WriteString("Attachments:\n")
+	for i, row := range rows {
+		state := "set aside"
+		switch {
+		case sess.Conversation.HasAttachment(row.ID):
+			state = "in context"
+		case row.MessageID != 0:
+			state = "in earlier history"
+		}
+		if content, err := c.readAttachment(row.Path); err != nil {
+			state += ", missing on disk"
+		} else if storage.AttachmentContentHash(content) != row.ContentHash {
+			state += ", changed on disk"
+		}
+		fmt.Fprintf(&b, "  %d. %s (%s, %s)\n", i+1, row.Path, formatBytes(int(row.SizeBytes)), state)
+	}
+	b.WriteString("\nUse /attachments show <number> to view a file or /attachments reattach <number> to send it again.")
+	return b.String()
+}
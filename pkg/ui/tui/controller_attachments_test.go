@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/storage"
+	"m31labs.dev/fluffyui/backend/sim"
+)
+
+func TestFileSelectAttachmentReattachesAfterResume(t *testing.T) {
+	cfg, store, workDir := newControllerSessionTestConfig(t)
+	createControllerTestSession(t, store, "attach", workDir, storage.SessionStatusActive, time.Now())
+	if err := os.WriteFile(filepath.Join(workDir, "notes.md"), []byte("# notes\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+
+	sessions, current, err := loadOrCreateControllerSessions(cfg, workDir)
+	if err != nil {
+		t.Fatalf("loadOrCreateControllerSessions: %v", err)
+	}
+	ctrl := &Controller{app: app, store: store, workDir: workDir, sessions: sessions, currentSession: current}
+	ctrl.handleFileSelect("notes.md")
+
+	rows, err := store.ListMessageAttachments("attach")
+	if err != nil {
+		t.Fatalf("ListMessageAttachments: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Path != "notes.md" || rows[0].MessageID == 0 {
+		t.Fatalf("attachments = %+v, want notes.md linked to a message", rows)
+	}
+
+	// Drop the attachment from the stored transcript, as compaction does.
+	conv := sessions[current].Conversation
+	conv.Messages = nil
+	if err := conv.SaveAllMessages(store); err != nil {
+		t.Fatalf("SaveAllMessages: %v", err)
+	}
+
+	resumed, current, err := loadOrCreateControllerSessions(cfg, workDir)
+	if err != nil {
+		t.Fatalf("resume sessions: %v", err)
+	}
+	ctrl = &Controller{app: app, store: store, workDir: workDir, sessions: resumed, currentSession: current}
+	if resumed[current].Conversation.HasAttachment(rows[0].ID) {
+		t.Fatal("set-aside attachment should not be in the resumed context")
+	}
+	ctrl.handleAttachmentsCommand([]string{"reattach", "1"})
+	if !resumed[current].Conversation.HasAttachment(rows[0].ID) {
+		t.Fatal("reattach did not add the attachment back to the conversation")
+	}
+	rows, err = store.ListMessageAttachments("attach")
+	if err != nil || len(rows) != 1 || rows[0].MessageID == 0 {
+		t.Fatalf("attachments after reattach = %+v, %v", rows, err)
+	}
+}
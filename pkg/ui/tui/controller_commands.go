@@ -1,7 +1,10 @@
 package tui
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,6 +16,7 @@ import (
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/orchestrator"
+	"m31labs.dev/buckley/pkg/tool"
 )
 
 type toolOutputStat struct {
@@ -65,7 +69,7 @@ func (c *Controller) cancelCurrentStream() {
 	c.mu.Unlock()
 
 	if compacting {
-		c.app.AddMessage("Context compaction is running and cannot be cancelled from the TUI yet.", "system")
+		c.app.AddMessage("Context compaction is running. Use /tasks to cancel it.", "system")
 		return
 	}
 	if !streaming || cancel == nil {
@@ -128,10 +132,7 @@ func (c *Controller) showContextReport() {
 		return
 	}
 	sess := c.sessions[c.currentSession]
-	modelID := model.ResolvePhaseModel(c.cfg, c.modelMgr, c.rulesEngine, "execution", "")
-	if strings.TrimSpace(modelID) == "" {
-		modelID = "openai/gpt-4o"
-	}
+	modelID, _ := c.modelMgr.ExecutionModelOrDefault(model.ResolvePhaseModel(c.cfg, c.modelMgr, c.rulesEngine, "execution", ""))
 	projectLoaded := c.projectCtx != nil && c.projectCtx.Loaded
 	projectBytes := 0
 	if c.projectCtx != nil {
@@ -163,7 +164,11 @@ func (c *Controller) showHistory(args []string) {
 }
 
 func (c *Controller) exportCurrentSession(args []string) {
-	target := strings.TrimSpace(strings.Join(args, " "))
+	opts, target, err := parseConversationExportArgs(args, conversationExportDefaults(c.cfg))
+	if err != nil {
+		c.app.AddMessage("Could not export: "+err.Error()+". "+conversationExportUsage, "system")
+		return
+	}
 
 	c.mu.Lock()
 	if len(c.sessions) == 0 {
@@ -173,11 +178,19 @@ func (c *Controller) exportCurrentSession(args []string) {
 	}
 	sess := c.sessions[c.currentSession]
 	sessionID := sess.ID
-	messages := cloneMessages(sess.Conversation.Messages)
+	source := sess.Conversation.Messages
+	if sess.Conversation.HasOlderMessages() && c.store != nil {
+		// Only the recent window is in memory; export the full history.
+		full := conversation.New(sessionID)
+		if err := full.LoadFromStorage(c.store); err == nil {
+			source = full.Messages
+		}
+	}
+	messages := conversation.NewExporter(opts).Filter(source)
 	workDir := c.workDir
 	c.mu.Unlock()
 
-	path, err := resolveConversationExportPath(workDir, target, sessionID, time.Now())
+	path, err := resolveConversationExportPath(workDir, target, sessionID, conversation.ExportExtension(opts.Format), time.Now())
 	if err != nil {
 		c.app.AddMessage("Could not resolve export path: "+err.Error(), "system")
 		return
@@ -186,7 +199,11 @@ func (c *Controller) exportCurrentSession(args []string) {
 		c.app.AddMessage("Could not create export directory: "+err.Error(), "system")
 		return
 	}
-	content := renderConversationMarkdown(sessionID, workDir, messages, time.Now())
+	content, err := renderConversationExport(opts, sessionID, workDir, messages, time.Now())
+	if err != nil {
+		c.app.AddMessage("Could not render export: "+err.Error(), "system")
+		return
+	}
 	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 		c.app.AddMessage("Could not write export: "+err.Error(), "system")
 		return
@@ -194,6 +211,340 @@ func (c *Controller) exportCurrentSession(args []string) {
 	c.app.AddMessage("Exported conversation to "+path, "system")
 }
 
+const conversationImportUsage = "Usage: /import [--new|--replace] <file.json>"
+
+// parseConversationImportArgs splits /import arguments into import options
+// and the file path.
+func parseConversationImportArgs(args []string) (conversation.ImportOptions, string, error) {
+	var opts conversation.ImportOptions
+	var rest []string
+	for _, arg := range args {
+		switch {
+		case arg == "--new":
+			opts.NewSessionID = true
+		case arg == "--replace":
+			opts.Replace = true
+		case strings.HasPrefix(arg, "--"):
+			return conversation.ImportOptions{}, "", fmt.Errorf("unknown flag %s", arg)
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if opts.NewSessionID && opts.Replace {
+		return conversation.ImportOptions{}, "", errors.New("--new and --replace cannot be combined")
+	}
+	path := strings.TrimSpace(strings.Join(rest, " "))
+	if path == "" {
+		return conversation.ImportOptions{}, "", errors.New("missing file")
+	}
+	return opts, path, nil
+}
+
+// importConversation loads a JSON export into storage and opens it. The
+// file's session ID is reused only when no session has it, unless --replace
+// overwrites that session; --new always uses a fresh ID.
+func (c *Controller) importConversation(args []string) {
+	opts, path, err := parseConversationImportArgs(args)
+	if err != nil {
+		c.app.AddMessage("Could not import: "+err.Error()+". "+conversationImportUsage, "system")
+		return
+	}
+	if c.store == nil {
+		c.app.AddMessage("Session storage unavailable", "system")
+		return
+	}
+	if c.store.Ephemeral() {
+		c.app.AddMessage("Import needs session storage, which is off for this run (--no-persist).", "system")
+		return
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.workDir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		c.app.AddMessage("Could not import: "+err.Error(), "system")
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	openIdx := -1
+	if opts.Replace {
+		// Overwriting a session that is streaming or compacting would race
+		// with its own writes, so check before touching storage.
+		if id := importSessionID(data); id != "" {
+			for i, sess := range c.sessions {
+				if sess.ID != id {
+					continue
+				}
+				if sess.Streaming || sess.Compacting {
+					c.app.AddMessage("Session "+id+" is busy. Wait for it to finish or import without --replace.", "system")
+					return
+				}
+				openIdx = i
+			}
+		}
+	}
+
+	opts.WorkDir = c.workDir
+	result, err := conversation.NewImporter(c.store).Import(bytes.NewReader(data), opts)
+	if err != nil {
+		c.app.AddMessage("Could not import: "+err.Error(), "system")
+		return
+	}
+	sess, err := newSessionState(c.cfg, c.store, c.workDir, c.telemetry, result.SessionID, true)
+	if err != nil {
+		c.app.AddMessage("Could not load imported session: "+err.Error(), "system")
+		return
+	}
+	c.registerMCPTools(sess.ToolRegistry)
+	c.attachToolApprover(sess)
+	if openIdx >= 0 && c.sessions[openIdx].ID == result.SessionID {
+		c.sessions[openIdx] = sess
+		c.currentSession = openIdx
+	} else {
+		c.sessions = append([]*SessionState{sess}, c.sessions...)
+		c.currentSession = 0
+	}
+	c.switchToSessionLocked(c.currentSession)
+
+	summary := fmt.Sprintf("Imported %d messages into session %s", result.Imported, result.SessionID)
+	if result.Replaced {
+		summary += " (replaced existing messages)"
+	}
+	if result.Skipped > 0 {
+		summary += fmt.Sprintf("; skipped %d tool calls and results", result.Skipped)
+	}
+	c.app.AddMessage(summary, "system")
+}
+
+// importSessionID returns the session ID recorded in an export, or "" when
+// the file cannot be parsed; Import reports the error.
+func importSessionID(data []byte) string {
+	var doc struct {
+		SessionID string `json:"sessionId"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.SessionID)
+}
+
+const pinUsage = "Usage: /pin [list] or /unpin <n|all>"
+
+// handlePinCommand pins the latest user message so budget trimming and
+// compaction keep it exact, or lists the current pins.
+func (c *Controller) handlePinCommand(args []string) {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if len(args) > 0 {
+		text := pinUsage
+		if len(args) == 1 && args[0] == "list" {
+			text = pinnedSummary(sess.Conversation.Messages)
+		}
+		c.mu.Unlock()
+		c.app.AddMessage(text, "system")
+		return
+	}
+	if sess.Streaming || sess.Compacting {
+		c.mu.Unlock()
+		c.app.AddMessage("A response is still in progress. Wait for it to finish before pinning.", "system")
+		return
+	}
+	idx := latestUserMessage(sess.Conversation.Messages)
+	if idx < 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No message to pin.", "system")
+		return
+	}
+	if sess.Conversation.Messages[idx].Pinned {
+		c.mu.Unlock()
+		c.app.AddMessage("Your latest message is already pinned.", "system")
+		return
+	}
+	err := sess.Conversation.SetPinned(c.store, idx, true)
+	preview := truncatePreview(oneLine(conversation.GetContentAsString(sess.Conversation.Messages[idx].Content)), 120)
+	c.mu.Unlock()
+
+	if err != nil {
+		c.app.AddMessage("Could not pin message: "+err.Error(), "system")
+		return
+	}
+	c.app.AddMessage("Pinned: "+preview, "system")
+}
+
+// handleUnpinCommand removes one pin, numbered as in /pin list, or all pins.
+func (c *Controller) handleUnpinCommand(args []string) {
+	if len(args) != 1 {
+		c.app.AddMessage(pinUsage, "system")
+		return
+	}
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if sess.Streaming || sess.Compacting {
+		c.mu.Unlock()
+		c.app.AddMessage("A response is still in progress. Wait for it to finish before unpinning.", "system")
+		return
+	}
+	pinned := pinnedMessageIndexes(sess.Conversation.Messages)
+	targets := pinned
+	if args[0] != "all" {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(pinned) {
+			c.mu.Unlock()
+			c.app.AddMessage(fmt.Sprintf("No pin %q. Run /pin list to see pinned messages.", args[0]), "system")
+			return
+		}
+		targets = pinned[n-1 : n]
+	}
+	var err error
+	for _, idx := range targets {
+		if err = sess.Conversation.SetPinned(c.store, idx, false); err != nil {
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	switch {
+	case err != nil:
+		c.app.AddMessage("Could not unpin message: "+err.Error(), "system")
+	case len(targets) == 0:
+		c.app.AddMessage("No pinned messages.", "system")
+	default:
+		c.app.AddMessage(fmt.Sprintf("Unpinned %d message(s).", len(targets)), "system")
+	}
+}
+
+// handleUndoCommand reverts the most recent file change made by a tool in
+// the current session.
+func (c *Controller) handleUndoCommand() {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if sess.Streaming {
+		c.mu.Unlock()
+		c.app.AddMessage("A response is still in progress. Wait for it to finish or use /cancel first.", "system")
+		return
+	}
+	registry := sess.ToolRegistry
+	c.mu.Unlock()
+
+	edit, err := registry.UndoLastEdit()
+	if errors.Is(err, tool.ErrNothingToUndo) {
+		c.app.AddMessage("No file change to undo.", "system")
+		return
+	}
+	if errors.Is(err, tool.ErrUndoConflict) {
+		c.app.AddMessage("Undo skipped: "+err.Error()+". The later change was kept and this edit dropped.", "system")
+		return
+	}
+	if err != nil {
+		c.app.AddMessage("Undo failed: "+err.Error(), "system")
+		return
+	}
+	c.app.AddMessage(undoSummary(edit, c.workDir), "system")
+}
+
+// handleDryRunCommand shows or toggles dry-run mode for the current
+// session's tools.
+func (c *Controller) handleDryRunCommand(args []string) {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	registry := c.sessions[c.currentSession].ToolRegistry
+	c.mu.Unlock()
+
+	if len(args) == 0 {
+		c.app.AddMessage(formatDryRunMode(registry.DryRun())+" Use /dryrun on|off to change it.", "system")
+		return
+	}
+	switch strings.ToLower(args[0]) {
+	case "on":
+		registry.SetDryRun(true)
+	case "off":
+		registry.SetDryRun(false)
+	default:
+		c.app.AddMessage("Usage: /dryrun [on|off]", "system")
+		return
+	}
+	c.app.AddMessage(formatDryRunMode(registry.DryRun()), "system")
+}
+
+func formatDryRunMode(enabled bool) string {
+	if enabled {
+		return "Dry run is on; file writes, shell commands and other changes are described but not performed."
+	}
+	return "Dry run is off; tools make changes normally."
+}
+
+// undoSummary describes which files an undone edit restored or removed.
+func undoSummary(edit *tool.FileEdit, workDir string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Undid %s:", edit.ToolName)
+	for _, file := range edit.Files {
+		path := file.Path
+		if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		if file.Existed {
+			fmt.Fprintf(&b, "\n  restored %s", path)
+		} else {
+			fmt.Fprintf(&b, "\n  removed %s (created by the edit)", path)
+		}
+	}
+	return b.String()
+}
+
+func latestUserMessage(messages []conversation.Message) int {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return i
+		}
+	}
+	return -1
+}
+
+func pinnedMessageIndexes(messages []conversation.Message) []int {
+	var indexes []int
+	for i, msg := range messages {
+		if msg.Pinned {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func pinnedSummary(messages []conversation.Message) string {
+	pinned := pinnedMessageIndexes(messages)
+	if len(pinned) == 0 {
+		return "No pinned messages. Use /pin to pin your latest message."
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Pinned messages (%d):\n", len(pinned)))
+	for n, idx := range pinned {
+		msg := messages[idx]
+		b.WriteString(fmt.Sprintf("%d. %s: %s\n", n+1, formatRole(msg.Role), truncatePreview(oneLine(conversation.GetContentAsString(msg.Content)), 180)))
+	}
+	return strings.TrimSpace(b.String())
+}
+
 func (c *Controller) compactCurrentSession() {
 	c.mu.Lock()
 	if len(c.sessions) == 0 {
@@ -228,23 +579,29 @@ func (c *Controller) compactCurrentSession() {
 	estimatedSaved := conversation.NewCompactionManager(c.modelMgr, c.cfg, c.rulesEngine).EstimateTokensSaved(snapshot)
 	sess.Compacting = true
 	sessionID := sess.ID
+	ctx, cancel := context.WithCancel(context.Background())
+	taskID := c.startTaskLocked(taskKindCompaction, sessionID, fmt.Sprintf("%d messages", len(snapshot.Messages)), cancel)
 	c.mu.Unlock()
 
 	c.app.StartProcessStatus("Compacting context")
 	go func() {
+		defer cancel()
 		manager := conversation.NewCompactionManager(c.modelMgr, c.cfg, c.rulesEngine)
 		if c.evaluator != nil {
 			manager.SetEvaluator(c.evaluator)
 		}
-		err := manager.Compact(snapshot)
+		err := manager.CompactContext(ctx, snapshot)
 		after := conversation.CountTokensForMessages(snapshot.Messages)
 
 		c.mu.Lock()
+		delete(c.tasks, taskID)
 		sess.Compacting = false
 		if err == nil {
 			sess.Conversation.Messages = cloneMessages(snapshot.Messages)
 			sess.Conversation.TokenCount = snapshot.TokenCount
 			sess.Conversation.CompactionCount = snapshot.CompactionCount
+			sess.Conversation.RecordedPromptTokens = snapshot.RecordedPromptTokens
+			sess.Conversation.RecordedMessageCount = snapshot.RecordedMessageCount
 			if c.store != nil {
 				err = sess.Conversation.SaveAllMessages(c.store)
 			}
@@ -252,6 +609,11 @@ func (c *Controller) compactCurrentSession() {
 		c.mu.Unlock()
 
 		c.app.StopProcessStatus()
+		if errors.Is(err, context.Canceled) {
+			c.app.AddMessage("Context compaction cancelled.", "system")
+			c.app.SetStatus("Ready")
+			return
+		}
 		if err != nil {
 			c.app.AddMessage("Context compaction failed: "+err.Error(), "system")
 			return
@@ -261,6 +623,123 @@ func (c *Controller) compactCurrentSession() {
 	}()
 }
 
+// handleStopSequenceCommand manages the stop sequences sent with every model
+// request in the current session. Sequences may be quoted to include spaces
+// or escapes such as "\n".
+func (c *Controller) handleStopSequenceCommand(args string) {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	sub, rest, _ := strings.Cut(args, " ")
+	switch strings.ToLower(sub) {
+	case "":
+		msg := formatStopSequences(sess.StopSequences)
+		c.mu.Unlock()
+		c.app.AddMessage(msg, "system")
+	case "clear":
+		sess.StopSequences = nil
+		c.mu.Unlock()
+		c.app.AddMessage("Cleared stop sequences for this session.", "system")
+	case "add":
+		limit := model.MaxStopSequences(c.modelMgr.ProviderIDForModel(c.resolveExecutionModel()))
+		seq, err := addStopSequence(sess.StopSequences, rest, limit)
+		if err != nil {
+			c.mu.Unlock()
+			c.app.AddMessage(err.Error(), "system")
+			return
+		}
+		sess.StopSequences = seq
+		c.mu.Unlock()
+		c.app.AddMessage(fmt.Sprintf("Added stop sequence %q (%d/%d).", seq[len(seq)-1], len(seq), limit), "system")
+	default:
+		c.mu.Unlock()
+		c.app.AddMessage("Usage: /stop-seq [add <sequence>|clear]", "system")
+	}
+}
+
+func addStopSequence(existing []string, raw string, limit int) ([]string, error) {
+	seq := strings.TrimSpace(raw)
+	if strings.HasPrefix(seq, "\"") {
+		unquoted, err := strconv.Unquote(seq)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid quoted stop sequence %s: %v", seq, err)
+		}
+		seq = unquoted
+	}
+	if seq == "" {
+		return nil, fmt.Errorf("Usage: /stop-seq add <sequence>")
+	}
+	for _, s := range existing {
+		if s == seq {
+			return nil, fmt.Errorf("Stop sequence %q is already set.", seq)
+		}
+	}
+	if limit > 0 && len(existing) >= limit {
+		return nil, fmt.Errorf("The current provider accepts at most %d stop sequences. Use /stop-seq clear first.", limit)
+	}
+	return append(append([]string(nil), existing...), seq), nil
+}
+
+func formatStopSequences(seqs []string) string {
+	if len(seqs) == 0 {
+		return "No stop sequences set. Use /stop-seq add <sequence>."
+	}
+	var b strings.Builder
+	b.WriteString("Stop sequences:")
+	for i, seq := range seqs {
+		b.WriteString(fmt.Sprintf("\n  %d. %q", i+1, seq))
+	}
+	return b.String()
+}
+
+// handleRenderCommand toggles rendered versus raw markdown display for the
+// current session and redraws its transcript.
+func (c *Controller) handleRenderCommand(args []string) {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if len(args) == 0 {
+		raw := sess.RawMarkdown
+		c.mu.Unlock()
+		c.app.AddMessage(formatRenderMode(raw)+" Use /render on|off to change it.", "system")
+		return
+	}
+
+	var raw bool
+	switch strings.ToLower(args[0]) {
+	case "on":
+		raw = false
+	case "off", "raw":
+		raw = true
+	default:
+		c.mu.Unlock()
+		c.app.AddMessage("Usage: /render [on|off]", "system")
+		return
+	}
+	sess.RawMarkdown = raw
+	c.app.SetRawMarkdown(raw)
+	c.app.ClearScrollback()
+	c.app.WelcomeScreen()
+	renderConversationHistoryImmediately(c.app, sess.Conversation.Messages)
+	c.mu.Unlock()
+	c.app.AddMessage(formatRenderMode(raw), "system")
+}
+
+func formatRenderMode(raw bool) string {
+	if raw {
+		return "Markdown rendering is off; messages show raw markdown."
+	}
+	return "Markdown rendering is on."
+}
+
 func (c *Controller) showPlans() {
 	if c.cfg == nil {
 		c.app.AddMessage("Config unavailable; cannot locate plan directory.", "system")
@@ -400,6 +879,9 @@ func historySummary(messages []conversation.Message, limit int) string {
 		if msg.IsSummary {
 			role += " summary"
 		}
+		if msg.Pinned {
+			role += " (pinned)"
+		}
 		if msg.Name != "" {
 			role += " " + msg.Name
 		}
@@ -420,6 +902,8 @@ func cloneConversation(conv *conversation.Conversation) *conversation.Conversati
 	out.Messages = cloneMessages(conv.Messages)
 	out.TokenCount = conv.TokenCount
 	out.CompactionCount = conv.CompactionCount
+	out.RecordedPromptTokens = conv.RecordedPromptTokens
+	out.RecordedMessageCount = conv.RecordedMessageCount
 	return out
 }
 
@@ -2,10 +2,13 @@ package tui
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/fluffyui/backend/sim"
@@ -134,11 +137,14 @@ func TestRenderConversationMarkdown_TruncatesLargeToolOutput(t *testing.T) {
 	conv.AddUserMessage("inspect")
 	conv.AddToolResponseMessage("call-1", "read_file", "HEAD-"+strings.Repeat("middle", 5000)+"-TAIL")
 
-	got := renderConversationMarkdown("session-1", "/work/project", conv.Messages, time.Unix(0, 0).UTC())
+	got, err := renderConversationExport(conversation.ExportOptions{Format: conversation.ExportMarkdown}, "session-1", "/work/project", conv.Messages, time.Unix(0, 0).UTC())
+	if err != nil {
+		t.Fatalf("render markdown: %v", err)
+	}
 	if !strings.Contains(got, "Buckley Conversation Export") {
 		t.Fatalf("markdown missing header:\n%s", got)
 	}
-	if !strings.Contains(got, "tool output truncated for chat context") {
+	if !strings.Contains(got, "bytes omitted from export") {
 		t.Fatalf("markdown did not truncate large tool output")
 	}
 	if len(got) > 20*1024 {
@@ -157,7 +163,10 @@ func TestRenderConversationMarkdown_IncludesAssistantTextAndToolCalls(t *testing
 		},
 	})
 
-	got := renderConversationMarkdown("session-1", "/work/project", conv.Messages, time.Unix(0, 0).UTC())
+	got, err := renderConversationExport(conversation.ExportOptions{Format: conversation.ExportMarkdown}, "session-1", "/work/project", conv.Messages, time.Unix(0, 0).UTC())
+	if err != nil {
+		t.Fatalf("render markdown: %v", err)
+	}
 	for _, want := range []string{
 		"I'll inspect both files.",
 		"Tool calls: read_file, call-2",
@@ -168,9 +177,135 @@ func TestRenderConversationMarkdown_IncludesAssistantTextAndToolCalls(t *testing
 	}
 }
 
+func TestParseConversationExportArgs_UsesConfigDefaults(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if got := conversationExportDefaults(cfg); got.IncludeSystem || got.IncludeTools {
+		t.Fatalf("default export options = %+v, want system and tools excluded", got)
+	}
+
+	cfg.Export.IncludeSystem = true
+	cfg.Export.IncludeTools = true
+	opts, target, err := parseConversationExportArgs([]string{"notes.md"}, conversationExportDefaults(cfg))
+	if err != nil {
+		t.Fatalf("parseConversationExportArgs: %v", err)
+	}
+	if !opts.IncludeSystem || !opts.IncludeTools || target != "notes.md" {
+		t.Fatalf("opts = %+v, target = %q; want config defaults kept", opts, target)
+	}
+}
+
+func TestParseConversationExportArgs_FlagsOverrideDefaults(t *testing.T) {
+	opts, target, err := parseConversationExportArgs(
+		[]string{"--no-system", "--tools", "out", "file.md"},
+		conversation.ExportOptions{IncludeSystem: true},
+	)
+	if err != nil {
+		t.Fatalf("parseConversationExportArgs: %v", err)
+	}
+	if opts.IncludeSystem || !opts.IncludeTools {
+		t.Fatalf("opts = %+v, want flags to override defaults", opts)
+	}
+	if target != "out file.md" {
+		t.Fatalf("target = %q, want remaining args joined", target)
+	}
+
+	if _, _, err := parseConversationExportArgs([]string{"--all"}, conversation.ExportOptions{}); err == nil {
+		t.Fatal("expected error for unknown flag")
+	}
+}
+
+func TestParseConversationImportArgs(t *testing.T) {
+	opts, path, err := parseConversationImportArgs([]string{"--new", "exports/my", "chat.json"})
+	if err != nil {
+		t.Fatalf("parseConversationImportArgs: %v", err)
+	}
+	if !opts.NewSessionID || opts.Replace || path != "exports/my chat.json" {
+		t.Fatalf("opts = %+v, path = %q", opts, path)
+	}
+	if opts, _, err = parseConversationImportArgs([]string{"--replace", "chat.json"}); err != nil || !opts.Replace {
+		t.Fatalf("--replace opts = %+v, err = %v", opts, err)
+	}
+	for _, args := range [][]string{{}, {"--new"}, {"--force", "chat.json"}, {"--new", "--replace", "chat.json"}} {
+		if _, _, err := parseConversationImportArgs(args); err == nil {
+			t.Errorf("parseConversationImportArgs(%v): expected error", args)
+		}
+	}
+}
+
+func TestConversationExportDefaults_Format(t *testing.T) {
+	if got := conversationExportDefaults(nil).Format; got != conversation.ExportMarkdown {
+		t.Fatalf("nil config format = %q, want markdown", got)
+	}
+	cfg := config.DefaultConfig()
+	if got := conversationExportDefaults(cfg).Format; got != conversation.ExportMarkdown {
+		t.Fatalf("default format = %q, want markdown", got)
+	}
+	cfg.Export.DefaultFormat = "JSON"
+	if got := conversationExportDefaults(cfg).Format; got != conversation.ExportJSON {
+		t.Fatalf("configured format = %q, want json", got)
+	}
+}
+
+func TestParseConversationExportArgs_FormatFlagOverridesDefault(t *testing.T) {
+	defaults := conversation.ExportOptions{Format: conversation.ExportJSON}
+	for _, args := range [][]string{{"--format", "html", "out.html"}, {"--format=html", "out.html"}} {
+		opts, target, err := parseConversationExportArgs(args, defaults)
+		if err != nil {
+			t.Fatalf("parseConversationExportArgs(%v): %v", args, err)
+		}
+		if opts.Format != conversation.ExportHTML || target != "out.html" {
+			t.Fatalf("args %v: format = %q, target = %q; want html and out.html", args, opts.Format, target)
+		}
+	}
+	if opts, _, _ := parseConversationExportArgs(nil, defaults); opts.Format != conversation.ExportJSON {
+		t.Fatalf("format without flag = %q, want configured json", opts.Format)
+	}
+	for _, args := range [][]string{{"--format"}, {"--format", "pdf"}, {"--format="}} {
+		if _, _, err := parseConversationExportArgs(args, defaults); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
+
+func TestRenderConversationExport_Formats(t *testing.T) {
+	conv := conversation.New("session-1")
+	conv.AddUserMessage("compare <a> & <b>")
+	exportedAt := time.Unix(0, 0).UTC()
+
+	got, err := renderConversationExport(conversation.ExportOptions{Format: conversation.ExportJSON}, "session-1", "/work/project", conv.Messages, exportedAt)
+	if err != nil {
+		t.Fatalf("render json: %v", err)
+	}
+	var decoded struct {
+		SessionID string `json:"sessionId"`
+		Messages  []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatalf("json export does not decode: %v\n%s", err, got)
+	}
+	if decoded.SessionID != "session-1" || len(decoded.Messages) != 1 || decoded.Messages[0].Content != "compare <a> & <b>" {
+		t.Fatalf("json export = %+v", decoded)
+	}
+
+	got, err = renderConversationExport(conversation.ExportOptions{Format: conversation.ExportHTML}, "session-1", "/work/project", conv.Messages, exportedAt)
+	if err != nil {
+		t.Fatalf("render html: %v", err)
+	}
+	if !strings.Contains(got, "<h1>Buckley Conversation Export</h1>") || !strings.Contains(got, "compare &lt;a&gt; &amp; &lt;b&gt;") {
+		t.Fatalf("html export missing escaped content:\n%s", got)
+	}
+
+	if ext := conversation.ExportExtension(conversation.ExportHTML); ext != ".html" {
+		t.Fatalf("html extension = %q", ext)
+	}
+}
+
 func TestResolveConversationExportPath_Default(t *testing.T) {
 	workDir := t.TempDir()
-	got, err := resolveConversationExportPath(workDir, "", "buckley/session 1", time.Date(2026, 6, 17, 1, 2, 3, 0, time.UTC))
+	got, err := resolveConversationExportPath(workDir, "", "buckley/session 1", ".md", time.Date(2026, 6, 17, 1, 2, 3, 0, time.UTC))
 	if err != nil {
 		t.Fatalf("resolveConversationExportPath: %v", err)
 	}
@@ -179,3 +314,95 @@ func TestResolveConversationExportPath_Default(t *testing.T) {
 		t.Fatalf("export path = %q, want suffix %q", got, wantSuffix)
 	}
 }
+
+func TestAddStopSequence(t *testing.T) {
+	seqs, err := addStopSequence(nil, ` "\n\nUser:" `, 2)
+	if err != nil {
+		t.Fatalf("addStopSequence quoted: %v", err)
+	}
+	seqs, err = addStopSequence(seqs, "END", 2)
+	if err != nil {
+		t.Fatalf("addStopSequence plain: %v", err)
+	}
+	if len(seqs) != 2 || seqs[0] != "\n\nUser:" || seqs[1] != "END" {
+		t.Fatalf("stop sequences = %#v", seqs)
+	}
+
+	if _, err := addStopSequence(seqs, "STOP", 2); err == nil || !strings.Contains(err.Error(), "at most 2") {
+		t.Fatalf("expected provider limit error, got %v", err)
+	}
+	if _, err := addStopSequence(seqs[:1], "END", 2); err != nil {
+		t.Fatalf("addStopSequence should not mutate the caller's slice: %v", err)
+	}
+	if _, err := addStopSequence(nil, "END", 4); err != nil {
+		t.Fatalf("addStopSequence fresh: %v", err)
+	}
+	if _, err := addStopSequence([]string{"END"}, "END", 4); err == nil {
+		t.Fatal("expected duplicate stop sequence to be rejected")
+	}
+	if _, err := addStopSequence(nil, "   ", 4); err == nil {
+		t.Fatal("expected empty stop sequence to be rejected")
+	}
+}
+
+func TestHandleRenderCommand_TogglesSessionRenderPath(t *testing.T) {
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	first := &SessionState{ID: "session-1", Conversation: conversation.New("session-1")}
+	second := &SessionState{ID: "session-2", Conversation: conversation.New("session-2")}
+	ctrl := &Controller{app: app, sessions: []*SessionState{first, second}}
+
+	ctrl.handleCommand("/render off")
+	if !first.RawMarkdown || !app.chatView.RawMarkdown() {
+		t.Fatalf("after /render off: session raw=%v, view raw=%v", first.RawMarkdown, app.chatView.RawMarkdown())
+	}
+
+	ctrl.mu.Lock()
+	ctrl.currentSession = 1
+	ctrl.switchToSessionLocked(1)
+	ctrl.mu.Unlock()
+	if app.chatView.RawMarkdown() {
+		t.Fatal("switching to a session with rendering on should restore rendered markdown")
+	}
+
+	ctrl.mu.Lock()
+	ctrl.currentSession = 0
+	ctrl.switchToSessionLocked(0)
+	ctrl.mu.Unlock()
+	if !app.chatView.RawMarkdown() {
+		t.Fatal("switching back should restore the session's raw markdown setting")
+	}
+
+	ctrl.handleCommand("/render on")
+	if first.RawMarkdown || app.chatView.RawMarkdown() {
+		t.Fatalf("after /render on: session raw=%v, view raw=%v", first.RawMarkdown, app.chatView.RawMarkdown())
+	}
+
+	ctrl.handleCommand("/render sideways")
+	if first.RawMarkdown {
+		t.Fatal("invalid /render argument should not change the session setting")
+	}
+}
+
+func TestHandleStopSequenceCommand_AddAndClear(t *testing.T) {
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	sess := &SessionState{ID: "session-1", Conversation: conversation.New("session-1")}
+	ctrl := &Controller{app: app, sessions: []*SessionState{sess}}
+
+	for i := 0; i < model.DefaultMaxStopSequences+1; i++ {
+		ctrl.handleCommand("/stop-seq add STOP" + strconv.Itoa(i))
+	}
+	if len(sess.StopSequences) != model.DefaultMaxStopSequences {
+		t.Fatalf("stop sequences = %#v, want capped at %d", sess.StopSequences, model.DefaultMaxStopSequences)
+	}
+
+	ctrl.handleCommand("/stop-seq clear")
+	if len(sess.StopSequences) != 0 {
+		t.Fatalf("stop sequences after clear = %#v", sess.StopSequences)
+	}
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+
+	"m31labs.dev/buckley/pkg/conversation"
+)
+
+const commitUsage = "Usage: /commit [apply]"
+
+// commitPromptHeader opens the /commit prompt; /commit apply looks for it to
+// find the reply that holds the generated message.
+const commitPromptHeader = "Generate a commit message for these staged changes:"
+
+// pendingCommit is a generated message waiting on the approval dialog.
+type pendingCommit struct {
+	ID      string
+	Message string
+}
+
+var commitRequestSeq atomic.Int64
+
+// gitCommitWithMessage runs `git commit -F -` in dir with message on stdin.
+// Tests replace it to avoid touching a real repository.
+var gitCommitWithMessage = func(dir, message string) (string, error) {
+	cmd := exec.Command("git", "commit", "-F", "-")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(message)
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+func (c *Controller) handleCommitCommand(args []string) {
+	if len(args) == 0 {
+		c.handleCommit()
+		return
+	}
+	if len(args) == 1 && strings.EqualFold(args[0], "apply") {
+		c.handleCommitApply()
+		return
+	}
+	c.app.AddMessage(commitUsage, "system")
+}
+
+// handleCommitApply asks for confirmation before committing the staged
+// changes with the message generated by the latest /commit.
+func (c *Controller) handleCommitApply() {
+	sess := c.currentSessionState()
+	if sess == nil || sess.Conversation == nil {
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	c.mu.Lock()
+	streaming := sess.Streaming
+	c.mu.Unlock()
+	if streaming {
+		c.app.AddMessage("A response is still running. Wait for the commit message to finish before /commit apply.", "system")
+		return
+	}
+
+	message, ok := lastCommitMessage(sess.Conversation)
+	if !ok {
+		c.app.AddMessage("No generated commit message. Run /commit first.", "system")
+		return
+	}
+	diff, err := c.getGitDiffStaged()
+	if err != nil {
+		c.app.AddMessage(fmt.Sprintf("Error getting staged changes: %v", err), "system")
+		return
+	}
+	if strings.TrimSpace(diff) == "" {
+		c.app.AddMessage("No staged changes. Use `git add` to stage files first.", "system")
+		return
+	}
+
+	pending := &pendingCommit{
+		ID:      fmt.Sprintf("commit-%d", commitRequestSeq.Add(1)),
+		Message: message,
+	}
+	c.mu.Lock()
+	c.pendingCommit = pending
+	c.mu.Unlock()
+	c.app.RequestApproval(commitApprovalRequest(pending))
+}
+
+// commitApprovalRequest shows the full message as context lines so it can be
+// read before approving.
+func commitApprovalRequest(pending *pendingCommit) ApprovalRequestMsg {
+	lines := strings.Split(pending.Message, "\n")
+	diffLines := make([]DiffLine, len(lines))
+	for i, line := range lines {
+		diffLines[i] = DiffLine{Type: DiffContext, Content: line}
+	}
+	return ApprovalRequestMsg{
+		ID:          pending.ID,
+		Tool:        "git",
+		Operation:   "commit",
+		Description: "Commit staged changes with the generated message",
+		Command:     "git commit -F -",
+		FilePath:    "commit message",
+		DiffLines:   diffLines,
+	}
+}
+
+// handleApproval receives approval dialog decisions.
+func (c *Controller) handleApproval(requestID string, approved, _ bool) {
+	if c.toolApprovals != nil && c.toolApprovals.resolve(requestID, approved) {
+		return
+	}
+	c.mu.Lock()
+	pending := c.pendingCommit
+	if pending == nil || pending.ID != requestID {
+		c.mu.Unlock()
+		return
+	}
+	c.pendingCommit = nil
+	c.mu.Unlock()
+
+	if !approved {
+		c.app.AddMessage("Commit cancelled.", "system")
+		return
+	}
+	go c.applyCommit(pending.Message)
+}
+
+func (c *Controller) applyCommit(message string) {
+	output, err := gitCommitWithMessage(c.workDir, message)
+	if err != nil {
+		if output != "" {
+			c.app.AddMessage(fmt.Sprintf("git commit failed: %v\n%s", err, output), "system")
+		} else {
+			c.app.AddMessage(fmt.Sprintf("git commit failed: %v", err), "system")
+		}
+		return
+	}
+	if output == "" {
+		output = "Committed."
+	}
+	c.app.AddMessage(output, "system")
+}
+
+// lastCommitMessage returns the newest assistant reply after the latest
+// /commit prompt, so a follow-up such as "make it shorter" is what gets
+// applied.
+func lastCommitMessage(conv *conversation.Conversation) (string, bool) {
+	var reply string
+	for i := len(conv.Messages) - 1; i >= 0; i-- {
+		msg := conv.Messages[i]
+		switch msg.Role {
+		case "assistant":
+			if reply == "" {
+				reply = strings.TrimSpace(conversation.GetContentAsString(msg.Content))
+			}
+		case "user":
+			if strings.HasPrefix(conversation.GetContentAsString(msg.Content), commitPromptHeader) {
+				message := extractCommitMessage(reply)
+				return message, message != ""
+			}
+		}
+	}
+	return "", false
+}
+
+// extractCommitMessage strips the code fence models often wrap around the
+// message despite being asked not to.
+func extractCommitMessage(reply string) string {
+	reply = strings.TrimSpace(reply)
+	if !strings.HasPrefix(reply, "```") {
+		return reply
+	}
+	body := reply[3:]
+	if newline := strings.IndexByte(body, '\n'); newline >= 0 {
+		body = body[newline+1:]
+	} else {
+		body = ""
+	}
+	if end := strings.LastIndex(body, "```"); end >= 0 {
+		body = body[:end]
+	}
+	return strings.TrimSpace(body)
+}
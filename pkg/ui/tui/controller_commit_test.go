@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/fluffyui/backend/sim"
+)
+
+func TestExtractCommitMessage(t *testing.T) {
+	tests := map[string]string{
+		"fix(ui): wrap long lines\n":                       "fix(ui): wrap long lines",
+		"```\nfeat: add stats\n\nBody line.\n```":          "feat: add stats\n\nBody line.",
+		"```text\nchore: bump deps\n```\n":                 "chore: bump deps",
+		"```":                                              "",
+		"  docs: note ```fences``` inside the subject  \n": "docs: note ```fences``` inside the subject",
+	}
+	for reply, want := range tests {
+		if got := extractCommitMessage(reply); got != want {
+			t.Errorf("extractCommitMessage(%q) = %q, want %q", reply, got, want)
+		}
+	}
+}
+
+func TestLastCommitMessage(t *testing.T) {
+	conv := conversation.New("s-1")
+	if _, ok := lastCommitMessage(conv); ok {
+		t.Fatal("empty conversation reported a commit message")
+	}
+
+	conv.AddUserMessage("explain the parser")
+	conv.AddAssistantMessage("It tokenizes first.")
+	if _, ok := lastCommitMessage(conv); ok {
+		t.Fatal("reply to an unrelated prompt was treated as a commit message")
+	}
+
+	conv.AddUserMessage(commitPromptHeader + "\n\n```diff\n+x\n```")
+	if _, ok := lastCommitMessage(conv); ok {
+		t.Fatal("commit prompt without a reply reported a message")
+	}
+	conv.AddAssistantMessage("```\nfeat(parser): add lookahead\n```")
+	if got, ok := lastCommitMessage(conv); !ok || got != "feat(parser): add lookahead" {
+		t.Fatalf("lastCommitMessage = %q, %v", got, ok)
+	}
+
+	conv.AddUserMessage("make it mention the lexer")
+	conv.AddAssistantMessage("feat(parser): add lexer lookahead")
+	if got, _ := lastCommitMessage(conv); got != "feat(parser): add lexer lookahead" {
+		t.Fatalf("follow-up reply not applied, got %q", got)
+	}
+}
+
+func TestHandleApprovalRunsCommitOnlyWhenApproved(t *testing.T) {
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	type commitCall struct{ dir, message string }
+	calls := make(chan commitCall, 1)
+	orig := gitCommitWithMessage
+	gitCommitWithMessage = func(dir, message string) (string, error) {
+		calls <- commitCall{dir, message}
+		return "", errors.New("stubbed")
+	}
+	t.Cleanup(func() { gitCommitWithMessage = orig })
+
+	ctrl := &Controller{app: app, workDir: "/repo"}
+
+	ctrl.pendingCommit = &pendingCommit{ID: "commit-1", Message: "fix: one"}
+	ctrl.handleApproval("commit-1", false, false)
+	if ctrl.pendingCommit != nil {
+		t.Fatal("declined commit stayed pending")
+	}
+
+	ctrl.pendingCommit = &pendingCommit{ID: "commit-2", Message: "fix: two"}
+	ctrl.handleApproval("tool-7", true, false)
+	if ctrl.pendingCommit == nil {
+		t.Fatal("an unrelated approval cleared the pending commit")
+	}
+	ctrl.handleApproval("commit-2", true, false)
+	select {
+	case call := <-calls:
+		if call.dir != "/repo" || call.message != "fix: two" {
+			t.Fatalf("git commit called with %+v", call)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("approved commit did not run git commit")
+	}
+	select {
+	case call := <-calls:
+		t.Fatalf("unexpected extra git commit %+v", call)
+	default:
+	}
+}
+
+func TestCommitApprovalRequestShowsMessage(t *testing.T) {
+	req := commitApprovalRequest(&pendingCommit{ID: "commit-3", Message: "feat: a\n\nbody"})
+	if req.ID != "commit-3" || req.Command != "git commit -F -" {
+		t.Fatalf("request = %+v", req)
+	}
+	if len(req.DiffLines) != 3 || req.DiffLines[0].Content != "feat: a" || req.DiffLines[2].Content != "body" {
+		t.Fatalf("diff lines = %+v", req.DiffLines)
+	}
+}
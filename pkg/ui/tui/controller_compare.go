@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/model"
+)
+
+// modelCompareTimeout bounds a /model compare run so a stalled provider does
+// not leave the comparison pending forever.
+const modelCompareTimeout = 3 * time.Minute
+
+// modelCompareCall sends one chat request. Tests replace it to avoid real
+// provider traffic.
+type modelCompareCall func(ctx context.Context, req model.ChatRequest) (*model.ChatResponse, error)
+
+// modelCompareResult is one model's answer in a /model compare run.
+type modelCompareResult struct {
+	ModelID string
+	Text    string
+	Usage   model.Usage
+	Latency time.Duration
+	CostUSD float64
+	HasCost bool
+	Err     error
+}
+
+// handleModelCompare sends the current context to two models and shows both
+// answers stacked in the chat. Neither answer is added to the conversation.
+func (c *Controller) handleModelCompare(args []string) {
+	if len(args) != 2 {
+		c.app.AddMessage("Usage: /model compare <model-a> <model-b>", "system")
+		return
+	}
+	modelA, modelB := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+	if modelA == modelB {
+		c.app.AddMessage("Pick two different models to compare.", "system")
+		return
+	}
+
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if sess.Streaming || sess.Compacting {
+		c.mu.Unlock()
+		c.app.AddMessage("A response is still running. Use /cancel or wait before comparing models.", "system")
+		return
+	}
+	if !conversationHasUserMessage(sess.Conversation) {
+		c.mu.Unlock()
+		c.app.AddMessage("Send a prompt first; /model compare answers the current context.", "system")
+		return
+	}
+	if c.modelMgr == nil {
+		c.mu.Unlock()
+		c.app.AddMessage("Model manager unavailable; cannot compare models.", "system")
+		return
+	}
+	reqs := []model.ChatRequest{
+		c.buildModelCompareRequest(sess, modelA),
+		c.buildModelCompareRequest(sess, modelB),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), modelCompareTimeout)
+	taskID := c.startTaskLocked(taskKindModelCompare, sess.ID, modelA+" vs "+modelB, cancel)
+	c.mu.Unlock()
+
+	c.app.StartProcessStatus(fmt.Sprintf("Comparing %s and %s", modelA, modelB))
+	go func() {
+		defer cancel()
+		defer c.finishTask(taskID)
+
+		results := runModelCompare(ctx, c.modelMgr.ChatCompletion, reqs)
+		if errors.Is(ctx.Err(), context.Canceled) {
+			c.app.StopProcessStatus()
+			c.app.AddMessage("Model comparison cancelled.", "system")
+			c.app.SetStatus("Ready")
+			return
+		}
+		for i := range results {
+			if results[i].Err != nil {
+				continue
+			}
+			if cost, err := c.modelMgr.CalculateCost(results[i].ModelID, results[i].Usage); err == nil {
+				results[i].CostUSD = cost
+				results[i].HasCost = true
+				c.telemetryBridge.AddSessionCost(cost)
+			}
+		}
+
+		c.app.StopProcessStatus()
+		c.app.AddMessage(formatModelCompare(results), "system")
+		c.app.SetStatus("Ready")
+	}()
+}
+
+// buildModelCompareRequest builds a tool-free request for modelID from the
+// session's current context. Callers must hold c.mu.
+func (c *Controller) buildModelCompareRequest(sess *SessionState, modelID string) model.ChatRequest {
+	req := model.ChatRequest{
+		Model:     modelID,
+		Messages:  c.buildMessagesForSession(sess),
+		SessionID: sess.ID,
+	}
+	if len(sess.StopSequences) > 0 {
+		req.Stop = append([]string(nil), sess.StopSequences...)
+	}
+	contextWindow, _ := c.modelMgr.GetContextLength(modelID)
+	req.Messages = conversation.CompactModelMessagesForRequest(req.Messages, req, contextWindow)
+	return req
+}
+
+func conversationHasUserMessage(conv *conversation.Conversation) bool {
+	if conv == nil {
+		return false
+	}
+	for _, msg := range conv.Messages {
+		if msg.Role == "user" {
+			return true
+		}
+	}
+	return false
+}
+
+// runModelCompare dispatches every request concurrently and returns results in
+// request order, so each answer stays paired with the model that produced it.
+func runModelCompare(ctx context.Context, call modelCompareCall, reqs []model.ChatRequest) []modelCompareResult {
+	results := make([]modelCompareResult, len(reqs))
+	var wg sync.WaitGroup
+	for i := range reqs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = runModelCompareRequest(ctx, call, reqs[i])
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+func runModelCompareRequest(ctx context.Context, call modelCompareCall, req model.ChatRequest) modelCompareResult {
+	result := modelCompareResult{ModelID: req.Model}
+	start := time.Now()
+	resp, err := call(ctx, req)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	choice, err := firstToolLoopChoice(req, resp)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Usage = resp.Usage
+	text, err := model.ExtractTextContent(choice.Message.Content)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Text = strings.TrimSpace(text)
+	return result
+}
+
+func formatModelCompare(results []modelCompareResult) string {
+	var b strings.Builder
+	b.WriteString("Model comparison (not added to the conversation):")
+	for _, result := range results {
+		b.WriteString("\n\n### " + result.ModelID + "\n")
+		b.WriteString(formatModelCompareStats(result) + "\n\n")
+		switch {
+		case result.Err != nil:
+			b.WriteString("Failed: " + result.Err.Error())
+		case result.Text == "":
+			b.WriteString("(empty response from model)")
+		default:
+			b.WriteString(result.Text)
+		}
+	}
+	return b.String()
+}
+
+func formatModelCompareStats(result modelCompareResult) string {
+	stats := []string{fmt.Sprintf("%.1fs", result.Latency.Seconds())}
+	if result.Err == nil {
+		stats = append(stats, fmt.Sprintf("%d in / %d out tokens", result.Usage.PromptTokens, result.Usage.CompletionTokens))
+		if result.HasCost {
+			stats = append(stats, fmt.Sprintf("$%.4f", result.CostUSD))
+		}
+	}
+	return "_" + strings.Join(stats, " · ") + "_"
+}
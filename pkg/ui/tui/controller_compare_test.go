@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/model"
+)
+
+func TestRunModelCompare_DispatchesBothModels(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]int{}
+	release := make(chan struct{})
+	started := make(chan string, 2)
+
+	call := func(ctx context.Context, req model.ChatRequest) (*model.ChatResponse, error) {
+		mu.Lock()
+		seen[req.Model]++
+		mu.Unlock()
+		started <- req.Model
+		// Block until both requests are in flight to prove they run concurrently.
+		<-release
+		return &model.ChatResponse{
+			Choices: []model.Choice{{Message: model.Message{Role: "assistant", Content: "answer from " + req.Model}}},
+			Usage:   model.Usage{PromptTokens: 100, CompletionTokens: 20},
+		}, nil
+	}
+
+	done := make(chan []modelCompareResult, 1)
+	go func() {
+		done <- runModelCompare(context.Background(), call, []model.ChatRequest{
+			{Model: "openai/gpt-4o"},
+			{Model: "anthropic/claude-3.5"},
+		})
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatal("requests were not dispatched concurrently")
+		}
+	}
+	close(release)
+	results := <-done
+
+	if seen["openai/gpt-4o"] != 1 || seen["anthropic/claude-3.5"] != 1 {
+		t.Fatalf("dispatch counts = %v, want one call per model", seen)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results))
+	}
+}
+
+func TestRunModelCompare_PairsResultsWithModels(t *testing.T) {
+	call := func(ctx context.Context, req model.ChatRequest) (*model.ChatResponse, error) {
+		if req.Model == "slow/model" {
+			time.Sleep(20 * time.Millisecond)
+			return &model.ChatResponse{
+				Choices: []model.Choice{{Message: model.Message{Content: "slow answer"}}},
+				Usage:   model.Usage{PromptTokens: 50, CompletionTokens: 5},
+			}, nil
+		}
+		return nil, errors.New("rate limited")
+	}
+
+	results := runModelCompare(context.Background(), call, []model.ChatRequest{
+		{Model: "slow/model"},
+		{Model: "broken/model"},
+	})
+
+	if results[0].ModelID != "slow/model" || results[0].Text != "slow answer" || results[0].Err != nil {
+		t.Fatalf("first result = %+v, want slow/model answer", results[0])
+	}
+	if results[0].Usage.PromptTokens != 50 || results[0].Latency <= 0 {
+		t.Fatalf("first result usage/latency = %+v/%s", results[0].Usage, results[0].Latency)
+	}
+	if results[1].ModelID != "broken/model" || results[1].Err == nil {
+		t.Fatalf("second result = %+v, want broken/model error", results[1])
+	}
+}
+
+func TestRunModelCompare_EmptyChoicesIsError(t *testing.T) {
+	call := func(ctx context.Context, req model.ChatRequest) (*model.ChatResponse, error) {
+		return &model.ChatResponse{}, nil
+	}
+	results := runModelCompare(context.Background(), call, []model.ChatRequest{{Model: "a"}})
+	if results[0].Err == nil {
+		t.Fatal("expected an error for a response without choices")
+	}
+}
+
+func TestFormatModelCompare_ShowsStatsPerModel(t *testing.T) {
+	out := formatModelCompare([]modelCompareResult{
+		{
+			ModelID: "openai/gpt-4o",
+			Text:    "Use a map.",
+			Usage:   model.Usage{PromptTokens: 1200, CompletionTokens: 30},
+			Latency: 1500 * time.Millisecond,
+			CostUSD: 0.0042,
+			HasCost: true,
+		},
+		{
+			ModelID: "anthropic/claude-3.5",
+			Latency: 300 * time.Millisecond,
+			Err:     errors.New("rate limited"),
+		},
+	})
+
+	for _, want := range []string{
+		"not added to the conversation",
+		"### openai/gpt-4o",
+		"1.5s · 1200 in / 30 out tokens · $0.0042",
+		"Use a map.",
+		"### anthropic/claude-3.5",
+		"Failed: rate limited",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("formatModelCompare missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "openai/gpt-4o") > strings.Index(out, "anthropic/claude-3.5") {
+		t.Fatalf("results should keep request order:\n%s", out)
+	}
+}
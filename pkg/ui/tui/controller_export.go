@@ -7,91 +7,88 @@ import (
 	"time"
 	"unicode"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/storage"
 )
 
-const conversationExportContentMaxBytes = 16 * 1024
+const conversationExportUsage = "Usage: /export [--format markdown|json|html] [--system|--no-system] [--tools|--no-tools] [file]"
 
-func renderConversationMarkdown(sessionID, workDir string, messages []conversation.Message, exportedAt time.Time) string {
-	var b strings.Builder
-	writeConversationExportHeader(&b, sessionID, workDir, len(messages), exportedAt)
-	for i, msg := range messages {
-		writeConversationExportMessage(&b, i+1, msg)
-	}
-	return b.String()
-}
-
-func writeConversationExportHeader(b *strings.Builder, sessionID, workDir string, messageCount int, exportedAt time.Time) {
-	b.WriteString("# Buckley Conversation Export\n\n")
-	b.WriteString("- Session: `" + sessionID + "`\n")
-	b.WriteString("- Project: `" + workDir + "`\n")
-	b.WriteString("- Exported: `" + exportedAt.Format(time.RFC3339) + "`\n")
-	b.WriteString(fmt.Sprintf("- Messages: `%d`\n\n", messageCount))
-	b.WriteString("## Messages\n\n")
-}
-
-func writeConversationExportMessage(b *strings.Builder, index int, msg conversation.Message) {
-	b.WriteString(fmt.Sprintf("### %d. %s\n\n", index, conversationExportTitle(msg)))
-	if !msg.Timestamp.IsZero() {
-		b.WriteString("_" + msg.Timestamp.Format(time.RFC3339) + "_\n\n")
-	}
-	b.WriteString(conversationExportContent(msg))
-	b.WriteString("\n\n")
-}
-
-func conversationExportTitle(msg conversation.Message) string {
-	title := formatRole(msg.Role)
-	if msg.IsSummary {
-		title += " Summary"
-	}
-	if msg.Name != "" {
-		title += " " + msg.Name
-	}
-	return title
+func conversationExportDefaults(cfg *config.Config) conversation.ExportOptions {
+	opts := conversation.ExportOptions{Format: conversation.ExportMarkdown}
+	if cfg == nil {
+		return opts
+	}
+	// Config validation rejects unknown formats, so only fall back when unset.
+	if format, err := conversation.ParseExportFormat(cfg.Export.DefaultFormat); err == nil && format != "" {
+		opts.Format = format
+	}
+	opts.IncludeSystem = cfg.Export.IncludeSystem
+	opts.IncludeTools = cfg.Export.IncludeTools
+	return opts
 }
 
-func conversationExportContent(msg conversation.Message) string {
-	var blocks []string
-	if content := conversation.GetContentAsString(msg.Content); strings.TrimSpace(content) != "" {
-		blocks = append(blocks, truncateConversationExportContent(content))
-	}
-	if calls := conversationExportToolCalls(msg); len(calls) > 0 {
-		blocks = append(blocks, "Tool calls: "+strings.Join(calls, ", "))
-	}
-	if len(blocks) == 0 && strings.TrimSpace(msg.Reasoning) != "" {
-		blocks = append(blocks, truncateConversationExportContent(msg.Reasoning))
-	}
-	if len(blocks) == 0 {
-		return "(empty)"
-	}
-	return strings.Join(blocks, "\n\n")
-}
-
-func conversationExportToolCalls(msg conversation.Message) []string {
-	if len(msg.ToolCalls) == 0 {
-		return nil
-	}
-	calls := make([]string, 0, len(msg.ToolCalls))
-	for _, call := range msg.ToolCalls {
-		name := strings.TrimSpace(call.Function.Name)
-		if name == "" {
-			name = emptyAs(call.ID, "tool_call")
+// parseConversationExportArgs applies /export flags on top of the configured
+// defaults and returns the remaining arguments as the target path.
+func parseConversationExportArgs(args []string, defaults conversation.ExportOptions) (conversation.ExportOptions, string, error) {
+	opts := defaults
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if value, ok := strings.CutPrefix(arg, "--format="); ok {
+			format, err := conversation.ParseExportFormat(value)
+			if err != nil || format == "" {
+				return opts, "", fmt.Errorf("--format requires markdown, json, or html")
+			}
+			opts.Format = format
+			continue
+		}
+		switch arg {
+		case "--format":
+			if i+1 >= len(args) {
+				return opts, "", fmt.Errorf("--format requires markdown, json, or html")
+			}
+			i++
+			format, err := conversation.ParseExportFormat(args[i])
+			if err != nil || format == "" {
+				return opts, "", fmt.Errorf("--format requires markdown, json, or html")
+			}
+			opts.Format = format
+		case "--system":
+			opts.IncludeSystem = true
+		case "--no-system":
+			opts.IncludeSystem = false
+		case "--tools":
+			opts.IncludeTools = true
+		case "--no-tools":
+			opts.IncludeTools = false
+		default:
+			if strings.HasPrefix(arg, "--") {
+				return opts, "", fmt.Errorf("unknown flag %s", arg)
+			}
+			rest = append(rest, arg)
 		}
-		calls = append(calls, name)
 	}
-	return calls
+	return opts, strings.TrimSpace(strings.Join(rest, " ")), nil
 }
 
-func truncateConversationExportContent(content string) string {
-	return truncateModelToolOutput(content, conversationExportContentMaxBytes)
+// renderConversationExport renders already-filtered messages of the current
+// session with the shared exporter.
+func renderConversationExport(opts conversation.ExportOptions, sessionID, workDir string, messages []conversation.Message, exportedAt time.Time) (string, error) {
+	var b strings.Builder
+	session := &storage.Session{ID: sessionID, ProjectPath: workDir}
+	if err := conversation.NewExporter(opts).WriteSession(&b, session, messages, exportedAt); err != nil {
+		return "", err
+	}
+	return b.String(), nil
 }
 
-func resolveConversationExportPath(workDir, target, sessionID string, now time.Time) (string, error) {
+func resolveConversationExportPath(workDir, target, sessionID, ext string, now time.Time) (string, error) {
 	if strings.TrimSpace(workDir) == "" {
 		return "", fmt.Errorf("workdir required")
 	}
 	if strings.TrimSpace(target) == "" {
-		name := fmt.Sprintf("%s-%s.md", safePathName(sessionID), now.Format("20060102-150405"))
+		name := fmt.Sprintf("%s-%s%s", safePathName(sessionID), now.Format("20060102-150405"), ext)
 		return filepath.Join(workDir, ".buckley", "exports", name), nil
 	}
 	if !filepath.IsAbs(target) {
@@ -2,12 +2,65 @@ package tui
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
+	"time"
 
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/model"
 )
 
+const (
+	// sessionHistoryWindow is how many of a session's newest messages are
+	// loaded when it is opened.
+	sessionHistoryWindow = 200
+	// sessionHistoryPage is how many older messages each scroll to the top
+	// of the transcript loads.
+	sessionHistoryPage = 200
+)
+
+const olderHistoryNotice = "Earlier messages not loaded · scroll up to load more"
+
+// renderSessionTranscriptImmediately replays a session's loaded messages,
+// noting when older ones are still in storage.
+func renderSessionTranscriptImmediately(app *WidgetApp, sess *SessionState) {
+	if app == nil || sess == nil || sess.Conversation == nil {
+		return
+	}
+	if sess.Conversation.HasOlderMessages() {
+		app.addMessageImmediately(olderHistoryNotice, "system")
+	}
+	renderConversationHistoryImmediately(app, sess.Conversation.Messages)
+}
+
+// loadOlderHistory prepends the previous page of the current session's
+// messages once the transcript is scrolled to the top, keeping the visible
+// lines in place. It does nothing while a response is streaming.
+func (c *Controller) loadOlderHistory() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.store == nil || c.currentSession < 0 || c.currentSession >= len(c.sessions) {
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if sess.Streaming || sess.Conversation == nil || !sess.Conversation.HasOlderMessages() {
+		return
+	}
+	added, err := sess.Conversation.LoadOlderFromStorage(c.store, sessionHistoryPage)
+	if err != nil {
+		c.app.setStatusOverride("Could not load earlier messages: "+err.Error(), 3*time.Second)
+	}
+	if added == 0 {
+		return
+	}
+	c.app.rebuildScrollback(func() {
+		c.app.WelcomeScreen()
+		renderSessionTranscriptImmediately(c.app, sess)
+	})
+	c.app.setStatusOverride(fmt.Sprintf("Loaded %d earlier messages", added), 2*time.Second)
+}
+
 func renderConversationHistory(app *WidgetApp, messages []conversation.Message) {
 	if app == nil {
 		return
@@ -62,7 +115,12 @@ func renderConversationHistoryWith(addMessage func(content, source string), mess
 			progress.WriteString(storedToolResultProgressSummary(msg.Name, content))
 		default:
 			flushProgress()
-			if strings.TrimSpace(content) != "" {
+			if strings.TrimSpace(content) == "" {
+				continue
+			}
+			if msg.Attachment != nil {
+				addMessage(content, "system")
+			} else {
 				addMessage(content, msg.Role)
 			}
 		}
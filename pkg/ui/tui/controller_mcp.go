@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"m31labs.dev/buckley/pkg/mcp"
+	"m31labs.dev/buckley/pkg/telemetry"
+	"m31labs.dev/buckley/pkg/tool"
+)
+
+// startMCP connects the configured MCP servers in the background, adds their
+// tools to every open session, and then watches the servers until Stop.
+func (c *Controller) startMCP() {
+	if c.cfg == nil || !c.cfg.MCP.Enabled || len(c.cfg.MCP.Servers) == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	c.mcpCancel = cancel
+	c.mu.Unlock()
+
+	go func() {
+		manager, err := mcp.ManagerFromConfig(ctx, c.cfg.MCP)
+		if err != nil && ctx.Err() == nil {
+			c.app.AddMessage("MCP: "+err.Error(), "system")
+		}
+		if manager == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			_ = manager.Close()
+			return
+		}
+		c.mcpManager.Store(manager)
+		for _, registry := range c.sessionRegistries() {
+			c.registerMCPTools(registry)
+		}
+		manager.Watch(ctx, c.cfg.MCP.HealthInterval, c.handleMCPServerEvent)
+	}()
+}
+
+func (c *Controller) stopMCP() {
+	c.mu.Lock()
+	cancel := c.mcpCancel
+	c.mcpCancel = nil
+	c.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	if manager := c.mcpManager.Swap(nil); manager != nil {
+		_ = manager.Close()
+	}
+}
+
+// registerMCPTools adds the tools of every connected MCP server to registry.
+// It is a no-op until startMCP has connected.
+func (c *Controller) registerMCPTools(registry *tool.Registry) {
+	manager := c.mcpManager.Load()
+	if manager == nil || registry == nil {
+		return
+	}
+	mcp.RegisterMCPTools(manager, func(_ string, candidate any) {
+		if t, ok := candidate.(tool.Tool); ok {
+			registry.Register(t)
+		}
+	})
+}
+
+// handleMCPServerEvent re-registers a reconnected server's tools, which may
+// have changed while it was down, and reports the state change. Tools of a
+// server that went down stay registered and fail with "server not connected"
+// until it returns.
+func (c *Controller) handleMCPServerEvent(event mcp.ServerEvent) {
+	manager := c.mcpManager.Load()
+	if event.Connected && manager != nil {
+		prefix := mcp.ToolPrefix(event.Server)
+		for _, registry := range c.sessionRegistries() {
+			registry.Filter(func(t tool.Tool) bool {
+				return !strings.HasPrefix(t.Name(), prefix)
+			})
+			mcp.RegisterServerTools(manager, event.Server, func(_ string, candidate any) {
+				if t, ok := candidate.(tool.Tool); ok {
+					registry.Register(t)
+				}
+			})
+		}
+	}
+
+	data := map[string]any{
+		"server":    event.Server,
+		"connected": event.Connected,
+	}
+	if event.Err != nil {
+		data["error"] = event.Err.Error()
+	}
+	if c.telemetry == nil {
+		c.app.AddMessage(mcpServerStateMessage(data), "system")
+		return
+	}
+	c.telemetry.Publish(telemetry.Event{
+		Type:      telemetry.EventMCPServerState,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+}
+
+func (c *Controller) sessionRegistries() []*tool.Registry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	registries := make([]*tool.Registry, 0, len(c.sessions))
+	for _, sess := range c.sessions {
+		if sess != nil && sess.ToolRegistry != nil {
+			registries = append(registries, sess.ToolRegistry)
+		}
+	}
+	return registries
+}
+
+// mcpServerStateMessage describes an mcp.server_state event for the
+// transcript.
+func mcpServerStateMessage(data map[string]any) string {
+	server := getString(data, "server")
+	if connected, _ := data["connected"].(bool); connected {
+		return "MCP server " + server + " reconnected; its tools are available again."
+	}
+	msg := "MCP server " + server + " is down"
+	if reason := getString(data, "error"); reason != "" {
+		msg += " (" + truncate(reason, 120) + ")"
+	}
+	return msg + "; its tools will fail until it reconnects."
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
@@ -8,23 +9,78 @@ import (
 	"m31labs.dev/buckley/pkg/ui/widgets"
 )
 
-func buildModelPickerItems(catalog []model.ModelInfo, mgr *model.Manager, execID, planID, reviewID string, curated map[string]struct{}) ([]widgets.PaletteItem, map[string]model.ModelInfo) {
-	catalogIndex, grouped := indexModelCatalog(catalog, mgr)
+// Model picker grouping modes.
+const (
+	modelGroupingProvider   = "provider"
+	modelGroupingCapability = "capability"
+)
+
+// normalizeModelGrouping maps a configured or typed grouping mode onto a known
+// mode, defaulting to provider grouping.
+func normalizeModelGrouping(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", modelGroupingProvider:
+		return modelGroupingProvider, true
+	case modelGroupingCapability, "capabilities":
+		return modelGroupingCapability, true
+	default:
+		return modelGroupingProvider, false
+	}
+}
+
+// handleModelGrouping shows or switches how the /model picker groups models.
+// The choice lasts for this TUI run; ui.model_picker_grouping sets the default.
+func (c *Controller) handleModelGrouping(args []string) {
+	c.mu.Lock()
+	if len(args) == 0 {
+		grouping := c.modelPickerGroupingLocked()
+		c.mu.Unlock()
+		c.app.AddMessage(fmt.Sprintf("Model picker groups by %s. Use /model group provider|capability to change it.", grouping), "system")
+		return
+	}
+	grouping, ok := normalizeModelGrouping(args[0])
+	if !ok {
+		c.mu.Unlock()
+		c.app.AddMessage("Usage: /model group [provider|capability]", "system")
+		return
+	}
+	c.modelPickerGrouping = grouping
+	c.mu.Unlock()
+	c.app.AddMessage(fmt.Sprintf("Model picker now groups by %s.", grouping), "system")
+}
+
+// modelPickerGroupingLocked returns the active grouping mode. Callers must hold c.mu.
+func (c *Controller) modelPickerGroupingLocked() string {
+	if c.modelPickerGrouping != "" {
+		return c.modelPickerGrouping
+	}
+	if c.cfg != nil {
+		grouping, _ := normalizeModelGrouping(c.cfg.UI.ModelPickerGrouping)
+		return grouping
+	}
+	return modelGroupingProvider
+}
+
+func buildModelPickerItems(catalog []model.ModelInfo, mgr *model.Manager, grouping, execID, planID, reviewID string, curated map[string]struct{}) ([]widgets.PaletteItem, map[string]model.ModelInfo) {
+	catalogIndex, grouped := indexModelCatalog(catalog, mgr, grouping)
 	pinnedIDs := preferredModelIDs(execID, planID, reviewID, catalogIndex)
 	pinnedSet := make(map[string]struct{}, len(pinnedIDs))
 
 	items := make([]widgets.PaletteItem, 0, len(catalog))
 	items = appendPinnedModelItems(items, pinnedSet, catalogIndex, pinnedIDs, execID, planID, reviewID, curated)
-	items = appendGroupedModelItems(items, pinnedSet, grouped, execID, planID, reviewID, curated)
+	items = appendGroupedModelItems(items, pinnedSet, grouped, grouping, execID, planID, reviewID, curated)
 	return items, catalogIndex
 }
 
-func indexModelCatalog(catalog []model.ModelInfo, mgr *model.Manager) (map[string]model.ModelInfo, map[string][]model.ModelInfo) {
+func indexModelCatalog(catalog []model.ModelInfo, mgr *model.Manager, grouping string) (map[string]model.ModelInfo, map[string][]model.ModelInfo) {
 	catalogIndex := make(map[string]model.ModelInfo, len(catalog))
 	grouped := make(map[string][]model.ModelInfo)
 	for _, info := range catalog {
 		catalogIndex[info.ID] = info
 		group := modelGroupKey(info.ID, mgr)
+		if grouping == modelGroupingCapability {
+			group = modelCapabilityGroupKey(info)
+		}
 		grouped[group] = append(grouped[group], info)
 	}
 	return catalogIndex, grouped
@@ -44,8 +100,11 @@ func appendPinnedModelItems(items []widgets.PaletteItem, pinnedSet map[string]st
 	return items
 }
 
-func appendGroupedModelItems(items []widgets.PaletteItem, pinnedSet map[string]struct{}, grouped map[string][]model.ModelInfo, execID, planID, reviewID string, curated map[string]struct{}) []widgets.PaletteItem {
+func appendGroupedModelItems(items []widgets.PaletteItem, pinnedSet map[string]struct{}, grouped map[string][]model.ModelInfo, grouping, execID, planID, reviewID string, curated map[string]struct{}) []widgets.PaletteItem {
 	groups := sortedModelGroups(grouped)
+	if grouping == modelGroupingCapability {
+		groups = sortedCapabilityGroups(grouped)
+	}
 	for _, group := range groups {
 		models := sortedModelGroupEntries(grouped[group])
 		for _, info := range models {
@@ -70,6 +129,75 @@ func sortedModelGroups(grouped map[string][]model.ModelInfo) []string {
 	return groups
 }
 
+// modelCapabilityGroupKey names the capability cluster for a model, such as
+// "tools + vision · 200K+ context".
+func modelCapabilityGroupKey(info model.ModelInfo) string {
+	var caps []string
+	if info.SupportsTools() {
+		caps = append(caps, "tools")
+	}
+	if info.SupportsVision() {
+		caps = append(caps, "vision")
+	}
+	if info.SupportsReasoning() {
+		caps = append(caps, "reasoning")
+	}
+	label := "text only"
+	if len(caps) > 0 {
+		label = strings.Join(caps, " + ")
+	}
+	return label + " · " + modelContextTiers[modelContextTier(info.ContextLength)]
+}
+
+// modelContextTiers are labels for modelContextTier, smallest first.
+var modelContextTiers = []string{"unknown context", "under 32K context", "32K+ context", "128K+ context", "200K+ context", "1M+ context"}
+
+func modelContextTier(contextLength int) int {
+	switch {
+	case contextLength >= 1_000_000:
+		return 5
+	case contextLength >= 200_000:
+		return 4
+	case contextLength >= 128_000:
+		return 3
+	case contextLength >= 32_000:
+		return 2
+	case contextLength > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortedCapabilityGroups puts the most capable clusters first: more
+// capabilities, then larger context, then name.
+func sortedCapabilityGroups(grouped map[string][]model.ModelInfo) []string {
+	groups := sortedModelGroups(grouped)
+	rank := func(group string) (int, int) {
+		models := grouped[group]
+		if len(models) == 0 {
+			return 0, 0
+		}
+		info := models[0]
+		caps := 0
+		for _, ok := range []bool{info.SupportsTools(), info.SupportsVision(), info.SupportsReasoning()} {
+			if ok {
+				caps++
+			}
+		}
+		return caps, modelContextTier(info.ContextLength)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		capsI, tierI := rank(groups[i])
+		capsJ, tierJ := rank(groups[j])
+		if capsI != capsJ {
+			return capsI > capsJ
+		}
+		return tierI > tierJ
+	})
+	return groups
+}
+
 func sortedModelGroupEntries(models []model.ModelInfo) []model.ModelInfo {
 	sorted := append([]model.ModelInfo(nil), models...)
 	sort.Slice(sorted, func(i, j int) bool {
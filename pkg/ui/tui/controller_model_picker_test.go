@@ -1,9 +1,17 @@
 package tui
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
+	"gopkg.in/yaml.v3"
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/model"
+	"m31labs.dev/fluffyui/backend/sim"
 )
 
 func TestModelGroupKey(t *testing.T) {
@@ -81,6 +89,7 @@ func TestBuildModelPickerItems_PinsConfiguredAndHotModels(t *testing.T) {
 	items, index := buildModelPickerItems(
 		catalog,
 		nil,
+		modelGroupingProvider,
 		"openai/gpt-4o",
 		"z-ai/glm-5.2",
 		"moonshotai/kimi-k2.7-code",
@@ -126,7 +135,7 @@ func TestBuildModelPickerItems_SortsGroupsAndModels(t *testing.T) {
 		{ID: "alpha/model-a"},
 	}
 
-	items, _ := buildModelPickerItems(catalog, nil, "", "", "", nil)
+	items, _ := buildModelPickerItems(catalog, nil, modelGroupingProvider, "", "", "", nil)
 	want := []struct {
 		category string
 		id       string
@@ -146,3 +155,261 @@ func TestBuildModelPickerItems_SortsGroupsAndModels(t *testing.T) {
 		}
 	}
 }
+
+func TestModelCapabilityGroupKey(t *testing.T) {
+	tests := []struct {
+		name string
+		info model.ModelInfo
+		want string
+	}{
+		{
+			name: "all capabilities",
+			info: model.ModelInfo{
+				ID:                  "openai/gpt-5",
+				ContextLength:       400_000,
+				Architecture:        model.Architecture{Modality: "text+image->text"},
+				SupportedParameters: []string{"tools", "reasoning"},
+			},
+			want: "tools + vision + reasoning · 200K+ context",
+		},
+		{
+			name: "legacy functions parameter",
+			info: model.ModelInfo{ID: "acme/fn", ContextLength: 128_000, SupportedParameters: []string{"functions"}},
+			want: "tools · 128K+ context",
+		},
+		{
+			name: "million token context",
+			info: model.ModelInfo{ID: "google/gemini", ContextLength: 1_048_576, SupportedParameters: []string{"reasoning"}},
+			want: "reasoning · 1M+ context",
+		},
+		{
+			name: "plain small model",
+			info: model.ModelInfo{ID: "tiny/chat", ContextLength: 8_192},
+			want: "text only · under 32K context",
+		},
+		{
+			name: "unknown context",
+			info: model.ModelInfo{ID: "local/model"},
+			want: "text only · unknown context",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := modelCapabilityGroupKey(tt.info); got != tt.want {
+			t.Fatalf("%s: modelCapabilityGroupKey = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildModelPickerItems_GroupsByCapability(t *testing.T) {
+	catalog := []model.ModelInfo{
+		{ID: "tiny/chat", ContextLength: 8_192},
+		{ID: "openai/gpt-5", ContextLength: 400_000, Architecture: model.Architecture{Modality: "text+image->text"}, SupportedParameters: []string{"tools", "reasoning"}},
+		{ID: "anthropic/claude", ContextLength: 200_000, Architecture: model.Architecture{Modality: "text+image->text"}, SupportedParameters: []string{"tools", "reasoning"}},
+		{ID: "qwen/coder", ContextLength: 1_000_000, SupportedParameters: []string{"tools"}},
+		{ID: "acme/fn", ContextLength: 32_768, SupportedParameters: []string{"functions"}},
+	}
+
+	items, _ := buildModelPickerItems(catalog, nil, modelGroupingCapability, "", "", "", nil)
+	want := []struct {
+		category string
+		id       string
+	}{
+		{category: "tools + vision + reasoning · 200K+ context", id: "anthropic/claude"},
+		{category: "tools + vision + reasoning · 200K+ context", id: "openai/gpt-5"},
+		{category: "tools · 1M+ context", id: "qwen/coder"},
+		{category: "tools · 32K+ context", id: "acme/fn"},
+		{category: "text only · under 32K context", id: "tiny/chat"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("items = %d, want %d: %+v", len(items), len(want), items)
+	}
+	for i := range want {
+		if items[i].Category != want[i].category || items[i].ID != want[i].id {
+			t.Fatalf("item %d = %q in %q, want %q in %q", i, items[i].ID, items[i].Category, want[i].id, want[i].category)
+		}
+	}
+	if items[0].Label != "  anthropic/claude" {
+		t.Fatalf("capability label = %q, want the full model ID", items[0].Label)
+	}
+}
+
+func TestNormalizeModelGrouping(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{value: "", want: modelGroupingProvider, ok: true},
+		{value: "Provider", want: modelGroupingProvider, ok: true},
+		{value: " capability ", want: modelGroupingCapability, ok: true},
+		{value: "capabilities", want: modelGroupingCapability, ok: true},
+		{value: "price", want: modelGroupingProvider, ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeModelGrouping(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("normalizeModelGrouping(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatModelNotFound(t *testing.T) {
+	notFound := &model.ModelNotFoundError{ModelID: "openai/gpt-4p", Suggestions: []string{"openai/gpt-4o", "openai/gpt-5"}}
+	got := formatModelNotFound(notFound, "/model")
+	want := "Model not found in catalog: openai/gpt-4p\nDid you mean:\n  /model openai/gpt-4o\n  /model openai/gpt-5"
+	if got != want {
+		t.Fatalf("formatModelNotFound() = %q, want %q", got, want)
+	}
+	got = formatModelNotFound(notFound, "/model curate add")
+	want = "Model not found in catalog: openai/gpt-4p\nDid you mean:\n  /model curate add openai/gpt-4o\n  /model curate add openai/gpt-5"
+	if got != want {
+		t.Fatalf("formatModelNotFound() for curate = %q, want %q", got, want)
+	}
+	if got := formatModelNotFound(&model.ModelNotFoundError{ModelID: "zzz"}, "/model"); got != "Model not found in catalog: zzz\nRun /model to open the picker." {
+		t.Fatalf("formatModelNotFound() without suggestions = %q", got)
+	}
+}
+
+func newCuratedAutoSaveTestController(t *testing.T, target string, delay time.Duration) (*Controller, string) {
+	t.Helper()
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Models.Curated = nil
+	cfg.Models.CuratedAutoSave = target
+	workDir := t.TempDir()
+	ctrl := &Controller{app: app, cfg: cfg, workDir: workDir, curatedSaveDelay: delay}
+	return ctrl, filepath.Join(workDir, ".buckley", "config.yaml")
+}
+
+func readCuratedModels(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var raw struct {
+		Models struct {
+			Curated config.CuratedModels `yaml:"curated"`
+		} `yaml:"models"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("parse %s: %v", path, err)
+	}
+	return raw.Models.Curated.IDs()
+}
+
+func TestCuratedToggleScopesByProvider(t *testing.T) {
+	ctrl, _ := newCuratedAutoSaveTestController(t, "", time.Hour)
+	ctrl.cfg.Models.Curated = config.CuratedModels{config.CuratedAllBucket: {"z-ai/glm-5.2"}}
+
+	ctrl.handleCuratedToggle("openai/gpt-4o")
+	ctrl.handleCuratedToggle("z-ai/glm-5.2")
+	ctrl.handleModelCurate([]string{"add", "anthropic/claude-sonnet-4-5"})
+
+	want := config.CuratedModels{
+		"openai":    {"openai/gpt-4o"},
+		"anthropic": {"anthropic/claude-sonnet-4-5"},
+	}
+	if !reflect.DeepEqual(ctrl.cfg.Models.Curated, want) {
+		t.Fatalf("curated = %v, want %v", ctrl.cfg.Models.Curated, want)
+	}
+}
+
+func TestWriteCuratedModelsReplacesFlatList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	existing := "models:\n  execution: openai/gpt-4o\n  curated:\n    - openai/gpt-4o\n"
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	curated := config.CuratedModels{
+		config.CuratedAllBucket: {"openai/gpt-4o"},
+		"anthropic":             {"anthropic/claude-sonnet-4-5"},
+	}
+	if err := writeCuratedModels(path, curated); err != nil {
+		t.Fatalf("writeCuratedModels: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	var raw struct {
+		Models struct {
+			Execution string              `yaml:"execution"`
+			Curated   map[string][]string `yaml:"curated"`
+		} `yaml:"models"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("parse config: %v\n%s", err, data)
+	}
+	if raw.Models.Execution != "openai/gpt-4o" {
+		t.Fatalf("other model settings were lost:\n%s", data)
+	}
+	if !reflect.DeepEqual(config.CuratedModels(raw.Models.Curated), curated) {
+		t.Fatalf("curated on disk = %v, want %v", raw.Models.Curated, curated)
+	}
+}
+
+func TestFormatCuratedModelsGroupsByProvider(t *testing.T) {
+	got := formatCuratedModels(config.CuratedModels{
+		"openai":                {"openai/gpt-4o", "openai/gpt-5"},
+		config.CuratedAllBucket: {"z-ai/glm-5.2"},
+	})
+	want := "Curated models:\nall:\n- z-ai/glm-5.2\nopenai:\n- openai/gpt-4o\n- openai/gpt-5"
+	if got != want {
+		t.Fatalf("formatCuratedModels() = %q, want %q", got, want)
+	}
+}
+
+func TestCuratedAutoSaveWritesAfterDebounce(t *testing.T) {
+	ctrl, path := newCuratedAutoSaveTestController(t, "project", 50*time.Millisecond)
+
+	ctrl.handleCuratedToggle("openai/gpt-4o")
+	ctrl.handleCuratedToggle("anthropic/claude-sonnet-4-5")
+	ctrl.handleCuratedToggle("openai/gpt-4o")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("config written before the debounce window closed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if got := readCuratedModels(t, path); slices.Equal(got, []string{"anthropic/claude-sonnet-4-5"}) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("curated models on disk = %v, want the final toggle state", readCuratedModels(t, path))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCuratedAutoSaveFlushesPendingWrite(t *testing.T) {
+	ctrl, path := newCuratedAutoSaveTestController(t, "project", time.Hour)
+
+	ctrl.handleModelCurate([]string{"add", "openai/gpt-4o"})
+	ctrl.flushCuratedAutoSave()
+	if got := readCuratedModels(t, path); !slices.Equal(got, []string{"openai/gpt-4o"}) {
+		t.Fatalf("curated models on disk = %v, want flushed add", got)
+	}
+}
+
+func TestCuratedAutoSaveDisabledKeepsManualSave(t *testing.T) {
+	ctrl, path := newCuratedAutoSaveTestController(t, "", time.Millisecond)
+
+	ctrl.handleCuratedToggle("openai/gpt-4o")
+	time.Sleep(20 * time.Millisecond)
+	ctrl.flushCuratedAutoSave()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("config written without curated_autosave: %v", err)
+	}
+
+	ctrl.handleModelCurate([]string{"save", "project"})
+	if got := readCuratedModels(t, path); !slices.Equal(got, []string{"openai/gpt-4o"}) {
+		t.Fatalf("curated models after manual save = %v", got)
+	}
+}
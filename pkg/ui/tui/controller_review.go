@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const reviewUsage = "Usage: /review [--staged|--unstaged|<ref>]"
+
+// reviewTarget is the slice of changes /review sends to the model. The zero
+// value is the combined staged and unstaged diff.
+type reviewTarget struct {
+	staged   bool
+	unstaged bool
+	ref      string
+}
+
+// parseReviewArgs reads the /review arguments. It reports false for anything
+// other than no argument, one scope flag, or one ref.
+func parseReviewArgs(args []string) (reviewTarget, bool) {
+	if len(args) == 0 {
+		return reviewTarget{}, true
+	}
+	if len(args) > 1 {
+		return reviewTarget{}, false
+	}
+	arg := strings.TrimSpace(args[0])
+	switch arg {
+	case "--staged", "--cached":
+		return reviewTarget{staged: true}, true
+	case "--unstaged":
+		return reviewTarget{unstaged: true}, true
+	}
+	if arg == "" || strings.HasPrefix(arg, "-") {
+		return reviewTarget{}, false
+	}
+	return reviewTarget{ref: arg}, true
+}
+
+// command is the /review invocation shown in the transcript.
+func (t reviewTarget) command() string {
+	switch {
+	case t.staged:
+		return "/review --staged"
+	case t.unstaged:
+		return "/review --unstaged"
+	case t.ref != "":
+		return "/review " + t.ref
+	default:
+		return "/review"
+	}
+}
+
+// subject names the reviewed changes in the prompt.
+func (t reviewTarget) subject() string {
+	switch {
+	case t.staged:
+		return "staged changes"
+	case t.unstaged:
+		return "unstaged changes"
+	case t.ref != "":
+		return "changes against " + t.ref
+	default:
+		return "code changes"
+	}
+}
+
+// emptyMessage explains an empty diff for the chosen scope.
+func (t reviewTarget) emptyMessage() string {
+	switch {
+	case t.staged:
+		return "No staged changes to review. Use `git add` to stage files, or run /review --unstaged."
+	case t.unstaged:
+		return "No unstaged changes to review. Run /review --staged to review what is staged."
+	case t.ref != "":
+		return fmt.Sprintf("No changes against %s to review.", t.ref)
+	default:
+		return "No changes to review. Stage some changes or make modifications first."
+	}
+}
+
+func (c *Controller) handleReviewCommand(args []string) {
+	target, ok := parseReviewArgs(args)
+	if !ok {
+		c.app.AddMessage(reviewUsage, "system")
+		return
+	}
+	c.handleReview(target)
+}
+
+// reviewDiff returns the diff for target.
+func (c *Controller) reviewDiff(target reviewTarget) (string, error) {
+	switch {
+	case target.staged:
+		return c.getGitDiffStaged()
+	case target.unstaged:
+		return c.getGitDiffUnstaged()
+	case target.ref != "":
+		return c.getGitDiffAgainst(target.ref)
+	default:
+		return c.getGitDiff()
+	}
+}
+
+// getGitDiffUnstaged returns only changes not yet staged.
+func (c *Controller) getGitDiffUnstaged() (string, error) {
+	cmd := exec.Command("git", "diff")
+	cmd.Dir = c.workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// getGitDiffAgainst returns the working tree, staged changes included,
+// compared with ref.
+func (c *Controller) getGitDiffAgainst(ref string) (string, error) {
+	verify := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	verify.Dir = c.workDir
+	if err := verify.Run(); err != nil {
+		return "", fmt.Errorf("unknown git ref %q", ref)
+	}
+	cmd := exec.Command("git", "diff", ref, "--")
+	cmd.Dir = c.workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseReviewArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want reviewTarget
+		ok   bool
+	}{
+		{args: nil, want: reviewTarget{}, ok: true},
+		{args: []string{"--staged"}, want: reviewTarget{staged: true}, ok: true},
+		{args: []string{"--cached"}, want: reviewTarget{staged: true}, ok: true},
+		{args: []string{"--unstaged"}, want: reviewTarget{unstaged: true}, ok: true},
+		{args: []string{"main"}, want: reviewTarget{ref: "main"}, ok: true},
+		{args: []string{"HEAD~2"}, want: reviewTarget{ref: "HEAD~2"}, ok: true},
+		{args: []string{"--output=/tmp/x"}, ok: false},
+		{args: []string{"--staged", "main"}, ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseReviewArgs(tt.args)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseReviewArgs(%q) = %+v, %v; want %+v, %v", tt.args, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReviewDiffScopes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test")
+	write("index.txt", "one\n")
+	write("work.txt", "one\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "base")
+	git("tag", "base")
+
+	c := &Controller{workDir: dir}
+	for _, target := range []reviewTarget{{staged: true}, {unstaged: true}, {ref: "base"}, {}} {
+		diff, err := c.reviewDiff(target)
+		if err != nil {
+			t.Fatalf("reviewDiff(%+v): %v", target, err)
+		}
+		if strings.TrimSpace(diff) != "" {
+			t.Fatalf("reviewDiff(%+v) on a clean tree = %q, want empty", target, diff)
+		}
+	}
+
+	write("index.txt", "two\n")
+	git("add", "index.txt")
+	write("work.txt", "two\n")
+
+	tests := []struct {
+		target  reviewTarget
+		include []string
+		exclude []string
+	}{
+		{target: reviewTarget{staged: true}, include: []string{"index.txt"}, exclude: []string{"work.txt"}},
+		{target: reviewTarget{unstaged: true}, include: []string{"work.txt"}, exclude: []string{"index.txt"}},
+		{target: reviewTarget{ref: "base"}, include: []string{"index.txt", "work.txt"}},
+		{target: reviewTarget{}, include: []string{"index.txt", "work.txt"}},
+	}
+	for _, tt := range tests {
+		diff, err := c.reviewDiff(tt.target)
+		if err != nil {
+			t.Fatalf("reviewDiff(%+v): %v", tt.target, err)
+		}
+		for _, name := range tt.include {
+			if !strings.Contains(diff, name) {
+				t.Errorf("reviewDiff(%+v) is missing %s:\n%s", tt.target, name, diff)
+			}
+		}
+		for _, name := range tt.exclude {
+			if strings.Contains(diff, name) {
+				t.Errorf("reviewDiff(%+v) includes %s:\n%s", tt.target, name, diff)
+			}
+		}
+	}
+
+	if _, err := c.reviewDiff(reviewTarget{ref: "no-such-ref"}); err == nil || !strings.Contains(err.Error(), "no-such-ref") {
+		t.Fatalf("reviewDiff with unknown ref error = %v, want unknown ref", err)
+	}
+}
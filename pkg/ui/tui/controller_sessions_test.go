@@ -1,12 +1,14 @@
 package tui
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/storage"
+	"m31labs.dev/fluffyui/backend/sim"
 )
 
 func newControllerSessionTestConfig(t *testing.T) (ControllerConfig, *storage.Store, string) {
@@ -149,3 +151,159 @@ func TestLoadOrCreateControllerSessions_SelectsRequestedActiveSession(t *testing
 		t.Fatalf("current session ID = %q, want old", sessions[current].ID)
 	}
 }
+
+func TestLoadOrCreateControllerSessions_LoadsRecentHistoryWindow(t *testing.T) {
+	cfg, store, workDir := newControllerSessionTestConfig(t)
+	now := time.Now()
+	createControllerTestSession(t, store, "long", workDir, storage.SessionStatusActive, now)
+	total := sessionHistoryWindow + 5
+	for i := 0; i < total; i++ {
+		if err := store.SaveMessage(&storage.Message{
+			SessionID: "long",
+			Role:      "user",
+			Content:   fmt.Sprintf("message %d", i),
+			Timestamp: now.Add(time.Duration(i-total) * time.Second),
+		}); err != nil {
+			t.Fatalf("SaveMessage %d: %v", i, err)
+		}
+	}
+
+	sessions, current, err := loadOrCreateControllerSessions(cfg, workDir)
+	if err != nil {
+		t.Fatalf("loadOrCreateControllerSessions: %v", err)
+	}
+	sess := sessions[current]
+	if got := len(sess.Conversation.Messages); got != sessionHistoryWindow {
+		t.Fatalf("loaded messages = %d, want %d", got, sessionHistoryWindow)
+	}
+	if !sess.Conversation.HasOlderMessages() {
+		t.Fatal("expected older messages to remain in storage")
+	}
+
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	ctrl := &Controller{app: app, store: store, sessions: sessions, currentSession: current}
+	app.SetScrollTopCallback(ctrl.loadOlderHistory)
+	renderSessionTranscriptImmediately(app, sess)
+	app.chatView.ScrollToTop()
+	top, before, _ := app.chatView.ScrollPosition()
+	if top != 0 {
+		t.Fatalf("scroll top = %d, want 0", top)
+	}
+
+	if !app.update(ScrollTopMsg{}) {
+		t.Fatal("expected scrolling to the top to load older history")
+	}
+	if got := len(sess.Conversation.Messages); got != total {
+		t.Fatalf("messages after scroll-back = %d, want %d", got, total)
+	}
+	if sess.Conversation.HasOlderMessages() {
+		t.Fatal("expected the whole session to be loaded")
+	}
+	top, after, _ := app.chatView.ScrollPosition()
+	if after <= before || top == 0 {
+		t.Fatalf("scroll = %d of %d (was 0 of %d), want the view kept below the loaded history", top, after, before)
+	}
+}
+
+func TestLoadOlderHistorySkipsWhileStreaming(t *testing.T) {
+	cfg, store, workDir := newControllerSessionTestConfig(t)
+	now := time.Now()
+	createControllerTestSession(t, store, "busy", workDir, storage.SessionStatusActive, now)
+	for i := 0; i < sessionHistoryWindow+1; i++ {
+		if err := store.SaveMessage(&storage.Message{SessionID: "busy", Role: "user", Content: "hi", Timestamp: now.Add(time.Duration(i) * time.Millisecond)}); err != nil {
+			t.Fatalf("SaveMessage %d: %v", i, err)
+		}
+	}
+	sessions, current, err := loadOrCreateControllerSessions(cfg, workDir)
+	if err != nil {
+		t.Fatalf("loadOrCreateControllerSessions: %v", err)
+	}
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	sessions[current].Streaming = true
+	ctrl := &Controller{app: app, store: store, sessions: sessions, currentSession: current}
+
+	ctrl.loadOlderHistory()
+	if got := len(sessions[current].Conversation.Messages); got != sessionHistoryWindow {
+		t.Fatalf("messages = %d, want %d while streaming", got, sessionHistoryWindow)
+	}
+}
+
+func TestForkCurrentSessionCopiesHistory(t *testing.T) {
+	cfg, store, workDir := newControllerSessionTestConfig(t)
+	now := time.Now()
+	createControllerTestSession(t, store, "origin", workDir, storage.SessionStatusActive, now)
+	for i, role := range []string{"user", "assistant"} {
+		if err := store.SaveMessage(&storage.Message{SessionID: "origin", Role: role, Content: fmt.Sprintf("turn %d", i), Timestamp: now.Add(time.Duration(i) * time.Millisecond)}); err != nil {
+			t.Fatalf("SaveMessage %d: %v", i, err)
+		}
+	}
+	sessions, current, err := loadOrCreateControllerSessions(cfg, workDir)
+	if err != nil {
+		t.Fatalf("loadOrCreateControllerSessions: %v", err)
+	}
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	ctrl := &Controller{app: app, store: store, workDir: workDir, cfg: cfg.Config, sessions: sessions, currentSession: current}
+
+	ctrl.forkCurrentSession()
+	if len(ctrl.sessions) != 2 || ctrl.currentSession != 0 {
+		t.Fatalf("sessions = %d, current = %d; want the fork opened first", len(ctrl.sessions), ctrl.currentSession)
+	}
+	fork := ctrl.sessions[0]
+	if fork.ID == "origin" || len(fork.Conversation.Messages) != 2 {
+		t.Fatalf("fork %s has %d messages, want a new session with 2", fork.ID, len(fork.Conversation.Messages))
+	}
+	record, err := store.GetSession(fork.ID)
+	if err != nil || record == nil || record.ParentSessionID != "origin" {
+		t.Fatalf("fork record = %+v, %v; want parent origin", record, err)
+	}
+	original, err := store.GetMessages("origin", 10, 0)
+	if err != nil || len(original) != 2 {
+		t.Fatalf("origin messages = %d, %v; want 2 untouched", len(original), err)
+	}
+}
+
+func TestPinCommandsPersistAcrossReload(t *testing.T) {
+	cfg, store, workDir := newControllerSessionTestConfig(t)
+	now := time.Now()
+	createControllerTestSession(t, store, "origin", workDir, storage.SessionStatusActive, now)
+	for i, role := range []string{"user", "assistant", "user", "assistant"} {
+		if err := store.SaveMessage(&storage.Message{SessionID: "origin", Role: role, Content: fmt.Sprintf("turn %d", i), Timestamp: now.Add(time.Duration(i) * time.Millisecond)}); err != nil {
+			t.Fatalf("SaveMessage %d: %v", i, err)
+		}
+	}
+	sessions, current, err := loadOrCreateControllerSessions(cfg, workDir)
+	if err != nil {
+		t.Fatalf("loadOrCreateControllerSessions: %v", err)
+	}
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	ctrl := &Controller{app: app, store: store, workDir: workDir, cfg: cfg.Config, sessions: sessions, currentSession: current}
+
+	ctrl.handlePinCommand(nil)
+	if pinned := pinnedMessageIndexes(ctrl.sessions[current].Conversation.Messages); len(pinned) != 1 || pinned[0] != 2 {
+		t.Fatalf("pinned indexes = %v, want the latest user message", pinned)
+	}
+	stored, err := store.GetAllMessages("origin")
+	if err != nil || len(stored) != 4 || !stored[2].IsPinned {
+		t.Fatalf("stored messages = %+v, %v; want turn 2 pinned", stored, err)
+	}
+
+	ctrl.handleUnpinCommand([]string{"all"})
+	if pinned := pinnedMessageIndexes(ctrl.sessions[current].Conversation.Messages); len(pinned) != 0 {
+		t.Fatalf("pinned indexes after /unpin all = %v", pinned)
+	}
+	if stored, _ = store.GetAllMessages("origin"); stored[2].IsPinned {
+		t.Fatal("pin still stored after /unpin all")
+	}
+}
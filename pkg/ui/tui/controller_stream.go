@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/cost"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/telemetry"
 )
@@ -12,6 +14,10 @@ import (
 // streamResponse handles the AI response streaming for a specific session.
 func (c *Controller) streamResponse(ctx context.Context, prompt string, sess *SessionState) {
 	defer c.finishStreamLifecycle(sess)
+	c.mu.Lock()
+	taskID := c.startTaskLocked(taskKindResponse, sess.ID, truncatePreview(oneLine(prompt), 60), c.streamTaskCancel(sess, sess.Cancel))
+	c.mu.Unlock()
+	defer c.finishTask(taskID)
 
 	modelID := c.prepareStreamRequest(prompt, sess)
 	fullResponse, usage, finishReason, err := c.runToolLoop(ctx, sess, modelID)
@@ -47,9 +53,11 @@ func (c *Controller) prepareStreamRequest(prompt string, sess *SessionState) str
 }
 
 func (c *Controller) resolveExecutionModel() string {
-	modelID := model.ResolvePhaseModel(c.cfg, c.modelMgr, c.rulesEngine, "execution", c.modelOverride)
-	if modelID == "" {
-		return "openai/gpt-4o"
+	modelID, auto := c.modelMgr.ExecutionModelOrDefault(model.ResolvePhaseModel(c.cfg, c.modelMgr, c.rulesEngine, "execution", c.modelOverride))
+	if auto {
+		c.autoModelNoteOnce.Do(func() {
+			c.app.AddMessage(model.AutoSelectedModelNote(modelID), "system")
+		})
 	}
 	return modelID
 }
@@ -81,6 +89,68 @@ func (c *Controller) renderStreamResponse(fullResponse, finishReason string) {
 func (c *Controller) updateStreamUsage(modelID, fullResponse string, usage *model.Usage) {
 	stats := streamUsageStats(modelID, fullResponse, usage, c.modelMgr)
 	c.app.SetTokenCount(stats.tokens, stats.costCents)
+	c.telemetryBridge.AddSessionCost(stats.costCents / 100)
+}
+
+// recordCost adds one model call to the session's cost tracker when
+// cost_management.hard_stop is enabled. The call's response is already paid
+// for, so it is kept; enforceHardStop stops the turn before the next call.
+func (c *Controller) recordCost(sess *SessionState, modelID string, usage model.Usage) {
+	tracker := c.sessionCostTracker(sess)
+	if tracker == nil {
+		return
+	}
+	// Persistence failures still update the in-memory totals enforceHardStop reads.
+	_, _ = tracker.RecordAPICall(modelID, usage.PromptTokens, usage.CompletionTokens)
+}
+
+// enforceHardStop cancels the session's stream and explains why when hard
+// stop is enabled and a budget is exhausted.
+func (c *Controller) enforceHardStop(sess *SessionState) bool {
+	tracker := c.sessionCostTracker(sess)
+	if tracker == nil {
+		return false
+	}
+	err := tracker.CheckBudget().Err()
+	if err == nil {
+		return false
+	}
+	c.mu.Lock()
+	cancel := sess.Cancel
+	c.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	c.app.AddMessage(fmt.Sprintf("⛔ Stopped: %v (cost_management.hard_stop)", err), "system")
+	return true
+}
+
+// sessionCostTracker returns the hard-stop tracker for sess, creating it on
+// first use. It is nil when hard stop is off or costs cannot be tracked.
+func (c *Controller) sessionCostTracker(sess *SessionState) *cost.Tracker {
+	if sess == nil || c.cfg == nil || !c.cfg.CostManagement.HardStop || c.store == nil || c.modelMgr == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if sess.CostTracker != nil {
+		return sess.CostTracker
+	}
+	var tracker *cost.Tracker
+	var err error
+	if c.store.Ephemeral() {
+		// Keep this session's calls in memory; nothing may be written.
+		tracker, err = cost.NewRunTracker(c.store, c.modelMgr)
+	} else {
+		tracker, err = cost.New(sess.ID, c.store, c.modelMgr)
+	}
+	if err != nil {
+		return nil
+	}
+	budgets := c.cfg.CostManagement
+	tracker.SetBudgets(budgets.SessionBudget, budgets.DailyBudget, budgets.MonthlyBudget, budgets.AutoStopAt)
+	sess.CostTracker = tracker
+	return tracker
 }
 
 type streamUsage struct {
@@ -121,3 +191,122 @@ func (c *Controller) emitStreaming(sessionID string, streaming bool) {
 		SessionID: sessionID,
 	})
 }
+
+const (
+	continuePrompt         = "Continue exactly where your previous response was cut off. Do not repeat text you already wrote."
+	continueAfterToolsNote = " Results for the tools you already ran are in the conversation; do not repeat completed tool calls."
+	continueToolCallNote   = " If a tool call was cut off, issue it again in full."
+)
+
+// continueTruncatedResponse asks the model to resume the last response when
+// it stopped at the output token limit.
+func (c *Controller) continueTruncatedResponse() {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if sess.Streaming {
+		c.mu.Unlock()
+		c.app.AddMessage("A response is still in progress. Wait for it to finish or use /cancel first.", "system")
+		return
+	}
+	prompt, ok := buildContinuePrompt(sess.Conversation.Messages)
+	c.mu.Unlock()
+	if !ok {
+		c.app.AddMessage("The last response was not truncated; nothing to continue.", "system")
+		return
+	}
+	c.submitPrompt(prompt, false)
+}
+
+// buildContinuePrompt returns the follow-up prompt for resuming the latest
+// assistant reply, or false when that reply was not truncated. Tool calls made
+// earlier in the same turn are called out so the model does not rerun them.
+func buildContinuePrompt(messages []conversation.Message) (string, bool) {
+	last := -1
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "assistant" {
+			last = i
+			break
+		}
+		if messages[i].Role == "user" {
+			return "", false
+		}
+	}
+	if last < 0 || !messages[last].IsTruncated {
+		return "", false
+	}
+
+	if len(messages[last].ToolCalls) > 0 {
+		return continuePrompt + continueToolCallNote, true
+	}
+	for i := last - 1; i >= 0 && messages[i].Role != "user"; i-- {
+		if len(messages[i].ToolCalls) > 0 {
+			return continuePrompt + continueAfterToolsNote, true
+		}
+	}
+	return continuePrompt, true
+}
+
+// retryLastTurn drops the latest prompt and everything the model produced
+// for it, then sends the same prompt again.
+func (c *Controller) retryLastTurn() {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	sess := c.sessions[c.currentSession]
+	if sess.Streaming {
+		c.mu.Unlock()
+		c.app.AddMessage("A response is still in progress. Wait for it to finish or use /cancel first.", "system")
+		return
+	}
+	if sess.Compacting {
+		c.mu.Unlock()
+		c.app.AddMessage("Context compaction is running. Wait for it to finish before retrying.", "system")
+		return
+	}
+	start, prompt, ok := retryTurnStart(sess.Conversation.Messages)
+	if !ok {
+		c.mu.Unlock()
+		c.app.AddMessage("No prompt to retry.", "system")
+		return
+	}
+	sess.Conversation.Messages = sess.Conversation.Messages[:start]
+	sess.Conversation.UpdateTokenCount()
+	var err error
+	if c.store != nil {
+		err = sess.Conversation.SaveAllMessages(c.store)
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		c.app.AddMessage("Error saving chat turn: "+err.Error(), "system")
+	}
+	c.submitPrompt(prompt, false)
+}
+
+// retryTurnStart returns the index of the latest user prompt and its text.
+// Attached files are user messages too; a file attached after the prompt
+// belongs to the next turn, so there is nothing safe to retry.
+func retryTurnStart(messages []conversation.Message) (int, string, bool) {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != "user" {
+			continue
+		}
+		if messages[i].Attachment != nil {
+			return 0, "", false
+		}
+		prompt := conversation.GetContentAsString(messages[i].Content)
+		if strings.TrimSpace(prompt) == "" {
+			return 0, "", false
+		}
+		return i, prompt, true
+	}
+	return 0, "", false
+}
@@ -1,8 +1,10 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
+	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/model"
 )
 
@@ -44,3 +46,151 @@ func TestStoredToolResultProgressSummaryIncludesPersistedError(t *testing.T) {
 		t.Fatalf("unexpected progress summary: %q", got)
 	}
 }
+
+func TestBuildContinuePrompt(t *testing.T) {
+	toolCall := []model.ToolCall{{ID: "call-1", Function: model.FunctionCall{Name: "read_file"}}}
+	tests := []struct {
+		name     string
+		messages []conversation.Message
+		ok       bool
+		contains string
+		excludes string
+	}{
+		{name: "empty conversation"},
+		{
+			name: "complete reply",
+			messages: []conversation.Message{
+				{Role: "user", Content: "explain"},
+				{Role: "assistant", Content: "done"},
+			},
+		},
+		{
+			name: "awaiting reply to newer prompt",
+			messages: []conversation.Message{
+				{Role: "assistant", Content: "partial", IsTruncated: true},
+				{Role: "user", Content: "something else"},
+			},
+		},
+		{
+			name: "truncated text reply",
+			messages: []conversation.Message{
+				{Role: "user", Content: "write a long doc"},
+				{Role: "assistant", Content: "part one", IsTruncated: true},
+			},
+			ok:       true,
+			contains: "Do not repeat text",
+			excludes: "tool",
+		},
+		{
+			name: "truncated after tool calls in the same turn",
+			messages: []conversation.Message{
+				{Role: "user", Content: "summarize the file"},
+				{Role: "assistant", ToolCalls: toolCall},
+				{Role: "tool", ToolCallID: "call-1", Content: "contents"},
+				{Role: "assistant", Content: "summary so far", IsTruncated: true},
+			},
+			ok:       true,
+			contains: "do not repeat completed tool calls",
+		},
+		{
+			name: "tool calls from an earlier turn are ignored",
+			messages: []conversation.Message{
+				{Role: "user", Content: "read it"},
+				{Role: "assistant", ToolCalls: toolCall},
+				{Role: "tool", ToolCallID: "call-1", Content: "contents"},
+				{Role: "assistant", Content: "read"},
+				{Role: "user", Content: "now write a long doc"},
+				{Role: "assistant", Content: "part one", IsTruncated: true},
+			},
+			ok:       true,
+			excludes: "tool",
+		},
+		{
+			name: "truncated mid tool call",
+			messages: []conversation.Message{
+				{Role: "user", Content: "edit it"},
+				{Role: "assistant", ToolCalls: toolCall, IsTruncated: true},
+			},
+			ok:       true,
+			contains: "issue it again in full",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := buildContinuePrompt(tt.messages)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v (prompt %q)", ok, tt.ok, got)
+			}
+			if !ok {
+				return
+			}
+			if !strings.HasPrefix(got, continuePrompt) {
+				t.Fatalf("prompt = %q, want base continue prompt", got)
+			}
+			if tt.contains != "" && !strings.Contains(got, tt.contains) {
+				t.Fatalf("prompt = %q, want %q", got, tt.contains)
+			}
+			if tt.excludes != "" && strings.Contains(got, tt.excludes) {
+				t.Fatalf("prompt = %q, should not mention %q", got, tt.excludes)
+			}
+		})
+	}
+}
+
+func TestRetryTurnStart(t *testing.T) {
+	toolCall := []model.ToolCall{{ID: "call-1", Function: model.FunctionCall{Name: "read_file"}}}
+	tests := []struct {
+		name     string
+		messages []conversation.Message
+		start    int
+		prompt   string
+		ok       bool
+	}{
+		{name: "empty conversation"},
+		{
+			name:     "only a system message",
+			messages: []conversation.Message{{Role: "system", Content: "be brief"}},
+		},
+		{
+			name: "prompt whose reply failed",
+			messages: []conversation.Message{
+				{Role: "user", Content: "first"},
+				{Role: "assistant", Content: "one"},
+				{Role: "user", Content: "second"},
+			},
+			start:  2,
+			prompt: "second",
+			ok:     true,
+		},
+		{
+			name: "reply with tool calls is dropped with the prompt",
+			messages: []conversation.Message{
+				{Role: "user", Content: "summarize the file"},
+				{Role: "assistant", ToolCalls: toolCall},
+				{Role: "tool", ToolCallID: "call-1", Content: "contents"},
+				{Role: "assistant", Content: "partial", IsTruncated: true},
+			},
+			start:  0,
+			prompt: "summarize the file",
+			ok:     true,
+		},
+		{
+			name: "file attached after the prompt",
+			messages: []conversation.Message{
+				{Role: "user", Content: "explain"},
+				{Role: "assistant", Content: "done"},
+				{Role: "user", Content: "file body", Attachment: &conversation.Attachment{Path: "a.go"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, prompt, ok := retryTurnStart(tt.messages)
+			if ok != tt.ok || start != tt.start || prompt != tt.prompt {
+				t.Fatalf("retryTurnStart = (%d, %q, %v), want (%d, %q, %v)", start, prompt, ok, tt.start, tt.prompt, tt.ok)
+			}
+		})
+	}
+}
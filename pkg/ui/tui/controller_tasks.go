@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const tasksUsage = "Usage: /tasks [cancel <id>]"
+
+const (
+	taskKindResponse     = "response"
+	taskKindCompaction   = "compaction"
+	taskKindModelCompare = "model compare"
+)
+
+// backgroundTask is a running operation listed by /tasks.
+type backgroundTask struct {
+	ID        int
+	Kind      string
+	SessionID string
+	Label     string
+	Started   time.Time
+	cancel    func()
+}
+
+// startTaskLocked registers a running operation and returns its /tasks ID.
+// cancel may be nil for work that cannot be stopped. Callers must hold c.mu.
+func (c *Controller) startTaskLocked(kind, sessionID, label string, cancel func()) int {
+	if c.tasks == nil {
+		c.tasks = make(map[int]*backgroundTask)
+	}
+	c.nextTaskID++
+	c.tasks[c.nextTaskID] = &backgroundTask{
+		ID:        c.nextTaskID,
+		Kind:      kind,
+		SessionID: sessionID,
+		Label:     label,
+		Started:   time.Now(),
+		cancel:    cancel,
+	}
+	return c.nextTaskID
+}
+
+func (c *Controller) finishTask(id int) {
+	c.mu.Lock()
+	delete(c.tasks, id)
+	c.mu.Unlock()
+}
+
+// streamTaskCancel stops a session's response the way /cancel does, dropping
+// queued messages so the session does not start the next one.
+func (c *Controller) streamTaskCancel(sess *SessionState, cancel func()) func() {
+	if cancel == nil {
+		return nil
+	}
+	return func() {
+		c.mu.Lock()
+		sess.MessageQueue = nil
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// handleTasksCommand lists running background operations across all
+// sessions, or cancels one by the ID shown in the list.
+func (c *Controller) handleTasksCommand(args []string) {
+	c.mu.Lock()
+	tasks := make([]backgroundTask, 0, len(c.tasks))
+	for _, task := range c.tasks {
+		tasks = append(tasks, *task)
+	}
+	c.mu.Unlock()
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	if len(args) == 0 || (len(args) == 1 && strings.EqualFold(args[0], "list")) {
+		c.app.AddMessage(formatTaskList(tasks, time.Now()), "system")
+		return
+	}
+	if len(args) != 2 || !strings.EqualFold(args[0], "cancel") {
+		c.app.AddMessage(tasksUsage, "system")
+		return
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(args[1], "#"))
+	if err != nil {
+		c.app.AddMessage(tasksUsage, "system")
+		return
+	}
+	for _, task := range tasks {
+		if task.ID != id {
+			continue
+		}
+		if task.cancel == nil {
+			c.app.AddMessage(fmt.Sprintf("Task %d (%s) cannot be cancelled.", id, task.Kind), "system")
+			return
+		}
+		task.cancel()
+		c.app.AddMessage(fmt.Sprintf("Cancelling task %d (%s in %s).", id, task.Kind, task.SessionID), "system")
+		return
+	}
+	c.app.AddMessage(fmt.Sprintf("No running task %d. Run /tasks to list them.", id), "system")
+}
+
+func formatTaskList(tasks []backgroundTask, now time.Time) string {
+	if len(tasks) == 0 {
+		return "No background tasks are running."
+	}
+	var b strings.Builder
+	b.WriteString("Background tasks:\n")
+	for _, task := range tasks {
+		fmt.Fprintf(&b, "  %d. %s in %s (%s)", task.ID, task.Kind, task.SessionID, formatProcessElapsed(now.Sub(task.Started)))
+		if task.Label != "" {
+			fmt.Fprintf(&b, ": %s", task.Label)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\nUse /tasks cancel <id> to stop one.")
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/fluffyui/backend/sim"
+)
+
+func TestFormatTaskList(t *testing.T) {
+	if got := formatTaskList(nil, time.Now()); got != "No background tasks are running." {
+		t.Fatalf("empty list = %q", got)
+	}
+
+	now := time.Now()
+	got := formatTaskList([]backgroundTask{
+		{ID: 1, Kind: taskKindResponse, SessionID: "s-1", Label: "fix the parser", Started: now.Add(-75 * time.Second)},
+		{ID: 3, Kind: taskKindCompaction, SessionID: "s-2", Started: now.Add(-2 * time.Second)},
+	}, now)
+	for _, want := range []string{
+		"1. response in s-1 (1m15s): fix the parser",
+		"3. compaction in s-2 (2s)",
+		"/tasks cancel <id>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("task list missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestHandleTasksCommand_CancelsOnlyTargetedTask(t *testing.T) {
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	streamCtx, streamCancel := context.WithCancel(context.Background())
+	t.Cleanup(streamCancel)
+	compactCtx, compactCancel := context.WithCancel(context.Background())
+	t.Cleanup(compactCancel)
+	sess := &SessionState{
+		ID:           "session-1",
+		Conversation: conversation.New("session-1"),
+		Streaming:    true,
+		Cancel:       streamCancel,
+		MessageQueue: []QueuedMessage{{Content: "next"}},
+	}
+	ctrl := &Controller{app: app, sessions: []*SessionState{sess}}
+
+	ctrl.mu.Lock()
+	streamID := ctrl.startTaskLocked(taskKindResponse, sess.ID, "prompt", ctrl.streamTaskCancel(sess, sess.Cancel))
+	compactID := ctrl.startTaskLocked(taskKindCompaction, "session-2", "", compactCancel)
+	fixedID := ctrl.startTaskLocked(taskKindModelCompare, "session-3", "", nil)
+	ctrl.mu.Unlock()
+
+	ctrl.handleTasksCommand([]string{"cancel", "#2"})
+	if compactCtx.Err() == nil {
+		t.Fatalf("task %d was not cancelled", compactID)
+	}
+	if streamCtx.Err() != nil {
+		t.Fatal("cancelling the compaction also cancelled the response")
+	}
+
+	ctrl.handleTasksCommand([]string{"cancel", "1"})
+	if streamCtx.Err() == nil {
+		t.Fatalf("task %d was not cancelled", streamID)
+	}
+	if len(sess.MessageQueue) != 0 {
+		t.Fatalf("cancelling a response kept queued messages: %#v", sess.MessageQueue)
+	}
+
+	// Unknown and non-cancellable tasks are reported without side effects.
+	ctrl.handleTasksCommand([]string{"cancel", "99"})
+	ctrl.handleTasksCommand([]string{"cancel", "3"})
+
+	ctrl.finishTask(streamID)
+	ctrl.finishTask(compactID)
+	if len(ctrl.tasks) != 1 || ctrl.tasks[fixedID] == nil {
+		t.Fatalf("tasks after finish = %#v, want only task %d", ctrl.tasks, fixedID)
+	}
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"m31labs.dev/buckley/pkg/tool"
+)
+
+var toolApprovalSeq atomic.Int64
+
+// toolApprovals shows tools.dangerous calls in the approval dialog and hands
+// the decision back to the tool call waiting on it.
+type toolApprovals struct {
+	request func(ApprovalRequestMsg)
+
+	mu      sync.Mutex
+	pending map[string]chan bool
+}
+
+func newToolApprovals(request func(ApprovalRequestMsg)) *toolApprovals {
+	return &toolApprovals{request: request, pending: make(map[string]chan bool)}
+}
+
+// approve is the registry's tool.ApprovalFunc. It blocks the tool loop, never
+// the UI goroutine, until the dialog is answered or ctx is cancelled.
+func (t *toolApprovals) approve(ctx context.Context, name string, params map[string]any) (bool, error) {
+	id := fmt.Sprintf("tool-approval-%d", toolApprovalSeq.Add(1))
+	decision := make(chan bool, 1)
+	t.mu.Lock()
+	t.pending[id] = decision
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+	}()
+
+	t.request(dangerousToolApprovalRequest(id, name, params))
+	select {
+	case approved := <-decision:
+		return approved, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// resolve delivers a dialog decision and reports whether id was a pending
+// tool approval.
+func (t *toolApprovals) resolve(id string, approved bool) bool {
+	t.mu.Lock()
+	decision, ok := t.pending[id]
+	delete(t.pending, id)
+	t.mu.Unlock()
+	if ok {
+		decision <- approved
+	}
+	return ok
+}
+
+// attachToolApprover routes the session's dangerous tool calls through the
+// approval dialog.
+func (c *Controller) attachToolApprover(sess *SessionState) {
+	if c == nil || c.toolApprovals == nil || sess == nil || sess.ToolRegistry == nil {
+		return
+	}
+	sess.ToolRegistry.SetApprover(c.toolApprovals.approve)
+}
+
+// dangerousToolApprovalRequest shows the call's arguments as context lines.
+func dangerousToolApprovalRequest(id, name string, params map[string]any) ApprovalRequestMsg {
+	req := ApprovalRequestMsg{
+		ID:          id,
+		Tool:        name,
+		Operation:   "dangerous",
+		Description: fmt.Sprintf("%s is listed in tools.dangerous and needs approval for every call", name),
+	}
+	args := make(map[string]any, len(params))
+	for key, value := range params {
+		if key != tool.ToolCallIDParam {
+			args[key] = value
+		}
+	}
+	if command, ok := args["command"].(string); ok {
+		req.Command = command
+	}
+	if path, ok := args["path"].(string); ok {
+		req.FilePath = path
+	}
+	if len(args) == 0 {
+		return req
+	}
+	data, err := json.MarshalIndent(args, "", "  ")
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", args))
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		req.DiffLines = append(req.DiffLines, DiffLine{Type: DiffContext, Content: line})
+	}
+	return req
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/config"
+	"m31labs.dev/buckley/pkg/model"
+	"m31labs.dev/buckley/pkg/tool"
+	"m31labs.dev/buckley/pkg/tool/builtin"
+)
+
+func TestDangerousToolCallWaitsForApprovalDialog(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Tools.Dangerous = []string{"git_status"}
+	var mu sync.Mutex
+	var order []string
+	var live, peak int
+	registry := tool.NewEmptyRegistry()
+	registry.Register(&parallelLoopTool{name: "git_status", mu: &mu, order: &order, live: &live, peak: &peak})
+	tool.ApplyToolPolicyConfig(registry, cfg)
+
+	requests := make(chan ApprovalRequestMsg, 1)
+	ctrl := &Controller{cfg: cfg, toolApprovals: newToolApprovals(func(req ApprovalRequestMsg) { requests <- req })}
+	sess := &SessionState{ID: "s", ToolRegistry: registry}
+	ctrl.attachToolApprover(sess)
+
+	call := model.ToolCall{Function: model.FunctionCall{Name: "git_status", Arguments: `{}`}}
+	if ctrl.toolLoopCallParallelSafe(sess, call, nil) {
+		t.Fatal("a dangerous tool was batched; its approval dialogs would overlap")
+	}
+
+	for _, approve := range []bool{false, true} {
+		done := make(chan *builtin.Result, 1)
+		go func() {
+			res, _ := registry.ExecuteWithContext(context.Background(), "git_status", map[string]any{"id": "x", tool.ToolCallIDParam: "call-1"})
+			done <- res
+		}()
+
+		var req ApprovalRequestMsg
+		select {
+		case req = <-requests:
+		case <-time.After(2 * time.Second):
+			t.Fatal("no approval dialog requested")
+		}
+		if req.Tool != "git_status" || len(req.DiffLines) != 3 || req.DiffLines[1].Content != `  "id": "x"` {
+			t.Fatalf("approval request = %+v, want the call arguments without the call ID", req)
+		}
+		ctrl.handleApproval(req.ID, approve, false)
+
+		select {
+		case res := <-done:
+			if res.Success != approve {
+				t.Fatalf("approve=%v: result = %+v", approve, res)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("tool call did not finish after the dialog was answered")
+		}
+	}
+	if len(order) != 1 {
+		t.Fatalf("tool ran %d times, want only the approved call", len(order))
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -67,3 +68,52 @@ func TestTruncateModelToolOutput_PreservesHeadAndTail(t *testing.T) {
 		t.Fatalf("tail was not preserved: %s", got[max(0, len(got)-32):])
 	}
 }
+
+func TestFormatStoredToolResultForModel_MarksAndStoresTruncatedOutput(t *testing.T) {
+	result := &builtin.Result{
+		Success: true,
+		Data:    map[string]any{"content": "HEAD-" + strings.Repeat("middle ", 10_000) + "-TAIL"},
+	}
+	outputs := builtin.NewToolOutputStore(0)
+
+	got := formatStoredToolResultForModel(result, nil, "call_9", outputs)
+	if len(got) > defaultTUIToolModelMaxBytes {
+		t.Fatalf("model output = %d bytes, want <= %d", len(got), defaultTUIToolModelMaxBytes)
+	}
+	if !strings.Contains(got, `tool call "call_9"`) || !strings.Contains(got, "read_tool_output") {
+		t.Fatalf("truncation marker has no fetch handle: %s", got[:min(len(got), 200)])
+	}
+	full, ok := outputs.Get("call_9")
+	if !ok {
+		t.Fatal("full output was not stored")
+	}
+
+	// The marker's offset is where the omitted part begins in the stored output.
+	var offset, omitted int
+	markerStart := strings.Index(got, "\n\n... tool output truncated")
+	if _, err := fmt.Sscanf(got[markerStart:], "\n\n... tool output truncated for chat context (tool call \"call_9\", %d bytes omitted). The full output is stored: call read_tool_output with this tool_call_id and offset %d", &omitted, &offset); err != nil {
+		t.Fatalf("parse marker: %v", err)
+	}
+	if offset != markerStart || got[:offset] != full[:offset] {
+		t.Fatalf("offset %d does not match the %d-byte head", offset, markerStart)
+	}
+	if !strings.HasSuffix(got, full[offset+omitted:]) {
+		t.Fatal("head, omitted, and tail do not cover the stored output")
+	}
+
+	read, err := (&builtin.ReadToolOutputTool{Store: outputs}).Execute(map[string]any{"tool_call_id": "call_9", "offset": offset})
+	if err != nil || !read.Success {
+		t.Fatalf("read_tool_output = %+v, %v", read, err)
+	}
+	if content := read.Data["content"].(string); !strings.HasPrefix(full[offset:], content) || content == "" {
+		t.Fatalf("read_tool_output returned %q, want the start of the omitted part", content[:min(len(content), 40)])
+	}
+
+	small := formatStoredToolResultForModel(&builtin.Result{Success: true, Data: map[string]any{"ok": true}}, nil, "call_10", outputs)
+	if strings.Contains(small, "truncated") {
+		t.Fatalf("small output was truncated: %s", small)
+	}
+	if _, ok := outputs.Get("call_10"); ok {
+		t.Fatal("untruncated output should not be stored")
+	}
+}
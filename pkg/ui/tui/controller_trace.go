@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"m31labs.dev/buckley/pkg/conversation"
+)
+
+const (
+	traceReasoningMaxBytes = 600
+	traceArgumentsMaxBytes = 240
+	traceResultMaxBytes    = 240
+)
+
+// turnTrace is the reasoning -> tool call -> result sequence for one user turn.
+type turnTrace struct {
+	Prompt string
+	Steps  []turnTraceStep
+	Answer string
+}
+
+// turnTraceStep is one assistant tool-call message and the results it got.
+type turnTraceStep struct {
+	Reasoning string
+	Calls     []turnTraceCall
+}
+
+type turnTraceCall struct {
+	ID        string
+	Name      string
+	Arguments string
+	Result    string
+	HasResult bool
+}
+
+func (c *Controller) showTurnTrace() {
+	c.mu.Lock()
+	if len(c.sessions) == 0 {
+		c.mu.Unlock()
+		c.app.AddMessage("No active session.", "system")
+		return
+	}
+	messages := cloneMessages(c.sessions[c.currentSession].Conversation.Messages)
+	c.mu.Unlock()
+
+	trace, ok := buildLastTurnTrace(messages)
+	if !ok {
+		c.app.AddMessage("No turns to trace yet.", "system")
+		return
+	}
+	c.app.AddMessage(formatTurnTrace(trace), "system")
+}
+
+// buildLastTurnTrace assembles the trace for the messages after the most
+// recent user prompt, pairing each tool call with its result by call ID.
+func buildLastTurnTrace(messages []conversation.Message) (turnTrace, bool) {
+	start := -1
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" && !messages[i].IsSummary {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return turnTrace{}, false
+	}
+
+	trace := turnTrace{Prompt: conversation.GetContentAsString(messages[start].Content)}
+	callIndex := make(map[string]*turnTraceCall)
+	for _, msg := range messages[start+1:] {
+		switch {
+		case msg.Role == "assistant" && len(msg.ToolCalls) > 0:
+			step := turnTraceStep{Reasoning: strings.TrimSpace(msg.Reasoning)}
+			for _, tc := range msg.ToolCalls {
+				step.Calls = append(step.Calls, turnTraceCall{
+					ID:        tc.ID,
+					Name:      tc.Function.Name,
+					Arguments: tc.Function.Arguments,
+				})
+			}
+			trace.Steps = append(trace.Steps, step)
+			last := &trace.Steps[len(trace.Steps)-1]
+			for i := range last.Calls {
+				if last.Calls[i].ID != "" {
+					callIndex[last.Calls[i].ID] = &last.Calls[i]
+				}
+			}
+		case msg.Role == "tool":
+			if call, ok := callIndex[msg.ToolCallID]; ok {
+				call.Result = conversation.GetContentAsString(msg.Content)
+				call.HasResult = true
+			}
+		case msg.Role == "assistant":
+			trace.Answer = conversation.GetContentAsString(msg.Content)
+		}
+	}
+	return trace, true
+}
+
+func formatTurnTrace(trace turnTrace) string {
+	var b strings.Builder
+	b.WriteString("Trace of the last turn:\n")
+	b.WriteString("Prompt: " + truncatePreview(oneLine(trace.Prompt), 180) + "\n")
+	if len(trace.Steps) == 0 {
+		b.WriteString("\nNo tool calls in this turn.")
+	}
+	for i, step := range trace.Steps {
+		b.WriteString(fmt.Sprintf("\n%d. Reasoning: %s\n", i+1, emptyAs(truncatePreview(oneLine(step.Reasoning), traceReasoningMaxBytes), "(not provided by the model)")))
+		for _, call := range step.Calls {
+			b.WriteString("   -> " + emptyAs(call.Name, "tool") + " " + truncatePreview(oneLine(call.Arguments), traceArgumentsMaxBytes) + "\n")
+			if call.HasResult {
+				b.WriteString("      <- " + emptyAs(truncatePreview(oneLine(call.Result), traceResultMaxBytes), "(empty result)") + "\n")
+			} else {
+				b.WriteString("      <- (no result recorded)\n")
+			}
+		}
+	}
+	if strings.TrimSpace(trace.Answer) != "" {
+		b.WriteString("\nAnswer: " + truncatePreview(oneLine(trace.Answer), 180))
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"m31labs.dev/buckley/pkg/conversation"
+	"m31labs.dev/buckley/pkg/model"
+)
+
+func traceToolCall(id, name, args string) model.ToolCall {
+	call := model.ToolCall{ID: id, Type: "function"}
+	call.Function.Name = name
+	call.Function.Arguments = args
+	return call
+}
+
+func TestBuildLastTurnTrace_PairsReasoningCallsAndResults(t *testing.T) {
+	conv := conversation.New("trace")
+	conv.AddUserMessage("old prompt")
+	conv.AddToolCallMessageWithReasoning([]model.ToolCall{traceToolCall("old", "read_file", `{"path":"old.go"}`)}, "old reasoning", nil)
+	conv.AddToolResponseMessage("old", "read_file", "old result")
+	conv.AddAssistantMessage("old answer")
+
+	conv.AddUserMessage("Why does the build fail?")
+	conv.AddToolCallMessageWithReasoning([]model.ToolCall{
+		traceToolCall("c1", "run_shell", `{"command":"go build ./..."}`),
+		traceToolCall("c2", "read_file", `{"path":"main.go"}`),
+	}, "Build first, then read the failing file.", nil)
+	conv.AddToolResponseMessage("c2", "read_file", "package main")
+	conv.AddToolResponseMessage("c1", "run_shell", "main.go:3: undefined: foo")
+	conv.AddToolCallMessageWithReasoning([]model.ToolCall{traceToolCall("c3", "search_text", `{"pattern":"foo"}`)}, "", nil)
+	conv.AddAssistantMessage("foo was never declared.")
+
+	trace, ok := buildLastTurnTrace(conv.Messages)
+	if !ok {
+		t.Fatal("expected a trace for the last turn")
+	}
+	if trace.Prompt != "Why does the build fail?" {
+		t.Fatalf("prompt = %q, want last user prompt", trace.Prompt)
+	}
+	if len(trace.Steps) != 2 {
+		t.Fatalf("steps = %d, want 2", len(trace.Steps))
+	}
+	first := trace.Steps[0]
+	if first.Reasoning != "Build first, then read the failing file." || len(first.Calls) != 2 {
+		t.Fatalf("first step = %+v", first)
+	}
+	if first.Calls[0].Name != "run_shell" || first.Calls[0].Result != "main.go:3: undefined: foo" {
+		t.Fatalf("run_shell call = %+v, want its own result", first.Calls[0])
+	}
+	if first.Calls[1].Name != "read_file" || first.Calls[1].Result != "package main" {
+		t.Fatalf("read_file call = %+v, want its own result", first.Calls[1])
+	}
+	if second := trace.Steps[1]; second.Reasoning != "" || second.Calls[0].HasResult {
+		t.Fatalf("second step = %+v, want no reasoning and no result", second)
+	}
+	if trace.Answer != "foo was never declared." {
+		t.Fatalf("answer = %q", trace.Answer)
+	}
+}
+
+func TestBuildLastTurnTrace_NoUserMessage(t *testing.T) {
+	conv := conversation.New("trace")
+	conv.AddSystemMessage("system prompt")
+	if _, ok := buildLastTurnTrace(conv.Messages); ok {
+		t.Fatal("expected no trace without a user prompt")
+	}
+}
+
+func TestFormatTurnTrace(t *testing.T) {
+	out := formatTurnTrace(turnTrace{
+		Prompt: "Fix the test",
+		Steps: []turnTraceStep{{
+			Calls: []turnTraceCall{
+				{Name: "run_tests", Arguments: `{"pkg":"./..."}`, Result: "FAIL", HasResult: true},
+				{Name: "read_file", Arguments: `{"path":"a.go"}`},
+			},
+		}},
+		Answer: "Fixed.",
+	})
+	for _, want := range []string{
+		"Prompt: Fix the test",
+		"1. Reasoning: (not provided by the model)",
+		`-> run_tests {"pkg":"./..."}`,
+		"<- FAIL",
+		"<- (no result recorded)",
+		"Answer: Fixed.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("formatTurnTrace missing %q:\n%s", want, out)
+		}
+	}
+
+	if out := formatTurnTrace(turnTrace{Prompt: "hi", Answer: "hello"}); !strings.Contains(out, "No tool calls in this turn.") {
+		t.Fatalf("expected no-tool-call note:\n%s", out)
+	}
+}
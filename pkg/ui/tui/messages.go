@@ -106,6 +106,11 @@ type RefreshMsg struct{}
 
 func (RefreshMsg) isMessage() {}
 
+// ScrollTopMsg signals that the chat view was scrolled to its first line.
+type ScrollTopMsg struct{}
+
+func (ScrollTopMsg) isMessage() {}
+
 // --- UI Events ---
 
 // StatusMsg updates the status bar text.
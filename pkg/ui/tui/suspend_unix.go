@@ -0,0 +1,33 @@
+//go:build !windows
+
+package tui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const suspendSupported = true
+
+func notifySuspendSignals(sigCh chan<- os.Signal) {
+	signal.Notify(sigCh, syscall.SIGTSTP)
+}
+
+func stopSuspendSignals(sigCh chan<- os.Signal) {
+	signal.Stop(sigCh)
+}
+
+// stopProcessUntilContinued stops the process group the way the shell's
+// Ctrl+Z would and blocks until SIGCONT (fg/bg) resumes it.
+func stopProcessUntilContinued() error {
+	cont := make(chan os.Signal, 1)
+	signal.Notify(cont, syscall.SIGCONT)
+	defer signal.Stop(cont)
+
+	if err := syscall.Kill(0, syscall.SIGTSTP); err != nil {
+		return err
+	}
+	<-cont
+	return nil
+}
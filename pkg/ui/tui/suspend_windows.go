@@ -0,0 +1,18 @@
+//go:build windows
+
+package tui
+
+import (
+	"errors"
+	"os"
+)
+
+const suspendSupported = false
+
+func notifySuspendSignals(sigCh chan<- os.Signal) {}
+
+func stopSuspendSignals(sigCh chan<- os.Signal) {}
+
+func stopProcessUntilContinued() error {
+	return errors.New("suspend is not supported on windows")
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -36,6 +37,12 @@ type TelemetryUIBridge struct {
 	experimentVariants map[string]widgets.ExperimentVariant
 	rlmStatus          *widgets.RLMStatus
 	rlmScratchpad      []widgets.RLMScratchpadEntry
+
+	// Health signals
+	recentErrors  []time.Time
+	circuitState  string
+	sessionSpent  float64
+	sessionBudget float64
 }
 
 type touchEntry struct {
@@ -99,6 +106,8 @@ func (b *TelemetryUIBridge) handleEvent(event telemetry.Event) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	b.recordHealthEvent(event)
+
 	switch event.Type {
 	// Task events
 	case telemetry.EventTaskStarted:
@@ -167,7 +176,44 @@ func (b *TelemetryUIBridge) handleEvent(event telemetry.Event) {
 		b.handleExperimentVariant(event, "failed")
 	case telemetry.EventRLMIteration:
 		b.handleRLMIteration(event)
+
+	// Model events
+	case telemetry.EventModelFallback:
+		b.handleModelFallback(event)
+
+	// MCP events
+	case telemetry.EventMCPServerState:
+		b.handleMCPServerState(event)
+	}
+}
+
+// handleMCPServerState tells the user an MCP server went down or recovered.
+func (b *TelemetryUIBridge) handleMCPServerState(event telemetry.Event) {
+	if b.app == nil {
+		return
+	}
+	server := getString(event.Data, "server")
+	if connected, _ := event.Data["connected"].(bool); connected {
+		b.app.SetStatus("MCP " + server + " reconnected")
+	} else {
+		b.app.SetStatus("MCP " + server + " down")
+	}
+	b.app.AddMessage(mcpServerStateMessage(event.Data), "system")
+}
+
+// handleModelFallback tells the user a request was answered by a fallback
+// model because the requested model's provider failed.
+func (b *TelemetryUIBridge) handleModelFallback(event telemetry.Event) {
+	if b.app == nil {
+		return
+	}
+	from := getString(event.Data, "from")
+	if from == "" {
+		from = "the requested model"
 	}
+	to := getString(event.Data, "to")
+	b.app.SetStatus("Falling back to " + to)
+	b.app.AddMessage(fmt.Sprintf("%s is unavailable (%s); retrying with fallback model %s.", from, truncate(getString(event.Data, "error"), 120), to), "system")
 }
 
 func (b *TelemetryUIBridge) handleSubagentActive(event telemetry.Event) {
@@ -358,6 +404,7 @@ func (b *TelemetryUIBridge) updateSidebar() {
 	experimentVariants := b.collectExperimentVariants()
 
 	// Post updates to app (thread-safe)
+	b.app.sidebar.SetHealth(b.healthSummary(time.Now()))
 	b.app.SetCurrentTask(b.currentTask, b.taskProgress)
 	b.app.SetPlanTasks(b.planTasks)
 	b.app.SetRunningTools(tools)
@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"m31labs.dev/buckley/pkg/telemetry"
+	"m31labs.dev/buckley/pkg/ui/widgets"
+)
+
+const (
+	// healthErrorWindow bounds how long a failure counts against health.
+	healthErrorWindow = 5 * time.Minute
+	// healthDegradedErrors and healthFailingErrors are recent-failure
+	// thresholds for the degraded and error states.
+	healthDegradedErrors = 1
+	healthFailingErrors  = 3
+	// healthBudgetWarnFraction is the share of the session budget that
+	// marks the session as degraded.
+	healthBudgetWarnFraction = 0.8
+)
+
+// healthSignals are the inputs the sidebar health status is computed from.
+type healthSignals struct {
+	RecentErrors int
+	CircuitState string  // Provider circuit breaker state: closed, half-open, or open
+	Spent        float64 // Session spend in USD
+	Budget       float64 // Session budget in USD; 0 disables the budget check
+}
+
+// computeHealth derives an at-a-glance health status. The worst signal wins.
+func computeHealth(signals healthSignals) widgets.HealthSummary {
+	summary := widgets.HealthSummary{Status: widgets.HealthOK}
+	raise := func(status widgets.HealthStatus, reason string) {
+		if status == widgets.HealthError || summary.Status == widgets.HealthOK {
+			summary.Status = status
+		}
+		summary.Reasons = append(summary.Reasons, reason)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(signals.CircuitState)) {
+	case "open":
+		raise(widgets.HealthError, "Provider unreachable")
+	case "half-open":
+		raise(widgets.HealthDegraded, "Provider recovering")
+	}
+
+	switch {
+	case signals.RecentErrors >= healthFailingErrors:
+		raise(widgets.HealthError, fmt.Sprintf("%d recent errors", signals.RecentErrors))
+	case signals.RecentErrors >= healthDegradedErrors:
+		raise(widgets.HealthDegraded, fmt.Sprintf("%d recent %s", signals.RecentErrors, pluralize(signals.RecentErrors, "error", "errors")))
+	}
+
+	if signals.Budget > 0 {
+		used := signals.Spent / signals.Budget
+		switch {
+		case used >= 1:
+			raise(widgets.HealthError, fmt.Sprintf("Budget exhausted ($%.2f/$%.2f)", signals.Spent, signals.Budget))
+		case used >= healthBudgetWarnFraction:
+			raise(widgets.HealthDegraded, fmt.Sprintf("Budget %.0f%% used", used*100))
+		}
+	}
+
+	return summary
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// isHealthFailureEvent reports whether an event counts as a recent error.
+func isHealthFailureEvent(eventType telemetry.EventType) bool {
+	switch eventType {
+	case telemetry.EventTaskFailed,
+		telemetry.EventBuilderFailed,
+		telemetry.EventShellCommandFailed,
+		telemetry.EventResearchFailed,
+		telemetry.EventToolFailed,
+		telemetry.EventSubagentFailed,
+		telemetry.EventCircuitFailure:
+		return true
+	}
+	return false
+}
+
+func (b *TelemetryUIBridge) recordHealthEvent(event telemetry.Event) {
+	if isHealthFailureEvent(event.Type) {
+		at := event.Timestamp
+		if at.IsZero() {
+			at = time.Now()
+		}
+		b.recentErrors = append(b.recentErrors, at)
+	}
+
+	switch event.Type {
+	case telemetry.EventCircuitStateChange:
+		b.circuitState = firstNonEmpty(getString(event.Data, "new_state"), getString(event.Data, "state"))
+		b.updateSidebar()
+	case telemetry.EventCostUpdated:
+		if total, ok := getFloat(event.Data, "total"); ok {
+			b.sessionSpent = total
+		}
+		if budget, ok := getFloat(event.Data, "budget"); ok {
+			b.sessionBudget = budget
+		}
+		b.updateSidebar()
+	}
+}
+
+// healthSummary prunes expired failures and computes the current status.
+func (b *TelemetryUIBridge) healthSummary(now time.Time) widgets.HealthSummary {
+	cutoff := now.Add(-healthErrorWindow)
+	kept := b.recentErrors[:0]
+	for _, at := range b.recentErrors {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	b.recentErrors = kept
+
+	return computeHealth(healthSignals{
+		RecentErrors: len(b.recentErrors),
+		CircuitState: b.circuitState,
+		Spent:        b.sessionSpent,
+		Budget:       b.sessionBudget,
+	})
+}
+
+// SetSessionBudget sets the session budget in USD used for health reporting.
+func (b *TelemetryUIBridge) SetSessionBudget(budget float64) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sessionBudget = budget
+}
+
+// AddSessionCost adds spend in USD to the session total used for health
+// reporting.
+func (b *TelemetryUIBridge) AddSessionCost(cost float64) {
+	if b == nil || cost <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sessionSpent += cost
+	b.updateSidebar()
+}
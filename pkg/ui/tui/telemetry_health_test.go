@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"m31labs.dev/buckley/pkg/telemetry"
+	"m31labs.dev/buckley/pkg/ui/widgets"
+)
+
+func TestComputeHealth(t *testing.T) {
+	tests := []struct {
+		name    string
+		signals healthSignals
+		want    widgets.HealthStatus
+		reasons int
+	}{
+		{name: "quiet session", signals: healthSignals{}, want: widgets.HealthOK},
+		{name: "closed circuit", signals: healthSignals{CircuitState: "closed"}, want: widgets.HealthOK},
+		{name: "single error", signals: healthSignals{RecentErrors: 1}, want: widgets.HealthDegraded, reasons: 1},
+		{name: "many errors", signals: healthSignals{RecentErrors: 3}, want: widgets.HealthError, reasons: 1},
+		{name: "provider recovering", signals: healthSignals{CircuitState: "half-open"}, want: widgets.HealthDegraded, reasons: 1},
+		{name: "provider unreachable", signals: healthSignals{CircuitState: "open"}, want: widgets.HealthError, reasons: 1},
+		{name: "budget under warning", signals: healthSignals{Spent: 5, Budget: 10}, want: widgets.HealthOK},
+		{name: "budget warning", signals: healthSignals{Spent: 8.5, Budget: 10}, want: widgets.HealthDegraded, reasons: 1},
+		{name: "budget exhausted", signals: healthSignals{Spent: 10, Budget: 10}, want: widgets.HealthError, reasons: 1},
+		{name: "no budget configured", signals: healthSignals{Spent: 100}, want: widgets.HealthOK},
+		{
+			name:    "worst signal wins",
+			signals: healthSignals{RecentErrors: 1, CircuitState: "open", Spent: 9, Budget: 10},
+			want:    widgets.HealthError,
+			reasons: 3,
+		},
+		{
+			name:    "degraded does not mask error",
+			signals: healthSignals{CircuitState: "open", RecentErrors: 1},
+			want:    widgets.HealthError,
+			reasons: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeHealth(tt.signals)
+			if got.Status != tt.want {
+				t.Fatalf("status = %q, want %q (reasons %v)", got.Status, tt.want, got.Reasons)
+			}
+			if len(got.Reasons) != tt.reasons {
+				t.Fatalf("reasons = %v, want %d", got.Reasons, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestTelemetryUIBridge_HealthSignals(t *testing.T) {
+	hub := telemetry.NewHub()
+	defer hub.Close()
+
+	bridge := NewTelemetryUIBridge(hub, nil)
+	now := time.Now()
+
+	bridge.handleEvent(telemetry.Event{Type: telemetry.EventToolFailed, Timestamp: now.Add(-10 * time.Minute)})
+	bridge.handleEvent(telemetry.Event{Type: telemetry.EventTaskFailed, TaskID: "task-1", Timestamp: now})
+	if got := bridge.healthSummary(now); got.Status != widgets.HealthDegraded {
+		t.Fatalf("status = %q, want degraded from one recent error", got.Status)
+	}
+	if len(bridge.recentErrors) != 1 {
+		t.Fatalf("expected expired error to be pruned, got %d", len(bridge.recentErrors))
+	}
+
+	bridge.handleEvent(telemetry.Event{
+		Type: telemetry.EventCircuitStateChange,
+		Data: map[string]any{"new_state": "open"},
+	})
+	if got := bridge.healthSummary(now); got.Status != widgets.HealthError {
+		t.Fatalf("status = %q, want error with open circuit", got.Status)
+	}
+
+	bridge.handleEvent(telemetry.Event{
+		Type: telemetry.EventCircuitStateChange,
+		Data: map[string]any{"new_state": "closed"},
+	})
+	bridge.SetSessionBudget(1)
+	bridge.AddSessionCost(0.9)
+	got := bridge.healthSummary(now.Add(healthErrorWindow))
+	if got.Status != widgets.HealthDegraded || len(got.Reasons) != 1 {
+		t.Fatalf("health = %+v, want degraded by budget only", got)
+	}
+}
@@ -7,13 +7,18 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/model"
+	"m31labs.dev/buckley/pkg/storage"
 	"m31labs.dev/buckley/pkg/tool"
 	"m31labs.dev/buckley/pkg/tool/builtin"
 )
 
+// toolAuditOutputMaxBytes matches the output kept by the headless runner's audit log.
+const toolAuditOutputMaxBytes = 10000
+
 type toolLoopState struct {
 	useTools   bool
 	totalUsage model.Usage
@@ -73,6 +78,9 @@ func (c *Controller) runToolLoopIteration(ctx context.Context, sess *SessionStat
 	if ctx.Err() != nil {
 		return toolLoopIterationResult{}, ctx.Err()
 	}
+	if c.enforceHardStop(sess) {
+		return toolLoopIterationResult{}, context.Canceled
+	}
 
 	allowedTools := toolLoopAllowedTools(sess)
 	req, nextUseTools := c.buildToolLoopRequest(sess, modelID, state.useTools, allowedTools)
@@ -83,6 +91,8 @@ func (c *Controller) runToolLoopIteration(ctx context.Context, sess *SessionStat
 		return toolLoopIterationResult{}, c.handleToolLoopModelError(err, state)
 	}
 	state.totalUsage = model.AddUsage(state.totalUsage, resp.Usage)
+	sess.Conversation.RecordPromptUsage(resp.Usage.PromptTokens)
+	c.recordCost(sess, modelID, resp.Usage)
 
 	choice, err := firstToolLoopChoice(req, resp)
 	if err != nil {
@@ -128,6 +138,9 @@ func (c *Controller) buildToolLoopRequest(sess *SessionState, modelID string, us
 		Messages:  c.buildMessagesForSession(sess),
 		SessionID: sess.ID,
 	}
+	if len(sess.StopSequences) > 0 {
+		req.Stop = append([]string(nil), sess.StopSequences...)
+	}
 
 	if useTools && sess.ToolRegistry != nil {
 		tools := sess.ToolRegistry.ToOpenAIFunctionsGoverned(c.evaluator, "interactive", "coding", allowedTools, 0)
@@ -135,8 +148,8 @@ func (c *Controller) buildToolLoopRequest(sess *SessionState, modelID string, us
 			req.Tools = tools
 			req.ToolChoice = "auto"
 			if c.modelMgr != nil && c.modelMgr.SupportsParameter(modelID, "parallel_tool_calls") {
-				sequential := false
-				req.ParallelToolCalls = &sequential
+				parallel := c.maxParallelToolCalls() > 1
+				req.ParallelToolCalls = &parallel
 			}
 		} else {
 			useTools = false
@@ -162,9 +175,23 @@ func (c *Controller) buildToolLoopRequest(sess *SessionState, modelID string, us
 		contextWindow, _ = c.modelMgr.GetContextLength(modelID)
 	}
 	req.Messages = conversation.CompactModelMessagesForRequest(req.Messages, req, contextWindow)
+	c.warnPinnedOverBudget(sess, conversation.PinnedMessageBytes(req.Messages), conversation.RequestMessageBudget(req, contextWindow))
 	return req, useTools
 }
 
+// warnPinnedOverBudget tells the user once when pinned messages alone no
+// longer fit the context budget. They are still sent in full.
+func (c *Controller) warnPinnedOverBudget(sess *SessionState, pinnedBytes, budget int) {
+	over := pinnedBytes > budget
+	c.mu.Lock()
+	warn := over && !sess.PinnedOverBudget
+	sess.PinnedOverBudget = over
+	c.mu.Unlock()
+	if warn {
+		c.app.AddMessage(fmt.Sprintf("Pinned messages (%s) exceed this model's context budget (%s). They are kept in full, so the request may be rejected; use /unpin to free space.", formatBytes(pinnedBytes), formatBytes(budget)), "system")
+	}
+}
+
 func (c *Controller) callToolLoopModel(ctx context.Context, req model.ChatRequest, modelID string, iteration int, state *toolLoopState) (*model.ChatResponse, error) {
 	status := modelProcessStatus(modelID, iteration, len(req.Tools), req.Reasoning)
 	if estimate := model.EstimateRequestTokens(req); estimate.Total >= 1000 {
@@ -294,6 +321,9 @@ func (c *Controller) finishToolLoopResponse(sess *SessionState, msg model.Messag
 		text = msg.Reasoning
 	}
 	sess.Conversation.AddAssistantMessageWithReasoningDetails(text, msg.Reasoning, msg.ReasoningDetails)
+	if isTokenLimitFinishReason(finishReason) {
+		sess.Conversation.Messages[len(sess.Conversation.Messages)-1].IsTruncated = true
+	}
 	c.saveLatestConversationMessage(sess)
 	return text, &totalUsage, finishReason, nil
 }
@@ -343,12 +373,75 @@ func (c *Controller) recordToolLoopCalls(sess *SessionState, calls []model.ToolC
 	c.saveLatestConversationMessage(sess)
 }
 
+// executeToolLoopCalls runs a turn's tool calls in order. Consecutive
+// read-only calls run together, up to tool_middleware.max_parallel at a time;
+// their results are still added to the conversation in call order.
 func (c *Controller) executeToolLoopCalls(ctx context.Context, sess *SessionState, calls []model.ToolCall, allowedTools []string, state *toolLoopState) {
-	for i, tc := range calls {
+	limit := c.maxParallelToolCalls()
+	for start := 0; start < len(calls); {
 		if ctx.Err() != nil {
 			return
 		}
-		c.executeToolLoopCall(ctx, sess, tc, i+1, len(calls), allowedTools, state)
+		end := start + 1
+		if limit > 1 && c.toolLoopCallParallelSafe(sess, calls[start], allowedTools) {
+			for end < len(calls) && c.toolLoopCallParallelSafe(sess, calls[end], allowedTools) {
+				end++
+			}
+		}
+		if end-start == 1 {
+			c.executeToolLoopCall(ctx, sess, calls[start], start+1, len(calls), allowedTools, state)
+		} else {
+			c.executeToolLoopCallBatch(ctx, sess, calls[start:end], start, len(calls), limit, state)
+		}
+		start = end
+	}
+}
+
+func (c *Controller) maxParallelToolCalls() int {
+	if c == nil || c.cfg == nil {
+		return 1
+	}
+	return max(c.cfg.ToolMiddleware.MaxParallel, 1)
+}
+
+// toolLoopCallParallelSafe reports whether a call can share a batch: it must
+// be allowed, have valid arguments, and name a read-only tool that does not
+// wait on the approval dialog.
+func (c *Controller) toolLoopCallParallelSafe(sess *SessionState, tc model.ToolCall, allowedTools []string) bool {
+	if sess == nil || sess.ToolRegistry == nil || !tool.IsToolAllowed(tc.Function.Name, allowedTools) {
+		return false
+	}
+	if sess.ToolRegistry.RequiresApproval(tc.Function.Name) {
+		return false
+	}
+	if _, err := parseToolParams(tc.Function.Arguments); err != nil {
+		return false
+	}
+	return sess.ToolRegistry.CanRunConcurrently(tc.Function.Name)
+}
+
+// executeToolLoopCallBatch runs parallel-safe calls concurrently, then reports
+// each result in call order.
+func (c *Controller) executeToolLoopCallBatch(ctx context.Context, sess *SessionState, calls []model.ToolCall, offset, total, limit int, state *toolLoopState) {
+	batch := make([]tool.BatchCall, len(calls))
+	for i, tc := range calls {
+		params, _ := parseToolParams(tc.Function.Arguments)
+		if params == nil {
+			params = make(map[string]any)
+		}
+		if tc.ID != "" {
+			params[tool.ToolCallIDParam] = tc.ID
+		}
+		batch[i] = tool.BatchCall{Name: tc.Function.Name, Params: params}
+	}
+
+	c.app.StartProcessStatus(fmt.Sprintf("Running %d tools in parallel (%d-%d/%d) · Ctrl+C to interrupt", len(calls), offset+1, offset+len(calls), total))
+	results := sess.ToolRegistry.ExecuteBatch(ctx, batch, limit)
+	c.app.StopProcessStatus()
+
+	for i, tc := range calls {
+		c.appendToolCallProgress(state, tc)
+		c.recordToolLoopResult(sess, tc, results[i], state)
 	}
 }
 
@@ -388,17 +481,48 @@ func (c *Controller) executeToolLoopCall(ctx context.Context, sess *SessionState
 		})
 	}
 	c.app.StartProcessStatus(fmt.Sprintf("Running %s (%d/%d) · Ctrl+C to interrupt", compactStatusText(tc.Function.Name, 36), index, total))
+	started := time.Now()
 	result, execErr := sess.ToolRegistry.ExecuteWithContext(toolCtx, tc.Function.Name, params)
+	duration := time.Since(started)
 	c.app.StopProcessStatus()
 	if streamingShellOutput {
 		c.app.AppendToLastMessage("\n```")
 	}
-	c.appendToolResultProgress(state, tc.Function.Name, result, execErr)
-	modelResult := formatToolResultForModel(result, execErr)
+	c.recordToolLoopResult(sess, tc, tool.BatchResult{Result: result, Err: execErr, Started: started, Duration: duration}, state)
+}
+
+// recordToolLoopResult shows an executed call's outcome, audits it, and adds
+// the tool response to the conversation.
+func (c *Controller) recordToolLoopResult(sess *SessionState, tc model.ToolCall, res tool.BatchResult, state *toolLoopState) {
+	c.appendToolResultProgress(state, tc.Function.Name, res.Result, res.Err)
+	modelResult := formatStoredToolResultForModel(res.Result, res.Err, tc.ID, sess.ToolOutputs)
+	c.auditToolLoopCall(sess, tc, res.Started, res.Duration, res.Err == nil && res.Result != nil && res.Result.Success, modelResult)
 	modelResult += stagnationNudge(state, tc, modelResult)
 	c.addToolLoopResponse(sess, tc, modelResult)
 }
 
+// auditToolLoopCall records an executed tool call in the session's tool audit
+// log. Failures are reported but never interrupt the turn. Nothing is recorded
+// for ephemeral stores.
+func (c *Controller) auditToolLoopCall(sess *SessionState, tc model.ToolCall, started time.Time, duration time.Duration, success bool, output string) {
+	if c == nil || c.store.Ephemeral() || sess == nil {
+		return
+	}
+	err := c.store.LogToolExecution(&storage.ToolAuditEntry{
+		SessionID:  sess.ID,
+		ToolName:   tc.Function.Name,
+		ToolInput:  tc.Function.Arguments,
+		ToolOutput: takePrefixBytes(output, toolAuditOutputMaxBytes),
+		Decision:   "auto",
+		ExecutedAt: started,
+		DurationMs: duration.Milliseconds(),
+		Success:    success,
+	})
+	if err != nil {
+		c.app.AddMessage("Error recording tool call audit: "+err.Error(), "system")
+	}
+}
+
 func stagnationNudge(state *toolLoopState, call model.ToolCall, result string) string {
 	if state == nil {
 		return ""
@@ -563,7 +687,7 @@ func modelFinishReasonNotice(reason string) string {
 	case "", "stop", "tool_calls":
 		return ""
 	case "length", "max_tokens", "max_output_tokens", "token_limit":
-		return "Response stopped because the provider reported finish_reason=" + trimmed + ", which usually means the output token limit was reached. Ask Buckley to continue, reduce context, or raise the chat max_tokens setting."
+		return "Response stopped because the provider reported finish_reason=" + trimmed + ", which usually means the output token limit was reached. Run /continue to resume where it stopped, reduce context, or raise the chat max_tokens setting."
 	case "content_filter", "safety":
 		return "Response stopped because the provider reported finish_reason=" + trimmed + "."
 	default:
@@ -676,6 +800,13 @@ func parseToolParams(raw string) (map[string]any, error) {
 }
 
 func formatToolResultForModel(result *builtin.Result, execErr error) string {
+	return formatStoredToolResultForModel(result, execErr, "", nil)
+}
+
+// formatStoredToolResultForModel keeps the full encoded result in outputs
+// when it has to be truncated, and points the model at read_tool_output
+// with callID so the omitted part can be fetched instead of lost.
+func formatStoredToolResultForModel(result *builtin.Result, execErr error, callID string, outputs *builtin.ToolOutputStore) string {
 	if execErr != nil {
 		return fmt.Sprintf("Error: %v", execErr)
 	}
@@ -686,7 +817,11 @@ func formatToolResultForModel(result *builtin.Result, execErr error) string {
 	if err != nil {
 		return fmt.Sprintf("{\"success\":%t}", result.Success)
 	}
-	return truncateModelToolOutput(encoded, defaultTUIToolModelMaxBytes)
+	if outputs == nil || callID == "" || len(encoded) <= defaultTUIToolModelMaxBytes {
+		return truncateModelToolOutput(encoded, defaultTUIToolModelMaxBytes)
+	}
+	outputs.Put(callID, encoded)
+	return truncateModelToolOutputWithHandle(encoded, defaultTUIToolModelMaxBytes, callID)
 }
 
 func truncateModelToolOutput(content string, maxBytes int) string {
@@ -703,6 +838,27 @@ func truncateModelToolOutput(content string, maxBytes int) string {
 	return takePrefixBytes(content, headBytes) + marker + takeSuffixBytes(content, tailBytes)
 }
 
+// truncateModelToolOutputWithHandle truncates like truncateModelToolOutput,
+// but the marker names the tool call and the byte offset where the omitted
+// part starts, for a follow-up read_tool_output call.
+func truncateModelToolOutputWithHandle(content string, maxBytes int, callID string) string {
+	if maxBytes <= 0 || len(content) <= maxBytes {
+		return content
+	}
+	marker := func(offset, omitted int) string {
+		return fmt.Sprintf("\n\n... tool output truncated for chat context (tool call %q, %d bytes omitted). The full output is stored: call read_tool_output with this tool_call_id and offset %d to read the omitted part. ...\n\n", callID, omitted, offset)
+	}
+	// Lay out head and tail with the widest numbers so the final marker fits.
+	widest := marker(len(content), len(content))
+	if len(widest) >= maxBytes {
+		return truncateModelToolOutput(content, maxBytes)
+	}
+	available := maxBytes - len(widest)
+	head := takePrefixBytes(content, available*2/3)
+	tail := takeSuffixBytes(content, available-available*2/3)
+	return head + marker(len(head), len(content)-len(head)-len(tail)) + tail
+}
+
 func toolDisplayMessage(name string, result *builtin.Result, execErr error) string {
 	if execErr != nil {
 		return fmt.Sprintf("Error running %s: %v", name, execErr)
@@ -1,10 +1,14 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"m31labs.dev/buckley/pkg/config"
 	"m31labs.dev/buckley/pkg/conversation"
 	"m31labs.dev/buckley/pkg/model"
 	"m31labs.dev/buckley/pkg/tool"
@@ -146,3 +150,101 @@ func TestToolProgressSummariesIncludeIntentAndFailureReason(t *testing.T) {
 		t.Fatalf("tool result summary omitted failure reason: %q", got)
 	}
 }
+
+func TestBuildToolLoopRequestIncludesSessionStopSequences(t *testing.T) {
+	ctrl := &Controller{}
+	conv := conversation.New("session-1")
+	conv.AddUserMessage("hello")
+	sess := &SessionState{
+		ID:            "session-1",
+		Conversation:  conv,
+		StopSequences: []string{"END", "\n\n"},
+	}
+
+	req, _ := ctrl.buildToolLoopRequest(sess, "openai/gpt-4o", false, nil)
+	if len(req.Stop) != 2 || req.Stop[0] != "END" || req.Stop[1] != "\n\n" {
+		t.Fatalf("stop = %#v, want session stop sequences", req.Stop)
+	}
+
+	sess.StopSequences = nil
+	req, _ = ctrl.buildToolLoopRequest(sess, "openai/gpt-4o", false, nil)
+	if req.Stop != nil {
+		t.Fatalf("stop = %#v, want omitted after clear", req.Stop)
+	}
+}
+
+type parallelLoopTool struct {
+	name  string
+	mu    *sync.Mutex
+	order *[]string
+	live  *int
+	peak  *int
+}
+
+func (t *parallelLoopTool) Name() string        { return t.name }
+func (t *parallelLoopTool) Description() string { return t.name }
+func (t *parallelLoopTool) Parameters() builtin.ParameterSchema {
+	return builtin.ParameterSchema{Type: "object"}
+}
+
+func (t *parallelLoopTool) Execute(params map[string]any) (*builtin.Result, error) {
+	id, _ := params["id"].(string)
+	delay, _ := params["delay_ms"].(float64)
+	t.mu.Lock()
+	*t.live++
+	*t.peak = max(*t.peak, *t.live)
+	t.mu.Unlock()
+	time.Sleep(time.Duration(delay) * time.Millisecond)
+	t.mu.Lock()
+	*t.live--
+	*t.order = append(*t.order, id)
+	t.mu.Unlock()
+	return &builtin.Result{Success: true, Data: map[string]any{"id": id}}, nil
+}
+
+func TestExecuteToolLoopCallsRunsReadsConcurrentlyInOrder(t *testing.T) {
+	app, err := NewWidgetApp(WidgetAppConfig{Backend: sim.New(80, 24)})
+	if err != nil {
+		t.Fatalf("NewWidgetApp: %v", err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.ToolMiddleware.MaxParallel = 2
+	ctrl := &Controller{app: app, cfg: cfg}
+
+	var mu sync.Mutex
+	var order []string
+	var live, peak int
+	registry := tool.NewEmptyRegistry()
+	for _, name := range []string{"read_file", "write_file"} {
+		registry.Register(&parallelLoopTool{name: name, mu: &mu, order: &order, live: &live, peak: &peak})
+	}
+	sess := &SessionState{ID: "s", Conversation: conversation.New("s"), ToolRegistry: registry}
+
+	var calls []model.ToolCall
+	for i, name := range []string{"read_file", "read_file", "read_file", "write_file", "read_file"} {
+		// Earlier calls sleep longer, so completion order differs from call order.
+		calls = append(calls, model.ToolCall{
+			ID:       fmt.Sprintf("call-%d", i),
+			Function: model.FunctionCall{Name: name, Arguments: fmt.Sprintf(`{"id":"call-%d","delay_ms":%d}`, i, (5-i)*10)},
+		})
+	}
+	state := &toolLoopState{executions: make(map[[32]byte]int)}
+
+	ctrl.executeToolLoopCalls(context.Background(), sess, calls, nil, state)
+
+	if peak != 2 {
+		t.Fatalf("peak concurrency = %d, want the cap of 2", peak)
+	}
+	if len(order) != 5 || order[3] != "call-3" {
+		t.Fatalf("execution order = %v, want the write to run alone after the first three reads", order)
+	}
+	var responses []string
+	for _, msg := range sess.Conversation.Messages {
+		if msg.Role == "tool" {
+			responses = append(responses, msg.ToolCallID)
+		}
+	}
+	if strings.Join(responses, ",") != "call-0,call-1,call-2,call-3,call-4" {
+		t.Fatalf("tool responses = %v, want call order", responses)
+	}
+}
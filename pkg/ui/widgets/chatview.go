@@ -34,6 +34,7 @@ type ChatView struct {
 	// Markdown rendering
 	mdRenderer  *markdown.Renderer
 	codeBlockBG backend.Style
+	rawMarkdown bool // Show markdown source instead of rendered output
 	lastSource  string
 	lastContent string
 
@@ -80,6 +81,17 @@ func (c *ChatView) SetMarkdownRenderer(renderer *markdown.Renderer, codeBlockBG
 	c.codeBlockBG = codeBlockBG
 }
 
+// SetRawMarkdown toggles between rendered markdown and raw markdown source.
+// It applies to messages added after the call.
+func (c *ChatView) SetRawMarkdown(raw bool) {
+	c.rawMarkdown = raw
+}
+
+// RawMarkdown reports whether messages are displayed as raw markdown source.
+func (c *ChatView) RawMarkdown() bool {
+	return c.rawMarkdown
+}
+
 // OnScrollChange sets a callback for scroll position changes.
 func (c *ChatView) OnScrollChange(fn func(top, total, viewHeight int)) {
 	c.onScrollChange = fn
@@ -131,7 +143,7 @@ func (c *ChatView) buildMessageLines(content, source string) []scrollback.Line {
 }
 
 func (c *ChatView) messageBodyLines(content, source string) []scrollback.Line {
-	if c.mdRenderer != nil {
+	if c.mdRenderer != nil && !c.rawMarkdown {
 		return c.renderMarkdownLines(content, source)
 	}
 	return c.renderPlainLines(content, source)
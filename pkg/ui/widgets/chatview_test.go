@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/mattn/go-runewidth"
+	"m31labs.dev/buckley/pkg/ui/scrollback"
 	"m31labs.dev/fluffyui/backend"
 	"m31labs.dev/fluffyui/markdown"
 	"m31labs.dev/fluffyui/runtime"
@@ -56,6 +57,40 @@ func TestChatView_FluffyMarkdownRenderingMatrix(t *testing.T) {
 	}
 }
 
+func TestChatView_RawMarkdownSkipsRenderer(t *testing.T) {
+	cv := NewChatView()
+	cv.SetMarkdownRenderer(markdown.NewRenderer(theme.DefaultTheme()), backend.DefaultStyle())
+	cv.Layout(runtime.Rect{X: 0, Y: 0, Width: 80, Height: 40})
+
+	lineText := func(lines []scrollback.Line) string {
+		var b strings.Builder
+		for _, line := range lines {
+			b.WriteString(line.Content)
+			b.WriteByte('\n')
+		}
+		return b.String()
+	}
+
+	source := "# Title\n\n**bold** text"
+	if got := lineText(cv.messageBodyLines(source, "assistant")); strings.Contains(got, "**bold**") {
+		t.Fatalf("rendered output kept markdown syntax:\n%s", got)
+	}
+
+	cv.SetRawMarkdown(true)
+	if !cv.RawMarkdown() {
+		t.Fatal("expected raw markdown to be enabled")
+	}
+	got := lineText(cv.messageBodyLines(source, "assistant"))
+	if got != "# Title\n\n**bold** text\n" {
+		t.Fatalf("raw output = %q, want markdown source verbatim", got)
+	}
+
+	cv.SetRawMarkdown(false)
+	if got := lineText(cv.messageBodyLines(source, "assistant")); strings.Contains(got, "**bold**") {
+		t.Fatalf("re-enabled rendering kept markdown syntax:\n%s", got)
+	}
+}
+
 func TestChatView_WideTableStaysInsideTranscriptAndPreservesCells(t *testing.T) {
 	cv := NewChatView()
 	cv.SetMarkdownRenderer(markdown.NewRenderer(theme.DefaultTheme()), backend.DefaultStyle())
@@ -75,10 +75,26 @@ type RLMScratchpadEntry struct {
 	Summary string
 }
 
+// HealthStatus is the overall session health shown at the top of the sidebar.
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded"
+	HealthError    HealthStatus = "error"
+)
+
+// HealthSummary is a computed health status with the reasons behind it.
+type HealthSummary struct {
+	Status  HealthStatus
+	Reasons []string
+}
+
 type sidebarSection int
 
 const (
-	sidebarSectionCurrentTask sidebarSection = iota
+	sidebarSectionHealth sidebarSection = iota
+	sidebarSectionCurrentTask
 	sidebarSectionPlan
 	sidebarSectionTools
 	sidebarSectionRLM
@@ -93,6 +109,7 @@ type sidebarSectionCandidate struct {
 }
 
 var sidebarSectionCandidates = []sidebarSectionCandidate{
+	{section: sidebarSectionHealth, visible: hasHealthSection},
 	{section: sidebarSectionCurrentTask, visible: hasCurrentTaskSection},
 	{section: sidebarSectionPlan, visible: hasPlanSection},
 	{section: sidebarSectionTools, visible: hasToolsSection},
@@ -138,6 +155,9 @@ type Sidebar struct {
 	rlmScratchpad []RLMScratchpadEntry
 	showRLM       bool
 
+	// Health section
+	health HealthSummary
+
 	// Scroll state (for long lists)
 	planScrollOffset int
 	focusedSection   int // 0=task, 1=plan, 2=tools, 3=touches, 4=files
@@ -190,6 +210,16 @@ func (s *Sidebar) HasContent() bool {
 	return len(s.visibleSections()) > 0
 }
 
+// SetHealth updates the health summary.
+func (s *Sidebar) SetHealth(summary HealthSummary) {
+	s.health = summary
+}
+
+// Health returns the current health summary.
+func (s *Sidebar) Health() HealthSummary {
+	return s.health
+}
+
 // SetCurrentTask updates the current task display.
 func (s *Sidebar) SetCurrentTask(name string, progress int) {
 	s.currentTask = name
@@ -332,6 +362,20 @@ func (s *Sidebar) visibleSections() []sidebarSection {
 	return sections
 }
 
+// hasHealthSection shows problems on their own, but only shows a healthy
+// status alongside other content so it never opens an otherwise empty sidebar.
+func hasHealthSection(s *Sidebar) bool {
+	switch s.health.Status {
+	case HealthDegraded, HealthError:
+		return true
+	case HealthOK:
+		return hasCurrentTaskSection(s) || hasPlanSection(s) || hasToolsSection(s) ||
+			hasRLMSection(s) || hasExperimentSection(s) || hasTouchesSection(s) ||
+			hasRecentFilesSection(s)
+	}
+	return false
+}
+
 func hasCurrentTaskSection(s *Sidebar) bool {
 	return s.showCurrentTask && strings.TrimSpace(s.currentTask) != ""
 }
@@ -362,6 +406,8 @@ func hasRecentFilesSection(s *Sidebar) bool {
 
 func (s *Sidebar) renderSection(section sidebarSection, buf *runtime.Buffer, x, y, width, bottom int) int {
 	switch section {
+	case sidebarSectionHealth:
+		return s.renderHealth(buf, x, y, width)
 	case sidebarSectionCurrentTask:
 		return s.renderCurrentTask(buf, x, y, width)
 	case sidebarSectionPlan:
@@ -387,6 +433,32 @@ func (s *Sidebar) renderLeftBorder(buf *runtime.Buffer, b runtime.Rect) {
 	}
 }
 
+// renderHealth draws the health status line and the reasons behind it.
+func (s *Sidebar) renderHealth(buf *runtime.Buffer, x, y, width int) int {
+	style := s.completedStyle
+	label := "OK"
+	switch s.health.Status {
+	case HealthDegraded:
+		style = s.activeStyle
+		label = "Degraded"
+	case HealthError:
+		style = s.failedStyle
+		label = "Error"
+	}
+	buf.Set(x, y, '●', style)
+	buf.SetString(x+2, y, "Health: ", s.headerStyle)
+	buf.SetString(x+10, y, truncateSidebarText(label, width-10), style.Bold(true))
+	y++
+
+	for _, reason := range s.health.Reasons {
+		buf.SetString(x+2, y, truncateSidebarLine(reason, width-2), s.textStyle)
+		y++
+	}
+	y++
+
+	return y
+}
+
 // renderCurrentTask draws the current task section.
 func (s *Sidebar) renderCurrentTask(buf *runtime.Buffer, x, y, width int) int {
 	// Header
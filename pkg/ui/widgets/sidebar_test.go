@@ -271,6 +271,35 @@ func TestSidebar_Render(t *testing.T) {
 	}
 }
 
+func TestSidebar_HealthSection(t *testing.T) {
+	s := NewSidebar()
+	s.SetHealth(HealthSummary{Status: HealthOK})
+	if s.HasContent() {
+		t.Fatal("healthy status alone should not open the sidebar")
+	}
+
+	s.SetHealth(HealthSummary{Status: HealthDegraded, Reasons: []string{"1 recent error"}})
+	if !s.HasContent() {
+		t.Fatal("degraded status should open the sidebar")
+	}
+
+	s.Layout(runtime.Rect{X: 0, Y: 0, Width: 30, Height: 10})
+	buf := runtime.NewBuffer(30, 10)
+	s.Render(runtime.RenderContext{Buffer: buf})
+	if got := readBufferRunes(buf, 3, 0, 16); got != "Health: Degraded" {
+		t.Fatalf("health line = %q", got)
+	}
+	if got := readBufferRunes(buf, 3, 1, 14); got != "1 recent error" {
+		t.Fatalf("health reason = %q", got)
+	}
+
+	s.SetHealth(HealthSummary{Status: HealthOK})
+	s.SetCurrentTask("Implement feature", 10)
+	if sections := s.visibleSections(); len(sections) != 2 || sections[0] != sidebarSectionHealth {
+		t.Fatalf("visible sections = %v, want health first alongside task", sections)
+	}
+}
+
 func TestSidebar_RenderProgressBar_PercentFits(t *testing.T) {
 	s := NewSidebar()
 	buf := runtime.NewBuffer(30, 1)
@@ -145,6 +145,47 @@ func (wm *Manager) Create(branchName string) (*Worktree, error) {
 	}, nil
 }
 
+// CreateDetached checks out ref in a new worktree with a detached HEAD, so
+// no branch is created. Remove it with Remove(name, false).
+func (wm *Manager) CreateDetached(name, ref string) (*Worktree, error) {
+	wtPath := wm.getWorktreePath(name)
+
+	absRoot, _ := filepath.Abs(wm.worktreeRoot)
+	absWtPath, _ := filepath.Abs(wtPath)
+	if !strings.HasPrefix(absWtPath, absRoot+string(filepath.Separator)) {
+		return nil, fmt.Errorf("invalid worktree name: path escapes worktree root")
+	}
+
+	if _, err := os.Stat(wtPath); err == nil {
+		return nil, fmt.Errorf("worktree path already exists: %s", wtPath)
+	}
+
+	// Resolve the ref first so a typo reports cleanly instead of as a
+	// worktree failure
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("unknown git ref %q", ref)
+	}
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = wm.repoPath
+	commit, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unknown git ref %q", ref)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(wtPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+
+	cmd = exec.Command("git", "worktree", "add", "--detach", wtPath, strings.TrimSpace(string(commit)))
+	cmd.Dir = wm.repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worktree: %w\nOutput: %s", err, string(output))
+	}
+
+	return &Worktree{Path: wtPath}, nil
+}
+
 // CreateWithSpec creates a worktree and provisions containers based on a spec.
 func (wm *Manager) CreateWithSpec(branchName string, spec *ContainerSpec) (*Worktree, error) {
 	wt, err := wm.Create(branchName)
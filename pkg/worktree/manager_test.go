@@ -64,6 +64,43 @@ func TestCreateListRemoveWorktree(t *testing.T) {
 	}
 }
 
+func TestCreateDetachedWorktree(t *testing.T) {
+	repo := initGitRepo(t)
+	runGit(t, repo, "tag", "v1")
+	if err := os.WriteFile(filepath.Join(repo, "CHANGELOG.md"), []byte("v2"), 0o644); err != nil {
+		t.Fatalf("failed to write CHANGELOG: %v", err)
+	}
+	runGit(t, repo, "add", "CHANGELOG.md")
+	runGit(t, repo, "commit", "-m", "second")
+
+	mgr, err := NewManager(repo, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager returned error: %v", err)
+	}
+
+	if _, err := mgr.CreateDetached("old", "no-such-ref"); err == nil || !strings.Contains(err.Error(), "no-such-ref") {
+		t.Fatalf("expected unknown ref error, got %v", err)
+	}
+
+	wt, err := mgr.CreateDetached("old", "v1")
+	if err != nil {
+		t.Fatalf("CreateDetached returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "README.md")); err != nil {
+		t.Fatalf("README.md missing from detached worktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "CHANGELOG.md")); !os.IsNotExist(err) {
+		t.Fatalf("detached worktree is not at v1, CHANGELOG.md err=%v", err)
+	}
+
+	if err := mgr.Remove("old", false); err != nil {
+		t.Fatalf("Remove returned error: %v", err)
+	}
+	if _, err := os.Stat(wt.Path); !os.IsNotExist(err) {
+		t.Fatalf("expected worktree path to be removed, got err=%v", err)
+	}
+}
+
 func TestNewManagerRejectsNonRepo(t *testing.T) {
 	dir := t.TempDir()
 	if _, err := NewManager(dir, ""); err == nil {